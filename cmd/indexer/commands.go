@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/bootstrap"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// errUsage is returned for unknown subcommands and missing or extra arguments
+var errUsage = errors.New("invalid usage")
+
+// usage describes the subcommands of the indexer binary
+const usage = `Usage: indexer [command] [-config <file>]
+
+Commands:
+  serve                        Serve HTTP, and gRPC if configured, with scheduled tasks (default)
+  reindex-all [-force]         Reindex all conferences and exit; -force allows the public index to shrink
+  reindex-conference <slug>    Reindex the conference with the slug and exit
+  reindex-talk <talkId>        Reindex a single talk and exit
+
+Options:
+  -config <file>               Read settings from the YAML file; defaults to CONFIG_FILE
+
+Configuration is read from the environment, overriding the configuration file, as for the server.
+The server reloads its configuration on SIGHUP.
+`
+
+// command is a parsed subcommand with its arguments
+type command struct {
+	name   string
+	target string
+	force  bool
+	config string
+}
+
+// serves reports whether the command runs the servers rather than a single reindex
+func (c command) serves() bool {
+	return c.name == "serve"
+}
+
+// parseCommand parses the arguments following the program name. Without a command the server is run,
+// so existing deployments starting the binary without one keep working.
+func parseCommand(args []string, output io.Writer) (command, error) {
+	if len(args) == 0 {
+		return command{name: "serve"}, nil
+	}
+
+	name, rest := args[0], args[1:]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(output, usage)
+		return command{name: name}, flag.ErrHelp
+	}
+	if strings.HasPrefix(name, "-") {
+		name, rest = "serve", args
+	}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() { fmt.Fprint(output, usage) }
+
+	cmd := command{name: name}
+	flags.StringVar(&cmd.config, "config", "", "read settings from the YAML file")
+	switch cmd.name {
+	case "serve":
+		return cmd, parseArgs(flags, rest, 0)
+	case "reindex-all":
+		flags.BoolVar(&cmd.force, "force", false, "replace the public index even if it shrinks by more than allowed")
+		return cmd, parseArgs(flags, rest, 0)
+	case "reindex-conference", "reindex-talk":
+		if err := parseArgs(flags, rest, 1); err != nil {
+			return cmd, err
+		}
+		cmd.target = flags.Arg(0)
+		return cmd, nil
+	}
+
+	fmt.Fprintf(output, "unknown command %q\n\n%s", cmd.name, usage)
+	return cmd, fmt.Errorf("%w: unknown command %q", errUsage, cmd.name)
+}
+
+// parseArgs parses the flags of a subcommand and checks the number of positional arguments
+func parseArgs(flags *flag.FlagSet, args []string, positional int) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != positional || (positional > 0 && flags.Arg(0) == "") {
+		err := fmt.Errorf("%w: %s takes %d argument(s)", errUsage, flags.Name(), positional)
+		fmt.Fprintf(flags.Output(), "%v\n\n", err)
+		flags.Usage()
+		return err
+	}
+	return nil
+}
+
+// cliActor is the actor one-shot reindexes are attributed to in logs and job records
+var cliActor = domain.Actor{Kind: domain.ActorSystem, Name: "cli"}
+
+// runOnce runs a one-shot reindex command within the call, without starting the servers or the scheduler
+func runOnce(ctx context.Context, application *bootstrap.App, cmd command) error {
+	ctx = domain.WithActor(ctx, cliActor)
+	switch cmd.name {
+	case "reindex-all":
+		if cmd.force {
+			ctx = domain.WithShrinkAllowed(ctx)
+		}
+		return application.Indexer.ReindexAll(ctx)
+	case "reindex-conference":
+		return application.Indexer.ReindexConference(ctx, cmd.target)
+	case "reindex-talk":
+		return application.Indexer.ReindexTalk(ctx, cmd.target)
+	}
+	return fmt.Errorf("%w: unknown command %q", errUsage, cmd.name)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want command
+	}{
+		{"no command serves", nil, command{name: "serve"}},
+		{"serve", []string{"serve"}, command{name: "serve"}},
+		{"reindex all", []string{"reindex-all"}, command{name: "reindex-all"}},
+		{"forced reindex all", []string{"reindex-all", "-force"}, command{name: "reindex-all", force: true}},
+		{"reindex conference", []string{"reindex-conference", "javazone2024"}, command{name: "reindex-conference", target: "javazone2024"}},
+		{"reindex talk", []string{"reindex-talk", "talk-1"}, command{name: "reindex-talk", target: "talk-1"}},
+		{"config file", []string{"reindex-all", "-config", "indexer.yaml", "-force"}, command{name: "reindex-all", force: true, config: "indexer.yaml"}},
+		{"config file without command serves", []string{"--config", "indexer.yaml"}, command{name: "serve", config: "indexer.yaml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := parseCommand(tt.args, io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cmd)
+		})
+	}
+}
+
+func TestParseCommand_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"unknown command", []string{"reindex"}},
+		{"missing slug", []string{"reindex-conference"}},
+		{"empty slug", []string{"reindex-conference", ""}},
+		{"extra argument", []string{"reindex-talk", "talk-1", "talk-2"}},
+		{"unknown flag", []string{"reindex-all", "-dry-run"}},
+		{"arguments to serve", []string{"serve", "now"}},
+		{"missing config file", []string{"serve", "-config"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCommand(tt.args, io.Discard)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("help", func(t *testing.T) {
+		_, err := parseCommand([]string{"help"}, io.Discard)
+		assert.ErrorIs(t, err, flag.ErrHelp)
+	})
+}
@@ -2,128 +2,155 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
-
-	"github.com/javaBin/talks-indexer/internal/adapters/api"
-	"github.com/javaBin/talks-indexer/internal/adapters/auth"
-	"github.com/javaBin/talks-indexer/internal/adapters/elasticsearch"
-	"github.com/javaBin/talks-indexer/internal/adapters/moresleep"
-	"github.com/javaBin/talks-indexer/internal/adapters/web"
-	"github.com/javaBin/talks-indexer/internal/app"
+
+	"github.com/javaBin/talks-indexer/internal/bootstrap"
 	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/logging"
+	"github.com/javaBin/talks-indexer/internal/systemd"
 )
 
 func main() {
+	// Parse the command before the configuration, so help works without any environment
+	cmd, err := parseCommand(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
 	// Load configuration first to determine logging mode
-	cfg := config.MustLoad()
+	cfg := config.MustLoad(cmd.config)
 
 	// Inject config into context for use by adapters and services
 	ctx := config.WithConfig(context.Background(), cfg)
 
-	// Configure logging based on mode
-	var logger *slog.Logger
+	// Configure logging based on mode; log lines are attributed to the actor in their context.
+	// The level follows LOG_LEVEL, also when the configuration is reloaded.
+	level, err := cfg.Log.SlogLevel(cfg.Mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(level)
+
+	// Logs go to standard output unless LOG_FILE is set
+	var output io.Writer = os.Stdout
+	var logFile *logging.File
+	if cfg.Log.File != "" {
+		if logFile, err = logging.OpenFile(cfg.Log.File); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open LOG_FILE: %v\n", err)
+			os.Exit(1)
+		}
+		output = logFile
+	}
+
+	var handler slog.Handler
 	if cfg.Mode.IsDevelopment() {
-		logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		}))
+		handler = slog.NewTextHandler(output, &slog.HandlerOptions{
+			Level: logLevel,
+		})
 	} else {
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-		}))
+		handler = slog.NewJSONHandler(output, &slog.HandlerOptions{
+			Level: logLevel,
+		})
 	}
+	// The most recent records are kept for the diagnostics bundle
+	recorder := logging.NewRecorder(handler, cfg.Diagnostics.LogLines)
+	logger := slog.New(logging.NewActorHandler(recorder))
 	slog.SetDefault(logger)
 
 	logger.Info("configuration loaded",
+		"command", cmd.name,
 		"mode", cfg.Mode,
 		"httpAddr", cfg.Http.Addr(),
 		"moresleepURL", cfg.Moresleep.URL,
+		"searchBackend", cfg.Search.Backend,
 		"elasticsearchURL", cfg.Elasticsearch.URL,
-		"privateIndex", cfg.Index.Private,
-		"publicIndex", cfg.Index.Public,
+		"privateIndex", cfg.Index.PrivateName(),
+		"publicIndex", cfg.Index.PublicName(),
 	)
 
-	// Initialize moresleep client
-	moresleepClient, err := moresleep.New(ctx)
-	if err != nil {
-		logger.Error("failed to create moresleep client", "error", err)
+	// One-shot reindexes write to the cluster, so they are refused in read-only mode
+	if !cmd.serves() && cfg.ReadOnly {
+		logger.Error("read-only mode: reindexes are disabled", "command", cmd.name)
 		os.Exit(1)
 	}
-	logger.Info("moresleep client initialized")
 
-	// Initialize elasticsearch client
-	esClient, err := elasticsearch.New(ctx)
+	application, err := bootstrap.Build(ctx, cfg,
+		bootstrap.WithLogHistory(recorder),
+		bootstrap.WithLogLevel(logLevel),
+		bootstrap.WithConfigLoader(func() (*config.Config, error) { return config.LoadFile(cmd.config) }),
+	)
 	if err != nil {
-		logger.Error("failed to create elasticsearch client", "error", err)
+		logger.Error("failed to initialize", "error", err)
 		os.Exit(1)
 	}
-	logger.Info("elasticsearch client initialized")
-
-	// Create indexer service
-	indexerService := app.NewIndexerService(
-		ctx,
-		moresleepClient,
-		esClient,
-		elasticsearch.TalkPrivateIndexMapping,
-		elasticsearch.TalkPublicIndexMapping,
-	)
-	logger.Info("indexer service initialized")
-
-	// Create HTTP server
-	mux := http.NewServeMux()
 
-	// Register API routes (mode-aware)
-	apiAdapter := api.New(ctx, indexerService)
-	apiAdapter.RegisterRoutes(mux)
+	// Run until interrupted or the reindex is done, then shut down gracefully
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	if cmd.serves() {
+		stopReloads := reloadOnHangup(ctx, application, logger)
+		stopReopens := reopenLogsOnSignal(logFile, logger)
+		err = application.Run(ctx)
+		stopReopens()
+		stopReloads()
+	} else {
+		err = runOnce(ctx, application, cmd)
+	}
+	stop()
 
-	// Initialize auth adapter and register routes
-	authAdapter, err := auth.New(ctx)
+	if closeErr := application.Close(); closeErr != nil {
+		logger.Error("failed to close index store", "error", closeErr)
+	}
 	if err != nil {
-		logger.Error("failed to initialize auth", "error", err)
+		if cmd.serves() {
+			logger.Error("server error", "error", err)
+		} else {
+			logger.Error("reindex failed", "command", cmd.name, "target", cmd.target, "error", err)
+		}
 		os.Exit(1)
 	}
-	authAdapter.RegisterRoutes(mux)
-
-	// Register web admin routes (protected if auth middleware is available)
-	webAdapter := web.New(indexerService, moresleepClient)
-	webAdapter.RegisterRoutes(mux, web.MiddlewareFunc(authAdapter.Middleware()))
-
-	server := &http.Server{
-		Addr:         cfg.Http.Addr(),
-		Handler:      mux,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 60 * time.Second, // Longer for reindex operations
-		IdleTimeout:  60 * time.Second,
+	if !cmd.serves() {
+		logger.Info("reindex completed", "command", cmd.name, "target", cmd.target)
 	}
+}
+
+// reloadOnHangup reloads the configuration of the application whenever the process receives SIGHUP,
+// until the returned function is called
+func reloadOnHangup(ctx context.Context, application *bootstrap.App, logger *slog.Logger) func() {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
 
-	// Start server in goroutine
 	go func() {
-		logger.Info("starting HTTP server", "addr", server.Addr)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error("server error", "error", err)
-			os.Exit(1)
+		for range hangups {
+			logger.Info("reloading configuration on SIGHUP")
+			notify(logger, systemd.Reloading)
+			if _, err := application.ReloadConfig(ctx); err != nil {
+				logger.Error("failed to reload configuration, keeping the running configuration", "error", err)
+			}
+			notify(logger, systemd.Ready)
 		}
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	logger.Info("shutting down server...")
-
-	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Error("server shutdown error", "error", err)
-		os.Exit(1)
+	return func() {
+		signal.Stop(hangups)
+		close(hangups)
 	}
+}
 
-	logger.Info("server stopped")
+// notify sends the state to systemd if the server runs as a Type=notify service
+func notify(logger *slog.Logger, state string) {
+	if _, err := systemd.Notify(state); err != nil {
+		logger.Warn("failed to notify systemd", "state", state, "error", err)
+	}
 }
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package main
+
+import (
+	"log/slog"
+
+	"github.com/javaBin/talks-indexer/internal/logging"
+)
+
+// reopenLogsOnSignal does nothing where there is no SIGUSR1; the log file is reopened on restart
+func reopenLogsOnSignal(*logging.File, *slog.Logger) func() {
+	return func() {}
+}
@@ -0,0 +1,39 @@
+//go:build unix
+
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/javaBin/talks-indexer/internal/logging"
+)
+
+// reopenLogsOnSignal reopens the log file whenever the process receives SIGUSR1, as logrotate sends
+// after moving it away, until the returned function is called. Without a log file the signal is
+// ignored rather than ending the process.
+func reopenLogsOnSignal(file *logging.File, logger *slog.Logger) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	go func() {
+		for range signals {
+			if file == nil {
+				logger.Info("ignoring SIGUSR1, logs go to standard output")
+				continue
+			}
+			if err := file.Reopen(); err != nil {
+				logger.Error("failed to reopen log file, logging to the previous file", "error", err)
+				continue
+			}
+			logger.Info("reopened log file on SIGUSR1")
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(signals)
+	}
+}
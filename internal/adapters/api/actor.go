@@ -0,0 +1,120 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// hashedTokenPrefix marks API tokens configured as the hex SHA-256 digest of the token
+const hashedTokenPrefix = "sha256:"
+
+// anonymousAPIActor attributes API requests made without an API token. Tokens are not required in
+// development mode while none are configured.
+var anonymousAPIActor = domain.Actor{Kind: domain.ActorAPIKey, Name: "anonymous"}
+
+// apiToken is a configured API token, kept as the digest of the token
+type apiToken struct {
+	name   string
+	digest []byte
+}
+
+// parseAPITokens reads the configured name=token pairs, ordered by name. Tokens with the sha256:
+// prefix are taken as digests; invalid digests are logged and ignored.
+func parseAPITokens(tokens map[string]string) []apiToken {
+	var parsed []apiToken
+	for name, token := range tokens {
+		if hexDigest, ok := strings.CutPrefix(token, hashedTokenPrefix); ok {
+			digest, err := hex.DecodeString(hexDigest)
+			if err != nil || len(digest) != sha256.Size {
+				slog.Warn("ignoring API token with invalid sha256 digest", "name", name)
+				continue
+			}
+			parsed = append(parsed, apiToken{name: name, digest: digest})
+			continue
+		}
+		if token == "" {
+			slog.Warn("ignoring empty API token", "name", name)
+			continue
+		}
+		digest := sha256.Sum256([]byte(token))
+		parsed = append(parsed, apiToken{name: name, digest: digest[:]})
+	}
+
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i].name < parsed[j].name })
+	return parsed
+}
+
+// SetTokenAuthenticator accepts the API tokens admins create in the admin UI next to those from
+// API_TOKENS. In production mode, the reindex and job routes are then enabled and require a token.
+func (a *Adapter) SetTokenAuthenticator(tokens ports.TokenAuthenticator) {
+	a.storedTokens = tokens
+}
+
+// tokenCaller returns the name of the caller whose API token is sent as a bearer token, comparing
+// against every configured token in constant time before looking for a token created in the admin UI
+func (a *Adapter) tokenCaller(r *http.Request) (string, bool, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", false, nil
+	}
+
+	digest := sha256.Sum256([]byte(token))
+	var caller string
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(digest[:], t.digest) == 1 {
+			caller = t.name
+		}
+	}
+	if caller != "" || a.storedTokens == nil {
+		return caller, caller != "", nil
+	}
+
+	stored, ok, err := a.storedTokens.Authenticate(r.Context(), token)
+	if err != nil || !ok {
+		return "", false, err
+	}
+	return stored.ActorName(), true, nil
+}
+
+// requiresToken reports whether API requests must carry a valid API token: whenever tokens are
+// configured, and in production mode when tokens can be created in the admin UI
+func (a *Adapter) requiresToken() bool {
+	return len(a.tokens) > 0 || (a.storedTokens != nil && !a.cfg.Mode.IsDevelopment())
+}
+
+// withAPIActor attributes the request to the calling API client. Any actor set further out is
+// replaced, so clients cannot act on behalf of someone else. When API tokens are required, requests
+// without a valid bearer token are rejected with 401.
+func (a *Adapter) withAPIActor(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.requiresToken() {
+			next(w, r.WithContext(domain.WithActor(r.Context(), anonymousAPIActor)))
+			return
+		}
+
+		caller, ok, err := a.tokenCaller(r)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to check API token", "error", err)
+			http.Error(w, "API tokens cannot be checked right now", http.StatusServiceUnavailable)
+			return
+		}
+		if !ok {
+			slog.WarnContext(r.Context(), "rejected API request with invalid token", "method", r.Method, "path", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="talks-indexer"`)
+			http.Error(w, "Authentication required: send a valid API token as a bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		setUsageClient(r.Context(), caller)
+		actor := domain.Actor{Kind: domain.ActorAPIKey, Name: caller}
+		next(w, r.WithContext(domain.WithActor(r.Context(), actor)))
+	}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockTokenAuthenticator is a mock implementation of ports.TokenAuthenticator
+type mockTokenAuthenticator struct {
+	tokens map[string]domain.APIToken
+	err    error
+}
+
+func (m *mockTokenAuthenticator) Authenticate(ctx context.Context, token string) (domain.APIToken, bool, error) {
+	stored, ok := m.tokens[token]
+	return stored, ok, m.err
+}
+
+func TestWithAPIActor(t *testing.T) {
+	adapter := &Adapter{}
+
+	var actor domain.Actor
+	handler := adapter.withAPIActor(func(w http.ResponseWriter, r *http.Request) {
+		actor = domain.ActorFromContext(r.Context())
+	})
+
+	// An actor attached further out must not survive into the API handler
+	ctx := domain.WithActor(context.Background(), domain.Actor{Kind: domain.ActorUser, Name: "admin@java.no"})
+	req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil).WithContext(ctx)
+	handler(httptest.NewRecorder(), req)
+
+	assert.Equal(t, anonymousAPIActor, actor)
+}
+
+func TestParseAPITokens(t *testing.T) {
+	tokens := parseAPITokens(map[string]string{
+		"deploy": "sha256:2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		"ci":     "s3cret",
+		"short":  "sha256:abcd",
+		"empty":  "",
+	})
+
+	if assert.Len(t, tokens, 2) {
+		assert.Equal(t, "ci", tokens[0].name)
+		assert.Equal(t, "deploy", tokens[1].name)
+	}
+}
+
+func TestWithAPIActor_Tokens(t *testing.T) {
+	adapter := &Adapter{tokens: parseAPITokens(map[string]string{
+		"ci":     "s3cret",
+		"deploy": "sha256:2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", // sha256 of "secret"
+	})}
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		caller        string
+	}{
+		{name: "plain token", authorization: "Bearer s3cret", status: http.StatusOK, caller: "ci"},
+		{name: "hashed token", authorization: "Bearer secret", status: http.StatusOK, caller: "deploy"},
+		{name: "wrong token", authorization: "Bearer guess", status: http.StatusUnauthorized},
+		{name: "not a bearer token", authorization: "Basic s3cret", status: http.StatusUnauthorized},
+		{name: "missing token", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor domain.Actor
+			handler := adapter.withAPIActor(func(w http.ResponseWriter, r *http.Request) {
+				actor = domain.ActorFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, domain.Actor{Kind: domain.ActorAPIKey, Name: tt.caller}, actor)
+			} else {
+				assert.Equal(t, domain.Actor{}, actor)
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestWithAPIActor_StoredTokens(t *testing.T) {
+	stored := &mockTokenAuthenticator{tokens: map[string]domain.APIToken{
+		"created-in-ui": {ID: "1", Label: "deploy", Owner: "admin@example.com"},
+	}}
+
+	tests := []struct {
+		name          string
+		mode          config.Mode
+		configured    map[string]string
+		authorization string
+		err           error
+		status        int
+		caller        string
+	}{
+		{name: "stored token", mode: config.ModeProduction, authorization: "Bearer created-in-ui", status: http.StatusOK, caller: "deploy (admin@example.com)"},
+		{name: "configured token first", mode: config.ModeProduction, configured: map[string]string{"ci": "s3cret"}, authorization: "Bearer s3cret", status: http.StatusOK, caller: "ci"},
+		{name: "unknown token", mode: config.ModeProduction, authorization: "Bearer guess", status: http.StatusUnauthorized},
+		{name: "token store failing", mode: config.ModeProduction, authorization: "Bearer created-in-ui", err: errors.New("cluster down"), status: http.StatusServiceUnavailable},
+		{name: "development mode without configured tokens", mode: config.ModeDevelopment, status: http.StatusOK, caller: "anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored.err = tt.err
+			adapter := &Adapter{
+				cfg:    &config.Config{ApplicationConfig: config.ApplicationConfig{Mode: tt.mode}},
+				tokens: parseAPITokens(tt.configured),
+			}
+			adapter.SetTokenAuthenticator(stored)
+
+			var actor domain.Actor
+			handler := adapter.withAPIActor(func(w http.ResponseWriter, r *http.Request) {
+				actor = domain.ActorFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, domain.Actor{Kind: domain.ActorAPIKey, Name: tt.caller}, actor)
+			}
+		})
+	}
+}
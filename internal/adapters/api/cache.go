@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// checkNotModified sets cache validators for a response derived from the given index and
+// reports whether the request can be answered with 304 Not Modified.
+// The ETag combines the index generation and document version, and Last-Modified is the
+// last successful reindex time. If the index version cannot be determined, no validators are set.
+func (a *Adapter) checkNotModified(w http.ResponseWriter, r *http.Request, indexName string) bool {
+	ctx := r.Context()
+
+	version, err := a.reader.IndexVersion(ctx, indexName)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to get index version, skipping cache validators", "index", indexName, "error", err)
+		return false
+	}
+
+	etag := fmt.Sprintf(`"%s-%d"`, version.Generation, version.Version)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(a.cfg.Http.PublicCacheMaxAge.Seconds())))
+
+	lastModified := a.indexer.LastReindex(indexName).Truncate(time.Second)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	// If-None-Match takes precedence over If-Modified-Since (RFC 9110, section 13.2.2)
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatches(inm, etag)
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.After(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeNotModified writes a 304 Not Modified response
+func writeNotModified(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// etagMatches reports whether the If-None-Match header value matches the ETag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
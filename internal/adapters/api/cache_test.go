@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// cacheTestAdapter returns an adapter serving the "public" index at the given version
+func cacheTestAdapter(lastReindex time.Time, versionErr error) *Adapter {
+	cfg := &config.Config{
+		Http:  config.HttpConfig{PublicCacheMaxAge: 5 * time.Minute},
+		Index: config.IndexConfig{Public: "public"},
+	}
+	reader := &mockTalkReader{
+		indexVersionFunc: func(ctx context.Context, indexName string) (domain.IndexVersion, error) {
+			if versionErr != nil {
+				return domain.IndexVersion{}, versionErr
+			}
+			return domain.IndexVersion{Index: indexName, Generation: "abc", Version: 42}, nil
+		},
+	}
+	return New(config.WithConfig(context.Background(), cfg), &mockIndexer{lastReindex: lastReindex}, reader)
+}
+
+func TestCheckNotModified_SetsValidators(t *testing.T) {
+	lastReindex := time.Date(2024, 9, 4, 9, 0, 0, 0, time.UTC)
+	adapter := cacheTestAdapter(lastReindex, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+	w := httptest.NewRecorder()
+
+	assert.False(t, adapter.checkNotModified(w, req, "public"))
+	assert.Equal(t, `"abc-42"`, w.Header().Get("ETag"))
+	assert.Equal(t, "Wed, 04 Sep 2024 09:00:00 GMT", w.Header().Get("Last-Modified"))
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+}
+
+func TestCheckNotModified_Conditions(t *testing.T) {
+	lastReindex := time.Date(2024, 9, 4, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected bool
+	}{
+		{name: "matching etag", headers: map[string]string{"If-None-Match": `"abc-42"`}, expected: true},
+		{name: "weak matching etag in list", headers: map[string]string{"If-None-Match": `"old-1", W/"abc-42"`}, expected: true},
+		{name: "wildcard", headers: map[string]string{"If-None-Match": "*"}, expected: true},
+		{name: "stale etag", headers: map[string]string{"If-None-Match": `"abc-41"`}, expected: false},
+		{
+			name: "etag takes precedence over modified since",
+			headers: map[string]string{
+				"If-None-Match":     `"abc-41"`,
+				"If-Modified-Since": "Thu, 05 Sep 2024 09:00:00 GMT",
+			},
+			expected: false,
+		},
+		{name: "not modified since", headers: map[string]string{"If-Modified-Since": "Wed, 04 Sep 2024 09:00:00 GMT"}, expected: true},
+		{name: "modified since", headers: map[string]string{"If-Modified-Since": "Tue, 03 Sep 2024 09:00:00 GMT"}, expected: false},
+		{name: "invalid date", headers: map[string]string{"If-Modified-Since": "yesterday"}, expected: false},
+		{name: "no conditions", headers: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := cacheTestAdapter(lastReindex, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			assert.Equal(t, tt.expected, adapter.checkNotModified(httptest.NewRecorder(), req, "public"))
+		})
+	}
+}
+
+func TestCheckNotModified_UnknownReindexTime(t *testing.T) {
+	adapter := cacheTestAdapter(time.Time{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+	req.Header.Set("If-Modified-Since", "Wed, 04 Sep 2024 09:00:00 GMT")
+	w := httptest.NewRecorder()
+
+	assert.False(t, adapter.checkNotModified(w, req, "public"))
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+}
+
+func TestCheckNotModified_VersionError(t *testing.T) {
+	adapter := cacheTestAdapter(time.Now(), errors.New("stats unavailable"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+	req.Header.Set("If-None-Match", "*")
+	w := httptest.NewRecorder()
+
+	assert.False(t, adapter.checkNotModified(w, req, "public"))
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
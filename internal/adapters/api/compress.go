@@ -0,0 +1,273 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content codings negotiated with Accept-Encoding, in order of preference
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// brotliLevel trades some compression for speed; higher levels gain little on JSON and HTML but cost
+// several times the CPU per response
+const brotliLevel = 4
+
+// encoder is a pooled compressor of a content coding
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var encoderPools = map[string]*sync.Pool{
+	encodingBrotli: {New: func() any { return brotli.NewWriterLevel(io.Discard, brotliLevel) }},
+	encodingGzip:   {New: func() any { return gzip.NewWriter(io.Discard) }},
+}
+
+// CompressResponses wraps the server handler so that responses are compressed with brotli or gzip,
+// as negotiated with the Accept-Encoding header, unless disabled with HTTP_COMPRESSION. Only textual
+// content such as JSON, NDJSON, CSV and HTML is compressed, and only bodies of at least
+// HTTP_COMPRESSION_MIN_BYTES; already encoded responses, partial content and event streams pass through.
+func (a *Adapter) CompressResponses(next http.Handler) http.Handler {
+	if !a.cfg.Http.Compression {
+		return next
+	}
+	return compress(next, a.cfg.Http.CompressionMinBytes)
+}
+
+// compress compresses the responses of the handler with the content coding the client prefers
+func compress(next http.Handler, minBytes int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Caches must keep compressed and uncompressed responses apart
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: w, encoding: encoding, minBytes: minBytes}
+		next.ServeHTTP(writer, r)
+		if err := writer.close(); err != nil {
+			slog.WarnContext(r.Context(), "failed to finish compressed response", "path", r.URL.Path, "error", err)
+		}
+	})
+}
+
+// negotiateEncoding returns the supported content coding with the highest quality in the Accept-Encoding
+// header, preferring brotli on ties, or an empty string if the client accepts none of them
+func negotiateEncoding(header string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[name] = quality
+	}
+
+	best, bestQuality := "", 0.0
+	for _, encoding := range []string{encodingBrotli, encodingGzip} {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+		if ok && quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+	return best
+}
+
+// compressibleType returns true for the textual media types worth compressing
+func compressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if mediaType == "text/event-stream" {
+		// Events must reach the browser as they are written
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/x-ndjson", "application/javascript", "application/xml":
+		return true
+	}
+	return false
+}
+
+// compressWriter holds back the start of the response until it knows whether to compress it: when
+// the headers rule it out, or once minBytes of the body or a flush arrive
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minBytes int
+
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         []byte
+
+	// encoder compresses the body, nil when the response is sent uncompressed
+	encoder encoder
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		// Informational responses such as 103 Early Hints precede the final response
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.wroteHeader = true
+	w.status = status
+	if !w.compressibleHeaders() {
+		// Nothing is buffered yet, so only the headers are written
+		_ = w.decide(false)
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minBytes {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends what is written so far, compressed if the response is compressible
+func (w *compressWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		if err := w.decide(true); err != nil {
+			return
+		}
+	}
+	if w.encoder != nil {
+		if err := w.encoder.Flush(); err != nil {
+			return
+		}
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressibleHeaders returns false if the status or headers rule out compressing the response. The
+// content type is only checked when set, as the body reveals it otherwise.
+func (w *compressWriter) compressibleHeaders() bool {
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusNotModified || w.status == http.StatusPartialContent {
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	if contentType := h.Get("Content-Type"); contentType != "" && !compressibleType(contentType) {
+		return false
+	}
+	return true
+}
+
+// decide writes the headers, compressing the body if wanted and the response allows it, and sends the
+// buffered start of the body
+func (w *compressWriter) decide(compressed bool) error {
+	w.decided = true
+	h := w.Header()
+	if h.Get("Content-Type") == "" && len(w.buf) > 0 {
+		// Sniff as net/http would have on the first write
+		h.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+
+	// Without a content type nothing was written that tells what the body is
+	if compressed && h.Get("Content-Type") != "" && w.compressibleHeaders() {
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", w.encoding)
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			// The compressed body differs byte for byte, so the validator is only weakly equal
+			h.Set("ETag", "W/"+etag)
+		}
+		w.encoder = encoderPools[w.encoding].Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// close sends a body smaller than minBytes uncompressed and finishes the compressed stream
+func (w *compressWriter) close() error {
+	if !w.wroteHeader {
+		// Nothing was written, so net/http sends an empty 200 response
+		return nil
+	}
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.encoder == nil {
+		return nil
+	}
+
+	err := w.encoder.Close()
+	w.encoder.Reset(io.Discard)
+	encoderPools[w.encoding].Put(w.encoder)
+	w.encoder = nil
+	return err
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", encodingGzip},
+		{"gzip, deflate, br", encodingBrotli},
+		{"br;q=0.5, gzip", encodingGzip},
+		{"br;q=0, gzip;q=0", ""},
+		{"*", encodingBrotli},
+		{"*;q=0.1, br;q=0", encodingGzip},
+		{"GZIP;q=0.8", encodingGzip},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, negotiateEncoding(tt.header), tt.header)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat(`{"title":"Compressing JSON over venue wifi"}`, 100)
+
+	handler := compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", "4400")
+			w.Header().Set("ETag", `"v1"`)
+			io.WriteString(w, body)
+		case "/small":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{}`)
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			io.WriteString(w, body)
+		case "/encoded":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "gzip")
+			io.WriteString(w, body)
+		case "/sniffed":
+			io.WriteString(w, "<!DOCTYPE html><html>"+body)
+		case "/not-modified":
+			w.WriteHeader(http.StatusNotModified)
+		}
+	}), 1024)
+
+	get := func(path, acceptEncoding string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	t.Run("brotli", func(t *testing.T) {
+		res := get("/json", "gzip, br")
+		assert.Equal(t, encodingBrotli, res.Header.Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", res.Header.Get("Vary"))
+		assert.Empty(t, res.Header.Get("Content-Length"))
+		assert.Equal(t, `W/"v1"`, res.Header.Get("ETag"))
+
+		decoded, err := io.ReadAll(brotli.NewReader(res.Body))
+		require.NoError(t, err)
+		assert.Equal(t, body, string(decoded))
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		res := get("/sniffed", "gzip")
+		assert.Equal(t, encodingGzip, res.Header.Get("Content-Encoding"))
+		assert.Equal(t, "text/html; charset=utf-8", res.Header.Get("Content-Type"))
+
+		reader, err := gzip.NewReader(res.Body)
+		require.NoError(t, err)
+		decoded, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "<!DOCTYPE html><html>"+body, string(decoded))
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		res := get("/json", "")
+		assert.Empty(t, res.Header.Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", res.Header.Get("Vary"))
+		assert.Equal(t, `"v1"`, res.Header.Get("ETag"))
+	})
+
+	t.Run("passes through", func(t *testing.T) {
+		for _, path := range []string{"/small", "/image", "/encoded"} {
+			res := get(path, "br")
+			decoded, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			if path == "/small" {
+				assert.Equal(t, "{}", string(decoded))
+			} else {
+				assert.Equal(t, body, string(decoded), path)
+			}
+			if path != "/encoded" {
+				assert.Empty(t, res.Header.Get("Content-Encoding"), path)
+			}
+		}
+
+		res := get("/not-modified", "br")
+		assert.Equal(t, http.StatusNotModified, res.StatusCode)
+		assert.Empty(t, res.Header.Get("Content-Encoding"))
+	})
+}
+
+func TestCompress_Flush(t *testing.T) {
+	handler := compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		io.WriteString(w, "{\"id\":1}\n")
+		require.NoError(t, http.NewResponseController(w).Flush())
+		io.WriteString(w, "{\"id\":2}\n")
+	}), 1024)
+
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, encodingGzip, rec.Header().Get("Content-Encoding"), "flushed streams are compressed regardless of size")
+	reader, err := gzip.NewReader(bytes.NewReader(rec.Body.Bytes()))
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n", string(decoded))
+}
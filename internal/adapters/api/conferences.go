@@ -0,0 +1,39 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Default and maximum number of conferences on a page of the conference list
+const (
+	defaultConferencePageSize = 100
+	maxConferencePageSize     = 500
+)
+
+// HandleListConferences lists the conferences present in the public index with their talk counts.
+// The optional size and cursor parameters page through the conferences.
+func (a *Adapter) HandleListConferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, err := parsePage(r, defaultConferencePageSize, maxConferencePageSize)
+	if err != nil {
+		writeListError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if a.checkNotModified(w, r, a.cfg.Index.PublicName()) {
+		writeNotModified(w)
+		return
+	}
+
+	conferences, err := a.reader.ListConferences(ctx, a.cfg.Index.PublicName())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list public conferences", "error", err)
+		writeListError(w, r, http.StatusInternalServerError, "failed to list conferences")
+		return
+	}
+
+	conferences, meta := paginate(conferences, page)
+	writeList(w, r, conferences, meta)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleListConferences(t *testing.T) {
+	var capturedIndex string
+	reader := &mockTalkReader{
+		listConferencesFunc: func(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+			capturedIndex = indexName
+			return []domain.ConferenceSummary{
+				{
+					ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024", TalkCount: 120,
+					ConferenceMetadata: domain.ConferenceMetadata{Venue: "Nova Spektrum", StartDate: "2024-09-04", EndDate: "2024-09-05"},
+				},
+			}, nil
+		},
+	}
+
+	cfg := &config.Config{Index: config.IndexConfig{Public: "public", Prefix: "staging_"}}
+	adapter := New(config.WithConfig(context.Background(), cfg), &mockIndexer{}, reader)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+	w := httptest.NewRecorder()
+
+	adapter.HandleListConferences(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"gen-1"`, w.Header().Get("ETag"))
+	assert.Equal(t, "staging_public", capturedIndex)
+
+	conferences, meta := decodeList[domain.ConferenceSummary](t, w)
+	require.Len(t, conferences, 1)
+	assert.Equal(t, "javazone2024", conferences[0].Slug)
+	assert.Equal(t, 120, conferences[0].TalkCount)
+	assert.Equal(t, "Nova Spektrum", conferences[0].Venue)
+	assert.Equal(t, "2024-09-04", conferences[0].StartDate)
+	assert.Equal(t, ListMeta{Page: 1, Size: defaultConferencePageSize, Total: intPtr(1)}, meta)
+}
+
+func TestHandleListConferences_NotModified(t *testing.T) {
+	called := false
+	reader := &mockTalkReader{
+		listConferencesFunc: func(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+			called = true
+			return nil, nil
+		},
+	}
+	adapter := New(testContext(), &mockIndexer{}, reader)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+	req.Header.Set("If-None-Match", `"gen-1"`)
+	w := httptest.NewRecorder()
+
+	adapter.HandleListConferences(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.False(t, called, "conferences should not be read for a 304 response")
+}
+
+func TestHandleListConferences_Error(t *testing.T) {
+	reader := &mockTalkReader{
+		listConferencesFunc: func(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+			return nil, errors.New("index unavailable")
+		},
+	}
+	adapter := New(testContext(), &mockIndexer{}, reader)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+	w := httptest.NewRecorder()
+
+	adapter.HandleListConferences(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetDatasetVersions enables the dataset version endpoint
+func (a *Adapter) SetDatasetVersions(datasets ports.DatasetVersions) {
+	a.datasets = datasets
+}
+
+// HandleDatasetVersion returns the version of the public documents of a conference, which increases
+// whenever they change, so clients such as the mobile apps only resync their program when needed
+func (a *Adapter) HandleDatasetVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	slug := r.PathValue("slug")
+
+	if a.checkNotModified(w, r, a.cfg.Index.PublicName()) {
+		writeNotModified(w)
+		return
+	}
+
+	version, err := a.datasets.DatasetVersion(ctx, slug)
+	switch {
+	case errors.Is(err, domain.ErrConferenceNotFound):
+		http.Error(w, "conference not found", http.StatusNotFound)
+		return
+	case errors.Is(err, domain.ErrDatasetRebuilding):
+		writeRebuilding(w, err)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to get dataset version", "slug", slug, "error", err)
+		http.Error(w, "failed to get dataset version", http.StatusInternalServerError)
+		return
+	}
+
+	a.writeSignedJSON(w, r, version)
+}
+
+// HandleDatasetChanges returns the public documents of a conference added, updated and removed since the
+// dataset version in the since parameter, so clients such as the mobile apps can sync incrementally.
+// Without since, every document is returned as added. If the changes since that version are not known,
+// 410 Gone tells the client to resync the whole conference.
+func (a *Adapter) HandleDatasetChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	slug := r.PathValue("slug")
+
+	var since int64
+	if param := r.URL.Query().Get("since"); param != "" {
+		parsed, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || parsed < 0 {
+			http.Error(w, "since must be a dataset version", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	if a.checkNotModified(w, r, a.cfg.Index.PublicName()) {
+		writeNotModified(w)
+		return
+	}
+
+	changes, err := a.datasets.DatasetChanges(ctx, slug, since)
+	switch {
+	case errors.Is(err, domain.ErrConferenceNotFound):
+		http.Error(w, "conference not found", http.StatusNotFound)
+		return
+	case errors.Is(err, domain.ErrDatasetVersionUnavailable):
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	case errors.Is(err, domain.ErrDatasetRebuilding):
+		writeRebuilding(w, err)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to get dataset changes", "slug", slug, "since", since, "error", err)
+		http.Error(w, "failed to get dataset changes", http.StatusInternalServerError)
+		return
+	}
+
+	a.writeSignedJSON(w, r, changes)
+}
+
+// writeRebuilding tells the client to retry once the full reindex rebuilding the public index is done
+func writeRebuilding(w http.ResponseWriter, err error) {
+	w.Header().Set("Retry-After", "60")
+	http.Error(w, err.Error(), http.StatusServiceUnavailable)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDatasetVersions is a mock implementation of the DatasetVersions interface for testing
+type mockDatasetVersions struct {
+	versionFunc func(ctx context.Context, conferenceSlug string) (domain.DatasetVersion, error)
+	changesFunc func(ctx context.Context, conferenceSlug string, since int64) (domain.DatasetChanges, error)
+}
+
+func (m *mockDatasetVersions) DatasetVersion(ctx context.Context, conferenceSlug string) (domain.DatasetVersion, error) {
+	return m.versionFunc(ctx, conferenceSlug)
+}
+
+func (m *mockDatasetVersions) DatasetChanges(ctx context.Context, conferenceSlug string, since int64) (domain.DatasetChanges, error) {
+	return m.changesFunc(ctx, conferenceSlug, since)
+}
+
+func TestHandleDatasetVersion(t *testing.T) {
+	changedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetDatasetVersions(&mockDatasetVersions{
+		versionFunc: func(ctx context.Context, conferenceSlug string) (domain.DatasetVersion, error) {
+			return domain.DatasetVersion{ConferenceSlug: conferenceSlug, Version: 3, Hash: "abc", Talks: 120, ChangedAt: changedAt}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences/javazone2025/dataset-version", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+
+	var version domain.DatasetVersion
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&version))
+	assert.Equal(t, domain.DatasetVersion{ConferenceSlug: "javazone2025", Version: 3, Hash: "abc", Talks: 120, ChangedAt: changedAt}, version)
+}
+
+func TestHandleDatasetVersion_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unknown conference", fmt.Errorf("%w: nosuchconf", domain.ErrConferenceNotFound), http.StatusNotFound},
+		{"rebuilding", domain.ErrDatasetRebuilding, http.StatusServiceUnavailable},
+		{"index error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetDatasetVersions(&mockDatasetVersions{
+				versionFunc: func(ctx context.Context, conferenceSlug string) (domain.DatasetVersion, error) {
+					return domain.DatasetVersion{}, tt.err
+				},
+			})
+			mux := http.NewServeMux()
+			adapter.RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/conferences/nosuchconf/dataset-version", nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandleDatasetVersion_NotRegisteredWithoutService(t *testing.T) {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences/javazone2025/dataset-version", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleDatasetChanges(t *testing.T) {
+	var captured int64
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetDatasetVersions(&mockDatasetVersions{
+		changesFunc: func(ctx context.Context, conferenceSlug string, since int64) (domain.DatasetChanges, error) {
+			captured = since
+			return domain.DatasetChanges{
+				ConferenceSlug: conferenceSlug,
+				Since:          since,
+				Version:        5,
+				Added:          []domain.Talk{{ID: "talk-3"}},
+				Updated:        []domain.Talk{},
+				Removed:        []string{"talk-2"},
+			}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conferences/javazone2025/changes?since=3", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(3), captured)
+
+	var changes domain.DatasetChanges
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&changes))
+	assert.Equal(t, int64(5), changes.Version)
+	require.Len(t, changes.Added, 1)
+	assert.Equal(t, "talk-3", changes.Added[0].ID)
+	assert.Empty(t, changes.Updated)
+	assert.Equal(t, []string{"talk-2"}, changes.Removed)
+}
+
+func TestHandleDatasetChanges_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+	}{
+		{"invalid since", "?since=latest", nil, http.StatusBadRequest},
+		{"negative since", "?since=-1", nil, http.StatusBadRequest},
+		{"unknown conference", "", fmt.Errorf("%w: nosuchconf", domain.ErrConferenceNotFound), http.StatusNotFound},
+		{"version not available", "?since=1", fmt.Errorf("%w: 1", domain.ErrDatasetVersionUnavailable), http.StatusGone},
+		{"rebuilding", "?since=1", domain.ErrDatasetRebuilding, http.StatusServiceUnavailable},
+		{"index error", "?since=1", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetDatasetVersions(&mockDatasetVersions{
+				changesFunc: func(ctx context.Context, conferenceSlug string, since int64) (domain.DatasetChanges, error) {
+					return domain.DatasetChanges{}, tt.err
+				},
+			})
+			mux := http.NewServeMux()
+			adapter.RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/conferences/javazone2025/changes"+tt.query, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
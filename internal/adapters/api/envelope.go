@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// cursorPrefix marks the decoded form of a pagination cursor
+const cursorPrefix = "offset:"
+
+// ListResponse is the envelope of every list endpoint: the items of the page in data, the position in
+// meta, and the problems that made the request fail in errors
+type ListResponse struct {
+	Data   interface{} `json:"data"`
+	Meta   ListMeta    `json:"meta"`
+	Errors []APIError  `json:"errors,omitempty"`
+}
+
+// ListMeta describes the page of a list response. Total is omitted when the total number of items is
+// not known, and NextCursor when there are no more items.
+type ListMeta struct {
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	Total      *int   `json:"total,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// APIError is a problem reported in the errors of a list response
+type APIError struct {
+	Message string `json:"message"`
+}
+
+// pageRequest is the page a list request asks for
+type pageRequest struct {
+	Size   int
+	Offset int
+}
+
+// parsePage reads the size and cursor parameters of a list request. The size defaults to defaultSize
+// and may not exceed maxSize; the cursor is the nextCursor of the previous page.
+func parsePage(r *http.Request, defaultSize, maxSize int) (pageRequest, error) {
+	page := pageRequest{Size: defaultSize}
+	query := r.URL.Query()
+
+	if param := query.Get("size"); param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 1 || n > maxSize {
+			return pageRequest{}, errors.New("size must be between 1 and " + strconv.Itoa(maxSize))
+		}
+		page.Size = n
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		offset, err := decodeCursor(cursor)
+		if err != nil {
+			return pageRequest{}, err
+		}
+		page.Offset = offset
+	}
+	return page, nil
+}
+
+// meta returns the meta of the page, given whether more items follow it
+func (p pageRequest) meta(total *int, more bool) ListMeta {
+	meta := ListMeta{
+		Page:  p.Offset/p.Size + 1,
+		Size:  p.Size,
+		Total: total,
+	}
+	if more {
+		meta.NextCursor = encodeCursor(p.Offset + p.Size)
+	}
+	return meta
+}
+
+// encodeCursor returns the opaque cursor of the page starting at offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset of a cursor created by encodeCursor
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	value, ok := strings.CutPrefix(string(decoded), cursorPrefix)
+	if !ok {
+		return 0, errors.New("invalid cursor")
+	}
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid cursor")
+	}
+	return offset, nil
+}
+
+// paginate returns the items of the page from a list holding every item
+func paginate[T any](items []T, page pageRequest) ([]T, ListMeta) {
+	total := len(items)
+	start := min(page.Offset, total)
+	end := min(start+page.Size, total)
+	return items[start:end], page.meta(&total, end < total)
+}
+
+// writeList writes a list response with status 200
+func writeList(w http.ResponseWriter, r *http.Request, data interface{}, meta ListMeta) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(ListResponse{Data: data, Meta: meta}); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode list response", "path", r.URL.Path, "error", err)
+	}
+}
+
+// writeListError writes a list response without data, reporting the message in its errors
+func writeListError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	response := ListResponse{Data: []interface{}{}, Errors: []APIError{{Message: message}}}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode list error response", "path", r.URL.Path, "error", err)
+	}
+}
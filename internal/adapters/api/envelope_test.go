@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeList decodes a list response, returning its items and meta
+func decodeList[T any](t *testing.T, w *httptest.ResponseRecorder) ([]T, ListMeta) {
+	t.Helper()
+	var response struct {
+		Data   []T        `json:"data"`
+		Meta   ListMeta   `json:"meta"`
+		Errors []APIError `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Empty(t, response.Errors)
+	return response.Data, response.Meta
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestParsePage(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		page, err := parsePage(httptest.NewRequest(http.MethodGet, "/api/jobs", nil), 20, 100)
+		require.NoError(t, err)
+		assert.Equal(t, pageRequest{Size: 20}, page)
+	})
+
+	t.Run("size and cursor", func(t *testing.T) {
+		page, err := parsePage(httptest.NewRequest(http.MethodGet, "/api/jobs?size=10&cursor="+encodeCursor(30), nil), 20, 100)
+		require.NoError(t, err)
+		assert.Equal(t, pageRequest{Size: 10, Offset: 30}, page)
+	})
+
+	for _, query := range []string{"size=0", "size=101", "size=ten", "cursor=abc", "cursor=" + encodeCursor(-1)} {
+		t.Run(query, func(t *testing.T) {
+			_, err := parsePage(httptest.NewRequest(http.MethodGet, "/api/jobs?"+query, nil), 20, 100)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+
+	page, meta := paginate(items, pageRequest{Size: 2})
+	assert.Equal(t, []string{"a", "b"}, page)
+	assert.Equal(t, ListMeta{Page: 1, Size: 2, Total: intPtr(5), NextCursor: encodeCursor(2)}, meta)
+
+	page, meta = paginate(items, pageRequest{Size: 2, Offset: 4})
+	assert.Equal(t, []string{"e"}, page)
+	assert.Equal(t, ListMeta{Page: 3, Size: 2, Total: intPtr(5)}, meta)
+
+	page, _ = paginate(items, pageRequest{Size: 2, Offset: 10})
+	assert.Empty(t, page)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetSearchFacets enables the search facet endpoint
+func (a *Adapter) SetSearchFacets(facets ports.SearchFacets) {
+	a.facets = facets
+}
+
+// HandleSearchFacets returns the number of public talks per year, format, language and keyword, for
+// rendering search filters. The optional conference parameter limits the counts to one conference and
+// keywords bounds the number of keywords returned. Responses are cached like the other public endpoints.
+func (a *Adapter) HandleSearchFacets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	req := domain.FacetRequest{ConferenceSlug: query.Get("conference")}
+	if keywords := query.Get("keywords"); keywords != "" {
+		n, err := strconv.Atoi(keywords)
+		if err != nil || n < 1 {
+			http.Error(w, "keywords must be a positive number", http.StatusBadRequest)
+			return
+		}
+		req.KeywordLimit = n
+	}
+
+	if a.checkNotModified(w, r, a.cfg.Index.PublicName()) {
+		writeNotModified(w)
+		return
+	}
+
+	facets, err := a.facets.SearchFacets(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to count search facets", "error", err)
+		http.Error(w, "failed to count search facets", http.StatusInternalServerError)
+		return
+	}
+
+	a.writeSignedJSON(w, r, facets)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSearchFacets is a mock implementation of the SearchFacets interface for testing
+type mockSearchFacets struct {
+	searchFacetsFunc func(ctx context.Context, req domain.FacetRequest) (*domain.SearchFacets, error)
+}
+
+func (m *mockSearchFacets) SearchFacets(ctx context.Context, req domain.FacetRequest) (*domain.SearchFacets, error) {
+	return m.searchFacetsFunc(ctx, req)
+}
+
+func TestHandleSearchFacets(t *testing.T) {
+	var captured domain.FacetRequest
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetSearchFacets(&mockSearchFacets{
+		searchFacetsFunc: func(ctx context.Context, req domain.FacetRequest) (*domain.SearchFacets, error) {
+			captured = req
+			return &domain.SearchFacets{
+				Total:     3,
+				Years:     []domain.FacetCount{{Value: "2024", Count: 3}},
+				Formats:   []domain.FacetCount{{Value: "presentation", Count: 2}, {Value: "workshop", Count: 1}},
+				Languages: []domain.FacetCount{{Value: "en", Count: 3}},
+				Keywords:  []domain.FacetCount{{Value: "kotlin", Count: 2}},
+			}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/search/facets?conference=javazone2024&keywords=20", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.FacetRequest{ConferenceSlug: "javazone2024", KeywordLimit: 20}, captured)
+	assert.Equal(t, `"gen-1"`, w.Header().Get("ETag"))
+
+	var facets domain.SearchFacets
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&facets))
+	assert.Equal(t, 3, facets.Total)
+	assert.Equal(t, []domain.FacetCount{{Value: "presentation", Count: 2}, {Value: "workshop", Count: 1}}, facets.Formats)
+
+	t.Run("not modified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/search/facets", nil)
+		req.Header.Set("If-None-Match", `"gen-1"`)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+}
+
+func TestHandleSearchFacets_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid keywords", "/api/search/facets?keywords=all", nil, http.StatusBadRequest},
+		{"too many keywords", "/api/search/facets?keywords=1000", fmt.Errorf("%w: keywords must be between 1 and 500", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"index error", "/api/search/facets", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetSearchFacets(&mockSearchFacets{
+				searchFacetsFunc: func(ctx context.Context, req domain.FacetRequest) (*domain.SearchFacets, error) {
+					return nil, tt.err
+				},
+			})
+			mux := http.NewServeMux()
+			adapter.RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRegisterRoutes_SearchFacetsOnlyWhenSet(t *testing.T) {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/search/facets", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"net/http"
+	"net/netip"
 
 	"github.com/javaBin/talks-indexer/internal/config"
 	"github.com/javaBin/talks-indexer/internal/ports"
@@ -9,14 +11,60 @@ import (
 
 // Adapter holds the API adapter dependencies
 type Adapter struct {
-	indexer ports.Indexer
-	cfg     *config.Config
+	indexer      ports.Indexer
+	reader       ports.IndexReader
+	signer       ports.ContentSigner
+	querier      ports.Querier
+	sampler      ports.Sampler
+	keywords     ports.KeywordTrends
+	speakerStats ports.SpeakerStatisticsReporter
+	searcher     ports.Searcher
+	facets       ports.SearchFacets
+	suggester    ports.Suggester
+	lookup       ports.TalkLookup
+	datasets     ports.DatasetVersions
+	previews     ports.TalkPreviews
+	jobs         ports.ReindexJobs
+	notices      ports.Notices
+	metrics      ports.RequestMetrics
+	indexMetrics ports.IndexMetrics
+	usage        ports.UsageRecorder
+	reloader     ports.ConfigReloader
+	cfg          *config.Config
+
+	idempotency *idempotencyStore
+	webhooks    *webhookVerifier
+	tokens      []apiToken
+
+	// storedTokens checks the API tokens admins created in the admin UI, nil if not available
+	storedTokens ports.TokenAuthenticator
+
+	healthChecks     []ports.HealthCheck
+	readiness        readinessCache
+	healthAuthorized func(r *http.Request) bool
+	searchAuthorized func(r *http.Request) bool
+	trustedNetworks  []netip.Prefix
+}
+
+// KeepStateOf carries over the in-memory state of the adapter this one replaces on a configuration
+// reload: the responses kept for Idempotency-Key and the signatures of accepted webhooks, so requests
+// seen before the reload are still replayed, or refused as replays. The settings of this adapter apply.
+func (a *Adapter) KeepStateOf(previous *Adapter) {
+	previous.idempotency.setWindow(a.cfg.Http.IdempotencyWindow)
+	a.idempotency = previous.idempotency
+	a.webhooks.seen = previous.webhooks.seen
 }
 
 // New creates a new API adapter
-func New(ctx context.Context, indexer ports.Indexer) *Adapter {
+func New(ctx context.Context, indexer ports.Indexer, reader ports.IndexReader) *Adapter {
+	cfg := config.GetConfig(ctx)
 	return &Adapter{
-		indexer: indexer,
-		cfg:     config.GetConfig(ctx),
+		indexer:         indexer,
+		reader:          reader,
+		cfg:             cfg,
+		idempotency:     newIdempotencyStore(cfg.Http.IdempotencyWindow),
+		webhooks:        newWebhookVerifier(cfg.Webhook),
+		tokens:          parseAPITokens(cfg.API.Tokens),
+		trustedNetworks: parseTrustedNetworks(cfg.Health.TrustedNetworks),
 	}
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,9 +23,20 @@ func testContext() context.Context {
 
 // mockIndexer is a mock implementation of the Indexer interface for testing
 type mockIndexer struct {
-	reindexAllFunc        func(ctx context.Context) error
-	reindexConferenceFunc func(ctx context.Context, slug string) error
-	reindexTalkFunc       func(ctx context.Context, talkID string) error
+	reindexAllFunc            func(ctx context.Context) error
+	reindexConferenceFunc     func(ctx context.Context, slug string) error
+	reindexConferenceByIDFunc func(ctx context.Context, conferenceID string) error
+	reindexTalkFunc           func(ctx context.Context, talkID string) error
+	deleteTalkFunc            func(ctx context.Context, talkID string) error
+	lastReindex               time.Time
+}
+
+func (m *mockIndexer) LastReindex(indexName string) time.Time {
+	return m.lastReindex
+}
+
+func (m *mockIndexer) IndexNames() domain.IndexNames {
+	return domain.IndexNames{Private: "private", Public: "public"}
 }
 
 func (m *mockIndexer) ReindexAll(ctx context.Context) error {
@@ -40,6 +53,13 @@ func (m *mockIndexer) ReindexConference(ctx context.Context, slug string) error
 	return nil
 }
 
+func (m *mockIndexer) ReindexConferenceByID(ctx context.Context, conferenceID string) error {
+	if m.reindexConferenceByIDFunc != nil {
+		return m.reindexConferenceByIDFunc(ctx, conferenceID)
+	}
+	return nil
+}
+
 func (m *mockIndexer) ReindexTalk(ctx context.Context, talkID string) error {
 	if m.reindexTalkFunc != nil {
 		return m.reindexTalkFunc(ctx, talkID)
@@ -47,10 +67,45 @@ func (m *mockIndexer) ReindexTalk(ctx context.Context, talkID string) error {
 	return nil
 }
 
+func (m *mockIndexer) DeleteTalk(ctx context.Context, talkID string) error {
+	if m.deleteTalkFunc != nil {
+		return m.deleteTalkFunc(ctx, talkID)
+	}
+	return nil
+}
+
+// mockTalkReader is a mock implementation of the IndexReader interface for testing
+type mockTalkReader struct {
+	fetchTalksFunc      func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error)
+	listConferencesFunc func(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error)
+	indexVersionFunc    func(ctx context.Context, indexName string) (domain.IndexVersion, error)
+}
+
+func (m *mockTalkReader) ListConferences(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+	if m.listConferencesFunc != nil {
+		return m.listConferencesFunc(ctx, indexName)
+	}
+	return nil, nil
+}
+
+func (m *mockTalkReader) IndexVersion(ctx context.Context, indexName string) (domain.IndexVersion, error) {
+	if m.indexVersionFunc != nil {
+		return m.indexVersionFunc(ctx, indexName)
+	}
+	return domain.IndexVersion{Index: indexName, Generation: "gen", Version: 1}, nil
+}
+
+func (m *mockTalkReader) FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+	if m.fetchTalksFunc != nil {
+		return m.fetchTalksFunc(ctx, indexName, conferenceSlug)
+	}
+	return nil, nil
+}
+
 func TestNew(t *testing.T) {
 	ctx := testContext()
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	assert.NotNil(t, adapter)
 	assert.Equal(t, indexer, adapter.indexer)
@@ -58,7 +113,7 @@ func TestNew(t *testing.T) {
 
 func TestNew_WithNilIndexer(t *testing.T) {
 	ctx := testContext()
-	adapter := New(ctx, nil)
+	adapter := New(ctx, nil, nil)
 
 	assert.NotNil(t, adapter)
 	assert.Nil(t, adapter.indexer)
@@ -1,26 +1,278 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/buildinfo"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+const (
+	// healthCheckTimeout bounds the time spent probing dependencies for detailed health output
+	healthCheckTimeout = 5 * time.Second
+
+	// readinessTimeout bounds the time spent probing dependencies for readiness, below the probe
+	// timeout recommended for Kubernetes
+	readinessTimeout = 2 * time.Second
+
+	// readinessCacheTTL is how long a readiness result is reused, so the probes of every kubelet and
+	// load balancer do not each reach the dependencies
+	readinessCacheTTL = 5 * time.Second
 )
 
-// HealthResponse represents the health check response
+// recentJobsChecked is how many of the most recent jobs are searched for the last successful reindex
+// of an index not written to since startup
+const recentJobsChecked = 50
+
+// HealthResponse represents the health check response.
+// Build, uptime, dependencies and indexes are only included in detailed output.
 type HealthResponse struct {
-	Status string `json:"status"`
+	Status        string                    `json:"status"`
+	Build         *buildinfo.Info           `json:"build,omitempty"`
+	UptimeSeconds int64                     `json:"uptimeSeconds,omitempty"`
+	Dependencies  []domain.DependencyHealth `json:"dependencies,omitempty"`
+	Indexes       []IndexHealth             `json:"indexes,omitempty"`
+}
+
+// IndexHealth reports the document count of an index and when it was last successfully reindexed
+type IndexHealth struct {
+	Name        string     `json:"name"`
+	DocCount    int64      `json:"docCount"`
+	LastReindex *time.Time `json:"lastReindex,omitempty"`
+	Error       string     `json:"error,omitempty"`
 }
 
-// HandleHealth handles the health check endpoint
+// SetHealthChecks sets the dependencies probed for detailed health output
+func (a *Adapter) SetHealthChecks(checks ...ports.HealthCheck) {
+	a.healthChecks = checks
+}
+
+// AddHealthCheck adds a check to the ones probed for readiness and detailed health output, such as
+// the freshness of the indexes
+func (a *Adapter) AddHealthCheck(check ports.HealthCheck) {
+	a.healthChecks = append(a.healthChecks, check)
+}
+
+// SetHealthAuthorizer sets the function deciding whether a caller is authenticated
+// and may see detailed health output
+func (a *Adapter) SetHealthAuthorizer(authorized func(r *http.Request) bool) {
+	a.healthAuthorized = authorized
+}
+
+// HandleHealth handles the health check endpoint. Everyone gets a minimal "ok";
+// callers requesting ?detail=full from a trusted network or with an authenticated session
+// also get dependency status, latencies and index document counts.
 func (a *Adapter) HandleHealth(w http.ResponseWriter, r *http.Request) {
 	response := HealthResponse{
-		Status: "ok",
+		Status: domain.HealthStatusOK,
+	}
+	status := http.StatusOK
+
+	if r.URL.Query().Get("detail") == "full" && a.mayViewHealthDetail(r) {
+		a.addHealthDetail(r.Context(), &response)
+		if response.Status != domain.HealthStatusOK {
+			status = http.StatusServiceUnavailable
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		slog.Error("failed to encode health response", "error", err)
 	}
 }
+
+// mayViewHealthDetail reports whether the caller is on a trusted network or authenticated
+func (a *Adapter) mayViewHealthDetail(r *http.Request) bool {
+	if len(a.trustedNetworks) > 0 {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if addr, err := netip.ParseAddr(host); err == nil {
+			addr = addr.Unmap()
+			for _, network := range a.trustedNetworks {
+				if network.Contains(addr) {
+					return true
+				}
+			}
+		}
+	}
+
+	return a.healthAuthorized != nil && a.healthAuthorized(r)
+}
+
+// HandleLiveness reports that the process is up and serving, without probing any dependency, so a
+// dependency outage does not get the process restarted
+func (a *Adapter) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(HealthResponse{Status: domain.HealthStatusOK}); err != nil {
+		slog.Error("failed to encode liveness response", "error", err)
+	}
+}
+
+// HandleReadiness probes Elasticsearch and moresleep, and the freshness of the indexes if checked, and
+// reports the status of each, with 503 when any is failing so load balancers stop routing traffic to
+// the instance. Errors and cluster details
+// are only included for the callers that may see detailed health output. Results are reused for a
+// few seconds.
+func (a *Adapter) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	dependencies := a.readiness.probe(r.Context(), a.healthChecks)
+
+	response := HealthResponse{Status: healthStatus(dependencies)}
+	detail := a.mayViewHealthDetail(r)
+	for _, dep := range dependencies {
+		if !detail {
+			dep = domain.DependencyHealth{Name: dep.Name, Status: dep.Status, LatencyMS: dep.LatencyMS}
+		}
+		response.Dependencies = append(response.Dependencies, dep)
+	}
+
+	status := http.StatusOK
+	if response.Status != domain.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to encode readiness response", "error", err)
+	}
+}
+
+// readinessCache keeps the last readiness probe of the dependencies
+type readinessCache struct {
+	mu           sync.Mutex
+	dependencies []domain.DependencyHealth
+	checkedAt    time.Time
+}
+
+// probe returns the health of the dependencies, probing them if the last result is too old. Concurrent
+// callers wait for one probe instead of probing the dependencies each.
+func (c *readinessCache) probe(ctx context.Context, checks []ports.HealthCheck) []domain.DependencyHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dependencies == nil || time.Since(c.checkedAt) >= readinessCacheTTL {
+		c.dependencies = probeDependencies(ctx, checks, readinessTimeout)
+		c.checkedAt = time.Now()
+	}
+	return c.dependencies
+}
+
+// probeDependencies probes all dependencies concurrently within the timeout
+func probeDependencies(ctx context.Context, checks []ports.HealthCheck, timeout time.Duration) []domain.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dependencies := make([]domain.DependencyHealth, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dependencies[i] = check.CheckHealth(ctx)
+		}()
+	}
+	wg.Wait()
+	return dependencies
+}
+
+// healthStatus returns degraded if any dependency is not ok
+func healthStatus(dependencies []domain.DependencyHealth) string {
+	for _, dep := range dependencies {
+		if dep.Status != domain.HealthStatusOK {
+			return domain.HealthStatusDegraded
+		}
+	}
+	return domain.HealthStatusOK
+}
+
+// addHealthDetail adds the running build, probes all dependencies concurrently and reads index
+// document counts and last reindex times
+func (a *Adapter) addHealthDetail(ctx context.Context, response *HealthResponse) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	build := buildinfo.Read()
+	response.Build = &build
+	response.UptimeSeconds = int64(buildinfo.Uptime().Seconds())
+
+	response.Dependencies = probeDependencies(ctx, a.healthChecks, healthCheckTimeout)
+	response.Status = healthStatus(response.Dependencies)
+
+	if a.reader == nil {
+		return
+	}
+	lastReindexes := a.lastReindexes(ctx)
+	for _, name := range []string{a.cfg.Index.PrivateName(), a.cfg.Index.PublicName()} {
+		index := IndexHealth{Name: name}
+		if last, ok := lastReindexes[name]; ok {
+			index.LastReindex = &last
+		}
+		version, err := a.reader.IndexVersion(ctx, name)
+		if err != nil {
+			index.Error = err.Error()
+			response.Status = domain.HealthStatusDegraded
+		} else {
+			index.DocCount = version.DocCount
+		}
+		response.Indexes = append(response.Indexes, index)
+	}
+}
+
+// lastReindexes returns when each index was last successfully written to: since startup as the
+// indexer recorded it, and before that as the most recent succeeded jobs that wrote to it recorded it
+func (a *Adapter) lastReindexes(ctx context.Context) map[string]time.Time {
+	last := make(map[string]time.Time)
+	for _, name := range []string{a.cfg.Index.PrivateName(), a.cfg.Index.PublicName()} {
+		if at := a.indexer.LastReindex(name); !at.IsZero() {
+			last[name] = at
+		}
+	}
+	if a.jobs == nil || len(last) == 2 {
+		return last
+	}
+
+	jobs, err := a.jobs.ListJobs(ctx, recentJobsChecked)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to list jobs for health output", "error", err)
+		return last
+	}
+	for _, job := range jobs {
+		if job.State != domain.JobStateSucceeded || job.FinishedAt == nil {
+			continue
+		}
+		for name := range job.Report.Indexed {
+			if at, ok := last[name]; !ok || job.FinishedAt.After(at) {
+				last[name] = job.FinishedAt.UTC()
+			}
+		}
+	}
+	return last
+}
+
+// parseTrustedNetworks parses CIDR strings, skipping and logging invalid entries
+func parseTrustedNetworks(cidrs []string) []netip.Prefix {
+	var networks []netip.Prefix
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			slog.Warn("ignoring invalid trusted network", "cidr", cidr, "error", err)
+			continue
+		}
+		networks = append(networks, prefix.Masked())
+	}
+	return networks
+}
@@ -1,11 +1,16 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -14,7 +19,7 @@ func TestHandleHealth(t *testing.T) {
 	// Create adapter with mock indexer
 	ctx := testContext()
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	// Create request
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
@@ -38,7 +43,7 @@ func TestHandleHealth(t *testing.T) {
 func TestHandleHealth_ContentType(t *testing.T) {
 	ctx := testContext()
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
 	w := httptest.NewRecorder()
@@ -52,7 +57,7 @@ func TestHandleHealth_ContentType(t *testing.T) {
 func TestHandleHealth_StatusCode(t *testing.T) {
 	ctx := testContext()
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
 	w := httptest.NewRecorder()
@@ -61,3 +66,220 @@ func TestHandleHealth_StatusCode(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 }
+
+// mockHealthCheck is a mock implementation of ports.HealthCheck
+type mockHealthCheck struct {
+	health domain.DependencyHealth
+}
+
+func (m *mockHealthCheck) CheckHealth(ctx context.Context) domain.DependencyHealth {
+	return m.health
+}
+
+// healthDetailAdapter returns an adapter trusting 10.0.0.0/8 with the given health checks
+func healthDetailAdapter(checks ...ports.HealthCheck) *Adapter {
+	cfg := &config.Config{
+		Index:  config.IndexConfig{Private: "private", Public: "public"},
+		Health: config.HealthConfig{TrustedNetworks: []string{"10.0.0.0/8", "not-a-cidr"}},
+	}
+	reader := &mockTalkReader{
+		indexVersionFunc: func(ctx context.Context, indexName string) (domain.IndexVersion, error) {
+			return domain.IndexVersion{Index: indexName, DocCount: 42}, nil
+		},
+	}
+	adapter := New(config.WithConfig(context.Background(), cfg), &mockIndexer{}, reader)
+	adapter.SetHealthChecks(checks...)
+	return adapter
+}
+
+func TestHandleHealth_Detail(t *testing.T) {
+	esCheck := &mockHealthCheck{health: domain.DependencyHealth{
+		Name: "elasticsearch", Status: domain.HealthStatusOK, Details: map[string]interface{}{"clusterName": "javabin"},
+	}}
+
+	t.Run("public caller gets minimal output", func(t *testing.T) {
+		adapter := healthDetailAdapter(esCheck)
+
+		req := httptest.NewRequest(http.MethodGet, "/health?detail=full", nil)
+		req.RemoteAddr = "203.0.113.10:5000"
+		w := httptest.NewRecorder()
+		adapter.HandleHealth(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "javabin")
+		assert.NotContains(t, w.Body.String(), "dependencies")
+	})
+
+	t.Run("trusted network gets detail", func(t *testing.T) {
+		adapter := healthDetailAdapter(esCheck)
+
+		req := httptest.NewRequest(http.MethodGet, "/health?detail=full", nil)
+		req.RemoteAddr = "10.1.2.3:5000"
+		w := httptest.NewRecorder()
+		adapter.HandleHealth(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response HealthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "ok", response.Status)
+		require.Len(t, response.Dependencies, 1)
+		assert.Equal(t, "javabin", response.Dependencies[0].Details["clusterName"])
+		assert.Equal(t, []IndexHealth{{Name: "private", DocCount: 42}, {Name: "public", DocCount: 42}}, response.Indexes)
+		require.NotNil(t, response.Build)
+		assert.NotEmpty(t, response.Build.Version)
+		assert.False(t, response.Build.StartedAt.IsZero())
+	})
+
+	t.Run("last reindex times", func(t *testing.T) {
+		reindexed := time.Date(2025, 9, 3, 4, 0, 0, 0, time.UTC)
+		finished := time.Date(2025, 9, 2, 4, 0, 0, 0, time.UTC)
+		adapter := healthDetailAdapter(esCheck)
+		adapter.indexer = &mockIndexer{lastReindex: reindexed}
+		jobs := &mockReindexJobs{jobs: []domain.Job{
+			{ID: "job-2", State: domain.JobStateFailed, FinishedAt: &reindexed, Report: domain.JobReport{Indexed: map[string]int{"private": 1}}},
+			{ID: "job-1", State: domain.JobStateSucceeded, FinishedAt: &finished, Report: domain.JobReport{Indexed: map[string]int{"private": 3, "public": 2}}},
+		}}
+		adapter.SetReindexJobs(jobs)
+
+		req := httptest.NewRequest(http.MethodGet, "/health?detail=full", nil)
+		req.RemoteAddr = "10.1.2.3:5000"
+		w := httptest.NewRecorder()
+		adapter.HandleHealth(w, req)
+
+		var response HealthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Indexes, 2)
+		assert.Equal(t, reindexed, *response.Indexes[0].LastReindex, "times recorded since startup are used")
+		assert.Equal(t, reindexed, *response.Indexes[1].LastReindex)
+		assert.Zero(t, jobs.limit, "jobs are not listed when the indexer knows both times")
+
+		// After a restart the indexer knows no times, so they are taken from succeeded jobs
+		adapter.indexer = &mockIndexer{}
+		w = httptest.NewRecorder()
+		adapter.HandleHealth(w, req)
+
+		response = HealthResponse{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, finished, *response.Indexes[0].LastReindex)
+		assert.Equal(t, finished, *response.Indexes[1].LastReindex)
+	})
+
+	t.Run("trusted network without detail parameter gets minimal output", func(t *testing.T) {
+		adapter := healthDetailAdapter(esCheck)
+
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.RemoteAddr = "10.1.2.3:5000"
+		w := httptest.NewRecorder()
+		adapter.HandleHealth(w, req)
+
+		assert.NotContains(t, w.Body.String(), "dependencies")
+	})
+
+	t.Run("authenticated caller gets detail", func(t *testing.T) {
+		adapter := healthDetailAdapter(esCheck)
+		adapter.SetHealthAuthorizer(func(r *http.Request) bool { return r.Header.Get("Cookie") == "session=valid" })
+
+		req := httptest.NewRequest(http.MethodGet, "/health?detail=full", nil)
+		req.RemoteAddr = "203.0.113.10:5000"
+		req.Header.Set("Cookie", "session=valid")
+		w := httptest.NewRecorder()
+		adapter.HandleHealth(w, req)
+
+		assert.Contains(t, w.Body.String(), "javabin")
+	})
+
+	t.Run("failing dependency degrades status", func(t *testing.T) {
+		moresleepCheck := &mockHealthCheck{health: domain.DependencyHealth{
+			Name: "moresleep", Status: domain.HealthStatusError, Error: "connection refused",
+		}}
+		adapter := healthDetailAdapter(esCheck, moresleepCheck)
+
+		req := httptest.NewRequest(http.MethodGet, "/health?detail=full", nil)
+		req.RemoteAddr = "10.1.2.3:5000"
+		w := httptest.NewRecorder()
+		adapter.HandleHealth(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		var response HealthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "degraded", response.Status)
+		assert.Len(t, response.Dependencies, 2)
+	})
+}
+
+func TestHandleLiveness(t *testing.T) {
+	failing := &mockHealthCheck{health: domain.DependencyHealth{Name: "elasticsearch", Status: domain.HealthStatusError}}
+	adapter := healthDetailAdapter(failing)
+
+	w := httptest.NewRecorder()
+	adapter.HandleLiveness(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code, "dependencies do not affect liveness")
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestHandleReadiness(t *testing.T) {
+	esCheck := &mockHealthCheck{health: domain.DependencyHealth{
+		Name: "elasticsearch", Status: domain.HealthStatusOK, Details: map[string]interface{}{"clusterName": "javabin"},
+	}}
+	moresleepCheck := &mockHealthCheck{health: domain.DependencyHealth{
+		Name: "moresleep", Status: domain.HealthStatusError, Error: "dial tcp 10.0.0.5:443: connection refused",
+	}}
+
+	ready := func(adapter *Adapter, remoteAddr string) (int, HealthResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		adapter.HandleReadiness(w, req)
+
+		var response HealthResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		return w.Code, response
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		code, response := ready(healthDetailAdapter(esCheck), "203.0.113.10:5000")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, domain.HealthStatusOK, response.Status)
+		assert.Equal(t, []domain.DependencyHealth{{Name: "elasticsearch", Status: domain.HealthStatusOK}}, response.Dependencies, "public callers get no details")
+	})
+
+	t.Run("failing dependency", func(t *testing.T) {
+		adapter := healthDetailAdapter(esCheck, moresleepCheck)
+
+		code, response := ready(adapter, "203.0.113.10:5000")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, domain.HealthStatusDegraded, response.Status)
+		require.Len(t, response.Dependencies, 2)
+		assert.Equal(t, domain.HealthStatusError, response.Dependencies[1].Status)
+		assert.Empty(t, response.Dependencies[1].Error)
+
+		_, response = ready(adapter, "10.1.2.3:5000")
+		assert.Equal(t, "dial tcp 10.0.0.5:443: connection refused", response.Dependencies[1].Error, "trusted networks get errors")
+	})
+
+	t.Run("results are reused", func(t *testing.T) {
+		check := &mockHealthCheck{health: domain.DependencyHealth{Name: "elasticsearch", Status: domain.HealthStatusError}}
+		adapter := healthDetailAdapter(check)
+
+		code, _ := ready(adapter, "10.1.2.3:5000")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+
+		check.health.Status = domain.HealthStatusOK
+		code, _ = ready(adapter, "10.1.2.3:5000")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+
+		adapter.readiness.checkedAt = time.Now().Add(-readinessCacheTTL)
+		code, _ = ready(adapter, "10.1.2.3:5000")
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
+
+func TestParseTrustedNetworks(t *testing.T) {
+	networks := parseTrustedNetworks([]string{"10.0.0.0/8", "invalid", "fd00::/8", "192.168.1.7/24"})
+
+	require.Len(t, networks, 3)
+	assert.Equal(t, "192.168.1.0/24", networks[2].String())
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients safely retry reindex requests
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader marks responses replayed from an earlier request with the same key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength bounds the size of keys kept in memory
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyEntry holds the response of a request while it runs and after it completes
+type idempotencyEntry struct {
+	done    chan struct{}
+	expires time.Time
+	status  int
+	header  http.Header
+	body    []byte
+}
+
+// idempotencyStore remembers responses to requests carrying an Idempotency-Key for a time window
+type idempotencyStore struct {
+	window  time.Duration
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+// newIdempotencyStore creates a store keeping responses for the given window
+func newIdempotencyStore(window time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		window:  window,
+		entries: make(map[string]*idempotencyEntry),
+	}
+}
+
+// setWindow changes how long responses completed from now on are kept
+func (s *idempotencyStore) setWindow(window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.window = window
+}
+
+// begin returns the existing entry for the key, or registers a new one and reports that
+// the caller owns it and must run the request
+func (s *idempotencyStore) begin(key string, now time.Time) (*idempotencyEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, entry := range s.entries {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			delete(s.entries, k)
+		}
+	}
+
+	if entry, ok := s.entries[key]; ok {
+		return entry, false
+	}
+
+	entry := &idempotencyEntry{done: make(chan struct{})}
+	s.entries[key] = entry
+	return entry, true
+}
+
+// complete stores the response of an owned entry. Server errors are not remembered,
+// so a retry after a failed run triggers a new run.
+func (s *idempotencyStore) complete(key string, entry *idempotencyEntry, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry.status >= http.StatusInternalServerError {
+		delete(s.entries, key)
+	} else {
+		entry.expires = now.Add(s.window)
+	}
+	close(entry.done)
+}
+
+// idempotent wraps a handler so that requests repeating an Idempotency-Key within the window
+// receive the original response instead of running the operation again. Duplicates arriving
+// while the original is still running wait for it to finish. Keys are scoped to the calling API
+// client, so one client cannot replay another's response.
+func (a *Adapter) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			http.Error(w, "idempotency key is too long", http.StatusBadRequest)
+			return
+		}
+
+		storeKey := r.Method + " " + r.URL.Path + " " + domain.ActorFromContext(r.Context()).Name + " " + key
+		entry, owner := a.idempotency.begin(storeKey, time.Now())
+
+		if !owner {
+			select {
+			case <-entry.done:
+			case <-r.Context().Done():
+				return
+			}
+
+			slog.InfoContext(r.Context(), "replaying idempotent response", "path", r.URL.Path, "idempotencyKey", key)
+			for name, values := range entry.header {
+				w.Header()[name] = values
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(entry.status)
+			w.Write(entry.body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			entry.status = recorder.status
+			entry.header = recorder.Header().Clone()
+			entry.body = recorder.body.Bytes()
+			a.idempotency.complete(storeKey, entry, time.Now())
+		}()
+
+		next(recorder, r)
+	}
+}
+
+// responseRecorder passes a response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
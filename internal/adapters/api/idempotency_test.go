@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idempotencyTestMux returns a mux with the reindex routes and an indexer counting full reindex runs
+func idempotencyTestMux(window time.Duration, reindexAll func(ctx context.Context) error) *http.ServeMux {
+	cfg := &config.Config{
+		ApplicationConfig: config.ApplicationConfig{Mode: config.ModeDevelopment},
+		Http:              config.HttpConfig{IdempotencyWindow: window},
+	}
+	adapter := New(config.WithConfig(context.Background(), cfg), &mockIndexer{reindexAllFunc: reindexAll}, &mockTalkReader{})
+
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+	return mux
+}
+
+func postReindex(mux *http.ServeMux, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysDuplicate(t *testing.T) {
+	var runs atomic.Int32
+	mux := idempotencyTestMux(time.Minute, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	first := postReindex(mux, "/api/reindex", "key-1")
+	second := postReindex(mux, "/api/reindex", "key-1")
+
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+}
+
+func TestIdempotency_DistinctKeysAndRequests(t *testing.T) {
+	var runs atomic.Int32
+	mux := idempotencyTestMux(time.Minute, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	postReindex(mux, "/api/reindex", "key-1")
+	postReindex(mux, "/api/reindex", "key-2")
+	postReindex(mux, "/api/reindex", "")
+	postReindex(mux, "/api/reindex", "")
+
+	assert.Equal(t, int32(4), runs.Load())
+}
+
+func TestIdempotency_ScopedToCaller(t *testing.T) {
+	var runs atomic.Int32
+	cfg := &config.Config{
+		Http: config.HttpConfig{IdempotencyWindow: time.Minute},
+		API:  config.APIConfig{Tokens: map[string]string{"ci": "ci-token", "deploy": "deploy-token"}},
+	}
+	adapter := New(config.WithConfig(context.Background(), cfg), &mockIndexer{reindexAllFunc: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}}, &mockTalkReader{})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	for _, token := range []string{"ci-token", "deploy-token", "ci-token"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestIdempotency_WindowExpires(t *testing.T) {
+	var runs atomic.Int32
+	mux := idempotencyTestMux(time.Nanosecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	postReindex(mux, "/api/reindex", "key-1")
+	time.Sleep(time.Millisecond)
+	postReindex(mux, "/api/reindex", "key-1")
+
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestIdempotency_FailuresAreNotRemembered(t *testing.T) {
+	var runs atomic.Int32
+	mux := idempotencyTestMux(time.Minute, func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			return errors.New("elasticsearch unavailable")
+		}
+		return nil
+	})
+
+	first := postReindex(mux, "/api/reindex", "key-1")
+	second := postReindex(mux, "/api/reindex", "key-1")
+
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Empty(t, second.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestIdempotency_ConcurrentDuplicatesWaitForOriginal(t *testing.T) {
+	var runs atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+	mux := idempotencyTestMux(time.Minute, func(ctx context.Context) error {
+		runs.Add(1)
+		close(started)
+		<-release
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 3)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		responses[0] = postReindex(mux, "/api/reindex", "key-1")
+	}()
+	<-started
+
+	for i := 1; i < len(responses); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = postReindex(mux, "/api/reindex", "key-1")
+		}(i)
+	}
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), runs.Load())
+	for _, w := range responses {
+		require.NotNil(t, w)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	mux := idempotencyTestMux(time.Minute, nil)
+
+	w := postReindex(mux, "/api/reindex", strings.Repeat("k", maxIdempotencyKeyLength+1))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestKeepStateOf(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	var runs atomic.Int32
+	newAdapter := func() *Adapter {
+		cfg := &config.Config{
+			ApplicationConfig: config.ApplicationConfig{Mode: config.ModeDevelopment},
+			Http:              config.HttpConfig{IdempotencyWindow: time.Minute},
+			Webhook:           testWebhookConfig(),
+		}
+		adapter := New(config.WithConfig(context.Background(), cfg), &mockIndexer{reindexAllFunc: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		}}, &mockTalkReader{})
+		adapter.webhooks.now = func() time.Time { return now }
+		return adapter
+	}
+	accept := func(w http.ResponseWriter, r *http.Request) {}
+
+	previous := newAdapter()
+	mux := http.NewServeMux()
+	previous.RegisterRoutes(mux)
+	require.Equal(t, http.StatusOK, postReindex(mux, "/api/reindex", "key-1").Code)
+	w := httptest.NewRecorder()
+	previous.verifiedWebhook(accept)(w, signedWebhookRequest("webhook-secret", now, `{}`))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// The adapter replacing it on a reload still replays the request and refuses the webhook replay
+	next := newAdapter()
+	next.KeepStateOf(previous)
+	mux = http.NewServeMux()
+	next.RegisterRoutes(mux)
+
+	replayed := postReindex(mux, "/api/reindex", "key-1")
+	assert.Equal(t, "true", replayed.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, int32(1), runs.Load())
+
+	w = httptest.NewRecorder()
+	next.verifiedWebhook(accept)(w, signedWebhookRequest("webhook-secret", now, `{}`))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Default and maximum number of jobs on a page of the job list, and how far back the list can be paged
+const (
+	defaultJobPageSize = 20
+	maxJobPageSize     = 100
+	maxJobListWindow   = 1000
+)
+
+// HandleListJobs returns the most recent jobs with their state, start time and errors, newest first.
+// The optional size and cursor parameters page through the jobs.
+func (a *Adapter) HandleListJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, err := parsePage(r, defaultJobPageSize, maxJobPageSize)
+	if err != nil {
+		writeListError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if page.Offset+page.Size > maxJobListWindow {
+		writeListError(w, r, http.StatusBadRequest, "only the "+strconv.Itoa(maxJobListWindow)+" most recent jobs can be listed")
+		return
+	}
+
+	// One job more than the page tells whether another page follows
+	jobs, err := a.jobs.ListJobs(ctx, page.Offset+page.Size+1)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list jobs", "error", err)
+		writeListError(w, r, http.StatusInternalServerError, "failed to list jobs")
+		return
+	}
+
+	jobs = jobs[min(page.Offset, len(jobs)):]
+	more := len(jobs) > page.Size
+	writeList(w, r, jobs[:min(page.Size, len(jobs))], page.meta(nil, more))
+}
+
+// HandleGetJob returns a single job, including the report collected so far while it runs
+func (a *Adapter) HandleGetJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.PathValue("id")
+
+	job, err := a.jobs.GetJob(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get job", "jobID", id, "error", err)
+		http.Error(w, "failed to get job", http.StatusInternalServerError)
+		return
+	}
+	if job == nil {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		slog.ErrorContext(ctx, "failed to encode job response", "error", err)
+	}
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockReindexJobs is a mock implementation of the ReindexJobs interface for testing
+type mockReindexJobs struct {
+	started  []domain.JobScope
+	startErr error
+	running  *domain.Job
+	jobs     []domain.Job
+	limit    int
+}
+
+func (m *mockReindexJobs) StartReindex(ctx context.Context, scope domain.JobScope) (domain.Job, error) {
+	if m.running != nil {
+		return *m.running, fmt.Errorf("%w: job %s", domain.ErrJobRunning, m.running.ID)
+	}
+	if m.startErr != nil {
+		return domain.Job{}, m.startErr
+	}
+	m.started = append(m.started, scope)
+	return domain.Job{ID: "job-1", Scope: scope, State: domain.JobStateQueued}, nil
+}
+
+func (m *mockReindexJobs) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	for _, job := range m.jobs {
+		if job.ID == id {
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockReindexJobs) ListJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	m.limit = limit
+	return m.jobs, nil
+}
+
+func newJobsTestMux(jobs *mockReindexJobs, indexer *mockIndexer) *http.ServeMux {
+	adapter := New(testContext(), indexer, &mockTalkReader{})
+	adapter.SetReindexJobs(jobs)
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+	return mux
+}
+
+func TestReindex_StartsBackgroundJob(t *testing.T) {
+	tests := []struct {
+		path  string
+		scope domain.JobScope
+	}{
+		{"/api/reindex", domain.JobScope{Kind: domain.JobKindReindexAll}},
+		{"/api/reindex/conference/javazone2024", domain.JobScope{Kind: domain.JobKindReindexConference, Target: "javazone2024"}},
+		{"/api/reindex/conference-id/conf-1", domain.JobScope{Kind: domain.JobKindReindexConferenceID, Target: "conf-1"}},
+		{"/api/reindex/talk/talk-1", domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			jobs := &mockReindexJobs{}
+			indexer := &mockIndexer{reindexAllFunc: func(ctx context.Context) error {
+				t.Error("the reindex must not run in the request")
+				return nil
+			}}
+			mux := newJobsTestMux(jobs, indexer)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusAccepted, w.Code)
+			assert.Equal(t, "/api/jobs/job-1", w.Header().Get("Location"))
+			assert.Equal(t, []domain.JobScope{tt.scope}, jobs.started)
+
+			var response ReindexResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+			assert.Equal(t, "accepted", response.Status)
+			assert.Equal(t, "job-1", response.JobID)
+		})
+	}
+
+	t.Run("start failure", func(t *testing.T) {
+		mux := newJobsTestMux(&mockReindexJobs{startErr: errors.New("job store unavailable")}, &mockIndexer{})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("already running", func(t *testing.T) {
+		running := &domain.Job{ID: "job-7", Scope: domain.JobScope{Kind: domain.JobKindReindexAll}, State: domain.JobStateRunning}
+		mux := newJobsTestMux(&mockReindexJobs{running: running}, &mockIndexer{})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "/api/jobs/job-7", w.Header().Get("Location"))
+		var response ReindexResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "job-7", response.JobID)
+	})
+}
+
+func TestHandleJobs(t *testing.T) {
+	jobs := &mockReindexJobs{jobs: []domain.Job{
+		{ID: "job-2", Scope: domain.JobScope{Kind: domain.JobKindReindexAll}, State: domain.JobStateRunning},
+		{ID: "job-1", Scope: domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}, State: domain.JobStateFailed, Error: "talk not found"},
+	}}
+	mux := newJobsTestMux(jobs, &mockIndexer{})
+
+	t.Run("list", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs?size=5", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 6, jobs.limit, "one job more than the page is fetched")
+
+		listed, meta := decodeList[domain.Job](t, w)
+		require.Len(t, listed, 2)
+		assert.Equal(t, "job-2", listed[0].ID)
+		assert.Equal(t, ListMeta{Page: 1, Size: 5}, meta)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs?size=1", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		listed, meta := decodeList[domain.Job](t, w)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "job-2", listed[0].ID)
+		require.NotEmpty(t, meta.NextCursor)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/jobs?size=1&cursor="+meta.NextCursor, nil)
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		listed, meta = decodeList[domain.Job](t, w)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "job-1", listed[0].ID)
+		assert.Equal(t, 2, meta.Page)
+		assert.Empty(t, meta.NextCursor)
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs?size=1000", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response ListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Errors, 1)
+		assert.Contains(t, response.Errors[0].Message, "size must be between 1 and 100")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/job-1", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var job domain.Job
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&job))
+		assert.Equal(t, domain.JobStateFailed, job.State)
+		assert.Equal(t, "talk not found", job.Error)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/job-9", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetKeywordTrends enables the keyword trend endpoint
+func (a *Adapter) SetKeywordTrends(keywords ports.KeywordTrends) {
+	a.keywords = keywords
+}
+
+// HandleKeywordTrends returns how often keywords occur in the public talks of every conference year.
+// The keyword parameter, repeated or comma-separated, selects the keywords to compare; without it the
+// most frequent keywords are returned, as many as the optional top parameter.
+func (a *Adapter) HandleKeywordTrends(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req domain.KeywordTrendRequest
+	for _, param := range r.URL.Query()["keyword"] {
+		req.Keywords = append(req.Keywords, strings.Split(param, ",")...)
+	}
+	if top := r.URL.Query().Get("top"); top != "" {
+		n, err := strconv.Atoi(top)
+		if err != nil || n < 1 {
+			http.Error(w, "top must be a positive number", http.StatusBadRequest)
+			return
+		}
+		req.Top = n
+	}
+
+	report, err := a.keywords.KeywordTrends(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "keyword trend analysis failed", "error", err)
+		http.Error(w, "keyword trend analysis failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		slog.ErrorContext(ctx, "failed to encode keyword trends response", "error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKeywordTrends is a mock implementation of the KeywordTrends interface for testing
+type mockKeywordTrends struct {
+	keywordTrendsFunc func(ctx context.Context, req domain.KeywordTrendRequest) (*domain.KeywordTrendReport, error)
+}
+
+func (m *mockKeywordTrends) KeywordTrends(ctx context.Context, req domain.KeywordTrendRequest) (*domain.KeywordTrendReport, error) {
+	return m.keywordTrendsFunc(ctx, req)
+}
+
+func TestHandleKeywordTrends(t *testing.T) {
+	var captured domain.KeywordTrendRequest
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetKeywordTrends(&mockKeywordTrends{
+		keywordTrendsFunc: func(ctx context.Context, req domain.KeywordTrendRequest) (*domain.KeywordTrendReport, error) {
+			captured = req
+			return &domain.KeywordTrendReport{
+				Index:    "public",
+				Keywords: []string{"kotlin", "java"},
+				Years:    []domain.KeywordYear{{Year: 2024, Talks: 10, Counts: map[string]int{"kotlin": 3, "java": 5}}},
+			}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleViewer))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/keywords?keyword=kotlin,java&keyword=scala&top=3", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.KeywordTrendRequest{Keywords: []string{"kotlin", "java", "scala"}, Top: 3}, captured)
+
+	var report domain.KeywordTrendReport
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	assert.Equal(t, []string{"kotlin", "java"}, report.Keywords)
+	require.Len(t, report.Years, 1)
+	assert.Equal(t, 3, report.Years[0].Counts["kotlin"])
+}
+
+func TestHandleKeywordTrends_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid top", "/api/analytics/keywords?top=all", nil, http.StatusBadRequest},
+		{"too many keywords", "/api/analytics/keywords?top=100", fmt.Errorf("%w: compare between 1 and 20 keywords", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"index error", "/api/analytics/keywords", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetKeywordTrends(&mockKeywordTrends{
+				keywordTrendsFunc: func(ctx context.Context, req domain.KeywordTrendRequest) (*domain.KeywordTrendReport, error) {
+					return nil, tt.err
+				},
+			})
+			mux := http.NewServeMux()
+			adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleViewer))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+	_ "time/tzdata" // Embedded so the conference time zone resolves in minimal containers
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// legacyLocalTimeFormat is the local time format used by sleepingpill
+const legacyLocalTimeFormat = "2006-01-02T15:04"
+
+// legacyTimeZone is the time zone sleepingpill used for local session times
+const legacyTimeZone = "Europe/Oslo"
+
+// LegacySessionsResponse mirrors the sleepingpill /public/allSessions response
+type LegacySessionsResponse struct {
+	Sessions []LegacySession `json:"sessions"`
+}
+
+// LegacySession mirrors a single session in the sleepingpill public API
+type LegacySession struct {
+	ID                    string          `json:"id"`
+	SessionID             string          `json:"sessionId"`
+	ConferenceID          string          `json:"conferenceId"`
+	Title                 string          `json:"title,omitempty"`
+	Abstract              string          `json:"abstract,omitempty"`
+	IntendedAudience      string          `json:"intendedAudience,omitempty"`
+	Language              string          `json:"language,omitempty"`
+	Format                string          `json:"format,omitempty"`
+	Level                 string          `json:"level,omitempty"`
+	Length                string          `json:"length,omitempty"`
+	Room                  string          `json:"room,omitempty"`
+	StartTime             string          `json:"startTime,omitempty"`
+	EndTime               string          `json:"endTime,omitempty"`
+	StartTimeZulu         string          `json:"startTimeZulu,omitempty"`
+	EndTimeZulu           string          `json:"endTimeZulu,omitempty"`
+	StartSlot             string          `json:"startSlot,omitempty"`
+	StartSlotZulu         string          `json:"startSlotZulu,omitempty"`
+	Video                 string          `json:"video,omitempty"`
+	RegisterLoc           string          `json:"registerLoc,omitempty"`
+	WorkshopPrerequisites string          `json:"workshopPrerequisites,omitempty"`
+	Keywords              []string        `json:"keywords"`
+	Speakers              []LegacySpeaker `json:"speakers"`
+}
+
+// LegacySpeaker mirrors a speaker in the sleepingpill public API
+type LegacySpeaker struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Bio        string `json:"bio,omitempty"`
+	Twitter    string `json:"twitter,omitempty"`
+	PictureURL string `json:"pictureUrl,omitempty"`
+}
+
+// HandleLegacyAllSessions serves the public sessions of a conference in the legacy
+// sleepingpill JSON shape, read from the public index.
+func (a *Adapter) HandleLegacyAllSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	slug := r.PathValue("conferenceSlug")
+	if slug == "" {
+		http.Error(w, "conference slug is required", http.StatusBadRequest)
+		return
+	}
+
+	if a.checkNotModified(w, r, a.cfg.Index.PublicName()) {
+		writeNotModified(w)
+		return
+	}
+
+	talks, err := a.reader.FetchTalks(ctx, a.cfg.Index.PublicName(), slug)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to fetch public sessions", "slug", slug, "error", err)
+		http.Error(w, "failed to fetch sessions", http.StatusInternalServerError)
+		return
+	}
+
+	location, err := time.LoadLocation(legacyTimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	response := LegacySessionsResponse{
+		Sessions: make([]LegacySession, 0, len(talks)),
+	}
+	for _, talk := range talks {
+		response.Sessions = append(response.Sessions, toLegacySession(talk, location))
+	}
+
+	a.writeSignedJSON(w, r, response)
+}
+
+// toLegacySession converts an indexed public talk to the sleepingpill session shape
+func toLegacySession(talk domain.Talk, location *time.Location) LegacySession {
+	session := LegacySession{
+		ID:                    talk.ID,
+		SessionID:             talk.ID,
+		ConferenceID:          talk.ConferenceID,
+		Title:                 dataString(talk.Data, "title"),
+		Abstract:              dataString(talk.Data, "abstract"),
+		IntendedAudience:      dataString(talk.Data, "intendedAudience"),
+		Language:              dataString(talk.Data, "language"),
+		Format:                dataString(talk.Data, "format"),
+		Level:                 dataString(talk.Data, "level"),
+		Length:                dataString(talk.Data, "length"),
+		Room:                  dataString(talk.Data, "room"),
+		Video:                 dataString(talk.Data, "video"),
+		RegisterLoc:           dataString(talk.Data, "registerLoc"),
+		WorkshopPrerequisites: dataString(talk.Data, "workshopPrerequisites"),
+		Keywords:              dataStrings(talk.Data, "keywords"),
+		Speakers:              make([]LegacySpeaker, 0, len(talk.Speakers)),
+	}
+
+	session.StartTime, session.StartTimeZulu = legacyTimes(dataString(talk.Data, "startTime"), location)
+	session.EndTime, session.EndTimeZulu = legacyTimes(dataString(talk.Data, "endTime"), location)
+	session.StartSlot, session.StartSlotZulu = session.StartTime, session.StartTimeZulu
+
+	for _, speaker := range talk.Speakers {
+		session.Speakers = append(session.Speakers, LegacySpeaker{
+			ID:         speaker.ID,
+			Name:       speaker.Name,
+			Bio:        dataString(speaker.Data, "bio"),
+			Twitter:    dataString(speaker.Data, "twitter"),
+			PictureURL: dataString(speaker.Data, "pictureUrl"),
+		})
+	}
+
+	return session
+}
+
+// legacyTimes returns the local and zulu representation of a stored time value.
+// Times without an offset are interpreted in the conference time zone.
+func legacyTimes(value string, location *time.Location) (string, string) {
+	if value == "" {
+		return "", ""
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		for _, format := range []string{"2006-01-02T15:04:05", legacyLocalTimeFormat} {
+			if t, err = time.ParseInLocation(format, value, location); err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return value, ""
+	}
+
+	return t.In(location).Format(legacyLocalTimeFormat), t.UTC().Format(time.RFC3339)
+}
+
+// dataString safely extracts a string value from a data map
+func dataString(data map[string]interface{}, key string) string {
+	if str, ok := data[key].(string); ok {
+		return str
+	}
+	return ""
+}
+
+// dataStrings safely extracts a string slice from a data map, never returning nil
+func dataStrings(data map[string]interface{}, key string) []string {
+	result := []string{}
+	switch values := data[key].(type) {
+	case []interface{}:
+		for _, item := range values {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+	case []string:
+		result = append(result, values...)
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleLegacyAllSessions(t *testing.T) {
+	var capturedIndex, capturedSlug string
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			capturedIndex = indexName
+			capturedSlug = conferenceSlug
+			return []domain.Talk{
+				{
+					ID:           "talk-1",
+					ConferenceID: "conf-1",
+					Status:       "APPROVED",
+					Data: map[string]interface{}{
+						"title":     "Virtual threads",
+						"format":    "presentation",
+						"length":    "45",
+						"room":      "Room 1",
+						"startTime": "2024-09-04T09:00",
+						"endTime":   "2024-09-04T09:45",
+						"keywords":  []interface{}{"java", "loom"},
+					},
+					Speakers: domain.Speakers{
+						{ID: "s1", Name: "Jane Doe", Data: map[string]interface{}{"bio": "Developer", "twitter": "@jane"}},
+					},
+				},
+			}, nil
+		},
+	}
+
+	cfg := &config.Config{Index: config.IndexConfig{Public: "public"}}
+	adapter := New(config.WithConfig(context.Background(), cfg), &mockIndexer{}, reader)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/allSessions/javazone2024", nil)
+	req.SetPathValue("conferenceSlug", "javazone2024")
+	w := httptest.NewRecorder()
+
+	adapter.HandleLegacyAllSessions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "public", capturedIndex)
+	assert.Equal(t, "javazone2024", capturedSlug)
+
+	var response LegacySessionsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response.Sessions, 1)
+
+	session := response.Sessions[0]
+	assert.Equal(t, "talk-1", session.ID)
+	assert.Equal(t, "talk-1", session.SessionID)
+	assert.Equal(t, "conf-1", session.ConferenceID)
+	assert.Equal(t, "Virtual threads", session.Title)
+	assert.Equal(t, "45", session.Length)
+	assert.Equal(t, "2024-09-04T09:00", session.StartTime)
+	assert.Equal(t, "2024-09-04T07:00:00Z", session.StartTimeZulu)
+	assert.Equal(t, "2024-09-04T07:45:00Z", session.EndTimeZulu)
+	assert.Equal(t, session.StartTime, session.StartSlot)
+	assert.Equal(t, []string{"java", "loom"}, session.Keywords)
+	require.Len(t, session.Speakers, 1)
+	assert.Equal(t, "Jane Doe", session.Speakers[0].Name)
+	assert.Equal(t, "@jane", session.Speakers[0].Twitter)
+}
+
+func TestHandleLegacyAllSessions_Empty(t *testing.T) {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+
+	req := httptest.NewRequest(http.MethodGet, "/public/allSessions/unknown", nil)
+	req.SetPathValue("conferenceSlug", "unknown")
+	w := httptest.NewRecorder()
+
+	adapter.HandleLegacyAllSessions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"sessions":[]}`, w.Body.String())
+}
+
+func TestHandleLegacyAllSessions_Error(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return nil, errors.New("index unavailable")
+		},
+	}
+	adapter := New(testContext(), &mockIndexer{}, reader)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/allSessions/javazone2024", nil)
+	req.SetPathValue("conferenceSlug", "javazone2024")
+	w := httptest.NewRecorder()
+
+	adapter.HandleLegacyAllSessions(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestLegacyTimes(t *testing.T) {
+	location, err := time.LoadLocation(legacyTimeZone)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		value         string
+		expectedLocal string
+		expectedZulu  string
+	}{
+		{"empty", "", "", ""},
+		{"local without seconds", "2024-09-04T09:00", "2024-09-04T09:00", "2024-09-04T07:00:00Z"},
+		{"local with seconds", "2024-09-04T09:00:00", "2024-09-04T09:00", "2024-09-04T07:00:00Z"},
+		{"rfc3339", "2024-09-04T07:00:00Z", "2024-09-04T09:00", "2024-09-04T07:00:00Z"},
+		{"unparseable", "tbd", "tbd", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local, zulu := legacyTimes(tt.value, location)
+			assert.Equal(t, tt.expectedLocal, local)
+			assert.Equal(t, tt.expectedZulu, zulu)
+		})
+	}
+}
+
+func TestHandleLegacyAllSessions_NotModified(t *testing.T) {
+	called := false
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			called = true
+			return nil, nil
+		},
+	}
+	adapter := New(testContext(), &mockIndexer{}, reader)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/allSessions/javazone2024", nil)
+	req.SetPathValue("conferenceSlug", "javazone2024")
+	req.Header.Set("If-None-Match", `"gen-1"`)
+	w := httptest.NewRecorder()
+
+	adapter.HandleLegacyAllSessions(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.False(t, called, "sessions should not be read for a 304 response")
+}
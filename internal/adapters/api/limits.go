@@ -0,0 +1,64 @@
+package api
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// LimitRequestBodies wraps the server handler so that POST, PUT and PATCH requests are
+// rejected with 413 when the body exceeds HTTP_MAX_BODY_BYTES and with 415 when the body
+// has a content type outside HTTP_ALLOWED_CONTENT_TYPES. Requests without a body pass through.
+func (a *Adapter) LimitRequestBodies(next http.Handler) http.Handler {
+	return limitBody(next, a.cfg.Http.MaxBodyBytes, a.cfg.Http.AllowedContentTypes)
+}
+
+// limitBody enforces a body size limit and content type allow-list on requests with a body.
+// The body is wrapped in a MaxBytesReader so bodies without a declared length are also capped.
+func limitBody(next http.Handler, maxBytes int64, contentTypes []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasBodyMethod(r.Method) || r.ContentLength == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if maxBytes > 0 && r.ContentLength > maxBytes {
+			slog.WarnContext(r.Context(), "rejected oversized request body",
+				"path", r.URL.Path, "contentLength", r.ContentLength, "maxBytes", maxBytes)
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if len(contentTypes) > 0 && !allowedContentType(r.Header.Get("Content-Type"), contentTypes) {
+			slog.WarnContext(r.Context(), "rejected request with unsupported content type",
+				"path", r.URL.Path, "contentType", r.Header.Get("Content-Type"))
+			w.Header().Set("Accept-Post", strings.Join(contentTypes, ", "))
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+
+		if maxBytes > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hasBodyMethod reports whether requests with the given method are expected to carry a body
+func hasBodyMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// allowedContentType reports whether the media type of the Content-Type header is in the allow-list,
+// ignoring parameters such as charset or boundary
+func allowedContentType(header string, contentTypes []string) bool {
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(contentTypes, func(allowed string) bool {
+		return strings.EqualFold(strings.TrimSpace(allowed), mediaType)
+	})
+}
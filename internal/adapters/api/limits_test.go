@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// limitsTestHandler returns the body limit middleware around a handler echoing the body length
+func limitsTestHandler(maxBytes int64) http.Handler {
+	cfg := &config.Config{
+		Http: config.HttpConfig{
+			MaxBodyBytes:        maxBytes,
+			AllowedContentTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		},
+	}
+	adapter := New(config.WithConfig(context.Background(), cfg), &mockIndexer{}, &mockTalkReader{})
+
+	return adapter.LimitRequestBodies(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.Write(body)
+	}))
+}
+
+func TestLimitRequestBodies(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{name: "json within limit", method: http.MethodPost, contentType: "application/json", body: `{"a":1}`, expectedStatus: http.StatusOK},
+		{name: "content type parameters ignored", method: http.MethodPost, contentType: "application/json; charset=utf-8", body: `{}`, expectedStatus: http.StatusOK},
+		{name: "form within limit", method: http.MethodPost, contentType: "application/x-www-form-urlencoded", body: "slug=javazone2024", expectedStatus: http.StatusOK},
+		{name: "post without body", method: http.MethodPost, expectedStatus: http.StatusOK},
+		{name: "oversized body", method: http.MethodPost, contentType: "application/json", body: strings.Repeat("x", 33), expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "unsupported content type", method: http.MethodPost, contentType: "text/xml", body: "<a/>", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "missing content type", method: http.MethodPut, body: "data", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "get is not checked", method: http.MethodGet, contentType: "text/xml", body: "<a/>", expectedStatus: http.StatusOK},
+	}
+
+	handler := limitsTestHandler(32)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, "/webhooks/test", body)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestLimitRequestBodies_UnknownLength(t *testing.T) {
+	handler := limitsTestHandler(32)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(strings.Repeat("x", 64)))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestLimitRequestBodies_AcceptPost(t *testing.T) {
+	handler := limitsTestHandler(32)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader("<a/>"))
+	req.Header.Set("Content-Type", "text/xml")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Equal(t, "application/json, application/x-www-form-urlencoded", w.Header().Get("Accept-Post"))
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetTalkLookup enables the talk lookup endpoint
+func (a *Adapter) SetTalkLookup(lookup ports.TalkLookup) {
+	a.lookup = lookup
+}
+
+// HandleLookupTalk resolves a talk slug or title to talk IDs, best match first. The slug or title
+// parameter is required, and conference limits the lookup to one conference. The matches fit on one page.
+func (a *Adapter) HandleLookupTalk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	req := domain.TalkLookupRequest{
+		ConferenceSlug: query.Get("conference"),
+		Title:          query.Get("title"),
+		Slug:           query.Get("slug"),
+	}
+
+	talks, err := a.lookup.LookupTalk(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		writeListError(w, r, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "talk lookup failed", "error", err)
+		writeListError(w, r, http.StatusInternalServerError, "lookup failed")
+		return
+	}
+
+	count := len(talks)
+	writeList(w, r, talks, ListMeta{Page: 1, Size: count, Total: &count})
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTalkLookup is a mock implementation of the TalkLookup interface for testing
+type mockTalkLookup struct {
+	lookupFunc func(ctx context.Context, req domain.TalkLookupRequest) ([]domain.TalkMatch, error)
+}
+
+func (m *mockTalkLookup) LookupTalk(ctx context.Context, req domain.TalkLookupRequest) ([]domain.TalkMatch, error) {
+	return m.lookupFunc(ctx, req)
+}
+
+func TestHandleLookupTalk(t *testing.T) {
+	var captured domain.TalkLookupRequest
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetTalkLookup(&mockTalkLookup{
+		lookupFunc: func(ctx context.Context, req domain.TalkLookupRequest) ([]domain.TalkMatch, error) {
+			captured = req
+			return []domain.TalkMatch{{ID: "talk-1", ConferenceSlug: "javazone2024", Status: "APPROVED", Title: "Kotlin in production"}}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleOperator))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lookup/talk?conference=javazone2024&title=kotlin", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.TalkLookupRequest{ConferenceSlug: "javazone2024", Title: "kotlin"}, captured)
+
+	talks, meta := decodeList[domain.TalkMatch](t, w)
+	require.Len(t, talks, 1)
+	assert.Equal(t, "talk-1", talks[0].ID)
+	assert.Equal(t, ListMeta{Page: 1, Size: 1, Total: intPtr(1)}, meta)
+}
+
+func TestHandleLookupTalk_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       domain.Role
+		err        error
+		wantStatus int
+	}{
+		{"viewer", domain.RoleViewer, nil, http.StatusForbidden},
+		{"missing title and slug", domain.RoleOperator, fmt.Errorf("%w: title or slug is required", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"index error", domain.RoleOperator, errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetTalkLookup(&mockTalkLookup{
+				lookupFunc: func(ctx context.Context, req domain.TalkLookupRequest) ([]domain.TalkMatch, error) {
+					return nil, tt.err
+				},
+			})
+			mux := http.NewServeMux()
+			adapter.RegisterAuthenticatedRoutes(mux, withRole(tt.role))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/lookup/talk?conference=javazone2024", nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
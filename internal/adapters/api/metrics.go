@@ -0,0 +1,240 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// metricsContentType is the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// usageDigestPrefix is the number of hex digits of a token's digest that name its client on the usage
+// page when the route does not authenticate the token
+const usageDigestPrefix = 8
+
+// usageClientKey is the context key for the client withAPIActor authenticated, read back after the
+// request by RecordRequests
+type usageClientKey struct{}
+
+// SetRequestMetrics enables recording request metrics per route and the /metrics endpoint
+func (a *Adapter) SetRequestMetrics(metrics ports.RequestMetrics) {
+	a.metrics = metrics
+}
+
+// SetIndexMetrics enables exposing the counts of index events on the /metrics endpoint
+func (a *Adapter) SetIndexMetrics(metrics ports.IndexMetrics) {
+	a.indexMetrics = metrics
+}
+
+// SetUsageRecorder enables counting the requests to the API routes per client for the usage page
+func (a *Adapter) SetUsageRecorder(usage ports.UsageRecorder) {
+	a.usage = usage
+}
+
+// RecordRequests wraps the server handler so that every request is recorded under the mux pattern
+// that served it, such as "GET /api/conferences". Requests matching no pattern are recorded as
+// domain.UnmatchedRoute so arbitrary paths cannot create new routes. Requests to the API routes are
+// also counted per client when SetUsageRecorder is called.
+func (a *Adapter) RecordRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.metrics == nil && a.usage == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var client string
+		if a.usage != nil {
+			r = r.WithContext(context.WithValue(r.Context(), usageClientKey{}, &client))
+		}
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// The mux sets the matched pattern on the request it was given
+		route := r.Pattern
+		if route == "" {
+			route = domain.UnmatchedRoute
+		}
+		latency := time.Since(start)
+		if a.metrics != nil {
+			a.metrics.RecordRequest(route, recorder.status, latency)
+		}
+		if a.usage != nil && isUsageRoute(route) {
+			a.usage.RecordUsage(route, usageClient(r, client), recorder.status, latency)
+		}
+	})
+}
+
+// isUsageRoute returns true for the routes API clients call, such as "GET /api/search" or
+// "GET /public/allSessions/{conferenceSlug}"
+func isUsageRoute(route string) bool {
+	_, path, _ := strings.Cut(route, " ")
+	return strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/public/")
+}
+
+// setUsageClient records the client withAPIActor authenticated, for counting the request under it
+func setUsageClient(ctx context.Context, name string) {
+	if client, ok := ctx.Value(usageClientKey{}).(*string); ok {
+		*client = name
+	}
+}
+
+// usageClient returns the client to count a request under: the API token the route authenticated, or
+// otherwise "token:" and the start of the bearer token's digest, so public requests are told apart
+// without looking the token up. Requests without a token are domain.UsageAnonymousClient.
+func usageClient(r *http.Request, authenticated string) string {
+	if authenticated != "" {
+		return authenticated
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return domain.UsageAnonymousClient
+	}
+	digest := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(digest[:])[:usageDigestPrefix]
+}
+
+// HandleMetrics serves the request and index metrics in the Prometheus text format to callers on a trusted
+// network or with an authenticated session, the same callers that may see detailed health output
+func (a *Adapter) HandleMetrics(w http.ResponseWriter, r *http.Request) {
+	if a.metrics == nil && a.indexMetrics == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !a.mayViewHealthDetail(r) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", metricsContentType)
+	out := bufio.NewWriter(w)
+	if a.metrics != nil {
+		writeRouteMetrics(out, a.metrics.RouteMetrics())
+	}
+	if a.indexMetrics != nil {
+		writeIndexMetrics(out, a.indexMetrics.IndexEventCounts())
+	}
+	if err := out.Flush(); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write metrics", "error", err)
+	}
+}
+
+// writeRouteMetrics writes request counts, the latency histogram and the SLO burn rates of each route
+func writeRouteMetrics(out *bufio.Writer, metrics []domain.RouteMetrics) {
+	fmt.Fprintln(out, "# HELP talks_indexer_http_requests_total Requests served per route and status class.")
+	fmt.Fprintln(out, "# TYPE talks_indexer_http_requests_total counter")
+	for _, m := range metrics {
+		classes := make([]string, 0, len(m.Requests))
+		for class := range m.Requests {
+			classes = append(classes, class)
+		}
+		slices.Sort(classes)
+		for _, class := range classes {
+			fmt.Fprintf(out, "talks_indexer_http_requests_total{route=%s,code=%q} %d\n", quoteLabel(m.Route), class, m.Requests[class])
+		}
+	}
+
+	fmt.Fprintln(out, "# HELP talks_indexer_http_request_duration_seconds Latency of requests per route.")
+	fmt.Fprintln(out, "# TYPE talks_indexer_http_request_duration_seconds histogram")
+	for _, m := range metrics {
+		route := quoteLabel(m.Route)
+		for _, bucket := range m.LatencyBuckets {
+			fmt.Fprintf(out, "talks_indexer_http_request_duration_seconds_bucket{route=%s,le=%q} %d\n", route, formatSeconds(bucket.UpperBound), bucket.Count)
+		}
+		fmt.Fprintf(out, "talks_indexer_http_request_duration_seconds_bucket{route=%s,le=\"+Inf\"} %d\n", route, m.Count)
+		fmt.Fprintf(out, "talks_indexer_http_request_duration_seconds_sum{route=%s} %s\n", route, formatSeconds(m.LatencySum))
+		fmt.Fprintf(out, "talks_indexer_http_request_duration_seconds_count{route=%s} %d\n", route, m.Count)
+	}
+
+	fmt.Fprintln(out, "# HELP talks_indexer_slo_objective Fraction of requests that must be served without a 5xx status within the latency threshold.")
+	fmt.Fprintln(out, "# TYPE talks_indexer_slo_objective gauge")
+	for _, m := range metrics {
+		if m.SLO != nil {
+			fmt.Fprintf(out, "talks_indexer_slo_objective{route=%s} %s\n", quoteLabel(m.Route), strconv.FormatFloat(m.SLO.Objective, 'g', -1, 64))
+		}
+	}
+
+	fmt.Fprintln(out, "# HELP talks_indexer_slo_latency_threshold_seconds Slowest response that meets the objective.")
+	fmt.Fprintln(out, "# TYPE talks_indexer_slo_latency_threshold_seconds gauge")
+	for _, m := range metrics {
+		if m.SLO != nil {
+			fmt.Fprintf(out, "talks_indexer_slo_latency_threshold_seconds{route=%s} %s\n", quoteLabel(m.Route), formatSeconds(m.SLO.Latency))
+		}
+	}
+
+	fmt.Fprintln(out, "# HELP talks_indexer_slo_burn_rate Error budget burn rate per window; 1 spends exactly the budget.")
+	fmt.Fprintln(out, "# TYPE talks_indexer_slo_burn_rate gauge")
+	for _, m := range metrics {
+		if m.SLO == nil {
+			continue
+		}
+		for _, burn := range m.SLO.BurnRates {
+			fmt.Fprintf(out, "talks_indexer_slo_burn_rate{route=%s,window=%q} %s\n", quoteLabel(m.Route), formatWindow(burn.Window), strconv.FormatFloat(burn.Rate, 'g', -1, 64))
+		}
+	}
+}
+
+// writeIndexMetrics writes the number of index events published per event name
+func writeIndexMetrics(out *bufio.Writer, counts map[string]uint64) {
+	fmt.Fprintln(out, "# HELP talks_indexer_index_events_total Events published by the indexer after changing an index.")
+	fmt.Fprintln(out, "# TYPE talks_indexer_index_events_total counter")
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "talks_indexer_index_events_total{event=%s} %d\n", quoteLabel(name), counts[name])
+	}
+}
+
+// quoteLabel quotes a label value, escaping backslashes, quotes and newlines
+func quoteLabel(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// formatSeconds formats a duration in seconds
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
+
+// formatWindow formats a burn rate window the way alert rules usually name it, such as "5m" or "1h"
+func formatWindow(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	}
+	return d.String()
+}
+
+// statusRecorder passes a response through while keeping its status code
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
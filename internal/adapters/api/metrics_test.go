@@ -0,0 +1,194 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedRequest is a request passed to mockRequestMetrics
+type recordedRequest struct {
+	Route  string
+	Status int
+}
+
+// mockRequestMetrics is a mock implementation of ports.RequestMetrics for testing
+type mockRequestMetrics struct {
+	recorded []recordedRequest
+	metrics  []domain.RouteMetrics
+}
+
+func (m *mockRequestMetrics) RecordRequest(route string, status int, latency time.Duration) {
+	m.recorded = append(m.recorded, recordedRequest{Route: route, Status: status})
+}
+
+func (m *mockRequestMetrics) RouteMetrics() []domain.RouteMetrics {
+	return m.metrics
+}
+
+func TestRecordRequests(t *testing.T) {
+	metrics := &mockRequestMetrics{}
+	adapter := healthDetailAdapter()
+	adapter.SetRequestMetrics(metrics)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /public/allSessions/{conferenceSlug}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	handler := adapter.RecordRequests(mux)
+
+	for _, path := range []string{"/public/allSessions/javazone2024", "/public/allSessions/javazone2025", "/wp-login.php"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, []recordedRequest{
+		{Route: "GET /public/allSessions/{conferenceSlug}", Status: http.StatusServiceUnavailable},
+		{Route: "GET /public/allSessions/{conferenceSlug}", Status: http.StatusServiceUnavailable},
+		{Route: domain.UnmatchedRoute, Status: http.StatusNotFound},
+	}, metrics.recorded)
+}
+
+// mockUsageRecorder is a mock implementation of ports.UsageRecorder for testing
+type mockUsageRecorder struct {
+	recorded []string
+}
+
+func (m *mockUsageRecorder) RecordUsage(route, client string, status int, latency time.Duration) {
+	m.recorded = append(m.recorded, client+" "+route)
+}
+
+func TestRecordRequests_Usage(t *testing.T) {
+	usage := &mockUsageRecorder{}
+	adapter := healthDetailAdapter()
+	adapter.tokens = parseAPITokens(map[string]string{"mobile-app": "secret"})
+	adapter.SetUsageRecorder(usage)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/jobs", adapter.withAPIActor(func(w http.ResponseWriter, r *http.Request) {}))
+	mux.HandleFunc("GET /public/allSessions", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+	handler := adapter.RecordRequests(mux)
+
+	for _, token := range []string{"secret", "wrong", ""} {
+		for _, path := range []string{"/api/jobs", "/public/allSessions", "/health", "/wp-login.php"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}
+	}
+
+	// Only API routes are counted: by token name where the route authenticates the token, and
+	// otherwise by the start of the token's digest
+	assert.Equal(t, []string{
+		"mobile-app GET /api/jobs",
+		"token:2bb80d53 GET /public/allSessions",
+		"token:8810ad58 GET /api/jobs",
+		"token:8810ad58 GET /public/allSessions",
+		"anonymous GET /api/jobs",
+		"anonymous GET /public/allSessions",
+	}, usage.recorded)
+}
+
+func TestHandleMetrics(t *testing.T) {
+	metrics := &mockRequestMetrics{metrics: []domain.RouteMetrics{
+		{
+			Route:          "GET /api/conferences",
+			Requests:       map[string]uint64{"5xx": 1, "2xx": 9},
+			LatencyBuckets: []domain.LatencyBucket{{UpperBound: 100 * time.Millisecond, Count: 7}, {UpperBound: time.Second, Count: 10}},
+			LatencySum:     1500 * time.Millisecond,
+			Count:          10,
+			SLO: &domain.SLOStatus{
+				Objective: 0.999,
+				Latency:   300 * time.Millisecond,
+				BurnRates: []domain.BurnRate{{Window: 5 * time.Minute, Rate: 14.4}, {Window: time.Hour, Rate: 2}},
+			},
+		},
+		{
+			Route:    "GET /health",
+			Requests: map[string]uint64{"2xx": 3},
+			Count:    3,
+		},
+	}}
+
+	t.Run("trusted network gets metrics", func(t *testing.T) {
+		adapter := healthDetailAdapter()
+		adapter.SetRequestMetrics(metrics)
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.RemoteAddr = "10.1.2.3:5000"
+		w := httptest.NewRecorder()
+		adapter.HandleMetrics(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, metricsContentType, w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.Contains(t, body, "# TYPE talks_indexer_http_requests_total counter\n")
+		assert.Contains(t, body, `talks_indexer_http_requests_total{route="GET /api/conferences",code="2xx"} 9`+"\n")
+		assert.Contains(t, body, `talks_indexer_http_requests_total{route="GET /api/conferences",code="5xx"} 1`+"\n")
+		assert.Contains(t, body, `talks_indexer_http_request_duration_seconds_bucket{route="GET /api/conferences",le="0.1"} 7`+"\n")
+		assert.Contains(t, body, `talks_indexer_http_request_duration_seconds_bucket{route="GET /api/conferences",le="+Inf"} 10`+"\n")
+		assert.Contains(t, body, `talks_indexer_http_request_duration_seconds_sum{route="GET /api/conferences"} 1.5`+"\n")
+		assert.Contains(t, body, `talks_indexer_slo_objective{route="GET /api/conferences"} 0.999`+"\n")
+		assert.Contains(t, body, `talks_indexer_slo_latency_threshold_seconds{route="GET /api/conferences"} 0.3`+"\n")
+		assert.Contains(t, body, `talks_indexer_slo_burn_rate{route="GET /api/conferences",window="5m"} 14.4`+"\n")
+		assert.Contains(t, body, `talks_indexer_slo_burn_rate{route="GET /api/conferences",window="1h"} 2`+"\n")
+		assert.NotContains(t, body, `talks_indexer_slo_objective{route="GET /health"}`)
+	})
+
+	t.Run("public caller is forbidden", func(t *testing.T) {
+		adapter := healthDetailAdapter()
+		adapter.SetRequestMetrics(metrics)
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.RemoteAddr = "203.0.113.10:5000"
+		w := httptest.NewRecorder()
+		adapter.HandleMetrics(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("not found without metrics", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.RemoteAddr = "10.1.2.3:5000"
+		w := httptest.NewRecorder()
+		healthDetailAdapter().HandleMetrics(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestQuoteLabel(t *testing.T) {
+	assert.Equal(t, `"GET /a\"b\\c\n"`, quoteLabel("GET /a\"b\\c\n"))
+}
+
+// mockIndexMetrics is a mock implementation of ports.IndexMetrics for testing
+type mockIndexMetrics struct {
+	counts map[string]uint64
+}
+
+func (m *mockIndexMetrics) IndexEventCounts() map[string]uint64 {
+	return m.counts
+}
+
+func TestHandleMetrics_IndexEvents(t *testing.T) {
+	adapter := healthDetailAdapter()
+	adapter.SetIndexMetrics(&mockIndexMetrics{counts: map[string]uint64{"talk_indexed": 4, "conference_reindexed": 1}})
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.RemoteAddr = "10.1.2.3:5000"
+	w := httptest.NewRecorder()
+	adapter.HandleMetrics(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `talks_indexer_index_events_total{event="conference_reindexed"} 1`+"\n")
+	assert.Contains(t, body, `talks_indexer_index_events_total{event="talk_indexed"} 4`+"\n")
+	assert.NotContains(t, body, "talks_indexer_http_requests_total")
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// maxWebhookTalks is the largest number of talks a single moresleep notification may name
+const maxWebhookTalks = 100
+
+// MoresleepWebhook is a talk-changed notification from moresleep, naming one talk or several
+type MoresleepWebhook struct {
+	TalkID  string   `json:"talkId"`
+	TalkIDs []string `json:"talkIds"`
+}
+
+// talkIDs returns the distinct, non-empty talk IDs of the notification
+func (n MoresleepWebhook) talkIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, id := range append([]string{n.TalkID}, n.TalkIDs...) {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// WebhookResponse is the response to an accepted moresleep notification
+type WebhookResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+
+	// JobIDs identifies the background job of each talk reindex, see GET /api/jobs/{id}
+	JobIDs []string `json:"jobIds,omitempty"`
+}
+
+// HandleMoresleepWebhook reindexes the talks named in a talk-changed notification from moresleep.
+// With reindex jobs set, each talk is reindexed in a background job and the response is 202 Accepted
+// with the job IDs; otherwise the talks are reindexed before responding.
+func (a *Adapter) HandleMoresleepWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var notification MoresleepWebhook
+	if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+		http.Error(w, "invalid notification: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	talkIDs := notification.talkIDs()
+	if len(talkIDs) == 0 {
+		http.Error(w, "talkId or talkIds is required", http.StatusBadRequest)
+		return
+	}
+	if len(talkIDs) > maxWebhookTalks {
+		http.Error(w, "at most "+strconv.Itoa(maxWebhookTalks)+" talks can be reindexed per notification", http.StatusBadRequest)
+		return
+	}
+
+	slog.InfoContext(ctx, "received talk change notification", "talks", len(talkIDs))
+
+	status := http.StatusOK
+	response := WebhookResponse{Status: "success", Message: "reindexed talks: " + strings.Join(talkIDs, ", ")}
+	if a.jobs != nil {
+		status = http.StatusAccepted
+		response = WebhookResponse{Status: "accepted", Message: "talk reindexes started: " + strings.Join(talkIDs, ", ")}
+		for _, talkID := range talkIDs {
+			job, err := a.jobs.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexTalk, Target: talkID})
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to start talk reindex", "talkID", talkID, "error", err)
+				a.writeErrorResponse(w, "failed to start reindex of talk "+talkID, err)
+				return
+			}
+			response.JobIDs = append(response.JobIDs, job.ID)
+		}
+	} else {
+		var failed []string
+		for _, talkID := range talkIDs {
+			if err := a.indexer.ReindexTalk(ctx, talkID); err != nil {
+				slog.ErrorContext(ctx, "failed to reindex talk", "talkID", talkID, "error", err)
+				failed = append(failed, talkID+" ("+err.Error()+")")
+			}
+		}
+		if len(failed) > 0 {
+			a.writeErrorResponse(w, "failed to reindex talks: "+strings.Join(failed, ", "), nil)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode webhook response", "error", err)
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMoresleepWebhookMux returns a production-mode mux with the webhook secret configured
+func newMoresleepWebhookMux(indexer *mockIndexer, jobs *mockReindexJobs) *http.ServeMux {
+	cfg := &config.Config{Webhook: testWebhookConfig()}
+	adapter := New(config.WithConfig(context.Background(), cfg), indexer, &mockTalkReader{})
+	if jobs != nil {
+		adapter.SetReindexJobs(jobs)
+	}
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+	return mux
+}
+
+// moresleepWebhookRequest builds a signed notification to the moresleep webhook
+func moresleepWebhookRequest(body string) *http.Request {
+	req := signedWebhookRequest("webhook-secret", time.Now(), body)
+	req.URL.Path = "/webhooks/moresleep"
+	return req
+}
+
+func TestMoresleepWebhook_ReindexesTalks(t *testing.T) {
+	var reindexed []string
+	indexer := &mockIndexer{reindexTalkFunc: func(ctx context.Context, talkID string) error {
+		assert.Equal(t, domain.WebhookActor, domain.ActorFromContext(ctx))
+		reindexed = append(reindexed, talkID)
+		return nil
+	}}
+	mux := newMoresleepWebhookMux(indexer, nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, moresleepWebhookRequest(`{"talkId":"talk-1","talkIds":["talk-2","talk-1",""]}`))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"talk-1", "talk-2"}, reindexed)
+}
+
+func TestMoresleepWebhook_StartsBackgroundJobs(t *testing.T) {
+	jobs := &mockReindexJobs{}
+	mux := newMoresleepWebhookMux(&mockIndexer{}, jobs)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, moresleepWebhookRequest(`{"talkIds":["talk-1","talk-2"]}`))
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, []domain.JobScope{
+		{Kind: domain.JobKindReindexTalk, Target: "talk-1"},
+		{Kind: domain.JobKindReindexTalk, Target: "talk-2"},
+	}, jobs.started)
+
+	var response WebhookResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "accepted", response.Status)
+	assert.Equal(t, []string{"job-1", "job-1"}, response.JobIDs)
+}
+
+func TestMoresleepWebhook_ReportsFailedTalks(t *testing.T) {
+	indexer := &mockIndexer{reindexTalkFunc: func(ctx context.Context, talkID string) error {
+		if talkID == "talk-2" {
+			return errors.New("talk not found")
+		}
+		return nil
+	}}
+	mux := newMoresleepWebhookMux(indexer, nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, moresleepWebhookRequest(`{"talkIds":["talk-1","talk-2"]}`))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "talk-2 (talk not found)")
+}
+
+func TestMoresleepWebhook_Rejections(t *testing.T) {
+	tooMany := make([]string, maxWebhookTalks+1)
+	for i := range tooMany {
+		tooMany[i] = "talk-" + strconv.Itoa(i)
+	}
+	tooManyBody, _ := json.Marshal(MoresleepWebhook{TalkIDs: tooMany})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid JSON", `{"talkId":`},
+		{"no talks", `{"talkIds":[]}`},
+		{"too many talks", string(tooManyBody)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := &mockIndexer{reindexTalkFunc: func(ctx context.Context, talkID string) error {
+				t.Error("no talk must be reindexed")
+				return nil
+			}}
+			mux := newMoresleepWebhookMux(indexer, nil)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, moresleepWebhookRequest(tt.body))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+
+	// Unsigned notifications never reach the handler
+	mux := newMoresleepWebhookMux(&mockIndexer{}, nil)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/moresleep", strings.NewReader(`{"talkId":"talk-1"}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// Headers announcing the notice set by admins
+const (
+	noticeHeader         = "X-Notice"
+	noticeSeverityHeader = "X-Notice-Severity"
+	noticeExpiresHeader  = "X-Notice-Expires"
+)
+
+// SetNotices enables announcing the admins' notice on every response
+func (a *Adapter) SetNotices(notices ports.Notices) {
+	a.notices = notices
+}
+
+// AnnounceNotice wraps the server handler so every response carries the active notice, such as an
+// announcement of an ongoing migration, in the X-Notice, X-Notice-Severity and X-Notice-Expires headers
+func (a *Adapter) AnnounceNotice(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.notices != nil {
+			if notice, ok := a.notices.ActiveNotice(r.Context()); ok {
+				w.Header().Set(noticeHeader, notice.Message)
+				w.Header().Set(noticeSeverityHeader, string(notice.Severity))
+				if notice.ExpiresAt != nil {
+					w.Header().Set(noticeExpiresHeader, notice.ExpiresAt.UTC().Format(time.RFC3339))
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockNotices is a mock implementation of the Notices interface for testing
+type mockNotices struct {
+	notice domain.Notice
+	active bool
+}
+
+func (m *mockNotices) ActiveNotice(ctx context.Context) (domain.Notice, bool) {
+	return m.notice, m.active
+}
+
+func (m *mockNotices) GetNotice(ctx context.Context) (domain.Notice, error) {
+	return m.notice, nil
+}
+
+func (m *mockNotices) SetNotice(ctx context.Context, notice domain.Notice, updatedBy string) error {
+	m.notice = notice
+	return nil
+}
+
+func (m *mockNotices) ClearNotice(ctx context.Context) error {
+	m.notice = domain.Notice{}
+	return nil
+}
+
+func TestAnnounceNotice(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("active notice", func(t *testing.T) {
+		expires := time.Date(2025, 9, 1, 18, 0, 0, 0, time.UTC)
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		adapter.SetNotices(&mockNotices{
+			notice: domain.Notice{Message: "Migrating to a new cluster", Severity: domain.NoticeWarning, ExpiresAt: &expires},
+			active: true,
+		})
+
+		w := httptest.NewRecorder()
+		adapter.AnnounceNotice(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/conferences", nil))
+
+		assert.Equal(t, "Migrating to a new cluster", w.Header().Get("X-Notice"))
+		assert.Equal(t, "warning", w.Header().Get("X-Notice-Severity"))
+		assert.Equal(t, "2025-09-01T18:00:00Z", w.Header().Get("X-Notice-Expires"))
+	})
+
+	t.Run("no active notice", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		adapter.SetNotices(&mockNotices{notice: domain.Notice{Message: "Expired", Severity: domain.NoticeInfo}})
+
+		w := httptest.NewRecorder()
+		adapter.AnnounceNotice(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/conferences", nil))
+
+		assert.Empty(t, w.Header().Get("X-Notice"))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetTalkPreviews enables creating speaker preview links through the API
+func (a *Adapter) SetTalkPreviews(previews ports.TalkPreviews) {
+	a.previews = previews
+}
+
+// PreviewLinkResponse is a preview link created for the speakers of a talk, with the path of the
+// preview page in the admin UI
+type PreviewLinkResponse struct {
+	domain.PreviewToken
+	Path string `json:"path"`
+}
+
+// HandleCreatePreviewLink creates a signed, expiring preview link for the speakers of a talk, such as
+// for automation mailing speakers the link before the program is published
+func (a *Adapter) HandleCreatePreviewLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	talkID := r.PathValue("talkId")
+
+	token, err := a.previews.CreatePreviewToken(ctx, talkID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create preview link", "talkID", talkID, "error", err)
+		http.Error(w, "failed to create preview link", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+
+	if err := json.NewEncoder(w).Encode(PreviewLinkResponse{PreviewToken: token, Path: "/preview/" + token.Token}); err != nil {
+		slog.ErrorContext(ctx, "failed to encode preview link response", "error", err)
+	}
+}
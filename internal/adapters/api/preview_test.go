@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTalkPreviews is a mock implementation of the TalkPreviews interface for testing
+type mockTalkPreviews struct {
+	createFunc func(ctx context.Context, talkID string) (domain.PreviewToken, error)
+}
+
+func (m *mockTalkPreviews) CreatePreviewToken(ctx context.Context, talkID string) (domain.PreviewToken, error) {
+	return m.createFunc(ctx, talkID)
+}
+
+func (m *mockTalkPreviews) PreviewTalk(ctx context.Context, token string) (domain.TalkPreview, error) {
+	return domain.TalkPreview{}, errors.New("not implemented")
+}
+
+func TestHandleCreatePreviewLink(t *testing.T) {
+	expiresAt := time.Date(2025, 8, 15, 12, 0, 0, 0, time.UTC)
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetTalkPreviews(&mockTalkPreviews{
+		createFunc: func(ctx context.Context, talkID string) (domain.PreviewToken, error) {
+			return domain.PreviewToken{TalkID: talkID, Token: "payload.signature", ExpiresAt: expiresAt}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/talks/talk-1/preview-link", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	var response PreviewLinkResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "talk-1", response.TalkID)
+	assert.Equal(t, "payload.signature", response.Token)
+	assert.Equal(t, expiresAt, response.ExpiresAt)
+	assert.Equal(t, "/preview/payload.signature", response.Path)
+}
+
+func TestHandleCreatePreviewLink_Error(t *testing.T) {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetTalkPreviews(&mockTalkPreviews{
+		createFunc: func(ctx context.Context, talkID string) (domain.PreviewToken, error) {
+			return domain.PreviewToken{}, errors.New("talk not found in moresleep")
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/talks/missing/preview-link", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestHandleCreatePreviewLink_NotRegisteredWithoutService(t *testing.T) {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/talks/talk-1/preview-link", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
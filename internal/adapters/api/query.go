@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/auth"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetQuerier enables the ad-hoc query endpoint on the private index
+func (a *Adapter) SetQuerier(querier ports.Querier) {
+	a.querier = querier
+}
+
+// RegisterAuthenticatedRoutes registers the API routes that require a logged-in user, wrapped with
+// the provided authentication middleware. The ad-hoc query, index sample, talk lookup, keyword trend,
+// speaker statistics and configuration reload endpoints are only registered when their services are set. The talk search
+// endpoint is open to everyone and registered here because it searches the private index for
+// logged-in users.
+func (a *Adapter) RegisterAuthenticatedRoutes(mux *http.ServeMux, middleware func(http.Handler) http.Handler) {
+	if a.searcher != nil {
+		mux.HandleFunc("GET /api/search", a.HandleSearch)
+	}
+	if a.querier != nil {
+		mux.Handle("POST /api/query", middleware(auth.RequireRole(domain.RoleOperator)(http.HandlerFunc(a.HandleQuery))))
+	}
+	if a.sampler != nil {
+		mux.Handle("GET /api/indexes/{name}/sample", middleware(auth.RequireRole(domain.RoleOperator)(http.HandlerFunc(a.HandleSample))))
+	}
+	if a.lookup != nil {
+		mux.Handle("GET /api/lookup/talk", middleware(auth.RequireRole(domain.RoleOperator)(http.HandlerFunc(a.HandleLookupTalk))))
+	}
+	if a.keywords != nil {
+		mux.Handle("GET /api/analytics/keywords", middleware(auth.RequireRole(domain.RoleViewer)(http.HandlerFunc(a.HandleKeywordTrends))))
+	}
+	if a.speakerStats != nil {
+		mux.Handle("GET /api/analytics/speakers", middleware(auth.RequireRole(domain.RoleAdmin)(http.HandlerFunc(a.HandleSpeakerStatistics))))
+	}
+	if a.reloader != nil {
+		mux.Handle("POST /api/config/reload", middleware(auth.RequireRole(domain.RoleAdmin)(http.HandlerFunc(a.HandleReloadConfig))))
+	}
+}
+
+// HandleQuery runs an ad-hoc query against the private index. The body is a search request limited to
+// query, aggs, sort, _source, size and from; anything outside the allowed subset or limits is rejected with 400.
+func (a *Adapter) HandleQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	var req domain.QueryRequest
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "invalid query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := a.querier.Query(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		slog.WarnContext(ctx, "ad-hoc query timed out", "error", err)
+		http.Error(w, "query timed out", http.StatusGatewayTimeout)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "ad-hoc query failed", "error", err)
+		http.Error(w, "query failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode query response", "error", err)
+	}
+}
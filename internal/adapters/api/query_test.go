@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/auth"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockQuerier is a mock implementation of the Querier interface for testing
+type mockQuerier struct {
+	queryFunc func(ctx context.Context, req domain.QueryRequest) (domain.QueryResult, error)
+}
+
+func (m *mockQuerier) Query(ctx context.Context, req domain.QueryRequest) (domain.QueryResult, error) {
+	return m.queryFunc(ctx, req)
+}
+
+// withRole is a middleware putting the given role in the context, as the auth middleware does
+func withRole(role domain.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), auth.RoleKey, role)))
+		})
+	}
+}
+
+func TestHandleQuery(t *testing.T) {
+	var captured domain.QueryRequest
+	querier := &mockQuerier{
+		queryFunc: func(ctx context.Context, req domain.QueryRequest) (domain.QueryResult, error) {
+			captured = req
+			return domain.QueryResult{Total: 1, Hits: []json.RawMessage{json.RawMessage(`{"id":"talk-1"}`)}}, nil
+		},
+	}
+
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetQuerier(querier)
+	mux := http.NewServeMux()
+	adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleOperator))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/query", strings.NewReader(`{"query":{"term":{"status":"APPROVED"}},"size":5}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.NotNil(t, captured.Size)
+	assert.Equal(t, 5, *captured.Size)
+	assert.Contains(t, captured.Query, "term")
+
+	var result domain.QueryResult
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal(t, 1, result.Total)
+	require.Len(t, result.Hits, 1)
+	assert.JSONEq(t, `{"id":"talk-1"}`, string(result.Hits[0]))
+}
+
+func TestHandleQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"malformed body", `{"query":`, nil, http.StatusBadRequest},
+		{"unknown top-level key", `{"script_fields":{}}`, nil, http.StatusBadRequest},
+		{"invalid query", `{}`, fmt.Errorf("%w: size must be between 0 and 100", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"timeout", `{}`, fmt.Errorf("failed to run query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{"index error", `{}`, errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetQuerier(&mockQuerier{
+				queryFunc: func(ctx context.Context, req domain.QueryRequest) (domain.QueryResult, error) {
+					return domain.QueryResult{}, tt.err
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/query", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			adapter.HandleQuery(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRegisterAuthenticatedRoutes(t *testing.T) {
+	t.Run("requires operator role", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		adapter.SetQuerier(&mockQuerier{
+			queryFunc: func(ctx context.Context, req domain.QueryRequest) (domain.QueryResult, error) {
+				t.Error("query must not run for viewers")
+				return domain.QueryResult{}, nil
+			},
+		})
+		mux := http.NewServeMux()
+		adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleViewer))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/query", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("not registered without querier", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		mux := http.NewServeMux()
+		adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleAdmin))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/query", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
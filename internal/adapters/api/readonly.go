@@ -0,0 +1,19 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// writable wraps a handler writing to the indexes so it is refused with 503 in read-only mode,
+// telling clients to retry after the maintenance window
+func (a *Adapter) writable(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.cfg.ReadOnly {
+			slog.WarnContext(r.Context(), "refused write in read-only mode", "method", r.Method, "path", r.URL.Path)
+			http.Error(w, "the indexer is in read-only mode, try again after maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		next(w, r)
+	}
+}
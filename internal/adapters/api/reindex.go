@@ -2,25 +2,47 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
 // ReindexResponse represents the response for reindex operations
 type ReindexResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message,omitempty"`
+
+	// JobID identifies the background job of a reindex, see GET /api/jobs/{id}
+	JobID string `json:"jobId,omitempty"`
 }
 
-// HandleReindexAll handles the full reindex endpoint
+// SetReindexJobs makes the reindex endpoints run reindexes in the background, responding with the job
+// right away, and enables the job status endpoints
+func (a *Adapter) SetReindexJobs(jobs ports.ReindexJobs) {
+	a.jobs = jobs
+}
+
+// HandleReindexAll handles the full reindex endpoint. With ?force=true the reindex replaces the live
+// public index even if it shrinks by more than REINDEX_MAX_SHRINK_PERCENT.
 func (a *Adapter) HandleReindexAll(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("force") == "true" {
+		r = r.WithContext(domain.WithShrinkAllowed(r.Context()))
+	}
 	ctx := r.Context()
 
-	slog.Info("starting full reindex")
+	if a.jobs != nil {
+		a.startReindex(w, r, domain.JobScope{Kind: domain.JobKindReindexAll}, "full reindex started")
+		return
+	}
+
+	slog.InfoContext(ctx, "starting full reindex")
 
 	err := a.indexer.ReindexAll(ctx)
 	if err != nil {
-		slog.Error("failed to reindex all conferences", "error", err)
+		slog.ErrorContext(ctx, "failed to reindex all conferences", "error", err)
 		a.writeErrorResponse(w, "failed to reindex all conferences", err)
 		return
 	}
@@ -31,11 +53,16 @@ func (a *Adapter) HandleReindexAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	a.writeSuccessResponse(w, response)
-	slog.Info("full reindex completed successfully")
+	slog.InfoContext(ctx, "full reindex completed successfully")
 }
 
-// HandleReindexConference handles the reindex endpoint for a specific conference
+// HandleReindexConference handles the reindex endpoint for a specific conference. With ?force=true the
+// reindex removes talks deleted in the source even if that removes more than REINDEX_MAX_SHRINK_PERCENT
+// of the conference's talks.
 func (a *Adapter) HandleReindexConference(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("force") == "true" {
+		r = r.WithContext(domain.WithShrinkAllowed(r.Context()))
+	}
 	ctx := r.Context()
 
 	// Extract slug from path using Go 1.22+ path parameter feature
@@ -45,11 +72,16 @@ func (a *Adapter) HandleReindexConference(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	slog.Info("starting conference reindex", "slug", slug)
+	if a.jobs != nil {
+		a.startReindex(w, r, domain.JobScope{Kind: domain.JobKindReindexConference, Target: slug}, "conference reindex started: "+slug)
+		return
+	}
+
+	slog.InfoContext(ctx, "starting conference reindex", "slug", slug)
 
 	err := a.indexer.ReindexConference(ctx, slug)
 	if err != nil {
-		slog.Error("failed to reindex conference", "slug", slug, "error", err)
+		slog.ErrorContext(ctx, "failed to reindex conference", "slug", slug, "error", err)
 		a.writeErrorResponse(w, "failed to reindex conference", err)
 		return
 	}
@@ -60,7 +92,44 @@ func (a *Adapter) HandleReindexConference(w http.ResponseWriter, r *http.Request
 	}
 
 	a.writeSuccessResponse(w, response)
-	slog.Info("conference reindex completed successfully", "slug", slug)
+	slog.InfoContext(ctx, "conference reindex completed successfully", "slug", slug)
+}
+
+// HandleReindexConferenceByID handles the reindex endpoint for a specific conference given by its ID,
+// for when several conferences share a slug. It takes ?force=true like HandleReindexConference.
+func (a *Adapter) HandleReindexConferenceByID(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("force") == "true" {
+		r = r.WithContext(domain.WithShrinkAllowed(r.Context()))
+	}
+	ctx := r.Context()
+
+	conferenceID := r.PathValue("conferenceId")
+	if conferenceID == "" {
+		a.writeErrorResponse(w, "conference ID is required", nil)
+		return
+	}
+
+	if a.jobs != nil {
+		a.startReindex(w, r, domain.JobScope{Kind: domain.JobKindReindexConferenceID, Target: conferenceID}, "conference reindex started: "+conferenceID)
+		return
+	}
+
+	slog.InfoContext(ctx, "starting conference reindex", "conferenceID", conferenceID)
+
+	err := a.indexer.ReindexConferenceByID(ctx, conferenceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reindex conference", "conferenceID", conferenceID, "error", err)
+		a.writeErrorResponse(w, "failed to reindex conference", err)
+		return
+	}
+
+	response := ReindexResponse{
+		Status:  "success",
+		Message: "successfully reindexed conference: " + conferenceID,
+	}
+
+	a.writeSuccessResponse(w, response)
+	slog.InfoContext(ctx, "conference reindex completed successfully", "conferenceID", conferenceID)
 }
 
 // HandleReindexTalk handles the reindex endpoint for a specific talk
@@ -74,11 +143,16 @@ func (a *Adapter) HandleReindexTalk(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	slog.Info("starting talk reindex", "talkID", talkID)
+	if a.jobs != nil {
+		a.startReindex(w, r, domain.JobScope{Kind: domain.JobKindReindexTalk, Target: talkID}, "talk reindex started: "+talkID)
+		return
+	}
+
+	slog.InfoContext(ctx, "starting talk reindex", "talkID", talkID)
 
 	err := a.indexer.ReindexTalk(ctx, talkID)
 	if err != nil {
-		slog.Error("failed to reindex talk", "talkID", talkID, "error", err)
+		slog.ErrorContext(ctx, "failed to reindex talk", "talkID", talkID, "error", err)
 		a.writeErrorResponse(w, "failed to reindex talk", err)
 		return
 	}
@@ -89,7 +163,77 @@ func (a *Adapter) HandleReindexTalk(w http.ResponseWriter, r *http.Request) {
 	}
 
 	a.writeSuccessResponse(w, response)
-	slog.Info("talk reindex completed successfully", "talkID", talkID)
+	slog.InfoContext(ctx, "talk reindex completed successfully", "talkID", talkID)
+}
+
+// HandleDeleteTalk handles deleting a talk from both indexes, such as a talk withdrawn in moresleep.
+// The deletion runs right away, also when reindexes run in the background.
+func (a *Adapter) HandleDeleteTalk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	talkID := r.PathValue("talkId")
+	if talkID == "" {
+		a.writeErrorResponse(w, "talk ID is required", nil)
+		return
+	}
+
+	slog.InfoContext(ctx, "deleting talk", "talkID", talkID)
+
+	err := a.indexer.DeleteTalk(ctx, talkID)
+	if errors.Is(err, domain.ErrTalkNotFound) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		if err := json.NewEncoder(w).Encode(ReindexResponse{Status: "error", Message: err.Error()}); err != nil {
+			slog.ErrorContext(ctx, "failed to encode not found response", "error", err)
+		}
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete talk", "talkID", talkID, "error", err)
+		a.writeErrorResponse(w, "failed to delete talk", err)
+		return
+	}
+
+	response := ReindexResponse{
+		Status:  "success",
+		Message: "successfully deleted talk: " + talkID,
+	}
+
+	a.writeSuccessResponse(w, response)
+	slog.InfoContext(ctx, "talk deleted", "talkID", talkID)
+}
+
+// startReindex starts the reindex as a background job and responds with 202 Accepted and the job ID,
+// or with 409 Conflict and the ID of the running job when a reindex of the same scope is running
+func (a *Adapter) startReindex(w http.ResponseWriter, r *http.Request, scope domain.JobScope, message string) {
+	ctx := r.Context()
+
+	job, err := a.jobs.StartReindex(ctx, scope)
+	if errors.Is(err, domain.ErrJobRunning) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/jobs/"+job.ID)
+		w.WriteHeader(http.StatusConflict)
+
+		response := ReindexResponse{Status: "running", Message: err.Error(), JobID: job.ID}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			slog.ErrorContext(ctx, "failed to encode conflict response", "error", err)
+		}
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to start reindex", "kind", scope.Kind, "target", scope.Target, "error", err)
+		a.writeErrorResponse(w, "failed to start reindex", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/jobs/"+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+
+	response := ReindexResponse{Status: "accepted", Message: message, JobID: job.ID}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode accepted response", "error", err)
+	}
 }
 
 // writeSuccessResponse writes a successful JSON response
@@ -102,19 +246,25 @@ func (a *Adapter) writeSuccessResponse(w http.ResponseWriter, response ReindexRe
 	}
 }
 
-// writeErrorResponse writes an error JSON response
+// writeErrorResponse writes an error JSON response. A reindex refused because another of the same
+// scope is running is a conflict, like the refusal of a background reindex.
 func (a *Adapter) writeErrorResponse(w http.ResponseWriter, message string, err error) {
 	response := ReindexResponse{
 		Status:  "error",
 		Message: message,
 	}
 
+	statusCode := http.StatusInternalServerError
 	if err != nil {
 		response.Message = message + ": " + err.Error()
 	}
+	if errors.Is(err, domain.ErrJobRunning) {
+		response.Status = "running"
+		statusCode = http.StatusConflict
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusInternalServerError)
+	w.WriteHeader(statusCode)
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		slog.Error("failed to encode error response", "error", err)
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/javaBin/talks-indexer/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,7 +22,7 @@ func TestHandleReindexAll_Success(t *testing.T) {
 			return nil
 		},
 	}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	// Create request
 	req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
@@ -42,6 +44,27 @@ func TestHandleReindexAll_Success(t *testing.T) {
 	assert.Contains(t, response.Message, "successfully reindexed all conferences")
 }
 
+func TestHandleReindexAll_Force(t *testing.T) {
+	for query, want := range map[string]bool{"": false, "?force=true": true} {
+		t.Run(query, func(t *testing.T) {
+			var allowed bool
+			indexer := &mockIndexer{
+				reindexAllFunc: func(ctx context.Context) error {
+					allowed = domain.ShrinkAllowed(ctx)
+					return nil
+				},
+			}
+			adapter := New(testContext(), indexer, nil)
+
+			w := httptest.NewRecorder()
+			adapter.HandleReindexAll(w, httptest.NewRequest(http.MethodPost, "/api/reindex"+query, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, want, allowed)
+		})
+	}
+}
+
 func TestHandleReindexAll_Error(t *testing.T) {
 	expectedError := errors.New("indexing failed")
 
@@ -52,7 +75,7 @@ func TestHandleReindexAll_Error(t *testing.T) {
 			return expectedError
 		},
 	}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	// Create request
 	req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
@@ -86,7 +109,7 @@ func TestHandleReindexConference_Success(t *testing.T) {
 			return nil
 		},
 	}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	// Create request with slug path parameter
 	req := httptest.NewRequest(http.MethodPost, "/api/reindex/javazone-2024", nil)
@@ -113,10 +136,36 @@ func TestHandleReindexConference_Success(t *testing.T) {
 	assert.Contains(t, response.Message, "javazone-2024")
 }
 
+func TestHandleReindexConferenceByID_Success(t *testing.T) {
+	var capturedID string
+
+	indexer := &mockIndexer{
+		reindexConferenceByIDFunc: func(ctx context.Context, conferenceID string) error {
+			capturedID = conferenceID
+			return nil
+		},
+	}
+	adapter := New(testContext(), indexer, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/reindex/conference-id/conf-1", nil)
+	req.SetPathValue("conferenceId", "conf-1")
+	w := httptest.NewRecorder()
+
+	adapter.HandleReindexConferenceByID(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "conf-1", capturedID)
+
+	var response ReindexResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "success", response.Status)
+	assert.Contains(t, response.Message, "conf-1")
+}
+
 func TestHandleReindexConference_MissingSlug(t *testing.T) {
 	ctx := testContext()
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	// Create request without slug
 	req := httptest.NewRequest(http.MethodPost, "/api/reindex/", nil)
@@ -147,7 +196,7 @@ func TestHandleReindexConference_Error(t *testing.T) {
 			return expectedError
 		},
 	}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 
 	// Create request with slug
 	req := httptest.NewRequest(http.MethodPost, "/api/reindex/invalid-conf", nil)
@@ -173,7 +222,7 @@ func TestHandleReindexConference_Error(t *testing.T) {
 
 func TestWriteSuccessResponse(t *testing.T) {
 	ctx := testContext()
-	adapter := New(ctx, &mockIndexer{})
+	adapter := New(ctx, &mockIndexer{}, nil)
 	w := httptest.NewRecorder()
 
 	response := ReindexResponse{
@@ -196,7 +245,7 @@ func TestWriteSuccessResponse(t *testing.T) {
 
 func TestWriteErrorResponse(t *testing.T) {
 	ctx := testContext()
-	adapter := New(ctx, &mockIndexer{})
+	adapter := New(ctx, &mockIndexer{}, nil)
 	w := httptest.NewRecorder()
 
 	testError := errors.New("test error")
@@ -214,9 +263,29 @@ func TestWriteErrorResponse(t *testing.T) {
 	assert.Contains(t, response.Message, "test error")
 }
 
+func TestHandleReindexAll_AlreadyRunning(t *testing.T) {
+	indexer := &mockIndexer{
+		reindexAllFunc: func(ctx context.Context) error {
+			return fmt.Errorf("%w: job job-1", domain.ErrJobRunning)
+		},
+	}
+	adapter := New(testContext(), indexer, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/reindex", nil)
+	w := httptest.NewRecorder()
+
+	adapter.HandleReindexAll(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ReindexResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "running", response.Status)
+	assert.Contains(t, response.Message, "job-1")
+}
+
 func TestWriteErrorResponse_NoError(t *testing.T) {
 	ctx := testContext()
-	adapter := New(ctx, &mockIndexer{})
+	adapter := New(ctx, &mockIndexer{}, nil)
 	w := httptest.NewRecorder()
 
 	adapter.writeErrorResponse(w, "operation failed", nil)
@@ -230,3 +299,61 @@ func TestWriteErrorResponse_NoError(t *testing.T) {
 	assert.Equal(t, "error", response.Status)
 	assert.Equal(t, "operation failed", response.Message)
 }
+
+func TestHandleDeleteTalk(t *testing.T) {
+	var capturedTalkID string
+	indexer := &mockIndexer{
+		deleteTalkFunc: func(ctx context.Context, talkID string) error {
+			capturedTalkID = talkID
+			return nil
+		},
+	}
+	adapter := New(testContext(), indexer, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/talks/talk-123", nil)
+	req.SetPathValue("talkId", "talk-123")
+	w := httptest.NewRecorder()
+
+	adapter.HandleDeleteTalk(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "talk-123", capturedTalkID)
+
+	var response ReindexResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "success", response.Status)
+	assert.Contains(t, response.Message, "talk-123")
+}
+
+func TestHandleDeleteTalk_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"talk in neither index", fmt.Errorf("%w: talk-123", domain.ErrTalkNotFound), http.StatusNotFound},
+		{"index error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := &mockIndexer{
+				deleteTalkFunc: func(ctx context.Context, talkID string) error {
+					return tt.err
+				},
+			}
+			adapter := New(testContext(), indexer, nil)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/talks/talk-123", nil)
+			req.SetPathValue("talkId", "talk-123")
+			w := httptest.NewRecorder()
+
+			adapter.HandleDeleteTalk(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var response ReindexResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+			assert.Equal(t, "error", response.Status)
+		})
+	}
+}
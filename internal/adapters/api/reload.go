@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetConfigReloader enables reloading the configuration through the API
+func (a *Adapter) SetConfigReloader(reloader ports.ConfigReloader) {
+	a.reloader = reloader
+}
+
+// HandleReloadConfig reads the configuration again and rebuilds the services from it, like sending
+// SIGHUP to the process. The response lists the names of the changed settings. Changes to settings that
+// need a restart are refused with 409, keeping the running configuration.
+func (a *Adapter) HandleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reload, err := a.reloader.ReloadConfig(ctx)
+	switch {
+	case errors.Is(err, domain.ErrRestartRequired):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "configuration reload failed", "error", err)
+		http.Error(w, "configuration reload failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(reload); err != nil {
+		slog.ErrorContext(ctx, "failed to encode reload response", "error", err)
+	}
+}
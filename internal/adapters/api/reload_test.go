@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockConfigReloader is a mock implementation of the ConfigReloader interface for testing
+type mockConfigReloader struct {
+	reloadConfigFunc func(ctx context.Context) (domain.ConfigReload, error)
+}
+
+func (m *mockConfigReloader) ReloadConfig(ctx context.Context) (domain.ConfigReload, error) {
+	return m.reloadConfigFunc(ctx)
+}
+
+// newReloadTestMux registers the reload endpoint for a user with the role, with a reloader returning err
+func newReloadTestMux(role domain.Role, err error) *http.ServeMux {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetConfigReloader(&mockConfigReloader{
+		reloadConfigFunc: func(ctx context.Context) (domain.ConfigReload, error) {
+			return domain.ConfigReload{Changed: []string{"INDEX_PREFIX"}, ReloadedAt: time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)}, err
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterAuthenticatedRoutes(mux, withRole(role))
+	return mux
+}
+
+func TestHandleReloadConfig(t *testing.T) {
+	mux := newReloadTestMux(domain.RoleAdmin, nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/config/reload", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var reload domain.ConfigReload
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reload))
+	assert.Equal(t, []string{"INDEX_PREFIX"}, reload.Changed)
+}
+
+func TestHandleReloadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       domain.Role
+		err        error
+		wantStatus int
+	}{
+		{"operator", domain.RoleOperator, nil, http.StatusForbidden},
+		{"restart required", domain.RoleAdmin, fmt.Errorf("%w: HTTP_PORT", domain.ErrRestartRequired), http.StatusConflict},
+		{"invalid configuration", domain.RoleAdmin, errors.New("failed to parse configuration"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := newReloadTestMux(tt.role, tt.err)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/config/reload", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -6,18 +6,60 @@ import (
 )
 
 // RegisterRoutes registers all API routes with the provided mux.
-// Health check is always available. API routes are only registered in development mode.
+// Health check and public read endpoints are always available, the search facet endpoint once
+// SetSearchFacets is called, the talk suggestion endpoint once SetSuggester is called and the dataset
+// version and change endpoints once SetDatasetVersions is called.
+// Reindex, talk deletion and job routes are registered in development mode, and in production mode when
+// API tokens are configured for machine callers or can be created in the admin UI, along with speaker
+// preview links once SetTalkPreviews is called. Call it after SetTokenAuthenticator.
 func (a *Adapter) RegisterRoutes(mux *http.ServeMux) {
-	// Health check is always available
+	// Health check is always available, along with the liveness and readiness probes
 	mux.HandleFunc("GET /health", a.HandleHealth)
+	mux.HandleFunc("GET /livez", a.HandleLiveness)
+	mux.HandleFunc("GET /readyz", a.HandleReadiness)
+	mux.HandleFunc("GET /health/ready", a.HandleReadiness)
 
-	// API routes only available in development mode
-	if a.cfg.Mode.IsDevelopment() {
-		mux.HandleFunc("POST /api/reindex", a.HandleReindexAll)
-		mux.HandleFunc("POST /api/reindex/conference/{slug}", a.HandleReindexConference)
-		mux.HandleFunc("POST /api/reindex/talk/{talkId}", a.HandleReindexTalk)
-		slog.Info("API routes enabled (development mode)")
+	// Request metrics are limited to the callers that may see detailed health output
+	mux.HandleFunc("GET /metrics", a.HandleMetrics)
+
+	// Public read endpoints serve data from the public index only
+	mux.HandleFunc("GET /api/conferences", a.HandleListConferences)
+	mux.HandleFunc("GET /public/allSessions/{conferenceSlug}", a.HandleLegacyAllSessions)
+	mux.HandleFunc("GET /public/signing-key", a.HandleSigningKey)
+	if a.facets != nil {
+		mux.HandleFunc("GET /api/search/facets", a.HandleSearchFacets)
+	}
+	if a.suggester != nil {
+		mux.HandleFunc("GET /api/suggest", a.HandleSuggest)
+	}
+	if a.datasets != nil {
+		mux.HandleFunc("GET /api/conferences/{slug}/dataset-version", a.HandleDatasetVersion)
+		mux.HandleFunc("GET /api/conferences/{slug}/changes", a.HandleDatasetChanges)
+	}
+
+	// Inbound webhooks are signed with WEBHOOK_SECRET, so they are available in every mode
+	mux.HandleFunc("POST /webhooks/moresleep", a.writable(a.verifiedWebhook(a.HandleMoresleepWebhook)))
+
+	// Reindex, talk deletion and job routes, refused in read-only mode. In production they require an API token.
+	if a.cfg.Mode.IsDevelopment() || a.requiresToken() {
+		mux.HandleFunc("POST /api/reindex", a.writable(a.withAPIActor(a.idempotent(a.HandleReindexAll))))
+		mux.HandleFunc("POST /api/reindex/conference/{slug}", a.writable(a.withAPIActor(a.idempotent(a.HandleReindexConference))))
+		mux.HandleFunc("POST /api/reindex/conference-id/{conferenceId}", a.writable(a.withAPIActor(a.idempotent(a.HandleReindexConferenceByID))))
+		mux.HandleFunc("POST /api/reindex/talk/{talkId}", a.writable(a.withAPIActor(a.idempotent(a.HandleReindexTalk))))
+		mux.HandleFunc("DELETE /api/talks/{talkId}", a.writable(a.withAPIActor(a.idempotent(a.HandleDeleteTalk))))
+		if a.previews != nil {
+			mux.HandleFunc("POST /api/talks/{talkId}/preview-link", a.withAPIActor(a.HandleCreatePreviewLink))
+		}
+		if a.jobs != nil {
+			mux.HandleFunc("GET /api/jobs", a.withAPIActor(a.HandleListJobs))
+			mux.HandleFunc("GET /api/jobs/{id}", a.withAPIActor(a.HandleGetJob))
+		}
+		if a.requiresToken() {
+			slog.Info("API routes enabled with token authentication", "tokens", len(a.tokens), "storedTokens", a.storedTokens != nil)
+		} else {
+			slog.Info("API routes enabled (development mode)")
+		}
 	} else {
-		slog.Info("API routes disabled (production mode)")
+		slog.Info("API routes disabled (production mode without API tokens)")
 	}
 }
@@ -32,7 +32,7 @@ func testConfigProduction() *config.Config {
 func TestRegisterRoutes_DevelopmentMode(t *testing.T) {
 	ctx := config.WithConfig(context.Background(), testConfigDevelopment())
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 	mux := http.NewServeMux()
 
 	adapter.RegisterRoutes(mux)
@@ -50,6 +50,24 @@ func TestRegisterRoutes_DevelopmentMode(t *testing.T) {
 			path:           "/health",
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "GET /livez",
+			method:         http.MethodGet,
+			path:           "/livez",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GET /readyz",
+			method:         http.MethodGet,
+			path:           "/readyz",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GET /health/ready",
+			method:         http.MethodGet,
+			path:           "/health/ready",
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:           "POST /api/reindex",
 			method:         http.MethodPost,
@@ -68,6 +86,12 @@ func TestRegisterRoutes_DevelopmentMode(t *testing.T) {
 			path:           "/api/reindex/talk/test-talk-id",
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "DELETE /api/talks/{talkId}",
+			method:         http.MethodDelete,
+			path:           "/api/talks/test-talk-id",
+			expectedStatus: http.StatusOK,
+		},
 	}
 
 	for _, tt := range tests {
@@ -90,7 +114,7 @@ func TestRegisterRoutes_DevelopmentMode(t *testing.T) {
 func TestRegisterRoutes_ProductionMode(t *testing.T) {
 	ctx := config.WithConfig(context.Background(), testConfigProduction())
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 	mux := http.NewServeMux()
 
 	adapter.RegisterRoutes(mux)
@@ -103,6 +127,29 @@ func TestRegisterRoutes_ProductionMode(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	// Public read endpoints should still be available
+	t.Run("GET /public/allSessions/{conferenceSlug} is available", func(t *testing.T) {
+		prodAdapter := New(ctx, indexer, &mockTalkReader{})
+		prodMux := http.NewServeMux()
+		prodAdapter.RegisterRoutes(prodMux)
+
+		req := httptest.NewRequest(http.MethodGet, "/public/allSessions/javazone2024", nil)
+		w := httptest.NewRecorder()
+		prodMux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("GET /api/conferences is available", func(t *testing.T) {
+		prodAdapter := New(ctx, indexer, &mockTalkReader{})
+		prodMux := http.NewServeMux()
+		prodAdapter.RegisterRoutes(prodMux)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/conferences", nil)
+		w := httptest.NewRecorder()
+		prodMux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
 	// API routes should NOT be available in production mode
 	apiRoutes := []struct {
 		name   string
@@ -112,6 +159,7 @@ func TestRegisterRoutes_ProductionMode(t *testing.T) {
 		{"POST /api/reindex", http.MethodPost, "/api/reindex"},
 		{"POST /api/reindex/conference/{slug}", http.MethodPost, "/api/reindex/conference/test-conf"},
 		{"POST /api/reindex/talk/{talkId}", http.MethodPost, "/api/reindex/talk/test-talk-id"},
+		{"DELETE /api/talks/{talkId}", http.MethodDelete, "/api/talks/test-talk-id"},
 	}
 
 	for _, tt := range apiRoutes {
@@ -124,10 +172,35 @@ func TestRegisterRoutes_ProductionMode(t *testing.T) {
 	}
 }
 
+func TestRegisterRoutes_ProductionModeWithTokens(t *testing.T) {
+	cfg := testConfigProduction()
+	cfg.API.Tokens = map[string]string{"ci": "s3cret"}
+	ctx := config.WithConfig(context.Background(), cfg)
+	adapter := New(ctx, &mockIndexer{}, nil)
+	mux := http.NewServeMux()
+
+	adapter.RegisterRoutes(mux)
+
+	t.Run("without token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/reindex", strings.NewReader("{}"))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("with token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/reindex", strings.NewReader("{}"))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
 func TestRegisterRoutes_MethodNotAllowed(t *testing.T) {
 	ctx := config.WithConfig(context.Background(), testConfigDevelopment())
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 	mux := http.NewServeMux()
 
 	adapter.RegisterRoutes(mux)
@@ -165,7 +238,7 @@ func TestRegisterRoutes_MethodNotAllowed(t *testing.T) {
 func TestRegisterRoutes_NotFound(t *testing.T) {
 	ctx := config.WithConfig(context.Background(), testConfigDevelopment())
 	indexer := &mockIndexer{}
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 	mux := http.NewServeMux()
 
 	adapter.RegisterRoutes(mux)
@@ -198,7 +271,7 @@ func TestRegisterRoutes_Integration(t *testing.T) {
 		},
 	}
 
-	adapter := New(ctx, indexer)
+	adapter := New(ctx, indexer, nil)
 	mux := http.NewServeMux()
 	adapter.RegisterRoutes(mux)
 
@@ -219,3 +292,45 @@ func TestRegisterRoutes_Integration(t *testing.T) {
 	assert.Equal(t, "javazone-2024", reindexConferenceSlug)
 	assert.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestRegisterRoutes_ReadOnlyMode(t *testing.T) {
+	cfg := testConfigDevelopment()
+	cfg.ReadOnly = true
+	ctx := config.WithConfig(context.Background(), cfg)
+
+	indexer := &mockIndexer{
+		reindexAllFunc: func(ctx context.Context) error {
+			t.Error("reindex must not run in read-only mode")
+			return nil
+		},
+		reindexConferenceFunc: func(ctx context.Context, slug string) error {
+			t.Error("reindex must not run in read-only mode")
+			return nil
+		},
+		reindexTalkFunc: func(ctx context.Context, talkID string) error {
+			t.Error("reindex must not run in read-only mode")
+			return nil
+		},
+	}
+	adapter := New(ctx, indexer, nil)
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	for _, path := range []string{"/api/reindex", "/api/reindex/conference/javazone-2024", "/api/reindex/talk/test-talk-id"} {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		})
+	}
+
+	t.Run("health stays available", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
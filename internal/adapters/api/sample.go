@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetSampler enables the index sample endpoint
+func (a *Adapter) SetSampler(sampler ports.Sampler) {
+	a.sampler = sampler
+}
+
+// HandleSample returns a few random documents of the private or public index.
+// The optional n parameter sets the number of documents and conference limits them to one conference.
+func (a *Adapter) HandleSample(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := domain.SampleRequest{
+		IndexName:      r.PathValue("name"),
+		ConferenceSlug: r.URL.Query().Get("conference"),
+	}
+	if n := r.URL.Query().Get("n"); n != "" {
+		size, err := strconv.Atoi(n)
+		if err != nil || size < 1 {
+			http.Error(w, "n must be a positive number", http.StatusBadRequest)
+			return
+		}
+		req.Size = size
+	}
+
+	result, err := a.sampler.Sample(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrIndexNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, domain.ErrInvalidQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "index sample failed", "index", req.IndexName, "error", err)
+		http.Error(w, "sample failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.ErrorContext(ctx, "failed to encode sample response", "error", err)
+	}
+}
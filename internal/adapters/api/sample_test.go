@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSampler is a mock implementation of the Sampler interface for testing
+type mockSampler struct {
+	sampleFunc func(ctx context.Context, req domain.SampleRequest) (domain.QueryResult, error)
+}
+
+func (m *mockSampler) Sample(ctx context.Context, req domain.SampleRequest) (domain.QueryResult, error) {
+	return m.sampleFunc(ctx, req)
+}
+
+func TestHandleSample(t *testing.T) {
+	var captured domain.SampleRequest
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetSampler(&mockSampler{
+		sampleFunc: func(ctx context.Context, req domain.SampleRequest) (domain.QueryResult, error) {
+			captured = req
+			return domain.QueryResult{Total: 120, Hits: []json.RawMessage{json.RawMessage(`{"id":"talk-1"}`)}}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleOperator))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/indexes/public/sample?n=3&conference=javazone2024", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.SampleRequest{IndexName: "public", Size: 3, ConferenceSlug: "javazone2024"}, captured)
+
+	var result domain.QueryResult
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal(t, 120, result.Total)
+	require.Len(t, result.Hits, 1)
+}
+
+func TestHandleSample_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid n", "/api/indexes/public/sample?n=many", nil, http.StatusBadRequest},
+		{"unknown index", "/api/indexes/other/sample", fmt.Errorf("%w: other", domain.ErrIndexNotFound), http.StatusNotFound},
+		{"n too large", "/api/indexes/public/sample?n=500", fmt.Errorf("%w: n must be between 1 and 50", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"index error", "/api/indexes/public/sample", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetSampler(&mockSampler{
+				sampleFunc: func(ctx context.Context, req domain.SampleRequest) (domain.QueryResult, error) {
+					return domain.QueryResult{}, tt.err
+				},
+			})
+			mux := http.NewServeMux()
+			adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleOperator))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	t.Run("requires operator role", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		adapter.SetSampler(&mockSampler{
+			sampleFunc: func(ctx context.Context, req domain.SampleRequest) (domain.QueryResult, error) {
+				t.Error("sample must not run for viewers")
+				return domain.QueryResult{}, nil
+			},
+		})
+		mux := http.NewServeMux()
+		adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleViewer))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/indexes/private/sample", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
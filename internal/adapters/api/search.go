@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetSearcher enables the talk search endpoint. The authorized function reports whether a request
+// belongs to a logged-in user, who searches the private index instead of the public one.
+func (a *Adapter) SetSearcher(searcher ports.Searcher, authorized func(r *http.Request) bool) {
+	a.searcher = searcher
+	a.searchAuthorized = authorized
+}
+
+// Default and maximum number of hits on a page of search results
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// HandleSearch runs a full-text search for talks. The q parameter is the search text, conference, status
+// and series filter the hits, and size and cursor page through them. Logged-in users can also filter on
+// the talks assigned to a reviewer, with pending=true for those the reviewer has not given feedback on,
+// and rank the hits by their review score with sort=reviewScore.
+func (a *Adapter) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	page, err := parsePage(r, defaultSearchPageSize, maxSearchPageSize)
+	if err != nil {
+		writeListError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := domain.SearchRequest{
+		Text:           query.Get("q"),
+		ConferenceSlug: query.Get("conference"),
+		Status:         query.Get("status"),
+		SeriesID:       query.Get("series"),
+		Reviewer:       query.Get("reviewer"),
+		ReviewPending:  query.Get("pending") == "true",
+		Private:        a.searchAuthorized != nil && a.searchAuthorized(r),
+		Sort:           query.Get("sort"),
+		Size:           page.Size,
+		From:           page.Offset,
+	}
+
+	result, err := a.searcher.Search(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		writeListError(w, r, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "talk search failed", "error", err)
+		writeListError(w, r, http.StatusInternalServerError, "search failed")
+		return
+	}
+
+	// Hits of the private index must not end up in shared caches, and the index searched depends on the session
+	w.Header().Set("Vary", "Cookie")
+	if req.Private {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	writeList(w, r, result.Hits, page.meta(&result.Total, page.Offset+len(result.Hits) < result.Total))
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSearcher is a mock implementation of the Searcher interface for testing
+type mockSearcher struct {
+	searchFunc func(ctx context.Context, req domain.SearchRequest) (domain.QueryResult, error)
+}
+
+func (m *mockSearcher) Search(ctx context.Context, req domain.SearchRequest) (domain.QueryResult, error) {
+	return m.searchFunc(ctx, req)
+}
+
+// newSearchTestMux registers the search endpoint with a searcher returning err, capturing the requests
+func newSearchTestMux(loggedIn bool, err error, captured *domain.SearchRequest) *http.ServeMux {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetSearcher(&mockSearcher{
+		searchFunc: func(ctx context.Context, req domain.SearchRequest) (domain.QueryResult, error) {
+			*captured = req
+			return domain.QueryResult{Total: 1, Hits: []json.RawMessage{json.RawMessage(`{"id":"talk-1"}`)}}, err
+		},
+	}, func(r *http.Request) bool { return loggedIn })
+	mux := http.NewServeMux()
+	adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleViewer))
+	return mux
+}
+
+func TestHandleSearch(t *testing.T) {
+	t.Run("anonymous search of the public index", func(t *testing.T) {
+		var captured domain.SearchRequest
+		mux := newSearchTestMux(false, nil, &captured)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/search?q=kotlin&conference=javazone2024&status=APPROVED&series=kotlin-workshop&size=5&cursor="+encodeCursor(10), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.SearchRequest{Text: "kotlin", ConferenceSlug: "javazone2024", Status: "APPROVED", SeriesID: "kotlin-workshop", Size: 5, From: 10}, captured)
+		assert.Empty(t, w.Header().Get("Cache-Control"))
+
+		hits, meta := decodeList[json.RawMessage](t, w)
+		assert.Len(t, hits, 1)
+		assert.Equal(t, ListMeta{Page: 3, Size: 5, Total: intPtr(1)}, meta)
+	})
+
+	t.Run("logged-in users search the private index", func(t *testing.T) {
+		var captured domain.SearchRequest
+		mux := newSearchTestMux(true, nil, &captured)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/search?q=kotlin", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, captured.Private)
+		assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("talks pending review by a reviewer", func(t *testing.T) {
+		var captured domain.SearchRequest
+		mux := newSearchTestMux(true, nil, &captured)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/search?reviewer=ada@javazone.no&pending=true", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.SearchRequest{Reviewer: "ada@javazone.no", ReviewPending: true, Private: true, Size: 20}, captured)
+	})
+
+	t.Run("ranked by review score", func(t *testing.T) {
+		var captured domain.SearchRequest
+		mux := newSearchTestMux(true, nil, &captured)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/search?conference=javazone2024&sort=reviewScore", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, domain.SearchRequest{ConferenceSlug: "javazone2024", Private: true, Sort: domain.SearchSortReviewScore, Size: 20}, captured)
+	})
+}
+
+func TestHandleSearch_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid size", "/api/search?size=many", nil, http.StatusBadRequest},
+		{"invalid cursor", "/api/search?cursor=first", nil, http.StatusBadRequest},
+		{"size too large", "/api/search?size=500", nil, http.StatusBadRequest},
+		{"too deep", "/api/search?cursor=" + encodeCursor(10000), fmt.Errorf("%w: from must be at least 0 and from + size at most 10000", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"reviewer without login", "/api/search?reviewer=ada@javazone.no", fmt.Errorf("%w: review assignments can only be searched by logged-in users", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"review score sort without login", "/api/search?sort=reviewScore", fmt.Errorf("%w: review scores can only be sorted on by logged-in users", domain.ErrInvalidQuery), http.StatusBadRequest},
+		{"index error", "/api/search?q=kotlin", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured domain.SearchRequest
+			mux := newSearchTestMux(false, tt.err, &captured)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
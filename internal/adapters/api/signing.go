@@ -0,0 +1,54 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetSigner enables signing of the public snapshot feeds and the signing key endpoint
+func (a *Adapter) SetSigner(signer ports.ContentSigner) {
+	a.signer = signer
+}
+
+// HandleSigningKey publishes the public key used to verify signed feeds
+func (a *Adapter) HandleSigningKey(w http.ResponseWriter, r *http.Request) {
+	if a.signer == nil {
+		http.Error(w, "content signing is not configured", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(a.signer.PublicKey()); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode signing key", "error", err)
+	}
+}
+
+// writeSignedJSON writes the value as a JSON response. When a signer is configured the body is
+// buffered so its detached signature can be sent in the response headers.
+func (a *Adapter) writeSignedJSON(w http.ResponseWriter, r *http.Request, value interface{}) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(value); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	if a.signer != nil {
+		w.Header().Set(domain.SignatureHeader, a.signer.Sign(body.Bytes()))
+		w.Header().Set(domain.SignatureKeyIDHeader, a.signer.PublicKey().KeyID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(body.Bytes()); err != nil {
+		slog.ErrorContext(r.Context(), "failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSigner is a mock implementation of ports.ContentSigner that records signed payloads
+type mockSigner struct {
+	signed []byte
+}
+
+func (m *mockSigner) Sign(payload []byte) string {
+	m.signed = append([]byte(nil), payload...)
+	return "signature"
+}
+
+func (m *mockSigner) PublicKey() domain.SigningKey {
+	return domain.SigningKey{KeyID: "key-1", Algorithm: domain.SigningAlgorithmEd25519, PublicKey: "cHVibGlj"}
+}
+
+func TestHandleSigningKey(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+
+		w := httptest.NewRecorder()
+		adapter.HandleSigningKey(w, httptest.NewRequest(http.MethodGet, "/public/signing-key", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		adapter.SetSigner(&mockSigner{})
+
+		w := httptest.NewRecorder()
+		adapter.HandleSigningKey(w, httptest.NewRequest(http.MethodGet, "/public/signing-key", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var key domain.SigningKey
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&key))
+		assert.Equal(t, "key-1", key.KeyID)
+		assert.Equal(t, domain.SigningAlgorithmEd25519, key.Algorithm)
+	})
+}
+
+func TestHandleLegacyAllSessions_Signed(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-1", Data: map[string]interface{}{"title": "Virtual threads"}}}, nil
+		},
+	}
+	signer := &mockSigner{}
+	adapter := New(testContext(), &mockIndexer{}, reader)
+	adapter.SetSigner(signer)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/allSessions/javazone2024", nil)
+	req.SetPathValue("conferenceSlug", "javazone2024")
+	w := httptest.NewRecorder()
+
+	adapter.HandleLegacyAllSessions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "signature", w.Header().Get(domain.SignatureHeader))
+	assert.Equal(t, "key-1", w.Header().Get(domain.SignatureKeyIDHeader))
+	assert.Equal(t, w.Body.Bytes(), signer.signed, "signature must cover the exact response body")
+}
+
+func TestHandleLegacyAllSessions_Unsigned(t *testing.T) {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+
+	req := httptest.NewRequest(http.MethodGet, "/public/allSessions/javazone2024", nil)
+	req.SetPathValue("conferenceSlug", "javazone2024")
+	w := httptest.NewRecorder()
+
+	adapter.HandleLegacyAllSessions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(domain.SignatureHeader))
+}
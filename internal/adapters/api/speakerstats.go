@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetSpeakerStatistics enables the private speaker statistics endpoint
+func (a *Adapter) SetSpeakerStatistics(speakerStats ports.SpeakerStatisticsReporter) {
+	a.speakerStats = speakerStats
+}
+
+// HandleSpeakerStatistics returns the speaker attributes aggregated from the private index.
+// The optional conference parameter limits them to one conference and the status parameter, repeated
+// or comma-separated, to talks with those statuses. The response holds private data and is never cached.
+func (a *Adapter) HandleSpeakerStatistics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := domain.SpeakerStatisticsRequest{ConferenceSlug: r.URL.Query().Get("conference")}
+	for _, param := range r.URL.Query()["status"] {
+		req.Statuses = append(req.Statuses, strings.Split(param, ",")...)
+	}
+
+	stats, err := a.speakerStats.SpeakerStatistics(ctx, req)
+	if err != nil {
+		slog.ErrorContext(ctx, "speaker statistics failed", "conference", req.ConferenceSlug, "error", err)
+		http.Error(w, "speaker statistics failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		slog.ErrorContext(ctx, "failed to encode speaker statistics response", "error", err)
+	}
+}
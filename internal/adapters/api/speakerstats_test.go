@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSpeakerStatistics is a mock implementation of the SpeakerStatisticsReporter interface for testing
+type mockSpeakerStatistics struct {
+	speakerStatisticsFunc func(ctx context.Context, req domain.SpeakerStatisticsRequest) (*domain.SpeakerStatistics, error)
+}
+
+func (m *mockSpeakerStatistics) SpeakerStatistics(ctx context.Context, req domain.SpeakerStatisticsRequest) (*domain.SpeakerStatistics, error) {
+	return m.speakerStatisticsFunc(ctx, req)
+}
+
+func TestHandleSpeakerStatistics(t *testing.T) {
+	var captured domain.SpeakerStatisticsRequest
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetSpeakerStatistics(&mockSpeakerStatistics{
+		speakerStatisticsFunc: func(ctx context.Context, req domain.SpeakerStatisticsRequest) (*domain.SpeakerStatistics, error) {
+			captured = req
+			return &domain.SpeakerStatistics{
+				Index:      "private",
+				Speakers:   12,
+				Attributes: []domain.SpeakerAttributeCounts{{Field: "residence", Values: map[string]int{"oslo": 7}, Other: 5}},
+			}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleAdmin))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/speakers?conference=javazone2024&status=APPROVED,SUBMITTED", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+	assert.Equal(t, domain.SpeakerStatisticsRequest{ConferenceSlug: "javazone2024", Statuses: []string{"APPROVED", "SUBMITTED"}}, captured)
+
+	var stats domain.SpeakerStatistics
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&stats))
+	assert.Equal(t, 12, stats.Speakers)
+	require.Len(t, stats.Attributes, 1)
+	assert.Equal(t, 7, stats.Attributes[0].Values["oslo"])
+}
+
+func TestHandleSpeakerStatistics_Errors(t *testing.T) {
+	t.Run("reader error", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		adapter.SetSpeakerStatistics(&mockSpeakerStatistics{
+			speakerStatisticsFunc: func(ctx context.Context, req domain.SpeakerStatisticsRequest) (*domain.SpeakerStatistics, error) {
+				return nil, errors.New("connection refused")
+			},
+		})
+		mux := http.NewServeMux()
+		adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleAdmin))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/analytics/speakers", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("requires admin role", func(t *testing.T) {
+		adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+		adapter.SetSpeakerStatistics(&mockSpeakerStatistics{
+			speakerStatisticsFunc: func(ctx context.Context, req domain.SpeakerStatisticsRequest) (*domain.SpeakerStatistics, error) {
+				t.Error("speaker statistics must not be built for operators")
+				return nil, nil
+			},
+		})
+		mux := http.NewServeMux()
+		adapter.RegisterAuthenticatedRoutes(mux, withRole(domain.RoleOperator))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/analytics/speakers", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
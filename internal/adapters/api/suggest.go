@@ -0,0 +1,57 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SuggestResponse is the response of the talk suggestion endpoint
+type SuggestResponse struct {
+	Suggestions []string `json:"suggestions"`
+}
+
+// SetSuggester enables the talk suggestion endpoint
+func (a *Adapter) SetSuggester(suggester ports.Suggester) {
+	a.suggester = suggester
+}
+
+// HandleSuggest returns talk titles, speaker names and keywords of public talks starting with the q
+// parameter, for typeahead in the public search. The optional size parameter bounds the number of
+// suggestions. Responses are cached like the other public endpoints.
+func (a *Adapter) HandleSuggest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	req := domain.SuggestRequest{Prefix: query.Get("q")}
+	if size := query.Get("size"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 {
+			http.Error(w, "size must be a positive number", http.StatusBadRequest)
+			return
+		}
+		req.Size = n
+	}
+
+	if a.checkNotModified(w, r, a.cfg.Index.PublicName()) {
+		writeNotModified(w)
+		return
+	}
+
+	suggestions, err := a.suggester.Suggest(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to suggest talks", "error", err)
+		http.Error(w, "failed to suggest talks", http.StatusInternalServerError)
+		return
+	}
+
+	a.writeSignedJSON(w, r, SuggestResponse{Suggestions: suggestions})
+}
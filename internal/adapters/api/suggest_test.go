@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSuggester is a mock implementation of the Suggester interface for testing
+type mockSuggester struct {
+	suggestFunc func(ctx context.Context, req domain.SuggestRequest) ([]string, error)
+}
+
+func (m *mockSuggester) Suggest(ctx context.Context, req domain.SuggestRequest) ([]string, error) {
+	return m.suggestFunc(ctx, req)
+}
+
+func TestHandleSuggest(t *testing.T) {
+	var captured domain.SuggestRequest
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	adapter.SetSuggester(&mockSuggester{
+		suggestFunc: func(ctx context.Context, req domain.SuggestRequest) ([]string, error) {
+			captured = req
+			return []string{"Kotlin for Java developers", "kotlin"}, nil
+		},
+	})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/suggest?q=kot&size=5", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.SuggestRequest{Prefix: "kot", Size: 5}, captured)
+	assert.Equal(t, `"gen-1"`, w.Header().Get("ETag"))
+
+	var response SuggestResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, []string{"Kotlin for Java developers", "kotlin"}, response.Suggestions)
+
+	t.Run("not modified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/suggest?q=kot", nil)
+		req.Header.Set("If-None-Match", `"gen-1"`)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+}
+
+func TestHandleSuggest_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid size", "/api/suggest?q=kot&size=all", nil, http.StatusBadRequest},
+		{"missing prefix", "/api/suggest", domain.ErrInvalidQuery, http.StatusBadRequest},
+		{"index error", "/api/suggest?q=kot", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+			adapter.SetSuggester(&mockSuggester{
+				suggestFunc: func(ctx context.Context, req domain.SuggestRequest) ([]string, error) {
+					return nil, tt.err
+				},
+			})
+			mux := http.NewServeMux()
+			adapter.RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRegisterRoutes_SuggestOnlyWhenSet(t *testing.T) {
+	adapter := New(testContext(), &mockIndexer{}, &mockTalkReader{})
+	mux := http.NewServeMux()
+	adapter.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/suggest?q=kot", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// Webhook rejection reasons, logged with every rejected request
+const (
+	webhookReasonMissingSignature   = "missing_signature"
+	webhookReasonMalformedSignature = "malformed_signature"
+	webhookReasonSignatureMismatch  = "signature_mismatch"
+	webhookReasonMissingTimestamp   = "missing_timestamp"
+	webhookReasonMalformedTimestamp = "malformed_timestamp"
+	webhookReasonStaleTimestamp     = "timestamp_outside_tolerance"
+	webhookReasonReplayed           = "replayed"
+)
+
+// webhookRejection is returned by the verifier when a webhook request must be rejected
+type webhookRejection struct {
+	reason string
+}
+
+func (e *webhookRejection) Error() string {
+	return "webhook rejected: " + e.reason
+}
+
+// webhookVerifier checks HMAC-SHA256 signatures of inbound webhooks and rejects replays.
+// Signatures already accepted are remembered until their timestamp leaves the tolerance window,
+// so a captured request cannot be replayed while its timestamp is still valid.
+type webhookVerifier struct {
+	cfg config.WebhookConfig
+	now func() time.Time
+
+	seen *acceptedSignatures
+}
+
+// acceptedSignatures holds the signatures of accepted webhooks until their timestamp expires
+type acceptedSignatures struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+}
+
+// newWebhookVerifier creates a verifier for the given configuration
+func newWebhookVerifier(cfg config.WebhookConfig) *webhookVerifier {
+	return &webhookVerifier{
+		cfg:  cfg,
+		now:  time.Now,
+		seen: &acceptedSignatures{expires: make(map[string]time.Time)},
+	}
+}
+
+// sign computes the HMAC of the signed payload: "<timestamp>.<body>", or the body when timestamp is empty
+func (v *webhookVerifier) sign(timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(v.cfg.Secret))
+	if timestamp != "" {
+		mac.Write([]byte(timestamp))
+		mac.Write([]byte("."))
+	}
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// decodeSignature strips the configured prefix and decodes the signature
+func (v *webhookVerifier) decodeSignature(header string) ([]byte, error) {
+	value, ok := strings.CutPrefix(strings.TrimSpace(header), v.cfg.SignaturePrefix)
+	if !ok {
+		return nil, errors.New("missing signature prefix")
+	}
+	if v.cfg.SignatureEncoding == config.WebhookEncodingBase64 {
+		return base64.StdEncoding.DecodeString(value)
+	}
+	return hex.DecodeString(value)
+}
+
+// verify checks the signature and timestamp headers of a request against its body
+func (v *webhookVerifier) verify(header http.Header, body []byte) error {
+	signatureHeader := header.Get(v.cfg.SignatureHeader)
+	if signatureHeader == "" {
+		return &webhookRejection{reason: webhookReasonMissingSignature}
+	}
+	signature, err := v.decodeSignature(signatureHeader)
+	if err != nil {
+		return &webhookRejection{reason: webhookReasonMalformedSignature}
+	}
+
+	var timestamp string
+	var signedAt time.Time
+	if v.cfg.RequireTimestamp {
+		timestamp = header.Get(v.cfg.TimestampHeader)
+		if timestamp == "" {
+			return &webhookRejection{reason: webhookReasonMissingTimestamp}
+		}
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return &webhookRejection{reason: webhookReasonMalformedTimestamp}
+		}
+		signedAt = time.Unix(seconds, 0)
+		if skew := v.now().Sub(signedAt).Abs(); skew > v.cfg.TimestampTolerance {
+			return &webhookRejection{reason: webhookReasonStaleTimestamp}
+		}
+	}
+
+	if !hmac.Equal(signature, v.sign(timestamp, body)) {
+		return &webhookRejection{reason: webhookReasonSignatureMismatch}
+	}
+
+	// Replays are recognized by the decoded signature, so re-encoding it, such as in other letter case
+	// or with a prefix, does not get the same request accepted twice
+	if v.cfg.RequireTimestamp && !v.remember(hex.EncodeToString(signature), signedAt.Add(v.cfg.TimestampTolerance)) {
+		return &webhookRejection{reason: webhookReasonReplayed}
+	}
+
+	return nil
+}
+
+// remember records an accepted signature until it expires, reporting false if it was already seen
+func (v *webhookVerifier) remember(signature string, expires time.Time) bool {
+	v.seen.mu.Lock()
+	defer v.seen.mu.Unlock()
+
+	now := v.now()
+	for sig, exp := range v.seen.expires {
+		if now.After(exp) {
+			delete(v.seen.expires, sig)
+		}
+	}
+
+	if _, ok := v.seen.expires[signature]; ok {
+		return false
+	}
+	v.seen.expires[signature] = expires
+	return true
+}
+
+// verifiedWebhook wraps an inbound webhook handler so it only runs for requests carrying a valid
+// signature. The body is read once for verification and handed to the handler unchanged.
+func (a *Adapter) verifiedWebhook(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if !a.cfg.Webhook.IsConfigured() {
+			slog.ErrorContext(ctx, "rejected webhook: no webhook secret configured", "path", r.URL.Path)
+			http.Error(w, "webhooks are not configured", http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		if err := a.webhooks.verify(r.Header, body); err != nil {
+			reason := err.Error()
+			var rejection *webhookRejection
+			if errors.As(err, &rejection) {
+				reason = rejection.reason
+			}
+			slog.WarnContext(ctx, "rejected webhook",
+				"reason", reason,
+				"path", r.URL.Path,
+				"remoteAddr", r.RemoteAddr,
+				"userAgent", r.UserAgent(),
+				"contentLength", len(body),
+			)
+			http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		handler(w, r.WithContext(domain.WithActor(ctx, domain.WebhookActor)))
+	}
+}
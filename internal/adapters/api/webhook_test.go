@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWebhookConfig returns the default webhook header scheme with a test secret
+func testWebhookConfig() config.WebhookConfig {
+	return config.WebhookConfig{
+		Secret:             "webhook-secret",
+		SignatureHeader:    "X-Webhook-Signature",
+		SignaturePrefix:    "sha256=",
+		SignatureEncoding:  config.WebhookEncodingHex,
+		RequireTimestamp:   true,
+		TimestampHeader:    "X-Webhook-Timestamp",
+		TimestampTolerance: 5 * time.Minute,
+	}
+}
+
+// webhookTestHandler returns a verified webhook handler echoing the body it receives
+func webhookTestHandler(cfg config.WebhookConfig, now time.Time) http.HandlerFunc {
+	appCfg := &config.Config{Webhook: cfg}
+	adapter := New(config.WithConfig(context.Background(), appCfg), &mockIndexer{}, &mockTalkReader{})
+	adapter.webhooks.now = func() time.Time { return now }
+
+	return adapter.verifiedWebhook(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Actor", domain.ActorFromContext(r.Context()).String())
+		w.Write(body)
+	})
+}
+
+// signedWebhookRequest builds a webhook request signed with the given secret and timestamp
+func signedWebhookRequest(secret string, timestamp time.Time, body string) *http.Request {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "." + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(body))
+	req.Header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Webhook-Timestamp", ts)
+	return req
+}
+
+func TestVerifiedWebhook_ValidSignature(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	handler := webhookTestHandler(testWebhookConfig(), now)
+
+	w := httptest.NewRecorder()
+	handler(w, signedWebhookRequest("webhook-secret", now.Add(-time.Minute), `{"talkId":"talk-1"}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"talkId":"talk-1"}`, w.Body.String())
+	assert.Equal(t, "system:webhook", w.Header().Get("X-Actor"))
+}
+
+func TestVerifiedWebhook_Rejections(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := `{"talkId":"talk-1"}`
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+	}{
+		{
+			name: "missing signature",
+			request: func() *http.Request {
+				req := signedWebhookRequest("webhook-secret", now, body)
+				req.Header.Del("X-Webhook-Signature")
+				return req
+			},
+		},
+		{
+			name: "malformed signature",
+			request: func() *http.Request {
+				req := signedWebhookRequest("webhook-secret", now, body)
+				req.Header.Set("X-Webhook-Signature", "sha256=not-hex")
+				return req
+			},
+		},
+		{
+			name: "wrong secret",
+			request: func() *http.Request {
+				return signedWebhookRequest("other-secret", now, body)
+			},
+		},
+		{
+			name: "tampered body",
+			request: func() *http.Request {
+				req := signedWebhookRequest("webhook-secret", now, body)
+				req.Body = io.NopCloser(strings.NewReader(`{"talkId":"talk-2"}`))
+				return req
+			},
+		},
+		{
+			name: "missing timestamp",
+			request: func() *http.Request {
+				req := signedWebhookRequest("webhook-secret", now, body)
+				req.Header.Del("X-Webhook-Timestamp")
+				return req
+			},
+		},
+		{
+			name: "stale timestamp",
+			request: func() *http.Request {
+				return signedWebhookRequest("webhook-secret", now.Add(-10*time.Minute), body)
+			},
+		},
+		{
+			name: "future timestamp",
+			request: func() *http.Request {
+				return signedWebhookRequest("webhook-secret", now.Add(10*time.Minute), body)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := webhookTestHandler(testWebhookConfig(), now)
+
+			w := httptest.NewRecorder()
+			handler(w, tt.request())
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestVerifiedWebhook_RejectsReplay(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	handler := webhookTestHandler(testWebhookConfig(), now)
+
+	first := httptest.NewRecorder()
+	handler(first, signedWebhookRequest("webhook-secret", now, `{}`))
+	require.Equal(t, http.StatusOK, first.Code)
+
+	replay := httptest.NewRecorder()
+	handler(replay, signedWebhookRequest("webhook-secret", now, `{}`))
+	assert.Equal(t, http.StatusUnauthorized, replay.Code)
+
+	// The same signature encoded differently is still a replay
+	reencoded := signedWebhookRequest("webhook-secret", now, `{}`)
+	signature := strings.TrimPrefix(reencoded.Header.Get("X-Webhook-Signature"), "sha256=")
+	reencoded.Header.Set("X-Webhook-Signature", "sha256="+strings.ToUpper(signature))
+	replay = httptest.NewRecorder()
+	handler(replay, reencoded)
+	assert.Equal(t, http.StatusUnauthorized, replay.Code)
+}
+
+func TestVerifiedWebhook_UntimestampedBase64Scheme(t *testing.T) {
+	cfg := testWebhookConfig()
+	cfg.SignatureHeader = "X-Signature"
+	cfg.SignaturePrefix = ""
+	cfg.SignatureEncoding = config.WebhookEncodingBase64
+	cfg.RequireTimestamp = false
+	handler := webhookTestHandler(cfg, time.Now())
+
+	mac := hmac.New(sha256.New, []byte("webhook-secret"))
+	mac.Write([]byte(`{}`))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(`{}`))
+		req.Header.Set("X-Signature", signature)
+		w := httptest.NewRecorder()
+		handler(w, req)
+
+		// Without timestamps there is no replay protection, so redeliveries are accepted
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestVerifiedWebhook_NotConfigured(t *testing.T) {
+	cfg := testWebhookConfig()
+	cfg.Secret = ""
+	now := time.Now()
+	handler := webhookTestHandler(cfg, now)
+
+	w := httptest.NewRecorder()
+	handler(w, signedWebhookRequest("", now, `{}`))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// GroupRoles maps OIDC groups to the roles granted to their members
+type GroupRoles map[string]domain.Role
+
+// ParseGroupRoles validates the configured group=role pairs
+func ParseGroupRoles(pairs map[string]string) (GroupRoles, error) {
+	roles := make(GroupRoles, len(pairs))
+	for group, role := range pairs {
+		if !domain.Role(role).IsValid() {
+			return nil, fmt.Errorf("invalid role %q for group %s", role, group)
+		}
+		roles[group] = domain.Role(role)
+	}
+	return roles, nil
+}
+
+// RoleFor returns the highest role granted by the given groups, or "" if none of them grant a role
+func (g GroupRoles) RoleFor(groups []string) domain.Role {
+	var role domain.Role
+	for _, group := range groups {
+		role = higherRole(role, g[group])
+	}
+	return role
+}
+
+// higherRole returns the more privileged of the two roles, ignoring unknown roles
+func higherRole(a, b domain.Role) domain.Role {
+	if !b.IsValid() {
+		return a
+	}
+	if !a.IsValid() || slices.Index(domain.Roles, b) > slices.Index(domain.Roles, a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDirectory is an allowlist of users and their roles; only RoleFor is used by the middleware
+type mockDirectory struct {
+	ports.UserDirectory
+	roles map[string]domain.Role
+	err   error
+}
+
+func (m *mockDirectory) RoleFor(ctx context.Context, email string) (domain.Role, bool, error) {
+	role, ok := m.roles[email]
+	return role, ok, m.err
+}
+
+func TestParseGroupRoles(t *testing.T) {
+	groups, err := ParseGroupRoles(map[string]string{"javabin-program": "operator", "javabin-readonly": "viewer"})
+	require.NoError(t, err)
+	assert.Equal(t, GroupRoles{"javabin-program": domain.RoleOperator, "javabin-readonly": domain.RoleViewer}, groups)
+
+	_, err = ParseGroupRoles(map[string]string{"javabin-program": "superuser"})
+	assert.Error(t, err)
+}
+
+func TestGroupRoles_RoleFor(t *testing.T) {
+	groups := GroupRoles{"javabin-program": domain.RoleOperator, "javabin-readonly": domain.RoleViewer}
+
+	assert.Equal(t, domain.RoleOperator, groups.RoleFor([]string{"javabin-readonly", "javabin-program"}))
+	assert.Equal(t, domain.RoleViewer, groups.RoleFor([]string{"other", "javabin-readonly"}))
+	assert.Equal(t, domain.Role(""), groups.RoleFor([]string{"other"}))
+	assert.Equal(t, domain.Role(""), groups.RoleFor(nil))
+}
+
+func TestLookupRole(t *testing.T) {
+	ctx := context.Background()
+	groups := GroupRoles{"javabin-program": domain.RoleOperator}
+	directory := &mockDirectory{roles: map[string]domain.Role{"admin@java.no": domain.RoleAdmin, "viewer@java.no": domain.RoleViewer}}
+
+	tests := []struct {
+		name      string
+		users     *mockDirectory
+		groups    GroupRoles
+		email     string
+		groupRole domain.Role
+		role      domain.Role
+		allowed   bool
+	}{
+		{name: "no allowlist or groups", email: "anyone@java.no", role: domain.RoleAdmin, allowed: true},
+		{name: "group role without allowlist", groups: groups, email: "anyone@java.no", groupRole: domain.RoleOperator, role: domain.RoleOperator, allowed: true},
+		{name: "no group role without allowlist", groups: groups, email: "anyone@java.no", allowed: false},
+		{name: "allowlist role", users: directory, email: "viewer@java.no", role: domain.RoleViewer, allowed: true},
+		{name: "group role above allowlist role", users: directory, groups: groups, email: "viewer@java.no", groupRole: domain.RoleOperator, role: domain.RoleOperator, allowed: true},
+		{name: "allowlist role above group role", users: directory, groups: groups, email: "admin@java.no", groupRole: domain.RoleOperator, role: domain.RoleAdmin, allowed: true},
+		{name: "group role outside allowlist", users: directory, groups: groups, email: "anyone@java.no", groupRole: domain.RoleOperator, role: domain.RoleOperator, allowed: true},
+		{name: "outside allowlist and groups", users: directory, groups: groups, email: "anyone@java.no", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var users ports.UserDirectory
+			if tt.users != nil {
+				users = tt.users
+			}
+
+			role, allowed, err := lookupRole(ctx, users, tt.groups, tt.email, tt.groupRole)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, allowed)
+			if tt.allowed {
+				assert.Equal(t, tt.role, role)
+			}
+		})
+	}
+
+	t.Run("allowlist error", func(t *testing.T) {
+		_, _, err := lookupRole(ctx, &mockDirectory{err: errors.New("settings unavailable")}, groups, "anyone@java.no", domain.RoleOperator)
+		assert.Error(t, err)
+	})
+}
+
+func TestGroupsFromClaims(t *testing.T) {
+	claims := map[string]interface{}{
+		"groups":       []interface{}{"javabin-program", 42, "javabin-readonly"},
+		"role":         "javabin-program",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"javabin-readonly"}},
+	}
+
+	assert.Equal(t, []string{"javabin-program", "javabin-readonly"}, groupsFromClaims(claims, "groups"))
+	assert.Equal(t, []string{"javabin-program"}, groupsFromClaims(claims, "role"))
+	assert.Equal(t, []string{"javabin-readonly"}, groupsFromClaims(claims, "realm_access.roles"))
+	assert.Nil(t, groupsFromClaims(claims, "missing"))
+	assert.Nil(t, groupsFromClaims(claims, "role.nested"))
+	assert.Nil(t, groupsFromClaims(claims, ""))
+}
@@ -3,15 +3,17 @@ package auth
 import (
 	"log/slog"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/javaBin/talks-indexer/internal/adapters/session"
+	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
 // Handler handles auth-related HTTP requests
 type Handler struct {
 	store         session.Store
+	users         ports.UserDirectory
+	groups        GroupRoles
 	authenticator *Authenticator
 	sessionTTL    time.Duration
 	secureCookies bool
@@ -27,6 +29,43 @@ func NewHandler(store session.Store, auth *Authenticator, secureCookies bool) *H
 	}
 }
 
+// HandleLogin starts the OIDC flow: it generates state, remembers the return URL and
+// redirects to the OIDC provider
+func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	state, err := generateState()
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	returnURL := r.URL.Query().Get("return_url")
+	if !IsValidReturnURL(returnURL) {
+		returnURL = "/admin"
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   300,
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     returnURLCookie,
+		Value:    returnURL,
+		Path:     "/",
+		MaxAge:   300,
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, h.authenticator.AuthURL(state), http.StatusFound)
+}
+
 // HandleCallback handles the OIDC callback
 func (h *Handler) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -54,14 +93,29 @@ func (h *Handler) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	email, err := h.authenticator.Exchange(ctx, code)
+	identity, err := h.authenticator.Exchange(ctx, code)
 	if err != nil {
 		slog.ErrorContext(ctx, "OIDC exchange failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusInternalServerError)
 		return
 	}
+	email := identity.Email
+	groupRole := h.groups.RoleFor(identity.Groups)
 
-	sess, err := h.store.Create(ctx, email, h.sessionTTL)
+	_, allowed, err := lookupRole(ctx, h.users, h.groups, email, groupRole)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to look up user role", "email", email, "error", err)
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		slog.WarnContext(ctx, "login rejected, user not in allowlist or role groups", "email", email, "groups", identity.Groups)
+		h.clearCookie(w, returnURLCookie)
+		http.Error(w, "Your account does not have access to this application", http.StatusForbidden)
+		return
+	}
+
+	sess, err := h.store.Create(ctx, email, groupRole, h.sessionTTL)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to create session", "error", err)
 		http.Error(w, "Session creation failed", http.StatusInternalServerError)
@@ -78,10 +132,10 @@ func (h *Handler) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		SameSite: http.SameSiteLaxMode,
 	})
 
-	slog.InfoContext(ctx, "user authenticated", "email", email)
+	slog.InfoContext(ctx, "user authenticated", "email", email, "groupRole", groupRole)
 
 	returnURL := "/admin"
-	if cookie, err := r.Cookie(returnURLCookie); err == nil && isValidReturnURL(cookie.Value) {
+	if cookie, err := r.Cookie(returnURLCookie); err == nil && IsValidReturnURL(cookie.Value) {
 		returnURL = cookie.Value
 	}
 	h.clearCookie(w, returnURLCookie)
@@ -117,8 +171,3 @@ func (h *Handler) clearCookie(w http.ResponseWriter, name string) {
 		SameSite: http.SameSiteLaxMode,
 	})
 }
-
-// isValidReturnURL validates the return URL to prevent open redirects
-func isValidReturnURL(url string) bool {
-	return strings.HasPrefix(url, "/admin")
-}
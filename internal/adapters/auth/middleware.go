@@ -4,9 +4,14 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/javaBin/talks-indexer/internal/adapters/session"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
 // ContextKey for storing user info in request context
@@ -16,9 +21,15 @@ const (
 	// SessionKey is the context key for the authenticated session
 	SessionKey ContextKey = "session"
 
+	// RoleKey is the context key for the role of the authenticated user
+	RoleKey ContextKey = "role"
+
 	sessionCookieName = "session"
 	stateCookieName   = "oauth_state"
 	returnURLCookie   = "return_url"
+
+	// loginPath is the login page served by the web adapter
+	loginPath = "/login"
 )
 
 // GetSession retrieves the session from the context, returns nil if not present
@@ -29,71 +40,152 @@ func GetSession(ctx context.Context) *session.Session {
 	return nil
 }
 
+// GetRole retrieves the role of the authenticated user from the context, returns "" if not present
+func GetRole(ctx context.Context) domain.Role {
+	role, _ := ctx.Value(RoleKey).(domain.Role)
+	return role
+}
+
+// RequireRole returns middleware that rejects users whose role does not include the required role.
+// It must be applied inside the authentication middleware, which puts the role in the context.
+func RequireRole(required domain.Role) MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !GetRole(r.Context()).Allows(required) {
+				http.Error(w, "Forbidden: requires the "+string(required)+" role", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// lookupRole returns the role of the user: the higher of the role in the allowlist and the role granted
+// by the user's OIDC groups. Without an allowlist or group roles every authenticated user is an admin.
+func lookupRole(ctx context.Context, users ports.UserDirectory, groups GroupRoles, email string, groupRole domain.Role) (domain.Role, bool, error) {
+	if users == nil {
+		if len(groups) == 0 {
+			return domain.RoleAdmin, true, nil
+		}
+		return groupRole, groupRole != "", nil
+	}
+
+	role, allowed, err := users.RoleFor(ctx, email)
+	if err != nil {
+		return "", false, err
+	}
+	if !allowed {
+		return groupRole, groupRole != "", nil
+	}
+	return higherRole(role, groupRole), true, nil
+}
+
 // Middleware protects routes with OIDC authentication
 type Middleware struct {
-	store         session.Store
-	authenticator *Authenticator
-	secureCookies bool
+	store  session.Store
+	users  ports.UserDirectory
+	groups GroupRoles
 }
 
 // NewMiddleware creates a new auth middleware
-func NewMiddleware(store session.Store, auth *Authenticator, secureCookies bool) *Middleware {
+func NewMiddleware(store session.Store) *Middleware {
 	return &Middleware{
-		store:         store,
-		authenticator: auth,
-		secureCookies: secureCookies,
+		store: store,
 	}
 }
 
-// RequireAuth wraps a handler requiring authentication
+// RequireAuth wraps a handler requiring authentication.
+// Unauthenticated page requests are sent to the login page; htmx requests get a 401
+// with an HX-Redirect header so htmx navigates there instead of following the redirect itself.
 func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cookie, err := r.Cookie(sessionCookieName)
 		if err != nil {
-			m.redirectToLogin(w, r)
+			m.requireLogin(w, r, false)
 			return
 		}
 
 		sess, err := m.store.Get(r.Context(), cookie.Value)
 		if err != nil || sess == nil {
-			m.redirectToLogin(w, r)
+			m.requireLogin(w, r, true)
+			return
+		}
+
+		// The allowlist is checked on every request so access changes apply to existing sessions.
+		// Group roles are read from the ID token at login and apply until the session expires.
+		role, allowed, err := lookupRole(r.Context(), m.users, m.groups, sess.Email, sess.Role)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to look up user role", "email", sess.Email, "error", err)
+			http.Error(w, "Failed to check access", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			slog.WarnContext(r.Context(), "user not in allowlist or role groups", "email", sess.Email, "path", r.URL.Path)
+			http.Error(w, "Your account does not have access to this application", http.StatusForbidden)
 			return
 		}
 
 		ctx := context.WithValue(r.Context(), SessionKey, sess)
+		ctx = context.WithValue(ctx, RoleKey, role)
+		ctx = domain.WithActor(ctx, domain.Actor{Kind: domain.ActorUser, Name: sess.Email})
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// redirectToLogin generates state, stores it, and redirects to OIDC provider
-func (m *Middleware) redirectToLogin(w http.ResponseWriter, r *http.Request) {
-	state, err := generateState()
+// IsAuthenticated reports whether the request carries a valid session cookie of an allowed user
+func (m *Middleware) IsAuthenticated(r *http.Request) bool {
+	cookie, err := r.Cookie(sessionCookieName)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+
+	sess, err := m.store.Get(r.Context(), cookie.Value)
+	if err != nil || sess == nil {
+		return false
+	}
+
+	_, allowed, err := lookupRole(r.Context(), m.users, m.groups, sess.Email, sess.Role)
+	return err == nil && allowed
+}
+
+// requireLogin sends the client to the login page, remembering the page it came from
+func (m *Middleware) requireLogin(w http.ResponseWriter, r *http.Request, expired bool) {
+	if r.Header.Get("HX-Request") == "true" {
+		returnURL := "/admin"
+		if current, err := url.Parse(r.Header.Get("HX-Current-URL")); err == nil && IsValidReturnURL(current.RequestURI()) {
+			returnURL = current.RequestURI()
+		}
+		w.Header().Set("HX-Redirect", LoginURL(returnURL, expired))
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     stateCookieName,
-		Value:    state,
-		Path:     "/",
-		MaxAge:   300,
-		HttpOnly: true,
-		Secure:   m.secureCookies,
-		SameSite: http.SameSiteLaxMode,
-	})
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     returnURLCookie,
-		Value:    r.URL.Path,
-		Path:     "/",
-		MaxAge:   300,
-		HttpOnly: true,
-		Secure:   m.secureCookies,
-		SameSite: http.SameSiteLaxMode,
-	})
+	http.Redirect(w, r, LoginURL(r.URL.RequestURI(), expired), http.StatusFound)
+}
+
+// LoginURL returns the login page URL for the given return URL.
+// Invalid return URLs are replaced by the dashboard to prevent open redirects.
+func LoginURL(returnURL string, expired bool) string {
+	if !IsValidReturnURL(returnURL) {
+		returnURL = "/admin"
+	}
+
+	query := url.Values{}
+	query.Set("return_url", returnURL)
+	if expired {
+		query.Set("expired", "true")
+	}
+	return loginPath + "?" + query.Encode()
+}
 
-	http.Redirect(w, r, m.authenticator.AuthURL(state), http.StatusFound)
+// IsValidReturnURL validates the return URL to prevent open redirects
+func IsValidReturnURL(returnURL string) bool {
+	return strings.HasPrefix(returnURL, "/admin")
 }
 
 // generateState generates a cryptographically secure random state parameter
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"golang.org/x/oauth2"
@@ -14,13 +15,21 @@ type OIDCConfig struct {
 	ClientID     string
 	ClientSecret string
 	RedirectURL  string
+	GroupsClaim  string
+}
+
+// Identity is the authenticated user from the ID token
+type Identity struct {
+	Email  string
+	Groups []string
 }
 
 // Authenticator handles OIDC authentication
 type Authenticator struct {
-	provider *oidc.Provider
-	config   oauth2.Config
-	verifier *oidc.IDTokenVerifier
+	provider    *oidc.Provider
+	config      oauth2.Config
+	verifier    *oidc.IDTokenVerifier
+	groupsClaim string
 }
 
 // NewAuthenticator creates a new OIDC authenticator
@@ -41,9 +50,10 @@ func NewAuthenticator(ctx context.Context, cfg OIDCConfig) (*Authenticator, erro
 	verifier := provider.Verifier(&oidc.Config{ClientID: cfg.ClientID})
 
 	return &Authenticator{
-		provider: provider,
-		config:   oauth2Config,
-		verifier: verifier,
+		provider:    provider,
+		config:      oauth2Config,
+		verifier:    verifier,
+		groupsClaim: cfg.GroupsClaim,
 	}, nil
 }
 
@@ -52,33 +62,63 @@ func (a *Authenticator) AuthURL(state string) string {
 	return a.config.AuthCodeURL(state)
 }
 
-// Exchange exchanges the authorization code for tokens and returns the email
-func (a *Authenticator) Exchange(ctx context.Context, code string) (string, error) {
+// Exchange exchanges the authorization code for tokens and returns the user's email and groups
+func (a *Authenticator) Exchange(ctx context.Context, code string) (Identity, error) {
 	token, err := a.config.Exchange(ctx, code)
 	if err != nil {
-		return "", fmt.Errorf("failed to exchange code for token: %w", err)
+		return Identity{}, fmt.Errorf("failed to exchange code for token: %w", err)
 	}
 
 	rawIDToken, ok := token.Extra("id_token").(string)
 	if !ok {
-		return "", fmt.Errorf("no id_token in token response")
+		return Identity{}, fmt.Errorf("no id_token in token response")
 	}
 
 	idToken, err := a.verifier.Verify(ctx, rawIDToken)
 	if err != nil {
-		return "", fmt.Errorf("failed to verify ID token: %w", err)
+		return Identity{}, fmt.Errorf("failed to verify ID token: %w", err)
 	}
 
-	var claims struct {
-		Email string `json:"email"`
-	}
+	var claims map[string]interface{}
 	if err := idToken.Claims(&claims); err != nil {
-		return "", fmt.Errorf("failed to parse claims: %w", err)
+		return Identity{}, fmt.Errorf("failed to parse claims: %w", err)
+	}
+
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return Identity{}, fmt.Errorf("no email claim in ID token")
 	}
 
-	if claims.Email == "" {
-		return "", fmt.Errorf("no email claim in ID token")
+	return Identity{Email: email, Groups: groupsFromClaims(claims, a.groupsClaim)}, nil
+}
+
+// groupsFromClaims returns the groups in the claim at the dot-separated path. The claim may be a list
+// of strings or a single string; a missing claim means no groups.
+func groupsFromClaims(claims map[string]interface{}, path string) []string {
+	if path == "" {
+		return nil
 	}
 
-	return claims.Email, nil
+	var value interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, item := range v {
+			if group, ok := item.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+		return groups
+	}
+	return nil
 }
@@ -8,6 +8,8 @@ import (
 
 	"github.com/javaBin/talks-indexer/internal/adapters/session"
 	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
 // MiddlewareFunc is a function that wraps a handler with middleware
@@ -15,26 +17,43 @@ type MiddlewareFunc func(http.Handler) http.Handler
 
 // Adapter holds the auth adapter dependencies
 type Adapter struct {
-	handler    *Handler
-	middleware MiddlewareFunc
+	sessions       *session.InMemoryStore
+	handler        *Handler
+	authMiddleware *Middleware
+	middleware     MiddlewareFunc
+	authenticated  func(r *http.Request) bool
 }
 
-// passthroughMiddleware returns the handler unchanged (no authentication)
+// developmentActor is the actor for requests in development mode, where nobody logs in
+var developmentActor = domain.Actor{Kind: domain.ActorUser, Name: "anonymous"}
+
+// passthroughMiddleware grants the admin role without authentication
 func passthroughMiddleware(next http.Handler) http.Handler {
-	return next
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), RoleKey, domain.RoleAdmin)
+		ctx = domain.WithActor(ctx, developmentActor)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
 }
 
 // New creates a new auth adapter.
 // In development mode, returns an adapter with passthrough middleware.
 // In production mode, OIDC must be configured or an error is returned.
 func New(ctx context.Context) (*Adapter, error) {
+	return NewWithSessions(ctx, nil)
+}
+
+// NewWithSessions creates a new auth adapter like New, keeping the login sessions of the store, such
+// as those of the adapter replaced when the configuration is reloaded. A new store is created if nil.
+func NewWithSessions(ctx context.Context, sessionStore *session.InMemoryStore) (*Adapter, error) {
 	cfg := config.GetConfig(ctx)
 
 	// In development mode, use passthrough middleware (no auth required)
 	if cfg.Mode.IsDevelopment() {
 		slog.Info("auth disabled (development mode)")
 		return &Adapter{
-			middleware: passthroughMiddleware,
+			middleware:    passthroughMiddleware,
+			authenticated: func(r *http.Request) bool { return true },
 		}, nil
 	}
 
@@ -49,6 +68,12 @@ func New(ctx context.Context) (*Adapter, error) {
 		ClientID:     cfg.OIDC.ClientID,
 		ClientSecret: cfg.OIDC.ClientSecret,
 		RedirectURL:  cfg.OIDC.RedirectURL,
+		GroupsClaim:  cfg.OIDC.GroupsClaim,
+	}
+
+	groups, err := ParseGroupRoles(cfg.OIDC.RoleGroups)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OIDC_ROLE_GROUPS: %w", err)
 	}
 
 	authenticator, err := NewAuthenticator(ctx, oidcConfig)
@@ -57,19 +82,53 @@ func New(ctx context.Context) (*Adapter, error) {
 	}
 	slog.Info("OIDC authenticator initialized")
 
-	sessionStore := session.NewInMemoryStore()
+	if sessionStore == nil {
+		sessionStore = session.NewInMemoryStore()
+	}
 	secureCookies := true
 
-	authMiddleware := NewMiddleware(sessionStore, authenticator, secureCookies)
+	authMiddleware := NewMiddleware(sessionStore)
 	authHandler := NewHandler(sessionStore, authenticator, secureCookies)
+	if len(groups) > 0 {
+		authMiddleware.groups = groups
+		authHandler.groups = groups
+		slog.Info("OIDC group roles enabled", "claim", cfg.OIDC.GroupsClaim, "groups", len(groups))
+	}
 
 	return &Adapter{
-		handler:    authHandler,
-		middleware: authMiddleware.RequireAuth,
+		sessions:       sessionStore,
+		handler:        authHandler,
+		authMiddleware: authMiddleware,
+		middleware:     authMiddleware.RequireAuth,
+		authenticated:  authMiddleware.IsAuthenticated,
 	}, nil
 }
 
-// RegisterRoutes registers auth routes (/auth/callback, /auth/logout).
+// SetUserDirectory restricts access to users in the allowlist and assigns their roles.
+// Without a user directory every authenticated user is an admin, unless OIDC_ROLE_GROUPS grants
+// roles by group. Has no effect in development mode.
+func (a *Adapter) SetUserDirectory(users ports.UserDirectory) {
+	if a.handler == nil {
+		return
+	}
+	a.handler.users = users
+	a.authMiddleware.users = users
+}
+
+// SetClock replaces the system clock deciding when sessions expire. Has no effect in development mode.
+func (a *Adapter) SetClock(clock ports.Clock) {
+	if a.sessions == nil {
+		return
+	}
+	a.sessions.SetClock(clock)
+}
+
+// Sessions returns the store of the login sessions, or nil in development mode
+func (a *Adapter) Sessions() *session.InMemoryStore {
+	return a.sessions
+}
+
+// RegisterRoutes registers auth routes (/auth/login, /auth/callback, /auth/logout).
 // Only registers routes if OIDC authentication is enabled.
 func (a *Adapter) RegisterRoutes(mux *http.ServeMux) {
 	// Skip if no handler (development mode)
@@ -77,6 +136,7 @@ func (a *Adapter) RegisterRoutes(mux *http.ServeMux) {
 		return
 	}
 
+	mux.HandleFunc("GET /auth/login", a.handler.HandleLogin)
 	mux.HandleFunc("GET /auth/callback", a.handler.HandleCallback)
 	mux.HandleFunc("POST /auth/logout", a.handler.HandleLogout)
 
@@ -89,3 +149,9 @@ func (a *Adapter) RegisterRoutes(mux *http.ServeMux) {
 func (a *Adapter) Middleware() MiddlewareFunc {
 	return a.middleware
 }
+
+// IsAuthenticated reports whether the request belongs to a logged-in user.
+// In development mode every request is treated as authenticated.
+func (a *Adapter) IsAuthenticated(r *http.Request) bool {
+	return a.authenticated(r)
+}
@@ -0,0 +1,143 @@
+package bleve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// FetchTalks retrieves all talks stored in the specified index, ordered by ID.
+// If conferenceSlug is non-empty, only talks for that conference are returned.
+func (s *Store) FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+	index, err := s.index(indexName, false)
+	if errors.Is(err, domain.ErrIndexNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var q query.Query = bleve.NewMatchAllQuery()
+	if conferenceSlug != "" {
+		q = termQuery("conferenceSlug", conferenceSlug)
+	}
+
+	count, err := index.DocCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count talks in index %s: %w", indexName, err)
+	}
+
+	req := bleve.NewSearchRequestOptions(q, int(count), 0, false)
+	req.Fields = []string{"source"}
+	req.SortBy([]string{"_id"})
+	res, err := index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from index %s: %w", indexName, err)
+	}
+
+	talks := make([]domain.Talk, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		source, _ := hit.Fields["source"].(string)
+		var talk domain.Talk
+		if err := json.Unmarshal([]byte(source), &talk); err != nil {
+			return nil, fmt.Errorf("failed to parse document %s: %w", hit.ID, err)
+		}
+		talks = append(talks, talk)
+	}
+
+	s.logger.DebugContext(ctx, "fetched talks from index", "index", indexName, "conferenceSlug", conferenceSlug, "count", len(talks))
+	return talks, nil
+}
+
+// ListTalkIDs returns the IDs of the talks of the conference stored in the specified index, ordered by ID.
+// The conference ID is not a search field, so it is read from the stored documents.
+func (s *Store) ListTalkIDs(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+	talks, err := s.FetchTalks(ctx, indexName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, talk := range talks {
+		if talk.ConferenceID == conferenceID {
+			ids = append(ids, talk.ID)
+		}
+	}
+	return ids, nil
+}
+
+// ListConferences returns the conferences present in the specified index with their talk counts,
+// ordered by slug. The conference ID, name and metadata are read from the conference's first talk.
+func (s *Store) ListConferences(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+	talks, err := s.FetchTalks(ctx, indexName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	conferences := []domain.ConferenceSummary{}
+	positions := make(map[string]int)
+	for _, talk := range talks {
+		if i, ok := positions[talk.ConferenceSlug]; ok {
+			conferences[i].TalkCount++
+			continue
+		}
+
+		summary := domain.ConferenceSummary{
+			ID:        talk.ConferenceID,
+			Name:      talk.ConferenceName,
+			Slug:      talk.ConferenceSlug,
+			TalkCount: 1,
+		}
+		if talk.Conference != nil {
+			summary.ConferenceMetadata = *talk.Conference
+		}
+		positions[talk.ConferenceSlug] = len(conferences)
+		conferences = append(conferences, summary)
+	}
+
+	sort.Slice(conferences, func(i, j int) bool { return conferences[i].Slug < conferences[j].Slug })
+	return conferences, nil
+}
+
+// IndexVersion returns the generation (random ID, which changes whenever the index is recreated)
+// and document version (number of documents written) of the specified index.
+func (s *Store) IndexVersion(ctx context.Context, indexName string) (domain.IndexVersion, error) {
+	index, err := s.index(indexName, false)
+	if err != nil {
+		return domain.IndexVersion{}, fmt.Errorf("failed to get stats for index %s: %w", indexName, err)
+	}
+
+	generation, err := index.GetInternal([]byte(generationKey))
+	if err != nil {
+		return domain.IndexVersion{}, fmt.Errorf("failed to get stats for index %s: %w", indexName, err)
+	}
+	version, err := indexVersionCount(index)
+	if err != nil {
+		return domain.IndexVersion{}, fmt.Errorf("failed to get stats for index %s: %w", indexName, err)
+	}
+	count, err := index.DocCount()
+	if err != nil {
+		return domain.IndexVersion{}, fmt.Errorf("failed to get stats for index %s: %w", indexName, err)
+	}
+
+	return domain.IndexVersion{
+		Index:      indexName,
+		Generation: string(generation),
+		Version:    version,
+		DocCount:   int64(count),
+	}, nil
+}
+
+// termQuery matches documents whose exact field value is term
+func termQuery(field, term string) query.Query {
+	q := bleve.NewTermQuery(term)
+	q.SetField(field)
+	return q
+}
@@ -0,0 +1,99 @@
+package bleve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	bsearch "github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/searchquery"
+)
+
+// textBoosts weights the searchable text fields like the search service does
+var textBoosts = map[string]float64{
+	"title":    3,
+	"keywords": 2,
+	"abstract": 1,
+	"speakers": 1,
+}
+
+// RunQuery runs a search request body against the given index. Only the queries built by the search
+// service are supported, as described by searchquery.Parse; other queries return an error wrapping
+// domain.ErrInvalidQuery. Hits with text are ordered by relevance, others by ID, after the sort field if any.
+func (s *Store) RunQuery(ctx context.Context, indexName string, body map[string]interface{}) (domain.QueryResult, error) {
+	search, err := searchquery.Parse(body)
+	if err != nil {
+		return domain.QueryResult{}, err
+	}
+
+	index, err := s.index(indexName, false)
+	if errors.Is(err, domain.ErrIndexNotFound) {
+		return domain.QueryResult{Hits: []json.RawMessage{}}, nil
+	}
+	if err != nil {
+		return domain.QueryResult{}, err
+	}
+
+	req := bleve.NewSearchRequestOptions(talkQuery(search), search.Size, search.From, false)
+	req.Fields = []string{"source"}
+	var order bsearch.SortOrder
+	if search.SortBy != "" {
+		order = append(order, &bsearch.SortField{Field: search.SortBy, Desc: true, Type: bsearch.SortFieldAsNumber, Missing: bsearch.SortFieldMissingLast})
+	}
+	if len(search.Texts) > 0 {
+		order = append(order, &bsearch.SortScore{Desc: true})
+	}
+	req.SortByCustom(append(order, &bsearch.SortDocID{}))
+
+	res, err := index.SearchInContext(ctx, req)
+	if err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to query index %s: %w", indexName, err)
+	}
+
+	result := domain.QueryResult{
+		Total:      int(res.Total),
+		TookMillis: int(res.Took.Milliseconds()),
+		Hits:       make([]json.RawMessage, 0, len(res.Hits)),
+	}
+	for _, hit := range res.Hits {
+		source, _ := hit.Fields["source"].(string)
+		result.Hits = append(result.Hits, json.RawMessage(source))
+	}
+	return result, nil
+}
+
+// talkQuery translates the search into a Bleve query: every filter must match, and with text any word
+// must match one of the text fields
+func talkQuery(search searchquery.Search) query.Query {
+	var conjuncts []query.Query
+	for field, value := range search.Filters {
+		if field == "id" {
+			conjuncts = append(conjuncts, bleve.NewDocIDQuery([]string{value}))
+			continue
+		}
+		conjuncts = append(conjuncts, termQuery(field, value))
+	}
+
+	if words := search.Words(); len(words) > 0 {
+		text := strings.Join(words, " ")
+		disjuncts := make([]query.Query, 0, len(textBoosts))
+		for field, boost := range textBoosts {
+			match := bleve.NewMatchQuery(text)
+			match.SetField(field)
+			match.SetBoost(boost)
+			disjuncts = append(disjuncts, match)
+		}
+		conjuncts = append(conjuncts, bleve.NewDisjunctionQuery(disjuncts...))
+	}
+
+	if len(conjuncts) == 0 {
+		return bleve.NewMatchAllQuery()
+	}
+	return bleve.NewConjunctionQuery(conjuncts...)
+}
@@ -0,0 +1,175 @@
+package bleve
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchBody returns a body shaped like the ones built by the search service
+func searchBody(text string, filters ...interface{}) map[string]interface{} {
+	query := map[string]interface{}{"filter": filters}
+	if text != "" {
+		query["should"] = []interface{}{
+			map[string]interface{}{"multi_match": map[string]interface{}{"query": text, "fields": []string{"data.title^3", "data.keywords^2", "data.abstract"}}},
+			map[string]interface{}{"nested": map[string]interface{}{
+				"path":  "speakers",
+				"query": map[string]interface{}{"match": map[string]interface{}{"speakers.name": text}},
+			}},
+		}
+		query["minimum_should_match"] = 1
+	}
+	return map[string]interface{}{"size": 10, "from": 0, "track_total_hits": true, "query": map[string]interface{}{"bool": query}}
+}
+
+// reviewScoreSort is the sort of the search service ranking hits by review score
+var reviewScoreSort = []interface{}{
+	map[string]interface{}{"review.score": map[string]interface{}{"order": "desc", "missing": "_last", "unmapped_type": "float"}},
+	"_score",
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// hitIDs returns the IDs of the hits in order
+func hitIDs(t *testing.T, result domain.QueryResult) []string {
+	ids := make([]string, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		var talk domain.Talk
+		require.NoError(t, json.Unmarshal(hit, &talk))
+		ids = append(ids, talk.ID)
+	}
+	return ids
+}
+
+func TestRunQuery(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	abstract := testTalk("talk-1", "javazone2024", "Building services", time.Now())
+	abstract.Data["abstract"] = "How we run kotlin coroutines in production"
+	keywords := testTalk("talk-2", "javazone2024", "Effective concurrency", time.Now())
+	keywords.Data["keywords"] = []interface{}{"kotlin", "jvm"}
+	keywords.SeriesID = "kotlin-workshop"
+	title := testTalk("talk-3", "javazone2024", "Kotlin for everyone", time.Now())
+	title.SeriesID = "kotlin-workshop"
+	title.Review = &domain.ReviewAssignment{
+		Reviewers:  []string{"ada@javazone.no", "grace@javazone.no"},
+		FeedbackBy: []string{"ada@javazone.no"},
+		Pending:    []string{"grace@javazone.no"},
+	}
+	rejected := testTalk("talk-4", "javazone2024", "Kotlin again", time.Now())
+	rejected.Status = "REJECTED"
+	rejected.Review = &domain.ReviewAssignment{Reviewers: []string{"ada@javazone.no"}, Pending: []string{"ada@javazone.no"}}
+	speaker := testTalk("talk-5", "javazone2023", "Type systems", time.Now())
+	speaker.Speakers = domain.Speakers{{ID: "grace", Name: "Grace Hopper"}}
+	title.Review.Score, rejected.Review.Score = floatPtr(2.5), floatPtr(-1)
+	speaker.Review = &domain.ReviewAssignment{FeedbackBy: []string{"grace@javazone.no"}, Score: floatPtr(3)}
+	_, err := store.BulkIndex(ctx, "javazone_private", []domain.Talk{abstract, keywords, title, rejected, speaker})
+	require.NoError(t, err)
+
+	t.Run("text ranks title and keywords over abstract", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_private", searchBody("kotlin",
+			map[string]interface{}{"term": map[string]interface{}{"status": "APPROVED"}},
+		))
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Total)
+		ids := hitIDs(t, result)
+		require.Len(t, ids, 3)
+		assert.ElementsMatch(t, []string{"talk-2", "talk-3"}, ids[:2])
+		assert.Equal(t, "talk-1", ids[2])
+	})
+
+	t.Run("speaker names", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_private", searchBody("hopper"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"talk-5"}, hitIDs(t, result))
+	})
+
+	t.Run("filters without text", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_private", searchBody("",
+			map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": "javazone2024"}},
+		))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"talk-1", "talk-2", "talk-3", "talk-4"}, hitIDs(t, result))
+	})
+
+	t.Run("series", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_private", searchBody("",
+			map[string]interface{}{"term": map[string]interface{}{"seriesId": "kotlin-workshop"}},
+		))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"talk-2", "talk-3"}, hitIDs(t, result))
+	})
+
+	t.Run("review assignments", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_private", searchBody("",
+			map[string]interface{}{"term": map[string]interface{}{"review.reviewers": "ada@javazone.no"}},
+		))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"talk-3", "talk-4"}, hitIDs(t, result))
+
+		result, err = store.RunQuery(ctx, "javazone_private", searchBody("",
+			map[string]interface{}{"term": map[string]interface{}{"review.pending": "ada@javazone.no"}},
+		))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"talk-4"}, hitIDs(t, result))
+	})
+
+	t.Run("sorted by review score", func(t *testing.T) {
+		body := searchBody("")
+		body["sort"] = reviewScoreSort
+
+		result, err := store.RunQuery(ctx, "javazone_private", body)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"talk-5", "talk-3", "talk-4", "talk-1", "talk-2"}, hitIDs(t, result))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		body := searchBody("")
+		body["size"] = 2
+		body["from"] = 2
+
+		result, err := store.RunQuery(ctx, "javazone_private", body)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Total)
+		assert.Equal(t, []string{"talk-3", "talk-4"}, hitIDs(t, result))
+	})
+
+	t.Run("query syntax in the text is matched literally", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_private", searchBody(`kotlin" OR NOT (*`))
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+	})
+
+	t.Run("other indexes are not searched", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_public", searchBody("kotlin"))
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Total)
+		assert.Empty(t, result.Hits)
+	})
+}
+
+func TestRunQuery_Unsupported(t *testing.T) {
+	store := newTestStore(t)
+
+	_, err := store.RunQuery(context.Background(), "javazone_private", map[string]interface{}{"aggs": map[string]interface{}{}})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+}
@@ -0,0 +1,444 @@
+package bleve
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/mapping"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// Internal keys stored next to the documents of each index
+const (
+	generationKey    = "generation"
+	versionKey       = "version"
+	docVersionPrefix = "version/"
+)
+
+// Store implements the SearchIndex, TalkReader and IndexVersioner interfaces on embedded Bleve indexes,
+// one directory per index, for single-binary deployments such as an offline demo of the archive search.
+// It runs the talk searches built by the search service, but none of the other Elasticsearch queries.
+type Store struct {
+	dir    string
+	logger *slog.Logger
+
+	// mu guards the open indexes; writeMu serializes bulk writes so version checks see earlier writes
+	mu      sync.Mutex
+	writeMu sync.Mutex
+	indexes map[string]bleve.Index
+}
+
+// New opens the Bleve store, retrieving configuration from context.
+func New(ctx context.Context) (*Store, error) {
+	cfg := config.GetConfig(ctx)
+
+	store, err := Open(cfg.Search.BlevePath)
+	if err != nil {
+		return nil, err
+	}
+
+	store.logger.Info("opened bleve store", "path", cfg.Search.BlevePath)
+	return store, nil
+}
+
+// Open opens the store keeping its indexes in dir, creating the directory if needed.
+// This constructor is primarily intended for testing purposes.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create bleve directory %s: %w", dir, err)
+	}
+
+	return &Store{
+		dir:     dir,
+		logger:  slog.Default().With("component", "bleve"),
+		indexes: make(map[string]bleve.Index),
+	}, nil
+}
+
+// Close closes the open indexes
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for name, index := range s.indexes {
+		errs = append(errs, index.Close())
+		delete(s.indexes, name)
+	}
+	return errors.Join(errs...)
+}
+
+// indexMapping indexes the search fields of a talk document: the conference slug, status, series ID and
+// review assignments as exact values, the review score as a number, the title, keywords, abstract and speaker names as analyzed text,
+// and the talk itself as a stored field returned with hits
+func indexMapping() mapping.IndexMapping {
+	exact := bleve.NewTextFieldMapping()
+	exact.Analyzer = keyword.Name
+	exact.Store = false
+	exact.IncludeInAll = false
+
+	text := bleve.NewTextFieldMapping()
+	text.Store = false
+	text.IncludeInAll = false
+
+	source := bleve.NewTextFieldMapping()
+	source.Index = false
+	source.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("conferenceSlug", exact)
+	doc.AddFieldMappingsAt("status", exact)
+	doc.AddFieldMappingsAt("seriesId", exact)
+	number := bleve.NewNumericFieldMapping()
+	number.Store = false
+	number.IncludeInAll = false
+
+	review := bleve.NewDocumentStaticMapping()
+	review.AddFieldMappingsAt("reviewers", exact)
+	review.AddFieldMappingsAt("pending", exact)
+	review.AddFieldMappingsAt("score", number)
+	doc.AddSubDocumentMapping("review", review)
+	for _, field := range []string{"title", "keywords", "abstract", "speakers"} {
+		doc.AddFieldMappingsAt(field, text)
+	}
+	doc.AddFieldMappingsAt("source", source)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	return m
+}
+
+// path returns the directory of the index, refusing names that would leave the store directory
+func (s *Store) path(indexName string) (string, error) {
+	if indexName == "" || indexName != filepath.Base(indexName) || strings.HasPrefix(indexName, ".") {
+		return "", fmt.Errorf("invalid index name %q", indexName)
+	}
+	return filepath.Join(s.dir, indexName), nil
+}
+
+// index returns the open index, opening it from disk if needed. Missing indexes are created when create
+// is set and reported as domain.ErrIndexNotFound otherwise.
+func (s *Store) index(indexName string, create bool) (bleve.Index, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index, ok := s.indexes[indexName]; ok {
+		return index, nil
+	}
+
+	path, err := s.path(indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		if !create {
+			return nil, fmt.Errorf("%w: %s", domain.ErrIndexNotFound, indexName)
+		}
+		index, err = newIndex(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index %s: %w", indexName, err)
+	}
+
+	s.indexes[indexName] = index
+	return index, nil
+}
+
+// newIndex creates an empty index at path with a new generation
+func newIndex(path string) (bleve.Index, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate index generation: %w", err)
+	}
+
+	index, err := bleve.New(path, indexMapping())
+	if err != nil {
+		return nil, err
+	}
+	if err := index.SetInternal([]byte(generationKey), []byte(hex.EncodeToString(b))); err != nil {
+		index.Close()
+		return nil, err
+	}
+	return index, nil
+}
+
+// BulkIndex writes the talks to the index in a single batch, creating the index if it does not exist.
+// Like the Elasticsearch client, documents are versioned by their last update time, and a talk already
+// stored with a newer version is reported as a conflict instead of being overwritten.
+func (s *Store) BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) (domain.BulkResult, error) {
+	if len(talks) == 0 {
+		s.logger.InfoContext(ctx, "no talks to index", "index", indexName)
+		return domain.BulkResult{}, nil
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	index, err := s.index(indexName, true)
+	if err != nil {
+		return domain.BulkResult{}, err
+	}
+
+	var result domain.BulkResult
+	batch := index.NewBatch()
+	for _, talk := range talks {
+		version, versioned := documentVersion(talk)
+		if versioned {
+			stored, err := index.GetInternal([]byte(docVersionPrefix + talk.ID))
+			if err != nil {
+				return domain.BulkResult{}, fmt.Errorf("failed to read version of talk %s: %w", talk.ID, err)
+			}
+			if n, err := strconv.ParseInt(string(stored), 10, 64); err == nil && n > version {
+				result.Conflicts = append(result.Conflicts, talk.ID)
+				continue
+			}
+			batch.SetInternal([]byte(docVersionPrefix+talk.ID), []byte(strconv.FormatInt(version, 10)))
+		}
+
+		doc, err := document(talk)
+		if err != nil {
+			return domain.BulkResult{}, err
+		}
+		if err := batch.Index(talk.ID, doc); err != nil {
+			return domain.BulkResult{}, fmt.Errorf("failed to index talk %s: %w", talk.ID, err)
+		}
+		result.Indexed++
+	}
+
+	count, err := indexVersionCount(index)
+	if err != nil {
+		return domain.BulkResult{}, err
+	}
+	batch.SetInternal([]byte(versionKey), []byte(strconv.FormatInt(count+int64(result.Indexed), 10)))
+
+	if err := index.Batch(batch); err != nil {
+		return domain.BulkResult{}, fmt.Errorf("failed to write talks to index %s: %w", indexName, err)
+	}
+
+	if len(result.Conflicts) > 0 {
+		s.logger.WarnContext(ctx, "skipped stale documents due to version conflicts", "index", indexName, "conflicts", len(result.Conflicts))
+	}
+	s.logger.InfoContext(ctx, "bulk indexed talks", "index", indexName, "count", result.Indexed)
+	return result, nil
+}
+
+// document returns the fields indexed for the talk, with the talk itself as the stored source
+func document(talk domain.Talk) (map[string]interface{}, error) {
+	source, err := json.Marshal(talk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal talk %s: %w", talk.ID, err)
+	}
+
+	names := make([]string, 0, len(talk.Speakers))
+	for _, speaker := range talk.Speakers {
+		names = append(names, speaker.Name)
+	}
+
+	doc := map[string]interface{}{
+		"conferenceSlug": talk.ConferenceSlug,
+		"status":         talk.Status,
+		"seriesId":       talk.SeriesID,
+		"title":          dataText(talk.Data["title"]),
+		"keywords":       dataText(talk.Data["keywords"]),
+		"abstract":       dataText(talk.Data["abstract"]),
+		"speakers":       strings.Join(names, " "),
+		"source":         string(source),
+	}
+	if talk.Review != nil {
+		review := map[string]interface{}{
+			"reviewers": talk.Review.Reviewers,
+			"pending":   talk.Review.Pending,
+		}
+		if talk.Review.Score != nil {
+			review["score"] = *talk.Review.Score
+		}
+		doc["review"] = review
+	}
+	return doc, nil
+}
+
+// documentVersion returns the version of a talk, derived from its last update time
+// (falling back to its creation time) in milliseconds
+func documentVersion(talk domain.Talk) (int64, bool) {
+	switch {
+	case talk.LastUpdated != nil:
+		return talk.LastUpdated.UnixMilli(), true
+	case talk.Created != nil:
+		return talk.Created.UnixMilli(), true
+	}
+	return 0, false
+}
+
+// dataText returns the searchable text of a talk data field: strings as they are, lists of strings
+// joined by spaces
+func dataText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, " ")
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if text, ok := item.(string); ok {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
+
+// indexVersionCount returns the number of documents written to the index since it was created
+func indexVersionCount(index bleve.Index) (int64, error) {
+	stored, err := index.GetInternal([]byte(versionKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read index version: %w", err)
+	}
+	if len(stored) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseInt(string(stored), 10, 64)
+}
+
+// DeleteIndex closes the index and removes its directory. Deleting an index that does not exist is not
+// an error.
+func (s *Store) DeleteIndex(ctx context.Context, indexName string) error {
+	path, err := s.path(indexName)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index, ok := s.indexes[indexName]; ok {
+		if err := index.Close(); err != nil {
+			return fmt.Errorf("failed to close index %s: %w", indexName, err)
+		}
+		delete(s.indexes, indexName)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to delete index %s: %w", indexName, err)
+	}
+
+	s.logger.InfoContext(ctx, "deleted index", "index", indexName)
+	return nil
+}
+
+// DeleteTalk removes the talk and its stored version from the index and returns the deleted talk, or nil
+// if the talk or the index does not exist. The index version is increased, like for written talks.
+func (s *Store) DeleteTalk(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	index, err := s.index(indexName, false)
+	if errors.Is(err, domain.ErrIndexNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req := bleve.NewSearchRequest(bleve.NewDocIDQuery([]string{talkID}))
+	req.Fields = []string{"source"}
+	res, err := index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read talk %s: %w", talkID, err)
+	}
+	if len(res.Hits) == 0 {
+		return nil, nil
+	}
+	source, _ := res.Hits[0].Fields["source"].(string)
+	var talk domain.Talk
+	if err := json.Unmarshal([]byte(source), &talk); err != nil {
+		return nil, fmt.Errorf("failed to parse talk %s: %w", talkID, err)
+	}
+
+	count, err := indexVersionCount(index)
+	if err != nil {
+		return nil, err
+	}
+	batch := index.NewBatch()
+	batch.Delete(talkID)
+	batch.DeleteInternal([]byte(docVersionPrefix + talkID))
+	batch.SetInternal([]byte(versionKey), []byte(strconv.FormatInt(count+1, 10)))
+	if err := index.Batch(batch); err != nil {
+		return nil, fmt.Errorf("failed to delete talk %s from index %s: %w", talkID, indexName, err)
+	}
+
+	s.logger.InfoContext(ctx, "deleted talk", "index", indexName, "talkID", talkID)
+	return &talk, nil
+}
+
+// CreateIndex creates a new, empty index. The Elasticsearch mapping is not used; every index gets the
+// Bleve mapping of the search fields.
+func (s *Store) CreateIndex(ctx context.Context, indexName string, mapping string) error {
+	exists, err := s.IndexExists(ctx, indexName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("create index error: index %s already exists", indexName)
+	}
+
+	if _, err := s.index(indexName, true); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "created index", "index", indexName)
+	return nil
+}
+
+// IndexExists checks if the index exists
+func (s *Store) IndexExists(ctx context.Context, indexName string) (bool, error) {
+	path, err := s.path(indexName)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check if index exists %s: %w", indexName, err)
+	}
+	return true, nil
+}
+
+// CheckHealth reports whether the store directory is accessible
+func (s *Store) CheckHealth(ctx context.Context) domain.DependencyHealth {
+	health := domain.DependencyHealth{Name: "bleve", Status: domain.HealthStatusOK}
+	start := time.Now()
+
+	_, err := os.Stat(s.dir)
+	health.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Status = domain.HealthStatusError
+		health.Error = err.Error()
+		return health
+	}
+
+	s.mu.Lock()
+	health.Details = map[string]interface{}{"openIndexes": len(s.indexes)}
+	s.mu.Unlock()
+	return health
+}
@@ -0,0 +1,172 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+)
+
+const (
+	// defaultFastlyAPIURL is the base URL of the Fastly API
+	defaultFastlyAPIURL = "https://api.fastly.com"
+
+	// defaultCloudflareAPIURL is the base URL of the Cloudflare v4 API
+	defaultCloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+
+	// cloudflareMaxFiles is the maximum number of URLs Cloudflare accepts per purge request
+	cloudflareMaxFiles = 30
+)
+
+// Client implements the CachePurger interface for the Fastly and Cloudflare purge APIs
+type Client struct {
+	provider   string
+	apiURL     string
+	publicURL  string
+	apiToken   string
+	zoneID     string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// New creates a new CDN Client, retrieving configuration from context
+func New(ctx context.Context) (*Client, error) {
+	cfg := config.GetConfig(ctx)
+
+	apiURL := ""
+	switch cfg.CDN.Provider {
+	case config.CDNProviderFastly:
+		apiURL = defaultFastlyAPIURL
+	case config.CDNProviderCloudflare:
+		apiURL = defaultCloudflareAPIURL
+	}
+
+	return NewWithHTTPClient(cfg.CDN, apiURL, &http.Client{Timeout: 30 * time.Second})
+}
+
+// NewWithHTTPClient creates a new CDN Client against the given API URL with a custom HTTP client.
+// This constructor is primarily intended for testing purposes.
+func NewWithHTTPClient(cfg config.CDNConfig, apiURL string, httpClient *http.Client) (*Client, error) {
+	switch cfg.Provider {
+	case config.CDNProviderFastly:
+	case config.CDNProviderCloudflare:
+		if cfg.ZoneID == "" {
+			return nil, fmt.Errorf("CDN zone ID is required for cloudflare")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported CDN provider: %q", cfg.Provider)
+	}
+
+	if cfg.PublicURL == "" {
+		return nil, fmt.Errorf("CDN public URL is required")
+	}
+	if cfg.APIToken == "" {
+		return nil, fmt.Errorf("CDN API token is required")
+	}
+
+	return &Client{
+		provider:   cfg.Provider,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		publicURL:  strings.TrimSuffix(cfg.PublicURL, "/"),
+		apiToken:   cfg.APIToken,
+		zoneID:     cfg.ZoneID,
+		httpClient: httpClient,
+		logger:     slog.Default().With("component", "cdn"),
+	}, nil
+}
+
+// Purge invalidates the given paths on the configured CDN
+func (c *Client) Purge(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	urls := make([]string, 0, len(paths))
+	for _, path := range paths {
+		urls = append(urls, c.publicURL+path)
+	}
+
+	var err error
+	switch c.provider {
+	case config.CDNProviderFastly:
+		err = c.purgeFastly(ctx, urls)
+	case config.CDNProviderCloudflare:
+		err = c.purgeCloudflare(ctx, urls)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.logger.InfoContext(ctx, "purged CDN cache", "provider", c.provider, "count", len(urls))
+	return nil
+}
+
+// purgeFastly purges each URL with a single-URL purge request
+func (c *Client) purgeFastly(ctx context.Context, urls []string) error {
+	for _, purgeURL := range urls {
+		u, err := url.Parse(purgeURL)
+		if err != nil {
+			return fmt.Errorf("failed to parse purge URL %s: %w", purgeURL, err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+"/purge/"+u.Host+u.EscapedPath(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Fastly-Key", c.apiToken)
+		req.Header.Set("Accept", "application/json")
+
+		if err := c.do(req); err != nil {
+			return fmt.Errorf("failed to purge %s: %w", purgeURL, err)
+		}
+	}
+	return nil
+}
+
+// purgeCloudflare purges the URLs in batches using the zone purge_cache endpoint
+func (c *Client) purgeCloudflare(ctx context.Context, urls []string) error {
+	for start := 0; start < len(urls); start += cloudflareMaxFiles {
+		end := min(start+cloudflareMaxFiles, len(urls))
+
+		body, err := json.Marshal(map[string]interface{}{"files": urls[start:end]})
+		if err != nil {
+			return fmt.Errorf("failed to marshal purge request: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+"/zones/"+c.zoneID+"/purge_cache", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		if err := c.do(req); err != nil {
+			return fmt.Errorf("failed to purge cloudflare cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// do executes a purge request and checks the response status
+func (c *Client) do(req *http.Request) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.CDNConfig
+		apiURL  string
+		wantErr string
+	}{
+		{
+			name:   "fastly",
+			cfg:    config.CDNConfig{Provider: "fastly", PublicURL: "https://sessions.example.com", APIToken: "token"},
+			apiURL: defaultFastlyAPIURL,
+		},
+		{
+			name:   "cloudflare",
+			cfg:    config.CDNConfig{Provider: "cloudflare", PublicURL: "https://sessions.example.com", APIToken: "token", ZoneID: "zone"},
+			apiURL: defaultCloudflareAPIURL,
+		},
+		{
+			name:    "unknown provider",
+			cfg:     config.CDNConfig{Provider: "akamai", PublicURL: "https://sessions.example.com", APIToken: "token"},
+			wantErr: "unsupported CDN provider",
+		},
+		{
+			name:    "cloudflare without zone",
+			cfg:     config.CDNConfig{Provider: "cloudflare", PublicURL: "https://sessions.example.com", APIToken: "token"},
+			wantErr: "zone ID is required",
+		},
+		{
+			name:    "missing public URL",
+			cfg:     config.CDNConfig{Provider: "fastly", APIToken: "token"},
+			wantErr: "public URL is required",
+		},
+		{
+			name:    "missing token",
+			cfg:     config.CDNConfig{Provider: "fastly", PublicURL: "https://sessions.example.com"},
+			wantErr: "API token is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.WithConfig(context.Background(), &config.Config{CDN: tt.cfg})
+
+			client, err := New(ctx)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.apiURL, client.apiURL)
+		})
+	}
+}
+
+func TestClient_Purge_Fastly(t *testing.T) {
+	var purged []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "token", r.Header.Get("Fastly-Key"))
+		purged = append(purged, r.URL.Path)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.CDNConfig{Provider: "fastly", PublicURL: "https://sessions.example.com/", APIToken: "token"}
+	client, err := NewWithHTTPClient(cfg, server.URL, server.Client())
+	require.NoError(t, err)
+
+	err = client.Purge(context.Background(), []string{"/api/conferences", "/public/allSessions/javazone2024"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/purge/sessions.example.com/api/conferences",
+		"/purge/sessions.example.com/public/allSessions/javazone2024",
+	}, purged)
+}
+
+func TestClient_Purge_Cloudflare(t *testing.T) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/purge_cache", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var body struct {
+			Files []string `json:"files"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		batches = append(batches, body.Files)
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	cfg := config.CDNConfig{Provider: "cloudflare", PublicURL: "https://sessions.example.com", APIToken: "token", ZoneID: "zone-1"}
+	client, err := NewWithHTTPClient(cfg, server.URL, server.Client())
+	require.NoError(t, err)
+
+	paths := make([]string, 0, 35)
+	for i := 0; i < 35; i++ {
+		paths = append(paths, fmt.Sprintf("/public/allSessions/conf%d", i))
+	}
+
+	require.NoError(t, client.Purge(context.Background(), paths))
+
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], cloudflareMaxFiles)
+	assert.Len(t, batches[1], 5)
+	assert.Equal(t, "https://sessions.example.com/public/allSessions/conf0", batches[0][0])
+}
+
+func TestClient_Purge_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"msg":"invalid token"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.CDNConfig{Provider: "fastly", PublicURL: "https://sessions.example.com", APIToken: "bad"}
+	client, err := NewWithHTTPClient(cfg, server.URL, server.Client())
+	require.NoError(t, err)
+
+	err = client.Purge(context.Background(), []string{"/api/conferences"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status code 403")
+}
+
+func TestClient_Purge_NoPaths(t *testing.T) {
+	cfg := config.CDNConfig{Provider: "fastly", PublicURL: "https://sessions.example.com", APIToken: "token"}
+	client, err := NewWithHTTPClient(cfg, "http://127.0.0.1:0", http.DefaultClient)
+	require.NoError(t, err)
+
+	assert.NoError(t, client.Purge(context.Background(), nil))
+}
@@ -0,0 +1,351 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+const (
+	// minBatchSize is the smallest batch the throttle shrinks to under backpressure
+	minBatchSize = 10
+
+	// minBatchBytes is the smallest byte budget the throttle shrinks to under backpressure
+	minBatchBytes = 256 * 1024
+
+	// maxRetryBackoff caps the wait between retries of rejected documents
+	maxRetryBackoff = 30 * time.Second
+)
+
+// BulkOptions controls how BulkIndex splits documents into bulk requests and backs off
+// when Elasticsearch rejects work because its write queue is full
+type BulkOptions struct {
+	// BatchSize is the maximum number of documents per bulk request
+	BatchSize int
+
+	// MaxBatchBytes is the byte budget per bulk request. Document sizes vary a lot (talks with long
+	// feedback threads are far larger than average), so batches are cut by size as well as count.
+	// A single document larger than the budget is sent on its own. It should stay below the cluster's
+	// http.max_content_length; a request refused as too large is split and sent again.
+	MaxBatchBytes int
+
+	// Concurrency is the maximum number of bulk requests in flight
+	Concurrency int
+
+	// MaxRetries is how many times a rejected document is retried before giving up
+	MaxRetries int
+
+	// RetryBackoff is the wait after the first rejection, doubled for each consecutive one
+	RetryBackoff time.Duration
+}
+
+// DefaultBulkOptions returns the bulk options used when none are configured
+func DefaultBulkOptions() BulkOptions {
+	return BulkOptions{
+		BatchSize:     500,
+		MaxBatchBytes: 5 * 1024 * 1024,
+		Concurrency:   2,
+		MaxRetries:    5,
+		RetryBackoff:  500 * time.Millisecond,
+	}
+}
+
+// SetBulkOptions overrides the bulk batching and backpressure settings
+func (c *Client) SetBulkOptions(opts BulkOptions) {
+	c.bulk = opts
+}
+
+// bulkDoc is a single encoded bulk operation (action and source lines)
+type bulkDoc struct {
+	id       string
+	lines    []byte
+	source   []byte
+	attempts int
+}
+
+// bulkOutcome is the result of a single bulk request
+type bulkOutcome struct {
+	indexed   int
+	conflicts []string
+	rejected  []bulkDoc
+	tooLarge  []bulkDoc
+	failed    []domain.FailedDocument
+	errors    []string
+}
+
+// batchBytes returns the size of the bulk request body for the documents
+func batchBytes(docs []bulkDoc) int {
+	size := 0
+	for _, doc := range docs {
+		size += len(doc.lines)
+	}
+	return size
+}
+
+// failedDocument returns the failure of a document with its source as payload
+func (d bulkDoc) failedDocument(reason string) domain.FailedDocument {
+	return domain.FailedDocument{ID: d.id, Payload: json.RawMessage(d.source), Error: reason}
+}
+
+// bulkThrottle adapts batch limits and concurrency to cluster load: it halves them when
+// Elasticsearch rejects documents and grows them back after requests succeed
+type bulkThrottle struct {
+	batchSize      int
+	batchBytes     int
+	concurrency    int
+	maxBatchSize   int
+	maxBatchBytes  int
+	maxConcurrency int
+}
+
+// newBulkThrottle creates a throttle starting at the configured limits
+func newBulkThrottle(opts BulkOptions) *bulkThrottle {
+	batchSize := max(opts.BatchSize, 1)
+	batchBytes := opts.MaxBatchBytes
+	if batchBytes <= 0 {
+		batchBytes = DefaultBulkOptions().MaxBatchBytes
+	}
+	concurrency := max(opts.Concurrency, 1)
+	return &bulkThrottle{
+		batchSize:      batchSize,
+		batchBytes:     batchBytes,
+		concurrency:    concurrency,
+		maxBatchSize:   batchSize,
+		maxBatchBytes:  batchBytes,
+		maxConcurrency: concurrency,
+	}
+}
+
+// backOff reduces the load sent to the cluster
+func (t *bulkThrottle) backOff() {
+	t.concurrency = max(t.concurrency/2, 1)
+	t.batchSize = max(t.batchSize/2, min(minBatchSize, t.maxBatchSize))
+	t.batchBytes = max(t.batchBytes/2, min(minBatchBytes, t.maxBatchBytes))
+}
+
+// recover gradually restores the configured limits
+func (t *bulkThrottle) recover() {
+	t.concurrency = min(t.concurrency+1, t.maxConcurrency)
+	t.batchSize = min(t.batchSize*2, t.maxBatchSize)
+	t.batchBytes = min(t.batchBytes*2, t.maxBatchBytes)
+}
+
+// limitBytes lowers the byte budget for good, after the cluster refused a request of twice the size
+func (t *bulkThrottle) limitBytes(budget int) {
+	t.maxBatchBytes = max(min(budget, t.maxBatchBytes), 1)
+	t.batchBytes = min(t.batchBytes, t.maxBatchBytes)
+}
+
+// nextBatch returns the length of the next batch: as many documents as fit within both the
+// document count and byte budget, but always at least one
+func (t *bulkThrottle) nextBatch(pending []bulkDoc) int {
+	n, size := 0, 0
+	for n < len(pending) && n < t.batchSize {
+		docSize := len(pending[n].lines)
+		if n > 0 && size+docSize > t.batchBytes {
+			break
+		}
+		size += docSize
+		n++
+	}
+	return n
+}
+
+// runBulk sends the documents in batches, retrying documents rejected with 429 (es_rejected_execution_exception)
+// with exponential backoff while reducing batch size and concurrency until the cluster recovers. Batches
+// refused with 413 are split until they fit. Documents that fail, including those of a failed request, or
+// are still rejected after the last retry, are returned in the result with their source, and the errors
+// of all batches are combined in the returned error.
+func (c *Client) runBulk(ctx context.Context, indexName string, docs []bulkDoc) (domain.BulkResult, error) {
+	throttle := newBulkThrottle(c.bulk)
+	result := domain.BulkResult{}
+	var errorDetails []string
+	backoff := c.bulk.RetryBackoff
+
+	pending := docs
+	for len(pending) > 0 {
+		var batches [][]bulkDoc
+		for len(batches) < throttle.concurrency && len(pending) > 0 {
+			n := throttle.nextBatch(pending)
+			batches = append(batches, pending[:n])
+			pending = pending[n:]
+		}
+
+		outcomes := make([]bulkOutcome, len(batches))
+		errs := make([]error, len(batches))
+		var wg sync.WaitGroup
+		for i, batch := range batches {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				outcomes[i], errs[i] = c.sendBulk(ctx, batch)
+			}()
+		}
+		wg.Wait()
+
+		var retry, resend []bulkDoc
+		for i, outcome := range outcomes {
+			// A failed request fails its own documents; the other batches still count
+			if errs[i] != nil {
+				if ctx.Err() != nil {
+					return result, ctx.Err()
+				}
+				reason := fmt.Sprintf("bulk request for %d documents failed: %v", len(batches[i]), errs[i])
+				for _, doc := range batches[i] {
+					result.Failed = append(result.Failed, doc.failedDocument(reason))
+				}
+				errorDetails = append(errorDetails, reason)
+				continue
+			}
+			result.Indexed += outcome.indexed
+			result.Conflicts = append(result.Conflicts, outcome.conflicts...)
+			result.Failed = append(result.Failed, outcome.failed...)
+			errorDetails = append(errorDetails, outcome.errors...)
+
+			// A request over the cluster's http.max_content_length is split and sent again right away
+			if len(outcome.tooLarge) == 1 {
+				doc := outcome.tooLarge[0]
+				reason := fmt.Sprintf("doc %s of %d bytes is larger than the cluster accepts in a bulk request", doc.id, len(doc.lines))
+				result.Failed = append(result.Failed, doc.failedDocument(reason))
+				errorDetails = append(errorDetails, reason)
+			} else if len(outcome.tooLarge) > 1 {
+				throttle.limitBytes(batchBytes(outcome.tooLarge) / 2)
+				resend = append(resend, outcome.tooLarge...)
+				c.logger.WarnContext(ctx, "elasticsearch refused bulk request as too large, splitting",
+					"index", indexName,
+					"documents", len(outcome.tooLarge),
+					"batchBytes", throttle.batchBytes,
+				)
+			}
+
+			for _, doc := range outcome.rejected {
+				doc.attempts++
+				if doc.attempts > c.bulk.MaxRetries {
+					reason := fmt.Sprintf("index rejected for doc %s after %d retries", doc.id, c.bulk.MaxRetries)
+					result.Failed = append(result.Failed, doc.failedDocument(reason))
+					errorDetails = append(errorDetails, reason)
+					continue
+				}
+				retry = append(retry, doc)
+			}
+		}
+		pending = append(resend, pending...)
+
+		if len(retry) == 0 {
+			throttle.recover()
+			backoff = c.bulk.RetryBackoff
+			continue
+		}
+
+		throttle.backOff()
+		c.logger.WarnContext(ctx, "elasticsearch rejected bulk documents, backing off",
+			"index", indexName,
+			"rejected", len(retry),
+			"batchSize", throttle.batchSize,
+			"batchBytes", throttle.batchBytes,
+			"concurrency", throttle.concurrency,
+			"backoff", backoff,
+		)
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+
+		pending = append(retry, pending...)
+	}
+
+	if len(errorDetails) > 0 {
+		return result, fmt.Errorf("bulk index had errors: %s", strings.Join(errorDetails, "; "))
+	}
+	return result, nil
+}
+
+// sendBulk executes a single bulk request. Documents rejected because the cluster is
+// overloaded are returned for retry rather than treated as errors.
+func (c *Client) sendBulk(ctx context.Context, batch []bulkDoc) (bulkOutcome, error) {
+	var buf bytes.Buffer
+	for _, doc := range batch {
+		buf.Write(doc.lines)
+	}
+
+	req := esapi.BulkRequest{
+		Body:    bytes.NewReader(buf.Bytes()),
+		Refresh: "true", // Make documents immediately available for search
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return bulkOutcome{}, fmt.Errorf("failed to execute bulk request: %w", err)
+	}
+	defer res.Body.Close()
+
+	// The whole request was rejected, e.g. by circuit breakers or a full queue
+	if res.StatusCode == http.StatusTooManyRequests {
+		io.Copy(io.Discard, res.Body)
+		return bulkOutcome{rejected: batch}, nil
+	}
+
+	// The request body exceeded http.max_content_length
+	if res.StatusCode == http.StatusRequestEntityTooLarge {
+		io.Copy(io.Discard, res.Body)
+		return bulkOutcome{tooLarge: batch}, nil
+	}
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return bulkOutcome{}, fmt.Errorf("bulk index error: %s - %s", res.Status(), string(body))
+	}
+
+	// Parse response to check for errors; items are returned in request order
+	var bulkResponse struct {
+		Items []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&bulkResponse); err != nil {
+		return bulkOutcome{}, fmt.Errorf("failed to parse bulk response: %w", err)
+	}
+
+	outcome := bulkOutcome{}
+	for i, item := range bulkResponse.Items {
+		for action, details := range item {
+			switch {
+			case details.Status == http.StatusConflict:
+				outcome.conflicts = append(outcome.conflicts, details.ID)
+			case details.Status == http.StatusTooManyRequests && i < len(batch):
+				outcome.rejected = append(outcome.rejected, batch[i])
+			case details.Status >= 400:
+				reason := fmt.Sprintf(
+					"%s failed for doc %s (status %d): %s - %s",
+					action, details.ID, details.Status, details.Error.Type, details.Error.Reason,
+				)
+				if i < len(batch) {
+					outcome.failed = append(outcome.failed, batch[i].failedDocument(reason))
+				}
+				outcome.errors = append(outcome.errors, reason)
+			default:
+				outcome.indexed++
+			}
+		}
+	}
+
+	return outcome, nil
+}
@@ -0,0 +1,343 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkRequestIDs extracts the document IDs from a bulk request body
+func bulkRequestIDs(t *testing.T, r *http.Request) []string {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+
+	var ids []string
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	for i := 0; i < len(lines); i += 2 {
+		var action map[string]map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &action))
+		ids = append(ids, action["index"]["_id"].(string))
+	}
+	return ids
+}
+
+// bulkItems builds bulk response items with the given status per document ID
+func bulkItems(ids []string, status func(id string) int) []map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		item := map[string]interface{}{"_id": id, "status": status(id)}
+		if status(id) == http.StatusTooManyRequests {
+			item["error"] = map[string]interface{}{"type": "es_rejected_execution_exception", "reason": "queue full"}
+		}
+		items = append(items, map[string]interface{}{"index": item})
+	}
+	return items
+}
+
+func testBulkOptions() BulkOptions {
+	return BulkOptions{BatchSize: 2, MaxBatchBytes: 1024 * 1024, Concurrency: 2, MaxRetries: 3, RetryBackoff: time.Millisecond}
+}
+
+func TestClient_BulkIndex_Batches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := bulkRequestIDs(t, r)
+		mu.Lock()
+		batches = append(batches, ids)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": bulkItems(ids, func(string) int { return http.StatusCreated }),
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(testBulkOptions())
+
+	result, err := client.BulkIndex(context.Background(), "test-index", createTestTalks(5))
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, result.Indexed)
+	require.Len(t, batches, 3)
+	for _, batch := range batches {
+		assert.LessOrEqual(t, len(batch), 2)
+	}
+}
+
+func TestClient_BulkIndex_RetriesRejectedDocuments(t *testing.T) {
+	var mu sync.Mutex
+	rejectedOnce := map[string]bool{}
+	requests := 0
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := bulkRequestIDs(t, r)
+
+		mu.Lock()
+		requests++
+		items := bulkItems(ids, func(id string) int {
+			// Reject every document the first time it is seen
+			if !rejectedOnce[id] {
+				rejectedOnce[id] = true
+				return http.StatusTooManyRequests
+			}
+			return http.StatusCreated
+		})
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"errors": true, "items": items})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(testBulkOptions())
+
+	result, err := client.BulkIndex(context.Background(), "test-index", createTestTalks(4))
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.Indexed)
+	assert.Greater(t, requests, 2)
+}
+
+func TestClient_BulkIndex_RequestRejected(t *testing.T) {
+	calls := 0
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := bulkRequestIDs(t, r)
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"type":"es_rejected_execution_exception"}}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": bulkItems(ids, func(string) int { return http.StatusCreated }),
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(BulkOptions{BatchSize: 10, Concurrency: 1, MaxRetries: 3, RetryBackoff: time.Millisecond})
+
+	result, err := client.BulkIndex(context.Background(), "test-index", createTestTalks(3))
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Indexed)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_BulkIndex_GivesUpAfterMaxRetries(t *testing.T) {
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := bulkRequestIDs(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": true,
+			"items":  bulkItems(ids, func(string) int { return http.StatusTooManyRequests }),
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(testBulkOptions())
+
+	result, err := client.BulkIndex(context.Background(), "test-index", createTestTalks(1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 3 retries")
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, "talk-1", result.Failed[0].ID)
+	assert.True(t, json.Valid(result.Failed[0].Payload))
+}
+
+func TestBulkThrottle(t *testing.T) {
+	throttle := newBulkThrottle(BulkOptions{BatchSize: 500, MaxBatchBytes: 4 * 1024 * 1024, Concurrency: 4})
+
+	throttle.backOff()
+	assert.Equal(t, 250, throttle.batchSize)
+	assert.Equal(t, 2*1024*1024, throttle.batchBytes)
+	assert.Equal(t, 2, throttle.concurrency)
+
+	for i := 0; i < 10; i++ {
+		throttle.backOff()
+	}
+	assert.Equal(t, minBatchSize, throttle.batchSize)
+	assert.Equal(t, minBatchBytes, throttle.batchBytes)
+	assert.Equal(t, 1, throttle.concurrency)
+
+	for i := 0; i < 10; i++ {
+		throttle.recover()
+	}
+	assert.Equal(t, 500, throttle.batchSize)
+	assert.Equal(t, 4*1024*1024, throttle.batchBytes)
+	assert.Equal(t, 4, throttle.concurrency)
+}
+
+func TestClient_BulkIndex_ByteBudget(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := bulkRequestIDs(t, r)
+		mu.Lock()
+		batches = append(batches, ids)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": bulkItems(ids, func(string) int { return http.StatusCreated }),
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(BulkOptions{BatchSize: 100, MaxBatchBytes: 8 * 1024, Concurrency: 1, MaxRetries: 1, RetryBackoff: time.Millisecond})
+
+	// One talk with a large feedback thread amid small ones
+	talks := createTestTalks(6)
+	talks[2].Data["feedback"] = strings.Repeat("x", 20*1024)
+
+	result, err := client.BulkIndex(context.Background(), "test-index", talks)
+	require.NoError(t, err)
+	assert.Equal(t, 6, result.Indexed)
+
+	assert.Equal(t, [][]string{{"talk-1", "talk-2"}, {"talk-3"}, {"talk-4", "talk-5", "talk-6"}}, batches)
+}
+
+func TestClient_BulkIndex_SplitsRequestsTooLarge(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Refuse bodies over the limit like http.max_content_length
+		if r.ContentLength > 3*1024 {
+			io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		ids := bulkRequestIDs(t, r)
+		mu.Lock()
+		sizes = append(sizes, len(ids))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": bulkItems(ids, func(string) int { return http.StatusCreated }),
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(BulkOptions{BatchSize: 100, MaxBatchBytes: 1024 * 1024, Concurrency: 1, MaxRetries: 1, RetryBackoff: time.Millisecond})
+
+	talks := createTestTalks(8)
+	for i := range talks {
+		talks[i].Data["feedback"] = strings.Repeat("x", 512)
+	}
+
+	result, err := client.BulkIndex(context.Background(), "test-index", talks)
+	require.NoError(t, err)
+	assert.Equal(t, 8, result.Indexed)
+	assert.Greater(t, len(sizes), 1)
+	for _, size := range sizes {
+		assert.Less(t, size, 8)
+	}
+}
+
+func TestClient_BulkIndex_DocumentTooLarge(t *testing.T) {
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > 4*1024 {
+			io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		ids := bulkRequestIDs(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": bulkItems(ids, func(string) int { return http.StatusCreated }),
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(testBulkOptions())
+
+	talks := createTestTalks(3)
+	talks[1].Data["feedback"] = strings.Repeat("x", 8*1024)
+
+	result, err := client.BulkIndex(context.Background(), "test-index", talks)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doc talk-2")
+	assert.Equal(t, 2, result.Indexed)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, "talk-2", result.Failed[0].ID)
+}
+
+func TestClient_BulkIndex_AggregatesRequestErrors(t *testing.T) {
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := bulkRequestIDs(t, r)
+		if ids[0] == "talk-1" || ids[0] == "talk-5" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":{"type":"internal_error"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": bulkItems(ids, func(string) int { return http.StatusCreated }),
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	client.SetBulkOptions(testBulkOptions())
+
+	result, err := client.BulkIndex(context.Background(), "test-index", createTestTalks(6))
+	require.Error(t, err)
+	assert.Equal(t, 2, strings.Count(err.Error(), "bulk request for 2 documents failed"))
+	assert.Equal(t, 2, result.Indexed)
+
+	failed := make([]string, 0, len(result.Failed))
+	for _, doc := range result.Failed {
+		failed = append(failed, doc.ID)
+	}
+	assert.ElementsMatch(t, []string{"talk-1", "talk-2", "talk-5", "talk-6"}, failed)
+}
+
+func TestBulkThrottle_NextBatch(t *testing.T) {
+	docs := func(sizes ...int) []bulkDoc {
+		result := make([]bulkDoc, 0, len(sizes))
+		for _, size := range sizes {
+			result = append(result, bulkDoc{lines: make([]byte, size)})
+		}
+		return result
+	}
+
+	throttle := newBulkThrottle(BulkOptions{BatchSize: 3, MaxBatchBytes: 100})
+
+	assert.Equal(t, 3, throttle.nextBatch(docs(10, 10, 10, 10)), "limited by count")
+	assert.Equal(t, 2, throttle.nextBatch(docs(40, 60, 10)), "limited by bytes")
+	assert.Equal(t, 1, throttle.nextBatch(docs(500, 10)), "oversized document goes alone")
+	assert.Equal(t, 1, throttle.nextBatch(docs(10, 500)), "oversized document is not added to a batch")
+}
+
+func TestBulkThrottle_SmallBatchSize(t *testing.T) {
+	throttle := newBulkThrottle(BulkOptions{BatchSize: 4, Concurrency: 0})
+
+	assert.Equal(t, 1, throttle.concurrency)
+	throttle.backOff()
+	assert.Equal(t, 4, throttle.batchSize, "batch size never shrinks below the smaller of the minimum and the configured size")
+}
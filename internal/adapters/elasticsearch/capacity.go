@@ -0,0 +1,61 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// DiskUsage returns the disk space of the data nodes summed from the shard allocation overview.
+// The row for unassigned shards has no disk values and is skipped.
+func (c *Client) DiskUsage(ctx context.Context) (domain.DiskUsage, error) {
+	req := esapi.CatAllocationRequest{
+		Format: "json",
+		H:      []string{"node", "disk.used", "disk.total"},
+		Bytes:  "b",
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return domain.DiskUsage{}, fmt.Errorf("failed to fetch disk allocation: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return domain.DiskUsage{}, fmt.Errorf("disk allocation error: %s - %s", res.Status(), string(body))
+	}
+
+	// _cat returns every value as a string, or null for the unassigned row
+	var rows []struct {
+		Node      string  `json:"node"`
+		DiskUsed  *string `json:"disk.used"`
+		DiskTotal *string `json:"disk.total"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil {
+		return domain.DiskUsage{}, fmt.Errorf("failed to parse disk allocation: %w", err)
+	}
+
+	var usage domain.DiskUsage
+	for _, row := range rows {
+		if row.DiskUsed == nil || row.DiskTotal == nil {
+			continue
+		}
+		used, err := strconv.ParseInt(*row.DiskUsed, 10, 64)
+		if err != nil {
+			return domain.DiskUsage{}, fmt.Errorf("invalid disk usage %q of node %s: %w", *row.DiskUsed, row.Node, err)
+		}
+		total, err := strconv.ParseInt(*row.DiskTotal, 10, 64)
+		if err != nil {
+			return domain.DiskUsage{}, fmt.Errorf("invalid disk total %q of node %s: %w", *row.DiskTotal, row.Node, err)
+		}
+		usage.UsedBytes += used
+		usage.TotalBytes += total
+	}
+	return usage, nil
+}
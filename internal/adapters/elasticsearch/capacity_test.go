@@ -0,0 +1,52 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DiskUsage(t *testing.T) {
+	t.Run("sums data nodes", func(t *testing.T) {
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/_cat/allocation", r.URL.Path)
+			assert.Equal(t, "b", r.URL.Query().Get("bytes"))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[
+				{"node": "es-1", "disk.used": "600", "disk.total": "1000"},
+				{"node": "es-2", "disk.used": "200", "disk.total": "1000"},
+				{"node": "UNASSIGNED", "disk.used": null, "disk.total": null}
+			]`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		usage, err := client.DiskUsage(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DiskUsage{TotalBytes: 2000, UsedBytes: 800}, usage)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": {"type": "security_exception"}}`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		_, err = client.DiskUsage(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "disk allocation error")
+	})
+}
@@ -1,7 +1,6 @@
 package elasticsearch
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,68 +8,81 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v9"
 	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/chaos"
 	"github.com/javaBin/talks-indexer/internal/config"
 	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/tlsconfig"
 )
 
 // Client implements the SearchIndex interface for Elasticsearch operations.
 type Client struct {
 	es     *elasticsearch.Client
+	bulk   BulkOptions
 	logger *slog.Logger
 }
 
 // New creates a new Elasticsearch client, retrieving configuration from context.
 func New(ctx context.Context) (*Client, error) {
-	appCfg := config.GetConfig(ctx)
+	return newFromConfig(ctx, false)
+}
 
-	esCfg := elasticsearch.Config{
-		Addresses: []string{appCfg.Elasticsearch.URL},
-	}
+// NewOpenSearch creates a client for an OpenSearch cluster, such as AWS OpenSearch Service, retrieving
+// configuration from context. The cluster is configured with the ELASTICSEARCH_ settings.
+func NewOpenSearch(ctx context.Context) (*Client, error) {
+	return newFromConfig(ctx, true)
+}
 
-	// Add authentication if credentials are provided
-	if appCfg.Elasticsearch.HasCredentials() {
-		esCfg.Username = appCfg.Elasticsearch.User
-		esCfg.Password = appCfg.Elasticsearch.Password
-	}
+// newFromConfig creates a client from the configuration in the context, talking to OpenSearch if set
+func newFromConfig(ctx context.Context, openSearch bool) (*Client, error) {
+	appCfg := config.GetConfig(ctx)
 
-	es, err := elasticsearch.NewClient(esCfg)
+	transport, err := tlsconfig.Transport(appCfg.Elasticsearch.TLS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create elasticsearch client: %w", err)
+		return nil, fmt.Errorf("failed to configure elasticsearch TLS: %w", err)
 	}
-
-	// Verify connection
-	res, err := es.Info()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to elasticsearch: %w", err)
+	if appCfg.Chaos.Elasticsearch.IsConfigured() {
+		transport = chaos.NewTransport("elasticsearch", transport, appCfg.Chaos.Elasticsearch)
 	}
-	defer res.Body.Close()
-
-	if res.IsError() {
-		body, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("elasticsearch connection error: %s - %s", res.Status(), string(body))
+	if openSearch {
+		transport = NewOpenSearchTransport(transport)
 	}
 
-	logger := slog.Default().With("component", "elasticsearch")
-	logger.Info("connected to elasticsearch", "url", appCfg.Elasticsearch.URL, "authenticated", appCfg.Elasticsearch.HasCredentials())
+	client, err := NewWithTransport(appCfg.Elasticsearch.URL, appCfg.Elasticsearch.User, appCfg.Elasticsearch.Password, transport)
+	if err != nil {
+		return nil, err
+	}
 
-	return &Client{
-		es:     es,
-		logger: logger,
-	}, nil
+	client.SetBulkOptions(BulkOptions{
+		BatchSize:     appCfg.Elasticsearch.BulkBatchSize,
+		MaxBatchBytes: appCfg.Elasticsearch.BulkMaxBatchBytes,
+		Concurrency:   appCfg.Elasticsearch.BulkConcurrency,
+		MaxRetries:    appCfg.Elasticsearch.BulkMaxRetries,
+		RetryBackoff:  appCfg.Elasticsearch.BulkRetryBackoff,
+	})
+	return client, nil
 }
 
-// NewWithURL creates a new Elasticsearch client with explicit URL and credentials.
-// This constructor is primarily intended for testing purposes.
+// NewWithURL creates a new Elasticsearch client with explicit URL and credentials and the default
+// bulk options. This constructor is primarily intended for testing purposes.
 func NewWithURL(elasticsearchURL, username, password string) (*Client, error) {
+	return NewWithTransport(elasticsearchURL, username, password, nil)
+}
+
+// NewWithTransport creates a new Elasticsearch client like NewWithURL, sending requests through the
+// given transport (the default transport if nil)
+func NewWithTransport(elasticsearchURL, username, password string, transport http.RoundTripper) (*Client, error) {
 	cfg := elasticsearch.Config{
 		Addresses: []string{elasticsearchURL},
+		Transport: transport,
 	}
 
 	// Add authentication if credentials are provided
-	if username != "" && password != "" {
+	authenticated := username != "" && password != ""
+	if authenticated {
 		cfg.Username = username
 		cfg.Password = password
 	}
@@ -93,106 +105,84 @@ func NewWithURL(elasticsearchURL, username, password string) (*Client, error) {
 	}
 
 	logger := slog.Default().With("component", "elasticsearch")
-	logger.Info("connected to elasticsearch", "url", elasticsearchURL, "authenticated", username != "")
+	logger.Info("connected to elasticsearch", "url", elasticsearchURL, "authenticated", authenticated)
 
 	return &Client{
 		es:     es,
+		bulk:   DefaultBulkOptions(),
 		logger: logger,
 	}, nil
 }
 
 // BulkIndex indexes multiple talks into the specified index using the Bulk API.
-// Each talk is indexed with its ID as the document ID.
-func (c *Client) BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) error {
+// Each talk is indexed with its ID as the document ID and, when known, its last update time
+// as an external version, so an older payload can never overwrite a newer document.
+// Version conflicts are reported in the result instead of failing the request.
+// Documents that could not be indexed are returned in the result along with the error.
+// Documents are sent in batches which shrink when the cluster signals backpressure.
+func (c *Client) BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) (domain.BulkResult, error) {
 	if len(talks) == 0 {
-		c.logger.Info("no talks to index", "index", indexName)
-		return nil
+		c.logger.InfoContext(ctx, "no talks to index", "index", indexName)
+		return domain.BulkResult{}, nil
 	}
 
-	var buf bytes.Buffer
-
-	// Build bulk request body
+	docs := make([]bulkDoc, 0, len(talks))
 	for _, talk := range talks {
 		// Action metadata
-		meta := map[string]interface{}{
-			"index": map[string]interface{}{
-				"_index": indexName,
-				"_id":    talk.ID,
-			},
+		action := map[string]interface{}{
+			"_index": indexName,
+			"_id":    talk.ID,
 		}
-		metaJSON, err := json.Marshal(meta)
+		if version, ok := documentVersion(talk); ok {
+			action["version"] = version
+			action["version_type"] = "external_gte"
+		}
+		metaJSON, err := json.Marshal(map[string]interface{}{"index": action})
 		if err != nil {
-			return fmt.Errorf("failed to marshal bulk metadata for talk %s: %w", talk.ID, err)
+			return domain.BulkResult{}, fmt.Errorf("failed to marshal bulk metadata for talk %s: %w", talk.ID, err)
 		}
 
 		// Document body
-		docJSON, err := json.Marshal(talk)
+		docJSON, err := json.Marshal(talkDocument{Talk: talk, Suggest: suggestInputs(talk)})
 		if err != nil {
-			return fmt.Errorf("failed to marshal talk %s: %w", talk.ID, err)
+			return domain.BulkResult{}, fmt.Errorf("failed to marshal talk %s: %w", talk.ID, err)
 		}
 
-		// Write to buffer (each line must be newline-delimited)
-		buf.Write(metaJSON)
-		buf.WriteByte('\n')
-		buf.Write(docJSON)
-		buf.WriteByte('\n')
-	}
-
-	// Execute bulk request
-	req := esapi.BulkRequest{
-		Body:    bytes.NewReader(buf.Bytes()),
-		Refresh: "true", // Make documents immediately available for search
+		// Each line must be newline-delimited
+		lines := make([]byte, 0, len(metaJSON)+len(docJSON)+2)
+		lines = append(lines, metaJSON...)
+		lines = append(lines, '\n')
+		lines = append(lines, docJSON...)
+		lines = append(lines, '\n')
+		docs = append(docs, bulkDoc{id: talk.ID, lines: lines, source: lines[len(metaJSON)+1 : len(lines)-1]})
 	}
 
-	res, err := req.Do(ctx, c.es)
+	result, err := c.runBulk(ctx, indexName, docs)
 	if err != nil {
-		return fmt.Errorf("failed to execute bulk request: %w", err)
+		return result, err
 	}
-	defer res.Body.Close()
 
-	if res.IsError() {
-		body, _ := io.ReadAll(res.Body)
-		return fmt.Errorf("bulk index error: %s - %s", res.Status(), string(body))
-	}
-
-	// Parse response to check for errors
-	var bulkResponse struct {
-		Errors bool `json:"errors"`
-		Items  []map[string]struct {
-			ID     string `json:"_id"`
-			Status int    `json:"status"`
-			Error  struct {
-				Type   string `json:"type"`
-				Reason string `json:"reason"`
-			} `json:"error"`
-		} `json:"items"`
-	}
-
-	if err := json.NewDecoder(res.Body).Decode(&bulkResponse); err != nil {
-		return fmt.Errorf("failed to parse bulk response: %w", err)
-	}
-
-	if bulkResponse.Errors {
-		// Collect error details
-		var errorDetails []string
-		for _, item := range bulkResponse.Items {
-			for action, details := range item {
-				if details.Status >= 400 {
-					errorDetails = append(errorDetails, fmt.Sprintf(
-						"%s failed for doc %s (status %d): %s - %s",
-						action, details.ID, details.Status, details.Error.Type, details.Error.Reason,
-					))
-				}
-			}
-		}
-		return fmt.Errorf("bulk index had errors: %s", strings.Join(errorDetails, "; "))
+	if len(result.Conflicts) > 0 {
+		c.logger.WarnContext(ctx, "skipped stale documents due to version conflicts", "index", indexName, "conflicts", len(result.Conflicts))
 	}
+	c.logger.InfoContext(ctx, "bulk indexed talks", "index", indexName, "count", result.Indexed)
+	return result, nil
+}
 
-	c.logger.Info("bulk indexed talks", "index", indexName, "count", len(talks))
-	return nil
+// documentVersion returns the external version of a talk, derived from its last update time
+// (falling back to its creation time) in milliseconds
+func documentVersion(talk domain.Talk) (int64, bool) {
+	switch {
+	case talk.LastUpdated != nil:
+		return talk.LastUpdated.UnixMilli(), true
+	case talk.Created != nil:
+		return talk.Created.UnixMilli(), true
+	}
+	return 0, false
 }
 
-// DeleteIndex removes an index from Elasticsearch.
+// DeleteIndex removes an index from Elasticsearch. If the name is an alias, as left by a republish,
+// the indexes behind the alias are removed, so a full reindex can recreate the index under that name.
 func (c *Client) DeleteIndex(ctx context.Context, indexName string) error {
 	req := esapi.IndicesDeleteRequest{
 		Index: []string{indexName},
@@ -207,15 +197,35 @@ func (c *Client) DeleteIndex(ctx context.Context, indexName string) error {
 	if res.IsError() {
 		// 404 is acceptable - index already doesn't exist
 		if res.StatusCode == http.StatusNotFound {
-			c.logger.Info("index does not exist (already deleted)", "index", indexName)
+			c.logger.InfoContext(ctx, "index does not exist (already deleted)", "index", indexName)
 			return nil
 		}
 
 		body, _ := io.ReadAll(res.Body)
+		if res.StatusCode == http.StatusBadRequest && strings.Contains(string(body), "matches an alias") {
+			return c.deleteAliasedIndexes(ctx, indexName)
+		}
 		return fmt.Errorf("delete index error: %s - %s", res.Status(), string(body))
 	}
 
-	c.logger.Info("deleted index", "index", indexName)
+	c.logger.InfoContext(ctx, "deleted index", "index", indexName)
+	return nil
+}
+
+// deleteAliasedIndexes removes the indexes the alias points to, and with them the alias
+func (c *Client) deleteAliasedIndexes(ctx context.Context, alias string) error {
+	current, err := c.getAliases(ctx, esapi.IndicesGetAliasRequest{Name: []string{alias}})
+	if err != nil {
+		return err
+	}
+
+	for index := range current {
+		if err := c.DeleteIndex(ctx, index); err != nil {
+			return err
+		}
+	}
+
+	c.logger.InfoContext(ctx, "deleted indexes behind alias", "alias", alias, "count", len(current))
 	return nil
 }
 
@@ -237,7 +247,7 @@ func (c *Client) CreateIndex(ctx context.Context, indexName string, mapping stri
 		return fmt.Errorf("create index error: %s - %s", res.Status(), string(body))
 	}
 
-	c.logger.Info("created index", "index", indexName)
+	c.logger.InfoContext(ctx, "created index", "index", indexName)
 	return nil
 }
 
@@ -265,3 +275,45 @@ func (c *Client) IndexExists(ctx context.Context, indexName string) (bool, error
 	body, _ := io.ReadAll(res.Body)
 	return false, fmt.Errorf("index exists check error: %s - %s", res.Status(), string(body))
 }
+
+// CheckHealth reports the cluster health. A red cluster is reported as an error.
+func (c *Client) CheckHealth(ctx context.Context) domain.DependencyHealth {
+	health := domain.DependencyHealth{Name: "elasticsearch", Status: domain.HealthStatusOK}
+	start := time.Now()
+
+	res, err := esapi.ClusterHealthRequest{}.Do(ctx, c.es)
+	health.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Status = domain.HealthStatusError
+		health.Error = err.Error()
+		return health
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		health.Status = domain.HealthStatusError
+		health.Error = res.Status()
+		return health
+	}
+
+	var cluster struct {
+		ClusterName   string `json:"cluster_name"`
+		Status        string `json:"status"`
+		NumberOfNodes int    `json:"number_of_nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&cluster); err != nil {
+		health.Status = domain.HealthStatusError
+		health.Error = fmt.Sprintf("failed to parse cluster health: %v", err)
+		return health
+	}
+
+	health.Details = map[string]interface{}{
+		"clusterName":   cluster.ClusterName,
+		"clusterStatus": cluster.Status,
+		"nodes":         cluster.NumberOfNodes,
+	}
+	if cluster.Status == "red" {
+		health.Status = domain.HealthStatusError
+	}
+	return health
+}
@@ -225,6 +225,30 @@ func TestClient_DeleteIndex(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "delete index error")
 	})
+
+	t.Run("alias", func(t *testing.T) {
+		var deleted []string
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == "DELETE" && r.URL.Path == "/test-index":
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"type":"illegal_argument_exception","reason":"The provided expression [test-index] matches an alias, specify the corresponding concrete indices instead."}}`))
+			case r.Method == "GET" && r.URL.Path == "/_alias/test-index":
+				w.Write([]byte(`{"test-index_20250101120000": {"aliases": {"test-index": {}}}}`))
+			case r.Method == "DELETE":
+				deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/"))
+				w.Write([]byte(`{"acknowledged":true}`))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		require.NoError(t, client.DeleteIndex(context.Background(), "test-index"))
+		assert.Equal(t, []string{"test-index_20250101120000"}, deleted)
+	})
 }
 
 func TestClient_IndexExists(t *testing.T) {
@@ -318,7 +342,7 @@ func TestClient_BulkIndex(t *testing.T) {
 		require.NoError(t, err)
 
 		talks := createTestTalks(2)
-		err = client.BulkIndex(context.Background(), "test-index", talks)
+		_, err = client.BulkIndex(context.Background(), "test-index", talks)
 		assert.NoError(t, err)
 
 		// Verify bulk request format
@@ -326,6 +350,7 @@ func TestClient_BulkIndex(t *testing.T) {
 		assert.Contains(t, receivedBody, `"_id":"talk-1"`)
 		assert.Contains(t, receivedBody, `"_id":"talk-2"`)
 		assert.Contains(t, receivedBody, `"title":"Test Talk 1"`)
+		assert.Contains(t, receivedBody, `"suggest":["Test Talk 1","John Doe","java","testing"]`)
 	})
 
 	t.Run("empty talks array", func(t *testing.T) {
@@ -335,7 +360,7 @@ func TestClient_BulkIndex(t *testing.T) {
 		client, err := NewWithURL(server.URL, "", "")
 		require.NoError(t, err)
 
-		err = client.BulkIndex(context.Background(), "test-index", []domain.Talk{})
+		_, err = client.BulkIndex(context.Background(), "test-index", []domain.Talk{})
 		assert.NoError(t, err) // Should not error for empty array
 	})
 
@@ -375,11 +400,19 @@ func TestClient_BulkIndex(t *testing.T) {
 		require.NoError(t, err)
 
 		talks := createTestTalks(2)
-		err = client.BulkIndex(context.Background(), "test-index", talks)
+		result, err := client.BulkIndex(context.Background(), "test-index", talks)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "bulk index had errors")
 		assert.Contains(t, err.Error(), "mapper_parsing_exception")
 		assert.Contains(t, err.Error(), "talk-2")
+
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, "talk-2", result.Failed[0].ID)
+		assert.Contains(t, result.Failed[0].Error, "mapper_parsing_exception")
+		var payload domain.Talk
+		require.NoError(t, json.Unmarshal(result.Failed[0].Payload, &payload))
+		assert.Equal(t, talks[1].ID, payload.ID)
+		assert.Equal(t, talks[1].Data["title"], payload.Data["title"])
 	})
 
 	t.Run("http error response", func(t *testing.T) {
@@ -395,7 +428,7 @@ func TestClient_BulkIndex(t *testing.T) {
 		require.NoError(t, err)
 
 		talks := createTestTalks(1)
-		err = client.BulkIndex(context.Background(), "test-index", talks)
+		_, err = client.BulkIndex(context.Background(), "test-index", talks)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "bulk index error")
 	})
@@ -424,7 +457,7 @@ func TestClient_BulkIndexFormat(t *testing.T) {
 		require.NoError(t, err)
 
 		talks := createTestTalks(1)
-		err = client.BulkIndex(context.Background(), "test-index", talks)
+		_, err = client.BulkIndex(context.Background(), "test-index", talks)
 		require.NoError(t, err)
 
 		// Bulk API format: action_and_meta_data\n + optional_source\n
@@ -518,3 +551,303 @@ func createTestTalks(count int) []domain.Talk {
 
 	return talks
 }
+
+func TestClient_FetchTalks(t *testing.T) {
+	t.Run("fetches talks for a conference", func(t *testing.T) {
+		var receivedBody map[string]interface{}
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" && r.URL.Path == "/test-index/_search" {
+				json.NewDecoder(r.Body).Decode(&receivedBody)
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"hits": map[string]interface{}{
+						"hits": []map[string]interface{}{
+							{
+								"_id":     "talk-1",
+								"_source": map[string]interface{}{"id": "talk-1", "conferenceSlug": "javazone", "status": "APPROVED"},
+								"sort":    []interface{}{"talk-1"},
+							},
+						},
+					},
+				})
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		talks, err := client.FetchTalks(context.Background(), "test-index", "javazone")
+		require.NoError(t, err)
+		require.Len(t, talks, 1)
+		assert.Equal(t, "talk-1", talks[0].ID)
+		assert.Equal(t, "APPROVED", talks[0].Status)
+
+		query := receivedBody["query"].(map[string]interface{})
+		assert.Equal(t, "javazone", query["term"].(map[string]interface{})["conferenceSlug"])
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"index_not_found_exception"}`))
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		talks, err := client.FetchTalks(context.Background(), "missing-index", "")
+		assert.Error(t, err)
+		assert.Nil(t, talks)
+		assert.Contains(t, err.Error(), "search error")
+	})
+}
+
+func TestClient_ListTalkIDs(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/test-index/_search" {
+			json.NewDecoder(r.Body).Decode(&receivedBody)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"hits": map[string]interface{}{
+					"hits": []map[string]interface{}{
+						{"_id": "talk-1", "sort": []interface{}{"talk-1"}},
+						{"_id": "talk-2", "sort": []interface{}{"talk-2"}},
+					},
+				},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+
+	ids, err := client.ListTalkIDs(context.Background(), "test-index", "conf-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"talk-1", "talk-2"}, ids)
+
+	assert.Equal(t, false, receivedBody["_source"], "only the IDs are read")
+	query := receivedBody["query"].(map[string]interface{})
+	assert.Equal(t, "conf-1", query["term"].(map[string]interface{})["conferenceId"])
+}
+
+func TestClient_ListConferences(t *testing.T) {
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/public/_search" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"hits": map[string]interface{}{"hits": []interface{}{}},
+				"aggregations": map[string]interface{}{
+					"conferences": map[string]interface{}{
+						"buckets": []map[string]interface{}{
+							{
+								"key":       "javazone2024",
+								"doc_count": 120,
+								"conference": map[string]interface{}{
+									"hits": map[string]interface{}{
+										"hits": []map[string]interface{}{
+											{"_id": "talk-1", "_source": map[string]interface{}{"conferenceId": "conf-1", "conferenceName": "JavaZone 2024"}},
+										},
+									},
+								},
+							},
+							{
+								"key":       "javazone2025",
+								"doc_count": 80,
+								"conference": map[string]interface{}{
+									"hits": map[string]interface{}{
+										"hits": []map[string]interface{}{
+											{"_id": "talk-2", "_source": map[string]interface{}{
+												"conferenceId":   "conf-2",
+												"conferenceName": "JavaZone 2025",
+												"conference":     map[string]interface{}{"venue": "Nova Spektrum", "startDate": "2025-09-03", "endDate": "2025-09-04"},
+											}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+
+	conferences, err := client.ListConferences(context.Background(), "public")
+	require.NoError(t, err)
+	require.Len(t, conferences, 2)
+	assert.Equal(t, domain.ConferenceSummary{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024", TalkCount: 120}, conferences[0])
+	assert.Equal(t, domain.ConferenceSummary{
+		ID: "conf-2", Name: "JavaZone 2025", Slug: "javazone2025", TalkCount: 80,
+		ConferenceMetadata: domain.ConferenceMetadata{Venue: "Nova Spektrum", StartDate: "2025-09-03", EndDate: "2025-09-04"},
+	}, conferences[1])
+}
+
+func TestClient_IndexVersion(t *testing.T) {
+	t.Run("returns generation and version", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" && r.URL.Path == "/public/_stats/docs,indexing" {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"indices": map[string]interface{}{
+						"public": map[string]interface{}{
+							"uuid": "abc123",
+							"primaries": map[string]interface{}{
+								"docs":     map[string]interface{}{"count": 10},
+								"indexing": map[string]interface{}{"index_total": 12, "delete_total": 2},
+							},
+						},
+					},
+				})
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		version, err := client.IndexVersion(context.Background(), "public")
+		require.NoError(t, err)
+		assert.Equal(t, domain.IndexVersion{Index: "public", Generation: "abc123", Version: 14, DocCount: 10}, version)
+	})
+
+	t.Run("stats error", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"index_not_found_exception"}`))
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		_, err = client.IndexVersion(context.Background(), "missing")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "index stats error")
+	})
+}
+
+func TestClient_BulkIndexVersioning(t *testing.T) {
+	t.Run("uses last updated time as external version", func(t *testing.T) {
+		var receivedLines []string
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" && r.URL.Path == "/_bulk" {
+				bodyBytes, _ := io.ReadAll(r.Body)
+				receivedLines = strings.Split(strings.TrimSpace(string(bodyBytes)), "\n")
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"errors": false,
+					"items": []map[string]interface{}{
+						{"index": map[string]interface{}{"_id": "talk-1", "status": 201}},
+						{"index": map[string]interface{}{"_id": "talk-2", "status": 201}},
+					},
+				})
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		talks := createTestTalks(2)
+		talks[1].LastUpdated = nil
+		talks[1].Created = nil
+
+		result, err := client.BulkIndex(context.Background(), "test-index", talks)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Indexed)
+		assert.Empty(t, result.Conflicts)
+
+		require.Len(t, receivedLines, 4)
+
+		var versioned map[string]map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(receivedLines[0]), &versioned))
+		assert.Equal(t, "external_gte", versioned["index"]["version_type"])
+		assert.Equal(t, float64(talks[0].LastUpdated.UnixMilli()), versioned["index"]["version"])
+
+		var unversioned map[string]map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(receivedLines[2]), &unversioned))
+		assert.NotContains(t, unversioned["index"], "version")
+		assert.NotContains(t, unversioned["index"], "version_type")
+	})
+
+	t.Run("reports version conflicts without failing", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" && r.URL.Path == "/_bulk" {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"errors": true,
+					"items": []map[string]interface{}{
+						{"index": map[string]interface{}{"_id": "talk-1", "status": 200}},
+						{"index": map[string]interface{}{
+							"_id":    "talk-2",
+							"status": 409,
+							"error": map[string]interface{}{
+								"type":   "version_conflict_engine_exception",
+								"reason": "current version is higher than the one provided",
+							},
+						}},
+					},
+				})
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		result, err := client.BulkIndex(context.Background(), "test-index", createTestTalks(2))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Indexed)
+		assert.Equal(t, []string{"talk-2"}, result.Conflicts)
+	})
+}
+
+func TestClient_CheckHealth(t *testing.T) {
+	tests := []struct {
+		name          string
+		clusterStatus string
+		expected      string
+	}{
+		{name: "green cluster", clusterStatus: "green", expected: domain.HealthStatusOK},
+		{name: "yellow cluster", clusterStatus: "yellow", expected: domain.HealthStatusOK},
+		{name: "red cluster", clusterStatus: "red", expected: domain.HealthStatusError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/_cluster/health" {
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"cluster_name":    "javabin",
+						"status":          tt.clusterStatus,
+						"number_of_nodes": 3,
+					})
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewWithURL(server.URL, "", "")
+			require.NoError(t, err)
+
+			health := client.CheckHealth(context.Background())
+			assert.Equal(t, "elasticsearch", health.Name)
+			assert.Equal(t, tt.expected, health.Status)
+			assert.Equal(t, "javabin", health.Details["clusterName"])
+			assert.Equal(t, tt.clusterStatus, health.Details["clusterStatus"])
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// maxListedDeadLetters bounds the number of dead letters returned when listing without a limit
+const maxListedDeadLetters = 1000
+
+// DeadLetterStore implements ports.DeadLetterStore with one document per dead letter in a dedicated index,
+// using the dead letter ID as the document ID. The index is created with DeadLettersIndexMapping on first write.
+type DeadLetterStore struct {
+	client    *Client
+	indexName string
+	index     *lazyIndex
+}
+
+// NewDeadLetterStore creates a dead-letter store backed by the given index
+func NewDeadLetterStore(client *Client, indexName string) *DeadLetterStore {
+	return &DeadLetterStore{
+		client:    client,
+		indexName: indexName,
+		index:     newLazyIndex(client, indexName, DeadLettersIndexMapping),
+	}
+}
+
+// SaveDeadLetter stores a dead letter, replacing any dead letter with the same ID
+func (s *DeadLetterStore) SaveDeadLetter(ctx context.Context, letter domain.DeadLetter) error {
+	if letter.ID == "" {
+		return fmt.Errorf("cannot save dead letter without ID")
+	}
+	if err := s.index.ensure(ctx); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter %s: %w", letter.ID, err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      s.indexName,
+		DocumentID: url.PathEscape(letter.ID),
+		Body:       bytes.NewReader(body),
+		Refresh:    "wait_for",
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return fmt.Errorf("failed to save dead letter %s: %w", letter.ID, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("save dead letter error: %s - %s", res.Status(), string(resBody))
+	}
+
+	return nil
+}
+
+// GetDeadLetter retrieves a dead letter by ID, returns nil if it or the dead-letter index does not exist
+func (s *DeadLetterStore) GetDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	req := esapi.GetRequest{
+		Index:      s.indexName,
+		DocumentID: url.PathEscape(id),
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letter %s: %w", id, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("get dead letter error: %s - %s", res.Status(), string(body))
+	}
+
+	var doc struct {
+		Source domain.DeadLetter `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse dead letter %s: %w", id, err)
+	}
+
+	return &doc.Source, nil
+}
+
+// ListDeadLetters returns up to limit dead letters, most recent failures first.
+// A limit of zero or less returns up to maxListedDeadLetters dead letters.
+func (s *DeadLetterStore) ListDeadLetters(ctx context.Context, limit int) ([]domain.DeadLetter, error) {
+	if err := s.index.ensure(ctx); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxListedDeadLetters {
+		limit = maxListedDeadLetters
+	}
+
+	page, err := s.client.search(ctx, s.indexName, map[string]interface{}{
+		"size":  limit,
+		"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+		"sort":  []interface{}{map[string]interface{}{"failedAt": "desc"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]domain.DeadLetter, 0, len(page.Hits.Hits))
+	for _, hit := range page.Hits.Hits {
+		var letter domain.DeadLetter
+		if err := json.Unmarshal(hit.Source, &letter); err != nil {
+			return nil, fmt.Errorf("failed to parse dead letter %s: %w", hit.ID, err)
+		}
+		letters = append(letters, letter)
+	}
+
+	return letters, nil
+}
+
+// DeleteDeadLetter removes a dead letter; a missing dead letter or dead-letter index is not an error
+func (s *DeadLetterStore) DeleteDeadLetter(ctx context.Context, id string) error {
+	req := esapi.DeleteRequest{
+		Index:      s.indexName,
+		DocumentID: url.PathEscape(id),
+		Refresh:    "wait_for",
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return fmt.Errorf("failed to delete dead letter %s: %w", id, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("delete dead letter error: %s - %s", res.Status(), string(body))
+	}
+
+	return nil
+}
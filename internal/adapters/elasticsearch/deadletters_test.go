@@ -0,0 +1,116 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterStore_SaveGetDelete(t *testing.T) {
+	var mu sync.Mutex
+	docs := make(map[string][]byte)
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimPrefix(r.URL.EscapedPath(), "/dead-letters/_doc/")
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/dead-letters":
+			// index exists
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/dead-letters/_doc/"):
+			body, _ := io.ReadAll(r.Body)
+			docs[id] = body
+			w.Write([]byte(`{"result":"created"}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/dead-letters/_doc/"):
+			doc, ok := docs[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"found":false}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"found": true, "_source": json.RawMessage(doc)})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/dead-letters/_doc/"):
+			if _, ok := docs[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"result":"not_found"}`))
+				return
+			}
+			delete(docs, id)
+			w.Write([]byte(`{"result":"deleted"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	store := NewDeadLetterStore(client, "dead-letters")
+	ctx := context.Background()
+
+	letter := domain.DeadLetter{
+		ID:       domain.DeadLetterID("javazone_private", "talk-1"),
+		Index:    "javazone_private",
+		TalkID:   "talk-1",
+		Payload:  json.RawMessage(`{"id":"talk-1"}`),
+		Error:    "mapper_parsing_exception",
+		Failures: 1,
+		FailedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.SaveDeadLetter(ctx, letter))
+
+	stored, err := store.GetDeadLetter(ctx, letter.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, letter.ID, stored.ID)
+	assert.JSONEq(t, `{"id":"talk-1"}`, string(stored.Payload))
+	assert.Equal(t, "mapper_parsing_exception", stored.Error)
+
+	require.NoError(t, store.DeleteDeadLetter(ctx, letter.ID))
+	missing, err := store.GetDeadLetter(ctx, letter.ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Deleting again is not an error
+	require.NoError(t, store.DeleteDeadLetter(ctx, letter.ID))
+}
+
+func TestDeadLetterStore_ListDeadLetters(t *testing.T) {
+	var searchBody map[string]interface{}
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodHead:
+			// index exists
+		case strings.HasSuffix(r.URL.Path, "/_search"):
+			json.NewDecoder(r.Body).Decode(&searchBody)
+			w.Write([]byte(`{"hits":{"total":{"value":1},"hits":[
+				{"_id":"javazone_public%3Atalk-1","_source":{"id":"javazone_public:talk-1","index":"javazone_public","talkId":"talk-1","payload":{"id":"talk-1"},"error":"boom","failures":2,"failedAt":"2025-01-01T12:00:00Z"}}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	store := NewDeadLetterStore(client, "dead-letters")
+
+	letters, err := store.ListDeadLetters(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "javazone_public:talk-1", letters[0].ID)
+	assert.Equal(t, 2, letters[0].Failures)
+	assert.Equal(t, float64(maxListedDeadLetters), searchBody["size"])
+}
@@ -0,0 +1,68 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// DeleteTalk removes a talk from the index by its ID and returns the deleted talk, read just before
+// deleting it so callers know its conference. It returns nil if the talk or the index does not exist.
+func (c *Client) DeleteTalk(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+	getReq := esapi.GetRequest{
+		Index:      indexName,
+		DocumentID: url.PathEscape(talkID),
+	}
+
+	res, err := getReq.Do(ctx, c.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get talk %s: %w", talkID, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("get talk error: %s - %s", res.Status(), string(body))
+	}
+
+	var doc struct {
+		Source domain.Talk `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse talk %s: %w", talkID, err)
+	}
+
+	deleteReq := esapi.DeleteRequest{
+		Index:      indexName,
+		DocumentID: url.PathEscape(talkID),
+		Refresh:    "wait_for",
+	}
+
+	deleteRes, err := deleteReq.Do(ctx, c.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete talk %s: %w", talkID, err)
+	}
+	defer deleteRes.Body.Close()
+
+	// 404 is acceptable - the talk was deleted since it was read
+	if deleteRes.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if deleteRes.IsError() {
+		body, _ := io.ReadAll(deleteRes.Body)
+		return nil, fmt.Errorf("delete talk error: %s - %s", deleteRes.Status(), string(body))
+	}
+
+	c.logger.InfoContext(ctx, "deleted talk", "index", indexName, "talkID", talkID)
+	return &doc.Source, nil
+}
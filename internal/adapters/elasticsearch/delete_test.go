@@ -0,0 +1,79 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DeleteTalk(t *testing.T) {
+	t.Run("reads and deletes the talk", func(t *testing.T) {
+		var deleted bool
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/javazone_public/_doc/talk-1", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodGet:
+				w.Write([]byte(`{"_id": "talk-1", "found": true, "_source": {"id": "talk-1", "conferenceSlug": "javazone2025"}}`))
+			case http.MethodDelete:
+				assert.Equal(t, "wait_for", r.URL.Query().Get("refresh"))
+				deleted = true
+				w.Write([]byte(`{"result": "deleted"}`))
+			default:
+				t.Errorf("unexpected %s request", r.Method)
+			}
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		talk, err := client.DeleteTalk(context.Background(), "javazone_public", "talk-1")
+
+		require.NoError(t, err)
+		require.NotNil(t, talk)
+		assert.Equal(t, "javazone2025", talk.ConferenceSlug)
+		assert.True(t, deleted)
+	})
+
+	t.Run("missing talk", func(t *testing.T) {
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method, "nothing is deleted")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"_id": "missing", "found": false}`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		talk, err := client.DeleteTalk(context.Background(), "javazone_public", "missing")
+
+		require.NoError(t, err)
+		assert.Nil(t, talk)
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				w.Write([]byte(`{"_id": "talk-1", "found": true, "_source": {"id": "talk-1"}}`))
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": "unavailable"}`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		_, err = client.DeleteTalk(context.Background(), "javazone_public", "talk-1")
+
+		assert.ErrorContains(t, err, "delete talk error")
+	})
+}
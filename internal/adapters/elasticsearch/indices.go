@@ -0,0 +1,199 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// ListIndexes returns the indexes matching the pattern with their aliases, document counts and sizes,
+// sorted by name. Hidden and system indexes (starting with ".") are never included.
+func (c *Client) ListIndexes(ctx context.Context, pattern string) ([]domain.IndexInfo, error) {
+	req := esapi.CatIndicesRequest{
+		Index:  []string{pattern},
+		Format: "json",
+		H:      []string{"index", "health", "docs.count", "store.size", "creation.date"},
+		Bytes:  "b",
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("list indexes error: %s - %s", res.Status(), string(body))
+	}
+
+	// _cat returns every value as a string
+	var rows []struct {
+		Index        string `json:"index"`
+		Health       string `json:"health"`
+		DocsCount    string `json:"docs.count"`
+		StoreSize    string `json:"store.size"`
+		CreationDate string `json:"creation.date"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to parse index list: %w", err)
+	}
+
+	aliases, err := c.getAliases(ctx, esapi.IndicesGetAliasRequest{Index: []string{pattern}})
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]domain.IndexInfo, 0, len(rows))
+	for _, row := range rows {
+		if strings.HasPrefix(row.Index, ".") {
+			continue
+		}
+
+		info := domain.IndexInfo{
+			Name:    row.Index,
+			Health:  row.Health,
+			Aliases: aliases[row.Index],
+		}
+		info.DocCount, _ = strconv.ParseInt(row.DocsCount, 10, 64)
+		info.SizeBytes, _ = strconv.ParseInt(row.StoreSize, 10, 64)
+		if millis, err := strconv.ParseInt(row.CreationDate, 10, 64); err == nil {
+			info.CreatedAt = time.UnixMilli(millis).UTC()
+		}
+		indexes = append(indexes, info)
+	}
+
+	slices.SortFunc(indexes, func(a, b domain.IndexInfo) int { return strings.Compare(a.Name, b.Name) })
+	return indexes, nil
+}
+
+// getAliases returns the sorted alias names of each index matched by the request
+func (c *Client) getAliases(ctx context.Context, req esapi.IndicesGetAliasRequest) (map[string][]string, error) {
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %w", err)
+	}
+	defer res.Body.Close()
+
+	// Matching no indexes or aliases is not an error
+	if res.StatusCode == http.StatusNotFound {
+		return map[string][]string{}, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("get aliases error: %s - %s", res.Status(), string(body))
+	}
+
+	var response map[string]struct {
+		Aliases map[string]json.RawMessage `json:"aliases"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases: %w", err)
+	}
+
+	result := make(map[string][]string, len(response))
+	for index, entry := range response {
+		for alias := range entry.Aliases {
+			result[index] = append(result[index], alias)
+		}
+		slices.Sort(result[index])
+	}
+	return result, nil
+}
+
+// PointAlias makes the alias point to the index only, removing it from any other index
+// in the same atomic request so readers never see the alias missing.
+// An index named like the alias, as created by a full reindex, is deleted in the same request.
+func (c *Client) PointAlias(ctx context.Context, alias string, indexName string) error {
+	current, err := c.getAliases(ctx, esapi.IndicesGetAliasRequest{Name: []string{alias}})
+	if err != nil {
+		return err
+	}
+
+	var actions []map[string]interface{}
+	for index, aliases := range current {
+		if index != indexName && slices.Contains(aliases, alias) {
+			actions = append(actions, map[string]interface{}{
+				"remove": map[string]interface{}{"index": index, "alias": alias},
+			})
+		}
+	}
+	if len(current) == 0 {
+		exists, err := c.IndexExists(ctx, alias)
+		if err != nil {
+			return err
+		}
+		if exists {
+			actions = append(actions, map[string]interface{}{
+				"remove_index": map[string]interface{}{"index": alias},
+			})
+		}
+	}
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]interface{}{"index": indexName, "alias": alias},
+	})
+
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return fmt.Errorf("failed to marshal alias actions: %w", err)
+	}
+
+	req := esapi.IndicesUpdateAliasesRequest{
+		Body: bytes.NewReader(body),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("failed to point alias %s to %s: %w", alias, indexName, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("update aliases error: %s - %s", res.Status(), string(resBody))
+	}
+
+	c.logger.InfoContext(ctx, "pointed alias", "alias", alias, "index", indexName, "removedFrom", len(actions)-1)
+	return nil
+}
+
+// IndexMapping returns the mappings of the index, or of the index the alias points to
+func (c *Client) IndexMapping(ctx context.Context, indexName string) (map[string]interface{}, error) {
+	req := esapi.IndicesGetMappingRequest{Index: []string{indexName}}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mapping of %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", domain.ErrIndexNotFound, indexName)
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("get mapping error: %s - %s", res.Status(), string(body))
+	}
+
+	// The response is keyed by the concrete index, which differs from the name for an alias
+	var indexes map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&indexes); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping of %s: %w", indexName, err)
+	}
+	for _, index := range indexes {
+		return index.Mappings, nil
+	}
+	return nil, fmt.Errorf("%w: %s", domain.ErrIndexNotFound, indexName)
+}
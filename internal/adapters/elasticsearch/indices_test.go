@@ -0,0 +1,173 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListIndexes(t *testing.T) {
+	server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/_cat/indices/staging_*":
+			assert.Equal(t, "json", r.URL.Query().Get("format"))
+			assert.Equal(t, "b", r.URL.Query().Get("bytes"))
+			w.Write([]byte(`[
+				{"index":"staging_public_v2","health":"green","docs.count":"120","store.size":"4096","creation.date":"1700000000000"},
+				{"index":"staging_public_v1","health":"yellow","docs.count":"100","store.size":"2048","creation.date":"1690000000000"}
+			]`))
+		case "/staging_*/_alias":
+			w.Write([]byte(`{
+				"staging_public_v2": {"aliases": {"staging_public": {}, "staging_current": {}}},
+				"staging_public_v1": {"aliases": {}}
+			}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+
+	indexes, err := client.ListIndexes(context.Background(), "staging_*")
+
+	require.NoError(t, err)
+	require.Len(t, indexes, 2)
+	assert.Equal(t, "staging_public_v1", indexes[0].Name)
+	assert.Empty(t, indexes[0].Aliases)
+	assert.Equal(t, "staging_public_v2", indexes[1].Name)
+	assert.Equal(t, []string{"staging_current", "staging_public"}, indexes[1].Aliases)
+	assert.Equal(t, "green", indexes[1].Health)
+	assert.Equal(t, int64(120), indexes[1].DocCount)
+	assert.Equal(t, int64(4096), indexes[1].SizeBytes)
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), indexes[1].CreatedAt)
+}
+
+func TestClient_PointAlias(t *testing.T) {
+	var actions []map[string]map[string]string
+	server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/_alias/staging_public":
+			w.Write([]byte(`{"staging_public_v1": {"aliases": {"staging_public": {}}}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/_aliases":
+			body, _ := io.ReadAll(r.Body)
+			var request struct {
+				Actions []map[string]map[string]string `json:"actions"`
+			}
+			require.NoError(t, json.Unmarshal(body, &request))
+			actions = request.Actions
+			w.Write([]byte(`{"acknowledged": true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.PointAlias(context.Background(), "staging_public", "staging_public_v2"))
+
+	assert.Equal(t, []map[string]map[string]string{
+		{"remove": {"index": "staging_public_v1", "alias": "staging_public"}},
+		{"add": {"index": "staging_public_v2", "alias": "staging_public"}},
+	}, actions)
+}
+
+func TestClient_PointAlias_NewAlias(t *testing.T) {
+	var actionCount int
+	server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/_alias/staging_public":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"alias [staging_public] missing","status":404}`))
+		case "/staging_public":
+			w.WriteHeader(http.StatusNotFound)
+		case "/_aliases":
+			var request struct {
+				Actions []json.RawMessage `json:"actions"`
+			}
+			json.NewDecoder(r.Body).Decode(&request)
+			actionCount = len(request.Actions)
+			w.Write([]byte(`{"acknowledged": true}`))
+		}
+	})
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.PointAlias(context.Background(), "staging_public", "staging_public_v2"))
+	assert.Equal(t, 1, actionCount)
+}
+
+func TestClient_PointAlias_ReplacesIndex(t *testing.T) {
+	var actions []map[string]map[string]string
+	server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/_alias/staging_public":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"alias [staging_public] missing","status":404}`))
+		case r.Method == http.MethodHead && r.URL.Path == "/staging_public":
+			// an index created by a full reindex
+		case r.Method == http.MethodPost && r.URL.Path == "/_aliases":
+			var request struct {
+				Actions []map[string]map[string]string `json:"actions"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			actions = request.Actions
+			w.Write([]byte(`{"acknowledged": true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.PointAlias(context.Background(), "staging_public", "staging_public_v2"))
+
+	assert.Equal(t, []map[string]map[string]string{
+		{"remove_index": {"index": "staging_public"}},
+		{"add": {"index": "staging_public_v2", "alias": "staging_public"}},
+	}, actions)
+}
+
+func TestClient_IndexMapping(t *testing.T) {
+	server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/staging_public/_mapping":
+			w.Write([]byte(`{"staging_public_v2": {"mappings": {"properties": {"id": {"type": "keyword"}}}}}`))
+		case "/missing/_mapping":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"type": "index_not_found_exception"}, "status": 404}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+
+	mapping, err := client.IndexMapping(context.Background(), "staging_public")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"properties": map[string]interface{}{"id": map[string]interface{}{"type": "keyword"}}}, mapping)
+
+	_, err = client.IndexMapping(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrIndexNotFound)
+}
@@ -0,0 +1,172 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// maxListedJobs bounds the number of jobs returned when listing without a limit
+const maxListedJobs = 1000
+
+// JobStore implements ports.JobStore with one document per job in a dedicated index,
+// using the document ID assigned by Elasticsearch as the job ID.
+// The index is created with JobsIndexMapping on first use.
+type JobStore struct {
+	client    *Client
+	indexName string
+	index     *lazyIndex
+}
+
+// NewJobStore creates a job store backed by the given index
+func NewJobStore(client *Client, indexName string) *JobStore {
+	return &JobStore{
+		client:    client,
+		indexName: indexName,
+		index:     newLazyIndex(client, indexName, JobsIndexMapping),
+	}
+}
+
+// CreateJob stores a new job and returns it with the ID assigned by Elasticsearch
+func (s *JobStore) CreateJob(ctx context.Context, job domain.Job) (domain.Job, error) {
+	if err := s.index.ensure(ctx); err != nil {
+		return domain.Job{}, err
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:   s.indexName,
+		Body:    bytes.NewReader(body),
+		Refresh: "wait_for",
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("failed to create job: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return domain.Job{}, fmt.Errorf("create job error: %s - %s", res.Status(), string(resBody))
+	}
+
+	var created struct {
+		ID string `json:"_id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return domain.Job{}, fmt.Errorf("failed to parse create job response: %w", err)
+	}
+
+	job.ID = created.ID
+	return job, nil
+}
+
+// UpdateJob replaces the stored job with the same ID
+func (s *JobStore) UpdateJob(ctx context.Context, job domain.Job) error {
+	if job.ID == "" {
+		return fmt.Errorf("cannot update job without ID")
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job %s: %w", job.ID, err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      s.indexName,
+		DocumentID: job.ID,
+		Body:       bytes.NewReader(body),
+		Refresh:    "wait_for",
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return fmt.Errorf("failed to update job %s: %w", job.ID, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("update job error: %s - %s", res.Status(), string(resBody))
+	}
+
+	return nil
+}
+
+// GetJob retrieves a job by ID, returns nil if not found
+func (s *JobStore) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	req := esapi.GetRequest{
+		Index:      s.indexName,
+		DocumentID: id,
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", id, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("get job error: %s - %s", res.Status(), string(body))
+	}
+
+	var doc struct {
+		ID     string     `json:"_id"`
+		Source domain.Job `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse job %s: %w", id, err)
+	}
+
+	job := doc.Source
+	job.ID = doc.ID
+	return &job, nil
+}
+
+// ListJobs returns up to limit jobs, most recently created first.
+// A limit of zero or less returns up to maxListedJobs jobs.
+func (s *JobStore) ListJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	if err := s.index.ensure(ctx); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxListedJobs {
+		limit = maxListedJobs
+	}
+
+	page, err := s.client.search(ctx, s.indexName, map[string]interface{}{
+		"size":  limit,
+		"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+		"sort":  []interface{}{map[string]interface{}{"createdAt": "desc"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(page.Hits.Hits))
+	for _, hit := range page.Hits.Hits {
+		var job domain.Job
+		if err := json.Unmarshal(hit.Source, &job); err != nil {
+			return nil, fmt.Errorf("failed to parse job %s: %w", hit.ID, err)
+		}
+		job.ID = hit.ID
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
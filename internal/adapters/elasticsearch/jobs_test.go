@@ -0,0 +1,105 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobStore_CreateUpdateGet(t *testing.T) {
+	docs := make(map[string][]byte)
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/jobs":
+			// index exists
+		case r.Method == http.MethodPost && r.URL.Path == "/jobs/_doc":
+			body, _ := io.ReadAll(r.Body)
+			docs["generated-id"] = body
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"_id":"generated-id","result":"created"}`))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/jobs/_doc/"):
+			body, _ := io.ReadAll(r.Body)
+			docs[strings.TrimPrefix(r.URL.Path, "/jobs/_doc/")] = body
+			w.Write([]byte(`{"result":"updated"}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/jobs/_doc/"):
+			id := strings.TrimPrefix(r.URL.Path, "/jobs/_doc/")
+			doc, ok := docs[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"found":false}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"_id": id, "found": true, "_source": json.RawMessage(doc)})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	store := NewJobStore(client, "jobs")
+	ctx := context.Background()
+
+	job, err := store.CreateJob(ctx, domain.NewJob(domain.JobScope{Kind: domain.JobKindReindexAll}, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, "generated-id", job.ID)
+
+	job.Start(time.Now())
+	job.Report.Indexed = map[string]int{"private": 10}
+	job.Finish(time.Now(), nil)
+	require.NoError(t, store.UpdateJob(ctx, job))
+
+	stored, err := store.GetJob(ctx, "generated-id")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "generated-id", stored.ID)
+	assert.Equal(t, domain.JobStateSucceeded, stored.State)
+	assert.Equal(t, 10, stored.Report.Indexed["private"])
+
+	missing, err := store.GetJob(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestJobStore_ListJobs(t *testing.T) {
+	var searchBody map[string]interface{}
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodHead:
+			// index exists
+		case strings.HasSuffix(r.URL.Path, "/_search"):
+			json.NewDecoder(r.Body).Decode(&searchBody)
+			w.Write([]byte(`{"hits":{"hits":[
+				{"_id":"job-2","_source":{"scope":{"kind":"reindex-talk","target":"talk-1"},"state":"running"}},
+				{"_id":"job-1","_source":{"scope":{"kind":"reindex-all"},"state":"succeeded"}}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	store := NewJobStore(client, "jobs")
+
+	jobs, err := store.ListJobs(context.Background(), 20)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(20), searchBody["size"])
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "job-2", jobs[0].ID)
+	assert.Equal(t, domain.JobKindReindexTalk, jobs[0].Scope.Kind)
+	assert.Equal(t, domain.JobStateSucceeded, jobs[1].State)
+}
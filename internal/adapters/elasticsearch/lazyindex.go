@@ -0,0 +1,49 @@
+package elasticsearch
+
+import (
+	"context"
+	"sync"
+)
+
+// lazyIndex creates a small auxiliary index (settings, jobs) with its mapping on first use
+type lazyIndex struct {
+	client  *Client
+	name    string
+	mapping string
+
+	mu      sync.Mutex
+	ensured bool
+}
+
+// newLazyIndex returns a lazyIndex for the given index name and mapping
+func newLazyIndex(client *Client, name, mapping string) *lazyIndex {
+	return &lazyIndex{
+		client:  client,
+		name:    name,
+		mapping: mapping,
+	}
+}
+
+// ensure creates the index with its mapping if it does not exist yet.
+// Failures are not remembered, so the next call tries again.
+func (l *lazyIndex) ensure(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.ensured {
+		return nil
+	}
+
+	exists, err := l.client.IndexExists(ctx, l.name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := l.client.CreateIndex(ctx, l.name, l.mapping); err != nil {
+			return err
+		}
+	}
+
+	l.ensured = true
+	return nil
+}
@@ -42,6 +42,74 @@ const TalkPrivateIndexMapping = `{
         "type": "date",
         "format": "strict_date_optional_time||epoch_millis"
       },
+      "conference": {
+        "properties": {
+          "venue": {
+            "type": "keyword"
+          },
+          "startDate": {
+            "type": "date",
+            "format": "strict_date"
+          },
+          "endDate": {
+            "type": "date",
+            "format": "strict_date"
+          },
+          "logoUrl": {
+            "type": "keyword",
+            "index": false
+          },
+          "cfpOpens": {
+            "type": "date",
+            "format": "strict_date"
+          },
+          "cfpCloses": {
+            "type": "date",
+            "format": "strict_date"
+          }
+        }
+      },
+      "workshop": {
+        "properties": {
+          "capacity": {
+            "type": "integer"
+          },
+          "registered": {
+            "type": "integer"
+          },
+          "spotsLeft": {
+            "type": "integer"
+          }
+        }
+      },
+      "seriesId": {
+        "type": "keyword"
+      },
+      "seriesPart": {
+        "type": "integer"
+      },
+      "suggest": {
+        "type": "completion"
+      },
+      "review": {
+        "properties": {
+          "reviewers": {
+            "type": "keyword"
+          },
+          "feedbackBy": {
+            "type": "keyword"
+          },
+          "pending": {
+            "type": "keyword"
+          },
+          "conflicts": {
+            "type": "keyword"
+          },
+          "score": {
+            "type": "float"
+          }
+        }
+      },
       "data": {
         "properties": {
           "title": {
@@ -56,6 +124,10 @@ const TalkPrivateIndexMapping = `{
           "abstract": {
             "type": "text"
           },
+          "abstractHtml": {
+            "type": "text",
+            "index": false
+          },
           "outline": {
             "type": "text"
           },
@@ -243,6 +315,9 @@ const TalkPrivateIndexMapping = `{
                 "type": "keyword",
                 "index": false
               },
+              "email": {
+                "type": "keyword"
+              },
               "emailAlias": {
                 "type": "keyword"
               },
@@ -299,6 +374,55 @@ const TalkPublicIndexMapping = `{
         "type": "date",
         "format": "strict_date_optional_time||epoch_millis"
       },
+      "conference": {
+        "properties": {
+          "venue": {
+            "type": "keyword"
+          },
+          "startDate": {
+            "type": "date",
+            "format": "strict_date"
+          },
+          "endDate": {
+            "type": "date",
+            "format": "strict_date"
+          },
+          "logoUrl": {
+            "type": "keyword",
+            "index": false
+          },
+          "cfpOpens": {
+            "type": "date",
+            "format": "strict_date"
+          },
+          "cfpCloses": {
+            "type": "date",
+            "format": "strict_date"
+          }
+        }
+      },
+      "workshop": {
+        "properties": {
+          "capacity": {
+            "type": "integer"
+          },
+          "registered": {
+            "type": "integer"
+          },
+          "spotsLeft": {
+            "type": "integer"
+          }
+        }
+      },
+      "seriesId": {
+        "type": "keyword"
+      },
+      "seriesPart": {
+        "type": "integer"
+      },
+      "suggest": {
+        "type": "completion"
+      },
       "data": {
         "properties": {
           "title": {
@@ -313,6 +437,10 @@ const TalkPublicIndexMapping = `{
           "abstract": {
             "type": "text"
           },
+          "abstractHtml": {
+            "type": "text",
+            "index": false
+          },
           "intendedAudience": {
             "type": "text"
           },
@@ -432,3 +560,111 @@ const TalkPublicIndexMapping = `{
     }
   }
 }`
+
+// SettingsIndexMapping defines the Elasticsearch mapping for the settings index.
+// Setting values are stored in _source only, so settings of different shapes never
+// conflict on field mappings.
+const SettingsIndexMapping = `{
+  "settings": {
+    "number_of_shards": 1,
+    "number_of_replicas": 1
+  },
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "value": {
+        "type": "object",
+        "enabled": false
+      },
+      "updatedAt": {
+        "type": "date"
+      }
+    }
+  }
+}`
+
+// JobsIndexMapping defines the Elasticsearch mapping for the jobs index.
+// Only the fields used for listing and filtering are indexed; the report is stored in _source only.
+const JobsIndexMapping = `{
+  "settings": {
+    "number_of_shards": 1,
+    "number_of_replicas": 1
+  },
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "scope": {
+        "properties": {
+          "kind": {
+            "type": "keyword"
+          },
+          "target": {
+            "type": "keyword"
+          }
+        }
+      },
+      "actor": {
+        "properties": {
+          "kind": {
+            "type": "keyword"
+          },
+          "name": {
+            "type": "keyword"
+          }
+        }
+      },
+      "state": {
+        "type": "keyword"
+      },
+      "createdAt": {
+        "type": "date"
+      },
+      "startedAt": {
+        "type": "date"
+      },
+      "finishedAt": {
+        "type": "date"
+      },
+      "error": {
+        "type": "text"
+      },
+      "report": {
+        "type": "object",
+        "enabled": false
+      }
+    }
+  }
+}`
+
+// DeadLettersIndexMapping defines the Elasticsearch mapping for the dead-letter index.
+// The payload is stored in _source only, since it is whatever document failed to index.
+const DeadLettersIndexMapping = `{
+  "settings": {
+    "number_of_shards": 1,
+    "number_of_replicas": 1
+  },
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "index": {
+        "type": "keyword"
+      },
+      "talkId": {
+        "type": "keyword"
+      },
+      "payload": {
+        "type": "object",
+        "enabled": false
+      },
+      "error": {
+        "type": "text"
+      },
+      "failures": {
+        "type": "integer"
+      },
+      "failedAt": {
+        "type": "date"
+      }
+    }
+  }
+}`
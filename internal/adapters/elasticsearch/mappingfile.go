@@ -0,0 +1,68 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+)
+
+// requiredMappingFields are the fields the indexer and the read endpoints filter on, which every talk
+// index mapping must declare
+var requiredMappingFields = []string{"id", "conferenceSlug", "status"}
+
+// TalkIndexMappings returns the mappings of the private and public talk indexes: those read from
+// PRIVATE_MAPPING_FILE and PUBLIC_MAPPING_FILE, or else the built-in TalkPrivateIndexMapping and
+// TalkPublicIndexMapping. The files are read on every call, so a configuration reload picks up changes.
+func TalkIndexMappings(cfg config.IndexConfig) (string, string, error) {
+	private, err := loadMapping(cfg.PrivateMappingFile, TalkPrivateIndexMapping)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid PRIVATE_MAPPING_FILE: %w", err)
+	}
+	public, err := loadMapping(cfg.PublicMappingFile, TalkPublicIndexMapping)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid PUBLIC_MAPPING_FILE: %w", err)
+	}
+	return private, public, nil
+}
+
+// loadMapping reads an index mapping with settings and mappings from the file, or returns the
+// fallback without a file
+func loadMapping(path, fallback string) (string, error) {
+	if path == "" {
+		return fallback, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if err := validateMapping(data); err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// validateMapping checks that the index mapping is a JSON object with mappings declaring the required
+// fields, so a broken file fails at startup instead of on the next reindex
+func validateMapping(data []byte) error {
+	var mapping struct {
+		Settings map[string]interface{} `json:"settings"`
+		Mappings *struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"mappings"`
+	}
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return fmt.Errorf("not a JSON object: %w", err)
+	}
+	if mapping.Mappings == nil {
+		return fmt.Errorf("missing mappings")
+	}
+	for _, field := range requiredMappingFields {
+		if _, ok := mapping.Mappings.Properties[field]; !ok {
+			return fmt.Errorf("missing field %q in mappings", field)
+		}
+	}
+	return nil
+}
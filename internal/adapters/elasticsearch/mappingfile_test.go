@@ -0,0 +1,51 @@
+package elasticsearch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTalkIndexMappings(t *testing.T) {
+	t.Run("built-in", func(t *testing.T) {
+		private, public, err := TalkIndexMappings(config.IndexConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, TalkPrivateIndexMapping, private)
+		assert.Equal(t, TalkPublicIndexMapping, public)
+		assert.NoError(t, validateMapping([]byte(TalkPrivateIndexMapping)))
+		assert.NoError(t, validateMapping([]byte(TalkPublicIndexMapping)))
+	})
+
+	t.Run("from file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "public.json")
+		mapping := `{"settings": {"analysis": {"analyzer": {"default": {"type": "norwegian"}}}}, "mappings": {"properties": {"id": {"type": "keyword"}, "conferenceSlug": {"type": "keyword"}, "status": {"type": "keyword"}}}}`
+		require.NoError(t, os.WriteFile(file, []byte(mapping), 0o600))
+
+		private, public, err := TalkIndexMappings(config.IndexConfig{PublicMappingFile: file})
+		require.NoError(t, err)
+		assert.Equal(t, TalkPrivateIndexMapping, private)
+		assert.Equal(t, mapping, public)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		for name, content := range map[string]string{
+			"not-json.json":      `mappings:`,
+			"no-mappings.json":   `{"settings": {}}`,
+			"missing-field.json": `{"mappings": {"properties": {"id": {"type": "keyword"}, "status": {"type": "keyword"}}}}`,
+		} {
+			file := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+			_, _, err := TalkIndexMappings(config.IndexConfig{PrivateMappingFile: file})
+			assert.ErrorContains(t, err, "PRIVATE_MAPPING_FILE", name)
+		}
+
+		_, _, err := TalkIndexMappings(config.IndexConfig{PublicMappingFile: filepath.Join(dir, "missing.json")})
+		assert.ErrorContains(t, err, "PUBLIC_MAPPING_FILE")
+	})
+}
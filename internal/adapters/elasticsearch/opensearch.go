@@ -0,0 +1,63 @@
+package elasticsearch
+
+import (
+	"net/http"
+	"strings"
+)
+
+// productHeader is the response header the Elasticsearch client checks to refuse talking to other products
+const productHeader = "X-Elastic-Product"
+
+// openSearchTransport lets the Elasticsearch client talk to OpenSearch, which serves every API the
+// adapter uses but is refused by the client's product check. It marks the responses as coming from
+// Elasticsearch and replaces the versioned Elasticsearch media types, which OpenSearch rejects, with
+// plain JSON.
+type openSearchTransport struct {
+	next http.RoundTripper
+}
+
+// NewOpenSearchTransport wraps the transport (the default transport if nil) for talking to OpenSearch,
+// such as AWS OpenSearch Service
+func NewOpenSearchTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &openSearchTransport{next: next}
+}
+
+// RoundTrip sends the request with OpenSearch media types and marks the response as the client expects
+func (t *openSearchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isVersionedMediaType(req.Header.Get("Accept")) || isVersionedMediaType(req.Header.Get("Content-Type")) {
+		req = req.Clone(req.Context())
+		for _, name := range []string{"Accept", "Content-Type"} {
+			if value := req.Header.Get(name); isVersionedMediaType(value) {
+				req.Header.Set(name, openSearchMediaType(value))
+			}
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Header == nil {
+		res.Header = make(http.Header)
+	}
+	res.Header.Set(productHeader, "Elasticsearch")
+	return res, nil
+}
+
+// isVersionedMediaType returns true for media types such as
+// "application/vnd.elasticsearch+json;compatible-with=9", sent in the client's compatibility mode
+func isVersionedMediaType(value string) bool {
+	return strings.HasPrefix(value, "application/vnd.elasticsearch+")
+}
+
+// openSearchMediaType returns the plain media type of a versioned Elasticsearch media type, keeping
+// newline-delimited JSON for bulk requests
+func openSearchMediaType(value string) string {
+	if strings.HasPrefix(value, "application/vnd.elasticsearch+x-ndjson") {
+		return "application/x-ndjson"
+	}
+	return "application/json"
+}
@@ -0,0 +1,86 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOpenSearchServer creates a mock OpenSearch cluster, which does not send the Elasticsearch product
+// header and rejects versioned Elasticsearch media types
+func newOpenSearchServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, name := range []string{"Accept", "Content-Type"} {
+			if strings.Contains(r.Header.Get(name), "vnd.elasticsearch") {
+				http.Error(w, name+" header is not supported", http.StatusNotAcceptable)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"cluster_name": "opensearch",
+				"version":      map[string]interface{}{"distribution": "opensearch", "number": "2.13.0"},
+			})
+		case "/_bulk":
+			w.Write([]byte(`{"errors":false,"items":[{"index":{"_id":"talk-1","status":201}}]}`))
+		default:
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewOpenSearch(t *testing.T) {
+	server := newOpenSearchServer(t)
+	ctx := config.WithConfig(context.Background(), testESConfig(server.URL))
+
+	t.Run("elasticsearch client refuses opensearch", func(t *testing.T) {
+		_, err := New(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("opensearch client connects", func(t *testing.T) {
+		client, err := NewOpenSearch(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		require.NoError(t, client.CreateIndex(context.Background(), "talks", TalkPublicIndexMapping))
+	})
+}
+
+func TestOpenSearchTransport(t *testing.T) {
+	server := newOpenSearchServer(t)
+	transport := NewOpenSearchTransport(nil)
+
+	t.Run("replaces versioned media types", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/_bulk", strings.NewReader("{}\n"))
+		require.NoError(t, err)
+		req.Header.Set("Accept", "application/vnd.elasticsearch+json;compatible-with=9")
+		req.Header.Set("Content-Type", "application/vnd.elasticsearch+x-ndjson;compatible-with=9")
+
+		res, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "Elasticsearch", res.Header.Get(productHeader))
+		assert.Equal(t, "application/vnd.elasticsearch+x-ndjson;compatible-with=9", req.Header.Get("Content-Type"), "the request of the caller is left unchanged")
+	})
+
+	t.Run("keeps plain media types", func(t *testing.T) {
+		assert.False(t, isVersionedMediaType("application/json"))
+		assert.Equal(t, "application/json", openSearchMediaType("application/vnd.elasticsearch+json;compatible-with=9"))
+		assert.Equal(t, "application/x-ndjson", openSearchMediaType("application/vnd.elasticsearch+x-ndjson;compatible-with=9"))
+	})
+}
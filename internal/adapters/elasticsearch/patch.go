@@ -0,0 +1,51 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// PatchTalk merges the partial document into an indexed talk using a partial update.
+// Objects are merged and arrays replaced; a nil value clears a field. Talks missing from the index
+// are reported as domain.ErrTalkNotFound.
+// The update bumps the document version, so a reindex of the unchanged talk is skipped as a
+// conflict and the patched fields survive until the talk is updated in moresleep.
+func (c *Client) PatchTalk(ctx context.Context, indexName string, talkID string, doc map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"doc": doc})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch for talk %s: %w", talkID, err)
+	}
+
+	req := esapi.UpdateRequest{
+		Index:      indexName,
+		DocumentID: talkID,
+		Body:       bytes.NewReader(body),
+		Refresh:    "wait_for",
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("failed to patch talk %s: %w", talkID, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("%w: patch talk error: %s - %s", domain.ErrTalkNotFound, res.Status(), string(resBody))
+	}
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("patch talk error: %s - %s", res.Status(), string(resBody))
+	}
+
+	c.logger.InfoContext(ctx, "patched talk", "index", indexName, "talkID", talkID, "fields", len(doc))
+	return nil
+}
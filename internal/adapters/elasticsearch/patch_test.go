@@ -0,0 +1,59 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PatchTalk(t *testing.T) {
+	t.Run("sends partial update of data", func(t *testing.T) {
+		var request map[string]map[string]map[string]interface{}
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/javazone_public/_update/talk-1", r.URL.Path)
+			assert.Equal(t, "wait_for", r.URL.Query().Get("refresh"))
+
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &request))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"result": "updated"}`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		err = client.PatchTalk(context.Background(), "javazone_public", "talk-1", map[string]interface{}{
+			"data": map[string]interface{}{"video": "https://vimeo.com/123"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://vimeo.com/123", request["doc"]["data"]["video"])
+	})
+
+	t.Run("missing talk", func(t *testing.T) {
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"type": "document_missing_exception"}}`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		err = client.PatchTalk(context.Background(), "javazone_public", "missing", map[string]interface{}{"data": map[string]interface{}{"video": "x"}})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "patch talk error")
+		assert.ErrorIs(t, err, domain.ErrTalkNotFound)
+	})
+}
@@ -0,0 +1,67 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// queryResponse is the subset of the search API response returned by ad-hoc queries
+type queryResponse struct {
+	Took     int  `json:"took"`
+	TimedOut bool `json:"timed_out"`
+	Hits     struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []searchHit `json:"hits"`
+	} `json:"hits"`
+	Aggregations json.RawMessage `json:"aggregations"`
+}
+
+// RunQuery executes a search request body against the given index and returns the hit sources,
+// the total hit count and the aggregations. The body must already be validated by the caller.
+func (c *Client) RunQuery(ctx context.Context, indexName string, body map[string]interface{}) (domain.QueryResult, error) {
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to marshal query: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{indexName},
+		Body:  bytes.NewReader(bodyJSON),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to query index %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return domain.QueryResult{}, fmt.Errorf("query error: %s - %s", res.Status(), string(resBody))
+	}
+
+	var response queryResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to parse query response: %w", err)
+	}
+
+	result := domain.QueryResult{
+		Total:        response.Hits.Total.Value,
+		TookMillis:   response.Took,
+		TimedOut:     response.TimedOut,
+		Hits:         make([]json.RawMessage, 0, len(response.Hits.Hits)),
+		Aggregations: response.Aggregations,
+	}
+	for _, hit := range response.Hits.Hits {
+		result.Hits = append(result.Hits, hit.Source)
+	}
+	return result, nil
+}
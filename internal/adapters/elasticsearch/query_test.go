@@ -0,0 +1,61 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RunQuery(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var request map[string]interface{}
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && r.URL.Path == "/private/_search" {
+				json.NewDecoder(r.Body).Decode(&request)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"took":7,"timed_out":true,"hits":{"total":{"value":42},"hits":[
+					{"_id":"talk-1","_source":{"id":"talk-1","status":"APPROVED"}}
+				]},"aggregations":{"formats":{"buckets":[{"key":"presentation","doc_count":30}]}}}`))
+				return
+			}
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		result, err := client.RunQuery(context.Background(), "private", map[string]interface{}{
+			"size":  1,
+			"query": map[string]interface{}{"term": map[string]interface{}{"status": "APPROVED"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, float64(1), request["size"])
+		assert.Equal(t, 42, result.Total)
+		assert.Equal(t, 7, result.TookMillis)
+		assert.True(t, result.TimedOut)
+		require.Len(t, result.Hits, 1)
+		assert.JSONEq(t, `{"id":"talk-1","status":"APPROVED"}`, string(result.Hits[0]))
+		assert.JSONEq(t, `{"formats":{"buckets":[{"key":"presentation","doc_count":30}]}}`, string(result.Aggregations))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"type":"parsing_exception"}}`))
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		_, err = client.RunQuery(context.Background(), "private", map[string]interface{}{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parsing_exception")
+	})
+}
@@ -0,0 +1,61 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// DeleteTalksUpdatedBefore deletes the talks with one of the statuses whose lastUpdated is before the
+// given time using a delete-by-query. Talks without lastUpdated do not match the range and are kept.
+// Version conflicts, such as a talk reindexed while the deletion runs, leave that talk in place.
+func (c *Client) DeleteTalksUpdatedBefore(ctx context.Context, indexName string, statuses []string, before time.Time) (int, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"terms": map[string]interface{}{"status": statuses}},
+					map[string]interface{}{"range": map[string]interface{}{"lastUpdated": map[string]interface{}{"lt": before.UTC().Format(time.RFC3339)}}},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal delete query: %w", err)
+	}
+
+	refresh := true
+	req := esapi.DeleteByQueryRequest{
+		Index:     []string{indexName},
+		Body:      bytes.NewReader(body),
+		Conflicts: "proceed",
+		Refresh:   &refresh,
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete talks from %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return 0, fmt.Errorf("delete by query error: %s - %s", res.Status(), string(resBody))
+	}
+
+	var result struct {
+		Deleted int `json:"deleted"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode delete by query response: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "deleted talks", "index", indexName, "statuses", statuses, "updatedBefore", before, "deleted", result.Deleted)
+	return result.Deleted, nil
+}
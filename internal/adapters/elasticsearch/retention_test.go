@@ -0,0 +1,63 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DeleteTalksUpdatedBefore(t *testing.T) {
+	t.Run("deletes matching talks", func(t *testing.T) {
+		var request map[string]interface{}
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/javazone_private/_delete_by_query", r.URL.Path)
+			assert.Equal(t, "proceed", r.URL.Query().Get("conflicts"))
+
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &request))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"deleted": 3, "version_conflicts": 0}`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		before := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+		deleted, err := client.DeleteTalksUpdatedBefore(context.Background(), "javazone_private", []string{"REJECTED", "DRAFT"}, before)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, deleted)
+
+		filter := request["query"].(map[string]interface{})["bool"].(map[string]interface{})["filter"].([]interface{})
+		require.Len(t, filter, 2)
+		assert.Equal(t, []interface{}{"REJECTED", "DRAFT"}, filter[0].(map[string]interface{})["terms"].(map[string]interface{})["status"])
+		lastUpdated := filter[1].(map[string]interface{})["range"].(map[string]interface{})["lastUpdated"].(map[string]interface{})
+		assert.Equal(t, "2023-09-01T00:00:00Z", lastUpdated["lt"])
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		server := createMockESServer(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"type": "index_not_found_exception"}}`))
+		})
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		_, err = client.DeleteTalksUpdatedBefore(context.Background(), "missing", []string{"REJECTED"}, time.Now())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "delete by query error")
+	})
+}
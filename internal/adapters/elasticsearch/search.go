@@ -0,0 +1,277 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// fetchPageSize is the number of documents requested per search page
+const fetchPageSize = 500
+
+// maxConferences is the upper bound of conferences returned by the conference aggregation
+const maxConferences = 1000
+
+// searchHit is a single hit in a search API response
+type searchHit struct {
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+	Sort   []interface{}   `json:"sort"`
+}
+
+// searchResponse is the subset of the search API response used when reading talks
+type searchResponse struct {
+	Hits struct {
+		Hits []searchHit `json:"hits"`
+	} `json:"hits"`
+	Aggregations json.RawMessage `json:"aggregations"`
+	Suggest      json.RawMessage `json:"suggest"`
+}
+
+// FetchTalks retrieves all talks stored in the specified index, paging through
+// results with search_after. If conferenceSlug is non-empty, only talks for that
+// conference are returned.
+func (c *Client) FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+	query := map[string]interface{}{
+		"match_all": map[string]interface{}{},
+	}
+	if conferenceSlug != "" {
+		query = map[string]interface{}{
+			"term": map[string]interface{}{
+				"conferenceSlug": conferenceSlug,
+			},
+		}
+	}
+
+	var talks []domain.Talk
+	var searchAfter []interface{}
+
+	for {
+		body := map[string]interface{}{
+			"size":  fetchPageSize,
+			"query": query,
+			"sort":  []interface{}{map[string]interface{}{"id": "asc"}},
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+
+		page, err := c.search(ctx, indexName, body)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range page.Hits.Hits {
+			var talk domain.Talk
+			if err := json.Unmarshal(hit.Source, &talk); err != nil {
+				return nil, fmt.Errorf("failed to parse document %s: %w", hit.ID, err)
+			}
+			talks = append(talks, talk)
+		}
+
+		if len(page.Hits.Hits) < fetchPageSize {
+			break
+		}
+		searchAfter = page.Hits.Hits[len(page.Hits.Hits)-1].Sort
+	}
+
+	c.logger.DebugContext(ctx, "fetched talks from index", "index", indexName, "conferenceSlug", conferenceSlug, "count", len(talks))
+	return talks, nil
+}
+
+// ListTalkIDs returns the IDs of the talks of the conference stored in the specified index, ordered
+// by ID. Only the document IDs are read, paging through the results with search_after.
+func (c *Client) ListTalkIDs(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+	var ids []string
+	var searchAfter []interface{}
+
+	for {
+		body := map[string]interface{}{
+			"size":    fetchPageSize,
+			"_source": false,
+			"query": map[string]interface{}{
+				"term": map[string]interface{}{
+					"conferenceId": conferenceID,
+				},
+			},
+			"sort": []interface{}{map[string]interface{}{"id": "asc"}},
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+
+		page, err := c.search(ctx, indexName, body)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range page.Hits.Hits {
+			ids = append(ids, hit.ID)
+		}
+
+		if len(page.Hits.Hits) < fetchPageSize {
+			break
+		}
+		searchAfter = page.Hits.Hits[len(page.Hits.Hits)-1].Sort
+	}
+
+	return ids, nil
+}
+
+// search executes a search request against the given index and decodes the response
+func (c *Client) search(ctx context.Context, indexName string, body map[string]interface{}) (*searchResponse, error) {
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search request: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{indexName},
+		Body:  bytes.NewReader(bodyJSON),
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search index %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("search error: %s - %s", res.Status(), string(resBody))
+	}
+
+	var response searchResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ListConferences returns the conferences present in the specified index with their talk counts,
+// using a terms aggregation on the conference slug.
+func (c *Client) ListConferences(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+	body := map[string]interface{}{
+		"size": 0,
+		"aggs": map[string]interface{}{
+			"conferences": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "conferenceSlug",
+					"size":  maxConferences,
+					"order": map[string]interface{}{"_key": "asc"},
+				},
+				"aggs": map[string]interface{}{
+					"conference": map[string]interface{}{
+						"top_hits": map[string]interface{}{
+							"size":    1,
+							"_source": []string{"conferenceId", "conferenceName", "conference"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	page, err := c.search(ctx, indexName, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggs struct {
+		Conferences struct {
+			Buckets []struct {
+				Key        string `json:"key"`
+				DocCount   int    `json:"doc_count"`
+				Conference struct {
+					Hits struct {
+						Hits []searchHit `json:"hits"`
+					} `json:"hits"`
+				} `json:"conference"`
+			} `json:"buckets"`
+		} `json:"conferences"`
+	}
+	if err := json.Unmarshal(page.Aggregations, &aggs); err != nil {
+		return nil, fmt.Errorf("failed to parse conference aggregation: %w", err)
+	}
+
+	conferences := make([]domain.ConferenceSummary, 0, len(aggs.Conferences.Buckets))
+	for _, bucket := range aggs.Conferences.Buckets {
+		summary := domain.ConferenceSummary{
+			Slug:      bucket.Key,
+			TalkCount: bucket.DocCount,
+		}
+		if hits := bucket.Conference.Hits.Hits; len(hits) > 0 {
+			var source struct {
+				ConferenceID   string                     `json:"conferenceId"`
+				ConferenceName string                     `json:"conferenceName"`
+				Conference     *domain.ConferenceMetadata `json:"conference"`
+			}
+			if err := json.Unmarshal(hits[0].Source, &source); err == nil {
+				summary.ID = source.ConferenceID
+				summary.Name = source.ConferenceName
+				if source.Conference != nil {
+					summary.ConferenceMetadata = *source.Conference
+				}
+			}
+		}
+		conferences = append(conferences, summary)
+	}
+
+	return conferences, nil
+}
+
+// IndexVersion returns the generation (index UUID, which changes whenever the index is recreated)
+// and document version (total index and delete operations) of the specified index.
+func (c *Client) IndexVersion(ctx context.Context, indexName string) (domain.IndexVersion, error) {
+	req := esapi.IndicesStatsRequest{
+		Index:  []string{indexName},
+		Metric: []string{"docs", "indexing"},
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return domain.IndexVersion{}, fmt.Errorf("failed to get stats for index %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return domain.IndexVersion{}, fmt.Errorf("index stats error: %s - %s", res.Status(), string(body))
+	}
+
+	var stats struct {
+		Indices map[string]struct {
+			UUID      string `json:"uuid"`
+			Primaries struct {
+				Docs struct {
+					Count int64 `json:"count"`
+				} `json:"docs"`
+				Indexing struct {
+					IndexTotal  int64 `json:"index_total"`
+					DeleteTotal int64 `json:"delete_total"`
+				} `json:"indexing"`
+			} `json:"primaries"`
+		} `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
+		return domain.IndexVersion{}, fmt.Errorf("failed to parse index stats: %w", err)
+	}
+
+	// The requested name may be an alias, so use whichever concrete index was returned
+	for name, index := range stats.Indices {
+		return domain.IndexVersion{
+			Index:      name,
+			Generation: index.UUID,
+			Version:    index.Primaries.Indexing.IndexTotal + index.Primaries.Indexing.DeleteTotal,
+			DocCount:   index.Primaries.Docs.Count,
+		}, nil
+	}
+
+	return domain.IndexVersion{}, fmt.Errorf("index stats returned no index for %s", indexName)
+}
@@ -0,0 +1,110 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// SettingsStore implements ports.SettingsStore with one document per setting in a dedicated index.
+// The index is created with SettingsIndexMapping on first write.
+type SettingsStore struct {
+	client    *Client
+	indexName string
+	index     *lazyIndex
+}
+
+// settingDocument is the stored shape of a setting
+type settingDocument struct {
+	Value     json.RawMessage `json:"value"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+// NewSettingsStore creates a settings store backed by the given index
+func NewSettingsStore(client *Client, indexName string) *SettingsStore {
+	return &SettingsStore{
+		client:    client,
+		indexName: indexName,
+		index:     newLazyIndex(client, indexName, SettingsIndexMapping),
+	}
+}
+
+// LoadSetting decodes the setting stored under key into value.
+// A missing setting or settings index is reported as not found.
+func (s *SettingsStore) LoadSetting(ctx context.Context, key string, value any) (bool, error) {
+	req := esapi.GetRequest{
+		Index:      s.indexName,
+		DocumentID: url.PathEscape(key),
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return false, fmt.Errorf("failed to load setting %s: %w", key, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return false, fmt.Errorf("load setting error: %s - %s", res.Status(), string(body))
+	}
+
+	var doc struct {
+		Source settingDocument `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return false, fmt.Errorf("failed to parse setting %s: %w", key, err)
+	}
+	if err := json.Unmarshal(doc.Source.Value, value); err != nil {
+		return false, fmt.Errorf("failed to decode setting %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+// SaveSetting stores value under key, replacing any previous value.
+// The write is refreshed before returning so it is visible to the next load.
+func (s *SettingsStore) SaveSetting(ctx context.Context, key string, value any) error {
+	if err := s.index.ensure(ctx); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal setting %s: %w", key, err)
+	}
+	body, err := json.Marshal(settingDocument{Value: raw, UpdatedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal setting %s: %w", key, err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      s.indexName,
+		DocumentID: url.PathEscape(key),
+		Body:       bytes.NewReader(body),
+		Refresh:    "wait_for",
+	}
+
+	res, err := req.Do(ctx, s.client.es)
+	if err != nil {
+		return fmt.Errorf("failed to save setting %s: %w", key, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("save setting error: %s - %s", res.Status(), string(resBody))
+	}
+
+	s.client.logger.DebugContext(ctx, "saved setting", "index", s.indexName, "key", key)
+	return nil
+}
@@ -0,0 +1,104 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// settingsTestServer is a minimal Elasticsearch stand-in storing documents of the settings index
+type settingsTestServer struct {
+	mu          sync.Mutex
+	indexExists bool
+	createCalls int
+	docs        map[string][]byte
+}
+
+func (s *settingsTestServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodHead && r.URL.Path == "/settings":
+		if !s.indexExists {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut && r.URL.Path == "/settings":
+		s.createCalls++
+		s.indexExists = true
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"acknowledged":true}`))
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.docs[r.URL.Path] = body
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":"created"}`))
+	case r.Method == http.MethodGet:
+		doc, ok := s.docs[r.URL.Path]
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"found":false}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"found": true, "_source": json.RawMessage(doc)})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestSettingsStore_SaveAndLoad(t *testing.T) {
+	backend := &settingsTestServer{docs: make(map[string][]byte)}
+	server := createMockESServer(backend.handle)
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	store := NewSettingsStore(client, "settings")
+	ctx := context.Background()
+
+	type prefs struct {
+		Theme    string `json:"theme"`
+		PageSize int    `json:"pageSize"`
+	}
+
+	var loaded prefs
+	found, err := store.LoadSetting(ctx, "preferences:user@example.com", &loaded)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, store.SaveSetting(ctx, "preferences:user@example.com", prefs{Theme: "dark", PageSize: 100}))
+	require.NoError(t, store.SaveSetting(ctx, "preferences:other@example.com", prefs{Theme: "light", PageSize: 25}))
+
+	// The index is created with its mapping once, on first write
+	assert.Equal(t, 1, backend.createCalls)
+
+	found, err = store.LoadSetting(ctx, "preferences:user@example.com", &loaded)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, prefs{Theme: "dark", PageSize: 100}, loaded)
+}
+
+func TestSettingsStore_LoadError(t *testing.T) {
+	server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"boom"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewWithURL(server.URL, "", "")
+	require.NoError(t, err)
+	store := NewSettingsStore(client, "settings")
+
+	var value map[string]interface{}
+	found, err := store.LoadSetting(context.Background(), "key", &value)
+
+	assert.Error(t, err)
+	assert.False(t, found)
+}
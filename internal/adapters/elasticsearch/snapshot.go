@@ -0,0 +1,59 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// CreateSnapshot snapshots the indexes into the repository and waits for the snapshot to complete.
+// Indexes that do not exist are skipped. The repository must be registered in the cluster.
+func (c *Client) CreateSnapshot(ctx context.Context, repository string, name string, indexes []string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"indices":              strings.Join(indexes, ","),
+		"ignore_unavailable":   true,
+		"include_global_state": false,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot request: %w", err)
+	}
+
+	waitForCompletion := true
+	req := esapi.SnapshotCreateRequest{
+		Repository:        repository,
+		Snapshot:          name,
+		Body:              bytes.NewReader(body),
+		WaitForCompletion: &waitForCompletion,
+	}
+
+	res, err := req.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot %s: %w", name, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		resBody, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("create snapshot error: %s - %s", res.Status(), string(resBody))
+	}
+
+	var response struct {
+		Snapshot struct {
+			State string `json:"state"`
+		} `json:"snapshot"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to parse snapshot response: %w", err)
+	}
+	if response.Snapshot.State != "SUCCESS" {
+		return fmt.Errorf("snapshot %s finished in state %s", name, response.Snapshot.State)
+	}
+
+	c.logger.InfoContext(ctx, "created snapshot", "repository", repository, "snapshot", name, "indexes", indexes)
+	return nil
+}
@@ -0,0 +1,68 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateSnapshot(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var request map[string]interface{}
+		var waitForCompletion string
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut && r.URL.Path == "/_snapshot/backups/republish-1" {
+				waitForCompletion = r.URL.Query().Get("wait_for_completion")
+				json.NewDecoder(r.Body).Decode(&request)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"snapshot":{"snapshot":"republish-1","state":"SUCCESS"}}`))
+				return
+			}
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		require.NoError(t, client.CreateSnapshot(context.Background(), "backups", "republish-1", []string{"private", "public"}))
+		assert.Equal(t, "true", waitForCompletion)
+		assert.Equal(t, "private,public", request["indices"])
+		assert.Equal(t, false, request["include_global_state"])
+	})
+
+	t.Run("partial snapshot", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"snapshot":{"snapshot":"republish-1","state":"PARTIAL"}}`))
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		err = client.CreateSnapshot(context.Background(), "backups", "republish-1", []string{"public"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PARTIAL")
+	})
+
+	t.Run("missing repository", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"repository_missing_exception"}}`))
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		err = client.CreateSnapshot(context.Background(), "backups", "republish-1", []string{"public"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "repository_missing_exception")
+	})
+}
@@ -0,0 +1,96 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// talkSuggestion is the name of the completion suggestion in suggest requests
+const talkSuggestion = "talk"
+
+// talkDocument is a talk as stored in the talk indexes, along with the inputs of the completion suggester
+type talkDocument struct {
+	domain.Talk
+	Suggest []string `json:"suggest,omitempty"`
+}
+
+// suggestResponse is the subset of the suggest section of a search API response
+type suggestResponse map[string][]struct {
+	Options []struct {
+		Text string `json:"text"`
+	} `json:"options"`
+}
+
+// suggestInputs returns the completion suggester inputs of a talk: its title, the names of its
+// speakers and its keywords, trimmed and without duplicates
+func suggestInputs(talk domain.Talk) []string {
+	var values []string
+	if title, ok := talk.Data["title"].(string); ok {
+		values = append(values, title)
+	}
+	for _, speaker := range talk.Speakers {
+		values = append(values, speaker.Name)
+	}
+	switch keywords := talk.Data["keywords"].(type) {
+	case []string:
+		values = append(values, keywords...)
+	case []interface{}:
+		for _, keyword := range keywords {
+			if keyword, ok := keyword.(string); ok {
+				values = append(values, keyword)
+			}
+		}
+	}
+
+	var inputs []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(inputs, value) {
+			inputs = append(inputs, value)
+		}
+	}
+	return inputs
+}
+
+// Suggest returns up to size titles, speaker names and keywords of the talks in the specified index
+// starting with the prefix, using the completion suggester. Duplicate suggestions are skipped.
+func (c *Client) Suggest(ctx context.Context, indexName string, prefix string, size int) ([]string, error) {
+	body := map[string]interface{}{
+		"_source": false,
+		"suggest": map[string]interface{}{
+			talkSuggestion: map[string]interface{}{
+				"prefix": prefix,
+				"completion": map[string]interface{}{
+					"field":           "suggest",
+					"size":            size,
+					"skip_duplicates": true,
+				},
+			},
+		},
+	}
+
+	response, err := c.search(ctx, indexName, body)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []string{}
+	if len(response.Suggest) == 0 {
+		return suggestions, nil
+	}
+	var suggest suggestResponse
+	if err := json.Unmarshal(response.Suggest, &suggest); err != nil {
+		return nil, fmt.Errorf("failed to parse suggest response: %w", err)
+	}
+	for _, entry := range suggest[talkSuggestion] {
+		for _, option := range entry.Options {
+			suggestions = append(suggestions, option.Text)
+		}
+	}
+	return suggestions, nil
+}
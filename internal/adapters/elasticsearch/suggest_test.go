@@ -0,0 +1,77 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestInputs(t *testing.T) {
+	talk := domain.Talk{
+		Speakers: domain.Speakers{{Name: "Ada Lovelace"}, {Name: " "}},
+		Data: map[string]interface{}{
+			"title":    " Kotlin for Java developers ",
+			"keywords": []interface{}{"kotlin", "Ada Lovelace", 42},
+		},
+	}
+	assert.Equal(t, []string{"Kotlin for Java developers", "Ada Lovelace", "kotlin"}, suggestInputs(talk))
+	assert.Empty(t, suggestInputs(domain.Talk{}))
+}
+
+func TestClient_Suggest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var request map[string]interface{}
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && r.URL.Path == "/public/_search" {
+				json.NewDecoder(r.Body).Decode(&request)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"hits":{"hits":[]},"suggest":{"talk":[{"text":"kot","options":[
+					{"text":"Kotlin for Java developers","_id":"talk-1"},
+					{"text":"kotlin","_id":"talk-2"}
+				]}]}}`))
+				return
+			}
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		suggestions, err := client.Suggest(context.Background(), "public", "kot", 5)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Kotlin for Java developers", "kotlin"}, suggestions)
+		assert.Equal(t, false, request["_source"])
+		assert.Equal(t, map[string]interface{}{
+			"talk": map[string]interface{}{
+				"prefix": "kot",
+				"completion": map[string]interface{}{
+					"field":           "suggest",
+					"size":            float64(5),
+					"skip_duplicates": true,
+				},
+			},
+		}, request["suggest"])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		server := createMockESServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"type":"illegal_argument_exception","reason":"no mapping found for field [suggest]"}}`))
+		}))
+		defer server.Close()
+
+		client, err := NewWithURL(server.URL, "", "")
+		require.NoError(t, err)
+
+		_, err = client.Suggest(context.Background(), "public", "kot", 5)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no mapping found")
+	})
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: indexer.proto
+
+package indexerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReindexAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexAllRequest) Reset() {
+	*x = ReindexAllRequest{}
+	mi := &file_indexer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexAllRequest) ProtoMessage() {}
+
+func (x *ReindexAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexAllRequest.ProtoReflect.Descriptor instead.
+func (*ReindexAllRequest) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{0}
+}
+
+type ReindexConferenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexConferenceRequest) Reset() {
+	*x = ReindexConferenceRequest{}
+	mi := &file_indexer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexConferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexConferenceRequest) ProtoMessage() {}
+
+func (x *ReindexConferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexConferenceRequest.ProtoReflect.Descriptor instead.
+func (*ReindexConferenceRequest) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReindexConferenceRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type ReindexConferenceByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConferenceId  string                 `protobuf:"bytes,1,opt,name=conference_id,json=conferenceId,proto3" json:"conference_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexConferenceByIdRequest) Reset() {
+	*x = ReindexConferenceByIdRequest{}
+	mi := &file_indexer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexConferenceByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexConferenceByIdRequest) ProtoMessage() {}
+
+func (x *ReindexConferenceByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexConferenceByIdRequest.ProtoReflect.Descriptor instead.
+func (*ReindexConferenceByIdRequest) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReindexConferenceByIdRequest) GetConferenceId() string {
+	if x != nil {
+		return x.ConferenceId
+	}
+	return ""
+}
+
+type ReindexTalkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TalkId        string                 `protobuf:"bytes,1,opt,name=talk_id,json=talkId,proto3" json:"talk_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexTalkRequest) Reset() {
+	*x = ReindexTalkRequest{}
+	mi := &file_indexer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexTalkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexTalkRequest) ProtoMessage() {}
+
+func (x *ReindexTalkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexTalkRequest.ProtoReflect.Descriptor instead.
+func (*ReindexTalkRequest) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReindexTalkRequest) GetTalkId() string {
+	if x != nil {
+		return x.TalkId
+	}
+	return ""
+}
+
+type ReindexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexResponse) Reset() {
+	*x = ReindexResponse{}
+	mi := &file_indexer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexResponse) ProtoMessage() {}
+
+func (x *ReindexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_indexer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexResponse.ProtoReflect.Descriptor instead.
+func (*ReindexResponse) Descriptor() ([]byte, []int) {
+	return file_indexer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReindexResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_indexer_proto protoreflect.FileDescriptor
+
+const file_indexer_proto_rawDesc = "" +
+	"\n" +
+	"\rindexer.proto\x12\x0ftalksindexer.v1\"\x13\n" +
+	"\x11ReindexAllRequest\".\n" +
+	"\x18ReindexConferenceRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"C\n" +
+	"\x1cReindexConferenceByIdRequest\x12#\n" +
+	"\rconference_id\x18\x01 \x01(\tR\fconferenceId\"-\n" +
+	"\x12ReindexTalkRequest\x12\x17\n" +
+	"\atalk_id\x18\x01 \x01(\tR\x06talkId\"+\n" +
+	"\x0fReindexResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage2\x86\x03\n" +
+	"\x0eIndexerService\x12R\n" +
+	"\n" +
+	"ReindexAll\x12\".talksindexer.v1.ReindexAllRequest\x1a .talksindexer.v1.ReindexResponse\x12`\n" +
+	"\x11ReindexConference\x12).talksindexer.v1.ReindexConferenceRequest\x1a .talksindexer.v1.ReindexResponse\x12h\n" +
+	"\x15ReindexConferenceById\x12-.talksindexer.v1.ReindexConferenceByIdRequest\x1a .talksindexer.v1.ReindexResponse\x12T\n" +
+	"\vReindexTalk\x12#.talksindexer.v1.ReindexTalkRequest\x1a .talksindexer.v1.ReindexResponseBCZAgithub.com/javaBin/talks-indexer/internal/adapters/grpc/indexerpbb\x06proto3"
+
+var (
+	file_indexer_proto_rawDescOnce sync.Once
+	file_indexer_proto_rawDescData []byte
+)
+
+func file_indexer_proto_rawDescGZIP() []byte {
+	file_indexer_proto_rawDescOnce.Do(func() {
+		file_indexer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_indexer_proto_rawDesc), len(file_indexer_proto_rawDesc)))
+	})
+	return file_indexer_proto_rawDescData
+}
+
+var file_indexer_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_indexer_proto_goTypes = []any{
+	(*ReindexAllRequest)(nil),            // 0: talksindexer.v1.ReindexAllRequest
+	(*ReindexConferenceRequest)(nil),     // 1: talksindexer.v1.ReindexConferenceRequest
+	(*ReindexConferenceByIdRequest)(nil), // 2: talksindexer.v1.ReindexConferenceByIdRequest
+	(*ReindexTalkRequest)(nil),           // 3: talksindexer.v1.ReindexTalkRequest
+	(*ReindexResponse)(nil),              // 4: talksindexer.v1.ReindexResponse
+}
+var file_indexer_proto_depIdxs = []int32{
+	0, // 0: talksindexer.v1.IndexerService.ReindexAll:input_type -> talksindexer.v1.ReindexAllRequest
+	1, // 1: talksindexer.v1.IndexerService.ReindexConference:input_type -> talksindexer.v1.ReindexConferenceRequest
+	2, // 2: talksindexer.v1.IndexerService.ReindexConferenceById:input_type -> talksindexer.v1.ReindexConferenceByIdRequest
+	3, // 3: talksindexer.v1.IndexerService.ReindexTalk:input_type -> talksindexer.v1.ReindexTalkRequest
+	4, // 4: talksindexer.v1.IndexerService.ReindexAll:output_type -> talksindexer.v1.ReindexResponse
+	4, // 5: talksindexer.v1.IndexerService.ReindexConference:output_type -> talksindexer.v1.ReindexResponse
+	4, // 6: talksindexer.v1.IndexerService.ReindexConferenceById:output_type -> talksindexer.v1.ReindexResponse
+	4, // 7: talksindexer.v1.IndexerService.ReindexTalk:output_type -> talksindexer.v1.ReindexResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_indexer_proto_init() }
+func file_indexer_proto_init() {
+	if File_indexer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_indexer_proto_rawDesc), len(file_indexer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_indexer_proto_goTypes,
+		DependencyIndexes: file_indexer_proto_depIdxs,
+		MessageInfos:      file_indexer_proto_msgTypes,
+	}.Build()
+	File_indexer_proto = out.File
+	file_indexer_proto_goTypes = nil
+	file_indexer_proto_depIdxs = nil
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: indexer.proto
+
+package indexerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	IndexerService_ReindexAll_FullMethodName            = "/talksindexer.v1.IndexerService/ReindexAll"
+	IndexerService_ReindexConference_FullMethodName     = "/talksindexer.v1.IndexerService/ReindexConference"
+	IndexerService_ReindexConferenceById_FullMethodName = "/talksindexer.v1.IndexerService/ReindexConferenceById"
+	IndexerService_ReindexTalk_FullMethodName           = "/talksindexer.v1.IndexerService/ReindexTalk"
+)
+
+// IndexerServiceClient is the client API for IndexerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IndexerServiceClient interface {
+	ReindexAll(ctx context.Context, in *ReindexAllRequest, opts ...grpc.CallOption) (*ReindexResponse, error)
+	ReindexConference(ctx context.Context, in *ReindexConferenceRequest, opts ...grpc.CallOption) (*ReindexResponse, error)
+	ReindexConferenceById(ctx context.Context, in *ReindexConferenceByIdRequest, opts ...grpc.CallOption) (*ReindexResponse, error)
+	ReindexTalk(ctx context.Context, in *ReindexTalkRequest, opts ...grpc.CallOption) (*ReindexResponse, error)
+}
+
+type indexerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIndexerServiceClient(cc grpc.ClientConnInterface) IndexerServiceClient {
+	return &indexerServiceClient{cc}
+}
+
+func (c *indexerServiceClient) ReindexAll(ctx context.Context, in *ReindexAllRequest, opts ...grpc.CallOption) (*ReindexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexResponse)
+	err := c.cc.Invoke(ctx, IndexerService_ReindexAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerServiceClient) ReindexConference(ctx context.Context, in *ReindexConferenceRequest, opts ...grpc.CallOption) (*ReindexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexResponse)
+	err := c.cc.Invoke(ctx, IndexerService_ReindexConference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerServiceClient) ReindexConferenceById(ctx context.Context, in *ReindexConferenceByIdRequest, opts ...grpc.CallOption) (*ReindexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexResponse)
+	err := c.cc.Invoke(ctx, IndexerService_ReindexConferenceById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerServiceClient) ReindexTalk(ctx context.Context, in *ReindexTalkRequest, opts ...grpc.CallOption) (*ReindexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexResponse)
+	err := c.cc.Invoke(ctx, IndexerService_ReindexTalk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IndexerServiceServer is the server API for IndexerService service.
+// All implementations must embed UnimplementedIndexerServiceServer
+// for forward compatibility.
+type IndexerServiceServer interface {
+	ReindexAll(context.Context, *ReindexAllRequest) (*ReindexResponse, error)
+	ReindexConference(context.Context, *ReindexConferenceRequest) (*ReindexResponse, error)
+	ReindexConferenceById(context.Context, *ReindexConferenceByIdRequest) (*ReindexResponse, error)
+	ReindexTalk(context.Context, *ReindexTalkRequest) (*ReindexResponse, error)
+	mustEmbedUnimplementedIndexerServiceServer()
+}
+
+// UnimplementedIndexerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIndexerServiceServer struct{}
+
+func (UnimplementedIndexerServiceServer) ReindexAll(context.Context, *ReindexAllRequest) (*ReindexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReindexAll not implemented")
+}
+func (UnimplementedIndexerServiceServer) ReindexConference(context.Context, *ReindexConferenceRequest) (*ReindexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReindexConference not implemented")
+}
+func (UnimplementedIndexerServiceServer) ReindexConferenceById(context.Context, *ReindexConferenceByIdRequest) (*ReindexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReindexConferenceById not implemented")
+}
+func (UnimplementedIndexerServiceServer) ReindexTalk(context.Context, *ReindexTalkRequest) (*ReindexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReindexTalk not implemented")
+}
+func (UnimplementedIndexerServiceServer) mustEmbedUnimplementedIndexerServiceServer() {}
+func (UnimplementedIndexerServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeIndexerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IndexerServiceServer will
+// result in compilation errors.
+type UnsafeIndexerServiceServer interface {
+	mustEmbedUnimplementedIndexerServiceServer()
+}
+
+func RegisterIndexerServiceServer(s grpc.ServiceRegistrar, srv IndexerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIndexerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IndexerService_ServiceDesc, srv)
+}
+
+func _IndexerService_ReindexAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerServiceServer).ReindexAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerService_ReindexAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerServiceServer).ReindexAll(ctx, req.(*ReindexAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerService_ReindexConference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexConferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerServiceServer).ReindexConference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerService_ReindexConference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerServiceServer).ReindexConference(ctx, req.(*ReindexConferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerService_ReindexConferenceById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexConferenceByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerServiceServer).ReindexConferenceById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerService_ReindexConferenceById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerServiceServer).ReindexConferenceById(ctx, req.(*ReindexConferenceByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerService_ReindexTalk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexTalkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerServiceServer).ReindexTalk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerService_ReindexTalk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerServiceServer).ReindexTalk(ctx, req.(*ReindexTalkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IndexerService_ServiceDesc is the grpc.ServiceDesc for IndexerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IndexerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "talksindexer.v1.IndexerService",
+	HandlerType: (*IndexerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReindexAll",
+			Handler:    _IndexerService_ReindexAll_Handler,
+		},
+		{
+			MethodName: "ReindexConference",
+			Handler:    _IndexerService_ReindexConference_Handler,
+		},
+		{
+			MethodName: "ReindexConferenceById",
+			Handler:    _IndexerService_ReindexConferenceById_Handler,
+		},
+		{
+			MethodName: "ReindexTalk",
+			Handler:    _IndexerService_ReindexTalk_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "indexer.proto",
+}
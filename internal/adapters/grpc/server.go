@@ -0,0 +1,150 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/grpc/indexerpb"
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// grpcActor attributes reindexes triggered over gRPC, whose callers are identified by the shared token only
+var grpcActor = domain.Actor{Kind: domain.ActorAPIKey, Name: "grpc"}
+
+// Adapter serves the Indexer port over gRPC for other internal services, such as the CFP backend.
+// Reindexes run within the call, so the caller's deadline and cancellation reach the indexer.
+type Adapter struct {
+	indexerpb.UnimplementedIndexerServiceServer
+
+	indexer ports.Indexer
+	cfg     *config.Config
+	server  *grpc.Server
+}
+
+// New creates a new gRPC adapter
+func New(ctx context.Context, indexer ports.Indexer) *Adapter {
+	a := &Adapter{
+		indexer: indexer,
+		cfg:     config.GetConfig(ctx),
+	}
+	a.server = grpc.NewServer(grpc.ChainUnaryInterceptor(a.authenticate, a.writable))
+	indexerpb.RegisterIndexerServiceServer(a.server, a)
+	return a
+}
+
+// Serve accepts connections on the listener until Stop is called
+func (a *Adapter) Serve(lis net.Listener) error {
+	return a.server.Serve(lis)
+}
+
+// Stop stops accepting connections and waits for running calls to finish
+func (a *Adapter) Stop() {
+	a.server.GracefulStop()
+}
+
+// authenticate rejects calls without the configured bearer token and attributes the others to the gRPC actor
+func (a *Adapter) authenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			token, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+	}
+
+	if !a.cfg.Grpc.IsConfigured() || subtle.ConstantTimeCompare([]byte(token), []byte(a.cfg.Grpc.Token)) != 1 {
+		slog.WarnContext(ctx, "rejected gRPC call with invalid token", "method", info.FullMethod)
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	return handler(domain.WithActor(ctx, grpcActor), req)
+}
+
+// writable refuses every call in read-only mode, since all methods change the indexes
+func (a *Adapter) writable(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if a.cfg.ReadOnly {
+		slog.WarnContext(ctx, "refused gRPC call in read-only mode", "method", info.FullMethod)
+		return nil, status.Error(codes.Unavailable, "the indexer is in read-only mode, try again after maintenance")
+	}
+	return handler(ctx, req)
+}
+
+// ReindexAll reindexes all conferences
+func (a *Adapter) ReindexAll(ctx context.Context, req *indexerpb.ReindexAllRequest) (*indexerpb.ReindexResponse, error) {
+	slog.InfoContext(ctx, "grpc: starting full reindex")
+	if err := a.indexer.ReindexAll(ctx); err != nil {
+		return nil, reindexError(ctx, "failed to reindex all conferences", err)
+	}
+	return &indexerpb.ReindexResponse{Message: "successfully reindexed all conferences"}, nil
+}
+
+// ReindexConference reindexes the conference with the slug
+func (a *Adapter) ReindexConference(ctx context.Context, req *indexerpb.ReindexConferenceRequest) (*indexerpb.ReindexResponse, error) {
+	if req.GetSlug() == "" {
+		return nil, status.Error(codes.InvalidArgument, "conference slug is required")
+	}
+
+	slog.InfoContext(ctx, "grpc: starting conference reindex", "slug", req.GetSlug())
+	if err := a.indexer.ReindexConference(ctx, req.GetSlug()); err != nil {
+		return nil, reindexError(ctx, "failed to reindex conference", err)
+	}
+	return &indexerpb.ReindexResponse{Message: "successfully reindexed conference: " + req.GetSlug()}, nil
+}
+
+// ReindexConferenceById reindexes the conference with the moresleep ID
+func (a *Adapter) ReindexConferenceById(ctx context.Context, req *indexerpb.ReindexConferenceByIdRequest) (*indexerpb.ReindexResponse, error) {
+	if req.GetConferenceId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "conference ID is required")
+	}
+
+	slog.InfoContext(ctx, "grpc: starting conference reindex", "conferenceID", req.GetConferenceId())
+	if err := a.indexer.ReindexConferenceByID(ctx, req.GetConferenceId()); err != nil {
+		return nil, reindexError(ctx, "failed to reindex conference", err)
+	}
+	return &indexerpb.ReindexResponse{Message: "successfully reindexed conference: " + req.GetConferenceId()}, nil
+}
+
+// ReindexTalk reindexes a single talk
+func (a *Adapter) ReindexTalk(ctx context.Context, req *indexerpb.ReindexTalkRequest) (*indexerpb.ReindexResponse, error) {
+	if req.GetTalkId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "talk ID is required")
+	}
+
+	slog.InfoContext(ctx, "grpc: starting talk reindex", "talkID", req.GetTalkId())
+	if err := a.indexer.ReindexTalk(ctx, req.GetTalkId()); err != nil {
+		return nil, reindexError(ctx, "failed to reindex talk", err)
+	}
+	return &indexerpb.ReindexResponse{Message: "successfully reindexed talk: " + req.GetTalkId()}, nil
+}
+
+// reindexError logs a failed reindex and converts the error to a gRPC status with a matching code
+func reindexError(ctx context.Context, message string, err error) error {
+	slog.ErrorContext(ctx, "grpc: "+message, "error", err)
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, domain.ErrConferenceNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrAmbiguousConference):
+		code = codes.FailedPrecondition
+	case errors.Is(err, domain.ErrInsufficientCapacity):
+		code = codes.ResourceExhausted
+	case errors.Is(err, domain.ErrJobRunning):
+		code = codes.Aborted
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+	return status.Error(code, message+": "+err.Error())
+}
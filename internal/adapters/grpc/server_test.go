@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/grpc/indexerpb"
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// mockIndexer is a mock implementation of the Indexer interface for testing
+type mockIndexer struct {
+	reindexConferenceFunc func(ctx context.Context, slug string) error
+	reindexTalkFunc       func(ctx context.Context, talkID string) error
+}
+
+func (m *mockIndexer) ReindexAll(ctx context.Context) error { return nil }
+
+func (m *mockIndexer) ReindexConference(ctx context.Context, slug string) error {
+	if m.reindexConferenceFunc != nil {
+		return m.reindexConferenceFunc(ctx, slug)
+	}
+	return nil
+}
+
+func (m *mockIndexer) ReindexConferenceByID(ctx context.Context, conferenceID string) error {
+	return nil
+}
+
+func (m *mockIndexer) ReindexTalk(ctx context.Context, talkID string) error {
+	if m.reindexTalkFunc != nil {
+		return m.reindexTalkFunc(ctx, talkID)
+	}
+	return nil
+}
+
+func (m *mockIndexer) DeleteTalk(ctx context.Context, talkID string) error { return nil }
+
+func (m *mockIndexer) LastReindex(indexName string) time.Time { return time.Time{} }
+
+func (m *mockIndexer) IndexNames() domain.IndexNames {
+	return domain.IndexNames{Private: "private", Public: "public"}
+}
+
+// newTestClient serves the adapter over an in-memory connection and returns a client for it
+func newTestClient(t *testing.T, cfg *config.Config, indexer *mockIndexer) indexerpb.IndexerServiceClient {
+	adapter := New(config.WithConfig(context.Background(), cfg), indexer)
+	lis := bufconn.Listen(1024 * 1024)
+	go adapter.Serve(lis)
+	t.Cleanup(adapter.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return indexerpb.NewIndexerServiceClient(conn)
+}
+
+// withToken returns a context sending the bearer token
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestReindexTalk(t *testing.T) {
+	var captured string
+	var actor domain.Actor
+	var deadline time.Time
+	indexer := &mockIndexer{reindexTalkFunc: func(ctx context.Context, talkID string) error {
+		captured = talkID
+		actor = domain.ActorFromContext(ctx)
+		deadline, _ = ctx.Deadline()
+		return nil
+	}}
+	client := newTestClient(t, &config.Config{Grpc: config.GrpcConfig{Token: "secret"}}, indexer)
+
+	ctx, cancel := context.WithTimeout(withToken("secret"), time.Minute)
+	defer cancel()
+	resp, err := client.ReindexTalk(ctx, &indexerpb.ReindexTalkRequest{TalkId: "talk-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "successfully reindexed talk: talk-1", resp.GetMessage())
+	assert.Equal(t, "talk-1", captured)
+	assert.Equal(t, grpcActor, actor)
+	assert.False(t, deadline.IsZero(), "the caller's deadline reaches the indexer")
+}
+
+func TestReindexConference_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		token    string
+		slug     string
+		err      error
+		wantCode codes.Code
+	}{
+		{"missing token", config.Config{Grpc: config.GrpcConfig{Token: "secret"}}, "", "javazone2024", nil, codes.Unauthenticated},
+		{"wrong token", config.Config{Grpc: config.GrpcConfig{Token: "secret"}}, "guess", "javazone2024", nil, codes.Unauthenticated},
+		{"no token configured", config.Config{}, "", "javazone2024", nil, codes.Unauthenticated},
+		{"read-only mode", config.Config{ApplicationConfig: config.ApplicationConfig{ReadOnly: true}, Grpc: config.GrpcConfig{Token: "secret"}}, "secret", "javazone2024", nil, codes.Unavailable},
+		{"missing slug", config.Config{Grpc: config.GrpcConfig{Token: "secret"}}, "secret", "", nil, codes.InvalidArgument},
+		{"unknown conference", config.Config{Grpc: config.GrpcConfig{Token: "secret"}}, "secret", "javazone1999", fmt.Errorf("%w: javazone1999", domain.ErrConferenceNotFound), codes.NotFound},
+		{"duplicate slug", config.Config{Grpc: config.GrpcConfig{Token: "secret"}}, "secret", "javazone2024", fmt.Errorf("%w javazone2024", domain.ErrAmbiguousConference), codes.FailedPrecondition},
+		{"already running", config.Config{Grpc: config.GrpcConfig{Token: "secret"}}, "secret", "javazone2024", fmt.Errorf("%w: job job-1", domain.ErrJobRunning), codes.Aborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := &mockIndexer{reindexConferenceFunc: func(ctx context.Context, slug string) error {
+				return tt.err
+			}}
+			client := newTestClient(t, &tt.cfg, indexer)
+
+			_, err := client.ReindexConference(withToken(tt.token), &indexerpb.ReindexConferenceRequest{Slug: tt.slug})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// userAgent identifies the indexer to the sites being checked
+const userAgent = "talks-indexer-linkcheck/1.0"
+
+// maxRedirects is how many redirects are followed before a link is given up on
+const maxRedirects = 10
+
+// errBlockedAddress is returned when a link resolves to an address on the indexer's own network
+var errBlockedAddress = errors.New("address not allowed")
+
+// headUnsupported lists statuses some servers answer HEAD with while GET works, so GET is tried instead
+var headUnsupported = []int{http.StatusForbidden, http.StatusMethodNotAllowed, http.StatusNotImplemented}
+
+// Client implements the LinkChecker interface over HTTP
+type Client struct {
+	httpClient *http.Client
+}
+
+// New creates a new link check Client, retrieving configuration from context.
+// Links come from speakers, so the client refuses to connect to loopback, private, link-local and
+// unspecified addresses, checked after DNS resolution and again for every redirect.
+func New(ctx context.Context) *Client {
+	cfg := config.GetConfig(ctx)
+	return NewWithHTTPClient(newPublicHTTPClient(cfg.LinkCheck.Timeout))
+}
+
+// newPublicHTTPClient creates an HTTP client that only connects to public addresses over http and https
+func newPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialPublicOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: checkRedirect,
+	}
+}
+
+// dialPublicOnly is a dialer Control hook that runs on the resolved address of every connection
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s", errBlockedAddress, host)
+	}
+	return nil
+}
+
+// checkRedirect only follows redirects to http and https URLs, and not to literal internal addresses
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("%w: redirect to %s URL", errBlockedAddress, req.URL.Scheme)
+	}
+	if ip := net.ParseIP(req.URL.Hostname()); ip != nil && !publicIP(ip) {
+		return fmt.Errorf("%w: %s", errBlockedAddress, ip)
+	}
+	return nil
+}
+
+// publicIP reports whether an address is outside loopback, private, link-local and unspecified ranges.
+// Link-local includes the cloud metadata address 169.254.169.254.
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast()
+}
+
+// NewWithHTTPClient creates a new link check Client with a custom HTTP client.
+// This constructor is primarily intended for testing purposes.
+func NewWithHTTPClient(httpClient *http.Client) *Client {
+	return &Client{httpClient: httpClient}
+}
+
+// CheckLink requests the URL with HEAD, falling back to GET for servers that do not support HEAD.
+// Redirects are followed. Only http and https URLs are checked; anything else is reported as broken.
+func (c *Client) CheckLink(ctx context.Context, rawURL string) domain.LinkCheck {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.LinkCheck{Status: domain.LinkBroken, Error: "not an http or https URL"}
+	}
+
+	statusCode, err := c.request(ctx, http.MethodHead, rawURL)
+	if err == nil && slices.Contains(headUnsupported, statusCode) {
+		statusCode, err = c.request(ctx, http.MethodGet, rawURL)
+	}
+
+	return classify(statusCode, err)
+}
+
+// request sends a request and returns the final response status code
+func (c *Client) request(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
+
+// classify maps a response status or request error to a link status.
+// Only outcomes that mean the target is gone are broken; everything else that fails may be temporary.
+func classify(statusCode int, err error) domain.LinkCheck {
+	if err != nil {
+		if errors.Is(err, errBlockedAddress) {
+			return domain.LinkCheck{Status: domain.LinkBroken, Error: err.Error()}
+		}
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return domain.LinkCheck{Status: domain.LinkBroken, Error: "host not found"}
+		}
+		return domain.LinkCheck{Status: domain.LinkUnreachable, Error: err.Error()}
+	}
+
+	switch {
+	case statusCode >= 200 && statusCode < 400:
+		return domain.LinkCheck{Status: domain.LinkOK, StatusCode: statusCode}
+	case statusCode == http.StatusNotFound || statusCode == http.StatusGone:
+		return domain.LinkCheck{Status: domain.LinkBroken, StatusCode: statusCode}
+	default:
+		return domain.LinkCheck{Status: domain.LinkUnreachable, StatusCode: statusCode}
+	}
+}
@@ -0,0 +1,118 @@
+package linkcheck
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CheckLink(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Write([]byte("ok"))
+		case "/error":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithHTTPClient(server.Client())
+
+	tests := []struct {
+		path       string
+		status     domain.LinkStatus
+		statusCode int
+	}{
+		{path: "/ok", status: domain.LinkOK, statusCode: http.StatusOK},
+		{path: "/moved", status: domain.LinkOK, statusCode: http.StatusOK},
+		{path: "/missing", status: domain.LinkBroken, statusCode: http.StatusNotFound},
+		{path: "/gone", status: domain.LinkBroken, statusCode: http.StatusGone},
+		{path: "/no-head", status: domain.LinkOK, statusCode: http.StatusOK},
+		{path: "/error", status: domain.LinkUnreachable, statusCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			check := client.CheckLink(context.Background(), server.URL+tt.path)
+
+			assert.Equal(t, tt.status, check.Status)
+			assert.Equal(t, tt.statusCode, check.StatusCode)
+		})
+	}
+
+	assert.Contains(t, methods, "GET /no-head", "falls back to GET when HEAD is not allowed")
+}
+
+func TestClient_CheckLink_InvalidURL(t *testing.T) {
+	client := NewWithHTTPClient(http.DefaultClient)
+
+	for _, rawURL := range []string{"ftp://example.com/file", "javascript:alert(1)", "not a url"} {
+		check := client.CheckLink(context.Background(), rawURL)
+		assert.Equal(t, domain.LinkBroken, check.Status, rawURL)
+	}
+}
+
+func TestClient_CheckLink_RefusesInternalAddresses(t *testing.T) {
+	var requests int
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer internal.Close()
+
+	client := NewWithHTTPClient(newPublicHTTPClient(time.Second))
+
+	for _, rawURL := range []string{internal.URL, "http://localhost:1/", "http://[::1]:1/", "http://169.254.169.254/latest/meta-data/", "http://10.0.0.1:1/"} {
+		check := client.CheckLink(context.Background(), rawURL)
+		assert.Equal(t, domain.LinkBroken, check.Status, rawURL)
+		assert.Contains(t, check.Error, "address not allowed", rawURL)
+	}
+	assert.Zero(t, requests, "never connects to the internal server")
+}
+
+func TestCheckRedirect(t *testing.T) {
+	redirect := func(rawURL string) error {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		require.NoError(t, err)
+		return checkRedirect(req, []*http.Request{{}})
+	}
+
+	assert.NoError(t, redirect("https://example.com/moved"))
+	assert.ErrorIs(t, redirect("http://127.0.0.1/admin"), errBlockedAddress)
+	assert.ErrorIs(t, redirect("http://192.168.1.1/"), errBlockedAddress)
+	assert.ErrorIs(t, redirect("file:///etc/passwd"), errBlockedAddress)
+	assert.Error(t, checkRedirect(&http.Request{URL: &url.URL{Scheme: "https", Host: "example.com"}}, make([]*http.Request, maxRedirects)))
+}
+
+func TestClassify_Errors(t *testing.T) {
+	notFound := classify(0, &net.DNSError{Err: "no such host", Name: "gone.example", IsNotFound: true})
+	refused := classify(0, &net.OpError{Op: "dial", Err: &net.DNSError{Err: "timeout", IsTimeout: true}})
+
+	assert.Equal(t, domain.LinkBroken, notFound.Status)
+	assert.Equal(t, domain.LinkUnreachable, refused.Status)
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// JobStore implements ports.JobStore in memory, keeping the most recent jobs up to its capacity.
+// Jobs are lost on restart; use the Elasticsearch job store to keep history.
+type JobStore struct {
+	capacity int
+
+	mu    sync.RWMutex
+	jobs  map[string]domain.Job
+	order []string
+}
+
+// NewJobStore creates an in-memory job store retaining at most capacity jobs
+func NewJobStore(capacity int) *JobStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &JobStore{
+		capacity: capacity,
+		jobs:     make(map[string]domain.Job),
+	}
+}
+
+// CreateJob stores a new job with a generated ID, evicting the oldest job when full
+func (s *JobStore) CreateJob(ctx context.Context, job domain.Job) (domain.Job, error) {
+	id, err := generateJobID()
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	job.ID = id
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[id] = job
+	s.order = append(s.order, id)
+	for len(s.order) > s.capacity {
+		delete(s.jobs, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	return job, nil
+}
+
+// UpdateJob replaces the stored job with the same ID
+func (s *JobStore) UpdateJob(ctx context.Context, job domain.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[job.ID]; !exists {
+		return fmt.Errorf("job not found: %s", job.ID)
+	}
+	s.jobs[job.ID] = job
+	return nil
+}
+
+// GetJob retrieves a job by ID, returns nil if not found or evicted
+func (s *JobStore) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, exists := s.jobs[id]
+	if !exists {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// ListJobs returns up to limit jobs, most recently created first.
+// A limit of zero or less returns all retained jobs.
+func (s *JobStore) ListJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = len(s.order)
+	}
+	jobs := make([]domain.Job, 0, min(limit, len(s.order)))
+	for i := len(s.order) - 1; i >= 0 && len(jobs) < limit; i-- {
+		jobs = append(jobs, s.jobs[s.order[i]])
+	}
+	return jobs, nil
+}
+
+// generateJobID generates a random job ID
+func generateJobID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobStore_CreateAndGet(t *testing.T) {
+	store := NewJobStore(10)
+	ctx := context.Background()
+
+	job, err := store.CreateJob(ctx, domain.NewJob(domain.JobScope{Kind: domain.JobKindReindexAll}, time.Now()))
+	require.NoError(t, err)
+	assert.NotEmpty(t, job.ID)
+
+	stored, err := store.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, domain.JobStateQueued, stored.State)
+
+	missing, err := store.GetJob(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestJobStore_Update(t *testing.T) {
+	store := NewJobStore(10)
+	ctx := context.Background()
+
+	job, err := store.CreateJob(ctx, domain.NewJob(domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}, time.Now()))
+	require.NoError(t, err)
+
+	job.Start(time.Now())
+	job.Finish(time.Now(), nil)
+	require.NoError(t, store.UpdateJob(ctx, job))
+
+	stored, err := store.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobStateSucceeded, stored.State)
+
+	assert.Error(t, store.UpdateJob(ctx, domain.Job{ID: "missing"}))
+}
+
+func TestJobStore_ListNewestFirstAndEvictsOldest(t *testing.T) {
+	store := NewJobStore(3)
+	ctx := context.Background()
+
+	var ids []string
+	for _, target := range []string{"a", "b", "c", "d"} {
+		job, err := store.CreateJob(ctx, domain.NewJob(domain.JobScope{Kind: domain.JobKindReindexConference, Target: target}, time.Now()))
+		require.NoError(t, err)
+		ids = append(ids, job.ID)
+	}
+
+	jobs, err := store.ListJobs(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+	assert.Equal(t, "d", jobs[0].Scope.Target)
+	assert.Equal(t, "b", jobs[2].Scope.Target)
+
+	evicted, err := store.GetJob(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Nil(t, evicted)
+
+	limited, err := store.ListJobs(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, limited, 2)
+}
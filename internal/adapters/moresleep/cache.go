@@ -0,0 +1,79 @@
+package moresleep
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// DefaultConferenceCacheTTL is how long the conference list is reused when none is configured
+const DefaultConferenceCacheTTL = 5 * time.Minute
+
+// conferenceCache keeps the last conference list fetched, so looking up the slug and name of the
+// conference of fetched talks does not list every conference again
+type conferenceCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu          sync.Mutex
+	conferences []domain.Conference
+	fetchedAt   time.Time
+}
+
+// SetConferenceCacheTTL sets how long the conference list is reused for conference lookups;
+// zero disables the cache
+func (c *Client) SetConferenceCacheTTL(ttl time.Duration) {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	c.cache.ttl = ttl
+}
+
+// InvalidateConferences drops the cached conference list, so the next lookup lists the conferences again
+func (c *Client) InvalidateConferences() {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	c.cache.conferences = nil
+	c.cache.fetchedAt = time.Time{}
+}
+
+// store replaces the cached conferences with a list just fetched
+func (cc *conferenceCache) store(conferences []domain.Conference) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.conferences = conferences
+	cc.fetchedAt = cc.now()
+}
+
+// fresh returns the cached conferences if they are younger than the TTL
+func (cc *conferenceCache) fresh() ([]domain.Conference, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.fetchedAt.IsZero() || cc.now().Sub(cc.fetchedAt) >= cc.ttl {
+		return nil, false
+	}
+	return cc.conferences, true
+}
+
+// lookupConference finds the conference in the cached list, listing the conferences when the cache
+// is stale or the conference is not in it, as it may have been created since the list was cached
+func (c *Client) lookupConference(ctx context.Context, conferenceID string) (*domain.Conference, error) {
+	match := func(conf domain.Conference) bool { return conf.ID == conferenceID }
+
+	if conferences, ok := c.cache.fresh(); ok {
+		if i := slices.IndexFunc(conferences, match); i >= 0 {
+			return &conferences[i], nil
+		}
+	}
+
+	conferences, err := c.GetConferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if i := slices.IndexFunc(conferences, match); i >= 0 {
+		return &conferences[i], nil
+	}
+	return nil, nil
+}
@@ -3,14 +3,19 @@ package moresleep
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 
+	"github.com/javaBin/talks-indexer/internal/chaos"
 	"github.com/javaBin/talks-indexer/internal/config"
 	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+	"github.com/javaBin/talks-indexer/internal/tlsconfig"
 )
 
 // Client implements the TalkSource interface for the moresleep API
@@ -19,32 +24,47 @@ type Client struct {
 	username   string
 	password   string
 	httpClient *http.Client
+	retry      RetryOptions
+	cache      conferenceCache
 	logger     *slog.Logger
+	resolver   ports.ConferenceResolver
 }
 
 // New creates a new moresleep Client, retrieving configuration from context
 // If username and password are configured, Basic Auth will be used for all requests
 func New(ctx context.Context) (*Client, error) {
 	cfg := config.GetConfig(ctx)
-	return &Client{
-		baseURL:  cfg.Moresleep.URL,
-		username: cfg.Moresleep.User,
-		password: cfg.Moresleep.Password,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: slog.Default(),
-	}, nil
+	transport, err := tlsconfig.Transport(cfg.Moresleep.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure moresleep TLS: %w", err)
+	}
+	if cfg.Chaos.Moresleep.IsConfigured() {
+		transport = chaos.NewTransport("moresleep", transport, cfg.Chaos.Moresleep)
+	}
+	httpClient := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+	client := NewWithHTTPClient(cfg.Moresleep.URL, cfg.Moresleep.User, cfg.Moresleep.Password, httpClient)
+	client.SetRetryOptions(RetryOptions{
+		MaxRetries:     cfg.Moresleep.MaxRetries,
+		Backoff:        cfg.Moresleep.RetryBackoff,
+		RequestTimeout: cfg.Moresleep.RequestTimeout,
+	})
+	client.SetConferenceCacheTTL(cfg.Moresleep.ConferenceCacheTTL)
+	return client, nil
 }
 
-// NewWithHTTPClient creates a new moresleep Client with a custom HTTP client.
-// This constructor is primarily intended for testing purposes.
+// NewWithHTTPClient creates a new moresleep Client with a custom HTTP client, the default retry
+// options and the default conference cache TTL. This constructor is primarily intended for testing purposes.
 func NewWithHTTPClient(baseURL, username, password string, httpClient *http.Client) *Client {
 	return &Client{
 		baseURL:    baseURL,
 		username:   username,
 		password:   password,
 		httpClient: httpClient,
+		retry:      DefaultRetryOptions(),
+		cache:      conferenceCache{ttl: DefaultConferenceCacheTTL, now: time.Now},
 		logger:     slog.Default(),
 	}
 }
@@ -54,13 +74,56 @@ func (c *Client) SetLogger(logger *slog.Logger) {
 	c.logger = logger
 }
 
-// doRequest performs an HTTP request with optional Basic Auth
+// SetConferenceResolver makes the client look up the conference of fetched talks with the resolver
+// instead of listing all conferences from moresleep for every GetTalks and GetTalk call
+func (c *Client) SetConferenceResolver(resolver ports.ConferenceResolver) {
+	c.resolver = resolver
+}
+
+// doRequest performs an HTTP request with optional Basic Auth. Requests failing with a 5xx status or a
+// network error, including an attempt running past its timeout, are retried with jittered backoff.
 func (c *Client) doRequest(ctx context.Context, method, path string) ([]byte, error) {
 	url := c.baseURL + path
 
+	for retry := 0; ; retry++ {
+		body, retryable, err := c.attempt(ctx, method, url)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable || retry >= c.retry.MaxRetries || ctx.Err() != nil {
+			c.logger.ErrorContext(ctx, "HTTP request failed",
+				"url", url,
+				"attempts", retry+1,
+				"error", err,
+			)
+			return nil, err
+		}
+
+		wait := c.retry.backoff(retry + 1)
+		c.logger.WarnContext(ctx, "HTTP request failed, retrying",
+			"url", url,
+			"attempt", retry+1,
+			"backoff", wait,
+			"error", err,
+		)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+	}
+}
+
+// attempt performs a single HTTP request bounded by the request timeout, reporting whether a failure
+// may succeed when retried
+func (c *Client) attempt(ctx context.Context, method, url string) ([]byte, bool, error) {
+	if c.retry.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.retry.RequestTimeout)
+		defer cancel()
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add Basic Auth if credentials are provided
@@ -77,22 +140,18 @@ func (c *Client) doRequest(ctx context.Context, method, path string) ([]byte, er
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, true, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, true, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		c.logger.ErrorContext(ctx, "HTTP request failed",
-			"status", resp.StatusCode,
-			"url", url,
-			"body", string(body),
-		)
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		retryable := resp.StatusCode >= http.StatusInternalServerError
+		return nil, retryable, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
 	c.logger.DebugContext(ctx, "HTTP request successful",
@@ -100,10 +159,11 @@ func (c *Client) doRequest(ctx context.Context, method, path string) ([]byte, er
 		"url", url,
 	)
 
-	return body, nil
+	return body, false, nil
 }
 
-// GetConferences retrieves all available conferences from the moresleep API
+// GetConferences retrieves all available conferences from the moresleep API. The list is always
+// fetched, and replaces the cached list used to look up the conference of fetched talks.
 func (c *Client) GetConferences(ctx context.Context) ([]domain.Conference, error) {
 	c.logger.InfoContext(ctx, "Fetching conferences from moresleep API")
 
@@ -127,6 +187,7 @@ func (c *Client) GetConferences(ctx context.Context) ([]domain.Conference, error
 	}
 
 	conferences := MapConferences(response.Conferences)
+	c.cache.store(conferences)
 
 	c.logger.InfoContext(ctx, "Successfully fetched conferences",
 		"count", len(conferences),
@@ -141,7 +202,7 @@ func (c *Client) GetTalks(ctx context.Context, conferenceID string) ([]domain.Ta
 		"conferenceID", conferenceID,
 	)
 
-	path := fmt.Sprintf("/data/conference/%s/session", conferenceID)
+	path := fmt.Sprintf("/data/conference/%s/session", url.PathEscape(conferenceID))
 	body, err := c.doRequest(ctx, http.MethodGet, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch talks for conference %s: %w", conferenceID, err)
@@ -163,25 +224,9 @@ func (c *Client) GetTalks(ctx context.Context, conferenceID string) ([]domain.Ta
 	}
 
 	// We need to get the conference slug and name for mapping
-	// First, fetch the conference to get its details
-	conferences, err := c.GetConferences(ctx)
+	conferenceSlug, conferenceName, err := c.conferenceDetails(ctx, conferenceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch conferences to get details: %w", err)
-	}
-
-	var conferenceSlug, conferenceName string
-	for _, conf := range conferences {
-		if conf.ID == conferenceID {
-			conferenceSlug = conf.Slug
-			conferenceName = conf.Name
-			break
-		}
-	}
-
-	if conferenceSlug == "" {
-		c.logger.WarnContext(ctx, "Conference not found, using empty strings",
-			"conferenceID", conferenceID,
-		)
+		return nil, err
 	}
 
 	talks := MapTalks(response.Sessions, conferenceSlug, conferenceName)
@@ -200,7 +245,7 @@ func (c *Client) GetTalk(ctx context.Context, talkID string) (*domain.Talk, erro
 		"talkID", talkID,
 	)
 
-	path := fmt.Sprintf("/data/session/%s", talkID)
+	path := fmt.Sprintf("/data/session/%s", url.PathEscape(talkID))
 	body, err := c.doRequest(ctx, http.MethodGet, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch talk %s: %w", talkID, err)
@@ -217,24 +262,9 @@ func (c *Client) GetTalk(ctx context.Context, talkID string) (*domain.Talk, erro
 	}
 
 	// We need to get the conference slug and name for mapping
-	conferences, err := c.GetConferences(ctx)
+	conferenceSlug, conferenceName, err := c.conferenceDetails(ctx, session.ConferenceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch conferences to get details: %w", err)
-	}
-
-	var conferenceSlug, conferenceName string
-	for _, conf := range conferences {
-		if conf.ID == session.ConferenceID {
-			conferenceSlug = conf.Slug
-			conferenceName = conf.Name
-			break
-		}
-	}
-
-	if conferenceSlug == "" {
-		c.logger.WarnContext(ctx, "Conference not found, using empty strings",
-			"conferenceID", session.ConferenceID,
-		)
+		return nil, err
 	}
 
 	talk := MapTalk(session, conferenceSlug, conferenceName)
@@ -246,3 +276,45 @@ func (c *Client) GetTalk(ctx context.Context, talkID string) (*domain.Talk, erro
 
 	return &talk, nil
 }
+
+// conferenceDetails returns the slug and name of a conference, using the conference resolver when one
+// is set and the cached conference list otherwise. An unknown conference is logged and yields empty strings.
+func (c *Client) conferenceDetails(ctx context.Context, conferenceID string) (string, string, error) {
+	if c.resolver != nil {
+		conf, err := c.resolver.ConferenceByID(ctx, conferenceID)
+		switch {
+		case err == nil:
+			return conf.Slug, conf.Name, nil
+		case !errors.Is(err, domain.ErrConferenceNotFound):
+			return "", "", fmt.Errorf("failed to fetch conferences to get details: %w", err)
+		}
+	} else {
+		conf, err := c.lookupConference(ctx, conferenceID)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch conferences to get details: %w", err)
+		}
+		if conf != nil {
+			return conf.Slug, conf.Name, nil
+		}
+	}
+
+	c.logger.WarnContext(ctx, "Conference not found, using empty strings",
+		"conferenceID", conferenceID,
+	)
+	return "", "", nil
+}
+
+// CheckHealth reports whether moresleep answers and how long the conference listing takes.
+// The listing is requested once, without retries, so the latency and errors are those of moresleep.
+func (c *Client) CheckHealth(ctx context.Context) domain.DependencyHealth {
+	health := domain.DependencyHealth{Name: "moresleep", Status: domain.HealthStatusOK}
+	start := time.Now()
+
+	_, _, err := c.attempt(ctx, http.MethodGet, c.baseURL+"/data/conference")
+	health.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Status = domain.HealthStatusError
+		health.Error = err.Error()
+	}
+	return health
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -163,6 +164,7 @@ func TestClient_GetConferences(t *testing.T) {
 		defer server.Close()
 
 		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetRetryOptions(RetryOptions{MaxRetries: 1, Backoff: time.Millisecond})
 		conferences, err := client.GetConferences(context.Background())
 
 		require.Error(t, err)
@@ -386,6 +388,7 @@ func TestClient_GetTalks(t *testing.T) {
 		defer server.Close()
 
 		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetRetryOptions(RetryOptions{MaxRetries: 1, Backoff: time.Millisecond})
 		talks, err := client.GetTalks(context.Background(), "conf-1")
 
 		require.Error(t, err)
@@ -419,6 +422,211 @@ func TestClient_GetTalks(t *testing.T) {
 	})
 }
 
+// stubConferenceResolver is a ports.ConferenceResolver knowing a fixed list of conferences
+type stubConferenceResolver struct {
+	conferences []domain.Conference
+	lookups     int
+}
+
+func (r *stubConferenceResolver) ConferenceByID(ctx context.Context, id string) (*domain.Conference, error) {
+	r.lookups++
+	for _, conf := range r.conferences {
+		if conf.ID == id {
+			return &conf, nil
+		}
+	}
+	return nil, domain.ErrConferenceNotFound
+}
+
+func (r *stubConferenceResolver) ConferenceBySlug(ctx context.Context, slug string) (*domain.Conference, error) {
+	return nil, domain.ErrConferenceNotFound
+}
+
+func TestClient_ConferenceResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/session/talk-1":
+			json.NewEncoder(w).Encode(SessionResponse{ID: "talk-1", ConferenceID: "conf-1", Status: "APPROVED"})
+		case "/data/conference/conf-1/session", "/data/conference/conf-2/session":
+			json.NewEncoder(w).Encode(SessionsAPIResponse{Sessions: []SessionResponse{{ID: "talk-2", ConferenceID: "conf-2"}}})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := &stubConferenceResolver{conferences: []domain.Conference{{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"}}}
+	client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+	client.SetConferenceResolver(resolver)
+
+	t.Run("talk resolved without listing conferences", func(t *testing.T) {
+		talk, err := client.GetTalk(context.Background(), "talk-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "javazone2024", talk.ConferenceSlug)
+		assert.Equal(t, "JavaZone 2024", talk.ConferenceName)
+	})
+
+	t.Run("unknown conference uses empty strings", func(t *testing.T) {
+		talks, err := client.GetTalks(context.Background(), "conf-2")
+
+		require.NoError(t, err)
+		require.Len(t, talks, 1)
+		assert.Equal(t, "", talks[0].ConferenceSlug)
+	})
+
+	assert.Equal(t, 2, resolver.lookups)
+}
+
+func TestClient_Retry(t *testing.T) {
+	fastRetries := RetryOptions{MaxRetries: 3, Backoff: time.Millisecond, RequestTimeout: time.Second}
+
+	t.Run("retries server errors until a request succeeds", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"conferences":[{"id":"conf-1","name":"JavaZone","slug":"javazone"}]}`))
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetRetryOptions(fastRetries)
+		conferences, err := client.GetConferences(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, conferences, 1)
+		assert.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("gives up after the last retry", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetRetryOptions(fastRetries)
+		_, err := client.GetConferences(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status code: 503")
+		assert.Equal(t, int32(4), attempts.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetRetryOptions(fastRetries)
+		_, err := client.GetConferences(context.Background())
+
+		require.Error(t, err)
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("retries attempts running past the request timeout", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				<-r.Context().Done()
+				return
+			}
+			w.Write([]byte(`{"conferences":[]}`))
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetRetryOptions(RetryOptions{MaxRetries: 1, Backoff: time.Millisecond, RequestTimeout: 50 * time.Millisecond})
+		_, err := client.GetConferences(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), attempts.Load())
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetRetryOptions(RetryOptions{MaxRetries: 10, Backoff: time.Hour})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := client.GetConferences(ctx)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestRetryOptions_Backoff(t *testing.T) {
+	opts := RetryOptions{Backoff: time.Second}
+
+	for retry, base := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: maxRetryBackoff} {
+		wait := opts.backoff(retry)
+		assert.GreaterOrEqual(t, wait, base/2, "retry %d", retry)
+		assert.LessOrEqual(t, wait, base, "retry %d", retry)
+	}
+}
+
+func TestClient_CheckHealth(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/data/conference", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"conferences":[]}`))
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		health := client.CheckHealth(context.Background())
+
+		assert.Equal(t, "moresleep", health.Name)
+		assert.Equal(t, domain.HealthStatusOK, health.Status)
+		assert.Empty(t, health.Error)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		health := client.CheckHealth(context.Background())
+
+		assert.Equal(t, domain.HealthStatusError, health.Status)
+		assert.NotEmpty(t, health.Error)
+	})
+}
+
+func TestClient_GetTalk_EscapesID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/data/session/..%2Fconference%2Fconf-1%3Fx=1", r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+	_, err := client.GetTalk(context.Background(), "../conference/conf-1?x=1")
+	assert.Error(t, err)
+}
+
 func TestClient_NewWithHTTPClient(t *testing.T) {
 	customClient := &http.Client{
 		Timeout: 5 * time.Second,
@@ -454,3 +662,87 @@ func TestClient_InterfaceCompliance(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, conferences)
 }
+
+func TestClient_ConferenceCache(t *testing.T) {
+	newServer := func(conferenceRequests *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/data/conference":
+				conferenceRequests.Add(1)
+				w.Write([]byte(`{"conferences":[{"id":"conf-1","name":"JavaZone 2024","slug":"javazone2024"}]}`))
+			case "/data/conference/conf-1/session", "/data/conference/conf-new/session":
+				w.Write([]byte(`{"sessions":[{"id":"talk-1","status":"APPROVED"}]}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL.Path)
+			}
+		}))
+	}
+
+	t.Run("reuses the conference list within the TTL", func(t *testing.T) {
+		var conferenceRequests atomic.Int32
+		server := newServer(&conferenceRequests)
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		for range 3 {
+			talks, err := client.GetTalks(context.Background(), "conf-1")
+			require.NoError(t, err)
+			assert.Equal(t, "javazone2024", talks[0].ConferenceSlug)
+		}
+
+		assert.Equal(t, int32(1), conferenceRequests.Load())
+	})
+
+	t.Run("lists the conferences again after the TTL or invalidation", func(t *testing.T) {
+		var conferenceRequests atomic.Int32
+		server := newServer(&conferenceRequests)
+		defer server.Close()
+
+		now := time.Now()
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.cache.now = func() time.Time { return now }
+
+		_, err := client.GetTalks(context.Background(), "conf-1")
+		require.NoError(t, err)
+
+		now = now.Add(DefaultConferenceCacheTTL)
+		_, err = client.GetTalks(context.Background(), "conf-1")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), conferenceRequests.Load())
+
+		client.InvalidateConferences()
+		_, err = client.GetTalks(context.Background(), "conf-1")
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), conferenceRequests.Load())
+	})
+
+	t.Run("lists the conferences again for an unknown conference", func(t *testing.T) {
+		var conferenceRequests atomic.Int32
+		server := newServer(&conferenceRequests)
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		_, err := client.GetConferences(context.Background())
+		require.NoError(t, err)
+
+		talks, err := client.GetTalks(context.Background(), "conf-new")
+		require.NoError(t, err)
+		assert.Empty(t, talks[0].ConferenceSlug)
+		assert.Equal(t, int32(2), conferenceRequests.Load())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var conferenceRequests atomic.Int32
+		server := newServer(&conferenceRequests)
+		defer server.Close()
+
+		client := NewWithHTTPClient(server.URL, "", "", &http.Client{})
+		client.SetConferenceCacheTTL(0)
+		for range 2 {
+			_, err := client.GetTalks(context.Background(), "conf-1")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), conferenceRequests.Load())
+	})
+}
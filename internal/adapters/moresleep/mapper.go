@@ -57,6 +57,11 @@ func MapSpeaker(sr SpeakerResponse) domain.Speaker {
 		}
 	}
 
+	// Add the contact email to private data
+	if sr.Email != "" {
+		speaker.PrivateData["email"] = sr.Email
+	}
+
 	return speaker
 }
 
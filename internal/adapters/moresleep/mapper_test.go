@@ -62,6 +62,8 @@ func TestMapSpeaker(t *testing.T) {
 		assert.Equal(t, "Experienced developer", speaker.Data["bio"])
 		assert.Equal(t, "@janedoe", speaker.Data["twitter"])
 		assert.Equal(t, "https://example.com/jane.jpg", speaker.Data["pictureUrl"])
+		assert.Equal(t, "jane@example.com", speaker.PrivateData["email"])
+		assert.NotContains(t, speaker.ToPublic().Data, "email")
 	})
 
 	t.Run("with missing data fields", func(t *testing.T) {
@@ -0,0 +1,63 @@
+package moresleep
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// maxRetryBackoff caps the wait between retries of a failed request
+const maxRetryBackoff = 30 * time.Second
+
+// RetryOptions controls how requests failing with a 5xx status or a network error are retried
+type RetryOptions struct {
+	// MaxRetries is how many times a failed request is retried before giving up
+	MaxRetries int
+
+	// Backoff is the wait before the first retry, doubled for each further retry. Every wait is
+	// jittered between half and all of it, so instances do not retry in lockstep.
+	Backoff time.Duration
+
+	// RequestTimeout bounds each attempt, so a hanging response is retried rather than waited for.
+	// Zero leaves attempts bounded by the HTTP client and the caller's context only.
+	RequestTimeout time.Duration
+}
+
+// DefaultRetryOptions returns the retry options used when none are configured
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries:     3,
+		Backoff:        500 * time.Millisecond,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+// SetRetryOptions overrides how failed requests are retried
+func (c *Client) SetRetryOptions(opts RetryOptions) {
+	c.retry = opts
+}
+
+// backoff returns the jittered wait before the given retry, starting at 1
+func (o RetryOptions) backoff(retry int) time.Duration {
+	wait := o.Backoff
+	for i := 1; i < retry && wait < maxRetryBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, maxRetryBackoff)
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// sleep waits for the duration, returning early with the context's error if it is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package registration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// userAgent identifies the indexer to the registration system
+const userAgent = "talks-indexer-registration/1.0"
+
+// Client implements the RegistrationSource interface against the registration system's workshop
+// listing, a JSON array of objects with talkId, capacity and registered
+type Client struct {
+	httpClient *http.Client
+	url        string
+	token      string
+	logger     *slog.Logger
+}
+
+// New creates a new registration Client, retrieving configuration from context
+func New(ctx context.Context) *Client {
+	cfg := config.GetConfig(ctx)
+	return NewWithHTTPClient(&http.Client{Timeout: cfg.Registration.Timeout}, cfg.Registration.URL, cfg.Registration.Token)
+}
+
+// NewWithHTTPClient creates a new registration Client with a custom HTTP client.
+// This constructor is primarily intended for testing purposes.
+func NewWithHTTPClient(httpClient *http.Client, url string, token string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		url:        url,
+		token:      token,
+		logger:     slog.Default().With("component", "registration"),
+	}
+}
+
+// ListRegistrations returns the capacity and registration count of every workshop. Entries without
+// a talk ID are skipped, and negative counts are read as zero.
+func (c *Client) ListRegistrations(ctx context.Context) ([]domain.WorkshopRegistration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registration request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workshop registrations: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, fmt.Errorf("registration system returned %s: %s", resp.Status, string(body))
+	}
+
+	var entries []domain.WorkshopRegistration
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse workshop registrations: %w", err)
+	}
+
+	registrations := make([]domain.WorkshopRegistration, 0, len(entries))
+	for _, entry := range entries {
+		if entry.TalkID == "" {
+			continue
+		}
+		entry.Capacity = max(entry.Capacity, 0)
+		entry.Registered = max(entry.Registered, 0)
+		registrations = append(registrations, entry)
+	}
+
+	c.logger.DebugContext(ctx, "listed workshop registrations", "count", len(registrations))
+	return registrations, nil
+}
@@ -0,0 +1,65 @@
+package registration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListRegistrations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/workshops", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, userAgent, r.UserAgent())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"talkId": "talk-1", "capacity": 30, "registered": 28, "title": "Kotlin workshop"},
+			{"talkId": "", "capacity": 20, "registered": 1},
+			{"talkId": "talk-2", "capacity": 20, "registered": -1}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewWithHTTPClient(&http.Client{}, server.URL+"/api/workshops", "secret")
+	registrations, err := client.ListRegistrations(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.WorkshopRegistration{
+		{TalkID: "talk-1", Capacity: 30, Registered: 28},
+		{TalkID: "talk-2", Capacity: 20, Registered: 0},
+	}, registrations)
+}
+
+func TestClient_ListRegistrations_Errors(t *testing.T) {
+	t.Run("registration system error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("invalid token"))
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(&http.Client{}, server.URL, "wrong")
+		_, err := client.ListRegistrations(context.Background())
+
+		assert.EqualError(t, err, "registration system returned 401 Unauthorized: invalid token")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"workshops": []}`))
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(&http.Client{}, server.URL, "")
+		_, err := client.ListRegistrations(context.Background())
+
+		assert.ErrorContains(t, err, "failed to parse workshop registrations")
+	})
+}
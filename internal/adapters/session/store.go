@@ -6,6 +6,10 @@ import (
 	"encoding/base64"
 	"sync"
 	"time"
+
+	"github.com/javaBin/talks-indexer/internal/clock"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
 // Session represents an authenticated user session
@@ -14,11 +18,14 @@ type Session struct {
 	Email     string
 	CreatedAt time.Time
 	ExpiresAt time.Time
+
+	// Role is granted by the user's OIDC groups at login, empty if none of them grant a role
+	Role domain.Role
 }
 
 // Store defines the interface for session storage
 type Store interface {
-	Create(ctx context.Context, email string, ttl time.Duration) (*Session, error)
+	Create(ctx context.Context, email string, role domain.Role, ttl time.Duration) (*Session, error)
 	Get(ctx context.Context, sessionID string) (*Session, error)
 	Delete(ctx context.Context, sessionID string) error
 }
@@ -27,26 +34,34 @@ type Store interface {
 type InMemoryStore struct {
 	sessions map[string]*Session
 	mu       sync.RWMutex
+	clock    ports.Clock
 }
 
 // NewInMemoryStore creates a new in-memory session store
 func NewInMemoryStore() *InMemoryStore {
 	return &InMemoryStore{
 		sessions: make(map[string]*Session),
+		clock:    clock.System{},
 	}
 }
 
-// Create creates a new session for the given email
-func (s *InMemoryStore) Create(ctx context.Context, email string, ttl time.Duration) (*Session, error) {
+// SetClock replaces the system clock deciding when sessions expire
+func (s *InMemoryStore) SetClock(clock ports.Clock) {
+	s.clock = clock
+}
+
+// Create creates a new session for the given email and group role
+func (s *InMemoryStore) Create(ctx context.Context, email string, role domain.Role, ttl time.Duration) (*Session, error) {
 	id, err := generateSessionID()
 	if err != nil {
 		return nil, err
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 	session := &Session{
 		ID:        id,
 		Email:     email,
+		Role:      role,
 		CreatedAt: now,
 		ExpiresAt: now.Add(ttl),
 	}
@@ -68,7 +83,7 @@ func (s *InMemoryStore) Get(ctx context.Context, sessionID string) (*Session, er
 		return nil, nil
 	}
 
-	if time.Now().After(session.ExpiresAt) {
+	if s.clock.Now().After(session.ExpiresAt) {
 		s.Delete(ctx, sessionID)
 		return nil, nil
 	}
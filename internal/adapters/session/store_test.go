@@ -0,0 +1,34 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/clock"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC))
+	store := NewInMemoryStore()
+	store.SetClock(fake)
+
+	created, err := store.Create(ctx, "user@example.com", domain.RoleAdmin, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, fake.Now().Add(time.Hour), created.ExpiresAt)
+
+	fake.Advance(time.Hour)
+	session, err := store.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created, session, "valid until the expiry time has passed")
+
+	fake.Advance(time.Second)
+	session, err = store.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+	assert.Empty(t, store.sessions, "expired sessions are removed")
+}
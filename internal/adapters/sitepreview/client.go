@@ -0,0 +1,71 @@
+package sitepreview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// userAgent identifies the indexer to the website's preview renderer
+const userAgent = "talks-indexer-site-preview/1.0"
+
+// maxPageBytes bounds the rendered page read from the preview renderer
+const maxPageBytes = 2 << 20
+
+// Client implements the SiteRenderer interface by posting public documents to the website's
+// preview renderer, which renders them with the same templates as the program pages
+type Client struct {
+	httpClient *http.Client
+	url        string
+}
+
+// New creates a new site preview Client, retrieving configuration from context
+func New(ctx context.Context) *Client {
+	cfg := config.GetConfig(ctx)
+	return NewWithHTTPClient(&http.Client{Timeout: cfg.SitePreview.Timeout}, cfg.SitePreview.URL)
+}
+
+// NewWithHTTPClient creates a new site preview Client with a custom HTTP client.
+// This constructor is primarily intended for testing purposes.
+func NewWithHTTPClient(httpClient *http.Client, url string) *Client {
+	return &Client{httpClient: httpClient, url: url}
+}
+
+// RenderTalk posts the public document as JSON, exactly as the public index stores it, and returns
+// the HTML page the renderer responds with. Non-2xx responses are errors carrying the start of the body,
+// since renderers typically explain there which field they could not render.
+func (c *Client) RenderTalk(ctx context.Context, talk domain.Talk) (string, error) {
+	body, err := json.Marshal(talk)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode talk %s: %w", talk.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create preview request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to render talk %s: %w", talk.ID, err)
+	}
+	defer resp.Body.Close()
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read rendered talk %s: %w", talk.ID, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("preview renderer returned %s: %s", resp.Status, bytes.TrimSpace(page[:min(len(page), 200)]))
+	}
+	return string(page), nil
+}
@@ -0,0 +1,62 @@
+package sitepreview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RenderTalk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/preview", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, userAgent, r.UserAgent())
+
+		var talk domain.Talk
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&talk))
+		assert.Equal(t, "talk-1", talk.ID)
+		assert.Equal(t, "Records", talk.Data["title"])
+
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<h1>Records</h1>"))
+	}))
+	defer server.Close()
+
+	client := NewWithHTTPClient(&http.Client{}, server.URL+"/preview")
+	page, err := client.RenderTalk(context.Background(), domain.Talk{ID: "talk-1", Data: map[string]interface{}{"title": "Records"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "<h1>Records</h1>", page)
+}
+
+func TestClient_RenderTalk_Errors(t *testing.T) {
+	t.Run("renderer error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte("missing field: length\n"))
+		}))
+		defer server.Close()
+
+		client := NewWithHTTPClient(&http.Client{}, server.URL)
+		_, err := client.RenderTalk(context.Background(), domain.Talk{ID: "talk-1"})
+
+		assert.EqualError(t, err, "preview renderer returned 422 Unprocessable Entity: missing field: length")
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		client := NewWithHTTPClient(&http.Client{}, server.URL)
+		_, err := client.RenderTalk(context.Background(), domain.Talk{ID: "talk-1"})
+
+		assert.ErrorContains(t, err, "failed to render talk talk-1")
+	})
+}
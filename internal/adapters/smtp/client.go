@@ -0,0 +1,112 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+)
+
+// Client implements the MailSender interface over SMTP
+type Client struct {
+	cfg config.SMTPConfig
+	now func() time.Time
+}
+
+// New creates a new SMTP Client, retrieving configuration from context
+func New(ctx context.Context) *Client {
+	cfg := config.GetConfig(ctx)
+	return NewWithConfig(cfg.SMTP)
+}
+
+// NewWithConfig creates a new SMTP Client with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewWithConfig(cfg config.SMTPConfig) *Client {
+	return &Client{cfg: cfg, now: time.Now}
+}
+
+// SendMail sends a plain text email to the recipients in one transaction. The connection is upgraded
+// with STARTTLS when the server offers it, and authentication is only attempted if a username is set.
+func (c *Client) SendMail(ctx context.Context, to []string, subject, body string) error {
+	if c.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.Timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to the mail server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to the mail server: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.cfg.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if c.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, c.cfg.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate with the mail server: %w", err)
+		}
+	}
+
+	if err := client.Mail(c.cfg.From); err != nil {
+		return fmt.Errorf("mail server rejected the sender: %w", err)
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("mail server rejected recipient %s: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(c.message(to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return client.Quit()
+}
+
+// message formats the email with its headers, with the subject and body encoded for non-ASCII text
+// such as Norwegian conference names
+func (c *Client) message(to []string, subject, body string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", c.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", c.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	b.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+	w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	w.Close()
+	return b.Bytes()
+}
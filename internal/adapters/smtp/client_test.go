@@ -0,0 +1,132 @@
+package smtp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer accepts one SMTP transaction and records the commands and the message
+type fakeServer struct {
+	listener net.Listener
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeServer(t *testing.T, rejectRecipient string) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeServer{listener: listener, done: make(chan struct{})}
+	go server.serve(rejectRecipient)
+	return server
+}
+
+func (s *fakeServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeServer) serve(rejectRecipient string) {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		s.commands = append(s.commands, command)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "RCPT") && rejectRecipient != "" && strings.Contains(command, rejectRecipient):
+			reply("550 no such user")
+		case command == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.data = data.String()
+			reply("250 queued")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestClient_SendMail(t *testing.T) {
+	server := newFakeServer(t, "")
+	client := NewWithConfig(config.SMTPConfig{Host: "127.0.0.1", Port: server.port(), From: "indexer@java.no", Timeout: 5 * time.Second})
+	client.now = func() time.Time { return time.Date(2025, 9, 8, 7, 0, 0, 0, time.UTC) }
+
+	err := client.SendMail(context.Background(), []string{"program@java.no", "leads@java.no"}, "Ukentlig oppsummering", "Talks indexed: 380\nGodkjent: 12")
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Contains(t, server.commands, "MAIL FROM:<indexer@java.no>")
+	assert.Contains(t, server.commands, "RCPT TO:<program@java.no>")
+	assert.Contains(t, server.commands, "RCPT TO:<leads@java.no>")
+
+	headers, body, ok := strings.Cut(server.data, "\r\n\r\n")
+	require.True(t, ok)
+	assert.Contains(t, headers, "To: program@java.no, leads@java.no")
+	assert.Contains(t, headers, "Subject: Ukentlig oppsummering")
+	assert.Contains(t, headers, "Date: Mon, 08 Sep 2025 07:00:00 +0000")
+
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+	assert.Equal(t, "Talks indexed: 380\r\nGodkjent: 12\r\n", string(decoded))
+}
+
+func TestClient_SendMail_RejectedRecipient(t *testing.T) {
+	server := newFakeServer(t, "gone@java.no")
+	client := NewWithConfig(config.SMTPConfig{Host: "127.0.0.1", Port: server.port(), From: "indexer@java.no", Timeout: 5 * time.Second})
+
+	err := client.SendMail(context.Background(), []string{"gone@java.no"}, "Summary", "body")
+	assert.ErrorContains(t, err, "rejected recipient gone@java.no")
+}
+
+func TestClient_SendMail_Unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	client := NewWithConfig(config.SMTPConfig{Host: "127.0.0.1", Port: port, From: "indexer@java.no", Timeout: time.Second})
+	err = client.SendMail(context.Background(), []string{"program@java.no"}, "Summary", "body")
+	assert.ErrorContains(t, err, "failed to connect to the mail server")
+}
+
+func TestClient_Message_EncodesSubject(t *testing.T) {
+	client := NewWithConfig(config.SMTPConfig{From: "indexer@java.no"})
+	message := string(client.message([]string{"program@java.no"}, "Oppsummering for JavaZone – uke 37", "body"))
+	assert.Contains(t, message, "Subject: =?utf-8?q?")
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// FetchTalks retrieves all talks stored in the specified index, ordered by ID.
+// If conferenceSlug is non-empty, only talks for that conference are returned.
+func (s *Store) FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+	query := `SELECT id, doc FROM talks WHERE index_name = ?`
+	args := []interface{}{indexName}
+	if conferenceSlug != "" {
+		query += ` AND conference_slug = ?`
+		args = append(args, conferenceSlug)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from index %s: %w", indexName, err)
+	}
+	defer rows.Close()
+
+	var talks []domain.Talk
+	for rows.Next() {
+		var id, doc string
+		if err := rows.Scan(&id, &doc); err != nil {
+			return nil, fmt.Errorf("failed to read talk from index %s: %w", indexName, err)
+		}
+		var talk domain.Talk
+		if err := json.Unmarshal([]byte(doc), &talk); err != nil {
+			return nil, fmt.Errorf("failed to parse document %s: %w", id, err)
+		}
+		talks = append(talks, talk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from index %s: %w", indexName, err)
+	}
+
+	s.logger.DebugContext(ctx, "fetched talks from index", "index", indexName, "conferenceSlug", conferenceSlug, "count", len(talks))
+	return talks, nil
+}
+
+// ListTalkIDs returns the IDs of the talks of the conference stored in the specified index, ordered by ID.
+// The conference ID is read from the stored documents.
+func (s *Store) ListTalkIDs(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id FROM talks WHERE index_name = ? AND json_extract(doc, '$.conferenceId') = ? ORDER BY id`,
+		indexName, conferenceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list talks in index %s: %w", indexName, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to read talk from index %s: %w", indexName, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list talks in index %s: %w", indexName, err)
+	}
+	return ids, nil
+}
+
+// ListConferences returns the conferences present in the specified index with their talk counts,
+// ordered by slug. The conference ID, name and metadata are read from one of the conference's talks.
+func (s *Store) ListConferences(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT conference_slug, COUNT(*), MIN(doc) FROM talks WHERE index_name = ? GROUP BY conference_slug ORDER BY conference_slug`,
+		indexName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conferences in index %s: %w", indexName, err)
+	}
+	defer rows.Close()
+
+	conferences := []domain.ConferenceSummary{}
+	for rows.Next() {
+		var summary domain.ConferenceSummary
+		var doc string
+		if err := rows.Scan(&summary.Slug, &summary.TalkCount, &doc); err != nil {
+			return nil, fmt.Errorf("failed to read conference from index %s: %w", indexName, err)
+		}
+
+		var source struct {
+			ConferenceID   string                     `json:"conferenceId"`
+			ConferenceName string                     `json:"conferenceName"`
+			Conference     *domain.ConferenceMetadata `json:"conference"`
+		}
+		if err := json.Unmarshal([]byte(doc), &source); err == nil {
+			summary.ID = source.ConferenceID
+			summary.Name = source.ConferenceName
+			if source.Conference != nil {
+				summary.ConferenceMetadata = *source.Conference
+			}
+		}
+		conferences = append(conferences, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list conferences in index %s: %w", indexName, err)
+	}
+
+	return conferences, nil
+}
+
+// IndexVersion returns the generation (random ID, which changes whenever the index is recreated)
+// and document version (number of documents written) of the specified index.
+func (s *Store) IndexVersion(ctx context.Context, indexName string) (domain.IndexVersion, error) {
+	version := domain.IndexVersion{Index: indexName}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT generation, version, (SELECT COUNT(*) FROM talks WHERE index_name = name) FROM indexes WHERE name = ?`,
+		indexName,
+	).Scan(&version.Generation, &version.Version, &version.DocCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.IndexVersion{}, fmt.Errorf("index stats returned no index for %s", indexName)
+	}
+	if err != nil {
+		return domain.IndexVersion{}, fmt.Errorf("failed to get stats for index %s: %w", indexName, err)
+	}
+	return version, nil
+}
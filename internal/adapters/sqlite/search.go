@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/searchquery"
+)
+
+// termConditions maps the talk fields term filters may use (searchquery.TermFields) to the condition
+// matching the value, on their column or on the document for fields without a column. Like Elasticsearch,
+// list fields match if any of their values does.
+var termConditions = map[string]string{
+	"id":               "t.id = ?",
+	"conferenceSlug":   "t.conference_slug = ?",
+	"status":           "t.status = ?",
+	"seriesId":         "json_extract(t.doc, '$.seriesId') = ?",
+	"review.reviewers": "EXISTS (SELECT 1 FROM json_each(t.doc, '$.review.reviewers') WHERE value = ?)",
+	"review.pending":   "EXISTS (SELECT 1 FROM json_each(t.doc, '$.review.pending') WHERE value = ?)",
+}
+
+// sortExpressions maps the fields hits may be sorted on (searchquery.SortFields) to their value in the document
+var sortExpressions = map[string]string{
+	"review.score": "json_extract(t.doc, '$.review.score')",
+}
+
+// rankExpression orders full-text hits by relevance, weighting the title and keywords higher like the
+// search service does. The weights follow the columns of talks_fts, starting with the unindexed ones.
+const rankExpression = `bm25(talks_fts, 0, 0, 3.0, 2.0, 1.0, 1.0)`
+
+// RunQuery runs a search request body against the given index. Only the queries built by the search
+// service are supported, as described by searchquery.Parse; other queries return an error wrapping
+// domain.ErrInvalidQuery.
+func (s *Store) RunQuery(ctx context.Context, indexName string, body map[string]interface{}) (domain.QueryResult, error) {
+	start := time.Now()
+
+	search, err := searchquery.Parse(body)
+	if err != nil {
+		return domain.QueryResult{}, err
+	}
+
+	where := []string{"t.index_name = ?"}
+	args := []interface{}{indexName}
+	for field, value := range search.Filters {
+		where = append(where, termConditions[field])
+		args = append(args, value)
+	}
+
+	from := "talks t"
+	order := "t.id"
+	if match := matchExpression(search.Words()); match != "" {
+		from = "talks_fts JOIN talks t ON t.index_name = talks_fts.index_name AND t.id = talks_fts.id"
+		where = append(where, "talks_fts MATCH ?")
+		args = append(args, match)
+		order = rankExpression
+	}
+	if expression, ok := sortExpressions[search.SortBy]; ok {
+		order = expression + " IS NULL, " + expression + " DESC, " + order
+	}
+	condition := strings.Join(where, " AND ")
+
+	result := domain.QueryResult{Hits: []json.RawMessage{}}
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+from+" WHERE "+condition, args...).Scan(&result.Total); err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to query index %s: %w", indexName, err)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT t.doc FROM "+from+" WHERE "+condition+" ORDER BY "+order+" LIMIT ? OFFSET ?",
+		append(args, search.Size, search.From)...,
+	)
+	if err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to query index %s: %w", indexName, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var doc string
+		if err := rows.Scan(&doc); err != nil {
+			return domain.QueryResult{}, fmt.Errorf("failed to read hit from index %s: %w", indexName, err)
+		}
+		result.Hits = append(result.Hits, json.RawMessage(doc))
+	}
+	if err := rows.Err(); err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to query index %s: %w", indexName, err)
+	}
+
+	result.TookMillis = int(time.Since(start).Milliseconds())
+	return result, nil
+}
+
+// matchExpression returns the FTS5 expression matching documents containing any of the words, like an
+// Elasticsearch match query. Words are quoted so FTS5 operators in the text are not applied.
+func matchExpression(words []string) string {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"`)
+	}
+	return strings.Join(terms, " OR ")
+}
@@ -0,0 +1,360 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+
+	// Registers the pure Go "sqlite" driver, which includes FTS5
+	_ "modernc.org/sqlite"
+)
+
+// schema creates the tables of the store. Every index is a set of rows in the same tables; talks_fts
+// holds the searchable text of each talk and is kept in step with talks by the store.
+const schema = `
+CREATE TABLE IF NOT EXISTS indexes (
+	name       TEXT PRIMARY KEY,
+	mapping    TEXT NOT NULL,
+	generation TEXT NOT NULL,
+	version    INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS talks (
+	index_name      TEXT NOT NULL,
+	id              TEXT NOT NULL,
+	conference_slug TEXT NOT NULL,
+	status          TEXT NOT NULL,
+	version         INTEGER,
+	doc             TEXT NOT NULL,
+	PRIMARY KEY (index_name, id)
+);
+CREATE VIRTUAL TABLE IF NOT EXISTS talks_fts USING fts5(
+	index_name UNINDEXED,
+	id UNINDEXED,
+	title,
+	keywords,
+	abstract,
+	speakers
+);
+`
+
+// Store implements the SearchIndex, TalkReader and IndexVersioner interfaces on a local SQLite database
+// with FTS5, for deployments and CI runs without an Elasticsearch cluster. It runs the talk searches
+// built by the search service, but none of the other Elasticsearch queries.
+type Store struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// New opens the SQLite store, retrieving configuration from context.
+func New(ctx context.Context) (*Store, error) {
+	cfg := config.GetConfig(ctx)
+
+	store, err := Open(cfg.Search.SQLitePath)
+	if err != nil {
+		return nil, err
+	}
+
+	store.logger.Info("opened sqlite store", "path", cfg.Search.SQLitePath)
+	return store, nil
+}
+
+// Open opens the SQLite database at path, creating it and its tables if needed.
+// This constructor is primarily intended for testing purposes.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+
+	// A single connection serializes writes, which SQLite requires anyway, and keeps an in-memory
+	// database alive between statements
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create sqlite schema: %w", err)
+	}
+
+	return &Store{
+		db:     db,
+		logger: slog.Default().With("component", "sqlite"),
+	}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// BulkIndex writes the talks to the index in a single transaction, creating the index if it does not
+// exist. Like the Elasticsearch client, documents are versioned by their last update time, and a talk
+// already stored with a newer version is reported as a conflict instead of being overwritten.
+func (s *Store) BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) (domain.BulkResult, error) {
+	if len(talks) == 0 {
+		s.logger.InfoContext(ctx, "no talks to index", "index", indexName)
+		return domain.BulkResult{}, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.BulkResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureIndex(ctx, tx, indexName); err != nil {
+		return domain.BulkResult{}, err
+	}
+
+	var result domain.BulkResult
+	for _, talk := range talks {
+		written, err := writeTalk(ctx, tx, indexName, talk)
+		if err != nil {
+			return domain.BulkResult{}, err
+		}
+		if !written {
+			result.Conflicts = append(result.Conflicts, talk.ID)
+			continue
+		}
+		result.Indexed++
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE indexes SET version = version + ? WHERE name = ?`, result.Indexed, indexName); err != nil {
+		return domain.BulkResult{}, fmt.Errorf("failed to update version of index %s: %w", indexName, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.BulkResult{}, fmt.Errorf("failed to commit talks to index %s: %w", indexName, err)
+	}
+
+	if len(result.Conflicts) > 0 {
+		s.logger.WarnContext(ctx, "skipped stale documents due to version conflicts", "index", indexName, "conflicts", len(result.Conflicts))
+	}
+	s.logger.InfoContext(ctx, "bulk indexed talks", "index", indexName, "count", result.Indexed)
+	return result, nil
+}
+
+// writeTalk stores the talk and its searchable text, unless the index holds a newer version of it
+func writeTalk(ctx context.Context, tx *sql.Tx, indexName string, talk domain.Talk) (bool, error) {
+	version, versioned := documentVersion(talk)
+
+	var stored sql.NullInt64
+	err := tx.QueryRowContext(ctx, `SELECT version FROM talks WHERE index_name = ? AND id = ?`, indexName, talk.ID).Scan(&stored)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return false, fmt.Errorf("failed to read talk %s: %w", talk.ID, err)
+	case versioned && stored.Valid && stored.Int64 > version:
+		return false, nil
+	}
+
+	doc, err := json.Marshal(talk)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal talk %s: %w", talk.ID, err)
+	}
+
+	var storedVersion sql.NullInt64
+	if versioned {
+		storedVersion = sql.NullInt64{Int64: version, Valid: true}
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO talks (index_name, id, conference_slug, status, version, doc) VALUES (?, ?, ?, ?, ?, ?)`,
+		indexName, talk.ID, talk.ConferenceSlug, talk.Status, storedVersion, string(doc),
+	); err != nil {
+		return false, fmt.Errorf("failed to write talk %s: %w", talk.ID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM talks_fts WHERE index_name = ? AND id = ?`, indexName, talk.ID); err != nil {
+		return false, fmt.Errorf("failed to clear search text of talk %s: %w", talk.ID, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO talks_fts (index_name, id, title, keywords, abstract, speakers) VALUES (?, ?, ?, ?, ?, ?)`,
+		indexName, talk.ID, dataText(talk.Data["title"]), dataText(talk.Data["keywords"]), dataText(talk.Data["abstract"]), speakerNames(talk),
+	); err != nil {
+		return false, fmt.Errorf("failed to write search text of talk %s: %w", talk.ID, err)
+	}
+	return true, nil
+}
+
+// documentVersion returns the version of a talk, derived from its last update time
+// (falling back to its creation time) in milliseconds
+func documentVersion(talk domain.Talk) (int64, bool) {
+	switch {
+	case talk.LastUpdated != nil:
+		return talk.LastUpdated.UnixMilli(), true
+	case talk.Created != nil:
+		return talk.Created.UnixMilli(), true
+	}
+	return 0, false
+}
+
+// dataText returns the searchable text of a talk data field: strings as they are, lists of strings
+// joined by spaces
+func dataText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, " ")
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if text, ok := item.(string); ok {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
+
+// speakerNames returns the names of the talk's speakers joined by spaces
+func speakerNames(talk domain.Talk) string {
+	names := make([]string, 0, len(talk.Speakers))
+	for _, speaker := range talk.Speakers {
+		names = append(names, speaker.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+// ensureIndex creates the index row if the index does not exist
+func ensureIndex(ctx context.Context, tx *sql.Tx, indexName string) error {
+	generation, err := newGeneration()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR IGNORE INTO indexes (name, mapping, generation) VALUES (?, '', ?)`, indexName, generation,
+	); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", indexName, err)
+	}
+	return nil
+}
+
+// newGeneration returns a random generation ID, which changes whenever an index is recreated
+func newGeneration() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate index generation: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// DeleteIndex removes the index and its talks. Deleting an index that does not exist is not an error.
+func (s *Store) DeleteIndex(ctx context.Context, indexName string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range []string{
+		`DELETE FROM talks_fts WHERE index_name = ?`,
+		`DELETE FROM talks WHERE index_name = ?`,
+		`DELETE FROM indexes WHERE name = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, statement, indexName); err != nil {
+			return fmt.Errorf("failed to delete index %s: %w", indexName, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to delete index %s: %w", indexName, err)
+	}
+
+	s.logger.InfoContext(ctx, "deleted index", "index", indexName)
+	return nil
+}
+
+// DeleteTalk removes the talk and its searchable text from the index and returns the deleted talk, or nil
+// if the index does not hold it. The index version is increased, like for written talks.
+func (s *Store) DeleteTalk(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var doc string
+	err = tx.QueryRowContext(ctx, `SELECT doc FROM talks WHERE index_name = ? AND id = ?`, indexName, talkID).Scan(&doc)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read talk %s: %w", talkID, err)
+	}
+	var talk domain.Talk
+	if err := json.Unmarshal([]byte(doc), &talk); err != nil {
+		return nil, fmt.Errorf("failed to parse talk %s: %w", talkID, err)
+	}
+
+	for _, statement := range []string{
+		`DELETE FROM talks_fts WHERE index_name = ? AND id = ?`,
+		`DELETE FROM talks WHERE index_name = ? AND id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, statement, indexName, talkID); err != nil {
+			return nil, fmt.Errorf("failed to delete talk %s: %w", talkID, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE indexes SET version = version + 1 WHERE name = ?`, indexName); err != nil {
+		return nil, fmt.Errorf("failed to update version of index %s: %w", indexName, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to delete talk %s: %w", talkID, err)
+	}
+
+	s.logger.InfoContext(ctx, "deleted talk", "index", indexName, "talkID", talkID)
+	return &talk, nil
+}
+
+// CreateIndex creates a new, empty index. The mapping is stored but not applied, since the tables are
+// the same for every index.
+func (s *Store) CreateIndex(ctx context.Context, indexName string, mapping string) error {
+	generation, err := newGeneration()
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO indexes (name, mapping, generation) VALUES (?, ?, ?)`, indexName, mapping, generation,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create index %s: %w", indexName, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("create index error: index %s already exists", indexName)
+	}
+
+	s.logger.InfoContext(ctx, "created index", "index", indexName)
+	return nil
+}
+
+// IndexExists checks if the index exists
+func (s *Store) IndexExists(ctx context.Context, indexName string) (bool, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM indexes WHERE name = ?`, indexName).Scan(&n); err != nil {
+		return false, fmt.Errorf("failed to check if index exists %s: %w", indexName, err)
+	}
+	return n > 0, nil
+}
+
+// CheckHealth reports whether the database answers queries
+func (s *Store) CheckHealth(ctx context.Context) domain.DependencyHealth {
+	health := domain.DependencyHealth{Name: "sqlite", Status: domain.HealthStatusOK}
+	start := time.Now()
+
+	err := s.db.PingContext(ctx)
+	health.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Status = domain.HealthStatusError
+		health.Error = err.Error()
+	}
+	return health
+}
@@ -0,0 +1,266 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore opens an in-memory store closed at the end of the test
+func newTestStore(t *testing.T) *Store {
+	store, err := Open(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// testTalk returns a talk of the conference last updated at the given time
+func testTalk(id, conferenceSlug, title string, updated time.Time) domain.Talk {
+	return domain.Talk{
+		ID:             id,
+		ConferenceID:   conferenceSlug + "-id",
+		ConferenceSlug: conferenceSlug,
+		ConferenceName: "JavaZone",
+		Status:         "APPROVED",
+		LastUpdated:    &updated,
+		Speakers:       domain.Speakers{{ID: "speaker-" + id, Name: "Ada Lovelace"}},
+		Data:           map[string]interface{}{"title": title},
+	}
+}
+
+func TestNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talks.db")
+	ctx := config.WithConfig(context.Background(), &config.Config{Search: config.SearchConfig{SQLitePath: path}})
+
+	store, err := New(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateIndex(ctx, "javazone_public", "{}"))
+	require.NoError(t, store.Close())
+
+	// The indexes are kept in the file across restarts
+	store, err = New(ctx)
+	require.NoError(t, err)
+	defer store.Close()
+	exists, err := store.IndexExists(ctx, "javazone_public")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestIndexLifecycle(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	exists, err := store.IndexExists(ctx, "javazone_public")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, store.CreateIndex(ctx, "javazone_public", "{}"))
+	assert.Error(t, store.CreateIndex(ctx, "javazone_public", "{}"), "an existing index is not recreated")
+
+	exists, err = store.IndexExists(ctx, "javazone_public")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = store.BulkIndex(ctx, "javazone_public", []domain.Talk{testTalk("talk-1", "javazone2024", "Kotlin", time.Now())})
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteIndex(ctx, "javazone_public"))
+	require.NoError(t, store.DeleteIndex(ctx, "javazone_public"), "deleting a missing index is not an error")
+
+	exists, err = store.IndexExists(ctx, "javazone_public")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	talks, err := store.FetchTalks(ctx, "javazone_public", "")
+	require.NoError(t, err)
+	assert.Empty(t, talks)
+}
+
+func TestBulkIndex(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+
+	result, err := store.BulkIndex(ctx, "javazone_private", []domain.Talk{
+		testTalk("talk-1", "javazone2024", "Kotlin in production", now),
+		testTalk("talk-2", "javazone2024", "Rust for Java developers", now),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Indexed)
+
+	exists, err := store.IndexExists(ctx, "javazone_private")
+	require.NoError(t, err)
+	assert.True(t, exists, "indexing creates a missing index")
+
+	t.Run("stale documents are conflicts", func(t *testing.T) {
+		result, err := store.BulkIndex(ctx, "javazone_private", []domain.Talk{
+			testTalk("talk-1", "javazone2024", "Old title", now.Add(-time.Hour)),
+			testTalk("talk-2", "javazone2024", "New title", now.Add(time.Hour)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Indexed)
+		assert.Equal(t, []string{"talk-1"}, result.Conflicts)
+
+		talks, err := store.FetchTalks(ctx, "javazone_private", "")
+		require.NoError(t, err)
+		require.Len(t, talks, 2)
+		assert.Equal(t, "Kotlin in production", talks[0].Data["title"])
+		assert.Equal(t, "New title", talks[1].Data["title"])
+	})
+
+	t.Run("rewritten talks are searched by their new text", func(t *testing.T) {
+		result, err := store.RunQuery(ctx, "javazone_private", map[string]interface{}{"query": map[string]interface{}{
+			"match": map[string]interface{}{"data.title": "rust"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Total)
+	})
+}
+
+func TestFetchTalks(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	_, err := store.BulkIndex(ctx, "javazone_public", []domain.Talk{
+		testTalk("talk-2", "javazone2024", "Kotlin", time.Now()),
+		testTalk("talk-1", "javazone2023", "Rust", time.Now()),
+	})
+	require.NoError(t, err)
+
+	talks, err := store.FetchTalks(ctx, "javazone_public", "")
+	require.NoError(t, err)
+	require.Len(t, talks, 2)
+	assert.Equal(t, "talk-1", talks[0].ID)
+	assert.Equal(t, "Ada Lovelace", talks[0].Speakers[0].Name)
+
+	talks, err = store.FetchTalks(ctx, "javazone_public", "javazone2024")
+	require.NoError(t, err)
+	require.Len(t, talks, 1)
+	assert.Equal(t, "talk-2", talks[0].ID)
+}
+
+func TestListTalkIDs(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	_, err := store.BulkIndex(ctx, "javazone_public", []domain.Talk{
+		testTalk("talk-2", "javazone2024", "Kotlin", time.Now()),
+		testTalk("talk-1", "javazone2024", "Go", time.Now()),
+		testTalk("talk-3", "javazone2023", "Rust", time.Now()),
+	})
+	require.NoError(t, err)
+
+	ids, err := store.ListTalkIDs(ctx, "javazone_public", "javazone2024-id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"talk-1", "talk-2"}, ids)
+
+	ids, err = store.ListTalkIDs(ctx, "javazone_missing", "javazone2024-id")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestDeleteTalk(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	_, err := store.BulkIndex(ctx, "javazone_public", []domain.Talk{
+		testTalk("talk-1", "javazone2024", "Kotlin", now),
+		testTalk("talk-2", "javazone2024", "Rust", now),
+	})
+	require.NoError(t, err)
+	before, err := store.IndexVersion(ctx, "javazone_public")
+	require.NoError(t, err)
+
+	deleted, err := store.DeleteTalk(ctx, "javazone_public", "talk-1")
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+	assert.Equal(t, "javazone2024", deleted.ConferenceSlug)
+
+	talks, err := store.FetchTalks(ctx, "javazone_public", "")
+	require.NoError(t, err)
+	require.Len(t, talks, 1)
+	assert.Equal(t, "talk-2", talks[0].ID)
+
+	after, err := store.IndexVersion(ctx, "javazone_public")
+	require.NoError(t, err)
+	assert.Greater(t, after.Version, before.Version, "deleting a talk changes the index version")
+
+	result, err := store.RunQuery(ctx, "javazone_public", map[string]interface{}{"query": map[string]interface{}{
+		"match": map[string]interface{}{"data.title": "kotlin"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Total, "deleted talks are not searched")
+
+	t.Run("a deleted talk can be indexed again with an older version", func(t *testing.T) {
+		result, err := store.BulkIndex(ctx, "javazone_public", []domain.Talk{testTalk("talk-1", "javazone2024", "Kotlin", now.Add(-time.Hour))})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Indexed)
+	})
+
+	t.Run("missing talks and indexes", func(t *testing.T) {
+		deleted, err := store.DeleteTalk(ctx, "javazone_public", "missing")
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+
+		deleted, err = store.DeleteTalk(ctx, "javazone_missing", "talk-1")
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+	})
+}
+
+func TestListConferences(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	metadata := testTalk("talk-3", "javazone2024", "Go", time.Now())
+	metadata.Conference = &domain.ConferenceMetadata{Venue: "Nova Spektrum"}
+	_, err := store.BulkIndex(ctx, "javazone_public", []domain.Talk{
+		testTalk("talk-1", "javazone2024", "Kotlin", time.Now()),
+		testTalk("talk-2", "javazone2023", "Rust", time.Now()),
+		metadata,
+	})
+	require.NoError(t, err)
+
+	conferences, err := store.ListConferences(ctx, "javazone_public")
+	require.NoError(t, err)
+	require.Len(t, conferences, 2)
+	assert.Equal(t, domain.ConferenceSummary{ID: "javazone2023-id", Name: "JavaZone", Slug: "javazone2023", TalkCount: 1}, conferences[0])
+	assert.Equal(t, "javazone2024", conferences[1].Slug)
+	assert.Equal(t, 2, conferences[1].TalkCount)
+}
+
+func TestIndexVersion(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	_, err := store.IndexVersion(ctx, "javazone_public")
+	assert.Error(t, err)
+
+	require.NoError(t, store.CreateIndex(ctx, "javazone_public", "{}"))
+	_, err = store.BulkIndex(ctx, "javazone_public", []domain.Talk{testTalk("talk-1", "javazone2024", "Kotlin", time.Now())})
+	require.NoError(t, err)
+
+	first, err := store.IndexVersion(ctx, "javazone_public")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Version)
+	assert.Equal(t, int64(1), first.DocCount)
+	assert.NotEmpty(t, first.Generation)
+
+	require.NoError(t, store.DeleteIndex(ctx, "javazone_public"))
+	require.NoError(t, store.CreateIndex(ctx, "javazone_public", "{}"))
+	second, err := store.IndexVersion(ctx, "javazone_public")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Generation, second.Generation, "a recreated index has a new generation")
+	assert.Equal(t, int64(0), second.DocCount)
+}
+
+func TestCheckHealth(t *testing.T) {
+	store := newTestStore(t)
+
+	health := store.CheckHealth(context.Background())
+
+	assert.Equal(t, "sqlite", health.Name)
+	assert.Equal(t, domain.HealthStatusOK, health.Status)
+}
@@ -0,0 +1,231 @@
+package video
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+const (
+	// defaultVimeoAPIURL is the base URL of the Vimeo API
+	defaultVimeoAPIURL = "https://api.vimeo.com"
+
+	// defaultYouTubeAPIURL is the base URL of the YouTube Data API v3
+	defaultYouTubeAPIURL = "https://www.googleapis.com/youtube/v3"
+
+	// vimeoPageSize is the number of videos requested per Vimeo page (the API maximum)
+	vimeoPageSize = 100
+
+	// youTubePageSize is the number of playlist items requested per YouTube page (the API maximum)
+	youTubePageSize = 50
+)
+
+// Client implements the VideoSource interface for the Vimeo and YouTube APIs
+type Client struct {
+	provider   string
+	apiURL     string
+	channel    string
+	apiToken   string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// New creates a new video Client, retrieving configuration from context
+func New(ctx context.Context) (*Client, error) {
+	cfg := config.GetConfig(ctx)
+
+	apiURL := ""
+	switch cfg.Video.Provider {
+	case config.VideoProviderVimeo:
+		apiURL = defaultVimeoAPIURL
+	case config.VideoProviderYouTube:
+		apiURL = defaultYouTubeAPIURL
+	}
+
+	return NewWithHTTPClient(cfg.Video, apiURL, &http.Client{Timeout: 30 * time.Second})
+}
+
+// NewWithHTTPClient creates a new video Client against the given API URL with a custom HTTP client.
+// This constructor is primarily intended for testing purposes.
+func NewWithHTTPClient(cfg config.VideoConfig, apiURL string, httpClient *http.Client) (*Client, error) {
+	switch cfg.Provider {
+	case config.VideoProviderVimeo, config.VideoProviderYouTube:
+	default:
+		return nil, fmt.Errorf("unsupported video provider: %q", cfg.Provider)
+	}
+
+	if cfg.Channel == "" {
+		return nil, fmt.Errorf("video channel is required")
+	}
+	if cfg.APIToken == "" {
+		return nil, fmt.Errorf("video API token is required")
+	}
+
+	return &Client{
+		provider:   cfg.Provider,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		channel:    cfg.Channel,
+		apiToken:   cfg.APIToken,
+		httpClient: httpClient,
+		logger:     slog.Default().With("component", "video"),
+	}, nil
+}
+
+// ListVideos returns all videos published on the configured channel
+func (c *Client) ListVideos(ctx context.Context) ([]domain.Video, error) {
+	var videos []domain.Video
+	var err error
+	switch c.provider {
+	case config.VideoProviderVimeo:
+		videos, err = c.listVimeo(ctx)
+	case config.VideoProviderYouTube:
+		videos, err = c.listYouTube(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.InfoContext(ctx, "listed channel videos", "provider", c.provider, "channel", c.channel, "count", len(videos))
+	return videos, nil
+}
+
+// vimeoPage is a page of the Vimeo user videos listing
+type vimeoPage struct {
+	Data []struct {
+		URI         string    `json:"uri"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Link        string    `json:"link"`
+		ReleaseTime time.Time `json:"release_time"`
+	} `json:"data"`
+	Paging struct {
+		Next string `json:"next"`
+	} `json:"paging"`
+}
+
+// listVimeo pages through the videos of the Vimeo user, following the paging links
+func (c *Client) listVimeo(ctx context.Context) ([]domain.Video, error) {
+	query := url.Values{}
+	query.Set("per_page", fmt.Sprint(vimeoPageSize))
+	query.Set("fields", "uri,name,description,link,release_time")
+	next := "/users/" + url.PathEscape(c.channel) + "/videos?" + query.Encode()
+
+	var videos []domain.Video
+	for next != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+next, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "bearer "+c.apiToken)
+		req.Header.Set("Accept", "application/vnd.vimeo.*+json;version=3.4")
+
+		var page vimeoPage
+		if err := c.getJSON(req, &page); err != nil {
+			return nil, fmt.Errorf("failed to list vimeo videos: %w", err)
+		}
+
+		for _, item := range page.Data {
+			videos = append(videos, domain.Video{
+				ID:          strings.TrimPrefix(item.URI, "/videos/"),
+				Title:       item.Name,
+				Description: item.Description,
+				URL:         item.Link,
+				PublishedAt: item.ReleaseTime,
+			})
+		}
+		next = page.Paging.Next
+	}
+	return videos, nil
+}
+
+// youTubePage is a page of the YouTube playlist items listing
+type youTubePage struct {
+	Items []struct {
+		Snippet struct {
+			Title       string    `json:"title"`
+			Description string    `json:"description"`
+			PublishedAt time.Time `json:"publishedAt"`
+			ResourceID  struct {
+				VideoID string `json:"videoId"`
+			} `json:"resourceId"`
+		} `json:"snippet"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// listYouTube pages through the uploads playlist of the YouTube channel.
+// A channel ID (UC...) is mapped to its uploads playlist (UU...); any other value is used as a playlist ID.
+func (c *Client) listYouTube(ctx context.Context) ([]domain.Video, error) {
+	playlistID := c.channel
+	if strings.HasPrefix(playlistID, "UC") {
+		playlistID = "UU" + strings.TrimPrefix(playlistID, "UC")
+	}
+
+	var videos []domain.Video
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("part", "snippet")
+		query.Set("playlistId", playlistID)
+		query.Set("maxResults", fmt.Sprint(youTubePageSize))
+		query.Set("key", c.apiToken)
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+"/playlistItems?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		var page youTubePage
+		if err := c.getJSON(req, &page); err != nil {
+			return nil, fmt.Errorf("failed to list youtube videos: %w", err)
+		}
+
+		for _, item := range page.Items {
+			videoID := item.Snippet.ResourceID.VideoID
+			videos = append(videos, domain.Video{
+				ID:          videoID,
+				Title:       item.Snippet.Title,
+				Description: item.Snippet.Description,
+				URL:         "https://www.youtube.com/watch?v=" + videoID,
+				PublishedAt: item.Snippet.PublishedAt,
+			})
+		}
+
+		if page.NextPageToken == "" {
+			return videos, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// getJSON executes a request, checks the response status and decodes the JSON body
+func (c *Client) getJSON(req *http.Request, value any) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
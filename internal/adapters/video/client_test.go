@@ -0,0 +1,143 @@
+package video
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.VideoConfig
+		apiURL  string
+		wantErr string
+	}{
+		{
+			name:   "vimeo",
+			cfg:    config.VideoConfig{Provider: "vimeo", Channel: "javazone", APIToken: "token"},
+			apiURL: defaultVimeoAPIURL,
+		},
+		{
+			name:   "youtube",
+			cfg:    config.VideoConfig{Provider: "youtube", Channel: "UCabc", APIToken: "key"},
+			apiURL: defaultYouTubeAPIURL,
+		},
+		{
+			name:    "unknown provider",
+			cfg:     config.VideoConfig{Provider: "dailymotion", Channel: "javazone", APIToken: "token"},
+			wantErr: "unsupported video provider",
+		},
+		{
+			name:    "missing channel",
+			cfg:     config.VideoConfig{Provider: "vimeo", APIToken: "token"},
+			wantErr: "channel is required",
+		},
+		{
+			name:    "missing token",
+			cfg:     config.VideoConfig{Provider: "vimeo", Channel: "javazone"},
+			wantErr: "API token is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.WithConfig(context.Background(), &config.Config{Video: tt.cfg})
+
+			client, err := New(ctx)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.apiURL, client.apiURL)
+		})
+	}
+}
+
+func TestClient_ListVideos_Vimeo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/javazone/videos", r.URL.Path)
+		assert.Equal(t, "bearer token", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"data": [{"uri": "/videos/2", "name": "Second talk", "link": "https://vimeo.com/2"}], "paging": {"next": null}}`))
+			return
+		}
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		w.Write([]byte(`{
+			"data": [{"uri": "/videos/1", "name": "First talk", "description": "By Jane", "link": "https://vimeo.com/1", "release_time": "2024-09-10T10:00:00+00:00"}],
+			"paging": {"next": "/users/javazone/videos?page=2&per_page=100"}
+		}`))
+	}))
+	defer server.Close()
+
+	cfg := config.VideoConfig{Provider: "vimeo", Channel: "javazone", APIToken: "token"}
+	client, err := NewWithHTTPClient(cfg, server.URL, server.Client())
+	require.NoError(t, err)
+
+	videos, err := client.ListVideos(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, videos, 2)
+	assert.Equal(t, "1", videos[0].ID)
+	assert.Equal(t, "First talk", videos[0].Title)
+	assert.Equal(t, "By Jane", videos[0].Description)
+	assert.Equal(t, "https://vimeo.com/1", videos[0].URL)
+	assert.Equal(t, 2024, videos[0].PublishedAt.Year())
+	assert.Equal(t, "https://vimeo.com/2", videos[1].URL)
+}
+
+func TestClient_ListVideos_YouTube(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/playlistItems", r.URL.Path)
+		assert.Equal(t, "UUabc", r.URL.Query().Get("playlistId"))
+		assert.Equal(t, "key", r.URL.Query().Get("key"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "next" {
+			w.Write([]byte(`{"items": [{"snippet": {"title": "Second talk", "resourceId": {"videoId": "v2"}}}]}`))
+			return
+		}
+		w.Write([]byte(`{
+			"items": [{"snippet": {"title": "First talk", "publishedAt": "2024-09-10T10:00:00Z", "resourceId": {"videoId": "v1"}}}],
+			"nextPageToken": "next"
+		}`))
+	}))
+	defer server.Close()
+
+	cfg := config.VideoConfig{Provider: "youtube", Channel: "UCabc", APIToken: "key"}
+	client, err := NewWithHTTPClient(cfg, server.URL, server.Client())
+	require.NoError(t, err)
+
+	videos, err := client.ListVideos(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, videos, 2)
+	assert.Equal(t, "v1", videos[0].ID)
+	assert.Equal(t, "https://www.youtube.com/watch?v=v1", videos[0].URL)
+	assert.Equal(t, "Second talk", videos[1].Title)
+}
+
+func TestClient_ListVideos_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid token"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.VideoConfig{Provider: "vimeo", Channel: "javazone", APIToken: "bad"}
+	client, err := NewWithHTTPClient(cfg, server.URL, server.Client())
+	require.NoError(t, err)
+
+	_, err = client.ListVideos(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status code 401")
+}
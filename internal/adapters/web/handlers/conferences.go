@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// HandleConferenceMetadata renders the conference metadata page
+func (h *Handler) HandleConferenceMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.catalog == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	entries, err := h.catalog.ListConferenceMetadata(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list conference metadata", "error", err)
+		http.Error(w, "Failed to load conference metadata", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ConferenceMetadata(entries).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render conference metadata page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleSetConferenceMetadata stores the metadata of a conference, then re-renders the metadata list
+func (h *Handler) HandleSetConferenceMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.catalog == nil {
+		templates.ResultError("Conference metadata is not available").Render(ctx, w)
+		return
+	}
+
+	slug := r.FormValue("slug")
+	metadata := domain.ConferenceMetadata{
+		Venue:     strings.TrimSpace(r.FormValue("venue")),
+		StartDate: strings.TrimSpace(r.FormValue("startDate")),
+		EndDate:   strings.TrimSpace(r.FormValue("endDate")),
+		LogoURL:   strings.TrimSpace(r.FormValue("logoUrl")),
+		CFPOpens:  strings.TrimSpace(r.FormValue("cfpOpens")),
+		CFPCloses: strings.TrimSpace(r.FormValue("cfpCloses")),
+	}
+
+	message, errorMessage := "", ""
+	if err := h.catalog.SetConferenceMetadata(ctx, slug, metadata, userEmail(ctx)); err != nil {
+		slog.WarnContext(ctx, "web: failed to set conference metadata", "slug", slug, "error", err)
+		errorMessage = "Failed to save metadata: " + err.Error()
+	} else {
+		message = "Saved metadata for " + slug + ". Reindex the conference to update its talks."
+	}
+
+	h.renderConferenceMetadataList(w, r, message, errorMessage)
+}
+
+// HandleRemoveConferenceMetadata removes the stored metadata of a conference, then re-renders the metadata list
+func (h *Handler) HandleRemoveConferenceMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.catalog == nil {
+		templates.ResultError("Conference metadata is not available").Render(ctx, w)
+		return
+	}
+
+	slug := r.FormValue("slug")
+
+	message, errorMessage := "", ""
+	if err := h.catalog.RemoveConferenceMetadata(ctx, slug); err != nil {
+		slog.WarnContext(ctx, "web: failed to remove conference metadata", "slug", slug, "error", err)
+		errorMessage = "Failed to remove metadata: " + err.Error()
+	} else {
+		message = "Removed metadata for " + slug + ". Reindex the conference to update its talks."
+	}
+
+	h.renderConferenceMetadataList(w, r, message, errorMessage)
+}
+
+// renderConferenceMetadataList renders the current metadata list fragment with a result message
+func (h *Handler) renderConferenceMetadataList(w http.ResponseWriter, r *http.Request, message, errorMessage string) {
+	ctx := r.Context()
+
+	entries, err := h.catalog.ListConferenceMetadata(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list conference metadata", "error", err)
+		templates.ResultError("Failed to load conference metadata").Render(ctx, w)
+		return
+	}
+
+	templates.ConferenceMetadataList(entries, message, errorMessage).Render(ctx, w)
+}
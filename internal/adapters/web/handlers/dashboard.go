@@ -5,8 +5,20 @@ import (
 	"net/http"
 
 	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
+// SetTalkTrends enables the talk count trend charts on the dashboard
+func (h *Handler) SetTalkTrends(trends ports.TalkTrends) {
+	h.trends = trends
+}
+
+// SetReindexStatuses shows when each index was last successfully reindexed on the dashboard
+func (h *Handler) SetReindexStatuses(statuses ports.ReindexStatuses) {
+	h.reindexes = statuses
+}
+
 // HandleDashboard renders the admin dashboard page
 func (h *Handler) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -18,8 +30,27 @@ func (h *Handler) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The trend charts are left out rather than failing the dashboard
+	var trends []domain.TalkCountTrend
+	if h.trends != nil {
+		if trends, err = h.trends.TalkCountTrends(ctx); err != nil {
+			slog.WarnContext(ctx, "failed to load talk count trends", "error", err)
+		}
+	}
+
+	// Likewise the freshness of the indexes
+	var reindexes []domain.ReindexStatus
+	if h.reindexes != nil {
+		if reindexes, err = h.reindexes.ReindexStatuses(ctx); err != nil {
+			slog.WarnContext(ctx, "failed to load reindex statuses", "error", err)
+		}
+	}
+
+	prefs := h.loadPreferences(ctx)
+	ctx = templates.WithPreferences(ctx, prefs)
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := templates.Dashboard(conferences).Render(ctx, w); err != nil {
+	if err := templates.Dashboard(conferences, h.indexer.IndexNames(), reindexes, prefs, trends, h.progress != nil, h.previews != nil).Render(ctx, w); err != nil {
 		slog.ErrorContext(ctx, "failed to render dashboard", "error", err)
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		return
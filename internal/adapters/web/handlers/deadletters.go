@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// HandleDeadLetters renders the documents that failed indexing
+func (h *Handler) HandleDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.deadLetters == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	letters, err := h.deadLetters.ListDeadLetters(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list dead letters", "error", err)
+		http.Error(w, "Failed to load dead letters", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.DeadLetters(letters).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render dead letters page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleRetryDeadLetter indexes the payload of a dead letter again, then re-renders the dead letters
+func (h *Handler) HandleRetryDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.deadLetters == nil {
+		templates.ResultError("Dead letters are not available").Render(ctx, w)
+		return
+	}
+
+	id := r.FormValue("id")
+
+	message, errorMessage := "", ""
+	if err := h.deadLetters.RetryDeadLetter(ctx, id); err != nil {
+		slog.WarnContext(ctx, "web: failed to retry dead letter", "id", id, "error", err)
+		errorMessage = "Retry failed: " + err.Error()
+	} else {
+		message = "Indexed " + id + "."
+	}
+
+	h.renderDeadLetterList(w, r, message, errorMessage)
+}
+
+// HandleDiscardDeadLetter removes a dead letter without indexing it, then re-renders the dead letters
+func (h *Handler) HandleDiscardDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.deadLetters == nil {
+		templates.ResultError("Dead letters are not available").Render(ctx, w)
+		return
+	}
+
+	id := r.FormValue("id")
+
+	message, errorMessage := "", ""
+	if err := h.deadLetters.DiscardDeadLetter(ctx, id); err != nil {
+		slog.WarnContext(ctx, "web: failed to discard dead letter", "id", id, "error", err)
+		errorMessage = "Failed to discard dead letter: " + err.Error()
+	} else {
+		message = "Discarded " + id + "."
+	}
+
+	h.renderDeadLetterList(w, r, message, errorMessage)
+}
+
+// renderDeadLetterList renders the current dead letter list fragment with a result message
+func (h *Handler) renderDeadLetterList(w http.ResponseWriter, r *http.Request, message, errorMessage string) {
+	ctx := r.Context()
+
+	letters, err := h.deadLetters.ListDeadLetters(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list dead letters", "error", err)
+		templates.ResultError("Failed to load dead letters").Render(ctx, w)
+		return
+	}
+
+	templates.DeadLetterList(letters, message, errorMessage).Render(ctx, w)
+}
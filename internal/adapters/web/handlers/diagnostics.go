@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/app"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetDiagnostics enables the diagnostics bundle download
+func (h *Handler) SetDiagnostics(diagnostics ports.Diagnostics) {
+	h.diagnostics = diagnostics
+}
+
+// HandleDiagnosticsBundle serves the diagnostics bundle as a zip download for support tickets and issues
+func (h *Handler) HandleDiagnosticsBundle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.diagnostics == nil {
+		http.Error(w, "Diagnostics are not available", http.StatusNotFound)
+		return
+	}
+
+	bundle, err := h.diagnostics.DiagnosticsBundle(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to build diagnostics bundle", "error", err)
+		http.Error(w, "Failed to build diagnostics bundle", http.StatusInternalServerError)
+		return
+	}
+
+	// Buffer the archive so a failure is reported instead of sending a truncated download
+	var body bytes.Buffer
+	if err := app.WriteDiagnosticsZip(&body, bundle); err != nil {
+		slog.ErrorContext(ctx, "web: failed to write diagnostics bundle", "error", err)
+		http.Error(w, "Failed to write diagnostics bundle", http.StatusInternalServerError)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: exporting diagnostics bundle", "jobs", len(bundle.Jobs), "logLines", len(bundle.Logs))
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", attachment("talks-indexer-diagnostics", "zip"))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if _, err := w.Write(body.Bytes()); err != nil {
+		slog.ErrorContext(ctx, "web: failed to send diagnostics bundle", "error", err)
+	}
+}
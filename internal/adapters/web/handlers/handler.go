@@ -10,20 +10,119 @@ import (
 
 // Handler handles web UI requests for the admin dashboard
 type Handler struct {
-	indexer     ports.Indexer
-	provider    ports.ConferenceProvider
-	conferences []domain.Conference
-	confMu      sync.RWMutex
+	indexer      ports.Indexer
+	provider     ports.ConferenceProvider
+	reporter     ports.Reporter
+	signer       ports.ContentSigner
+	preferences  ports.Preferences
+	webhooks     ports.Webhooks
+	users        ports.UserDirectory
+	indexes      ports.IndexManager
+	videos       ports.VideoBackfill
+	links        ports.LinkReporter
+	keywords     ports.KeywordTrends
+	catalog      ports.ConferenceCatalog
+	series       ports.SeriesCatalog
+	deadLetters  ports.DeadLetters
+	republisher  ports.Republisher
+	whatIf       ports.WhatIfBuilder
+	notices      ports.Notices
+	trends       ports.TalkTrends
+	reindexes    ports.ReindexStatuses
+	jobs         ports.ReindexJobs
+	diagnostics  ports.Diagnostics
+	apiTokens    ports.APITokens
+	progress     ports.ReindexProgressStream
+	previews     ports.TalkPreviews
+	sitePreviews ports.SitePreviews
+	talkDiffs    ports.TalkDiffs
+	reviews      ports.Reviews
+	conflicts    ports.ReviewerConflicts
+	usage        ports.UsageAnalytics
+	readOnly     bool
+	conferences  []domain.Conference
+	confMu       sync.RWMutex
 }
 
 // NewHandler creates a new web Handler with the provided dependencies
-func NewHandler(indexer ports.Indexer, provider ports.ConferenceProvider) *Handler {
+func NewHandler(indexer ports.Indexer, provider ports.ConferenceProvider, reporter ports.Reporter) *Handler {
 	return &Handler{
 		indexer:  indexer,
 		provider: provider,
+		reporter: reporter,
 	}
 }
 
+// SetSigner enables signing of exported snapshots
+func (h *Handler) SetSigner(signer ports.ContentSigner) {
+	h.signer = signer
+}
+
+// SetPreferences enables per-user preferences such as the default conference
+func (h *Handler) SetPreferences(preferences ports.Preferences) {
+	h.preferences = preferences
+}
+
+// SetWebhooks enables managing outbound webhook subscriptions
+func (h *Handler) SetWebhooks(webhooks ports.Webhooks) {
+	h.webhooks = webhooks
+}
+
+// SetUserDirectory enables managing the admin allowlist and role assignments
+func (h *Handler) SetUserDirectory(users ports.UserDirectory) {
+	h.users = users
+}
+
+// SetIndexManager enables the index lifecycle page
+func (h *Handler) SetIndexManager(indexes ports.IndexManager) {
+	h.indexes = indexes
+}
+
+// SetVideoBackfill enables the missing video report and video link backfill
+func (h *Handler) SetVideoBackfill(videos ports.VideoBackfill) {
+	h.videos = videos
+}
+
+// SetLinkReporter enables the broken link report
+func (h *Handler) SetLinkReporter(links ports.LinkReporter) {
+	h.links = links
+}
+
+// SetKeywordTrends enables the keyword trend analysis page
+func (h *Handler) SetKeywordTrends(keywords ports.KeywordTrends) {
+	h.keywords = keywords
+}
+
+// SetUsageAnalytics enables the API usage page
+func (h *Handler) SetUsageAnalytics(usage ports.UsageAnalytics) {
+	h.usage = usage
+}
+
+// SetConferenceCatalog enables managing the conference metadata
+func (h *Handler) SetConferenceCatalog(catalog ports.ConferenceCatalog) {
+	h.catalog = catalog
+}
+
+// SetSeriesCatalog enables managing the series linking related sessions
+func (h *Handler) SetSeriesCatalog(series ports.SeriesCatalog) {
+	h.series = series
+}
+
+// SetDeadLetters enables inspecting and retrying documents that failed indexing
+func (h *Handler) SetDeadLetters(deadLetters ports.DeadLetters) {
+	h.deadLetters = deadLetters
+}
+
+// SetRepublisher enables the guided full republish
+func (h *Handler) SetRepublisher(republisher ports.Republisher) {
+	h.republisher = republisher
+}
+
+// SetWhatIfBuilder enables building what-if indexes with alternative transformation settings
+func (h *Handler) SetWhatIfBuilder(whatIf ports.WhatIfBuilder) {
+	h.whatIf = whatIf
+}
+
 // getConferences returns cached conferences, fetching them if not yet cached
 func (h *Handler) getConferences(ctx context.Context) ([]domain.Conference, error) {
 	h.confMu.RLock()
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// HandleIndexes renders the index lifecycle page
+func (h *Handler) HandleIndexes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.indexes == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	indexes, err := h.indexes.ListIndexes(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list indexes", "error", err)
+		http.Error(w, "Failed to load indexes", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Indexes(indexes).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render indexes page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleDeleteIndex deletes a stale index, then re-renders the index list
+func (h *Handler) HandleDeleteIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.indexes == nil {
+		templates.ResultError("Index management is not available").Render(ctx, w)
+		return
+	}
+
+	indexName := r.FormValue("index")
+
+	message, errorMessage := "", ""
+	if err := h.indexes.DeleteIndex(ctx, indexName); err != nil {
+		slog.WarnContext(ctx, "web: failed to delete index", "index", indexName, "error", err)
+		errorMessage = "Failed to delete index: " + err.Error()
+	} else {
+		message = "Deleted index " + indexName
+	}
+
+	h.renderIndexList(w, r, message, errorMessage)
+}
+
+// HandlePointAlias points an alias to an index, then re-renders the index list
+func (h *Handler) HandlePointAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.indexes == nil {
+		templates.ResultError("Index management is not available").Render(ctx, w)
+		return
+	}
+
+	alias := r.FormValue("alias")
+	indexName := r.FormValue("index")
+
+	message, errorMessage := "", ""
+	if err := h.indexes.PointAlias(ctx, alias, indexName); err != nil {
+		slog.WarnContext(ctx, "web: failed to point alias", "alias", alias, "index", indexName, "error", err)
+		errorMessage = "Failed to point alias: " + err.Error()
+	} else {
+		message = "Alias " + alias + " now points to " + indexName
+	}
+
+	h.renderIndexList(w, r, message, errorMessage)
+}
+
+// renderIndexList renders the current index list fragment with a result message
+func (h *Handler) renderIndexList(w http.ResponseWriter, r *http.Request, message, errorMessage string) {
+	ctx := r.Context()
+
+	indexes, err := h.indexes.ListIndexes(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list indexes", "error", err)
+		templates.ResultError("Failed to load indexes").Render(ctx, w)
+		return
+	}
+
+	templates.IndexList(indexes, message, errorMessage).Render(ctx, w)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// HandleKeywordTrends renders the keyword trend analysis for the comma-separated keywords parameter,
+// or for the most frequent keywords if it is empty
+func (h *Handler) HandleKeywordTrends(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.keywords == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	keywords := strings.TrimSpace(r.URL.Query().Get("keywords"))
+	var req domain.KeywordTrendRequest
+	if keywords != "" {
+		req.Keywords = strings.Split(keywords, ",")
+	}
+
+	errorMessage := ""
+	report, err := h.keywords.KeywordTrends(ctx, req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		errorMessage = err.Error()
+	case err != nil:
+		slog.ErrorContext(ctx, "web: failed to build keyword trends", "error", err)
+		errorMessage = "Failed to build keyword trends"
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.KeywordTrends(keywords, report, errorMessage).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render keyword trends page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
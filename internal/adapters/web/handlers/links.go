@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// HandleLinks renders the report of the last link check
+func (h *Handler) HandleLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.links == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	report, err := h.links.LatestReport(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load link report", "error", err)
+		http.Error(w, "Failed to load link report", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Links(report).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render links page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleCheckLinks runs a link check, then re-renders the report
+func (h *Handler) HandleCheckLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.links == nil {
+		templates.ResultError("Link checking is not available").Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: starting link check")
+
+	message, errorMessage := "", ""
+	if err := h.links.CheckLinks(ctx); err != nil {
+		slog.ErrorContext(ctx, "web: failed to check links", "error", err)
+		errorMessage = "Failed to check links: " + err.Error()
+	} else {
+		message = "Checked links in the public index"
+	}
+
+	report, err := h.links.LatestReport(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load link report", "error", err)
+		templates.ResultError("Failed to load link report").Render(ctx, w)
+		return
+	}
+
+	templates.LinkReport(report, message, errorMessage).Render(ctx, w)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// HandleLogin renders the login page shown when a session is missing or has expired
+func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	returnURL := r.URL.Query().Get("return_url")
+	expired := r.URL.Query().Get("expired") == "true"
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Login(returnURL, expired).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render login page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetNotices enables the notice banner admins set on the notice page
+func (h *Handler) SetNotices(notices ports.Notices) {
+	h.notices = notices
+}
+
+// WithBanners marks the request context with the read-only mode and the active notice,
+// so the layout shows their banners
+func (h *Handler) WithBanners(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if h.readOnly {
+			ctx = templates.WithReadOnly(ctx)
+		}
+		if h.notices != nil {
+			if notice, ok := h.notices.ActiveNotice(ctx); ok {
+				ctx = templates.WithNotice(ctx, notice)
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// HandleNotice renders the notice page
+func (h *Handler) HandleNotice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.notices == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	notice, err := h.notices.GetNotice(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load notice", "error", err)
+		http.Error(w, "Failed to load notice", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.NoticePage(notice).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render notice page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleSetNotice stores the notice, then re-renders the current notice
+func (h *Handler) HandleSetNotice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.notices == nil {
+		templates.ResultError("Notices are not available").Render(ctx, w)
+		return
+	}
+
+	notice := domain.Notice{
+		Message:  r.FormValue("message"),
+		Severity: domain.NoticeSeverity(r.FormValue("severity")),
+	}
+	if expiresIn := r.FormValue("expiresIn"); expiresIn != "" {
+		duration, err := time.ParseDuration(expiresIn)
+		if err != nil || duration <= 0 {
+			templates.ResultError("Invalid expiry: "+expiresIn).Render(ctx, w)
+			return
+		}
+		expiresAt := time.Now().Add(duration).UTC()
+		notice.ExpiresAt = &expiresAt
+	}
+
+	message, errorMessage := "", ""
+	if err := h.notices.SetNotice(ctx, notice, userEmail(ctx)); err != nil {
+		slog.WarnContext(ctx, "web: failed to set notice", "error", err)
+		errorMessage = "Failed to save notice: " + err.Error()
+	} else {
+		message = "Notice saved. Other instances show it within a minute."
+	}
+
+	h.renderNoticeStatus(w, r, message, errorMessage)
+}
+
+// HandleClearNotice removes the notice, then re-renders the current notice
+func (h *Handler) HandleClearNotice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.notices == nil {
+		templates.ResultError("Notices are not available").Render(ctx, w)
+		return
+	}
+
+	message, errorMessage := "", ""
+	if err := h.notices.ClearNotice(ctx); err != nil {
+		slog.WarnContext(ctx, "web: failed to clear notice", "error", err)
+		errorMessage = "Failed to clear notice: " + err.Error()
+	} else {
+		message = "Notice cleared."
+	}
+
+	h.renderNoticeStatus(w, r, message, errorMessage)
+}
+
+// renderNoticeStatus renders the current notice fragment with a result message
+func (h *Handler) renderNoticeStatus(w http.ResponseWriter, r *http.Request, message, errorMessage string) {
+	ctx := r.Context()
+
+	notice, err := h.notices.GetNotice(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load notice", "error", err)
+		templates.ResultError("Failed to load notice").Render(ctx, w)
+		return
+	}
+
+	templates.NoticeStatus(notice, message, errorMessage).Render(ctx, w)
+}
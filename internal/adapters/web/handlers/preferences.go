@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/auth"
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// anonymousUser keys preferences when there is no session (development mode without authentication)
+const anonymousUser = "anonymous"
+
+// userEmail returns the email of the logged-in user, or anonymousUser without a session
+func userEmail(ctx context.Context) string {
+	if sess := auth.GetSession(ctx); sess != nil {
+		return sess.Email
+	}
+	return anonymousUser
+}
+
+// WithUserPreferences loads the current user's preferences into the request context, so every page
+// and fragment is rendered in the user's language. It must run after the authentication middleware.
+func (h *Handler) WithUserPreferences(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := templates.WithPreferences(r.Context(), h.loadPreferences(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// loadPreferences returns the current user's preferences, falling back to the defaults
+// when preferences are not enabled or cannot be read. Preferences already loaded for the
+// request are reused.
+func (h *Handler) loadPreferences(ctx context.Context) domain.UserPreferences {
+	if prefs, ok := templates.PreferencesFromContext(ctx); ok {
+		return prefs
+	}
+	if h.preferences == nil {
+		return domain.DefaultUserPreferences()
+	}
+
+	prefs, err := h.preferences.GetPreferences(ctx, userEmail(ctx))
+	if err != nil {
+		slog.WarnContext(ctx, "web: failed to load preferences", "error", err)
+	}
+	return prefs
+}
+
+// rememberConference stores the conference as the current user's default, so it is preselected
+// on the next dashboard visit
+func (h *Handler) rememberConference(ctx context.Context, slug string) {
+	if h.preferences == nil {
+		return
+	}
+
+	prefs := h.loadPreferences(ctx)
+	if prefs.DefaultConference == slug {
+		return
+	}
+	prefs.DefaultConference = slug
+
+	if err := h.preferences.SavePreferences(ctx, userEmail(ctx), prefs); err != nil {
+		slog.WarnContext(ctx, "web: failed to remember conference", "slug", slug, "error", err)
+	}
+}
+
+// HandleSavePreferences stores the preferences submitted from the dashboard
+func (h *Handler) HandleSavePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.preferences == nil {
+		templates.ResultError("Preferences are not available").Render(ctx, w)
+		return
+	}
+
+	pageSize, err := strconv.Atoi(r.FormValue("pageSize"))
+	if err != nil {
+		templates.ResultError("Please select a page size").Render(ctx, w)
+		return
+	}
+
+	previous := h.loadPreferences(ctx)
+	prefs := domain.UserPreferences{
+		DefaultConference: r.FormValue("defaultConference"),
+		PageSize:          pageSize,
+		Theme:             r.FormValue("theme"),
+		Language:          r.FormValue("language"),
+	}
+
+	if err := h.preferences.SavePreferences(ctx, userEmail(ctx), prefs); err != nil {
+		slog.ErrorContext(ctx, "web: failed to save preferences", "error", err)
+		templates.ResultError("Failed to save preferences: "+err.Error()).Render(ctx, w)
+		return
+	}
+
+	// Reload the page so a new theme or language applies everywhere
+	if prefs.Theme != previous.Theme || prefs.Language != previous.Language {
+		w.Header().Set("HX-Refresh", "true")
+	}
+	templates.ResultSuccess("Preferences saved").Render(ctx, w)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetTalkPreviews enables creating preview links speakers open without logging in
+func (h *Handler) SetTalkPreviews(previews ports.TalkPreviews) {
+	h.previews = previews
+}
+
+// HandleCreatePreviewLink creates a preview link for the speakers of a talk
+func (h *Handler) HandleCreatePreviewLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.previews == nil {
+		templates.ResultError("Speaker previews are not available").Render(ctx, w)
+		return
+	}
+
+	talkID := r.FormValue("talkId")
+	if talkID == "" {
+		templates.ResultError("Please enter a talk ID").Render(ctx, w)
+		return
+	}
+
+	token, err := h.previews.CreatePreviewToken(ctx, talkID)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to create preview link", "talkID", talkID, "error", err)
+		templates.ResultError("Failed to create preview link: "+err.Error()).Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: preview link created", "talkID", talkID, "user", userEmail(ctx), "expiresAt", token.ExpiresAt)
+	templates.PreviewLink(token).Render(ctx, w)
+}
+
+// HandleTalkPreview renders the talk of a preview link without requiring a login. The token in the
+// path is the only credential, so the page is neither cached nor indexed and does not leak it as referrer.
+func (h *Handler) HandleTalkPreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.previews == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	preview, err := h.previews.PreviewTalk(ctx, r.PathValue("token"))
+	if errors.Is(err, domain.ErrInvalidPreviewToken) {
+		slog.WarnContext(ctx, "web: invalid preview link", "error", err)
+		w.WriteHeader(http.StatusNotFound)
+		templates.PreviewUnavailable(true).Render(ctx, w)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to load preview", "error", err)
+		w.WriteHeader(http.StatusBadGateway)
+		templates.PreviewUnavailable(false).Render(ctx, w)
+		return
+	}
+
+	if err := templates.TalkPreview(preview).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render talk preview", "talkID", preview.TalkID, "error", err)
+	}
+}
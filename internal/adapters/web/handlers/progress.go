@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// progressKeepAlive is how often a comment is sent on an idle progress stream, so proxies keep it open
+const progressKeepAlive = 30 * time.Second
+
+// SetReindexProgress enables following the progress of running reindexes live on the dashboard
+func (h *Handler) SetReindexProgress(progress ports.ReindexProgressStream) {
+	h.progress = progress
+}
+
+// HandleReindexStream streams the progress of the reindexes running on this instance as Server-Sent
+// Events until the client disconnects. Each event is named after the reindex stage and carries the
+// rendered progress log item, so the dashboard only has to append it.
+func (h *Handler) HandleReindexStream(w http.ResponseWriter, r *http.Request) {
+	if h.progress == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	controller := http.NewResponseController(w)
+
+	// The stream stays open for as long as the dashboard does, beyond the server's write timeout
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.WarnContext(ctx, "web: failed to clear write deadline for progress stream", "error", err)
+	}
+
+	updates := h.progress.SubscribeProgress(ctx)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		slog.ErrorContext(ctx, "web: progress stream cannot be flushed", "error", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		var err error
+		select {
+		case progress, ok := <-updates:
+			if !ok {
+				return
+			}
+			err = writeProgressEvent(ctx, w, progress)
+		case <-keepAlive.C:
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+		}
+		if err == nil {
+			err = controller.Flush()
+		}
+		if err != nil {
+			slog.DebugContext(ctx, "web: progress stream closed", "error", err)
+			return
+		}
+	}
+}
+
+// writeProgressEvent writes the progress as an event named after its stage, with the rendered log item
+// split over data lines as Server-Sent Events require
+func writeProgressEvent(ctx context.Context, w io.Writer, progress domain.ReindexProgress) error {
+	var item bytes.Buffer
+	if err := templates.ReindexProgressItem(progress).Render(ctx, &item); err != nil {
+		return fmt.Errorf("failed to render progress: %w", err)
+	}
+
+	var event strings.Builder
+	fmt.Fprintf(&event, "event: %s\n", progress.Stage)
+	for _, line := range strings.Split(item.String(), "\n") {
+		fmt.Fprintf(&event, "data: %s\n", line)
+	}
+	event.WriteString("\n")
+
+	_, err := io.WriteString(w, event.String())
+	return err
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// SetReadOnly refuses the actions writing to the cluster, for Elasticsearch maintenance windows
+func (h *Handler) SetReadOnly(readOnly bool) {
+	h.readOnly = readOnly
+}
+
+// RequireWritable wraps an action writing to the cluster so it is refused with an error result in read-only mode
+func (h *Handler) RequireWritable(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.readOnly {
+			next(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		slog.WarnContext(ctx, "web: refused action in read-only mode", "path", r.URL.Path)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		templates.ResultError("The indexer is in read-only mode during maintenance, try again later").Render(ctx, w)
+	}
+}
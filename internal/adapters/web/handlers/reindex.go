@@ -1,16 +1,57 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
-// HandleReindexAll triggers a full reindex of all conferences
+// SetReindexJobs makes full and conference reindexes run in the background as jobs, so the request
+// returns right away and the reindex is followed in the progress log
+func (h *Handler) SetReindexJobs(jobs ports.ReindexJobs) {
+	h.jobs = jobs
+}
+
+// startReindex starts the reindex as a background job and renders the job it runs as, or why it was
+// refused, such as another reindex of the same scope running. It reports whether the reindex started.
+func (h *Handler) startReindex(ctx context.Context, w http.ResponseWriter, scope domain.JobScope, what string) bool {
+	job, err := h.jobs.StartReindex(ctx, scope)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if errors.Is(err, domain.ErrJobRunning) {
+		slog.WarnContext(ctx, "web: reindex already running", "kind", scope.Kind, "target", scope.Target, "jobID", job.ID)
+		templates.ResultError("A "+what+" is already running: job "+job.ID).Render(ctx, w)
+		return false
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to start reindex", "kind", scope.Kind, "target", scope.Target, "error", err)
+		templates.ResultError("Failed to start "+what+": "+err.Error()).Render(ctx, w)
+		return false
+	}
+
+	slog.InfoContext(ctx, "web: reindex started", "kind", scope.Kind, "target", scope.Target, "jobID", job.ID)
+	templates.ResultSuccess("Started "+what+" as job "+job.ID+"; follow its progress below").Render(ctx, w)
+	return true
+}
+
+// HandleReindexAll triggers a full reindex of all conferences. With the force checkbox ticked the
+// reindex replaces the live public index even if it would shrink by more than REINDEX_MAX_SHRINK_PERCENT.
 func (h *Handler) HandleReindexAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	slog.InfoContext(ctx, "web: starting full reindex")
+	if r.FormValue("force") == "true" {
+		ctx = domain.WithShrinkAllowed(ctx)
+	}
+	slog.InfoContext(ctx, "web: starting full reindex", "force", domain.ShrinkAllowed(ctx))
+
+	if h.jobs != nil {
+		h.startReindex(ctx, w, domain.JobScope{Kind: domain.JobKindReindexAll}, "full reindex")
+		return
+	}
 
 	err := h.indexer.ReindexAll(ctx)
 
@@ -38,6 +79,13 @@ func (h *Handler) HandleReindexConference(w http.ResponseWriter, r *http.Request
 
 	slog.InfoContext(ctx, "web: starting conference reindex", "slug", slug)
 
+	if h.jobs != nil {
+		if h.startReindex(ctx, w, domain.JobScope{Kind: domain.JobKindReindexConference, Target: slug}, "reindex of "+slug) {
+			h.rememberConference(ctx, slug)
+		}
+		return
+	}
+
 	err := h.indexer.ReindexConference(ctx, slug)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -48,9 +96,42 @@ func (h *Handler) HandleReindexConference(w http.ResponseWriter, r *http.Request
 	}
 
 	slog.InfoContext(ctx, "web: conference reindex completed", "slug", slug)
+	h.rememberConference(ctx, slug)
 	templates.ResultSuccess("Successfully reindexed conference: "+slug).Render(ctx, w)
 }
 
+// HandleReindexConferenceByID triggers a reindex for a single conference given by its moresleep ID,
+// for when several conferences share a slug
+func (h *Handler) HandleReindexConferenceByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	conferenceID := r.FormValue("conferenceId")
+	if conferenceID == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		templates.ResultError("Please enter a conference ID").Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: starting conference reindex", "conferenceID", conferenceID)
+
+	if h.jobs != nil {
+		h.startReindex(ctx, w, domain.JobScope{Kind: domain.JobKindReindexConferenceID, Target: conferenceID}, "reindex of conference "+conferenceID)
+		return
+	}
+
+	err := h.indexer.ReindexConferenceByID(ctx, conferenceID)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to reindex conference", "conferenceID", conferenceID, "error", err)
+		templates.ResultError("Failed to reindex conference: "+err.Error()).Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: conference reindex completed", "conferenceID", conferenceID)
+	templates.ResultSuccess("Successfully reindexed conference: "+conferenceID).Render(ctx, w)
+}
+
 // HandleReindexTalk triggers a reindex for a single talk
 func (h *Handler) HandleReindexTalk(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -76,3 +157,29 @@ func (h *Handler) HandleReindexTalk(w http.ResponseWriter, r *http.Request) {
 	slog.InfoContext(ctx, "web: talk reindex completed", "talkID", talkID)
 	templates.ResultSuccess("Successfully reindexed talk: "+talkID).Render(ctx, w)
 }
+
+// HandleDeleteTalk deletes a single talk from both indexes, such as a talk withdrawn in moresleep
+func (h *Handler) HandleDeleteTalk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	talkID := r.FormValue("talkId")
+	if talkID == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		templates.ResultError("Please enter a talk ID").Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: deleting talk", "talkID", talkID)
+
+	err := h.indexer.DeleteTalk(ctx, talkID)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to delete talk", "talkID", talkID, "error", err)
+		templates.ResultError("Failed to delete talk: "+err.Error()).Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: talk deleted", "talkID", talkID)
+	templates.ResultSuccess("Successfully deleted talk: "+talkID).Render(ctx, w)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/app"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// HandleStatisticsJSON serves the aggregated statistics report as a JSON download
+func (h *Handler) HandleStatisticsJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.reporter.Statistics(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to build statistics report", "error", err)
+		http.Error(w, "Failed to build statistics report", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", attachment("statistics", "json"))
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		slog.ErrorContext(ctx, "web: failed to encode statistics report", "error", err)
+	}
+}
+
+// HandleStatisticsCSV serves the aggregated statistics report as a CSV download
+func (h *Handler) HandleStatisticsCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.reporter.Statistics(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to build statistics report", "error", err)
+		http.Error(w, "Failed to build statistics report", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", attachment("statistics", "csv"))
+
+	if err := app.WriteStatisticsCSV(w, report); err != nil {
+		slog.ErrorContext(ctx, "web: failed to write statistics csv", "error", err)
+	}
+}
+
+// HandleSpeakerContactsCSV serves the speakers of the approved talks of a conference with their
+// contact emails as a CSV download
+func (h *Handler) HandleSpeakerContactsCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	slug := r.URL.Query().Get("conference")
+	if slug == "" {
+		http.Error(w, "Conference is required", http.StatusBadRequest)
+		return
+	}
+
+	contacts, err := h.reporter.SpeakerContacts(ctx, slug)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to build speaker contacts", "conference", slug, "error", err)
+		http.Error(w, "Failed to build speaker contacts", http.StatusInternalServerError)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: exporting speaker contacts", "conference", slug, "speakers", len(contacts))
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", attachment("speakers", "csv"))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if err := app.WriteSpeakerContactsCSV(w, contacts); err != nil {
+		slog.ErrorContext(ctx, "web: failed to write speaker contacts csv", "error", err)
+	}
+}
+
+// attachment returns a Content-Disposition header value with a dated filename
+func attachment(name, extension string) string {
+	return fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().Format("2006-01-02"), extension)
+}
+
+// HandleAnonymizedDataset serves the anonymized research dataset as a newline-delimited JSON download
+func (h *Handler) HandleAnonymizedDataset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dataset, err := h.reporter.AnonymizedDataset(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to build anonymized dataset", "error", err)
+		http.Error(w, "Failed to build anonymized dataset", http.StatusInternalServerError)
+		return
+	}
+
+	// Buffer the snapshot so it can be signed as a whole
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, talk := range dataset {
+		if err := encoder.Encode(talk); err != nil {
+			slog.ErrorContext(ctx, "web: failed to encode anonymized talk", "error", err)
+			http.Error(w, "Failed to encode anonymized dataset", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if h.signer != nil {
+		w.Header().Set(domain.SignatureHeader, h.signer.Sign(body.Bytes()))
+		w.Header().Set(domain.SignatureKeyIDHeader, h.signer.PublicKey().KeyID)
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", attachment("anonymized-talks", "ndjson"))
+
+	if _, err := w.Write(body.Bytes()); err != nil {
+		slog.ErrorContext(ctx, "web: failed to write anonymized dataset", "error", err)
+	}
+}
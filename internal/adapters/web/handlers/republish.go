@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// HandleRepublish renders the guided full republish page
+func (h *Handler) HandleRepublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.republisher == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Republish().Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render republish page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandlePlanRepublish runs the dry-run diff of a republish and renders it
+func (h *Handler) HandlePlanRepublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.republisher == nil {
+		templates.ResultError("Republish is not available").Render(ctx, w)
+		return
+	}
+
+	plan, err := h.republisher.PlanRepublish(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: republish dry run failed", "error", err)
+		templates.ResultError("Dry run failed: "+err.Error()).Render(ctx, w)
+		return
+	}
+
+	templates.RepublishPlanResult(plan).Render(ctx, w)
+}
+
+// HandleRunRepublish runs a full republish and renders the outcome of each step. The force checkbox
+// lets the republish shrink the live public index like a forced full reindex.
+func (h *Handler) HandleRunRepublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.FormValue("force") == "true" {
+		ctx = domain.WithShrinkAllowed(ctx)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.republisher == nil {
+		templates.ResultError("Republish is not available").Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: starting republish")
+
+	job, err := h.republisher.Republish(ctx)
+	if err != nil && job.ID == "" {
+		slog.ErrorContext(ctx, "web: republish not started", "error", err)
+		templates.ResultError("Republish not started: "+err.Error()).Render(ctx, w)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "web: republish failed", "error", err)
+	} else {
+		slog.InfoContext(ctx, "web: republish completed", "jobID", job.ID)
+	}
+
+	templates.RepublishResult(job).Render(ctx, w)
+}
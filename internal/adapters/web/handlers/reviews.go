@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/app"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetReviews enables listing the talks assigned to the logged-in committee member
+func (h *Handler) SetReviews(reviews ports.Reviews) {
+	h.reviews = reviews
+}
+
+// SetReviewerConflicts enables committee members to register their conflicts of interest on the reviews page
+func (h *Handler) SetReviewerConflicts(conflicts ports.ReviewerConflicts) {
+	h.conflicts = conflicts
+}
+
+// HandleReviews renders the talks assigned to the logged-in user for review, of the conference
+// parameter if one is selected. Talks the user gave feedback on are listed if the all parameter is set.
+func (h *Handler) HandleReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.reviews == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	conferences, err := h.getConferences(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get conferences", "error", err)
+		http.Error(w, "Failed to load conferences", http.StatusInternalServerError)
+		return
+	}
+
+	slug := r.URL.Query().Get("conference")
+	includeReviewed := r.URL.Query().Get("all") == "true"
+
+	queue, err := h.reviews.AssignedTalks(ctx, userEmail(ctx), slug, includeReviewed)
+	errorMessage := ""
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to list assigned talks", "slug", slug, "error", err)
+		errorMessage = "Failed to list assigned talks: " + err.Error()
+	}
+
+	var conflicts *domain.ReviewerConflicts
+	if h.conflicts != nil && queue.Reviewer != "" {
+		registered, err := h.conflicts.GetConflicts(ctx, queue.Reviewer)
+		if err != nil {
+			slog.WarnContext(ctx, "web: failed to load conflicts", "error", err)
+		}
+		conflicts = &registered
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Reviews(conferences, slug, includeReviewed, queue, conflicts, errorMessage).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render reviews page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleSaveConflicts replaces the conflicts of interest of the logged-in committee member with the
+// submitted speaker emails and organizations
+func (h *Handler) HandleSaveConflicts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.conflicts == nil {
+		templates.ResultError("Conflicts of interest are not available").Render(ctx, w)
+		return
+	}
+
+	// Speaker emails may be separated by commas, spaces or newlines, and organizations by commas or newlines
+	speakerEmails := strings.FieldsFunc(r.FormValue("speakerEmails"), func(c rune) bool { return c == ',' || unicode.IsSpace(c) })
+	organizations := strings.FieldsFunc(r.FormValue("organizations"), func(c rune) bool { return c == ',' || c == '\n' })
+
+	if err := h.conflicts.SetConflicts(ctx, userEmail(ctx), speakerEmails, organizations); err != nil {
+		slog.WarnContext(ctx, "web: failed to save conflicts", "error", err)
+		templates.ResultError("Failed to save conflicts: "+err.Error()).Render(ctx, w)
+		return
+	}
+	templates.ResultSuccess("Conflicts saved. Talks are flagged when they are reindexed.").Render(ctx, w)
+}
+
+// HandleRankingCSV serves the committee ranking of the conference parameter as a CSV download, limited
+// to a single track if the track parameter is set
+func (h *Handler) HandleRankingCSV(w http.ResponseWriter, r *http.Request) {
+	rankings, ok := h.ranking(w, r)
+	if !ok {
+		return
+	}
+
+	if track := r.URL.Query().Get("track"); track != "" {
+		rankings = slices.DeleteFunc(rankings, func(ranking domain.TrackRanking) bool {
+			return !strings.EqualFold(ranking.Track, track)
+		})
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", attachment("ranking", "csv"))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if err := app.WriteRankingCSV(w, rankings); err != nil {
+		slog.ErrorContext(r.Context(), "web: failed to write ranking csv", "error", err)
+	}
+}
+
+// HandleRankingXLSX serves the committee ranking of the conference parameter as a workbook download
+// with a worksheet per track
+func (h *Handler) HandleRankingXLSX(w http.ResponseWriter, r *http.Request) {
+	rankings, ok := h.ranking(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", attachment("ranking", "xlsx"))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if err := app.WriteRankingXLSX(w, rankings); err != nil {
+		slog.ErrorContext(r.Context(), "web: failed to write ranking xlsx", "error", err)
+	}
+}
+
+// ranking returns the committee ranking of the conference parameter, writing the error response if it
+// cannot be built
+func (h *Handler) ranking(w http.ResponseWriter, r *http.Request) ([]domain.TrackRanking, bool) {
+	ctx := r.Context()
+
+	if h.reviews == nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	slug := r.URL.Query().Get("conference")
+	if slug == "" {
+		http.Error(w, "Conference is required", http.StatusBadRequest)
+		return nil, false
+	}
+
+	rankings, err := h.reviews.Ranking(ctx, slug)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to build ranking", "conference", slug, "error", err)
+		http.Error(w, "Failed to build ranking", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	slog.InfoContext(ctx, "web: exporting ranking", "conference", slug, "tracks", len(rankings))
+	return rankings, true
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// HandleSeries renders the series page
+func (h *Handler) HandleSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.series == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	entries, err := h.series.ListSeries(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list series", "error", err)
+		http.Error(w, "Failed to load series", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Series(entries).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render series page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleSetSeries stores a series, then re-renders the series list
+func (h *Handler) HandleSetSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.series == nil {
+		templates.ResultError("Series are not available").Render(ctx, w)
+		return
+	}
+
+	id := strings.TrimSpace(r.FormValue("id"))
+	// Talk IDs may be separated by commas, spaces or newlines
+	talkIDs := strings.FieldsFunc(r.FormValue("talkIds"), func(c rune) bool { return c == ',' || unicode.IsSpace(c) })
+
+	message, errorMessage := "", ""
+	if err := h.series.SetSeries(ctx, id, talkIDs, userEmail(ctx)); err != nil {
+		slog.WarnContext(ctx, "web: failed to set series", "seriesID", id, "error", err)
+		errorMessage = "Failed to save series: " + err.Error()
+	} else {
+		message = "Saved series " + id + ". Reindex the conference to update its talks."
+	}
+
+	h.renderSeriesList(w, r, message, errorMessage)
+}
+
+// HandleRemoveSeries removes a stored series, then re-renders the series list
+func (h *Handler) HandleRemoveSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.series == nil {
+		templates.ResultError("Series are not available").Render(ctx, w)
+		return
+	}
+
+	id := r.FormValue("id")
+
+	message, errorMessage := "", ""
+	if err := h.series.RemoveSeries(ctx, id); err != nil {
+		slog.WarnContext(ctx, "web: failed to remove series", "seriesID", id, "error", err)
+		errorMessage = "Failed to remove series: " + err.Error()
+	} else {
+		message = "Removed series " + id + ". Reindex the conference to update its talks."
+	}
+
+	h.renderSeriesList(w, r, message, errorMessage)
+}
+
+// renderSeriesList renders the current series list fragment with a result message
+func (h *Handler) renderSeriesList(w http.ResponseWriter, r *http.Request, message, errorMessage string) {
+	ctx := r.Context()
+
+	entries, err := h.series.ListSeries(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list series", "error", err)
+		templates.ResultError("Failed to load series").Render(ctx, w)
+		return
+	}
+
+	templates.SeriesList(entries, message, errorMessage).Render(ctx, w)
+}
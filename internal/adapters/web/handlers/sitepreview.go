@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetSitePreviews enables previewing public documents the way the website renders them
+func (h *Handler) SetSitePreviews(sitePreviews ports.SitePreviews) {
+	h.sitePreviews = sitePreviews
+}
+
+// HandleSitePreview renders the site preview page, checking every talk of the conference parameter
+// that will be published if one is selected
+func (h *Handler) HandleSitePreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.sitePreviews == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	conferences, err := h.getConferences(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get conferences", "error", err)
+		http.Error(w, "Failed to load conferences", http.StatusInternalServerError)
+		return
+	}
+
+	slug := r.URL.Query().Get("conference")
+	var report *domain.SitePreviewReport
+	errorMessage := ""
+	if slug != "" {
+		result, err := h.sitePreviews.PreviewConference(ctx, slug)
+		switch {
+		case errors.Is(err, domain.ErrConferenceNotFound):
+			errorMessage = "Unknown conference: " + slug
+		case err != nil:
+			slog.ErrorContext(ctx, "web: failed to preview conference", "slug", slug, "error", err)
+			errorMessage = "Failed to preview conference: " + err.Error()
+		default:
+			report = &result
+		}
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.SitePreview(conferences, slug, report, errorMessage).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render site preview page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleSitePreviewTalk renders a single talk of the talkId parameter the way the website shows it
+func (h *Handler) HandleSitePreviewTalk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.sitePreviews == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	talkID := r.URL.Query().Get("talkId")
+	if talkID == "" {
+		http.Redirect(w, r, "/admin/site-preview", http.StatusSeeOther)
+		return
+	}
+
+	preview, err := h.sitePreviews.PreviewTalk(ctx, talkID)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to preview talk", "talkID", talkID, "error", err)
+		http.Error(w, "Failed to preview talk", http.StatusBadGateway)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.SitePreviewTalk(preview).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render site preview of talk", "talkID", talkID, "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetTalkDiffs enables comparing a talk in moresleep with its indexed documents
+func (h *Handler) SetTalkDiffs(talkDiffs ports.TalkDiffs) {
+	h.talkDiffs = talkDiffs
+}
+
+// HandleTalkDiff renders the talk diff page, comparing the talk of the talkId parameter in moresleep
+// with its documents in both indexes if one is given
+func (h *Handler) HandleTalkDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.talkDiffs == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	talkID := r.URL.Query().Get("talkId")
+	var diff *domain.TalkDiff
+	errorMessage := ""
+	if talkID != "" {
+		result, err := h.talkDiffs.DiffTalk(ctx, talkID)
+		if err != nil {
+			slog.ErrorContext(ctx, "web: failed to compare talk with indexes", "talkID", talkID, "error", err)
+			errorMessage = "Failed to compare talk: " + err.Error()
+		} else {
+			diff = &result
+		}
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.TalkDiff(talkID, diff, errorMessage).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render talk diff page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
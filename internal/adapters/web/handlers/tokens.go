@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// SetAPITokens enables admins to manage their own API tokens
+func (h *Handler) SetAPITokens(tokens ports.APITokens) {
+	h.apiTokens = tokens
+}
+
+// HandleAPITokens renders the API tokens of the logged-in admin
+func (h *Handler) HandleAPITokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.apiTokens == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	tokens, err := h.apiTokens.ListTokens(ctx, userEmail(ctx))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list API tokens", "error", err)
+		http.Error(w, "Failed to load API tokens", http.StatusInternalServerError)
+		return
+	}
+	allTokens, err := h.apiTokens.ListAllTokens(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list API tokens", "error", err)
+		http.Error(w, "Failed to load API tokens", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.APITokens(tokens, allTokens).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render API tokens page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleCreateAPIToken creates a token and re-renders the token list with the new token
+func (h *Handler) HandleCreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.apiTokens == nil {
+		templates.ResultError("API tokens are not available").Render(ctx, w)
+		return
+	}
+
+	var issued *templates.IssuedAPIToken
+	errorMessage := ""
+	token, secret, err := h.apiTokens.CreateToken(ctx, userEmail(ctx), r.FormValue("label"))
+	if err != nil {
+		slog.WarnContext(ctx, "web: failed to create API token", "error", err)
+		errorMessage = "Failed to create token: " + err.Error()
+	} else {
+		issued = &templates.IssuedAPIToken{Label: token.Label, Secret: secret}
+	}
+
+	h.renderAPITokens(w, r, issued, errorMessage)
+}
+
+// HandleRotateAPIToken replaces a token and re-renders the token list with the new token
+func (h *Handler) HandleRotateAPIToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.apiTokens == nil {
+		templates.ResultError("API tokens are not available").Render(ctx, w)
+		return
+	}
+
+	var issued *templates.IssuedAPIToken
+	errorMessage := ""
+	token, secret, err := h.apiTokens.RotateToken(ctx, userEmail(ctx), r.PathValue("id"))
+	if err != nil {
+		slog.WarnContext(ctx, "web: failed to rotate API token", "id", r.PathValue("id"), "error", err)
+		errorMessage = "Failed to rotate token: " + err.Error()
+	} else {
+		issued = &templates.IssuedAPIToken{Label: token.Label, Secret: secret}
+	}
+
+	h.renderAPITokens(w, r, issued, errorMessage)
+}
+
+// HandleRevokeAPIToken deletes a token and re-renders the token list
+func (h *Handler) HandleRevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.apiTokens == nil {
+		templates.ResultError("API tokens are not available").Render(ctx, w)
+		return
+	}
+
+	errorMessage := ""
+	if err := h.apiTokens.RevokeToken(ctx, userEmail(ctx), r.PathValue("id")); err != nil && !errors.Is(err, domain.ErrAPITokenNotFound) {
+		slog.WarnContext(ctx, "web: failed to revoke API token", "id", r.PathValue("id"), "error", err)
+		errorMessage = "Failed to revoke token: " + err.Error()
+	}
+
+	h.renderAPITokens(w, r, nil, errorMessage)
+}
+
+// HandleRevokeAnyAPIToken deletes a token of any admin and re-renders the list of all tokens
+func (h *Handler) HandleRevokeAnyAPIToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.apiTokens == nil {
+		templates.ResultError("API tokens are not available").Render(ctx, w)
+		return
+	}
+
+	errorMessage := ""
+	if err := h.apiTokens.RevokeAnyToken(ctx, r.PathValue("id"), userEmail(ctx)); err != nil && !errors.Is(err, domain.ErrAPITokenNotFound) {
+		slog.WarnContext(ctx, "web: failed to revoke API token", "id", r.PathValue("id"), "error", err)
+		errorMessage = "Failed to revoke token: " + err.Error()
+	}
+
+	tokens, err := h.apiTokens.ListAllTokens(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list API tokens", "error", err)
+		templates.ResultError("Failed to load API tokens").Render(ctx, w)
+		return
+	}
+
+	templates.AllAPITokenList(tokens, errorMessage).Render(ctx, w)
+}
+
+// renderAPITokens renders the current token list fragment
+func (h *Handler) renderAPITokens(w http.ResponseWriter, r *http.Request, issued *templates.IssuedAPIToken, errorMessage string) {
+	ctx := r.Context()
+
+	tokens, err := h.apiTokens.ListTokens(ctx, userEmail(ctx))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list API tokens", "error", err)
+		templates.ResultError("Failed to load API tokens").Render(ctx, w)
+		return
+	}
+
+	templates.APITokenList(tokens, issued, errorMessage).Render(ctx, w)
+}
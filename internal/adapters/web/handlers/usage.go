@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// defaultUsageDays is how many days the API usage page covers without a days parameter
+const defaultUsageDays = 7
+
+// HandleUsage renders the API requests per day, route and client of the last days, given by the days
+// parameter
+func (h *Handler) HandleUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.usage == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	days := defaultUsageDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			http.Error(w, "days must be a positive number", http.StatusBadRequest)
+			return
+		}
+		days = parsed
+	}
+
+	report, err := h.usage.UsageReport(ctx, days)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: failed to build API usage report", "error", err)
+		http.Error(w, "Failed to load API usage", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Usage(days, report).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render API usage page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
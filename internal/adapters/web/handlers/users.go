@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// HandleUsers renders the allowlist and role assignments
+func (h *Handler) HandleUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.users == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	users, err := h.users.ListUsers(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list users", "error", err)
+		http.Error(w, "Failed to load users", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Users(users).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render users page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleSetUserRole adds a user to the allowlist or changes their role, then re-renders the user list
+func (h *Handler) HandleSetUserRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.users == nil {
+		templates.ResultError("User management is not available").Render(ctx, w)
+		return
+	}
+
+	email := r.FormValue("email")
+	role := domain.Role(r.FormValue("role"))
+
+	message, errorMessage := "", ""
+	if err := h.users.SetUserRole(ctx, email, role, userEmail(ctx)); err != nil {
+		slog.WarnContext(ctx, "web: failed to set user role", "email", email, "role", role, "error", err)
+		errorMessage = "Failed to save user: " + err.Error()
+	} else {
+		message = "Saved " + email + " as " + string(role)
+	}
+
+	h.renderUserList(w, r, message, errorMessage)
+}
+
+// HandleRemoveUser removes a user from the allowlist, then re-renders the user list
+func (h *Handler) HandleRemoveUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.users == nil {
+		templates.ResultError("User management is not available").Render(ctx, w)
+		return
+	}
+
+	email := r.FormValue("email")
+
+	message, errorMessage := "", ""
+	if err := h.users.RemoveUser(ctx, email); err != nil {
+		slog.WarnContext(ctx, "web: failed to remove user", "email", email, "error", err)
+		errorMessage = "Failed to remove user: " + err.Error()
+	} else {
+		message = "Removed " + email
+	}
+
+	h.renderUserList(w, r, message, errorMessage)
+}
+
+// renderUserList renders the current user list fragment with a result message
+func (h *Handler) renderUserList(w http.ResponseWriter, r *http.Request, message, errorMessage string) {
+	ctx := r.Context()
+
+	users, err := h.users.ListUsers(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list users", "error", err)
+		templates.ResultError("Failed to load users").Render(ctx, w)
+		return
+	}
+
+	templates.UserList(users, message, errorMessage).Render(ctx, w)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+)
+
+// HandleVideos renders the missing video report with the pending video proposals
+func (h *Handler) HandleVideos(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.videos == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	missing, err := h.videos.MissingVideos(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list talks without video", "error", err)
+		http.Error(w, "Failed to load talks without video", http.StatusInternalServerError)
+		return
+	}
+
+	proposals, err := h.videos.ListProposals(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list video proposals", "error", err)
+		http.Error(w, "Failed to load video proposals", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Videos(missing, proposals, h.videos.IsConfigured()).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render videos page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleProposeVideos matches the talks without video against the video channel, then re-renders the proposals
+func (h *Handler) HandleProposeVideos(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.videos == nil {
+		templates.ResultError("Video backfill is not available").Render(ctx, w)
+		return
+	}
+
+	slog.InfoContext(ctx, "web: starting video backfill")
+
+	message, errorMessage := "", ""
+	if err := h.videos.ProposeVideos(ctx); err != nil {
+		slog.ErrorContext(ctx, "web: failed to propose videos", "error", err)
+		errorMessage = "Failed to find videos: " + err.Error()
+	} else {
+		message = "Matched talks without video against the video channel"
+	}
+
+	h.renderVideoProposalList(w, r, message, errorMessage)
+}
+
+// HandleAcceptVideo patches the proposed video link into the talk, then re-renders the proposals
+func (h *Handler) HandleAcceptVideo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.videos == nil {
+		templates.ResultError("Video backfill is not available").Render(ctx, w)
+		return
+	}
+
+	talkID := r.FormValue("talkId")
+
+	message, errorMessage := "", ""
+	if err := h.videos.AcceptProposal(ctx, talkID); err != nil {
+		slog.WarnContext(ctx, "web: failed to accept video proposal", "talkID", talkID, "error", err)
+		errorMessage = "Failed to accept video: " + err.Error()
+	} else {
+		message = "Added video link to talk " + talkID
+	}
+
+	h.renderVideoProposalList(w, r, message, errorMessage)
+}
+
+// HandleRejectVideo rejects the proposed video for the talk, then re-renders the proposals
+func (h *Handler) HandleRejectVideo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.videos == nil {
+		templates.ResultError("Video backfill is not available").Render(ctx, w)
+		return
+	}
+
+	talkID := r.FormValue("talkId")
+
+	message, errorMessage := "", ""
+	if err := h.videos.RejectProposal(ctx, talkID); err != nil {
+		slog.WarnContext(ctx, "web: failed to reject video proposal", "talkID", talkID, "error", err)
+		errorMessage = "Failed to reject video: " + err.Error()
+	} else {
+		message = "Rejected video for talk " + talkID
+	}
+
+	h.renderVideoProposalList(w, r, message, errorMessage)
+}
+
+// renderVideoProposalList renders the current proposal list fragment with a result message
+func (h *Handler) renderVideoProposalList(w http.ResponseWriter, r *http.Request, message, errorMessage string) {
+	ctx := r.Context()
+
+	proposals, err := h.videos.ListProposals(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list video proposals", "error", err)
+		templates.ResultError("Failed to load video proposals").Render(ctx, w)
+		return
+	}
+
+	templates.VideoProposalList(proposals, message, errorMessage).Render(ctx, w)
+}
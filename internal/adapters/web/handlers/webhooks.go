@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// webhookDeliveryLogLimit is the number of recent deliveries shown on the webhooks page
+const webhookDeliveryLogLimit = 50
+
+// HandleWebhooks renders the outbound webhook subscriptions and the delivery log
+func (h *Handler) HandleWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.webhooks == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	subscriptions, err := h.webhooks.ListSubscriptions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list webhook subscriptions", "error", err)
+		http.Error(w, "Failed to load webhook subscriptions", http.StatusInternalServerError)
+		return
+	}
+
+	ctx = templates.WithPreferences(ctx, h.loadPreferences(ctx))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.Webhooks(subscriptions, h.webhooks.Deliveries(webhookDeliveryLogLimit)).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render webhooks page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleCreateWebhook creates a subscription and re-renders the subscription list with its secret
+func (h *Handler) HandleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.webhooks == nil {
+		templates.ResultError("Webhooks are not available").Render(ctx, w)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		templates.ResultError("Invalid form submission").Render(ctx, w)
+		return
+	}
+
+	var events []domain.EventType
+	for _, event := range r.Form["events"] {
+		events = append(events, domain.EventType(event))
+	}
+
+	var created *domain.WebhookSubscription
+	errorMessage := ""
+	sub, err := h.webhooks.CreateSubscription(ctx, r.FormValue("url"), events, r.FormValue("description"))
+	if err != nil {
+		slog.WarnContext(ctx, "web: failed to create webhook subscription", "error", err)
+		errorMessage = "Failed to create subscription: " + err.Error()
+	} else {
+		created = &sub
+	}
+
+	h.renderWebhookSubscriptions(w, r, created, errorMessage)
+}
+
+// HandleDeleteWebhook deletes a subscription and re-renders the subscription list
+func (h *Handler) HandleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.webhooks == nil {
+		templates.ResultError("Webhooks are not available").Render(ctx, w)
+		return
+	}
+
+	errorMessage := ""
+	if err := h.webhooks.DeleteSubscription(ctx, r.PathValue("id")); err != nil && !errors.Is(err, domain.ErrSubscriptionNotFound) {
+		slog.WarnContext(ctx, "web: failed to delete webhook subscription", "id", r.PathValue("id"), "error", err)
+		errorMessage = "Failed to delete subscription: " + err.Error()
+	}
+
+	h.renderWebhookSubscriptions(w, r, nil, errorMessage)
+}
+
+// renderWebhookSubscriptions renders the current subscription list fragment
+func (h *Handler) renderWebhookSubscriptions(w http.ResponseWriter, r *http.Request, created *domain.WebhookSubscription, errorMessage string) {
+	ctx := r.Context()
+
+	subscriptions, err := h.webhooks.ListSubscriptions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list webhook subscriptions", "error", err)
+		templates.ResultError("Failed to load webhook subscriptions").Render(ctx, w)
+		return
+	}
+
+	templates.WebhookSubscriptions(subscriptions, created, errorMessage).Render(ctx, w)
+}
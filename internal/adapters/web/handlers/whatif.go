@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/web/templates"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// HandleWhatIf renders the what-if index builder, with the form set to the live settings
+func (h *Handler) HandleWhatIf(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.whatIf == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	conferences, err := h.getConferences(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to fetch conferences", "error", err)
+		http.Error(w, "Failed to load conferences", http.StatusInternalServerError)
+		return
+	}
+
+	prefs := h.loadPreferences(ctx)
+	ctx = templates.WithPreferences(ctx, prefs)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.WhatIf(conferences, h.whatIf.WhatIfDefaults(), prefs.DefaultConference).Render(ctx, w); err != nil {
+		slog.ErrorContext(ctx, "failed to render what-if page", "error", err)
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleBuildWhatIf builds scratch indexes for a conference with the submitted settings
+func (h *Handler) HandleBuildWhatIf(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if h.whatIf == nil {
+		templates.ResultError("What-if indexes are not available").Render(ctx, w)
+		return
+	}
+
+	slug := r.FormValue("slug")
+	if slug == "" {
+		templates.ResultError("Please select a conference").Render(ctx, w)
+		return
+	}
+
+	settings := domain.WhatIfSettings{
+		AbstractHTML:       r.FormValue("abstractHtml") == "true",
+		ScrubPublic:        r.FormValue("scrubPublic") == "true",
+		ScrubFields:        splitList(r.FormValue("scrubFields")),
+		ScrubSpeakerFields: splitList(r.FormValue("scrubSpeakerFields")),
+		ScrubWords:         splitList(r.FormValue("scrubWords")),
+		Analyzer:           r.FormValue("analyzer"),
+	}
+
+	slog.InfoContext(ctx, "web: building what-if indexes", "slug", slug)
+
+	result, err := h.whatIf.BuildWhatIf(ctx, slug, settings)
+	if err != nil {
+		slog.ErrorContext(ctx, "web: what-if build failed", "slug", slug, "error", err)
+		templates.ResultError("What-if build failed: "+err.Error()).Render(ctx, w)
+		return
+	}
+
+	templates.WhatIfResult(result).Render(ctx, w)
+}
+
+// splitList splits a comma-separated form value, dropping empty entries
+func splitList(value string) []string {
+	var values []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			values = append(values, entry)
+		}
+	}
+	return values
+}
@@ -0,0 +1,467 @@
+package i18n
+
+// english is the default bundle; every key used by the templates must be present here
+var english = map[string]string{
+	"common.back":             "Back to dashboard",
+	"common.actions":          "Actions",
+	"common.by":               "by %s",
+	"common.conference":       "Conference",
+	"common.created":          "Created",
+	"common.delete":           "Delete",
+	"common.index":            "Index",
+	"common.indexesPage":      "Manage indexes",
+	"common.remove":           "Remove",
+	"common.save":             "Save",
+	"common.selectConference": "Select a conference...",
+	"common.status":           "Status",
+	"common.talk":             "Talk",
+	"common.updated":          "Updated",
+	"common.url":              "URL",
+
+	"language.en": "English",
+	"language.nb": "Norsk (bokmål)",
+
+	"layout.readOnly":       "Read-only mode: Elasticsearch is under maintenance. Reports and searches work, but reindexing and other changes are disabled.",
+	"layout.logout":         "Log out",
+	"layout.sessionExpires": "Your session expires soon.",
+	"layout.sessionLogin":   "Log in again in a new tab",
+	"layout.sessionKeep":    "to keep working without losing unsaved input.",
+	"layout.skipToContent":  "Skip to main content",
+
+	"login.title":   "Log in - Talks Indexer",
+	"login.heading": "Log in",
+	"login.expired": "Your session has expired. Log in again to continue.",
+	"login.help":    "The admin dashboard requires you to log in with your javaBin account.",
+	"login.button":  "Log in",
+
+	"dashboard.title":                   "Talks Indexer Admin",
+	"dashboard.indexes":                 "Indexes",
+	"dashboard.privateIndex":            "Private index:",
+	"dashboard.publicIndex":             "Public index:",
+	"dashboard.lastReindex":             "%s was last reindexed %s (%d hours ago) by %s, writing %d talks.",
+	"dashboard.reindexStale":            "The index is stale; check that reindexes are running and moresleep is reachable.",
+	"dashboard.reindexAll":              "Reindex All Conferences",
+	"dashboard.reindexAllHelp":          "Reindex all talks from all conferences. This will recreate both indexes.",
+	"dashboard.reindexAllButton":        "Reindex All",
+	"dashboard.forceShrink":             "Replace the live index even if it loses more public talks than allowed",
+	"dashboard.reindexingAll":           "Starting full reindex...",
+	"dashboard.reindexConference":       "Reindex Single Conference",
+	"dashboard.reindexConferenceHelp":   "Select a conference to reindex only its talks.",
+	"dashboard.reindexConferenceButton": "Reindex Conference",
+	"dashboard.reindexingConference":    "Starting conference reindex...",
+	"dashboard.reindexConferenceIDHelp": "Several conferences can share a slug. To reindex one of them, enter its moresleep conference ID instead.",
+	"dashboard.conferenceIdPlaceholder": "Conference ID...",
+	"dashboard.conferenceId":            "Conference ID",
+	"dashboard.reindexTalk":             "Reindex Single Talk",
+	"dashboard.reindexTalkHelp":         "Enter a talk ID to reindex that specific talk, to delete a talk withdrawn in moresleep from both indexes, or to create a preview link its speakers can open without logging in.",
+	"dashboard.talkIdPlaceholder":       "Enter talk ID...",
+	"dashboard.talkId":                  "Talk ID",
+	"dashboard.reindexTalkButton":       "Reindex Talk",
+	"dashboard.deleteTalkButton":        "Delete Talk",
+	"dashboard.previewLinkButton":       "Preview Link",
+	"dashboard.confirmDeleteTalk":       "Delete this talk from both indexes? It is indexed again if it is reindexed while it still exists in moresleep.",
+	"dashboard.reindexingTalk":          "Reindexing talk...",
+	"dashboard.reindexProgress":         "Reindex progress",
+	"dashboard.reindexProgressHelp":     "Live progress of the reindexes running on this instance, including those started by others or the scheduler.",
+	"dashboard.preferences":             "Preferences",
+	"dashboard.preferencesHelp":         "Your preferences are remembered across visits. Reindexing a conference makes it your default.",
+	"dashboard.noDefaultConference":     "No default conference",
+	"dashboard.defaultConference":       "Default conference",
+	"dashboard.pageSize":                "Page size",
+	"dashboard.theme":                   "Theme",
+	"dashboard.language":                "Language",
+	"dashboard.perPage":                 "%d per page",
+	"dashboard.themeSystem":             "System theme",
+	"dashboard.themeLight":              "Light theme",
+	"dashboard.themeDark":               "Dark theme",
+	"dashboard.savePreferences":         "Save Preferences",
+	"dashboard.administration":          "Administration",
+	"dashboard.administrationHelp":      "Manage who can access the admin UI, republish both indexes as a new generation, try alternative transformation settings on one conference in what-if indexes, maintain index generations and aliases, set the conference metadata added to indexed talks, inspect and retry documents that failed indexing, and notify external systems when a reindex completes or a talk is published.",
+	"dashboard.manageUsers":             "Manage Users",
+	"dashboard.fullRepublish":           "Full Republish",
+	"dashboard.whatIfIndexes":           "What-if Indexes",
+	"dashboard.manageIndexes":           "Manage Indexes",
+	"dashboard.conferenceMetadata":      "Conference Metadata",
+	"dashboard.series":                  "Series",
+	"dashboard.notice":                  "Notice Banner",
+	"dashboard.deadLetters":             "Dead Letters",
+	"dashboard.manageWebhooks":          "Manage Webhooks",
+	"dashboard.diagnostics":             "Diagnostics Bundle",
+	"dashboard.apiTokens":               "API Tokens",
+	"dashboard.trends":                  "Talk Count Trends",
+	"dashboard.trendsHelp":              "Daily talk counts per status of the conferences with an open CFP or upcoming conference dates.",
+	"dashboard.trendLatest":             "%s: %d talks",
+	"dashboard.trendSummary":            "Talk counts of %s: %d talks on %s, from %d on %s",
+	"dashboard.keywordsHelp":            "Compare how often keywords occur in the public talks across conference years.",
+	"dashboard.keywordTrends":           "Keyword Trends",
+	"dashboard.usageHelp":               "See which API clients call which routes, and how often, to plan capacity before the conference.",
+	"dashboard.usage":                   "API Usage",
+	"dashboard.sitePreviewHelp":         "Check that the talks of a conference have every field the website needs, and see them the way the program page shows them, before they are published.",
+	"dashboard.sitePreview":             "Site Preview",
+	"dashboard.talkDiffHelp":            "Compare a talk in moresleep with its documents in the private and public indexes, field by field, to find out why the website shows something other than what was submitted.",
+	"dashboard.talkDiff":                "Talk Diff",
+	"dashboard.reviewsHelp":             "List the talks you are assigned to review in the program committee and have not given feedback on yet.",
+	"dashboard.reviews":                 "My Reviews",
+	"dashboard.rankingHelp":             "Download the committee ranking of a conference's submitted talks by review score, with a worksheet per track and the declared conflicts of interest.",
+	"dashboard.rankingXLSX":             "Ranking (XLSX)",
+	"dashboard.rankingCSV":              "Ranking (CSV)",
+	"dashboard.reports":                 "Reports",
+	"dashboard.statisticsHelp":          "Download aggregated per-conference statistics (status, format, gender, acceptance rate and keywords) from the private index.",
+	"dashboard.statisticsCSV":           "Statistics (CSV)",
+	"dashboard.statisticsJSON":          "Statistics (JSON)",
+	"dashboard.anonymizedHelp":          "Download the anonymized research dataset. Speaker identity and private fields are removed according to the settings:",
+	"dashboard.anonymizedDataset":       "Anonymized dataset (NDJSON)",
+	"dashboard.speakerContactsHelp":     "Download the names and contact emails of the speakers of a conference's approved talks from the private index.",
+	"dashboard.speakerContacts":         "Speaker Emails (CSV)",
+	"dashboard.videosHelp":              "List published talks from past conferences without a video link and match them against the conference video channel.",
+	"dashboard.talksWithoutVideo":       "Talks Without Video",
+	"dashboard.linksHelp":               "Review video links, speaker pictures and links in abstracts that no longer resolve.",
+	"dashboard.brokenLinks":             "Broken Links",
+
+	"users.title":         "Users - Talks Indexer Admin",
+	"users.addOrChange":   "Add or Change User",
+	"users.help":          "Viewers can see the dashboard and download reports, operators can also reindex, and admins can also manage users and webhooks. Changes apply immediately, including to users who are already logged in.",
+	"users.save":          "Save User",
+	"users.allowed":       "Allowed Users",
+	"users.empty":         "The allowlist is empty, so every authenticated user is an admin. Add yourself as an admin to restrict access.",
+	"users.email":         "Email",
+	"users.role":          "Role",
+	"users.fromConfig":    "From configuration",
+	"users.confirmRemove": "Remove %s from the allowlist?",
+	"users.roleOf":        "Role of %s",
+	"users.saveRoleOf":    "Save role of %s",
+	"users.removeLabel":   "Remove %s",
+
+	"indexes.title":             "Indexes - Talks Indexer Admin",
+	"indexes.heading":           "Indexes",
+	"indexes.help":              "Indexes matching the configured prefix. Indexes the indexer uses, directly or through an alias, cannot be deleted.",
+	"indexes.pointAlias":        "Point Alias",
+	"indexes.pointAliasHelp":    "Move an alias to another index in one atomic step. Readers of the alias switch to the selected index immediately.",
+	"indexes.confirmPointAlias": "Point the alias to the selected index? Readers switch immediately.",
+	"indexes.aliasPlaceholder":  "Alias name",
+	"indexes.empty":             "No indexes found.",
+	"indexes.aliases":           "Aliases",
+	"indexes.documents":         "Documents",
+	"indexes.size":              "Size",
+	"indexes.health":            "Health",
+	"indexes.inUse":             "In use",
+	"indexes.confirmDelete":     "Delete index %s with %d documents? This cannot be undone.",
+	"indexes.deleteLabel":       "Delete index %s",
+
+	"conferences.title":                "Conference Metadata - Talks Indexer Admin",
+	"conferences.set":                  "Set Conference Metadata",
+	"conferences.help":                 "The metadata is added to every talk of the conference as the conference field and listed by /api/conferences. Saving replaces all metadata of the conference, including an entry from the metadata file. Reindex the conference for its talks to pick up the change.",
+	"conferences.slugPlaceholder":      "Conference slug, e.g. javazone2025",
+	"conferences.venue":                "Venue",
+	"conferences.logoPlaceholder":      "Logo URL (https://...)",
+	"conferences.startDatePlaceholder": "Start date (YYYY-MM-DD)",
+	"conferences.endDatePlaceholder":   "End date (YYYY-MM-DD)",
+	"conferences.cfpOpensPlaceholder":  "CFP opens (YYYY-MM-DD)",
+	"conferences.cfpClosesPlaceholder": "CFP closes (YYYY-MM-DD)",
+	"conferences.save":                 "Save Metadata",
+	"conferences.heading":              "Conferences",
+	"conferences.empty":                "No conference metadata is configured.",
+	"conferences.dates":                "Dates",
+	"conferences.cfp":                  "CFP",
+	"conferences.logo":                 "Logo",
+	"conferences.fromFile":             "From metadata file",
+	"conferences.until":                "until %s",
+	"conferences.confirmRemove":        "Remove the metadata of %s?",
+	"conferences.logoOf":               "Logo of %s",
+	"conferences.removeLabel":          "Remove the metadata of %s",
+
+	"series.title":              "Series - Talks Indexer Admin",
+	"series.set":                "Set Series",
+	"series.help":               "A series links related sessions, such as the parts of a workshop or a group of lightning talks. Its talks get the series ID as seriesId and their position as seriesPart, and /api/search?series= lists them. Saving replaces the series, including a series from the series file. Reindex the conference for its talks to pick up the change.",
+	"series.idPlaceholder":      "Series ID, e.g. kotlin-workshop-2025",
+	"series.talkIdsPlaceholder": "Talk IDs in order, separated by commas",
+	"series.save":               "Save Series",
+	"series.heading":            "Series",
+	"series.empty":              "No series are configured.",
+	"series.id":                 "Series ID",
+	"series.talks":              "Talks",
+	"series.fromFile":           "From series file",
+	"series.confirmRemove":      "Remove the series %s?",
+	"series.removeLabel":        "Remove the series %s",
+
+	"notice.title":              "Notice - Talks Indexer Admin",
+	"notice.set":                "Set Notice",
+	"notice.help":               "The notice is shown on every admin page and returned in the X-Notice header of every API response, for example to tell other committee members about an ongoing migration. Saving replaces the current notice.",
+	"notice.messagePlaceholder": "Message, e.g. Migrating to a new cluster tonight",
+	"notice.severity":           "Severity",
+	"notice.severity.info":      "Info",
+	"notice.severity.warning":   "Warning",
+	"notice.severity.critical":  "Critical",
+	"notice.expiresIn":          "Expires",
+	"notice.expiry.":            "Until cleared",
+	"notice.expiry.1h":          "In 1 hour",
+	"notice.expiry.4h":          "In 4 hours",
+	"notice.expiry.24h":         "In 1 day",
+	"notice.expiry.72h":         "In 3 days",
+	"notice.expiry.168h":        "In 1 week",
+	"notice.heading":            "Current Notice",
+	"notice.empty":              "No notice is set.",
+	"notice.message":            "Message",
+	"notice.expires":            "Expires",
+	"notice.noExpiry":           "Until cleared",
+	"notice.clear":              "Clear",
+	"notice.clearLabel":         "Clear the notice",
+	"notice.confirmClear":       "Clear the notice for all users?",
+
+	"deadLetters.title":          "Dead Letters - Talks Indexer Admin",
+	"deadLetters.heading":        "Dead Letters",
+	"deadLetters.help":           "Documents that Elasticsearch refused to index, even after retries, are kept here with the payload that was sent and the last error. Retry indexes the stored payload into the same index again; a newer indexed version of the talk is kept. Discard removes the dead letter without indexing it, for example after the talk has been fixed in moresleep and reindexed.",
+	"deadLetters.empty":          "No documents have failed indexing.",
+	"deadLetters.error":          "Error",
+	"deadLetters.failures":       "Failures",
+	"deadLetters.lastFailure":    "Last failure",
+	"deadLetters.payload":        "Payload",
+	"deadLetters.retry":          "Retry",
+	"deadLetters.discard":        "Discard",
+	"deadLetters.confirmDiscard": "Discard the dead letter of %s in %s?",
+	"deadLetters.retryLabel":     "Retry %s in %s",
+	"deadLetters.discardLabel":   "Discard %s in %s",
+
+	"keywords.title":       "Keyword Trends - Talks Indexer Admin",
+	"keywords.heading":     "Keyword Trends",
+	"keywords.help":        "Share of public talks per conference year carrying each keyword. Keywords are compared case-insensitively, with the synonyms in KEYWORDS_SYNONYMS counted as one keyword. Leave the field empty to compare the most frequent keywords.",
+	"keywords.placeholder": "Comma-separated keywords, e.g. kotlin,java",
+	"keywords.compare":     "Compare",
+	"keywords.empty":       "No public talks carry these keywords.",
+	"keywords.chart":       "Share of talks with the keywords %s from %d to %d",
+	"keywords.year":        "Year",
+	"keywords.talks":       "Talks",
+
+	"usage.title":         "API Usage - Talks Indexer Admin",
+	"usage.heading":       "API Usage",
+	"usage.help":          "Requests to the API routes per day, client and route. Clients are named by their API token; requests without a valid token are counted as anonymous. Usage is stored every USAGE_FLUSH_INTERVAL and kept for USAGE_DAYS days.",
+	"usage.period":        "Period",
+	"usage.days":          "Last %d days",
+	"usage.show":          "Show",
+	"usage.empty":         "No API requests recorded in this period.",
+	"usage.chart":         "API requests per day since %s",
+	"usage.date":          "Date",
+	"usage.requests":      "Requests",
+	"usage.errors":        "Errors (5xx)",
+	"usage.peakHour":      "Busiest hour",
+	"usage.byClient":      "By client",
+	"usage.byRoute":       "By route",
+	"usage.byClientRoute": "By client and route",
+	"usage.client":        "Client",
+	"usage.route":         "Route",
+	"usage.latency":       "Mean latency",
+
+	"links.title":    "Links - Talks Indexer Admin",
+	"links.heading":  "Broken Links",
+	"links.help":     "Video links, speaker pictures and links in abstracts in the public index. Broken links (404, 410 or an unknown host) are gone; unreachable links failed in a way that may be temporary.",
+	"links.check":    "Check Links Now",
+	"links.checking": "Checking links...",
+	"links.empty":    "No link check has run yet.",
+	"links.summary":  "Checked %d links in %d talks at %s.",
+	"links.problems": "%d problems found.",
+	"links.field":    "Field",
+	"links.cleared":  "Cleared from public index",
+
+	"videos.title":         "Videos - Talks Indexer Admin",
+	"videos.proposals":     "Video Proposals",
+	"videos.proposalsHelp": "Videos from the conference channel matched to talks by title and speaker. Accepting a proposal adds the link to the talk in the private and public index.",
+	"videos.patchedHelp":   "Patched links are replaced when the indexes are rebuilt or the talk changes in moresleep, so add the link in moresleep as well.",
+	"videos.find":          "Find Videos",
+	"videos.finding":       "Matching talks against the video channel...",
+	"videos.notConfigured": "No video channel is configured. Set these to find videos:",
+	"videos.missing":       "Talks Without Video",
+	"videos.missingCount":  "%d published talks from past conferences have no video link.",
+	"videos.talkTitle":     "Title",
+	"videos.speakers":      "Speakers",
+	"videos.talkId":        "Talk ID",
+	"videos.noProposals":   "No video proposals.",
+	"videos.video":         "Video",
+	"videos.score":         "Score",
+	"videos.accept":        "Accept",
+	"videos.reject":        "Reject",
+	"videos.acceptLabel":   "Accept video for %s",
+	"videos.rejectLabel":   "Reject video for %s",
+
+	"webhooks.title":                  "Webhooks - Talks Indexer Admin",
+	"webhooks.add":                    "Add Subscription",
+	"webhooks.help":                   "Events are posted as JSON and signed with the subscription secret. The secret is shown only once, right after the subscription is created.",
+	"webhooks.descriptionPlaceholder": "Description (optional)",
+	"webhooks.subscriptions":          "Subscriptions",
+	"webhooks.deliveries":             "Recent Deliveries",
+	"webhooks.noDeliveries":           "No deliveries since startup.",
+	"webhooks.time":                   "Time",
+	"webhooks.event":                  "Event",
+	"webhooks.attempts":               "Attempts",
+	"webhooks.lastError":              "Last error",
+	"webhooks.created":                "Subscription created. Store this secret now, it will not be shown again:",
+	"webhooks.empty":                  "No webhook subscriptions.",
+	"webhooks.events":                 "Events",
+	"webhooks.description":            "Description",
+	"webhooks.confirmDelete":          "Delete the subscription for %s?",
+	"webhooks.deleteLabel":            "Delete the subscription for %s",
+
+	"tokens.title":              "API Tokens - Talks Indexer Admin",
+	"tokens.create":             "Create Token",
+	"tokens.help":               "Tokens let your automation, such as CI pipelines, call the reindex and job endpoints as a bearer token. Jobs started with a token are attributed to its label and to you. The token is shown only once, right after it is created or rotated; only a hash of it is stored.",
+	"tokens.label":              "Label",
+	"tokens.labelPlaceholder":   "Label, e.g. deploy pipeline",
+	"tokens.yours":              "Your Tokens",
+	"tokens.issued":             "Token %s issued. Store it now, it will not be shown again:",
+	"tokens.empty":              "You have no API tokens.",
+	"tokens.rotated":            "Rotated",
+	"tokens.lastUsed":           "Last used",
+	"tokens.neverUsed":          "Never",
+	"tokens.rotate":             "Rotate",
+	"tokens.revoke":             "Revoke",
+	"tokens.confirmRotate":      "Replace the token %s? The current token stops working immediately.",
+	"tokens.confirmRevoke":      "Revoke the token %s? It stops working immediately.",
+	"tokens.rotateLabel":        "Rotate the token %s",
+	"tokens.revokeLabel":        "Revoke the token %s",
+	"tokens.all":                "All Tokens",
+	"tokens.allHelp":            "Tokens of every admin. Revoke the tokens of someone who has left; tokens also stop working as soon as their owner is no longer an admin.",
+	"tokens.allEmpty":           "No admin has API tokens.",
+	"tokens.owner":              "Owner",
+	"tokens.confirmRevokeOwned": "Revoke the token %s of %s? It stops working immediately.",
+	"tokens.revokeOwnedLabel":   "Revoke the token %s of %s",
+
+	"preview.title":        "Talk Preview - Talks Indexer",
+	"preview.help":         "This is how the program shows your talk. Contact the program committee to change anything.",
+	"preview.notPublic":    "Your talk is not published. Once it is accepted, the program will show it like this.",
+	"preview.notPublished": "Your talk is accepted but not published yet. The program will show it like this shortly.",
+	"preview.changed":      "Your latest changes are not published yet. Changed fields: %s.",
+	"preview.upToDate":     "The program shows your talk as below.",
+	"preview.speakers":     "Speakers",
+	"preview.fields":       "Details",
+	"preview.field":        "Field",
+	"preview.value":        "Value",
+	"preview.fieldChanged": "(changed)",
+	"preview.expires":      "This preview link expires at %s.",
+	"preview.invalid":      "This preview link is invalid or has expired. Ask the program committee for a new link.",
+	"preview.unavailable":  "The preview could not be loaded right now. Try again later.",
+	"preview.linkCreated":  "Preview link for talk %s created, valid until %s. Copy the link and send it to the speakers:",
+	"preview.openLink":     "Open preview",
+
+	"sitePreview.title":             "Site Preview - Talks Indexer Admin",
+	"sitePreview.heading":           "Site Preview",
+	"sitePreview.help":              "Builds the public documents of the accepted talks from moresleep the way the next reindex does, and checks them against the fields the website needs (SITE_PREVIEW_REQUIRED_FIELDS). With SITE_PREVIEW_URL set, every document is also rendered by the website's preview renderer. Nothing is indexed.",
+	"sitePreview.check":             "Check Conference",
+	"sitePreview.previewTalk":       "Preview Talk",
+	"sitePreview.results":           "Talks of %s",
+	"sitePreview.allReady":          "All %d talks are ready for publication.",
+	"sitePreview.notReady":          "%d of %d talks are not ready for publication.",
+	"sitePreview.rendered":          "Checked and rendered by the website at %s.",
+	"sitePreview.checked":           "Checked at %s. No website preview renderer is configured, so rendering was not tested.",
+	"sitePreview.talk":              "Talk",
+	"sitePreview.problems":          "Problems",
+	"sitePreview.ready":             "Ready",
+	"sitePreview.missing":           "Missing: %s",
+	"sitePreview.renderError":       "Rendering failed: %s",
+	"sitePreview.websiteRendering":  "Rendered by the website",
+	"sitePreview.embeddedRendering": "Embedded rendering",
+	"sitePreview.embeddedHelp":      "No website preview renderer is configured; this approximates the program page from the public document.",
+	"sitePreview.minutes":           "%s min",
+
+	"talkDiff.title":        "Talk Diff - Talks Indexer Admin",
+	"talkDiff.heading":      "Compare Talk with Indexes",
+	"talkDiff.help":         "Builds the documents of the talk from moresleep the way a reindex of the talk does, and compares them field by field with the documents in the private and public indexes. Nothing is indexed.",
+	"talkDiff.compare":      "Compare",
+	"talkDiff.privateIndex": "Private index (%s)",
+	"talkDiff.publicIndex":  "Public index (%s)",
+	"talkDiff.inSync":       "The index is up to date with moresleep.",
+	"talkDiff.notExpected":  "The talk is not written to this index, and the index has no document of it.",
+	"talkDiff.missing":      "The index has no document of the talk, although a reindex would write one.",
+	"talkDiff.unexpected":   "The index still has a document of the talk, although a reindex would not write one, for instance since the talk is no longer approved.",
+	"talkDiff.differs":      "%d fields differ from moresleep. Reindexing the talk updates them.",
+	"talkDiff.field":        "Field",
+	"talkDiff.source":       "moresleep",
+	"talkDiff.indexed":      "Index",
+	"talkDiff.absent":       "not set",
+
+	"reviews.title":                 "My Reviews - Talks Indexer Admin",
+	"reviews.heading":               "Assigned Talks",
+	"reviews.help":                  "Talks are assigned to you with a reviewer tag holding your email in moresleep, and count as reviewed once you have given feedback on them. Assignments are updated when a talk is reindexed.",
+	"reviews.allConferences":        "All conferences",
+	"reviews.includeReviewed":       "Include talks I have reviewed",
+	"reviews.show":                  "Show",
+	"reviews.noEmail":               "Your login has no email, so no talks can be assigned to you.",
+	"reviews.empty":                 "No talks are waiting for your review.",
+	"reviews.emptyAll":              "No talks are assigned to you.",
+	"reviews.showing":               "Showing %d of %d assigned talks.",
+	"reviews.talk":                  "Talk",
+	"reviews.reviewers":             "Reviewers",
+	"reviews.feedback":              "Your feedback",
+	"reviews.given":                 "Given",
+	"reviews.pending":               "Pending",
+	"reviews.conflict":              "Conflict of interest",
+	"reviews.conflicts":             "My Conflicts of Interest",
+	"reviews.conflictsHelp":         "Register the speakers and organizations you have a conflict of interest with, such as colleagues or your employer. Their talks are flagged when they are reindexed, and you are no longer asked for feedback on them. Organizations also match the domain of speaker emails, such as example.com.",
+	"reviews.conflictSpeakers":      "Speaker emails, one per line",
+	"reviews.conflictOrganizations": "Organizations, one per line",
+	"reviews.saveConflicts":         "Save Conflicts",
+
+	"republish.title":                         "Republish - Talks Indexer Admin",
+	"republish.heading":                       "Full Republish",
+	"republish.help":                          "Rebuilds both indexes from moresleep as a new generation and switches readers over to it in one step. Unlike a full reindex, the live indexes stay untouched until the new generation has been checked. The republish runs as a single job and stops at the first failed step:",
+	"republish.step.dry-run diff":             "Dry-run diff",
+	"republish.step.snapshot":                 "Snapshot",
+	"republish.step.build generation":         "Build generation",
+	"republish.step.reconciliation check":     "Reconciliation check",
+	"republish.step.alias swap":               "Alias swap",
+	"republish.step.warm-up":                  "Warm-up",
+	"republish.step.notification":             "Notification",
+	"republish.stepHelp.dry-run diff":         "fetches all talks, compares the public talks per conference with the live public index and stops if the public index would shrink more than allowed.",
+	"republish.stepHelp.snapshot":             "saves the live indexes to the snapshot repository, if one is configured.",
+	"republish.stepHelp.build generation":     "writes all talks to new indexes named after the current time.",
+	"republish.stepHelp.reconciliation check": "verifies that the new indexes hold every talk.",
+	"republish.stepHelp.alias swap":           "points the index names to the new generation. An index created by a full reindex under that name is deleted.",
+	"republish.stepHelp.warm-up":              "queries the new public index and purges the CDN.",
+	"republish.stepHelp.notification":         "sends a republish.completed event to webhook subscribers.",
+	"republish.keepGenerations":               "Previous generations are kept until they are no longer needed for rollback. Delete them on the indexes page:",
+	"republish.dryRun":                        "Dry Run",
+	"republish.confirm":                       "Rebuild both indexes and swap the aliases to the new generation?",
+	"republish.run":                           "Republish",
+	"republish.working":                       "Working...",
+	"republish.planSummary":                   "Dry run: %d talks, %d public (live: %d). No index was changed.",
+	"republish.livePublic":                    "Live public talks",
+	"republish.after":                         "After republish",
+	"republish.changed":                       "changed",
+	"republish.finished":                      "Republish finished",
+	"republish.failed":                        "Republish failed: %s",
+	"republish.stepColumn":                    "Step",
+	"republish.state":                         "State",
+	"republish.details":                       "Details",
+	"republish.notRun":                        "not run",
+
+	"whatIf.title":                         "What-if Indexes - Talks Indexer Admin",
+	"whatIf.heading":                       "Build What-if Indexes",
+	"whatIf.help":                          "Builds a private and a public scratch index from one conference with the settings below, without touching the live indexes, and lists the public talks whose document would change. The form starts with the settings the live indexes are built with. Delete the scratch indexes on the indexes page once evaluated:",
+	"whatIf.abstractHtml":                  "Render abstracts to HTML",
+	"whatIf.scrubPublic":                   "Scrub public free text",
+	"whatIf.scrubFieldsPlaceholder":        "Scrubbed talk fields, comma-separated",
+	"whatIf.scrubSpeakerFieldsPlaceholder": "Scrubbed speaker fields, comma-separated",
+	"whatIf.scrubWordsPlaceholder":         "Blocked words, comma-separated",
+	"whatIf.liveAnalyzer":                  "Live analyzer",
+	"whatIf.analyzerLabel":                 "Analyzer",
+	"whatIf.analyzer":                      "%s analyzer",
+	"whatIf.build":                         "Build",
+	"whatIf.building":                      "Building what-if indexes...",
+	"whatIf.built":                         "Built %s (%d talks) and %s (%d public talks)",
+	"whatIf.changed":                       "Changed public talks",
+	"whatIf.noChanges":                     "No public talk differs from the live public index.",
+	"whatIf.changedCount":                  "%d public talks differ from the live public index, including talks that would be added or removed:",
+	"whatIf.review":                        "Flagged for review",
+	"whatIf.noReview":                      "No talk is flagged for review with these settings.",
+	"whatIf.findings":                      "Findings",
+	"progress.started":                     "Started %s",
+	"progress.fetched":                     "Fetched %d talks of %s (%d of %d conferences)",
+	"progress.fetchFailed":                 "Failed to fetch the talks of %s (%d of %d conferences): %s",
+	"progress.indexed":                     "Wrote %d talks to %s",
+	"progress.finished":                    "Finished %s, %d talks written",
+	"progress.failed":                      "Failed %s: %s",
+	"progress.scope.unknown":               "a reindex",
+	"progress.scope.reindex-all":           "a reindex of all conferences",
+	"progress.scope.reindex-conference":    "a reindex of the conference %s",
+	"progress.scope.reindex-conference-id": "a reindex of the conference with ID %s",
+	"progress.scope.reindex-talk":          "a reindex of the talk %s",
+	"progress.scope.republish":             "a republish as generation %s",
+}
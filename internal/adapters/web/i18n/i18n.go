@@ -0,0 +1,33 @@
+// Package i18n holds the message bundles of the admin UI, one per supported language.
+// Messages are looked up by key, such as "dashboard.reindexAll", and may contain fmt verbs
+// filled in from the arguments of Translate.
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// bundles maps each supported language to its messages
+var bundles = map[string]map[string]string{
+	domain.LanguageEnglish:   english,
+	domain.LanguageNorwegian: norwegian,
+}
+
+// Translate returns the message for key in the given language, formatted with args if any are given.
+// Messages missing from the language fall back to English, and unknown keys are returned as is
+// so they stand out in the UI.
+func Translate(language, key string, args ...interface{}) string {
+	message, ok := bundles[language][key]
+	if !ok {
+		message, ok = english[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,73 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verbPattern matches the fmt verbs of a message
+var verbPattern = regexp.MustCompile(`%[a-z]`)
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Log out", Translate(domain.LanguageEnglish, "layout.logout"))
+	assert.Equal(t, "Logg ut", Translate(domain.LanguageNorwegian, "layout.logout"))
+	assert.Equal(t, "50 per side", Translate(domain.LanguageNorwegian, "dashboard.perPage", 50))
+
+	// Unknown languages fall back to English and unknown keys are returned as is
+	assert.Equal(t, "Log out", Translate("sv", "layout.logout"))
+	assert.Equal(t, "missing.key", Translate(domain.LanguageNorwegian, "missing.key"))
+}
+
+func TestBundles_CoverSupportedLanguages(t *testing.T) {
+	for _, language := range domain.Languages {
+		assert.Contains(t, bundles, language)
+		assert.Contains(t, english, "language."+language)
+	}
+}
+
+func TestBundles_MatchEnglish(t *testing.T) {
+	for language, bundle := range bundles {
+		for key, message := range english {
+			translated, ok := bundle[key]
+			if !assert.True(t, ok, "%s is missing %s", language, key) {
+				continue
+			}
+			assert.Equal(t, verbPattern.FindAllString(message, -1), verbPattern.FindAllString(translated, -1),
+				"%s has other verbs than English for %s", language, key)
+		}
+		for key := range bundle {
+			assert.Contains(t, english, key, "%s has key %s not in English", language, key)
+		}
+	}
+}
+
+func TestBundles_CoverTemplateKeys(t *testing.T) {
+	files, err := filepath.Glob("../templates/*.templ")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	// Keys built from domain values, such as the republish steps, are checked by listing them
+	keyPattern := regexp.MustCompile(`t\(ctx, "([^"]+)"`)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		for _, match := range keyPattern.FindAllStringSubmatch(string(content), -1) {
+			key := match[1]
+			if key[len(key)-1] == '.' {
+				continue
+			}
+			assert.Contains(t, english, key, "%s uses unknown key %s", filepath.Base(file), key)
+		}
+	}
+
+	for _, step := range domain.RepublishSteps {
+		assert.Contains(t, english, "republish.step."+step)
+		assert.Contains(t, english, "republish.stepHelp."+step)
+	}
+}
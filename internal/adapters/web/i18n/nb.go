@@ -0,0 +1,467 @@
+package i18n
+
+// norwegian is the Norwegian (bokmål) bundle; missing keys fall back to English
+var norwegian = map[string]string{
+	"common.back":             "Tilbake til oversikten",
+	"common.actions":          "Handlinger",
+	"common.by":               "av %s",
+	"common.conference":       "Konferanse",
+	"common.created":          "Opprettet",
+	"common.delete":           "Slett",
+	"common.index":            "Indeks",
+	"common.indexesPage":      "Administrer indekser",
+	"common.remove":           "Fjern",
+	"common.save":             "Lagre",
+	"common.selectConference": "Velg en konferanse...",
+	"common.status":           "Status",
+	"common.talk":             "Foredrag",
+	"common.updated":          "Oppdatert",
+	"common.url":              "URL",
+
+	"language.en": "English",
+	"language.nb": "Norsk (bokmål)",
+
+	"layout.readOnly":       "Skrivebeskyttet modus: Elasticsearch er under vedlikehold. Rapporter og søk fungerer, men reindeksering og andre endringer er slått av.",
+	"layout.logout":         "Logg ut",
+	"layout.sessionExpires": "Økten din utløper snart.",
+	"layout.sessionLogin":   "Logg inn på nytt i en ny fane",
+	"layout.sessionKeep":    "for å fortsette uten å miste ulagrede endringer.",
+	"layout.skipToContent":  "Hopp til hovedinnholdet",
+
+	"login.title":   "Logg inn - Talks Indexer",
+	"login.heading": "Logg inn",
+	"login.expired": "Økten din har utløpt. Logg inn på nytt for å fortsette.",
+	"login.help":    "Du må logge inn med javaBin-kontoen din for å bruke administrasjonssidene.",
+	"login.button":  "Logg inn",
+
+	"dashboard.title":                   "Talks Indexer Admin",
+	"dashboard.indexes":                 "Indekser",
+	"dashboard.privateIndex":            "Privat indeks:",
+	"dashboard.publicIndex":             "Offentlig indeks:",
+	"dashboard.lastReindex":             "%s ble sist reindeksert %s (for %d timer siden) ved %s, med %d foredrag skrevet.",
+	"dashboard.reindexStale":            "Indeksen er utdatert; sjekk at reindekseringer kjører og at moresleep svarer.",
+	"dashboard.reindexAll":              "Reindekser alle konferanser",
+	"dashboard.reindexAllHelp":          "Reindekser alle foredrag fra alle konferanser. Begge indeksene blir opprettet på nytt.",
+	"dashboard.reindexAllButton":        "Reindekser alt",
+	"dashboard.forceShrink":             "Erstatt den aktive indeksen selv om den mister flere offentlige foredrag enn tillatt",
+	"dashboard.reindexingAll":           "Starter full reindeksering...",
+	"dashboard.reindexConference":       "Reindekser én konferanse",
+	"dashboard.reindexConferenceHelp":   "Velg en konferanse for å reindeksere bare foredragene dens.",
+	"dashboard.reindexConferenceButton": "Reindekser konferanse",
+	"dashboard.reindexingConference":    "Starter reindeksering av konferanse...",
+	"dashboard.reindexConferenceIDHelp": "Flere konferanser kan ha samme slug. For å reindeksere én av dem, skriv inn konferanse-IDen fra moresleep i stedet.",
+	"dashboard.conferenceIdPlaceholder": "Konferanse-ID...",
+	"dashboard.conferenceId":            "Konferanse-ID",
+	"dashboard.reindexTalk":             "Reindekser ett foredrag",
+	"dashboard.reindexTalkHelp":         "Skriv inn en foredrags-ID for å reindeksere akkurat det foredraget, for å slette et foredrag som er trukket i moresleep fra begge indeksene, eller for å lage en lenke til forhåndsvisning som foredragsholderne kan åpne uten å logge inn.",
+	"dashboard.talkIdPlaceholder":       "Foredrags-ID...",
+	"dashboard.talkId":                  "Foredrags-ID",
+	"dashboard.reindexTalkButton":       "Reindekser foredrag",
+	"dashboard.deleteTalkButton":        "Slett foredrag",
+	"dashboard.previewLinkButton":       "Lenke til forhåndsvisning",
+	"dashboard.confirmDeleteTalk":       "Slette dette foredraget fra begge indeksene? Det indekseres igjen hvis det reindekseres mens det fortsatt finnes i moresleep.",
+	"dashboard.reindexingTalk":          "Reindekserer foredrag...",
+	"dashboard.reindexProgress":         "Fremdrift for reindeksering",
+	"dashboard.reindexProgressHelp":     "Fremdriften til reindekseringer som kjører på denne instansen, også de som er startet av andre eller av planleggeren.",
+	"dashboard.preferences":             "Innstillinger",
+	"dashboard.preferencesHelp":         "Innstillingene dine huskes mellom besøk. Når du reindekserer en konferanse, blir den din standardkonferanse.",
+	"dashboard.noDefaultConference":     "Ingen standardkonferanse",
+	"dashboard.defaultConference":       "Standardkonferanse",
+	"dashboard.pageSize":                "Sidestørrelse",
+	"dashboard.theme":                   "Tema",
+	"dashboard.language":                "Språk",
+	"dashboard.perPage":                 "%d per side",
+	"dashboard.themeSystem":             "Systemtema",
+	"dashboard.themeLight":              "Lyst tema",
+	"dashboard.themeDark":               "Mørkt tema",
+	"dashboard.savePreferences":         "Lagre innstillinger",
+	"dashboard.administration":          "Administrasjon",
+	"dashboard.administrationHelp":      "Styr hvem som har tilgang til administrasjonssidene, republiser begge indeksene som en ny generasjon, prøv alternative transformasjonsinnstillinger på én konferanse i what-if-indekser, vedlikehold indeksgenerasjoner og aliaser, angi konferansemetadata som legges til indekserte foredrag, se på og prøv igjen dokumenter som feilet under indeksering, og varsle eksterne systemer når en reindeksering er ferdig eller et foredrag publiseres.",
+	"dashboard.manageUsers":             "Administrer brukere",
+	"dashboard.fullRepublish":           "Full republisering",
+	"dashboard.whatIfIndexes":           "What-if-indekser",
+	"dashboard.manageIndexes":           "Administrer indekser",
+	"dashboard.conferenceMetadata":      "Konferansemetadata",
+	"dashboard.series":                  "Serier",
+	"dashboard.notice":                  "Kunngjøring",
+	"dashboard.deadLetters":             "Feilede dokumenter",
+	"dashboard.manageWebhooks":          "Administrer webhooks",
+	"dashboard.diagnostics":             "Diagnosepakke",
+	"dashboard.apiTokens":               "API-nøkler",
+	"dashboard.trends":                  "Utvikling i antall foredrag",
+	"dashboard.trendsHelp":              "Daglig antall foredrag per status for konferansene med åpen CFP eller kommende konferansedatoer.",
+	"dashboard.trendLatest":             "%s: %d foredrag",
+	"dashboard.trendSummary":            "Antall foredrag i %s: %d foredrag %s, fra %d %s",
+	"dashboard.keywordsHelp":            "Sammenlign hvor ofte nøkkelord forekommer i de offentlige foredragene på tvers av konferanseår.",
+	"dashboard.keywordTrends":           "Nøkkelordtrender",
+	"dashboard.usageHelp":               "Se hvilke API-klienter som kaller hvilke ruter, og hvor ofte, for å planlegge kapasitet før konferansen.",
+	"dashboard.usage":                   "API-bruk",
+	"dashboard.sitePreviewHelp":         "Sjekk at foredragene på en konferanse har alle feltene nettsiden trenger, og se dem slik programsiden viser dem, før de publiseres.",
+	"dashboard.sitePreview":             "Forhåndsvisning av nettsiden",
+	"dashboard.talkDiffHelp":            "Sammenlign et foredrag i moresleep med dokumentene i den private og den offentlige indeksen, felt for felt, for å finne ut hvorfor nettsiden viser noe annet enn det som ble sendt inn.",
+	"dashboard.talkDiff":                "Sammenlign foredrag",
+	"dashboard.reviewsHelp":             "List opp foredragene du er tildelt å vurdere i programkomiteen og ikke har gitt tilbakemelding på ennå.",
+	"dashboard.reviews":                 "Mine vurderinger",
+	"dashboard.rankingHelp":             "Last ned komiteens rangering av en konferanses innsendte foredrag etter vurderingspoeng, med ett regneark per spor og de oppgitte interessekonfliktene.",
+	"dashboard.rankingXLSX":             "Rangering (XLSX)",
+	"dashboard.rankingCSV":              "Rangering (CSV)",
+	"dashboard.reports":                 "Rapporter",
+	"dashboard.statisticsHelp":          "Last ned aggregert statistikk per konferanse (status, format, kjønn, andel godkjente og nøkkelord) fra den private indeksen.",
+	"dashboard.statisticsCSV":           "Statistikk (CSV)",
+	"dashboard.statisticsJSON":          "Statistikk (JSON)",
+	"dashboard.anonymizedHelp":          "Last ned det anonymiserte forskningsdatasettet. Taleridentitet og private felter fjernes i henhold til innstillingene:",
+	"dashboard.anonymizedDataset":       "Anonymisert datasett (NDJSON)",
+	"dashboard.speakerContactsHelp":     "Last ned navn og e-postadresser til foredragsholderne for en konferanses godkjente foredrag fra den private indeksen.",
+	"dashboard.speakerContacts":         "Foredragsholdernes e-post (CSV)",
+	"dashboard.videosHelp":              "Vis publiserte foredrag fra tidligere konferanser uten videolenke, og match dem mot konferansens videokanal.",
+	"dashboard.talksWithoutVideo":       "Foredrag uten video",
+	"dashboard.linksHelp":               "Gå gjennom videolenker, talerbilder og lenker i sammendrag som ikke lenger virker.",
+	"dashboard.brokenLinks":             "Døde lenker",
+
+	"users.title":         "Brukere - Talks Indexer Admin",
+	"users.addOrChange":   "Legg til eller endre bruker",
+	"users.help":          "Lesere kan se oversikten og laste ned rapporter, operatører kan i tillegg reindeksere, og administratorer kan i tillegg administrere brukere og webhooks. Endringer gjelder umiddelbart, også for brukere som allerede er innlogget.",
+	"users.save":          "Lagre bruker",
+	"users.allowed":       "Tillatte brukere",
+	"users.empty":         "Tilgangslisten er tom, så alle innloggede brukere er administratorer. Legg deg selv til som administrator for å begrense tilgangen.",
+	"users.email":         "E-post",
+	"users.role":          "Rolle",
+	"users.fromConfig":    "Fra konfigurasjonen",
+	"users.confirmRemove": "Fjerne %s fra tilgangslisten?",
+	"users.roleOf":        "Rollen til %s",
+	"users.saveRoleOf":    "Lagre rollen til %s",
+	"users.removeLabel":   "Fjern %s",
+
+	"indexes.title":             "Indekser - Talks Indexer Admin",
+	"indexes.heading":           "Indekser",
+	"indexes.help":              "Indekser som matcher det konfigurerte prefikset. Indekser som indekseren bruker, direkte eller via et alias, kan ikke slettes.",
+	"indexes.pointAlias":        "Flytt alias",
+	"indexes.pointAliasHelp":    "Flytt et alias til en annen indeks i ett atomisk steg. De som leser via aliaset, bytter umiddelbart til den valgte indeksen.",
+	"indexes.confirmPointAlias": "Flytte aliaset til den valgte indeksen? Lesere bytter umiddelbart.",
+	"indexes.aliasPlaceholder":  "Aliasnavn",
+	"indexes.empty":             "Fant ingen indekser.",
+	"indexes.aliases":           "Aliaser",
+	"indexes.documents":         "Dokumenter",
+	"indexes.size":              "Størrelse",
+	"indexes.health":            "Helse",
+	"indexes.inUse":             "I bruk",
+	"indexes.confirmDelete":     "Slette indeksen %s med %d dokumenter? Dette kan ikke angres.",
+	"indexes.deleteLabel":       "Slett indeksen %s",
+
+	"conferences.title":                "Konferansemetadata - Talks Indexer Admin",
+	"conferences.set":                  "Angi konferansemetadata",
+	"conferences.help":                 "Metadataene legges til hvert foredrag på konferansen i feltet conference og listes av /api/conferences. Lagring erstatter alle metadata for konferansen, også en oppføring fra metadatafilen. Reindekser konferansen for at foredragene skal få med endringen.",
+	"conferences.slugPlaceholder":      "Konferanse-slug, f.eks. javazone2025",
+	"conferences.venue":                "Sted",
+	"conferences.logoPlaceholder":      "Logo-URL (https://...)",
+	"conferences.startDatePlaceholder": "Startdato (ÅÅÅÅ-MM-DD)",
+	"conferences.endDatePlaceholder":   "Sluttdato (ÅÅÅÅ-MM-DD)",
+	"conferences.cfpOpensPlaceholder":  "CFP åpner (ÅÅÅÅ-MM-DD)",
+	"conferences.cfpClosesPlaceholder": "CFP stenger (ÅÅÅÅ-MM-DD)",
+	"conferences.save":                 "Lagre metadata",
+	"conferences.heading":              "Konferanser",
+	"conferences.empty":                "Ingen konferansemetadata er konfigurert.",
+	"conferences.dates":                "Datoer",
+	"conferences.cfp":                  "CFP",
+	"conferences.logo":                 "Logo",
+	"conferences.fromFile":             "Fra metadatafilen",
+	"conferences.until":                "til %s",
+	"conferences.confirmRemove":        "Fjerne metadataene for %s?",
+	"conferences.logoOf":               "Logoen til %s",
+	"conferences.removeLabel":          "Fjern metadataene for %s",
+
+	"series.title":              "Serier - Talks Indexer Admin",
+	"series.set":                "Angi serie",
+	"series.help":               "En serie knytter sammen relaterte sesjoner, som delene av en workshop eller en gruppe lyntaler. Foredragene får serie-ID-en som seriesId og plasseringen sin som seriesPart, og /api/search?series= lister dem. Lagring erstatter serien, også en serie fra seriefilen. Reindekser konferansen for at foredragene skal få med endringen.",
+	"series.idPlaceholder":      "Serie-ID, f.eks. kotlin-workshop-2025",
+	"series.talkIdsPlaceholder": "Foredrags-ID-er i rekkefølge, skilt med komma",
+	"series.save":               "Lagre serie",
+	"series.heading":            "Serier",
+	"series.empty":              "Ingen serier er konfigurert.",
+	"series.id":                 "Serie-ID",
+	"series.talks":              "Foredrag",
+	"series.fromFile":           "Fra seriefilen",
+	"series.confirmRemove":      "Fjerne serien %s?",
+	"series.removeLabel":        "Fjern serien %s",
+
+	"notice.title":              "Kunngjøring - Talks Indexer Admin",
+	"notice.set":                "Sett kunngjøring",
+	"notice.help":               "Kunngjøringen vises på alle adminsider og returneres i X-Notice-headeren på alle API-svar, for eksempel for å fortelle andre i komiteen om en pågående migrering. Lagring erstatter den nåværende kunngjøringen.",
+	"notice.messagePlaceholder": "Melding, f.eks. Migrerer til nytt cluster i kveld",
+	"notice.severity":           "Alvorlighetsgrad",
+	"notice.severity.info":      "Info",
+	"notice.severity.warning":   "Advarsel",
+	"notice.severity.critical":  "Kritisk",
+	"notice.expiresIn":          "Utløper",
+	"notice.expiry.":            "Til den fjernes",
+	"notice.expiry.1h":          "Om 1 time",
+	"notice.expiry.4h":          "Om 4 timer",
+	"notice.expiry.24h":         "Om 1 dag",
+	"notice.expiry.72h":         "Om 3 dager",
+	"notice.expiry.168h":        "Om 1 uke",
+	"notice.heading":            "Nåværende kunngjøring",
+	"notice.empty":              "Ingen kunngjøring er satt.",
+	"notice.message":            "Melding",
+	"notice.expires":            "Utløper",
+	"notice.noExpiry":           "Til den fjernes",
+	"notice.clear":              "Fjern",
+	"notice.clearLabel":         "Fjern kunngjøringen",
+	"notice.confirmClear":       "Fjerne kunngjøringen for alle brukere?",
+
+	"deadLetters.title":          "Feilede dokumenter - Talks Indexer Admin",
+	"deadLetters.heading":        "Feilede dokumenter",
+	"deadLetters.help":           "Dokumenter som Elasticsearch nektet å indeksere, også etter nye forsøk, lagres her med innholdet som ble sendt og den siste feilen. Prøv igjen indekserer det lagrede innholdet i den samme indeksen på nytt; en nyere indeksert versjon av foredraget beholdes. Forkast fjerner dokumentet uten å indeksere det, for eksempel etter at foredraget er rettet i moresleep og reindeksert.",
+	"deadLetters.empty":          "Ingen dokumenter har feilet under indeksering.",
+	"deadLetters.error":          "Feil",
+	"deadLetters.failures":       "Antall feil",
+	"deadLetters.lastFailure":    "Siste feil",
+	"deadLetters.payload":        "Innhold",
+	"deadLetters.retry":          "Prøv igjen",
+	"deadLetters.discard":        "Forkast",
+	"deadLetters.confirmDiscard": "Forkaste det feilede dokumentet for %s i %s?",
+	"deadLetters.retryLabel":     "Prøv %s i %s igjen",
+	"deadLetters.discardLabel":   "Forkast %s i %s",
+
+	"keywords.title":       "Nøkkelordtrender - Talks Indexer Admin",
+	"keywords.heading":     "Nøkkelordtrender",
+	"keywords.help":        "Andel offentlige foredrag per konferanseår som har hvert nøkkelord. Nøkkelord sammenlignes uten hensyn til store og små bokstaver, og synonymene i KEYWORDS_SYNONYMS telles som ett nøkkelord. La feltet stå tomt for å sammenligne de vanligste nøkkelordene.",
+	"keywords.placeholder": "Kommaseparerte nøkkelord, f.eks. kotlin,java",
+	"keywords.compare":     "Sammenlign",
+	"keywords.empty":       "Ingen offentlige foredrag har disse nøkkelordene.",
+	"keywords.chart":       "Andel foredrag med nøkkelordene %s fra %d til %d",
+	"keywords.year":        "År",
+	"keywords.talks":       "Foredrag",
+
+	"usage.title":         "API-bruk - Talks Indexer Admin",
+	"usage.heading":       "API-bruk",
+	"usage.help":          "Forespørsler til API-rutene per dag, klient og rute. Klienter navngis etter API-tokenet; forespørsler uten gyldig token telles som anonyme. Bruken lagres hvert USAGE_FLUSH_INTERVAL og beholdes i USAGE_DAYS dager.",
+	"usage.period":        "Periode",
+	"usage.days":          "Siste %d dager",
+	"usage.show":          "Vis",
+	"usage.empty":         "Ingen API-forespørsler registrert i perioden.",
+	"usage.chart":         "API-forespørsler per dag siden %s",
+	"usage.date":          "Dato",
+	"usage.requests":      "Forespørsler",
+	"usage.errors":        "Feil (5xx)",
+	"usage.peakHour":      "Travleste time",
+	"usage.byClient":      "Per klient",
+	"usage.byRoute":       "Per rute",
+	"usage.byClientRoute": "Per klient og rute",
+	"usage.client":        "Klient",
+	"usage.route":         "Rute",
+	"usage.latency":       "Gjennomsnittlig svartid",
+
+	"links.title":    "Lenker - Talks Indexer Admin",
+	"links.heading":  "Døde lenker",
+	"links.help":     "Videolenker, talerbilder og lenker i sammendrag i den offentlige indeksen. Døde lenker (404, 410 eller ukjent vert) er borte; utilgjengelige lenker feilet på en måte som kan være midlertidig.",
+	"links.check":    "Sjekk lenker nå",
+	"links.checking": "Sjekker lenker...",
+	"links.empty":    "Ingen lenkesjekk har kjørt ennå.",
+	"links.summary":  "Sjekket %d lenker i %d foredrag (%s).",
+	"links.problems": "Fant %d problemer.",
+	"links.field":    "Felt",
+	"links.cleared":  "Fjernet fra den offentlige indeksen",
+
+	"videos.title":         "Videoer - Talks Indexer Admin",
+	"videos.proposals":     "Videoforslag",
+	"videos.proposalsHelp": "Videoer fra konferansens kanal matchet mot foredrag på tittel og taler. Når du godtar et forslag, legges lenken til foredraget i den private og den offentlige indeksen.",
+	"videos.patchedHelp":   "Lenker lagt til her erstattes når indeksene bygges på nytt eller foredraget endres i moresleep, så legg også inn lenken i moresleep.",
+	"videos.find":          "Finn videoer",
+	"videos.finding":       "Matcher foredrag mot videokanalen...",
+	"videos.notConfigured": "Ingen videokanal er konfigurert. Sett disse for å finne videoer:",
+	"videos.missing":       "Foredrag uten video",
+	"videos.missingCount":  "%d publiserte foredrag fra tidligere konferanser mangler videolenke.",
+	"videos.talkTitle":     "Tittel",
+	"videos.speakers":      "Talere",
+	"videos.talkId":        "Foredrags-ID",
+	"videos.noProposals":   "Ingen videoforslag.",
+	"videos.video":         "Video",
+	"videos.score":         "Treff",
+	"videos.accept":        "Godta",
+	"videos.reject":        "Avvis",
+	"videos.acceptLabel":   "Godta video for %s",
+	"videos.rejectLabel":   "Avvis video for %s",
+
+	"webhooks.title":                  "Webhooks - Talks Indexer Admin",
+	"webhooks.add":                    "Legg til abonnement",
+	"webhooks.help":                   "Hendelser sendes som JSON og signeres med abonnementets hemmelighet. Hemmeligheten vises bare én gang, rett etter at abonnementet er opprettet.",
+	"webhooks.descriptionPlaceholder": "Beskrivelse (valgfri)",
+	"webhooks.subscriptions":          "Abonnementer",
+	"webhooks.deliveries":             "Siste leveranser",
+	"webhooks.noDeliveries":           "Ingen leveranser siden oppstart.",
+	"webhooks.time":                   "Tid",
+	"webhooks.event":                  "Hendelse",
+	"webhooks.attempts":               "Forsøk",
+	"webhooks.lastError":              "Siste feil",
+	"webhooks.created":                "Abonnementet er opprettet. Ta vare på hemmeligheten nå, den vises ikke igjen:",
+	"webhooks.empty":                  "Ingen webhook-abonnementer.",
+	"webhooks.events":                 "Hendelser",
+	"webhooks.description":            "Beskrivelse",
+	"webhooks.confirmDelete":          "Slette abonnementet for %s?",
+	"webhooks.deleteLabel":            "Slett abonnementet for %s",
+
+	"tokens.title":              "API-nøkler - Talks Indexer Admin",
+	"tokens.create":             "Opprett nøkkel",
+	"tokens.help":               "Nøkler lar automatiseringen din, som CI-pipelines, kalle reindekserings- og jobbendepunktene som bearer-token. Jobber startet med en nøkkel tilskrives navnet på nøkkelen og deg. Nøkkelen vises bare én gang, rett etter at den er opprettet eller byttet; bare en hash av den lagres.",
+	"tokens.label":              "Navn",
+	"tokens.labelPlaceholder":   "Navn, f.eks. deploy-pipeline",
+	"tokens.yours":              "Dine nøkler",
+	"tokens.issued":             "Nøkkelen %s er utstedt. Ta vare på den nå, den vises ikke igjen:",
+	"tokens.empty":              "Du har ingen API-nøkler.",
+	"tokens.rotated":            "Byttet",
+	"tokens.lastUsed":           "Sist brukt",
+	"tokens.neverUsed":          "Aldri",
+	"tokens.rotate":             "Bytt",
+	"tokens.revoke":             "Trekk tilbake",
+	"tokens.confirmRotate":      "Erstatte nøkkelen %s? Den nåværende nøkkelen slutter å virke med en gang.",
+	"tokens.confirmRevoke":      "Trekke tilbake nøkkelen %s? Den slutter å virke med en gang.",
+	"tokens.rotateLabel":        "Bytt nøkkelen %s",
+	"tokens.revokeLabel":        "Trekk tilbake nøkkelen %s",
+	"tokens.all":                "Alle nøkler",
+	"tokens.allHelp":            "Nøklene til alle administratorer. Trekk tilbake nøklene til noen som har sluttet; nøkler slutter også å virke så snart eieren ikke lenger er administrator.",
+	"tokens.allEmpty":           "Ingen administratorer har API-nøkler.",
+	"tokens.owner":              "Eier",
+	"tokens.confirmRevokeOwned": "Trekke tilbake nøkkelen %s til %s? Den slutter å virke med en gang.",
+	"tokens.revokeOwnedLabel":   "Trekk tilbake nøkkelen %s til %s",
+
+	"preview.title":        "Forhåndsvisning av foredrag - Talks Indexer",
+	"preview.help":         "Slik viser programmet foredraget ditt. Kontakt programkomiteen hvis noe skal endres.",
+	"preview.notPublic":    "Foredraget ditt er ikke publisert. Når det er akseptert, viser programmet det slik.",
+	"preview.notPublished": "Foredraget ditt er akseptert, men ikke publisert ennå. Programmet viser det slik om kort tid.",
+	"preview.changed":      "De siste endringene dine er ikke publisert ennå. Endrede felt: %s.",
+	"preview.upToDate":     "Programmet viser foredraget ditt som nedenfor.",
+	"preview.speakers":     "Foredragsholdere",
+	"preview.fields":       "Detaljer",
+	"preview.field":        "Felt",
+	"preview.value":        "Verdi",
+	"preview.fieldChanged": "(endret)",
+	"preview.expires":      "Denne lenken til forhåndsvisningen utløper %s.",
+	"preview.invalid":      "Denne lenken til forhåndsvisningen er ugyldig eller utløpt. Be programkomiteen om en ny lenke.",
+	"preview.unavailable":  "Forhåndsvisningen kunne ikke lastes nå. Prøv igjen senere.",
+	"preview.linkCreated":  "Lenke til forhåndsvisning av foredraget %s er opprettet og gjelder til %s. Kopier lenken og send den til foredragsholderne:",
+	"preview.openLink":     "Åpne forhåndsvisning",
+
+	"sitePreview.title":             "Forhåndsvisning av nettsiden - Talks Indexer Admin",
+	"sitePreview.heading":           "Forhåndsvisning av nettsiden",
+	"sitePreview.help":              "Bygger de offentlige dokumentene for de aksepterte foredragene fra moresleep slik neste reindeksering gjør, og sjekker dem mot feltene nettsiden trenger (SITE_PREVIEW_REQUIRED_FIELDS). Med SITE_PREVIEW_URL satt blir hvert dokument også vist av nettsidens forhåndsvisning. Ingenting indekseres.",
+	"sitePreview.check":             "Sjekk konferanse",
+	"sitePreview.previewTalk":       "Forhåndsvis foredrag",
+	"sitePreview.results":           "Foredrag på %s",
+	"sitePreview.allReady":          "Alle %d foredrag er klare for publisering.",
+	"sitePreview.notReady":          "%d av %d foredrag er ikke klare for publisering.",
+	"sitePreview.rendered":          "Sjekket og vist av nettsiden %s.",
+	"sitePreview.checked":           "Sjekket %s. Ingen forhåndsvisning fra nettsiden er satt opp, så visningen er ikke testet.",
+	"sitePreview.talk":              "Foredrag",
+	"sitePreview.problems":          "Problemer",
+	"sitePreview.ready":             "Klar",
+	"sitePreview.missing":           "Mangler: %s",
+	"sitePreview.renderError":       "Visningen feilet: %s",
+	"sitePreview.websiteRendering":  "Vist av nettsiden",
+	"sitePreview.embeddedRendering": "Innebygd visning",
+	"sitePreview.embeddedHelp":      "Ingen forhåndsvisning fra nettsiden er satt opp; dette ligner programsiden basert på det offentlige dokumentet.",
+	"sitePreview.minutes":           "%s min",
+
+	"talkDiff.title":        "Sammenlign foredrag - Talks Indexer Admin",
+	"talkDiff.heading":      "Sammenlign foredrag med indeksene",
+	"talkDiff.help":         "Bygger dokumentene for foredraget fra moresleep slik en reindeksering av foredraget gjør, og sammenligner dem felt for felt med dokumentene i den private og den offentlige indeksen. Ingenting indekseres.",
+	"talkDiff.compare":      "Sammenlign",
+	"talkDiff.privateIndex": "Privat indeks (%s)",
+	"talkDiff.publicIndex":  "Offentlig indeks (%s)",
+	"talkDiff.inSync":       "Indeksen er oppdatert med moresleep.",
+	"talkDiff.notExpected":  "Foredraget skrives ikke til denne indeksen, og indeksen har ikke noe dokument for det.",
+	"talkDiff.missing":      "Indeksen har ikke noe dokument for foredraget, selv om en reindeksering ville skrevet et.",
+	"talkDiff.unexpected":   "Indeksen har fortsatt et dokument for foredraget, selv om en reindeksering ikke ville skrevet et, for eksempel fordi foredraget ikke lenger er godkjent.",
+	"talkDiff.differs":      "%d felt er forskjellige fra moresleep. En reindeksering av foredraget oppdaterer dem.",
+	"talkDiff.field":        "Felt",
+	"talkDiff.source":       "moresleep",
+	"talkDiff.indexed":      "Indeks",
+	"talkDiff.absent":       "ikke satt",
+
+	"reviews.title":                 "Mine vurderinger - Talks Indexer Admin",
+	"reviews.heading":               "Tildelte foredrag",
+	"reviews.help":                  "Foredrag tildeles deg med en vurderingstagg med e-postadressen din i moresleep, og regnes som vurdert når du har gitt tilbakemelding på dem. Tildelingene oppdateres når et foredrag reindekseres.",
+	"reviews.allConferences":        "Alle konferanser",
+	"reviews.includeReviewed":       "Ta med foredrag jeg har vurdert",
+	"reviews.show":                  "Vis",
+	"reviews.noEmail":               "Innloggingen din har ingen e-postadresse, så ingen foredrag kan tildeles deg.",
+	"reviews.empty":                 "Ingen foredrag venter på din vurdering.",
+	"reviews.emptyAll":              "Ingen foredrag er tildelt deg.",
+	"reviews.showing":               "Viser %d av %d tildelte foredrag.",
+	"reviews.talk":                  "Foredrag",
+	"reviews.reviewers":             "Vurderere",
+	"reviews.feedback":              "Din tilbakemelding",
+	"reviews.given":                 "Gitt",
+	"reviews.pending":               "Venter",
+	"reviews.conflict":              "Interessekonflikt",
+	"reviews.conflicts":             "Mine interessekonflikter",
+	"reviews.conflictsHelp":         "Registrer foredragsholderne og organisasjonene du har en interessekonflikt med, for eksempel kolleger eller arbeidsgiveren din. Foredragene deres flagges når de reindekseres, og du blir ikke lenger bedt om tilbakemelding på dem. Organisasjoner matcher også domenet i foredragsholdernes e-postadresser, for eksempel example.com.",
+	"reviews.conflictSpeakers":      "E-postadresser til foredragsholdere, én per linje",
+	"reviews.conflictOrganizations": "Organisasjoner, én per linje",
+	"reviews.saveConflicts":         "Lagre interessekonflikter",
+
+	"republish.title":                         "Republisering - Talks Indexer Admin",
+	"republish.heading":                       "Full republisering",
+	"republish.help":                          "Bygger begge indeksene fra moresleep på nytt som en ny generasjon og bytter leserne over til den i ett steg. I motsetning til en full reindeksering blir de aktive indeksene ikke rørt før den nye generasjonen er sjekket. Republiseringen kjører som én jobb og stopper ved første steg som feiler:",
+	"republish.step.dry-run diff":             "Prøvekjøring",
+	"republish.step.snapshot":                 "Øyeblikksbilde",
+	"republish.step.build generation":         "Bygg generasjon",
+	"republish.step.reconciliation check":     "Avstemming",
+	"republish.step.alias swap":               "Aliasbytte",
+	"republish.step.warm-up":                  "Oppvarming",
+	"republish.step.notification":             "Varsling",
+	"republish.stepHelp.dry-run diff":         "henter alle foredrag, sammenligner de offentlige foredragene per konferanse med den aktive offentlige indeksen og stopper hvis den offentlige indeksen ville krympet mer enn tillatt.",
+	"republish.stepHelp.snapshot":             "lagrer de aktive indeksene i snapshot-repositoriet, hvis det er konfigurert.",
+	"republish.stepHelp.build generation":     "skriver alle foredrag til nye indekser navngitt etter nåværende tidspunkt.",
+	"republish.stepHelp.reconciliation check": "sjekker at de nye indeksene inneholder alle foredrag.",
+	"republish.stepHelp.alias swap":           "peker indeksnavnene til den nye generasjonen. En indeks med det navnet opprettet av en full reindeksering slettes.",
+	"republish.stepHelp.warm-up":              "kjører spørringer mot den nye offentlige indeksen og tømmer CDN-et.",
+	"republish.stepHelp.notification":         "sender en republish.completed-hendelse til webhook-abonnentene.",
+	"republish.keepGenerations":               "Tidligere generasjoner beholdes til de ikke lenger trengs for tilbakerulling. Slett dem på indekssiden:",
+	"republish.dryRun":                        "Prøvekjør",
+	"republish.confirm":                       "Bygge begge indeksene på nytt og bytte aliasene til den nye generasjonen?",
+	"republish.run":                           "Republiser",
+	"republish.working":                       "Jobber...",
+	"republish.planSummary":                   "Prøvekjøring: %d foredrag, %d offentlige (aktive: %d). Ingen indekser ble endret.",
+	"republish.livePublic":                    "Aktive offentlige foredrag",
+	"republish.after":                         "Etter republisering",
+	"republish.changed":                       "endret",
+	"republish.finished":                      "Republiseringen er ferdig",
+	"republish.failed":                        "Republiseringen feilet: %s",
+	"republish.stepColumn":                    "Steg",
+	"republish.state":                         "Tilstand",
+	"republish.details":                       "Detaljer",
+	"republish.notRun":                        "ikke kjørt",
+
+	"whatIf.title":                         "What-if-indekser - Talks Indexer Admin",
+	"whatIf.heading":                       "Bygg what-if-indekser",
+	"whatIf.help":                          "Bygger en privat og en offentlig prøveindeks fra én konferanse med innstillingene under, uten å røre de aktive indeksene, og viser de offentlige foredragene som ville blitt endret. Skjemaet starter med innstillingene de aktive indeksene bygges med. Slett prøveindeksene på indekssiden når du er ferdig:",
+	"whatIf.abstractHtml":                  "Gjør om sammendrag til HTML",
+	"whatIf.scrubPublic":                   "Vask offentlig fritekst",
+	"whatIf.scrubFieldsPlaceholder":        "Foredragsfelter som vaskes, kommaseparert",
+	"whatIf.scrubSpeakerFieldsPlaceholder": "Talerfelter som vaskes, kommaseparert",
+	"whatIf.scrubWordsPlaceholder":         "Blokkerte ord, kommaseparert",
+	"whatIf.liveAnalyzer":                  "Aktiv analysator",
+	"whatIf.analyzerLabel":                 "Analysator",
+	"whatIf.analyzer":                      "Analysator: %s",
+	"whatIf.build":                         "Bygg",
+	"whatIf.building":                      "Bygger what-if-indekser...",
+	"whatIf.built":                         "Bygde %s (%d foredrag) og %s (%d offentlige foredrag)",
+	"whatIf.changed":                       "Endrede offentlige foredrag",
+	"whatIf.noChanges":                     "Ingen offentlige foredrag skiller seg fra den aktive offentlige indeksen.",
+	"whatIf.changedCount":                  "%d offentlige foredrag skiller seg fra den aktive offentlige indeksen, inkludert foredrag som ville blitt lagt til eller fjernet:",
+	"whatIf.review":                        "Flagget for gjennomgang",
+	"whatIf.noReview":                      "Ingen foredrag flagges for gjennomgang med disse innstillingene.",
+	"whatIf.findings":                      "Funn",
+	"progress.started":                     "Startet %s",
+	"progress.fetched":                     "Hentet %d foredrag fra %s (%d av %d konferanser)",
+	"progress.fetchFailed":                 "Kunne ikke hente foredragene fra %s (%d av %d konferanser): %s",
+	"progress.indexed":                     "Skrev %d foredrag til %s",
+	"progress.finished":                    "Fullførte %s, %d foredrag skrevet",
+	"progress.failed":                      "Feilet %s: %s",
+	"progress.scope.unknown":               "en reindeksering",
+	"progress.scope.reindex-all":           "reindeksering av alle konferanser",
+	"progress.scope.reindex-conference":    "reindeksering av konferansen %s",
+	"progress.scope.reindex-conference-id": "reindeksering av konferansen med ID %s",
+	"progress.scope.reindex-talk":          "reindeksering av foredraget %s",
+	"progress.scope.republish":             "republisering som generasjon %s",
+}
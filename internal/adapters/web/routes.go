@@ -3,7 +3,9 @@ package web
 import (
 	"net/http"
 
+	"github.com/javaBin/talks-indexer/internal/adapters/auth"
 	"github.com/javaBin/talks-indexer/internal/adapters/web/handlers"
+	"github.com/javaBin/talks-indexer/internal/domain"
 	"github.com/javaBin/talks-indexer/internal/ports"
 )
 
@@ -16,17 +18,221 @@ type Adapter struct {
 }
 
 // New creates a new web adapter
-func New(indexer ports.Indexer, provider ports.ConferenceProvider) *Adapter {
+func New(indexer ports.Indexer, provider ports.ConferenceProvider, reporter ports.Reporter) *Adapter {
 	return &Adapter{
-		handler: handlers.NewHandler(indexer, provider),
+		handler: handlers.NewHandler(indexer, provider, reporter),
 	}
 }
 
+// SetSigner enables signing of exported snapshots
+func (a *Adapter) SetSigner(signer ports.ContentSigner) {
+	a.handler.SetSigner(signer)
+}
+
+// SetPreferences enables per-user preferences such as the default conference
+func (a *Adapter) SetPreferences(preferences ports.Preferences) {
+	a.handler.SetPreferences(preferences)
+}
+
+// SetWebhooks enables managing outbound webhook subscriptions
+func (a *Adapter) SetWebhooks(webhooks ports.Webhooks) {
+	a.handler.SetWebhooks(webhooks)
+}
+
+// SetUserDirectory enables managing the admin allowlist and role assignments
+func (a *Adapter) SetUserDirectory(users ports.UserDirectory) {
+	a.handler.SetUserDirectory(users)
+}
+
+// SetIndexManager enables the index lifecycle page
+func (a *Adapter) SetIndexManager(indexes ports.IndexManager) {
+	a.handler.SetIndexManager(indexes)
+}
+
+// SetVideoBackfill enables the missing video report and video link backfill
+func (a *Adapter) SetVideoBackfill(videos ports.VideoBackfill) {
+	a.handler.SetVideoBackfill(videos)
+}
+
+// SetLinkReporter enables the broken link report
+func (a *Adapter) SetLinkReporter(links ports.LinkReporter) {
+	a.handler.SetLinkReporter(links)
+}
+
+// SetKeywordTrends enables the keyword trend analysis page
+func (a *Adapter) SetKeywordTrends(keywords ports.KeywordTrends) {
+	a.handler.SetKeywordTrends(keywords)
+}
+
+// SetUsageAnalytics enables the API usage page
+func (a *Adapter) SetUsageAnalytics(usage ports.UsageAnalytics) {
+	a.handler.SetUsageAnalytics(usage)
+}
+
+// SetConferenceCatalog enables managing the conference metadata
+func (a *Adapter) SetConferenceCatalog(catalog ports.ConferenceCatalog) {
+	a.handler.SetConferenceCatalog(catalog)
+}
+
+// SetSeriesCatalog enables managing the series linking related sessions
+func (a *Adapter) SetSeriesCatalog(series ports.SeriesCatalog) {
+	a.handler.SetSeriesCatalog(series)
+}
+
+// SetDeadLetters enables inspecting and retrying documents that failed indexing
+func (a *Adapter) SetDeadLetters(deadLetters ports.DeadLetters) {
+	a.handler.SetDeadLetters(deadLetters)
+}
+
+// SetRepublisher enables the guided full republish
+func (a *Adapter) SetRepublisher(republisher ports.Republisher) {
+	a.handler.SetRepublisher(republisher)
+}
+
+// SetWhatIfBuilder enables building what-if indexes with alternative transformation settings
+func (a *Adapter) SetWhatIfBuilder(whatIf ports.WhatIfBuilder) {
+	a.handler.SetWhatIfBuilder(whatIf)
+}
+
+// SetNotices enables the notice banner admins set on the notice page
+func (a *Adapter) SetNotices(notices ports.Notices) {
+	a.handler.SetNotices(notices)
+}
+
+// SetTalkTrends enables the talk count trend charts on the dashboard
+func (a *Adapter) SetTalkTrends(trends ports.TalkTrends) {
+	a.handler.SetTalkTrends(trends)
+}
+
+// SetReindexStatuses shows when each index was last successfully reindexed on the dashboard
+func (a *Adapter) SetReindexStatuses(statuses ports.ReindexStatuses) {
+	a.handler.SetReindexStatuses(statuses)
+}
+
+// SetDiagnostics enables the diagnostics bundle download
+func (a *Adapter) SetDiagnostics(diagnostics ports.Diagnostics) {
+	a.handler.SetDiagnostics(diagnostics)
+}
+
+// SetAPITokens enables admins to manage their own API tokens
+func (a *Adapter) SetAPITokens(tokens ports.APITokens) {
+	a.handler.SetAPITokens(tokens)
+}
+
+// SetReindexJobs makes the full reindex run in the background as a job, responding right away
+func (a *Adapter) SetReindexJobs(jobs ports.ReindexJobs) {
+	a.handler.SetReindexJobs(jobs)
+}
+
+// SetReindexProgress enables following the progress of running reindexes live on the dashboard
+func (a *Adapter) SetReindexProgress(progress ports.ReindexProgressStream) {
+	a.handler.SetReindexProgress(progress)
+}
+
+// SetTalkPreviews enables creating preview links speakers open without logging in
+func (a *Adapter) SetTalkPreviews(previews ports.TalkPreviews) {
+	a.handler.SetTalkPreviews(previews)
+}
+
+// SetSitePreviews enables previewing public documents the way the website renders them
+func (a *Adapter) SetSitePreviews(sitePreviews ports.SitePreviews) {
+	a.handler.SetSitePreviews(sitePreviews)
+}
+
+// SetTalkDiffs enables comparing a talk in moresleep with its documents in both indexes
+func (a *Adapter) SetTalkDiffs(talkDiffs ports.TalkDiffs) {
+	a.handler.SetTalkDiffs(talkDiffs)
+}
+
+// SetReviews enables listing the talks assigned to the logged-in committee member
+func (a *Adapter) SetReviews(reviews ports.Reviews) {
+	a.handler.SetReviews(reviews)
+}
+
+// SetReviewerConflicts enables committee members to register their conflicts of interest
+func (a *Adapter) SetReviewerConflicts(conflicts ports.ReviewerConflicts) {
+	a.handler.SetReviewerConflicts(conflicts)
+}
+
+// SetReadOnly refuses the actions writing to the cluster and shows a read-only banner on every page
+func (a *Adapter) SetReadOnly(readOnly bool) {
+	a.handler.SetReadOnly(readOnly)
+}
+
 // RegisterRoutes registers all web routes with the provided mux.
-// All routes are wrapped with the provided middleware (auth or passthrough).
+// All routes except the login page and the speaker preview pages are wrapped with the provided
+// middleware (auth or passthrough) and require a minimum role: viewers can read, operators can
+// reindex and admins can manage access.
+// Protected routes are rendered with the user's preferences, such as the UI language.
+// Actions registered with write are refused in read-only mode.
 func (a *Adapter) RegisterRoutes(mux *http.ServeMux, middleware MiddlewareFunc) {
-	mux.Handle("GET /admin", middleware(http.HandlerFunc(a.handler.HandleDashboard)))
-	mux.Handle("POST /admin/reindex/all", middleware(http.HandlerFunc(a.handler.HandleReindexAll)))
-	mux.Handle("POST /admin/reindex/conference", middleware(http.HandlerFunc(a.handler.HandleReindexConference)))
-	mux.Handle("POST /admin/reindex/talk", middleware(http.HandlerFunc(a.handler.HandleReindexTalk)))
+	protect := func(role domain.Role, handler http.HandlerFunc) http.Handler {
+		return middleware(auth.RequireRole(role)(a.handler.WithUserPreferences(a.handler.WithBanners(handler))))
+	}
+	write := func(role domain.Role, handler http.HandlerFunc) http.Handler {
+		return protect(role, a.handler.RequireWritable(handler))
+	}
+
+	mux.HandleFunc("GET /login", a.handler.HandleLogin)
+	mux.HandleFunc("GET /preview/{token}", a.handler.HandleTalkPreview)
+	mux.Handle("GET /admin", protect(domain.RoleViewer, a.handler.HandleDashboard))
+	mux.Handle("POST /admin/reindex/all", write(domain.RoleOperator, a.handler.HandleReindexAll))
+	mux.Handle("POST /admin/reindex/conference", write(domain.RoleOperator, a.handler.HandleReindexConference))
+	mux.Handle("POST /admin/reindex/conference-id", write(domain.RoleOperator, a.handler.HandleReindexConferenceByID))
+	mux.Handle("POST /admin/reindex/talk", write(domain.RoleOperator, a.handler.HandleReindexTalk))
+	mux.Handle("POST /admin/talks/delete", write(domain.RoleOperator, a.handler.HandleDeleteTalk))
+	mux.Handle("POST /admin/talks/preview-link", protect(domain.RoleOperator, a.handler.HandleCreatePreviewLink))
+	mux.Handle("GET /admin/reindex/stream", protect(domain.RoleOperator, a.handler.HandleReindexStream))
+	mux.Handle("POST /admin/preferences", protect(domain.RoleViewer, a.handler.HandleSavePreferences))
+	mux.Handle("GET /admin/users", protect(domain.RoleAdmin, a.handler.HandleUsers))
+	mux.Handle("POST /admin/users", write(domain.RoleAdmin, a.handler.HandleSetUserRole))
+	mux.Handle("POST /admin/users/remove", write(domain.RoleAdmin, a.handler.HandleRemoveUser))
+	mux.Handle("GET /admin/indexes", protect(domain.RoleAdmin, a.handler.HandleIndexes))
+	mux.Handle("POST /admin/indexes/delete", write(domain.RoleAdmin, a.handler.HandleDeleteIndex))
+	mux.Handle("POST /admin/indexes/alias", write(domain.RoleAdmin, a.handler.HandlePointAlias))
+	mux.Handle("GET /admin/conferences", protect(domain.RoleAdmin, a.handler.HandleConferenceMetadata))
+	mux.Handle("POST /admin/conferences", write(domain.RoleAdmin, a.handler.HandleSetConferenceMetadata))
+	mux.Handle("POST /admin/conferences/remove", write(domain.RoleAdmin, a.handler.HandleRemoveConferenceMetadata))
+	mux.Handle("GET /admin/series", protect(domain.RoleAdmin, a.handler.HandleSeries))
+	mux.Handle("POST /admin/series", write(domain.RoleAdmin, a.handler.HandleSetSeries))
+	mux.Handle("POST /admin/series/remove", write(domain.RoleAdmin, a.handler.HandleRemoveSeries))
+	mux.Handle("GET /admin/republish", protect(domain.RoleAdmin, a.handler.HandleRepublish))
+	mux.Handle("POST /admin/republish/plan", protect(domain.RoleAdmin, a.handler.HandlePlanRepublish))
+	mux.Handle("POST /admin/republish", write(domain.RoleAdmin, a.handler.HandleRunRepublish))
+	mux.Handle("GET /admin/what-if", protect(domain.RoleAdmin, a.handler.HandleWhatIf))
+	mux.Handle("POST /admin/what-if", write(domain.RoleAdmin, a.handler.HandleBuildWhatIf))
+	mux.Handle("GET /admin/dead-letters", protect(domain.RoleAdmin, a.handler.HandleDeadLetters))
+	mux.Handle("POST /admin/dead-letters/retry", write(domain.RoleAdmin, a.handler.HandleRetryDeadLetter))
+	mux.Handle("POST /admin/dead-letters/discard", write(domain.RoleAdmin, a.handler.HandleDiscardDeadLetter))
+	mux.Handle("GET /admin/notice", protect(domain.RoleAdmin, a.handler.HandleNotice))
+	mux.Handle("POST /admin/notice", write(domain.RoleAdmin, a.handler.HandleSetNotice))
+	mux.Handle("POST /admin/notice/clear", write(domain.RoleAdmin, a.handler.HandleClearNotice))
+	mux.Handle("GET /admin/webhooks", protect(domain.RoleAdmin, a.handler.HandleWebhooks))
+	mux.Handle("POST /admin/webhooks", write(domain.RoleAdmin, a.handler.HandleCreateWebhook))
+	mux.Handle("POST /admin/webhooks/{id}/delete", write(domain.RoleAdmin, a.handler.HandleDeleteWebhook))
+	mux.Handle("GET /admin/tokens", protect(domain.RoleAdmin, a.handler.HandleAPITokens))
+	mux.Handle("POST /admin/tokens", write(domain.RoleAdmin, a.handler.HandleCreateAPIToken))
+	mux.Handle("POST /admin/tokens/{id}/rotate", write(domain.RoleAdmin, a.handler.HandleRotateAPIToken))
+	mux.Handle("POST /admin/tokens/{id}/revoke", write(domain.RoleAdmin, a.handler.HandleRevokeAPIToken))
+	mux.Handle("POST /admin/tokens/all/{id}/revoke", write(domain.RoleAdmin, a.handler.HandleRevokeAnyAPIToken))
+	mux.Handle("GET /admin/diagnostics.zip", protect(domain.RoleAdmin, a.handler.HandleDiagnosticsBundle))
+	mux.Handle("GET /admin/videos", protect(domain.RoleViewer, a.handler.HandleVideos))
+	mux.Handle("POST /admin/videos/propose", write(domain.RoleOperator, a.handler.HandleProposeVideos))
+	mux.Handle("POST /admin/videos/accept", write(domain.RoleAdmin, a.handler.HandleAcceptVideo))
+	mux.Handle("POST /admin/videos/reject", write(domain.RoleAdmin, a.handler.HandleRejectVideo))
+	mux.Handle("GET /admin/links", protect(domain.RoleViewer, a.handler.HandleLinks))
+	mux.Handle("POST /admin/links/check", write(domain.RoleOperator, a.handler.HandleCheckLinks))
+	mux.Handle("GET /admin/keywords", protect(domain.RoleViewer, a.handler.HandleKeywordTrends))
+	mux.Handle("GET /admin/usage", protect(domain.RoleViewer, a.handler.HandleUsage))
+	mux.Handle("GET /admin/site-preview", protect(domain.RoleViewer, a.handler.HandleSitePreview))
+	mux.Handle("GET /admin/site-preview/talk", protect(domain.RoleViewer, a.handler.HandleSitePreviewTalk))
+	mux.Handle("GET /admin/talk-diff", protect(domain.RoleViewer, a.handler.HandleTalkDiff))
+	mux.Handle("GET /admin/reviews", protect(domain.RoleViewer, a.handler.HandleReviews))
+	mux.Handle("POST /admin/reviews/conflicts", write(domain.RoleViewer, a.handler.HandleSaveConflicts))
+	mux.Handle("GET /admin/reports/statistics.json", protect(domain.RoleViewer, a.handler.HandleStatisticsJSON))
+	mux.Handle("GET /admin/reports/statistics.csv", protect(domain.RoleViewer, a.handler.HandleStatisticsCSV))
+	mux.Handle("GET /admin/reports/anonymized.ndjson", protect(domain.RoleViewer, a.handler.HandleAnonymizedDataset))
+	mux.Handle("GET /admin/reports/ranking.csv", protect(domain.RoleViewer, a.handler.HandleRankingCSV))
+	mux.Handle("GET /admin/reports/ranking.xlsx", protect(domain.RoleViewer, a.handler.HandleRankingXLSX))
+	mux.Handle("GET /admin/reports/speakers.csv", protect(domain.RoleAdmin, a.handler.HandleSpeakerContactsCSV))
 }
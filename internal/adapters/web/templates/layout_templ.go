@@ -10,10 +10,91 @@ import templruntime "github.com/a-h/templ/runtime"
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/javaBin/talks-indexer/internal/adapters/auth"
+	"github.com/javaBin/talks-indexer/internal/adapters/web/i18n"
+	"github.com/javaBin/talks-indexer/internal/domain"
 )
 
+// sessionExpiryWarning is how long before the session expires the renewal banner is shown
+const sessionExpiryWarning = 10 * time.Minute
+
+// tableTimeFormat is the timestamp format used in admin tables
+const tableTimeFormat = "2006-01-02 15:04:05"
+
+// preferencesContextKey is the context key for the current user's preferences
+type preferencesContextKey struct{}
+
+// WithPreferences returns a context carrying the user's preferences for rendering the layout
+func WithPreferences(ctx context.Context, prefs domain.UserPreferences) context.Context {
+	return context.WithValue(ctx, preferencesContextKey{}, prefs)
+}
+
+// PreferencesFromContext returns the preferences set with WithPreferences, if any
+func PreferencesFromContext(ctx context.Context) (domain.UserPreferences, bool) {
+	prefs, ok := ctx.Value(preferencesContextKey{}).(domain.UserPreferences)
+	return prefs, ok
+}
+
+// readOnlyContextKey is the context key marking requests served in read-only mode
+type readOnlyContextKey struct{}
+
+// WithReadOnly returns a context marking the indexer as read-only, so the layout shows the read-only banner
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyContextKey{}, true)
+}
+
+// isReadOnly reports whether the context was marked with WithReadOnly
+func isReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyContextKey{}).(bool)
+	return readOnly
+}
+
+// noticeContextKey is the context key for the active notice set by admins
+type noticeContextKey struct{}
+
+// WithNotice returns a context carrying the active notice, so the layout shows the notice banner
+func WithNotice(ctx context.Context, notice domain.Notice) context.Context {
+	return context.WithValue(ctx, noticeContextKey{}, notice)
+}
+
+// getNotice returns the notice set with WithNotice, if any
+func getNotice(ctx context.Context) (domain.Notice, bool) {
+	notice, ok := ctx.Value(noticeContextKey{}).(domain.Notice)
+	return notice, ok
+}
+
+// noticeRole announces critical notices right away and others politely
+func noticeRole(notice domain.Notice) string {
+	if notice.Severity == domain.NoticeCritical {
+		return "alert"
+	}
+	return "status"
+}
+
+// getTheme returns the user's theme, or the system theme when no preferences are set
+func getTheme(ctx context.Context) string {
+	if prefs, ok := PreferencesFromContext(ctx); ok && prefs.Theme != "" {
+		return prefs.Theme
+	}
+	return domain.ThemeSystem
+}
+
+// getLanguage returns the user's language, or English when no preferences are set
+func getLanguage(ctx context.Context) string {
+	if prefs, ok := PreferencesFromContext(ctx); ok && prefs.Language != "" {
+		return prefs.Language
+	}
+	return domain.LanguageEnglish
+}
+
+// t returns the message for key in the user's language, formatted with args if given
+func t(ctx context.Context, key string, args ...interface{}) string {
+	return i18n.Translate(getLanguage(ctx), key, args...)
+}
+
 func getUserEmail(ctx context.Context) string {
 	if sess := auth.GetSession(ctx); sess != nil {
 		return sess.Email
@@ -21,6 +102,19 @@ func getUserEmail(ctx context.Context) string {
 	return ""
 }
 
+// hasRole reports whether the current user's role includes the required role
+func hasRole(ctx context.Context, required domain.Role) bool {
+	return auth.GetRole(ctx).Allows(required)
+}
+
+// getSessionExpiry returns the session expiry in Unix milliseconds, or an empty string without a session
+func getSessionExpiry(ctx context.Context) string {
+	if sess := auth.GetSession(ctx); sess != nil {
+		return strconv.FormatInt(sess.ExpiresAt.UnixMilli(), 10)
+	}
+	return ""
+}
+
 func Layout(title string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
@@ -42,43 +136,275 @@ func Layout(title string) templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var2 string
-		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(getLanguage(ctx))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 22, Col: 17}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 112, Col: 30}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</title><script src=\"https://unpkg.com/htmx.org@2.0.4\"></script><style>\n\t\t\t\t* {\n\t\t\t\t\tbox-sizing: border-box;\n\t\t\t\t}\n\t\t\t\tbody {\n\t\t\t\t\tfont-family: system-ui, -apple-system, sans-serif;\n\t\t\t\t\tmax-width: 800px;\n\t\t\t\t\tmargin: 0 auto;\n\t\t\t\t\tpadding: 0 1rem;\n\t\t\t\t\tbackground-color: #f5f5f5;\n\t\t\t\t}\n\t\t\t\theader {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tjustify-content: space-between;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tpadding: 1rem 0;\n\t\t\t\t\tmargin-bottom: 1rem;\n\t\t\t\t\tborder-bottom: 1px solid #ddd;\n\t\t\t\t}\n\t\t\t\theader .user-info {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 1rem;\n\t\t\t\t\tcolor: #666;\n\t\t\t\t\tfont-size: 0.9rem;\n\t\t\t\t}\n\t\t\t\theader .logout-btn {\n\t\t\t\t\tpadding: 0.4rem 0.8rem;\n\t\t\t\t\tbackground-color: #dc3545;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tborder: none;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tcursor: pointer;\n\t\t\t\t\tfont-size: 0.85rem;\n\t\t\t\t}\n\t\t\t\theader .logout-btn:hover {\n\t\t\t\t\tbackground-color: #c82333;\n\t\t\t\t}\n\t\t\t\th1 {\n\t\t\t\t\tcolor: #333;\n\t\t\t\t\tmargin: 0;\n\t\t\t\t}\n\t\t\t\t.section {\n\t\t\t\t\tmargin-bottom: 1.5rem;\n\t\t\t\t\tpadding: 1.5rem;\n\t\t\t\t\tbackground: white;\n\t\t\t\t\tborder: 1px solid #ddd;\n\t\t\t\t\tborder-radius: 8px;\n\t\t\t\t\tbox-shadow: 0 1px 3px rgba(0,0,0,0.1);\n\t\t\t\t}\n\t\t\t\t.section h2 {\n\t\t\t\t\tmargin-top: 0;\n\t\t\t\t\tcolor: #444;\n\t\t\t\t\tfont-size: 1.25rem;\n\t\t\t\t}\n\t\t\t\t.section p {\n\t\t\t\t\tcolor: #666;\n\t\t\t\t\tmargin-bottom: 1rem;\n\t\t\t\t}\n\t\t\t\tbutton {\n\t\t\t\t\tpadding: 0.5rem 1rem;\n\t\t\t\t\tcursor: pointer;\n\t\t\t\t\tbackground-color: #0066cc;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tborder: none;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tfont-size: 0.9rem;\n\t\t\t\t}\n\t\t\t\tbutton:hover {\n\t\t\t\t\tbackground-color: #0055aa;\n\t\t\t\t}\n\t\t\t\tbutton:disabled {\n\t\t\t\t\tbackground-color: #ccc;\n\t\t\t\t\tcursor: not-allowed;\n\t\t\t\t}\n\t\t\t\tselect, input[type=\"text\"] {\n\t\t\t\t\tpadding: 0.5rem;\n\t\t\t\t\tmin-width: 250px;\n\t\t\t\t\tborder: 1px solid #ccc;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tfont-size: 0.9rem;\n\t\t\t\t}\n\t\t\t\t.form-group {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tgap: 0.5rem;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tflex-wrap: wrap;\n\t\t\t\t}\n\t\t\t\t.result {\n\t\t\t\t\tmargin-top: 1rem;\n\t\t\t\t\tpadding: 0.75rem 1rem;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t}\n\t\t\t\t.success {\n\t\t\t\t\tbackground-color: #d4edda;\n\t\t\t\t\tcolor: #155724;\n\t\t\t\t\tborder: 1px solid #c3e6cb;\n\t\t\t\t}\n\t\t\t\t.error {\n\t\t\t\t\tbackground-color: #f8d7da;\n\t\t\t\t\tcolor: #721c24;\n\t\t\t\t\tborder: 1px solid #f5c6cb;\n\t\t\t\t}\n\t\t\t\t.htmx-request button {\n\t\t\t\t\topacity: 0.6;\n\t\t\t\t}\n\t\t\t\t.htmx-indicator {\n\t\t\t\t\tdisplay: none;\n\t\t\t\t}\n\t\t\t\t.htmx-request .htmx-indicator {\n\t\t\t\t\tdisplay: block;\n\t\t\t\t}\n\t\t\t\t.loading {\n\t\t\t\t\tbackground-color: #fff3cd;\n\t\t\t\t\tcolor: #856404;\n\t\t\t\t\tborder: 1px solid #ffeeba;\n\t\t\t\t}\n\t\t\t</style></head><body><header><h1>Talks Indexer</h1>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\" data-theme=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var3 string
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(getTheme(ctx))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 112, Col: 59}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var4 string
+		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 116, Col: 17}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</title><script src=\"https://unpkg.com/htmx.org@2.0.4\"></script><style>\n\t\t\t\t* {\n\t\t\t\t\tbox-sizing: border-box;\n\t\t\t\t}\n\t\t\t\tbody {\n\t\t\t\t\tfont-family: system-ui, -apple-system, sans-serif;\n\t\t\t\t\tmax-width: 800px;\n\t\t\t\t\tmargin: 0 auto;\n\t\t\t\t\tpadding: 0 1rem;\n\t\t\t\t\tbackground-color: #f5f5f5;\n\t\t\t\t}\n\t\t\t\theader {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tjustify-content: space-between;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tpadding: 1rem 0;\n\t\t\t\t\tmargin-bottom: 1rem;\n\t\t\t\t\tborder-bottom: 1px solid #ddd;\n\t\t\t\t}\n\t\t\t\theader .user-info {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tgap: 1rem;\n\t\t\t\t\tcolor: #666;\n\t\t\t\t\tfont-size: 0.9rem;\n\t\t\t\t}\n\t\t\t\theader .logout-btn {\n\t\t\t\t\tpadding: 0.4rem 0.8rem;\n\t\t\t\t\tbackground-color: #dc3545;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tborder: none;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tcursor: pointer;\n\t\t\t\t\tfont-size: 0.85rem;\n\t\t\t\t}\n\t\t\t\theader .logout-btn:hover {\n\t\t\t\t\tbackground-color: #c82333;\n\t\t\t\t}\n\t\t\t\th1 {\n\t\t\t\t\tcolor: #333;\n\t\t\t\t\tmargin: 0;\n\t\t\t\t}\n\t\t\t\t.section {\n\t\t\t\t\tmargin-bottom: 1.5rem;\n\t\t\t\t\tpadding: 1.5rem;\n\t\t\t\t\tbackground: white;\n\t\t\t\t\tborder: 1px solid #ddd;\n\t\t\t\t\tborder-radius: 8px;\n\t\t\t\t\tbox-shadow: 0 1px 3px rgba(0,0,0,0.1);\n\t\t\t\t}\n\t\t\t\t.section h2 {\n\t\t\t\t\tmargin-top: 0;\n\t\t\t\t\tcolor: #444;\n\t\t\t\t\tfont-size: 1.25rem;\n\t\t\t\t}\n\t\t\t\t.section p {\n\t\t\t\t\tcolor: #666;\n\t\t\t\t\tmargin-bottom: 1rem;\n\t\t\t\t}\n\t\t\t\tbutton {\n\t\t\t\t\tpadding: 0.5rem 1rem;\n\t\t\t\t\tcursor: pointer;\n\t\t\t\t\tbackground-color: #0066cc;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tborder: none;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tfont-size: 0.9rem;\n\t\t\t\t}\n\t\t\t\tbutton:hover {\n\t\t\t\t\tbackground-color: #0055aa;\n\t\t\t\t}\n\t\t\t\ta.button-link {\n\t\t\t\t\tdisplay: inline-block;\n\t\t\t\t\tpadding: 0.5rem 1rem;\n\t\t\t\t\tbackground-color: #0066cc;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tfont-size: 0.9rem;\n\t\t\t\t\ttext-decoration: none;\n\t\t\t\t}\n\t\t\t\ta.button-link:hover {\n\t\t\t\t\tbackground-color: #0055aa;\n\t\t\t\t}\n\t\t\t\tbutton.danger {\n\t\t\t\t\tbackground-color: #dc3545;\n\t\t\t\t}\n\t\t\t\tbutton.danger:hover {\n\t\t\t\t\tbackground-color: #c82333;\n\t\t\t\t}\n\t\t\t\tbutton:disabled {\n\t\t\t\t\tbackground-color: #ccc;\n\t\t\t\t\tcursor: not-allowed;\n\t\t\t\t}\n\t\t\t\tselect, input[type=\"text\"] {\n\t\t\t\t\tpadding: 0.5rem;\n\t\t\t\t\tmin-width: 250px;\n\t\t\t\t\tborder: 1px solid #ccc;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tfont-size: 0.9rem;\n\t\t\t\t}\n\t\t\t\t.form-group {\n\t\t\t\t\tdisplay: flex;\n\t\t\t\t\tgap: 0.5rem;\n\t\t\t\t\talign-items: center;\n\t\t\t\t\tflex-wrap: wrap;\n\t\t\t\t}\n\t\t\t\t.result {\n\t\t\t\t\tmargin-top: 1rem;\n\t\t\t\t\tpadding: 0.75rem 1rem;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t}\n\t\t\t\t.success {\n\t\t\t\t\tbackground-color: #d4edda;\n\t\t\t\t\tcolor: #155724;\n\t\t\t\t\tborder: 1px solid #c3e6cb;\n\t\t\t\t}\n\t\t\t\t.error {\n\t\t\t\t\tbackground-color: #f8d7da;\n\t\t\t\t\tcolor: #721c24;\n\t\t\t\t\tborder: 1px solid #f5c6cb;\n\t\t\t\t}\n\t\t\t\t.htmx-request button {\n\t\t\t\t\topacity: 0.6;\n\t\t\t\t}\n\t\t\t\t.htmx-indicator {\n\t\t\t\t\tdisplay: none;\n\t\t\t\t}\n\t\t\t\t.htmx-request .htmx-indicator {\n\t\t\t\t\tdisplay: block;\n\t\t\t\t}\n\t\t\t\t.loading {\n\t\t\t\t\tbackground-color: #fff3cd;\n\t\t\t\t\tcolor: #856404;\n\t\t\t\t\tborder: 1px solid #ffeeba;\n\t\t\t\t}\n\t\t\t\t.progress-log {\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\tpadding-left: 1.5rem;\n\t\t\t\t}\n\t\t\t\t.progress-log li {\n\t\t\t\t\tmargin: 0.25rem 0;\n\t\t\t\t\tpadding: 0.25rem 0.5rem;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t}\n\t\t\t\thtml[data-theme=\"dark\"] body {\n\t\t\t\t\tbackground-color: #1e1e1e;\n\t\t\t\t\tcolor: #ddd;\n\t\t\t\t}\n\t\t\t\thtml[data-theme=\"dark\"] h1,\n\t\t\t\thtml[data-theme=\"dark\"] .section h2 {\n\t\t\t\t\tcolor: #eee;\n\t\t\t\t}\n\t\t\t\thtml[data-theme=\"dark\"] .section {\n\t\t\t\t\tbackground: #2a2a2a;\n\t\t\t\t\tborder-color: #444;\n\t\t\t\t}\n\t\t\t\thtml[data-theme=\"dark\"] .section p,\n\t\t\t\thtml[data-theme=\"dark\"] header .user-info {\n\t\t\t\t\tcolor: #bbb;\n\t\t\t\t}\n\t\t\t\t@media (prefers-color-scheme: dark) {\n\t\t\t\t\thtml[data-theme=\"system\"] body {\n\t\t\t\t\t\tbackground-color: #1e1e1e;\n\t\t\t\t\t\tcolor: #ddd;\n\t\t\t\t\t}\n\t\t\t\t\thtml[data-theme=\"system\"] h1,\n\t\t\t\t\thtml[data-theme=\"system\"] .section h2 {\n\t\t\t\t\t\tcolor: #eee;\n\t\t\t\t\t}\n\t\t\t\t\thtml[data-theme=\"system\"] .section {\n\t\t\t\t\t\tbackground: #2a2a2a;\n\t\t\t\t\t\tborder-color: #444;\n\t\t\t\t\t}\n\t\t\t\t\thtml[data-theme=\"system\"] .section p,\n\t\t\t\t\thtml[data-theme=\"system\"] header .user-info {\n\t\t\t\t\t\tcolor: #bbb;\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\ttable {\n\t\t\t\t\twidth: 100%;\n\t\t\t\t\tborder-collapse: collapse;\n\t\t\t\t\tfont-size: 0.85rem;\n\t\t\t\t}\n\t\t\t\tth, td {\n\t\t\t\t\tpadding: 0.4rem 0.5rem;\n\t\t\t\t\tborder-bottom: 1px solid #ddd;\n\t\t\t\t\ttext-align: left;\n\t\t\t\t\tvertical-align: top;\n\t\t\t\t}\n\t\t\t\t.badge {\n\t\t\t\t\tpadding: 0.1rem 0.4rem;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t}\n\t\t\t\t.visually-hidden {\n\t\t\t\t\tposition: absolute;\n\t\t\t\t\twidth: 1px;\n\t\t\t\t\theight: 1px;\n\t\t\t\t\tmargin: -1px;\n\t\t\t\t\tpadding: 0;\n\t\t\t\t\toverflow: hidden;\n\t\t\t\t\tclip: rect(0 0 0 0);\n\t\t\t\t\twhite-space: nowrap;\n\t\t\t\t\tborder: 0;\n\t\t\t\t}\n\t\t\t\t.skip-link {\n\t\t\t\t\tposition: absolute;\n\t\t\t\t\tleft: -10000px;\n\t\t\t\t}\n\t\t\t\t.skip-link:focus {\n\t\t\t\t\tleft: 1rem;\n\t\t\t\t\ttop: 1rem;\n\t\t\t\t\tpadding: 0.5rem 1rem;\n\t\t\t\t\tbackground: white;\n\t\t\t\t\tcolor: #0066cc;\n\t\t\t\t\tz-index: 10;\n\t\t\t\t}\n\t\t\t\t:focus-visible {\n\t\t\t\t\toutline: 3px solid #4d90fe;\n\t\t\t\t\toutline-offset: 2px;\n\t\t\t\t}\n\t\t\t\tmain:focus,\n\t\t\t\t[tabindex=\"-1\"]:focus {\n\t\t\t\t\toutline: none;\n\t\t\t\t}\n\t\t\t\t.session-banner,\n\t\t\t\t.read-only-banner,\n\t\t\t\t.notice-banner {\n\t\t\t\t\tmargin-bottom: 1rem;\n\t\t\t\t\tpadding: 0.75rem 1rem;\n\t\t\t\t\tborder-radius: 4px;\n\t\t\t\t\tbackground-color: #fff3cd;\n\t\t\t\t\tcolor: #856404;\n\t\t\t\t\tborder: 1px solid #ffeeba;\n\t\t\t\t}\n\t\t\t\t.notice-banner.notice-info {\n\t\t\t\t\tbackground-color: #d1ecf1;\n\t\t\t\t\tcolor: #0c5460;\n\t\t\t\t\tborder-color: #bee5eb;\n\t\t\t\t}\n\t\t\t\t.notice-banner.notice-critical {\n\t\t\t\t\tbackground-color: #f8d7da;\n\t\t\t\t\tcolor: #721c24;\n\t\t\t\t\tborder-color: #f5c6cb;\n\t\t\t\t}\n\t\t\t</style></head><body><a class=\"skip-link\" href=\"#main\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var5 string
+		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(t(ctx, "layout.skipToContent"))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 365, Col: 69}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</a><header><h1>Talks Indexer</h1>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if email := getUserEmail(ctx); email != "" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<div class=\"user-info\"><span>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<div class=\"user-info\"><span>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var6 string
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(email)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 370, Col: 19}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</span> ")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if role := auth.GetRole(ctx); role != "" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<span>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var7 string
+				templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(string(role))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 372, Col: 27}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</span>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "<form action=\"/auth/logout\" method=\"POST\" style=\"margin: 0;\"><button type=\"submit\" class=\"logout-btn\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var8 string
+			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(t(ctx, "layout.logout"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 375, Col: 73}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "</button></form></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "</header>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if notice, ok := getNotice(ctx); ok {
+			var templ_7745c5c3_Var9 = []any{"notice-banner", "notice-" + string(notice.Severity)}
+			templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var9...)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "<div class=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var10 string
+			templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var9).String())
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 1, Col: 0}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "\" role=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var11 string
+			templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(noticeRole(notice))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 381, Col: 97}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var12 string
+			templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(notice.Message)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 381, Col: 116}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "</div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if isReadOnly(ctx) {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "<div class=\"read-only-banner\" role=\"status\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var13 string
+			templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(t(ctx, "layout.readOnly"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 384, Col: 75}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "</div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if expiresAt := getSessionExpiry(ctx); expiresAt != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "<div id=\"session-banner\" class=\"session-banner\" role=\"alert\" data-expires-at=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var14 string
+			templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(expiresAt)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 391, Col: 32}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var14))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "\" data-warn-ms=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var15 string
+			templ_7745c5c3_Var15, templ_7745c5c3_Err = templ.JoinStringErrs(strconv.FormatInt(sessionExpiryWarning.Milliseconds(), 10))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 392, Col: 78}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var15))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "\" hidden>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var16 string
+			templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(t(ctx, "layout.sessionExpires"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 395, Col: 38}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, " <a href=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var17 templ.SafeURL
+			templ_7745c5c3_Var17, templ_7745c5c3_Err = templ.JoinURLErrs(loginStartURL("/admin"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 396, Col: 38}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var17))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "\" target=\"_blank\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var18 string
+			templ_7745c5c3_Var18, templ_7745c5c3_Err = templ.JoinStringErrs(t(ctx, "layout.sessionLogin"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 396, Col: 88}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var18))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "</a> ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var3 string
-			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(email)
+			var templ_7745c5c3_Var19 string
+			templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(t(ctx, "layout.sessionKeep"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 148, Col: 19}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/adapters/web/templates/layout.templ`, Line: 397, Col: 35}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</span><form action=\"/auth/logout\" method=\"POST\" style=\"margin: 0;\"><button type=\"submit\" class=\"logout-btn\">Log out</button></form></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "</div><script>\n\t\t\t\t\t(function () {\n\t\t\t\t\t\tvar banner = document.getElementById(\"session-banner\");\n\t\t\t\t\t\tvar warnAt = Number(banner.dataset.expiresAt) - Number(banner.dataset.warnMs);\n\t\t\t\t\t\tfunction check() {\n\t\t\t\t\t\t\tvar remaining = warnAt - Date.now();\n\t\t\t\t\t\t\tif (remaining <= 0) {\n\t\t\t\t\t\t\t\tbanner.hidden = false;\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tsetTimeout(check, Math.min(remaining, 60000));\n\t\t\t\t\t\t}\n\t\t\t\t\t\tcheck();\n\t\t\t\t\t})();\n\t\t\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</header><main>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "<main id=\"main\" tabindex=\"-1\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -86,7 +412,7 @@ func Layout(title string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</main></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "</main><div id=\"announcer-polite\" class=\"visually-hidden\" aria-live=\"polite\"></div><div id=\"announcer-assertive\" class=\"visually-hidden\" aria-live=\"assertive\"></div><script>\n\t\t\t\t// Announces htmx progress and results to screen readers, and moves focus to the updated\n\t\t\t\t// region when the focused element was replaced, so keyboard users do not lose their place\n\t\t\t\t(function () {\n\t\t\t\t\tfunction announce(text, assertive) {\n\t\t\t\t\t\tvar region = document.getElementById(assertive ? \"announcer-assertive\" : \"announcer-polite\");\n\t\t\t\t\t\tregion.textContent = \"\";\n\t\t\t\t\t\tsetTimeout(function () { region.textContent = text; }, 100);\n\t\t\t\t\t}\n\t\t\t\t\tdocument.body.addEventListener(\"htmx:beforeRequest\", function (event) {\n\t\t\t\t\t\tvar source = event.detail.elt.closest(\"[hx-indicator]\");\n\t\t\t\t\t\tvar indicator = source && document.querySelector(source.getAttribute(\"hx-indicator\"));\n\t\t\t\t\t\tif (indicator) {\n\t\t\t\t\t\t\tannounce(indicator.textContent.trim(), false);\n\t\t\t\t\t\t}\n\t\t\t\t\t});\n\t\t\t\t\tdocument.body.addEventListener(\"htmx:afterSwap\", function (event) {\n\t\t\t\t\t\tvar target = event.detail.target;\n\t\t\t\t\t\tvar result = target.matches(\".result\") ? target : target.querySelector(\".result\");\n\t\t\t\t\t\tif (result) {\n\t\t\t\t\t\t\tannounce(result.textContent.trim(), result.classList.contains(\"error\"));\n\t\t\t\t\t\t}\n\t\t\t\t\t\tvar focused = document.activeElement;\n\t\t\t\t\t\tif (!focused || focused === document.body || !document.contains(focused)) {\n\t\t\t\t\t\t\tif (!target.hasAttribute(\"tabindex\")) {\n\t\t\t\t\t\t\t\ttarget.setAttribute(\"tabindex\", \"-1\");\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\ttarget.focus();\n\t\t\t\t\t\t}\n\t\t\t\t\t});\n\t\t\t\t})();\n\t\t\t</script></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
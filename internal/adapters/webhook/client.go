@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+)
+
+// userAgent identifies the indexer to webhook receivers
+const userAgent = "talks-indexer-webhooks/1.0"
+
+// Client implements the WebhookSender interface over HTTP
+type Client struct {
+	httpClient *http.Client
+}
+
+// New creates a new webhook Client, retrieving configuration from context
+func New(ctx context.Context) *Client {
+	cfg := config.GetConfig(ctx)
+	return NewWithHTTPClient(&http.Client{Timeout: cfg.WebhookDelivery.Timeout})
+}
+
+// NewWithHTTPClient creates a new webhook Client with a custom HTTP client.
+// This constructor is primarily intended for testing purposes.
+func NewWithHTTPClient(httpClient *http.Client) *Client {
+	return &Client{httpClient: httpClient}
+}
+
+// SendWebhook posts a JSON payload to the URL and returns the response status code.
+// Redirects are not followed, so receivers must register their final URL.
+func (c *Client) SendWebhook(ctx context.Context, url string, body []byte, headers map[string]string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	client := *c.httpClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SendWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "signature", r.Header.Get("X-Webhook-Signature"))
+		assert.Equal(t, userAgent, r.UserAgent())
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"type":"reindex.completed"}`, string(body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewWithHTTPClient(&http.Client{})
+	status, err := client.SendWebhook(context.Background(), server.URL, []byte(`{"type":"reindex.completed"}`),
+		map[string]string{"X-Webhook-Signature": "signature"})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+}
+
+func TestClient_SendWebhook_DoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := NewWithHTTPClient(&http.Client{})
+	status, err := client.SendWebhook(context.Background(), server.URL, []byte(`{}`), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, status)
+}
+
+func TestClient_SendWebhook_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewWithHTTPClient(&http.Client{})
+	_, err := client.SendWebhook(context.Background(), server.URL, []byte(`{}`), nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// accessUsersKey is the settings key holding the admin allowlist
+const accessUsersKey = "access:users"
+
+// ErrLastAdmin is returned when a change would leave nobody able to manage users
+var ErrLastAdmin = errors.New("at least one admin is required")
+
+// AccessService manages the admin allowlist and role assignments, stored in a settings document.
+// Bootstrap admins from configuration are always allowed in. While the allowlist is empty and no
+// bootstrap admins are configured, every authenticated user is an admin, as before the allowlist existed.
+type AccessService struct {
+	store           ports.SettingsStore
+	bootstrapAdmins []string
+	requireListed   bool
+	logger          *slog.Logger
+
+	mu sync.Mutex
+}
+
+// NewAccessService creates a new AccessService backed by the given settings store
+func NewAccessService(store ports.SettingsStore, bootstrapAdmins []string) *AccessService {
+	admins := make([]string, 0, len(bootstrapAdmins))
+	for _, email := range bootstrapAdmins {
+		if email = normalizeEmail(email); email != "" {
+			admins = append(admins, email)
+		}
+	}
+
+	return &AccessService{
+		store:           store,
+		bootstrapAdmins: admins,
+		logger:          slog.Default().With("component", "access"),
+	}
+}
+
+// RequireAllowlist turns off open access while the allowlist is empty, for when roles are also
+// granted by OIDC groups and users outside both must be kept out
+func (s *AccessService) RequireAllowlist() {
+	s.requireListed = true
+}
+
+// RoleFor returns the role of the given user, or false if the user is not allowed in
+func (s *AccessService) RoleFor(ctx context.Context, email string) (domain.Role, bool, error) {
+	email = normalizeEmail(email)
+	if slices.Contains(s.bootstrapAdmins, email) {
+		return domain.RoleAdmin, true, nil
+	}
+
+	users, err := s.loadUsers(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(users) == 0 && len(s.bootstrapAdmins) == 0 && !s.requireListed {
+		return domain.RoleAdmin, true, nil
+	}
+
+	for _, user := range users {
+		if user.Email == email {
+			return user.Role, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// ListUsers returns all allowed users sorted by email, including bootstrap admins from configuration
+func (s *AccessService) ListUsers(ctx context.Context) ([]domain.UserAccess, error) {
+	users, err := s.loadUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, email := range s.bootstrapAdmins {
+		users = slices.DeleteFunc(users, func(user domain.UserAccess) bool { return user.Email == email })
+		users = append(users, domain.UserAccess{Email: email, Role: domain.RoleAdmin, FromConfig: true})
+	}
+
+	slices.SortFunc(users, func(a, b domain.UserAccess) int { return strings.Compare(a.Email, b.Email) })
+	return users, nil
+}
+
+// SetUserRole adds a user to the allowlist or changes their role
+func (s *AccessService) SetUserRole(ctx context.Context, email string, role domain.Role, updatedBy string) error {
+	email = normalizeEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil || !strings.Contains(email, "@") {
+		return fmt.Errorf("invalid email address %q", email)
+	}
+	if !role.IsValid() {
+		return fmt.Errorf("invalid role %q", role)
+	}
+	if slices.Contains(s.bootstrapAdmins, email) {
+		return fmt.Errorf("%s is an admin from configuration and cannot be changed here", email)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users, err := s.loadUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry := domain.UserAccess{Email: email, Role: role, UpdatedAt: time.Now().UTC(), UpdatedBy: updatedBy}
+	if i := slices.IndexFunc(users, func(user domain.UserAccess) bool { return user.Email == email }); i >= 0 {
+		users[i] = entry
+	} else {
+		users = append(users, entry)
+	}
+
+	if err := s.saveUsers(ctx, users); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "user role set", "email", email, "role", role, "updatedBy", updatedBy)
+	return nil
+}
+
+// RemoveUser removes a user from the allowlist
+func (s *AccessService) RemoveUser(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+	if slices.Contains(s.bootstrapAdmins, email) {
+		return fmt.Errorf("%s is an admin from configuration and cannot be removed here", email)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users, err := s.loadUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := slices.DeleteFunc(users, func(user domain.UserAccess) bool { return user.Email == email })
+	if len(remaining) == len(users) {
+		return fmt.Errorf("user %s is not in the allowlist", email)
+	}
+
+	if err := s.saveUsers(ctx, remaining); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "user removed", "email", email)
+	return nil
+}
+
+// loadUsers reads the stored allowlist
+func (s *AccessService) loadUsers(ctx context.Context) ([]domain.UserAccess, error) {
+	var users []domain.UserAccess
+	if _, err := s.store.LoadSetting(ctx, accessUsersKey, &users); err != nil {
+		return nil, fmt.Errorf("failed to load allowlist: %w", err)
+	}
+	return users, nil
+}
+
+// saveUsers stores the allowlist, refusing changes that would leave no admin
+func (s *AccessService) saveUsers(ctx context.Context, users []domain.UserAccess) error {
+	if len(s.bootstrapAdmins) == 0 && !slices.ContainsFunc(users, func(user domain.UserAccess) bool {
+		return user.Role == domain.RoleAdmin
+	}) {
+		return ErrLastAdmin
+	}
+
+	if err := s.store.SaveSetting(ctx, accessUsersKey, users); err != nil {
+		return fmt.Errorf("failed to save allowlist: %w", err)
+	}
+	return nil
+}
+
+// normalizeEmail lowercases and trims an email so lookups are case-insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccess_OpenWithoutAllowlist(t *testing.T) {
+	service := NewAccessService(newMockSettingsStore(), nil)
+
+	role, allowed, err := service.RoleFor(context.Background(), "anyone@example.com")
+
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, domain.RoleAdmin, role)
+}
+
+func TestAccess_RequireAllowlist(t *testing.T) {
+	service := NewAccessService(newMockSettingsStore(), nil)
+	service.RequireAllowlist()
+
+	_, allowed, err := service.RoleFor(context.Background(), "anyone@example.com")
+
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestAccess_BootstrapAdmins(t *testing.T) {
+	service := NewAccessService(newMockSettingsStore(), []string{" Admin@Example.com ", ""})
+	ctx := context.Background()
+
+	role, allowed, err := service.RoleFor(ctx, "admin@example.com")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, domain.RoleAdmin, role)
+
+	_, allowed, err = service.RoleFor(ctx, "other@example.com")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	users, err := service.ListUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserAccess{{Email: "admin@example.com", Role: domain.RoleAdmin, FromConfig: true}}, users)
+
+	assert.Error(t, service.SetUserRole(ctx, "admin@example.com", domain.RoleViewer, "other@example.com"))
+	assert.Error(t, service.RemoveUser(ctx, "admin@example.com"))
+}
+
+func TestAccess_SetUserRole(t *testing.T) {
+	service := NewAccessService(newMockSettingsStore(), nil)
+	ctx := context.Background()
+
+	require.NoError(t, service.SetUserRole(ctx, "Admin@Example.com", domain.RoleAdmin, "admin@example.com"))
+	require.NoError(t, service.SetUserRole(ctx, "viewer@example.com", domain.RoleViewer, "admin@example.com"))
+	require.NoError(t, service.SetUserRole(ctx, "viewer@example.com", domain.RoleOperator, "admin@example.com"))
+
+	role, allowed, err := service.RoleFor(ctx, "VIEWER@example.com")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, domain.RoleOperator, role)
+
+	// Once the allowlist has entries, users not in it are rejected
+	_, allowed, err = service.RoleFor(ctx, "stranger@example.com")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	users, err := service.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "admin@example.com", users[0].Email)
+	assert.Equal(t, "viewer@example.com", users[1].Email)
+	assert.Equal(t, domain.RoleOperator, users[1].Role)
+	assert.Equal(t, "admin@example.com", users[1].UpdatedBy)
+}
+
+func TestAccess_SetUserRole_Invalid(t *testing.T) {
+	service := NewAccessService(newMockSettingsStore(), nil)
+	ctx := context.Background()
+
+	assert.Error(t, service.SetUserRole(ctx, "not-an-email", domain.RoleAdmin, ""))
+	assert.Error(t, service.SetUserRole(ctx, "user@example.com", "superuser", ""))
+}
+
+func TestAccess_KeepsAnAdmin(t *testing.T) {
+	service := NewAccessService(newMockSettingsStore(), nil)
+	ctx := context.Background()
+
+	// The first user must be an admin, or nobody could manage the allowlist
+	assert.ErrorIs(t, service.SetUserRole(ctx, "viewer@example.com", domain.RoleViewer, ""), ErrLastAdmin)
+
+	require.NoError(t, service.SetUserRole(ctx, "admin@example.com", domain.RoleAdmin, ""))
+	require.NoError(t, service.SetUserRole(ctx, "viewer@example.com", domain.RoleViewer, ""))
+
+	assert.ErrorIs(t, service.SetUserRole(ctx, "admin@example.com", domain.RoleOperator, ""), ErrLastAdmin)
+	assert.ErrorIs(t, service.RemoveUser(ctx, "admin@example.com"), ErrLastAdmin)
+
+	require.NoError(t, service.RemoveUser(ctx, "viewer@example.com"))
+	assert.Error(t, service.RemoveUser(ctx, "viewer@example.com"))
+}
+
+func TestAccess_LoadError(t *testing.T) {
+	store := newMockSettingsStore()
+	store.loadErr = errors.New("connection refused")
+	service := NewAccessService(store, nil)
+
+	_, _, err := service.RoleFor(context.Background(), "user@example.com")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// scrubPatterns match personal details that may be embedded in free text
+var scrubPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{emailPattern, "[email]"},
+	{regexp.MustCompile(`https?://\S+`), "[url]"},
+	{regexp.MustCompile(`(^|\s)@[A-Za-z0-9_]{2,}`), "$1[handle]"},
+}
+
+// AnonymizationRules controls which data survives the anonymized research export
+type AnonymizationRules struct {
+	KeepFields []string
+	Statuses   []string
+	IDSalt     string
+	ScrubText  bool
+}
+
+// NewAnonymizationRules creates AnonymizationRules from configuration
+func NewAnonymizationRules(cfg config.AnonymizeConfig) AnonymizationRules {
+	return AnonymizationRules{
+		KeepFields: cfg.KeepFields,
+		Statuses:   cfg.Statuses,
+		IDSalt:     cfg.IDSalt,
+		ScrubText:  cfg.ScrubText,
+	}
+}
+
+// Includes returns true if a talk with the given status belongs in the export
+func (r AnonymizationRules) Includes(status string) bool {
+	if len(r.Statuses) == 0 {
+		return true
+	}
+	for _, s := range r.Statuses {
+		if strings.EqualFold(strings.TrimSpace(s), status) {
+			return true
+		}
+	}
+	return false
+}
+
+// Anonymize converts a talk into its anonymized form. Speakers are reduced to a count,
+// only allowlisted data fields are kept, and retained text is optionally scrubbed.
+func (r AnonymizationRules) Anonymize(talk domain.Talk) domain.AnonymizedTalk {
+	result := domain.AnonymizedTalk{
+		ConferenceSlug: talk.ConferenceSlug,
+		Year:           conferenceYear(talk),
+		Status:         talk.Status,
+		SpeakerCount:   len(talk.Speakers),
+		Data:           make(map[string]interface{}),
+	}
+
+	if r.IDSalt != "" {
+		sum := sha256.Sum256([]byte(r.IDSalt + talk.ID))
+		result.ID = hex.EncodeToString(sum[:])[:16]
+	}
+
+	for _, field := range r.KeepFields {
+		field = strings.TrimSpace(field)
+		value, ok := talk.Data[field]
+		if !ok {
+			continue
+		}
+		result.Data[field] = r.scrubValue(value)
+	}
+
+	return result
+}
+
+// scrubValue masks personal details in string values (and string slices) when enabled
+func (r AnonymizationRules) scrubValue(value interface{}) interface{} {
+	if !r.ScrubText {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		return scrubText(v)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = r.scrubValue(item)
+		}
+		return result
+	case []string:
+		result := make([]string, len(v))
+		for i, item := range v {
+			result[i] = scrubText(item)
+		}
+		return result
+	}
+	return value
+}
+
+// scrubText replaces emails, URLs, phone numbers and social handles with placeholders
+func scrubText(text string) string {
+	for _, p := range scrubPatterns {
+		text = p.pattern.ReplaceAllString(text, p.replacement)
+	}
+	masked, _ := maskPhoneNumbers(text)
+	return masked
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAnonymizationRules returns the default rules as loaded from configuration
+func testAnonymizationRules() AnonymizationRules {
+	return AnonymizationRules{
+		KeepFields: []string{"title", "abstract", "format", "language", "length", "level", "keywords"},
+		ScrubText:  true,
+	}
+}
+
+func TestNewAnonymizationRules(t *testing.T) {
+	rules := NewAnonymizationRules(config.AnonymizeConfig{
+		KeepFields: []string{"title"},
+		Statuses:   []string{"APPROVED"},
+		IDSalt:     "salt",
+		ScrubText:  true,
+	})
+
+	assert.Equal(t, []string{"title"}, rules.KeepFields)
+	assert.Equal(t, []string{"APPROVED"}, rules.Statuses)
+	assert.Equal(t, "salt", rules.IDSalt)
+	assert.True(t, rules.ScrubText)
+}
+
+func TestAnonymize_StripsSpeakerIdentityAndPrivateFields(t *testing.T) {
+	talk := domain.Talk{
+		ID:             "talk-1",
+		ConferenceSlug: "javazone2024",
+		Status:         "APPROVED",
+		Speakers: domain.Speakers{
+			{ID: "s1", Name: "Jane Doe", Data: map[string]interface{}{"bio": "Developer"}},
+			{ID: "s2", Name: "John Doe"},
+		},
+		Data: map[string]interface{}{
+			"title":                  "Virtual threads in practice",
+			"abstract":               "Learn about Loom",
+			"format":                 "presentation",
+			"postedBy":               "jane@example.com",
+			"infoToProgramCommittee": "I gave this talk last year",
+			"pkomfeedbacks":          []interface{}{"looks good"},
+		},
+	}
+
+	result := testAnonymizationRules().Anonymize(talk)
+
+	assert.Empty(t, result.ID)
+	assert.Equal(t, "javazone2024", result.ConferenceSlug)
+	assert.Equal(t, 2024, result.Year)
+	assert.Equal(t, "APPROVED", result.Status)
+	assert.Equal(t, 2, result.SpeakerCount)
+	assert.Equal(t, map[string]interface{}{
+		"title":    "Virtual threads in practice",
+		"abstract": "Learn about Loom",
+		"format":   "presentation",
+	}, result.Data)
+}
+
+func TestAnonymize_PseudonymousIDs(t *testing.T) {
+	rules := testAnonymizationRules()
+	rules.IDSalt = "research-2024"
+
+	first := rules.Anonymize(domain.Talk{ID: "talk-1"})
+	again := rules.Anonymize(domain.Talk{ID: "talk-1"})
+	other := rules.Anonymize(domain.Talk{ID: "talk-2"})
+
+	assert.Len(t, first.ID, 16)
+	assert.NotEqual(t, "talk-1", first.ID)
+	assert.Equal(t, first.ID, again.ID)
+	assert.NotEqual(t, first.ID, other.ID)
+}
+
+func TestAnonymize_ScrubsText(t *testing.T) {
+	talk := domain.Talk{
+		Data: map[string]interface{}{
+			"abstract": "Mail jane.doe@example.com or call +47 912 34 567, see https://example.com/me and follow @janedoe. Covers Java 8 - 21 from 2014 to 2024.",
+			"keywords": []interface{}{"java", "contact@example.com"},
+		},
+	}
+
+	result := testAnonymizationRules().Anonymize(talk)
+
+	assert.Equal(t,
+		"Mail [email] or call [phone], see [url] and follow [handle]. Covers Java 8 - 21 from 2014 to 2024.",
+		result.Data["abstract"],
+	)
+	assert.Equal(t, []interface{}{"java", "[email]"}, result.Data["keywords"])
+}
+
+func TestAnonymize_ScrubDisabled(t *testing.T) {
+	rules := testAnonymizationRules()
+	rules.ScrubText = false
+
+	result := rules.Anonymize(domain.Talk{Data: map[string]interface{}{"abstract": "Mail jane@example.com"}})
+
+	assert.Equal(t, "Mail jane@example.com", result.Data["abstract"])
+}
+
+func TestAnonymizationRules_Includes(t *testing.T) {
+	assert.True(t, AnonymizationRules{}.Includes("DRAFT"))
+
+	rules := AnonymizationRules{Statuses: []string{"APPROVED", " rejected "}}
+	assert.True(t, rules.Includes("APPROVED"))
+	assert.True(t, rules.Includes("REJECTED"))
+	assert.False(t, rules.Includes("DRAFT"))
+}
+
+func TestAnonymizedDataset(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return []domain.Talk{
+				{ID: "talk-1", Status: "APPROVED", Data: map[string]interface{}{"title": "Talk 1"}},
+				{ID: "talk-2", Status: "DRAFT", Data: map[string]interface{}{"title": "Talk 2"}},
+			}, nil
+		},
+	}
+
+	rules := testAnonymizationRules()
+	rules.Statuses = []string{"APPROVED"}
+
+	service := NewReportServiceWithConfig(reader, "private", rules)
+	dataset, err := service.AnonymizedDataset(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"private"}, reader.fetchCalls)
+	require.Len(t, dataset, 1)
+	assert.Equal(t, "Talk 1", dataset[0].Data["title"])
+}
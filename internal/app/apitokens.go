@@ -0,0 +1,299 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// apiTokensKey is the settings key holding the API tokens created in the admin UI
+const apiTokensKey = "api:tokens"
+
+// maxAPITokenLabelLength bounds token labels, which are shown in the audit log
+const maxAPITokenLabelLength = 100
+
+// apiTokenUsageResolution is how often the last use of a token is saved, so busy callers do not
+// write the settings document on every request
+const apiTokenUsageResolution = time.Minute
+
+// APITokenService manages the API tokens admins create for their own automation, stored as SHA-256
+// digests in a settings document. Each admin changes their own tokens, and can list and revoke those
+// of every admin. A token only works while its owner is still an admin.
+type APITokenService struct {
+	store  ports.SettingsStore
+	users  ports.UserDirectory
+	now    func() time.Time
+	logger *slog.Logger
+
+	mu sync.Mutex
+}
+
+// NewAPITokenService creates a new APITokenService backed by the given settings store
+func NewAPITokenService(store ports.SettingsStore) *APITokenService {
+	return &APITokenService{
+		store:  store,
+		now:    time.Now,
+		logger: slog.Default().With("component", "api-tokens"),
+	}
+}
+
+// SetClock replaces the system clock recording when tokens are created, rotated and used
+func (s *APITokenService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// SetUserDirectory checks the current role of a token's owner whenever the token is used, so tokens
+// of admins who are removed or demoted stop working
+func (s *APITokenService) SetUserDirectory(users ports.UserDirectory) {
+	s.users = users
+}
+
+// ListTokens returns the tokens of the given owner, ordered by label
+func (s *APITokenService) ListTokens(ctx context.Context, owner string) ([]domain.APIToken, error) {
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owner = normalizeEmail(owner)
+	owned := slices.DeleteFunc(tokens, func(token domain.APIToken) bool { return token.Owner != owner })
+	slices.SortFunc(owned, func(a, b domain.APIToken) int { return strings.Compare(a.Label, b.Label) })
+	return owned, nil
+}
+
+// ListAllTokens returns the tokens of every owner, ordered by owner and label
+func (s *APITokenService) ListAllTokens(ctx context.Context) ([]domain.APIToken, error) {
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(tokens, func(a, b domain.APIToken) int {
+		if c := strings.Compare(a.Owner, b.Owner); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Label, b.Label)
+	})
+	return tokens, nil
+}
+
+// CreateToken creates a labelled token for the owner and returns it along with the token itself
+func (s *APITokenService) CreateToken(ctx context.Context, owner, label string) (domain.APIToken, string, error) {
+	owner = normalizeEmail(owner)
+	label = strings.TrimSpace(label)
+	if owner == "" {
+		return domain.APIToken{}, "", errors.New("API tokens need a logged-in owner")
+	}
+	if label == "" || len(label) > maxAPITokenLabelLength {
+		return domain.APIToken{}, "", fmt.Errorf("the label must be between 1 and %d characters", maxAPITokenLabelLength)
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return domain.APIToken{}, "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return domain.APIToken{}, "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return domain.APIToken{}, "", err
+	}
+	if slices.ContainsFunc(tokens, func(token domain.APIToken) bool { return token.Owner == owner && token.Label == label }) {
+		return domain.APIToken{}, "", fmt.Errorf("you already have a token labelled %q", label)
+	}
+
+	token := domain.APIToken{
+		ID:        id,
+		Label:     label,
+		Owner:     owner,
+		Digest:    tokenDigest(secret),
+		CreatedAt: s.now().UTC(),
+	}
+	if err := s.saveTokens(ctx, append(tokens, token)); err != nil {
+		return domain.APIToken{}, "", err
+	}
+
+	s.logger.InfoContext(ctx, "API token created", "tokenID", id, "label", label, "owner", owner)
+	return token, secret, nil
+}
+
+// RotateToken replaces the owner's token with a new one. The old token stops working right away.
+func (s *APITokenService) RotateToken(ctx context.Context, owner, id string) (domain.APIToken, string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return domain.APIToken{}, "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return domain.APIToken{}, "", err
+	}
+	i := ownedTokenIndex(tokens, normalizeEmail(owner), id)
+	if i < 0 {
+		return domain.APIToken{}, "", fmt.Errorf("%w: %s", domain.ErrAPITokenNotFound, id)
+	}
+
+	rotatedAt := s.now().UTC()
+	tokens[i].Digest = tokenDigest(secret)
+	tokens[i].RotatedAt = &rotatedAt
+	tokens[i].LastUsedAt = nil
+	if err := s.saveTokens(ctx, tokens); err != nil {
+		return domain.APIToken{}, "", err
+	}
+
+	s.logger.InfoContext(ctx, "API token rotated", "tokenID", id, "label", tokens[i].Label, "owner", tokens[i].Owner)
+	return tokens[i], secret, nil
+}
+
+// RevokeToken deletes the owner's token
+func (s *APITokenService) RevokeToken(ctx context.Context, owner, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return err
+	}
+	i := ownedTokenIndex(tokens, normalizeEmail(owner), id)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", domain.ErrAPITokenNotFound, id)
+	}
+
+	revoked := tokens[i]
+	if err := s.saveTokens(ctx, slices.Delete(tokens, i, i+1)); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "API token revoked", "tokenID", id, "label", revoked.Label, "owner", revoked.Owner)
+	return nil
+}
+
+// RevokeAnyToken deletes a token of any owner, for admins cleaning up after someone else
+func (s *APITokenService) RevokeAnyToken(ctx context.Context, id, revokedBy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(tokens, func(token domain.APIToken) bool { return token.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", domain.ErrAPITokenNotFound, id)
+	}
+
+	revoked := tokens[i]
+	if err := s.saveTokens(ctx, slices.Delete(tokens, i, i+1)); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "API token revoked", "tokenID", id, "label", revoked.Label, "owner", revoked.Owner, "revokedBy", revokedBy)
+	return nil
+}
+
+// Authenticate returns the stored token matching the bearer token, comparing against every stored
+// token in constant time. Tokens whose owner is no longer an admin are not accepted. The time of use
+// is saved at most once per minute per token; failing to save it does not fail the request.
+func (s *APITokenService) Authenticate(ctx context.Context, secret string) (domain.APIToken, bool, error) {
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return domain.APIToken{}, false, err
+	}
+
+	digest := tokenDigest(secret)
+	match := -1
+	for i, token := range tokens {
+		if subtle.ConstantTimeCompare([]byte(digest), []byte(token.Digest)) == 1 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return domain.APIToken{}, false, nil
+	}
+
+	token := tokens[match]
+	if s.users != nil {
+		role, allowed, err := s.users.RoleFor(ctx, token.Owner)
+		if err != nil {
+			return domain.APIToken{}, false, fmt.Errorf("failed to look up the role of %s: %w", token.Owner, err)
+		}
+		if !allowed || !role.Allows(domain.RoleAdmin) {
+			s.logger.WarnContext(ctx, "rejected API token of an owner who is no longer an admin", "tokenID", token.ID, "owner", token.Owner, "role", role)
+			return domain.APIToken{}, false, nil
+		}
+	}
+
+	now := s.now().UTC()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= apiTokenUsageResolution {
+		token.LastUsedAt = &now
+		if err := s.recordUse(ctx, token.ID, now); err != nil {
+			s.logger.WarnContext(ctx, "failed to record API token use", "tokenID", token.ID, "error", err)
+		}
+	}
+	return token, true, nil
+}
+
+// recordUse saves the time the token was last used, unless it has been revoked meanwhile
+func (s *APITokenService) recordUse(ctx context.Context, id string, usedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.loadTokens(ctx)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(tokens, func(token domain.APIToken) bool { return token.ID == id })
+	if i < 0 {
+		return nil
+	}
+	tokens[i].LastUsedAt = &usedAt
+	return s.saveTokens(ctx, tokens)
+}
+
+// loadTokens reads the stored tokens of all owners
+func (s *APITokenService) loadTokens(ctx context.Context) ([]domain.APIToken, error) {
+	var tokens []domain.APIToken
+	if _, err := s.store.LoadSetting(ctx, apiTokensKey, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to load API tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// saveTokens stores the tokens of all owners
+func (s *APITokenService) saveTokens(ctx context.Context, tokens []domain.APIToken) error {
+	if err := s.store.SaveSetting(ctx, apiTokensKey, tokens); err != nil {
+		return fmt.Errorf("failed to save API tokens: %w", err)
+	}
+	return nil
+}
+
+// ownedTokenIndex returns the position of the owner's token with the ID, or -1
+func ownedTokenIndex(tokens []domain.APIToken, owner, id string) int {
+	return slices.IndexFunc(tokens, func(token domain.APIToken) bool { return token.ID == id && token.Owner == owner })
+}
+
+// tokenDigest returns the hex SHA-256 digest a token is stored as
+func tokenDigest(secret string) string {
+	digest := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(digest[:])
+}
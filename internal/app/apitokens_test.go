@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITokens_Lifecycle(t *testing.T) {
+	now := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	service := NewAPITokenService(newMockSettingsStore())
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	created, secret, err := service.CreateToken(ctx, "Admin@Example.com", " ci ")
+	require.NoError(t, err)
+	assert.Equal(t, "ci", created.Label)
+	assert.Equal(t, "admin@example.com", created.Owner)
+	assert.NotContains(t, created.Digest, secret, "only the digest is stored")
+	assert.Equal(t, "ci (admin@example.com)", created.ActorName())
+
+	_, _, err = service.CreateToken(ctx, "admin@example.com", "ci")
+	assert.Error(t, err, "labels are unique per owner")
+	_, _, err = service.CreateToken(ctx, "other@example.com", "ci")
+	require.NoError(t, err)
+
+	// Each owner only sees their own tokens
+	tokens, err := service.ListTokens(ctx, "admin@example.com")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Nil(t, tokens[0].LastUsedAt)
+
+	token, ok, err := service.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, created.ID, token.ID)
+	tokens, _ = service.ListTokens(ctx, "admin@example.com")
+	assert.Equal(t, &now, tokens[0].LastUsedAt)
+
+	// Someone else cannot rotate or revoke the token
+	_, _, err = service.RotateToken(ctx, "other@example.com", created.ID)
+	assert.ErrorIs(t, err, domain.ErrAPITokenNotFound)
+	assert.ErrorIs(t, service.RevokeToken(ctx, "other@example.com", created.ID), domain.ErrAPITokenNotFound)
+
+	rotated, newSecret, err := service.RotateToken(ctx, "admin@example.com", created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, rotated.ID)
+	assert.Equal(t, &now, rotated.RotatedAt)
+	assert.Nil(t, rotated.LastUsedAt)
+	_, ok, _ = service.Authenticate(ctx, secret)
+	assert.False(t, ok, "the old token stops working")
+	_, ok, _ = service.Authenticate(ctx, newSecret)
+	assert.True(t, ok)
+
+	require.NoError(t, service.RevokeToken(ctx, "admin@example.com", created.ID))
+	_, ok, _ = service.Authenticate(ctx, newSecret)
+	assert.False(t, ok)
+	tokens, _ = service.ListTokens(ctx, "admin@example.com")
+	assert.Empty(t, tokens)
+}
+
+func TestAPITokens_RecordsUseOncePerMinute(t *testing.T) {
+	now := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	store := newMockSettingsStore()
+	service := NewAPITokenService(store)
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, secret, err := service.CreateToken(ctx, "admin@example.com", "ci")
+	require.NoError(t, err)
+
+	firstUse := now
+	_, _, _ = service.Authenticate(ctx, secret)
+	now = now.Add(30 * time.Second)
+	_, _, _ = service.Authenticate(ctx, secret)
+
+	tokens, _ := service.ListTokens(ctx, "admin@example.com")
+	assert.Equal(t, &firstUse, tokens[0].LastUsedAt)
+
+	now = now.Add(30 * time.Second)
+	_, _, _ = service.Authenticate(ctx, secret)
+	tokens, _ = service.ListTokens(ctx, "admin@example.com")
+	assert.Equal(t, &now, tokens[0].LastUsedAt)
+}
+
+func TestAPITokens_InvalidLabel(t *testing.T) {
+	service := NewAPITokenService(newMockSettingsStore())
+	ctx := context.Background()
+
+	_, _, err := service.CreateToken(ctx, "admin@example.com", "  ")
+	assert.Error(t, err)
+	_, _, err = service.CreateToken(ctx, "", "ci")
+	assert.Error(t, err)
+}
+
+func TestAPITokens_RejectsOwnerNoLongerAdmin(t *testing.T) {
+	store := newMockSettingsStore()
+	access := NewAccessService(store, []string{"root@example.com"})
+	service := NewAPITokenService(store)
+	service.SetUserDirectory(access)
+	ctx := context.Background()
+
+	require.NoError(t, access.SetUserRole(ctx, "ada@example.com", domain.RoleAdmin, "root@example.com"))
+	_, secret, err := service.CreateToken(ctx, "ada@example.com", "ci")
+	require.NoError(t, err)
+
+	_, ok, err := service.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, access.SetUserRole(ctx, "ada@example.com", domain.RoleOperator, "root@example.com"))
+	_, ok, err = service.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	assert.False(t, ok, "demoted owners' tokens stop working")
+
+	require.NoError(t, access.SetUserRole(ctx, "ada@example.com", domain.RoleAdmin, "root@example.com"))
+	require.NoError(t, access.RemoveUser(ctx, "ada@example.com"))
+	_, ok, err = service.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	assert.False(t, ok, "removed owners' tokens stop working")
+}
+
+func TestAPITokens_AdminsManageAllTokens(t *testing.T) {
+	service := NewAPITokenService(newMockSettingsStore())
+	ctx := context.Background()
+
+	_, _, err := service.CreateToken(ctx, "grace@example.com", "deploy")
+	require.NoError(t, err)
+	ada, secret, err := service.CreateToken(ctx, "ada@example.com", "ci")
+	require.NoError(t, err)
+
+	tokens, err := service.ListAllTokens(ctx)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "ada@example.com", tokens[0].Owner)
+	assert.Equal(t, "grace@example.com", tokens[1].Owner)
+
+	require.NoError(t, service.RevokeAnyToken(ctx, ada.ID, "grace@example.com"))
+	_, ok, _ := service.Authenticate(ctx, secret)
+	assert.False(t, ok)
+	assert.ErrorIs(t, service.RevokeAnyToken(ctx, ada.ID, "grace@example.com"), domain.ErrAPITokenNotFound)
+}
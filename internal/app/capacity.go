@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// CapacityChecker verifies that the cluster has disk room for a bulk job before it starts, so a full
+// reindex or republish is refused up front rather than filling the disks halfway through.
+// The payload is estimated from the document count and the average document size of the live indexes.
+type CapacityChecker struct {
+	admin           ports.IndexAdmin
+	disk            ports.ClusterCapacity
+	maxDiskPercent  int
+	defaultDocBytes int64
+	overheadPercent int
+	logger          *slog.Logger
+}
+
+// NewCapacityChecker creates a new CapacityChecker, receiving context as first parameter
+// to retrieve configuration.
+func NewCapacityChecker(ctx context.Context, admin ports.IndexAdmin, disk ports.ClusterCapacity) *CapacityChecker {
+	return NewCapacityCheckerWithConfig(admin, disk, config.GetConfig(ctx).Capacity)
+}
+
+// NewCapacityCheckerWithConfig creates a new CapacityChecker with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewCapacityCheckerWithConfig(admin ports.IndexAdmin, disk ports.ClusterCapacity, cfg config.CapacityConfig) *CapacityChecker {
+	return &CapacityChecker{
+		admin:           admin,
+		disk:            disk,
+		maxDiskPercent:  cfg.MaxDiskPercent,
+		defaultDocBytes: cfg.DefaultDocBytes,
+		overheadPercent: cfg.OverheadPercent,
+		logger:          slog.Default().With("component", "capacity"),
+	}
+}
+
+// Estimate compares the disk space needed to write the documents into indexes like the given ones with
+// the room left below the disk limit. If reclaim is set, the job deletes the given indexes before
+// writing, so their size counts as available.
+func (c *CapacityChecker) Estimate(ctx context.Context, documents int, indexNames []string, reclaim bool) (domain.CapacityEstimate, error) {
+	var docCount, sizeBytes int64
+	for _, indexName := range indexNames {
+		indexes, err := c.admin.ListIndexes(ctx, indexName)
+		if err != nil {
+			return domain.CapacityEstimate{}, fmt.Errorf("failed to list index %s: %w", indexName, err)
+		}
+		for _, index := range indexes {
+			docCount += index.DocCount
+			sizeBytes += index.SizeBytes
+		}
+	}
+
+	usage, err := c.disk.DiskUsage(ctx)
+	if err != nil {
+		return domain.CapacityEstimate{}, fmt.Errorf("failed to fetch disk usage: %w", err)
+	}
+
+	// The index size includes replicas, so the average does too
+	average := c.defaultDocBytes
+	if docCount > 0 && sizeBytes > 0 {
+		average = sizeBytes / docCount
+	}
+
+	estimate := domain.CapacityEstimate{
+		Documents:       documents,
+		AverageDocBytes: average,
+		RequiredBytes:   int64(documents) * average * int64(100+c.overheadPercent) / 100,
+		Disk:            usage,
+		LimitBytes:      usage.TotalBytes * int64(c.maxDiskPercent) / 100,
+	}
+	if reclaim {
+		estimate.ReclaimedBytes = sizeBytes
+	}
+	return estimate, nil
+}
+
+// Check estimates the disk space like Estimate and returns an error wrapping
+// domain.ErrInsufficientCapacity if the documents do not fit below the disk limit
+func (c *CapacityChecker) Check(ctx context.Context, documents int, indexNames []string, reclaim bool) (domain.CapacityEstimate, error) {
+	estimate, err := c.Estimate(ctx, documents, indexNames, reclaim)
+	if err != nil {
+		return domain.CapacityEstimate{}, fmt.Errorf("failed to estimate capacity: %w", err)
+	}
+	if !estimate.Fits() {
+		c.logger.WarnContext(ctx, "refused bulk job exceeding disk capacity",
+			"documents", documents,
+			"requiredBytes", estimate.RequiredBytes,
+			"availableBytes", estimate.AvailableBytes(),
+		)
+		return estimate, fmt.Errorf("%w: %s", domain.ErrInsufficientCapacity, estimate)
+	}
+	return estimate, nil
+}
+
+// SetCapacityChecker enables verifying the cluster's disk headroom before full reindexes and republishes
+func (s *IndexerService) SetCapacityChecker(capacity *CapacityChecker) {
+	s.capacity = capacity
+}
+
+// checkCapacity verifies that the documents fit on the cluster, if a capacity checker is set.
+// The estimate is added to the report of the running job.
+func (s *IndexerService) checkCapacity(ctx context.Context, documents int, reclaim bool) error {
+	if s.capacity == nil || documents == 0 {
+		return nil
+	}
+
+	estimate, err := s.capacity.Check(ctx, documents, []string{s.privateIndex, s.publicIndex}, reclaim)
+	if report := jobReportFromContext(ctx); report != nil {
+		step := domain.JobStep{
+			Name:       "check capacity",
+			State:      domain.JobStepSucceeded,
+			Detail:     estimate.String(),
+			FinishedAt: time.Now().UTC(),
+		}
+		if err != nil {
+			step.State = domain.JobStepFailed
+			step.Error = err.Error()
+		}
+		report.step(step)
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClusterCapacity is a mock implementation of ports.ClusterCapacity
+type mockClusterCapacity struct {
+	usage domain.DiskUsage
+}
+
+func (m *mockClusterCapacity) DiskUsage(ctx context.Context) (domain.DiskUsage, error) {
+	return m.usage, nil
+}
+
+func newTestCapacityChecker(indexes []domain.IndexInfo, usage domain.DiskUsage) *CapacityChecker {
+	return NewCapacityCheckerWithConfig(&mockIndexAdmin{indexes: indexes}, &mockClusterCapacity{usage: usage}, config.CapacityConfig{
+		MaxDiskPercent:  80,
+		DefaultDocBytes: 2000,
+		OverheadPercent: 20,
+	})
+}
+
+func TestCapacityChecker_Estimate(t *testing.T) {
+	// The mock lists the same index for both the private and public index
+	checker := newTestCapacityChecker(
+		[]domain.IndexInfo{{Name: "private", DocCount: 100, SizeBytes: 100_000}},
+		domain.DiskUsage{TotalBytes: 1_000_000, UsedBytes: 700_000},
+	)
+
+	estimate, err := checker.Estimate(context.Background(), 50, []string{"private", "public"}, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), estimate.AverageDocBytes)
+	assert.Equal(t, int64(60_000), estimate.RequiredBytes)
+	assert.Equal(t, int64(200_000), estimate.ReclaimedBytes)
+	assert.Equal(t, int64(800_000), estimate.LimitBytes)
+	assert.Equal(t, int64(300_000), estimate.AvailableBytes())
+	assert.True(t, estimate.Fits())
+
+	estimate, err = checker.Estimate(context.Background(), 50, []string{"private", "public"}, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(100_000), estimate.AvailableBytes())
+}
+
+func TestCapacityChecker_EstimateWithoutStats(t *testing.T) {
+	checker := newTestCapacityChecker(nil, domain.DiskUsage{TotalBytes: 1_000_000})
+
+	estimate, err := checker.Estimate(context.Background(), 10, []string{"private"}, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), estimate.AverageDocBytes, "the default document size is used for empty indexes")
+	assert.Equal(t, int64(24_000), estimate.RequiredBytes)
+}
+
+func TestIndexerService_ReindexAllRefusedWithoutCapacity(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{
+				{ID: "talk-1", ConferenceSlug: "javazone2024", Status: "APPROVED"},
+				{ID: "talk-2", ConferenceSlug: "javazone2024", Status: "SUBMITTED"},
+			}, nil
+		},
+	}
+	index := &mockSearchIndex{}
+	jobs := newMockJobStore()
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+	service.SetCapacityChecker(newTestCapacityChecker(
+		[]domain.IndexInfo{{Name: "private", DocCount: 10, SizeBytes: 10_000}},
+		domain.DiskUsage{TotalBytes: 1_000_000, UsedBytes: 850_000},
+	))
+
+	err := service.ReindexAll(context.Background())
+
+	require.ErrorIs(t, err, domain.ErrInsufficientCapacity)
+	assert.Equal(t, []string{"private_staging", "public_staging", "private_staging", "public_staging"}, index.deleteIndexCalls,
+		"the live indexes are kept when the job is refused")
+	assert.Empty(t, index.callsTo("private"))
+
+	job := jobs.jobs["job-1"]
+	assert.Equal(t, domain.JobStateFailed, job.State)
+	require.Len(t, job.Report.Steps, 1)
+	assert.Equal(t, "check capacity", job.Report.Steps[0].Name)
+	assert.Equal(t, domain.JobStepFailed, job.Report.Steps[0].State)
+	assert.Equal(t, "3 documents need about 3.5 KiB, 0 B available below the disk limit", job.Report.Steps[0].Detail)
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// conferenceMetadataKey is the settings key holding the conference metadata saved in the admin UI
+const conferenceMetadataKey = "conferences:metadata"
+
+// ConferenceCatalogService manages the per-conference metadata denormalized onto indexed talks.
+// Metadata comes from an optional JSON file and from entries saved in the admin UI, which are
+// stored in a settings document and override the file entry for the same conference.
+type ConferenceCatalogService struct {
+	store  ports.SettingsStore
+	file   map[string]domain.ConferenceMetadata
+	now    func() time.Time
+	logger *slog.Logger
+
+	mu sync.Mutex
+}
+
+// NewConferenceCatalogService creates a new ConferenceCatalogService, receiving context as first parameter
+// to retrieve configuration. It fails if the configured metadata file cannot be read or is invalid.
+func NewConferenceCatalogService(ctx context.Context, store ports.SettingsStore) (*ConferenceCatalogService, error) {
+	cfg := config.GetConfig(ctx)
+	file, err := LoadConferenceMetadataFile(cfg.Conference.MetadataFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewConferenceCatalogServiceWithConfig(store, file), nil
+}
+
+// NewConferenceCatalogServiceWithConfig creates a new ConferenceCatalogService with explicit file entries.
+// This constructor is primarily intended for testing purposes.
+func NewConferenceCatalogServiceWithConfig(store ports.SettingsStore, file map[string]domain.ConferenceMetadata) *ConferenceCatalogService {
+	return &ConferenceCatalogService{
+		store:  store,
+		file:   file,
+		now:    time.Now,
+		logger: slog.Default().With("component", "conferences"),
+	}
+}
+
+// LoadConferenceMetadataFile reads a JSON object mapping conference slugs to their metadata.
+// An empty path returns no entries.
+func LoadConferenceMetadataFile(path string) (map[string]domain.ConferenceMetadata, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conference metadata file: %w", err)
+	}
+
+	var entries map[string]domain.ConferenceMetadata
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse conference metadata file: %w", err)
+	}
+
+	file := make(map[string]domain.ConferenceMetadata, len(entries))
+	for slug, metadata := range entries {
+		if err := metadata.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid metadata for conference %s in %s: %w", slug, path, err)
+		}
+		file[normalizeSlug(slug)] = metadata
+	}
+	return file, nil
+}
+
+// ListConferenceMetadata returns the metadata of all conferences sorted by slug, including entries
+// from the metadata file that are not overridden
+func (s *ConferenceCatalogService) ListConferenceMetadata(ctx context.Context) ([]domain.ConferenceEntry, error) {
+	entries, err := s.loadEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for slug, metadata := range s.file {
+		if !slices.ContainsFunc(entries, func(entry domain.ConferenceEntry) bool { return entry.Slug == slug }) {
+			entries = append(entries, domain.ConferenceEntry{Slug: slug, ConferenceMetadata: metadata, FromFile: true})
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b domain.ConferenceEntry) int { return strings.Compare(a.Slug, b.Slug) })
+	return entries, nil
+}
+
+// SetConferenceMetadata stores the metadata of a conference, overriding any entry from the metadata file.
+// Indexed talks get the new metadata on their next reindex.
+func (s *ConferenceCatalogService) SetConferenceMetadata(ctx context.Context, slug string, metadata domain.ConferenceMetadata, updatedBy string) error {
+	slug = normalizeSlug(slug)
+	if slug == "" {
+		return fmt.Errorf("conference slug is required")
+	}
+	if metadata.IsZero() {
+		return fmt.Errorf("no metadata given for %s", slug)
+	}
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.loadEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry := domain.ConferenceEntry{Slug: slug, ConferenceMetadata: metadata, UpdatedAt: s.now().UTC(), UpdatedBy: updatedBy}
+	if i := slices.IndexFunc(entries, func(entry domain.ConferenceEntry) bool { return entry.Slug == slug }); i >= 0 {
+		entries[i] = entry
+	} else {
+		entries = append(entries, entry)
+	}
+
+	if err := s.saveEntries(ctx, entries); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "conference metadata set", "slug", slug, "updatedBy", updatedBy)
+	return nil
+}
+
+// RemoveConferenceMetadata removes the stored metadata of a conference. Entries from the metadata
+// file cannot be removed; removing an override restores the file entry.
+func (s *ConferenceCatalogService) RemoveConferenceMetadata(ctx context.Context, slug string) error {
+	slug = normalizeSlug(slug)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.loadEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := slices.DeleteFunc(entries, func(entry domain.ConferenceEntry) bool { return entry.Slug == slug })
+	if len(remaining) == len(entries) {
+		if _, ok := s.file[slug]; ok {
+			return fmt.Errorf("metadata for %s is from the metadata file and cannot be removed here", slug)
+		}
+		return fmt.Errorf("%w: %s", domain.ErrConferenceMetadataNotFound, slug)
+	}
+
+	if err := s.saveEntries(ctx, remaining); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "conference metadata removed", "slug", slug)
+	return nil
+}
+
+// loadEntries reads the entries saved in the admin UI
+func (s *ConferenceCatalogService) loadEntries(ctx context.Context) ([]domain.ConferenceEntry, error) {
+	var entries []domain.ConferenceEntry
+	if _, err := s.store.LoadSetting(ctx, conferenceMetadataKey, &entries); err != nil {
+		return nil, fmt.Errorf("failed to load conference metadata: %w", err)
+	}
+	return entries, nil
+}
+
+// saveEntries stores the entries saved in the admin UI
+func (s *ConferenceCatalogService) saveEntries(ctx context.Context, entries []domain.ConferenceEntry) error {
+	if err := s.store.SaveSetting(ctx, conferenceMetadataKey, entries); err != nil {
+		return fmt.Errorf("failed to save conference metadata: %w", err)
+	}
+	return nil
+}
+
+// normalizeSlug lowercases and trims a conference slug so lookups are case-insensitive
+func normalizeSlug(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
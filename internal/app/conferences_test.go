@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConferenceCatalog(store *mockSettingsStore) *ConferenceCatalogService {
+	return NewConferenceCatalogServiceWithConfig(store, map[string]domain.ConferenceMetadata{
+		"javazone2024": {Venue: "Nova Spektrum", StartDate: "2024-09-04", EndDate: "2024-09-05"},
+	})
+}
+
+func TestLoadConferenceMetadataFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("no file configured", func(t *testing.T) {
+		file, err := LoadConferenceMetadataFile("")
+		require.NoError(t, err)
+		assert.Nil(t, file)
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		path := filepath.Join(dir, "valid.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"JavaZone2025": {"venue": "Nova Spektrum", "startDate": "2025-09-03", "cfpCloses": "2025-04-15"}}`), 0o600))
+
+		file, err := LoadConferenceMetadataFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]domain.ConferenceMetadata{
+			"javazone2025": {Venue: "Nova Spektrum", StartDate: "2025-09-03", CFPCloses: "2025-04-15"},
+		}, file)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"javazone2025": {"startDate": "3 September 2025"}}`), 0o600))
+
+		_, err := LoadConferenceMetadataFile(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "javazone2025")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadConferenceMetadataFile(filepath.Join(dir, "missing.json"))
+		require.Error(t, err)
+	})
+}
+
+func TestConferenceCatalog_SetOverridesFile(t *testing.T) {
+	service := newTestConferenceCatalog(newMockSettingsStore())
+	ctx := context.Background()
+
+	require.NoError(t, service.SetConferenceMetadata(ctx, " JavaZone2024 ", domain.ConferenceMetadata{Venue: "Oslo Spektrum"}, "admin@example.com"))
+	require.NoError(t, service.SetConferenceMetadata(ctx, "javazone2025", domain.ConferenceMetadata{LogoURL: "https://example.com/logo.svg"}, "admin@example.com"))
+
+	entries, err := service.ListConferenceMetadata(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "javazone2024", entries[0].Slug)
+	assert.Equal(t, "Oslo Spektrum", entries[0].Venue)
+	assert.Empty(t, entries[0].StartDate, "a saved entry replaces the file entry entirely")
+	assert.False(t, entries[0].FromFile)
+	assert.Equal(t, "admin@example.com", entries[0].UpdatedBy)
+	assert.Equal(t, "javazone2025", entries[1].Slug)
+
+	require.NoError(t, service.RemoveConferenceMetadata(ctx, "javazone2024"))
+
+	entries, err = service.ListConferenceMetadata(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Nova Spektrum", entries[0].Venue, "removing the override restores the file entry")
+	assert.True(t, entries[0].FromFile)
+}
+
+func TestConferenceCatalog_SetValidates(t *testing.T) {
+	service := newTestConferenceCatalog(newMockSettingsStore())
+	ctx := context.Background()
+
+	assert.Error(t, service.SetConferenceMetadata(ctx, "", domain.ConferenceMetadata{Venue: "Oslo"}, ""))
+	assert.Error(t, service.SetConferenceMetadata(ctx, "javazone2025", domain.ConferenceMetadata{}, ""))
+	assert.Error(t, service.SetConferenceMetadata(ctx, "javazone2025", domain.ConferenceMetadata{StartDate: "2025-09-04", EndDate: "2025-09-03"}, ""))
+	assert.Error(t, service.SetConferenceMetadata(ctx, "javazone2025", domain.ConferenceMetadata{LogoURL: "javascript:alert(1)"}, ""))
+}
+
+func TestConferenceCatalog_Remove(t *testing.T) {
+	service := newTestConferenceCatalog(newMockSettingsStore())
+	ctx := context.Background()
+
+	err := service.RemoveConferenceMetadata(ctx, "javazone2024")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metadata file")
+
+	assert.ErrorIs(t, service.RemoveConferenceMetadata(ctx, "javazone2030"), domain.ErrConferenceMetadataNotFound)
+}
+
+func TestReindexConference_AttachesConferenceMetadata(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-1", ConferenceSlug: "javazone2024", Status: "APPROVED"}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetConferenceCatalog(newTestConferenceCatalog(newMockSettingsStore()))
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	require.Len(t, index.bulkIndexCalls, 2)
+	for _, call := range index.bulkIndexCalls {
+		require.NotNil(t, call.Talks[0].Conference, call.IndexName)
+		assert.Equal(t, "Nova Spektrum", call.Talks[0].Conference.Venue, call.IndexName)
+	}
+}
+
+func TestReindexConference_IndexesWithoutMetadataOnCatalogError(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-1", ConferenceSlug: "javazone2024", Status: "APPROVED"}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+	store := newMockSettingsStore()
+	store.loadErr = errors.New("settings unavailable")
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetConferenceCatalog(newTestConferenceCatalog(store))
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	require.Len(t, index.bulkIndexCalls, 2)
+	assert.Nil(t, index.bulkIndexCalls[0].Talks[0].Conference)
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// reviewerConflictsKey is the settings key holding the conflicts of interest registered in the admin UI
+const reviewerConflictsKey = "review:conflicts"
+
+// ReviewerConflictsService stores the conflicts of interest program committee members register in the
+// admin UI, which the indexer flags on the talks of the speakers and organizations
+type ReviewerConflictsService struct {
+	store  ports.SettingsStore
+	now    func() time.Time
+	logger *slog.Logger
+
+	mu sync.Mutex
+}
+
+// NewReviewerConflictsService creates a new ReviewerConflictsService backed by the given settings store
+func NewReviewerConflictsService(store ports.SettingsStore) *ReviewerConflictsService {
+	return &ReviewerConflictsService{
+		store:  store,
+		now:    time.Now,
+		logger: slog.Default().With("component", "conflicts"),
+	}
+}
+
+// ListConflicts returns the conflicts of every committee member, sorted by reviewer
+func (s *ReviewerConflictsService) ListConflicts(ctx context.Context) ([]domain.ReviewerConflicts, error) {
+	var entries []domain.ReviewerConflicts
+	if _, err := s.store.LoadSetting(ctx, reviewerConflictsKey, &entries); err != nil {
+		return nil, fmt.Errorf("failed to load conflicts: %w", err)
+	}
+	slices.SortFunc(entries, func(a, b domain.ReviewerConflicts) int { return strings.Compare(a.Reviewer, b.Reviewer) })
+	return entries, nil
+}
+
+// GetConflicts returns the conflicts of the committee member, empty if none are registered
+func (s *ReviewerConflictsService) GetConflicts(ctx context.Context, reviewer string) (domain.ReviewerConflicts, error) {
+	reviewer = strings.ToLower(strings.TrimSpace(reviewer))
+
+	entries, err := s.ListConflicts(ctx)
+	if err != nil {
+		return domain.ReviewerConflicts{}, err
+	}
+	for _, entry := range entries {
+		if entry.Reviewer == reviewer {
+			return entry, nil
+		}
+	}
+	return domain.ReviewerConflicts{Reviewer: reviewer}, nil
+}
+
+// SetConflicts replaces the conflicts of the committee member; without speakers or organizations the
+// conflicts are removed. Indexed talks are flagged on their next reindex.
+func (s *ReviewerConflictsService) SetConflicts(ctx context.Context, reviewer string, speakerEmails, organizations []string) error {
+	entry := domain.ReviewerConflicts{
+		Reviewer:      strings.ToLower(strings.TrimSpace(reviewer)),
+		SpeakerEmails: normalizeConflicts(speakerEmails),
+		Organizations: normalizeConflicts(organizations),
+		UpdatedAt:     s.now().UTC(),
+	}
+	if !strings.Contains(entry.Reviewer, "@") {
+		return fmt.Errorf("conflicts can only be registered by users with an email")
+	}
+	for _, email := range entry.SpeakerEmails {
+		if !strings.Contains(email, "@") {
+			return fmt.Errorf("invalid speaker email %q", email)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.ListConflicts(ctx)
+	if err != nil {
+		return err
+	}
+	entries = slices.DeleteFunc(entries, func(other domain.ReviewerConflicts) bool { return other.Reviewer == entry.Reviewer })
+	if len(entry.SpeakerEmails) > 0 || len(entry.Organizations) > 0 {
+		entries = append(entries, entry)
+	}
+
+	if err := s.store.SaveSetting(ctx, reviewerConflictsKey, entries); err != nil {
+		return fmt.Errorf("failed to save conflicts: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "conflicts set", "reviewer", entry.Reviewer, "speakers", len(entry.SpeakerEmails), "organizations", len(entry.Organizations))
+	return nil
+}
+
+// normalizeConflicts returns the values lowercased, sorted and without duplicates or empty values
+func normalizeConflicts(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		normalized = appendReviewer(normalized, value)
+	}
+	slices.Sort(normalized)
+	return normalized
+}
+
+// flagReviewerConflicts adds the committee members whose registered conflicts match a speaker of the
+// talk to its conflicts, removing them from the pending reviewers. A speaker matches by their email, or
+// by an organization equal to the speaker's organization field or the domain of their email.
+func flagReviewerConflicts(talk domain.Talk, entries []domain.ReviewerConflicts, organizationField string) domain.Talk {
+	var conflicted []string
+	for _, entry := range entries {
+		for _, speaker := range talk.Speakers {
+			if speakerConflicts(speaker, entry, organizationField) {
+				conflicted = append(conflicted, entry.Reviewer)
+				break
+			}
+		}
+	}
+	if len(conflicted) == 0 {
+		return talk
+	}
+
+	var review domain.ReviewAssignment
+	if talk.Review != nil {
+		review = *talk.Review
+		review.Conflicts = slices.Clone(review.Conflicts)
+	}
+	for _, reviewer := range conflicted {
+		review.Conflicts = appendReviewer(review.Conflicts, reviewer)
+	}
+	review.Pending = slices.DeleteFunc(slices.Clone(review.Pending), func(reviewer string) bool {
+		return slices.Contains(review.Conflicts, reviewer)
+	})
+	if len(review.Pending) == 0 {
+		review.Pending = nil
+	}
+	talk.Review = &review
+	return talk
+}
+
+// speakerConflicts returns true if the registered conflicts match the speaker
+func speakerConflicts(speaker domain.Speaker, entry domain.ReviewerConflicts, organizationField string) bool {
+	email := strings.ToLower(strings.TrimSpace(speakerEmail(speaker)))
+	if email != "" && slices.Contains(entry.SpeakerEmails, email) {
+		return true
+	}
+
+	var organizations []string
+	if _, domainName, ok := strings.Cut(email, "@"); ok && domainName != "" {
+		organizations = append(organizations, domainName)
+	}
+	if organizationField != "" {
+		organization := speaker.PrivateData[organizationField]
+		if organization == nil {
+			organization = speaker.Data[organizationField]
+		}
+		if name := strings.ToLower(strings.TrimSpace(stringValue(organization))); name != "" {
+			organizations = append(organizations, name)
+		}
+	}
+	for _, organization := range organizations {
+		if slices.Contains(entry.Organizations, organization) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewerConflicts_SetAndGet(t *testing.T) {
+	service := NewReviewerConflictsService(newMockSettingsStore())
+	ctx := context.Background()
+
+	require.NoError(t, service.SetConflicts(ctx, "Grace@JavaZone.no", []string{" Linus@Example.com ", "ada@example.com", "linus@example.com"}, []string{"Bekk", ""}))
+	require.NoError(t, service.SetConflicts(ctx, "ada@javazone.no", nil, []string{"example.org"}))
+
+	conflicts, err := service.GetConflicts(ctx, "grace@javazone.no")
+	require.NoError(t, err)
+	assert.Equal(t, "grace@javazone.no", conflicts.Reviewer)
+	assert.Equal(t, []string{"ada@example.com", "linus@example.com"}, conflicts.SpeakerEmails)
+	assert.Equal(t, []string{"bekk"}, conflicts.Organizations)
+	assert.False(t, conflicts.UpdatedAt.IsZero())
+
+	entries, err := service.ListConflicts(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ada@javazone.no", entries[0].Reviewer)
+
+	t.Run("clearing removes the conflicts", func(t *testing.T) {
+		require.NoError(t, service.SetConflicts(ctx, "ada@javazone.no", nil, nil))
+
+		entries, err := service.ListConflicts(ctx)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		conflicts, err := service.GetConflicts(ctx, "ada@javazone.no")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReviewerConflicts{Reviewer: "ada@javazone.no"}, conflicts)
+	})
+
+	t.Run("reviewer without email", func(t *testing.T) {
+		require.Error(t, service.SetConflicts(ctx, "anonymous", []string{"ada@example.com"}, nil))
+	})
+
+	t.Run("invalid speaker email", func(t *testing.T) {
+		require.Error(t, service.SetConflicts(ctx, "grace@javazone.no", []string{"ada"}, nil))
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		store := newMockSettingsStore()
+		store.loadErr = errors.New("boom")
+		_, err := NewReviewerConflictsService(store).ListConflicts(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestFlagReviewerConflicts(t *testing.T) {
+	entries := []domain.ReviewerConflicts{
+		{Reviewer: "ada@javazone.no", SpeakerEmails: []string{"linus@example.com"}},
+		{Reviewer: "grace@javazone.no", Organizations: []string{"bekk", "example.org"}},
+	}
+	speaker := func(email, company string) domain.Speaker {
+		return domain.Speaker{
+			Data:        map[string]interface{}{"company": company},
+			PrivateData: map[string]interface{}{"email": email},
+		}
+	}
+
+	t.Run("speaker email", func(t *testing.T) {
+		talk := domain.Talk{
+			Speakers: domain.Speakers{speaker("Linus@Example.com", "")},
+			Review:   &domain.ReviewAssignment{Reviewers: []string{"ada@javazone.no", "alan@javazone.no"}, Pending: []string{"ada@javazone.no", "alan@javazone.no"}},
+		}
+
+		flagged := flagReviewerConflicts(talk, entries, "company")
+
+		assert.Equal(t, []string{"ada@javazone.no"}, flagged.Review.Conflicts)
+		assert.Equal(t, []string{"alan@javazone.no"}, flagged.Review.Pending)
+		assert.Equal(t, []string{"ada@javazone.no", "alan@javazone.no"}, talk.Review.Pending, "the given talk is not modified")
+	})
+
+	t.Run("organization field", func(t *testing.T) {
+		talk := domain.Talk{Speakers: domain.Speakers{speaker("ola@example.com", " BEKK ")}}
+
+		flagged := flagReviewerConflicts(talk, entries, "company")
+
+		require.NotNil(t, flagged.Review)
+		assert.Equal(t, []string{"grace@javazone.no"}, flagged.Review.Conflicts)
+		assert.Empty(t, flagged.Review.Pending)
+	})
+
+	t.Run("organization ignored without field", func(t *testing.T) {
+		talk := domain.Talk{Speakers: domain.Speakers{speaker("ola@example.com", "Bekk")}}
+
+		assert.Nil(t, flagReviewerConflicts(talk, entries, "").Review)
+	})
+
+	t.Run("email domain", func(t *testing.T) {
+		talk := domain.Talk{Speakers: domain.Speakers{speaker("kari@example.org", "")}}
+
+		flagged := flagReviewerConflicts(talk, entries, "company")
+
+		require.NotNil(t, flagged.Review)
+		assert.Equal(t, []string{"grace@javazone.no"}, flagged.Review.Conflicts)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		talk := domain.Talk{Speakers: domain.Speakers{speaker("kari@example.net", "Acme")}}
+
+		assert.Equal(t, talk, flagReviewerConflicts(talk, entries, "company"))
+	})
+}
+
+func TestIndexerService_FlagsReviewerConflicts(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{
+				ID:             "talk-1",
+				ConferenceSlug: "javazone2024",
+				Status:         "SUBMITTED",
+				Data:           map[string]interface{}{"tags": []interface{}{"reviewer:ada@javazone.no"}},
+				Speakers:       domain.Speakers{{ID: "s1", Name: "Linus", PrivateData: map[string]interface{}{"email": "linus@example.com"}}},
+			}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	conflicts := NewReviewerConflictsService(newMockSettingsStore())
+	require.NoError(t, conflicts.SetConflicts(context.Background(), "ada@javazone.no", []string{"linus@example.com"}, nil))
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.AddTransform(ReviewAssignments(config.ReviewConfig{TagPrefix: "reviewer:", TagsField: "tags"}))
+	service.SetReviewerConflicts(conflicts, "company")
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	require.NotEmpty(t, index.bulkIndexCalls)
+	for _, call := range index.bulkIndexCalls {
+		if call.IndexName != "private" {
+			continue
+		}
+		require.Len(t, call.Talks, 1)
+		require.NotNil(t, call.Talks[0].Review)
+		assert.Equal(t, []string{"ada@javazone.no"}, call.Talks[0].Review.Reviewers)
+		assert.Equal(t, []string{"ada@javazone.no"}, call.Talks[0].Review.Conflicts)
+		assert.Empty(t, call.Talks[0].Review.Pending)
+	}
+}
@@ -0,0 +1,313 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// datasetVersionKeyPrefix prefixes the settings keys holding the dataset version of each conference
+const datasetVersionKeyPrefix = "dataset:"
+
+// DatasetVersionService tells clients when and how the public documents of a conference have changed.
+// The documents are hashed on every lookup and the version stored in a settings document is increased
+// when the hash differs from the stored one, so changes from reindexes, webhooks and patches all count.
+// The digest of each talk is stored too, recording the version each talk was added, changed or removed in.
+// While a full reindex rebuilds the public index, its partial documents are not compared with the stored
+// version, so they never advance it; the service follows the reindex jobs as an index event handler.
+type DatasetVersionService struct {
+	reader      ports.TalkReader
+	store       ports.SettingsStore
+	publicIndex string
+	now         func() time.Time
+	logger      *slog.Logger
+
+	mu sync.Mutex
+
+	// rebuilds counts the full reindexes of this instance that are running
+	rebuilds   int
+	rebuildsMu sync.Mutex
+}
+
+// NewDatasetVersionService creates a new DatasetVersionService, receiving context as first parameter
+// to retrieve configuration.
+func NewDatasetVersionService(ctx context.Context, reader ports.TalkReader, store ports.SettingsStore) *DatasetVersionService {
+	cfg := config.GetConfig(ctx)
+	return NewDatasetVersionServiceWithConfig(reader, store, cfg.Index.PublicName())
+}
+
+// NewDatasetVersionServiceWithConfig creates a new DatasetVersionService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewDatasetVersionServiceWithConfig(reader ports.TalkReader, store ports.SettingsStore, publicIndex string) *DatasetVersionService {
+	return &DatasetVersionService{
+		reader:      reader,
+		store:       store,
+		publicIndex: publicIndex,
+		now:         time.Now,
+		logger:      slog.Default().With("component", "dataset-version"),
+	}
+}
+
+// SetClock replaces the system clock recording when the documents changed
+func (s *DatasetVersionService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// datasetState is the stored version of the public documents of a conference, with the versions each
+// talk was added and last changed in, so the changes since an earlier version can be listed
+type datasetState struct {
+	domain.DatasetVersion
+
+	// TrackedSince is the oldest version changes can be listed since. Versions stored before changes
+	// were tracked per talk cannot be compared with.
+	TrackedSince int64 `json:"trackedSince"`
+
+	// Documents maps the ID of each public talk to its digest and the versions it was added and changed in
+	Documents map[string]trackedDocument `json:"documents"`
+
+	// Removed maps the ID of each talk that is no longer public to the version it was removed in
+	Removed map[string]int64 `json:"removed,omitempty"`
+}
+
+// trackedDocument is the digest of a public talk and the versions it was added and last changed in
+type trackedDocument struct {
+	Digest  string `json:"digest"`
+	Added   int64  `json:"added"`
+	Changed int64  `json:"changed"`
+}
+
+// HandleIndexEvent follows the full reindexes, which recreate the public index, so the dataset versions
+// are not advanced from the partial documents while one runs
+func (s *DatasetVersionService) HandleIndexEvent(ctx context.Context, event domain.IndexEvent) {
+	s.rebuildsMu.Lock()
+	defer s.rebuildsMu.Unlock()
+
+	switch e := event.(type) {
+	case domain.ReindexProgress:
+		if e.Stage == domain.ReindexStageStarted && e.Scope != nil && e.Scope.Kind == domain.JobKindReindexAll {
+			s.rebuilds++
+		}
+	case domain.ReindexJobFinished:
+		if e.Job.Scope.Kind == domain.JobKindReindexAll && s.rebuilds > 0 {
+			s.rebuilds--
+		}
+	}
+}
+
+// rebuilding reports whether a full reindex is rebuilding the public index
+func (s *DatasetVersionService) rebuilding() bool {
+	s.rebuildsMu.Lock()
+	defer s.rebuildsMu.Unlock()
+	return s.rebuilds > 0
+}
+
+// DatasetVersion returns the current version of the public documents of the conference, increasing
+// the stored version if they changed since the last lookup. Conferences without public documents
+// that were never looked up before are not found, so unknown slugs do not create settings documents.
+// While a full reindex runs, the stored version is returned as is.
+func (s *DatasetVersionService) DatasetVersion(ctx context.Context, conferenceSlug string) (domain.DatasetVersion, error) {
+	if s.rebuilding() {
+		state, found, err := s.load(ctx, conferenceSlug)
+		if err != nil {
+			return domain.DatasetVersion{}, err
+		}
+		if !found {
+			return domain.DatasetVersion{}, domain.ErrDatasetRebuilding
+		}
+		return state.DatasetVersion, nil
+	}
+
+	state, _, err := s.refresh(ctx, conferenceSlug)
+	if err != nil {
+		return domain.DatasetVersion{}, err
+	}
+	return state.DatasetVersion, nil
+}
+
+// DatasetChanges returns the public documents of the conference added, updated and removed since the
+// given version, after bringing the version up to date. Since 0 returns every document as added.
+// While a full reindex runs, the changes cannot be listed and domain.ErrDatasetRebuilding is returned.
+func (s *DatasetVersionService) DatasetChanges(ctx context.Context, conferenceSlug string, since int64) (domain.DatasetChanges, error) {
+	if s.rebuilding() {
+		return domain.DatasetChanges{}, domain.ErrDatasetRebuilding
+	}
+
+	state, talks, err := s.refresh(ctx, conferenceSlug)
+	if err != nil {
+		return domain.DatasetChanges{}, err
+	}
+	if since != 0 && (since < state.TrackedSince || since > state.Version) {
+		return domain.DatasetChanges{}, fmt.Errorf("%w: %d (current version %d, changes tracked since %d)",
+			domain.ErrDatasetVersionUnavailable, since, state.Version, state.TrackedSince)
+	}
+
+	changes := domain.DatasetChanges{
+		ConferenceSlug: conferenceSlug,
+		Since:          since,
+		Version:        state.Version,
+		Added:          []domain.Talk{},
+		Updated:        []domain.Talk{},
+		Removed:        []string{},
+	}
+	for _, talk := range sortedTalks(talks) {
+		document := state.Documents[talk.ID]
+		switch {
+		case since == 0 || document.Added > since:
+			changes.Added = append(changes.Added, talk)
+		case document.Changed > since:
+			changes.Updated = append(changes.Updated, talk)
+		}
+	}
+	for id, removedIn := range state.Removed {
+		if removedIn > since {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	slices.Sort(changes.Removed)
+
+	return changes, nil
+}
+
+// refresh compares the public documents of the conference with the stored state, saving a new version
+// with the changed talks if they differ, and returns the state along with the documents
+func (s *DatasetVersionService) refresh(ctx context.Context, conferenceSlug string) (datasetState, []domain.Talk, error) {
+	talks, err := s.reader.FetchTalks(ctx, s.publicIndex, conferenceSlug)
+	if err != nil {
+		return datasetState{}, nil, fmt.Errorf("failed to fetch public talks: %w", err)
+	}
+	hash, err := datasetHash(talks)
+	if err != nil {
+		return datasetState{}, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A full reindex that started while the documents were read may have caught them half written
+	if s.rebuilding() {
+		return datasetState{}, nil, domain.ErrDatasetRebuilding
+	}
+
+	key := datasetVersionKeyPrefix + conferenceSlug
+	state, found, err := s.load(ctx, conferenceSlug)
+	if err != nil {
+		return datasetState{}, nil, err
+	}
+	if !found && len(talks) == 0 {
+		return datasetState{}, nil, fmt.Errorf("%w: %s", domain.ErrConferenceNotFound, conferenceSlug)
+	}
+	if found && state.Hash == hash && state.Documents != nil {
+		return state, talks, nil
+	}
+
+	next, err := s.nextState(state, found && state.Hash == hash, conferenceSlug, hash, talks)
+	if err != nil {
+		return datasetState{}, nil, err
+	}
+	if err := s.store.SaveSetting(ctx, key, next); err != nil {
+		return datasetState{}, nil, fmt.Errorf("failed to save dataset version: %w", err)
+	}
+
+	if next.Version != state.Version {
+		s.logger.InfoContext(ctx, "public dataset changed", "conference", conferenceSlug, "version", next.Version, "talks", next.Talks)
+	}
+	return next, talks, nil
+}
+
+// load reads the stored state of the conference
+func (s *DatasetVersionService) load(ctx context.Context, conferenceSlug string) (datasetState, bool, error) {
+	var state datasetState
+	found, err := s.store.LoadSetting(ctx, datasetVersionKeyPrefix+conferenceSlug, &state)
+	if err != nil {
+		return datasetState{}, false, fmt.Errorf("failed to load dataset version: %w", err)
+	}
+	return state, found, nil
+}
+
+// nextState returns the state following the stored one for the given documents. Unless unchanged is
+// set the version is increased, and talks are marked as added, changed or removed in it. States stored
+// before changes were tracked per talk start tracking at their current version.
+func (s *DatasetVersionService) nextState(state datasetState, unchanged bool, conferenceSlug, hash string, talks []domain.Talk) (datasetState, error) {
+	next := datasetState{
+		DatasetVersion: state.DatasetVersion,
+		TrackedSince:   state.TrackedSince,
+		Documents:      make(map[string]trackedDocument, len(talks)),
+		Removed:        state.Removed,
+	}
+	if !unchanged {
+		next.DatasetVersion = domain.DatasetVersion{
+			ConferenceSlug: conferenceSlug,
+			Version:        state.Version + 1,
+			Hash:           hash,
+			Talks:          len(talks),
+			ChangedAt:      s.now().UTC(),
+		}
+	}
+	if state.Documents == nil {
+		next.TrackedSince = next.Version
+	}
+	if next.Removed == nil {
+		next.Removed = make(map[string]int64)
+	}
+
+	version := next.Version
+	for _, talk := range talks {
+		digest, err := documentDigest(talk)
+		if err != nil {
+			return datasetState{}, err
+		}
+		document, ok := state.Documents[talk.ID]
+		switch {
+		case !ok:
+			document = trackedDocument{Digest: digest, Added: version, Changed: version}
+			delete(next.Removed, talk.ID)
+		case document.Digest != digest:
+			document.Digest = digest
+			document.Changed = version
+		}
+		next.Documents[talk.ID] = document
+	}
+	for id := range state.Documents {
+		if _, ok := next.Documents[id]; !ok {
+			next.Removed[id] = version
+		}
+	}
+
+	return next, nil
+}
+
+// datasetHash returns the hex SHA-256 digest of the talks ordered by ID, which does not depend on the
+// order the index returns them in
+func datasetHash(talks []domain.Talk) (string, error) {
+	digest := sha256.New()
+	if err := json.NewEncoder(digest).Encode(sortedTalks(talks)); err != nil {
+		return "", fmt.Errorf("failed to hash public talks: %w", err)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// documentDigest returns the hex SHA-256 digest of a single talk
+func documentDigest(talk domain.Talk) (string, error) {
+	encoded, err := json.Marshal(talk)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash public talk %s: %w", talk.ID, err)
+	}
+	digest := sha256.Sum256(encoded)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// sortedTalks returns a copy of the talks ordered by ID
+func sortedTalks(talks []domain.Talk) []domain.Talk {
+	return slices.SortedFunc(slices.Values(talks), func(a, b domain.Talk) int { return strings.Compare(a.ID, b.ID) })
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetVersion_IncreasesWhenDocumentsChange(t *testing.T) {
+	talks := []domain.Talk{
+		{ID: "talk-1", ConferenceSlug: "javazone2025", Data: map[string]interface{}{"title": "Kotlin"}},
+		{ID: "talk-2", ConferenceSlug: "javazone2025", Data: map[string]interface{}{"title": "Java"}},
+	}
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			assert.Equal(t, "javazone_public", indexName)
+			assert.Equal(t, "javazone2025", conferenceSlug)
+			return talks, nil
+		},
+	}
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	service := NewDatasetVersionServiceWithConfig(reader, newMockSettingsStore(), "javazone_public")
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	first, err := service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Version)
+	assert.Equal(t, 2, first.Talks)
+	assert.Equal(t, now, first.ChangedAt)
+
+	// The order the index returns the talks in does not matter
+	talks = []domain.Talk{talks[1], talks[0]}
+	now = now.Add(time.Hour)
+	unchanged, err := service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, first, unchanged)
+
+	talks[0].Data = map[string]interface{}{"title": "Java 25"}
+	changed, err := service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), changed.Version)
+	assert.NotEqual(t, first.Hash, changed.Hash)
+	assert.Equal(t, now, changed.ChangedAt)
+
+	// Unpublishing every talk is a change too
+	talks = nil
+	emptied, err := service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), emptied.Version)
+	assert.Equal(t, 0, emptied.Talks)
+}
+
+func TestDatasetVersion_HeldDuringFullReindex(t *testing.T) {
+	talks := []domain.Talk{
+		{ID: "talk-1", ConferenceSlug: "javazone2025", Data: map[string]interface{}{"title": "Kotlin"}},
+		{ID: "talk-2", ConferenceSlug: "javazone2025", Data: map[string]interface{}{"title": "Java"}},
+	}
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return talks, nil
+		},
+	}
+	service := NewDatasetVersionServiceWithConfig(reader, newMockSettingsStore(), "javazone_public")
+	ctx := context.Background()
+
+	before, err := service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+
+	scope := domain.JobScope{Kind: domain.JobKindReindexAll}
+	service.HandleIndexEvent(ctx, domain.ReindexProgress{Stage: domain.ReindexStageStarted, Scope: &scope})
+
+	// The recreated public index holds only some of the talks while the reindex runs
+	talks = talks[:1]
+	during, err := service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, before, during)
+	_, err = service.DatasetChanges(ctx, "javazone2025", before.Version)
+	assert.ErrorIs(t, err, domain.ErrDatasetRebuilding)
+	_, err = service.DatasetVersion(ctx, "javazone2024")
+	assert.ErrorIs(t, err, domain.ErrDatasetRebuilding, "conferences without a stored version cannot be answered")
+
+	// Reindexes of a single conference do not hold the versions
+	conference := domain.JobScope{Kind: domain.JobKindReindexConference, Target: "javazone2025"}
+	service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: domain.Job{Scope: conference}})
+	_, err = service.DatasetChanges(ctx, "javazone2025", before.Version)
+	assert.ErrorIs(t, err, domain.ErrDatasetRebuilding)
+
+	service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: domain.Job{Scope: scope}})
+	after, err := service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, before.Version+1, after.Version)
+	assert.Equal(t, 1, after.Talks)
+}
+
+func TestDatasetVersion_UnknownConference(t *testing.T) {
+	store := newMockSettingsStore()
+	service := NewDatasetVersionServiceWithConfig(&mockTalkReader{}, store, "javazone_public")
+
+	_, err := service.DatasetVersion(context.Background(), "nosuchconf")
+
+	assert.ErrorIs(t, err, domain.ErrConferenceNotFound)
+	assert.Empty(t, store.settings, "unknown slugs are not stored")
+}
+
+func TestDatasetVersion_Errors(t *testing.T) {
+	talks := []domain.Talk{{ID: "talk-1", ConferenceSlug: "javazone2025"}}
+
+	t.Run("index error", func(t *testing.T) {
+		reader := &mockTalkReader{
+			fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		service := NewDatasetVersionServiceWithConfig(reader, newMockSettingsStore(), "javazone_public")
+
+		_, err := service.DatasetVersion(context.Background(), "javazone2025")
+		assert.ErrorContains(t, err, "connection refused")
+	})
+
+	t.Run("settings error", func(t *testing.T) {
+		reader := &mockTalkReader{
+			fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+				return talks, nil
+			},
+		}
+		store := newMockSettingsStore()
+		store.loadErr = errors.New("settings index unavailable")
+		service := NewDatasetVersionServiceWithConfig(reader, store, "javazone_public")
+
+		_, err := service.DatasetVersion(context.Background(), "javazone2025")
+		assert.ErrorContains(t, err, "settings index unavailable")
+	})
+}
+
+func TestDatasetChanges(t *testing.T) {
+	talk := func(id, title string) domain.Talk {
+		return domain.Talk{ID: id, ConferenceSlug: "javazone2025", Data: map[string]interface{}{"title": title}}
+	}
+	talks := []domain.Talk{talk("talk-1", "Kotlin"), talk("talk-2", "Java")}
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return talks, nil
+		},
+	}
+	service := NewDatasetVersionServiceWithConfig(reader, newMockSettingsStore(), "javazone_public")
+	ctx := context.Background()
+
+	initial, err := service.DatasetChanges(ctx, "javazone2025", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), initial.Version)
+	assert.Equal(t, []domain.Talk{talk("talk-1", "Kotlin"), talk("talk-2", "Java")}, initial.Added, "a full sync lists every talk as added")
+
+	unchanged, err := service.DatasetChanges(ctx, "javazone2025", 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DatasetChanges{ConferenceSlug: "javazone2025", Since: 1, Version: 1, Added: []domain.Talk{}, Updated: []domain.Talk{}, Removed: []string{}}, unchanged)
+
+	// Version 2 changes talk-1 and adds talk-3, version 3 removes talk-2
+	talks = []domain.Talk{talk("talk-1", "Kotlin 2"), talk("talk-2", "Java"), talk("talk-3", "Rust")}
+	_, err = service.DatasetVersion(ctx, "javazone2025")
+	require.NoError(t, err)
+	talks = []domain.Talk{talk("talk-1", "Kotlin 2"), talk("talk-3", "Rust")}
+
+	changes, err := service.DatasetChanges(ctx, "javazone2025", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), changes.Version)
+	assert.Equal(t, []domain.Talk{talk("talk-3", "Rust")}, changes.Added)
+	assert.Equal(t, []domain.Talk{talk("talk-1", "Kotlin 2")}, changes.Updated)
+	assert.Equal(t, []string{"talk-2"}, changes.Removed)
+
+	changes, err = service.DatasetChanges(ctx, "javazone2025", 2)
+	require.NoError(t, err)
+	assert.Empty(t, changes.Added)
+	assert.Empty(t, changes.Updated)
+	assert.Equal(t, []string{"talk-2"}, changes.Removed)
+
+	// A talk published again is added, and no longer removed
+	talks = []domain.Talk{talk("talk-1", "Kotlin 2"), talk("talk-2", "Java"), talk("talk-3", "Rust")}
+	changes, err = service.DatasetChanges(ctx, "javazone2025", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), changes.Version)
+	assert.Equal(t, []domain.Talk{talk("talk-2", "Java")}, changes.Added)
+	assert.Empty(t, changes.Removed)
+
+	for _, since := range []int64{-1, 5} {
+		_, err = service.DatasetChanges(ctx, "javazone2025", since)
+		assert.ErrorIs(t, err, domain.ErrDatasetVersionUnavailable, "since %d", since)
+	}
+}
+
+func TestDatasetChanges_VersionsStoredBeforeTracking(t *testing.T) {
+	talks := []domain.Talk{{ID: "talk-1", ConferenceSlug: "javazone2025"}}
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return talks, nil
+		},
+	}
+	hash, err := datasetHash(talks)
+	require.NoError(t, err)
+	store := newMockSettingsStore()
+	require.NoError(t, store.SaveSetting(context.Background(), "dataset:javazone2025", domain.DatasetVersion{ConferenceSlug: "javazone2025", Version: 7, Hash: hash, Talks: 1}))
+	service := NewDatasetVersionServiceWithConfig(reader, store, "javazone_public")
+
+	version, err := service.DatasetVersion(context.Background(), "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), version.Version, "the version is kept")
+
+	_, err = service.DatasetChanges(context.Background(), "javazone2025", 6)
+	assert.ErrorIs(t, err, domain.ErrDatasetVersionUnavailable, "changes before tracking started are unknown")
+	changes, err := service.DatasetChanges(context.Background(), "javazone2025", 7)
+	require.NoError(t, err)
+	assert.Empty(t, changes.Added)
+	assert.Empty(t, changes.Updated)
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// DeadLetterService lists the documents that failed indexing even after retries and indexes
+// their stored payload again on request
+type DeadLetterService struct {
+	store       ports.DeadLetterStore
+	searchIndex ports.SearchIndex
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewDeadLetterService creates a new DeadLetterService
+func NewDeadLetterService(store ports.DeadLetterStore, searchIndex ports.SearchIndex) *DeadLetterService {
+	return &DeadLetterService{
+		store:       store,
+		searchIndex: searchIndex,
+		now:         time.Now,
+		logger:      slog.Default().With("component", "deadletters"),
+	}
+}
+
+// ListDeadLetters returns the documents that failed indexing, most recent failures first
+func (s *DeadLetterService) ListDeadLetters(ctx context.Context) ([]domain.DeadLetter, error) {
+	letters, err := s.store.ListDeadLetters(ctx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	return letters, nil
+}
+
+// RetryDeadLetter indexes the stored payload into the index it failed for. The dead letter is removed
+// if the document is indexed, or skipped because the index already holds a newer version of the talk.
+// If it fails again, the dead letter is updated with the new error.
+func (s *DeadLetterService) RetryDeadLetter(ctx context.Context, id string) error {
+	letter, err := s.store.GetDeadLetter(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load dead letter %s: %w", id, err)
+	}
+	if letter == nil {
+		return fmt.Errorf("%w: %s", domain.ErrDeadLetterNotFound, id)
+	}
+
+	var talk domain.Talk
+	if err := json.Unmarshal(letter.Payload, &talk); err != nil {
+		return fmt.Errorf("failed to decode payload of dead letter %s: %w", id, err)
+	}
+
+	result, err := s.searchIndex.BulkIndex(ctx, letter.Index, []domain.Talk{talk})
+	saveDeadLetters(ctx, s.store, s.logger, s.now().UTC(), letter.Index, result.Failed)
+	if err != nil {
+		return fmt.Errorf("failed to index talk %s into %s: %w", letter.TalkID, letter.Index, err)
+	}
+
+	if err := s.store.DeleteDeadLetter(ctx, id); err != nil {
+		return fmt.Errorf("failed to remove dead letter %s: %w", id, err)
+	}
+
+	if slices.Contains(result.Conflicts, letter.TalkID) {
+		s.logger.InfoContext(ctx, "dead letter discarded, index holds a newer version", "index", letter.Index, "talkID", letter.TalkID)
+	} else {
+		s.logger.InfoContext(ctx, "dead letter indexed", "index", letter.Index, "talkID", letter.TalkID)
+	}
+	return nil
+}
+
+// DiscardDeadLetter removes a dead letter without indexing it
+func (s *DeadLetterService) DiscardDeadLetter(ctx context.Context, id string) error {
+	letter, err := s.store.GetDeadLetter(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load dead letter %s: %w", id, err)
+	}
+	if letter == nil {
+		return fmt.Errorf("%w: %s", domain.ErrDeadLetterNotFound, id)
+	}
+
+	if err := s.store.DeleteDeadLetter(ctx, id); err != nil {
+		return fmt.Errorf("failed to remove dead letter %s: %w", id, err)
+	}
+
+	s.logger.InfoContext(ctx, "dead letter discarded", "index", letter.Index, "talkID", letter.TalkID)
+	return nil
+}
+
+// saveDeadLetters keeps documents that failed indexing in the dead-letter store, counting repeated
+// failures of the same talk. Store errors are only logged so they never hide the indexing error.
+func saveDeadLetters(ctx context.Context, store ports.DeadLetterStore, logger *slog.Logger, now time.Time, indexName string, failed []domain.FailedDocument) {
+	for _, doc := range failed {
+		letter := domain.DeadLetter{
+			ID:       domain.DeadLetterID(indexName, doc.ID),
+			Index:    indexName,
+			TalkID:   doc.ID,
+			Payload:  doc.Payload,
+			Error:    doc.Error,
+			Failures: 1,
+			FailedAt: now,
+		}
+
+		existing, err := store.GetDeadLetter(ctx, letter.ID)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to load dead letter", "index", indexName, "talkID", doc.ID, "error", err)
+		} else if existing != nil {
+			letter.Failures = existing.Failures + 1
+		}
+
+		if err := store.SaveDeadLetter(ctx, letter); err != nil {
+			logger.ErrorContext(ctx, "failed to save dead letter", "index", indexName, "talkID", doc.ID, "error", err)
+			continue
+		}
+		logger.WarnContext(ctx, "document failed indexing, kept in dead-letter index",
+			"index", indexName,
+			"talkID", doc.ID,
+			"failures", letter.Failures,
+			"error", doc.Error,
+		)
+	}
+}
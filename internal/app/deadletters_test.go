@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDeadLetterStore is an in-memory ports.DeadLetterStore
+type mockDeadLetterStore struct {
+	letters map[string]domain.DeadLetter
+	saveErr error
+}
+
+func newMockDeadLetterStore() *mockDeadLetterStore {
+	return &mockDeadLetterStore{letters: make(map[string]domain.DeadLetter)}
+}
+
+func (m *mockDeadLetterStore) SaveDeadLetter(ctx context.Context, letter domain.DeadLetter) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	m.letters[letter.ID] = letter
+	return nil
+}
+
+func (m *mockDeadLetterStore) GetDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	letter, ok := m.letters[id]
+	if !ok {
+		return nil, nil
+	}
+	return &letter, nil
+}
+
+func (m *mockDeadLetterStore) ListDeadLetters(ctx context.Context, limit int) ([]domain.DeadLetter, error) {
+	var letters []domain.DeadLetter
+	for _, letter := range m.letters {
+		letters = append(letters, letter)
+	}
+	slices.SortFunc(letters, func(a, b domain.DeadLetter) int { return b.FailedAt.Compare(a.FailedAt) })
+	return letters, nil
+}
+
+func (m *mockDeadLetterStore) DeleteDeadLetter(ctx context.Context, id string) error {
+	delete(m.letters, id)
+	return nil
+}
+
+func testDeadLetter(t *testing.T, indexName string, talk domain.Talk) domain.DeadLetter {
+	payload, err := json.Marshal(talk)
+	require.NoError(t, err)
+	return domain.DeadLetter{
+		ID:       domain.DeadLetterID(indexName, talk.ID),
+		Index:    indexName,
+		TalkID:   talk.ID,
+		Payload:  payload,
+		Error:    "mapper_parsing_exception",
+		Failures: 1,
+	}
+}
+
+func TestIndexerService_KeepsFailedDocumentsAsDeadLetters(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "APPROVED", Speakers: domain.Speakers{{ID: "s1"}}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		bulkFailed: []domain.FailedDocument{{ID: "talk-1", Payload: json.RawMessage(`{"id":"talk-1"}`), Error: "mapper_parsing_exception"}},
+		bulkIndexFunc: func(ctx context.Context, indexName string, talks []domain.Talk) error {
+			return errors.New("bulk index had errors")
+		},
+	}
+	store := newMockDeadLetterStore()
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetDeadLetterStore(store)
+
+	require.Error(t, service.ReindexTalk(context.Background(), "talk-1"))
+	letter, ok := store.letters[domain.DeadLetterID("private", "talk-1")]
+	require.True(t, ok)
+	assert.Equal(t, "private", letter.Index)
+	assert.Equal(t, "talk-1", letter.TalkID)
+	assert.JSONEq(t, `{"id":"talk-1"}`, string(letter.Payload))
+	assert.Equal(t, "mapper_parsing_exception", letter.Error)
+	assert.Equal(t, 1, letter.Failures)
+	assert.False(t, letter.FailedAt.IsZero())
+
+	// A repeated failure of the same talk updates the dead letter
+	require.Error(t, service.ReindexTalk(context.Background(), "talk-1"))
+	assert.Len(t, store.letters, 1)
+	assert.Equal(t, 2, store.letters[domain.DeadLetterID("private", "talk-1")].Failures)
+}
+
+func TestIndexerService_DeadLetterStoreErrorKeepsIndexingError(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "APPROVED", Speakers: domain.Speakers{{ID: "s1"}}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		bulkFailed: []domain.FailedDocument{{ID: "talk-1", Error: "boom"}},
+		bulkIndexFunc: func(ctx context.Context, indexName string, talks []domain.Talk) error {
+			return errors.New("bulk index had errors")
+		},
+	}
+	store := newMockDeadLetterStore()
+	store.saveErr = errors.New("dead-letter index unavailable")
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetDeadLetterStore(store)
+
+	err := service.ReindexTalk(context.Background(), "talk-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bulk index had errors")
+}
+
+func TestDeadLetterService_RetryDeadLetter(t *testing.T) {
+	talk := domain.Talk{ID: "talk-1", ConferenceSlug: "javazone2024", Data: map[string]interface{}{"title": "Retried"}}
+
+	t.Run("indexed", func(t *testing.T) {
+		store := newMockDeadLetterStore()
+		letter := testDeadLetter(t, "public", talk)
+		store.letters[letter.ID] = letter
+		index := &mockSearchIndex{}
+
+		service := NewDeadLetterService(store, index)
+		require.NoError(t, service.RetryDeadLetter(context.Background(), letter.ID))
+
+		require.Len(t, index.bulkIndexCalls, 1)
+		assert.Equal(t, "public", index.bulkIndexCalls[0].IndexName)
+		require.Len(t, index.bulkIndexCalls[0].Talks, 1)
+		assert.Equal(t, "talk-1", index.bulkIndexCalls[0].Talks[0].ID)
+		assert.Equal(t, "Retried", index.bulkIndexCalls[0].Talks[0].Data["title"])
+		assert.Empty(t, store.letters)
+	})
+
+	t.Run("newer version indexed", func(t *testing.T) {
+		store := newMockDeadLetterStore()
+		letter := testDeadLetter(t, "public", talk)
+		store.letters[letter.ID] = letter
+		index := &mockSearchIndex{bulkConflicts: []string{"talk-1"}}
+
+		service := NewDeadLetterService(store, index)
+		require.NoError(t, service.RetryDeadLetter(context.Background(), letter.ID))
+		assert.Empty(t, store.letters)
+	})
+
+	t.Run("fails again", func(t *testing.T) {
+		store := newMockDeadLetterStore()
+		letter := testDeadLetter(t, "public", talk)
+		store.letters[letter.ID] = letter
+		index := &mockSearchIndex{
+			bulkFailed: []domain.FailedDocument{{ID: "talk-1", Payload: letter.Payload, Error: "still broken"}},
+			bulkIndexFunc: func(ctx context.Context, indexName string, talks []domain.Talk) error {
+				return errors.New("bulk index had errors")
+			},
+		}
+
+		service := NewDeadLetterService(store, index)
+		require.Error(t, service.RetryDeadLetter(context.Background(), letter.ID))
+
+		require.Contains(t, store.letters, letter.ID)
+		assert.Equal(t, "still broken", store.letters[letter.ID].Error)
+		assert.Equal(t, 2, store.letters[letter.ID].Failures)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewDeadLetterService(newMockDeadLetterStore(), &mockSearchIndex{})
+		err := service.RetryDeadLetter(context.Background(), "public:missing")
+		assert.ErrorIs(t, err, domain.ErrDeadLetterNotFound)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		store := newMockDeadLetterStore()
+		store.letters["public:talk-1"] = domain.DeadLetter{ID: "public:talk-1", Index: "public", TalkID: "talk-1", Payload: json.RawMessage(`"not a talk"`)}
+		index := &mockSearchIndex{}
+
+		service := NewDeadLetterService(store, index)
+		require.Error(t, service.RetryDeadLetter(context.Background(), "public:talk-1"))
+		assert.Empty(t, index.bulkIndexCalls)
+		assert.Contains(t, store.letters, "public:talk-1")
+	})
+}
+
+func TestDeadLetterService_DiscardDeadLetter(t *testing.T) {
+	store := newMockDeadLetterStore()
+	letter := testDeadLetter(t, "private", domain.Talk{ID: "talk-1"})
+	store.letters[letter.ID] = letter
+	index := &mockSearchIndex{}
+
+	service := NewDeadLetterService(store, index)
+	require.NoError(t, service.DiscardDeadLetter(context.Background(), letter.ID))
+	assert.Empty(t, store.letters)
+	assert.Empty(t, index.bulkIndexCalls)
+
+	err := service.DiscardDeadLetter(context.Background(), letter.ID)
+	assert.ErrorIs(t, err, domain.ErrDeadLetterNotFound)
+}
+
+func TestDeadLetterService_ListDeadLetters(t *testing.T) {
+	store := newMockDeadLetterStore()
+	letter := testDeadLetter(t, "private", domain.Talk{ID: "talk-1"})
+	store.letters[letter.ID] = letter
+
+	service := NewDeadLetterService(store, &mockSearchIndex{})
+	letters, err := service.ListDeadLetters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, letter.ID, letters[0].ID)
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// DiagnosticsService assembles the diagnostics bundle admins download to attach full context to
+// support tickets and issues: recent logs, job reports, the configuration with secrets redacted, the
+// differences between the live and expected index mappings, and the health of the dependencies.
+// Each part is optional and only included once its source is set.
+type DiagnosticsService struct {
+	config   map[string]string
+	health   []ports.HealthCheck
+	jobs     ports.JobStore
+	jobLimit int
+	mappings ports.MappingReader
+	expected map[string]string
+	logs     ports.LogHistory
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+// NewDiagnosticsService creates a new DiagnosticsService checking the given dependencies, receiving
+// context as first parameter to retrieve configuration.
+func NewDiagnosticsService(ctx context.Context, health ...ports.HealthCheck) *DiagnosticsService {
+	cfg := config.GetConfig(ctx)
+	return NewDiagnosticsServiceWithConfig(cfg.Redacted(), cfg.Diagnostics, health...)
+}
+
+// NewDiagnosticsServiceWithConfig creates a new DiagnosticsService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewDiagnosticsServiceWithConfig(redacted map[string]string, cfg config.DiagnosticsConfig, health ...ports.HealthCheck) *DiagnosticsService {
+	return &DiagnosticsService{
+		config:   redacted,
+		health:   health,
+		jobLimit: cfg.Jobs,
+		now:      time.Now,
+		logger:   slog.Default().With("component", "diagnostics"),
+	}
+}
+
+// SetJobStore enables including the reports of the most recent jobs
+func (s *DiagnosticsService) SetJobStore(jobs ports.JobStore) {
+	s.jobs = jobs
+}
+
+// SetMappingReader enables comparing the live mapping of each index with the mapping it is created
+// with, given per index name
+func (s *DiagnosticsService) SetMappingReader(mappings ports.MappingReader, expected map[string]string) {
+	s.mappings = mappings
+	s.expected = expected
+}
+
+// SetLogHistory enables including the most recent log records
+func (s *DiagnosticsService) SetLogHistory(logs ports.LogHistory) {
+	s.logs = logs
+}
+
+// DiagnosticsBundle collects every part that has a source. A part that cannot be collected is
+// reported in the bundle's errors, so the bundle is still useful when a dependency is down.
+func (s *DiagnosticsService) DiagnosticsBundle(ctx context.Context) (*domain.DiagnosticsBundle, error) {
+	bundle := &domain.DiagnosticsBundle{
+		GeneratedAt: s.now().UTC(),
+		Config:      s.config,
+		Health:      make([]domain.DependencyHealth, 0, len(s.health)),
+		Jobs:        []domain.Job{},
+		Mappings:    []domain.MappingDiff{},
+	}
+
+	for _, check := range s.health {
+		bundle.Health = append(bundle.Health, check.CheckHealth(ctx))
+	}
+
+	if s.jobs != nil {
+		jobs, err := s.jobs.ListJobs(ctx, s.jobLimit)
+		if err != nil {
+			bundle.Errors = append(bundle.Errors, fmt.Sprintf("failed to list jobs: %v", err))
+		} else {
+			bundle.Jobs = jobs
+		}
+	}
+
+	if s.mappings != nil {
+		for _, indexName := range slices.Sorted(maps.Keys(s.expected)) {
+			bundle.Mappings = append(bundle.Mappings, s.diffMapping(ctx, indexName, s.expected[indexName]))
+		}
+	}
+
+	if s.logs != nil {
+		bundle.Logs = s.logs.RecentLogs()
+	}
+
+	for _, problem := range bundle.Errors {
+		s.logger.WarnContext(ctx, "diagnostics bundle incomplete", "error", problem)
+	}
+	return bundle, nil
+}
+
+// diffMapping compares the fields of the live index with those of the expected mapping
+func (s *DiagnosticsService) diffMapping(ctx context.Context, indexName string, expectedMapping string) domain.MappingDiff {
+	diff := domain.MappingDiff{Index: indexName}
+
+	var expected struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.Unmarshal([]byte(expectedMapping), &expected); err != nil {
+		diff.Error = fmt.Sprintf("failed to parse expected mapping: %v", err)
+		return diff
+	}
+
+	live, err := s.mappings.IndexMapping(ctx, indexName)
+	if err != nil {
+		diff.Error = err.Error()
+		return diff
+	}
+
+	want := mappingFields(expected.Mappings)
+	got := mappingFields(live)
+	for _, field := range slices.Sorted(maps.Keys(want)) {
+		liveType, ok := got[field]
+		switch {
+		case !ok:
+			diff.Missing = append(diff.Missing, field)
+		case liveType != want[field]:
+			diff.Changed = append(diff.Changed, fmt.Sprintf("%s: %s → %s", field, want[field], liveType))
+		}
+	}
+	for _, field := range slices.Sorted(maps.Keys(got)) {
+		if _, ok := want[field]; !ok {
+			diff.Unexpected = append(diff.Unexpected, field)
+		}
+	}
+	return diff
+}
+
+// mappingFields flattens the properties of a mapping into field types by dotted path, including
+// object fields and multi-fields
+func mappingFields(mapping map[string]interface{}) map[string]string {
+	fields := make(map[string]string)
+	var walk func(properties map[string]interface{}, prefix string)
+	walk = func(properties map[string]interface{}, prefix string) {
+		for name, raw := range properties {
+			definition, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			path := prefix + name
+			fieldType, _ := definition["type"].(string)
+			if fieldType == "" {
+				fieldType = "object"
+			}
+			fields[path] = fieldType
+
+			for _, nested := range []string{"properties", "fields"} {
+				if children, ok := definition[nested].(map[string]interface{}); ok {
+					walk(children, path+".")
+				}
+			}
+		}
+	}
+	if properties, ok := mapping["properties"].(map[string]interface{}); ok {
+		walk(properties, "")
+	}
+	return fields
+}
+
+// WriteDiagnosticsZip writes the bundle as a zip archive with one file per part: the summary with
+// the errors, the redacted configuration, the dependency health, the job reports and the mapping diffs
+// as JSON, and the logs as newline-delimited JSON
+func WriteDiagnosticsZip(w io.Writer, bundle *domain.DiagnosticsBundle) error {
+	archive := zip.NewWriter(w)
+
+	summary := struct {
+		GeneratedAt time.Time `json:"generatedAt"`
+		Errors      []string  `json:"errors,omitempty"`
+	}{bundle.GeneratedAt, bundle.Errors}
+
+	parts := []struct {
+		name    string
+		content interface{}
+	}{
+		{"summary.json", summary},
+		{"config.json", bundle.Config},
+		{"health.json", bundle.Health},
+		{"jobs.json", bundle.Jobs},
+		{"mappings.json", bundle.Mappings},
+	}
+	for _, part := range parts {
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: part.name, Method: zip.Deflate, Modified: bundle.GeneratedAt})
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", part.name, err)
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: "logs.ndjson", Method: zip.Deflate, Modified: bundle.GeneratedAt})
+	if err != nil {
+		return fmt.Errorf("failed to add logs.ndjson: %w", err)
+	}
+	for _, line := range bundle.Logs {
+		if _, err := fmt.Fprintf(file, "%s\n", line); err != nil {
+			return fmt.Errorf("failed to write logs.ndjson: %w", err)
+		}
+	}
+
+	return archive.Close()
+}
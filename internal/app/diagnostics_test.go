@@ -0,0 +1,167 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHealthCheck is a mock implementation of ports.HealthCheck
+type mockHealthCheck struct {
+	health domain.DependencyHealth
+}
+
+func (m *mockHealthCheck) CheckHealth(ctx context.Context) domain.DependencyHealth {
+	return m.health
+}
+
+// mockMappingReader is a mock implementation of ports.MappingReader
+type mockMappingReader struct {
+	mappings map[string]string
+}
+
+func (m *mockMappingReader) IndexMapping(ctx context.Context, indexName string) (map[string]interface{}, error) {
+	mapping, ok := m.mappings[indexName]
+	if !ok {
+		return nil, domain.ErrIndexNotFound
+	}
+	var parsed map[string]interface{}
+	err := json.Unmarshal([]byte(mapping), &parsed)
+	return parsed, err
+}
+
+// mockLogHistory is a mock implementation of ports.LogHistory
+type mockLogHistory [][]byte
+
+func (m mockLogHistory) RecentLogs() [][]byte {
+	return m
+}
+
+// failingJobStore is a job store that cannot list jobs
+type failingJobStore struct {
+	*mockJobStore
+}
+
+func (f failingJobStore) ListJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	return nil, errors.New("connection refused")
+}
+
+const testExpectedMapping = `{
+  "settings": {"number_of_shards": 1},
+  "mappings": {
+    "properties": {
+      "id": {"type": "keyword"},
+      "title": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+      "speakers": {"type": "nested", "properties": {"name": {"type": "text"}}},
+      "length": {"type": "integer"}
+    }
+  }
+}`
+
+func TestDiagnosticsService_DiffMapping(t *testing.T) {
+	service := NewDiagnosticsServiceWithConfig(nil, config.DiagnosticsConfig{})
+	service.SetMappingReader(&mockMappingReader{mappings: map[string]string{
+		"public": `{"properties": {
+			"id": {"type": "keyword"},
+			"title": {"type": "text"},
+			"speakers": {"type": "nested", "properties": {"name": {"type": "text"}}},
+			"length": {"type": "keyword"},
+			"data": {"properties": {"extra": {"type": "text"}}}
+		}}`,
+	}}, map[string]string{"public": testExpectedMapping, "private": testExpectedMapping})
+
+	bundle, err := service.DiagnosticsBundle(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, bundle.Mappings, 2)
+	assert.Equal(t, "private", bundle.Mappings[0].Index)
+	assert.Contains(t, bundle.Mappings[0].Error, "index not found")
+
+	public := bundle.Mappings[1]
+	assert.Equal(t, "public", public.Index)
+	assert.Equal(t, []string{"title.keyword"}, public.Missing)
+	assert.Equal(t, []string{"data", "data.extra"}, public.Unexpected)
+	assert.Equal(t, []string{"length: integer → keyword"}, public.Changed)
+	assert.Empty(t, public.Error)
+}
+
+func TestDiagnosticsService_DiagnosticsBundle(t *testing.T) {
+	jobs := newMockJobStore()
+	_, err := jobs.CreateJob(context.Background(), domain.NewJob(domain.JobScope{Kind: domain.JobKindReindexAll}, time.Now()))
+	require.NoError(t, err)
+
+	service := NewDiagnosticsServiceWithConfig(
+		map[string]string{"MODE": "production", "OIDC_CLIENT_SECRET": "[redacted]"},
+		config.DiagnosticsConfig{Jobs: 10},
+		&mockHealthCheck{health: domain.DependencyHealth{Name: "elasticsearch", Status: domain.HealthStatusOK}},
+		&mockHealthCheck{health: domain.DependencyHealth{Name: "moresleep", Status: domain.HealthStatusError, Error: "timeout"}},
+	)
+	service.SetJobStore(jobs)
+	service.SetLogHistory(mockLogHistory{[]byte(`{"msg":"first"}`), []byte(`{"msg":"second"}`)})
+
+	bundle, err := service.DiagnosticsBundle(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "[redacted]", bundle.Config["OIDC_CLIENT_SECRET"])
+	require.Len(t, bundle.Health, 2)
+	assert.Equal(t, "timeout", bundle.Health[1].Error)
+	require.Len(t, bundle.Jobs, 1)
+	assert.Equal(t, domain.JobKindReindexAll, bundle.Jobs[0].Scope.Kind)
+	assert.Empty(t, bundle.Mappings, "mappings are only compared with a mapping reader")
+	assert.Len(t, bundle.Logs, 2)
+	assert.Empty(t, bundle.Errors)
+
+	t.Run("reports parts that cannot be collected", func(t *testing.T) {
+		service.SetJobStore(failingJobStore{jobs})
+
+		bundle, err := service.DiagnosticsBundle(context.Background())
+
+		require.NoError(t, err)
+		assert.Empty(t, bundle.Jobs)
+		assert.Equal(t, []string{"failed to list jobs: connection refused"}, bundle.Errors)
+	})
+}
+
+func TestWriteDiagnosticsZip(t *testing.T) {
+	bundle := &domain.DiagnosticsBundle{
+		GeneratedAt: time.Date(2024, 9, 4, 12, 0, 0, 0, time.UTC),
+		Config:      map[string]string{"MODE": "production"},
+		Health:      []domain.DependencyHealth{},
+		Jobs:        []domain.Job{},
+		Mappings:    []domain.MappingDiff{{Index: "public", Missing: []string{"title.keyword"}}},
+		Logs:        [][]byte{[]byte(`{"msg":"first"}`), []byte(`{"msg":"second"}`)},
+		Errors:      []string{"failed to list jobs: connection refused"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDiagnosticsZip(&buf, bundle))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[file.Name] = string(content)
+	}
+
+	assert.Len(t, files, 6)
+	assert.Contains(t, files["summary.json"], "connection refused")
+	assert.JSONEq(t, `{"MODE": "production"}`, files["config.json"])
+	assert.JSONEq(t, `[]`, files["jobs.json"])
+	assert.Contains(t, files["mappings.json"], "title.keyword")
+	assert.Equal(t, "{\"msg\":\"first\"}\n{\"msg\":\"second\"}\n", files["logs.ndjson"])
+}
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// EventBus delivers the index events published by the indexer to its subscribers, synchronously and
+// in the order they subscribed
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers []ports.IndexEventHandler
+}
+
+// NewEventBus creates an EventBus without subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe adds a handler receiving every event published from now on
+func (b *EventBus) Subscribe(handler ports.IndexEventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish delivers the event to all subscribers
+func (b *EventBus) Publish(ctx context.Context, event domain.IndexEvent) {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler.HandleIndexEvent(ctx, event)
+	}
+}
+
+// IndexEventHandlerFunc adapts a function to the ports.IndexEventHandler interface
+type IndexEventHandlerFunc func(ctx context.Context, event domain.IndexEvent)
+
+// HandleIndexEvent calls the function
+func (f IndexEventHandlerFunc) HandleIndexEvent(ctx context.Context, event domain.IndexEvent) {
+	f(ctx, event)
+}
+
+// notifyingHandler translates index events into the events raised to the notifier, such as outbound webhooks
+func notifyingHandler(notifier ports.EventNotifier) IndexEventHandlerFunc {
+	return func(ctx context.Context, event domain.IndexEvent) {
+		var eventType domain.EventType
+		var data map[string]interface{}
+
+		switch e := event.(type) {
+		case domain.TalkIndexed:
+			if !e.Published {
+				return
+			}
+			eventType = domain.EventTalkPublished
+			data = map[string]interface{}{
+				"talkId":         e.TalkID,
+				"conferenceSlug": e.ConferenceSlug,
+				"title":          e.Title,
+			}
+		case domain.TalkDeleted:
+			if !e.Public {
+				return
+			}
+			eventType = domain.EventTalkDeleted
+			data = map[string]interface{}{
+				"talkId":         e.TalkID,
+				"conferenceSlug": e.ConferenceSlug,
+			}
+		case domain.IndexSwapped:
+			eventType = domain.EventRepublishCompleted
+			data = map[string]interface{}{
+				"generation":   e.Generation,
+				"indexes":      e.Indexes,
+				"privateTalks": e.PrivateTalks,
+				"publicTalks":  e.PublicTalks,
+				"actor":        e.Actor,
+			}
+		case domain.ReindexJobFinished:
+			eventType = domain.EventReindexCompleted
+			data = map[string]interface{}{
+				"jobId":  e.Job.ID,
+				"scope":  e.Job.Scope,
+				"actor":  e.Job.Actor,
+				"state":  e.Job.State,
+				"error":  e.Job.Error,
+				"report": e.Job.Report,
+			}
+		default:
+			return
+		}
+
+		notifier.Notify(ctx, domain.Event{
+			Type:       eventType,
+			OccurredAt: time.Now().UTC(),
+			Data:       data,
+		})
+	}
+}
+
+// purgingHandler purges the configured CDN paths of the conferences whose public documents changed.
+// Purge failures are logged rather than returned, since the index itself was updated successfully.
+func (s *IndexerService) purgingHandler(purger ports.CachePurger, paths []string) IndexEventHandlerFunc {
+	return func(ctx context.Context, event domain.IndexEvent) {
+		var slugs []string
+		switch e := event.(type) {
+		case domain.TalkIndexed:
+			if !e.Public || e.Bulk {
+				return
+			}
+			slugs = []string{e.ConferenceSlug}
+		case domain.TalkDeleted:
+			if !e.Public {
+				return
+			}
+			slugs = []string{e.ConferenceSlug}
+		case domain.ConferenceReindexed:
+			slugs = e.Slugs
+		case domain.IndexSwapped:
+			slugs = e.Slugs
+		default:
+			return
+		}
+
+		expanded := expandPurgePaths(paths, slugs)
+		if err := purger.Purge(ctx, expanded); err != nil {
+			s.logger.ErrorContext(ctx, "failed to purge CDN cache", "paths", len(expanded), "error", err)
+		}
+	}
+}
+
+// recordReindex records the current time as the last reindex time of the indexes written to or deleted from
+func (s *IndexerService) recordReindex(ctx context.Context, event domain.IndexEvent) {
+	switch e := event.(type) {
+	case domain.TalkIndexed:
+		s.markReindexed(e.IndexName)
+	case domain.TalkDeleted:
+		s.markReindexed(e.IndexNames...)
+	case domain.ConferenceReindexed:
+		s.markReindexed(e.IndexNames...)
+	case domain.IndexSwapped:
+		aliases := make([]string, 0, len(e.Indexes))
+		for alias := range e.Indexes {
+			aliases = append(aliases, alias)
+		}
+		s.markReindexed(aliases...)
+	}
+}
@@ -0,0 +1,259 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler keeps the events it receives, with the progress of reindexes apart from the others
+type recordingHandler struct {
+	events   []domain.IndexEvent
+	progress []domain.ReindexProgress
+}
+
+func (h *recordingHandler) HandleIndexEvent(ctx context.Context, event domain.IndexEvent) {
+	if progress, ok := event.(domain.ReindexProgress); ok {
+		h.progress = append(h.progress, progress)
+		return
+	}
+	h.events = append(h.events, event)
+}
+
+func TestEventBus_PublishesInSubscriptionOrder(t *testing.T) {
+	bus := NewEventBus()
+	var order []string
+	bus.Subscribe(IndexEventHandlerFunc(func(ctx context.Context, event domain.IndexEvent) {
+		order = append(order, "first:"+event.IndexEventName())
+	}))
+	bus.Subscribe(IndexEventHandlerFunc(func(ctx context.Context, event domain.IndexEvent) {
+		order = append(order, "second:"+event.IndexEventName())
+	}))
+
+	bus.Publish(context.Background(), domain.TalkIndexed{TalkID: "talk-1"})
+
+	assert.Equal(t, []string{"first:talk_indexed", "second:talk_indexed"}, order)
+}
+
+func TestIndexerService_PublishesIndexEvents(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{
+				{ID: "talk-1", ConferenceSlug: "javazone2024", Status: "APPROVED"},
+				{ID: "talk-2", ConferenceSlug: "javazone2024", Status: "SUBMITTED"},
+			}, nil
+		},
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "APPROVED"}, nil
+		},
+	}
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	handler := &recordingHandler{}
+	service.Events().Subscribe(handler)
+
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	require.Len(t, handler.events, 5)
+	assert.Equal(t, domain.ConferenceReindexed{
+		Slugs:        []string{"javazone2024"},
+		IndexNames:   []string{"private", "public"},
+		PrivateTalks: 2,
+		PublicTalks:  1,
+	}, handler.events[0])
+	assert.IsType(t, domain.ReindexJobFinished{}, handler.events[1])
+	assert.Equal(t, domain.TalkIndexed{TalkID: "talk-1", ConferenceSlug: "javazone2024", IndexName: "private"}, handler.events[2])
+	assert.Equal(t, domain.TalkIndexed{TalkID: "talk-1", ConferenceSlug: "javazone2024", IndexName: "public", Public: true, Published: true}, handler.events[3])
+	assert.Equal(t, domain.JobStateSucceeded, handler.events[4].(domain.ReindexJobFinished).Job.State)
+
+	assert.Equal(t, []domain.ReindexProgress{
+		{Stage: domain.ReindexStageStarted, Scope: &domain.JobScope{Kind: domain.JobKindReindexConference, Target: "javazone2024"}},
+		{Stage: domain.ReindexStageFetched, ConferenceSlug: "javazone2024", Fetched: 1, Conferences: 1, Talks: 2},
+		{Stage: domain.ReindexStageIndexed, IndexName: "private", Talks: 2},
+		{Stage: domain.ReindexStageIndexed, IndexName: "public", Talks: 1},
+		{Stage: domain.ReindexStageStarted, Scope: &domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}},
+		{Stage: domain.ReindexStageIndexed, IndexName: "private", Talks: 1},
+		{Stage: domain.ReindexStageIndexed, IndexName: "public", Talks: 1},
+	}, handler.progress)
+}
+
+func TestNotifyingHandler(t *testing.T) {
+	notifier := &mockEventNotifier{}
+	handler := notifyingHandler(notifier)
+	ctx := context.Background()
+
+	handler(ctx, domain.TalkIndexed{TalkID: "talk-1", IndexName: "private"})
+	handler(ctx, domain.TalkIndexed{TalkID: "talk-1", IndexName: "public", Public: true})
+	handler(ctx, domain.TalkDeleted{TalkID: "talk-1", IndexNames: []string{"private"}})
+	handler(ctx, domain.ConferenceReindexed{Slugs: []string{"javazone2024"}})
+	assert.Empty(t, notifier.events, "only newly published and deleted public talks, swaps and finished jobs are raised")
+
+	handler(ctx, domain.IndexSwapped{Generation: "20250901120000", Indexes: map[string]string{"public": "public_20250901120000"}})
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, domain.EventRepublishCompleted, notifier.events[0].Type)
+	assert.Equal(t, "20250901120000", notifier.events[0].Data["generation"])
+	assert.False(t, notifier.events[0].OccurredAt.IsZero())
+
+	handler(ctx, domain.TalkDeleted{TalkID: "talk-1", ConferenceSlug: "javazone2024", IndexNames: []string{"private", "public"}, Public: true})
+	require.Len(t, notifier.events, 2)
+	assert.Equal(t, domain.EventTalkDeleted, notifier.events[1].Type)
+	assert.Equal(t, map[string]interface{}{"talkId": "talk-1", "conferenceSlug": "javazone2024"}, notifier.events[1].Data)
+
+	handler(ctx, domain.TalkIndexed{TalkID: "talk-2", ConferenceSlug: "javazone2024", Title: "Loom", IndexName: "public", Public: true, Published: true})
+	require.Len(t, notifier.events, 3)
+	assert.Equal(t, domain.EventTalkPublished, notifier.events[2].Type)
+	assert.Equal(t, "talk-2", notifier.events[2].Data["talkId"])
+}
+
+func TestReindexTalk_PublishedOnlyOnStatusChange(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "APPROVED"}, nil
+		},
+	}
+	var listed []string
+	index := &mockSearchIndex{
+		listTalkIDsFunc: func(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+			listed = append(listed, indexName+"/"+conferenceID)
+			return []string{"talk-1"}, nil
+		},
+	}
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	handler := &recordingHandler{}
+	service.Events().Subscribe(handler)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-2"))
+
+	assert.Equal(t, []string{"public/conf-1", "public/conf-1"}, listed)
+	var published []bool
+	for _, event := range handler.events {
+		if indexed, ok := event.(domain.TalkIndexed); ok && indexed.Public {
+			published = append(published, indexed.Published)
+		}
+	}
+	assert.Equal(t, []bool{false, true}, published, "talk-1 was already public, talk-2 was not")
+}
+
+// publicIndexWith returns a search index whose public index lists the given talks until talks are
+// written to it, and the written talks as well from then on
+func publicIndexWith(ids ...string) *mockSearchIndex {
+	index := &mockSearchIndex{}
+	index.listTalkIDsFunc = func(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+		if indexName != "public" {
+			return nil, nil
+		}
+		listed := slices.Clone(ids)
+		for _, call := range index.callsTo("public") {
+			for _, talk := range call.Talks {
+				listed = append(listed, talk.ID)
+			}
+		}
+		return listed, nil
+	}
+	return index
+}
+
+// publishedTalks returns the IDs of the talks announced as newly public
+func publishedTalks(events []domain.IndexEvent) []string {
+	var ids []string
+	for _, event := range events {
+		if indexed, ok := event.(domain.TalkIndexed); ok && indexed.Published {
+			ids = append(ids, indexed.TalkID)
+		}
+	}
+	return ids
+}
+
+func TestReindexConference_PublishesNewlyPublicTalks(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{
+				{ID: "talk-1", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "APPROVED"},
+				{ID: "talk-2", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "APPROVED", Data: map[string]interface{}{"title": "Loom"}},
+				{ID: "talk-3", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "SUBMITTED"},
+			}, nil
+		},
+	}
+	index := publicIndexWith("talk-1")
+	purger := &mockCachePurger{}
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetCachePurger(purger, []string{"/public/allSessions/{conferenceSlug}"})
+	handler := &recordingHandler{}
+	service.Events().Subscribe(handler)
+
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	assert.Equal(t, []string{"talk-2"}, publishedTalks(handler.events), "talk-1 was already public, talk-3 is not approved")
+	assert.Contains(t, handler.events, domain.IndexEvent(domain.TalkIndexed{
+		TalkID:         "talk-2",
+		ConferenceSlug: "javazone2024",
+		Title:          "Loom",
+		IndexName:      "public",
+		Public:         true,
+		Published:      true,
+		Bulk:           true,
+	}))
+	assert.Len(t, purger.paths, 1, "the conference is purged once")
+}
+
+func TestReindexAll_PublishesNewlyPublicTalks(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}, {ID: "conf-2", Slug: "javazone2025"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			slug := map[string]string{"conf-1": "javazone2024", "conf-2": "javazone2025"}[conferenceID]
+			return []domain.Talk{
+				{ID: "talk-" + conferenceID, ConferenceID: conferenceID, ConferenceSlug: slug, Status: "APPROVED"},
+			}, nil
+		},
+	}
+
+	t.Run("talks entering the public index", func(t *testing.T) {
+		index := publicIndexWith("talk-conf-1")
+		service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+		handler := &recordingHandler{}
+		service.Events().Subscribe(handler)
+
+		require.NoError(t, service.ReindexAll(context.Background()))
+
+		assert.Equal(t, []string{"talk-conf-2"}, publishedTalks(handler.events))
+	})
+
+	t.Run("nothing announced when the public index is new", func(t *testing.T) {
+		index := publicIndexWith()
+		index.indexExistsFunc = func(ctx context.Context, indexName string) (bool, error) {
+			return false, nil
+		}
+		service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+		handler := &recordingHandler{}
+		service.Events().Subscribe(handler)
+
+		require.NoError(t, service.ReindexAll(context.Background()))
+
+		assert.Empty(t, publishedTalks(handler.events))
+	})
+}
+
+func TestIndexMetricsService(t *testing.T) {
+	metrics := NewIndexMetricsService()
+	bus := NewEventBus()
+	bus.Subscribe(metrics)
+
+	bus.Publish(context.Background(), domain.TalkIndexed{IndexName: "private"})
+	bus.Publish(context.Background(), domain.TalkIndexed{IndexName: "public", Public: true})
+	bus.Publish(context.Background(), domain.IndexSwapped{})
+
+	assert.Equal(t, map[string]uint64{"talk_indexed": 2, "index_swapped": 1}, metrics.IndexEventCounts())
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// Default and maximum number of values of the keyword facet
+const (
+	defaultFacetKeywords = 50
+	maxFacetKeywords     = 500
+)
+
+// FacetService counts the public talks per year, format, language and keyword, so consumers can
+// render search filters without querying the index themselves. Talks are read through the index
+// reader, so facets work with every search backend. Keywords are normalized like in the keyword
+// trend analysis, counting synonyms together. Since counting reads every talk, the counts are
+// kept per conference until the version of the public index changes.
+type FacetService struct {
+	reader      ports.IndexReader
+	publicIndex string
+	synonyms    map[string]string
+	logger      *slog.Logger
+
+	mu      sync.Mutex
+	version domain.IndexVersion
+	counts  map[string]*facetTally
+}
+
+// facetTally holds the counts of each facet for a conference, or for every conference
+type facetTally struct {
+	total     int
+	years     map[string]int
+	formats   map[string]int
+	languages map[string]int
+	keywords  map[string]int
+}
+
+// NewFacetService creates a new FacetService, receiving context as first parameter
+// to retrieve configuration.
+func NewFacetService(ctx context.Context, reader ports.IndexReader) *FacetService {
+	cfg := config.GetConfig(ctx)
+	return NewFacetServiceWithConfig(reader, cfg.Index.PublicName(), cfg.Keywords)
+}
+
+// NewFacetServiceWithConfig creates a new FacetService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewFacetServiceWithConfig(reader ports.IndexReader, publicIndex string, cfg config.KeywordsConfig) *FacetService {
+	logger := slog.Default().With("component", "facets")
+	return &FacetService{
+		reader:      reader,
+		publicIndex: publicIndex,
+		synonyms:    keywordSynonyms(cfg.Synonyms, logger),
+		logger:      logger,
+	}
+}
+
+// SearchFacets counts the public talks of the conference, or of every conference, per value of each facet.
+// Talks without a value for a facet are left out of it, and the keyword facet keeps the most frequent keywords.
+func (s *FacetService) SearchFacets(ctx context.Context, req domain.FacetRequest) (*domain.SearchFacets, error) {
+	limit := req.KeywordLimit
+	if limit == 0 {
+		limit = defaultFacetKeywords
+	}
+	if limit < 1 || limit > maxFacetKeywords {
+		return nil, fmt.Errorf("%w: keywords must be between 1 and %d", domain.ErrInvalidQuery, maxFacetKeywords)
+	}
+
+	tally, err := s.tally(ctx, req.ConferenceSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &domain.SearchFacets{
+		Total:     tally.total,
+		Years:     facetCounts(tally.years, 0),
+		Formats:   facetCounts(tally.formats, 0),
+		Languages: facetCounts(tally.languages, 0),
+		Keywords:  facetCounts(tally.keywords, limit),
+	}
+	slices.SortFunc(facets.Years, func(a, b domain.FacetCount) int { return strings.Compare(b.Value, a.Value) })
+	return facets, nil
+}
+
+// tally returns the counts of the conference, counting the talks in the public index again only
+// when the index has changed since they were last counted
+func (s *FacetService) tally(ctx context.Context, conferenceSlug string) (*facetTally, error) {
+	version, err := s.reader.IndexVersion(ctx, s.publicIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the public index version: %w", err)
+	}
+
+	s.mu.Lock()
+	if version != s.version {
+		s.version, s.counts = version, make(map[string]*facetTally)
+	}
+	tally, ok := s.counts[conferenceSlug]
+	s.mu.Unlock()
+	if ok {
+		return tally, nil
+	}
+
+	talks, err := s.reader.FetchTalks(ctx, s.publicIndex, conferenceSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from public index: %w", err)
+	}
+
+	tally = &facetTally{
+		total:     len(talks),
+		years:     make(map[string]int),
+		formats:   make(map[string]int),
+		languages: make(map[string]int),
+		keywords:  make(map[string]int),
+	}
+	for _, talk := range talks {
+		if year := conferenceYear(talk); year != 0 {
+			tally.years[strconv.Itoa(year)]++
+		}
+		if format := strings.TrimSpace(stringValue(talk.Data["format"])); format != "" {
+			tally.formats[format]++
+		}
+		if language := strings.TrimSpace(stringValue(talk.Data["language"])); language != "" {
+			tally.languages[language]++
+		}
+
+		// A talk listing a keyword twice, for instance as two spellings, counts once
+		var seen []string
+		for _, keyword := range stringSlice(talk.Data["keywords"]) {
+			if keyword = s.normalize(keyword); keyword != "" && !slices.Contains(seen, keyword) {
+				seen = append(seen, keyword)
+				tally.keywords[keyword]++
+			}
+		}
+	}
+
+	s.mu.Lock()
+	if version == s.version {
+		s.counts[conferenceSlug] = tally
+	}
+	s.mu.Unlock()
+
+	s.logger.InfoContext(ctx, "counted search facets", "conferenceSlug", conferenceSlug, "talks", len(talks), "keywords", len(tally.keywords), "version", version.Version)
+	return tally, nil
+}
+
+// normalize normalizes the keyword and maps it to the keyword it is a synonym of, if any
+func (s *FacetService) normalize(keyword string) string {
+	keyword = normalizeKeyword(keyword)
+	if synonym, ok := s.synonyms[keyword]; ok {
+		return synonym
+	}
+	return keyword
+}
+
+// facetCounts returns the values with their counts, most frequent first and then alphabetically,
+// keeping the first limit values if limit is positive
+func facetCounts(counts map[string]int, limit int) []domain.FacetCount {
+	if limit <= 0 {
+		limit = len(counts)
+	}
+	values := mostFrequent(counts, limit)
+
+	result := make([]domain.FacetCount, 0, len(values))
+	for _, value := range values {
+		result = append(result, domain.FacetCount{Value: value, Count: counts[value]})
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIndexReader is a mock implementation of ports.IndexReader
+type mockIndexReader struct {
+	*mockTalkReader
+	*mockIndexVersioner
+}
+
+func facetTalk(slug, format, language string, keywords ...interface{}) domain.Talk {
+	data := map[string]interface{}{"keywords": keywords}
+	if format != "" {
+		data["format"] = format
+	}
+	if language != "" {
+		data["language"] = language
+	}
+	return domain.Talk{ConferenceSlug: slug, Data: data}
+}
+
+func TestFacetService_SearchFacets(t *testing.T) {
+	var requested []string
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			requested = append(requested, conferenceSlug)
+			return []domain.Talk{
+				facetTalk("javazone2023", "presentation", "no", "Java", "Kotlin"),
+				facetTalk("javazone2024", "presentation", "en", "kotlin", "k8s", "Kubernetes"),
+				facetTalk("javazone2024", "lightning-talk", "en", "Kotlin "),
+				facetTalk("javazone2024", "workshop", ""),
+				facetTalk("meetup", "", "en", "java"),
+			}, nil
+		},
+	}
+	service := NewFacetServiceWithConfig(mockIndexReader{reader, &mockIndexVersioner{}}, "public", config.KeywordsConfig{Synonyms: []string{"k8s=kubernetes"}})
+
+	facets, err := service.SearchFacets(context.Background(), domain.FacetRequest{ConferenceSlug: "javazone2024"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"public"}, reader.fetchCalls)
+	assert.Equal(t, []string{"javazone2024"}, requested)
+	assert.Equal(t, 5, facets.Total)
+	assert.Equal(t, []domain.FacetCount{{Value: "2024", Count: 3}, {Value: "2023", Count: 1}}, facets.Years, "newest first, talks without a year left out")
+	assert.Equal(t, []domain.FacetCount{{Value: "presentation", Count: 2}, {Value: "lightning-talk", Count: 1}, {Value: "workshop", Count: 1}}, facets.Formats)
+	assert.Equal(t, []domain.FacetCount{{Value: "en", Count: 3}, {Value: "no", Count: 1}}, facets.Languages)
+	assert.Equal(t, []domain.FacetCount{{Value: "kotlin", Count: 3}, {Value: "java", Count: 2}, {Value: "kubernetes", Count: 1}}, facets.Keywords)
+}
+
+func TestFacetService_KeywordLimit(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return []domain.Talk{facetTalk("javazone2024", "", "", "java", "kotlin", "scala")}, nil
+		},
+	}
+	service := NewFacetServiceWithConfig(mockIndexReader{reader, &mockIndexVersioner{}}, "public", config.KeywordsConfig{})
+
+	facets, err := service.SearchFacets(context.Background(), domain.FacetRequest{KeywordLimit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.FacetCount{{Value: "java", Count: 1}, {Value: "kotlin", Count: 1}}, facets.Keywords)
+	assert.Empty(t, facets.Formats)
+
+	_, err = service.SearchFacets(context.Background(), domain.FacetRequest{KeywordLimit: maxFacetKeywords + 1})
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+}
+
+func TestFacetService_ReaderError(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return nil, errors.New("index unavailable")
+		},
+	}
+	service := NewFacetServiceWithConfig(mockIndexReader{reader, &mockIndexVersioner{}}, "public", config.KeywordsConfig{})
+
+	_, err := service.SearchFacets(context.Background(), domain.FacetRequest{})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrInvalidQuery)
+}
+
+func TestFacetService_CachesPerIndexVersion(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return []domain.Talk{facetTalk("javazone2024", "presentation", "en", "java")}, nil
+		},
+	}
+	versions := &mockIndexVersioner{docCount: 1, version: 7}
+	service := NewFacetServiceWithConfig(mockIndexReader{reader, versions}, "public", config.KeywordsConfig{})
+
+	for range 3 {
+		_, err := service.SearchFacets(context.Background(), domain.FacetRequest{})
+		require.NoError(t, err)
+	}
+	assert.Len(t, reader.fetchCalls, 1, "unchanged index is counted once")
+
+	_, err := service.SearchFacets(context.Background(), domain.FacetRequest{ConferenceSlug: "javazone2024"})
+	require.NoError(t, err)
+	assert.Len(t, reader.fetchCalls, 2, "each conference is counted separately")
+
+	versions.version = 8
+	facets, err := service.SearchFacets(context.Background(), domain.FacetRequest{})
+	require.NoError(t, err)
+	assert.Len(t, reader.fetchCalls, 3, "a changed index is counted again")
+	assert.Equal(t, 1, facets.Total)
+}
@@ -2,8 +2,15 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/javaBin/talks-indexer/internal/config"
 	"github.com/javaBin/talks-indexer/internal/domain"
@@ -13,12 +20,49 @@ import (
 // IndexerService handles the business logic for indexing talks
 type IndexerService struct {
 	source              ports.TalkSource
+	conferences         *ConferenceResolverService
 	searchIndex         ports.SearchIndex
 	privateIndex        string
 	publicIndex         string
 	privateIndexMapping string
 	publicIndexMapping  string
 	logger              *slog.Logger
+
+	events *EventBus
+
+	jobs ports.JobStore
+
+	// background tracks the reindex jobs started by StartReindex
+	background sync.WaitGroup
+
+	// running holds the full and conference reindexes that have not finished, so a second one of the
+	// same scope is refused however it is started
+	running *runningReindexes
+
+	deadLetters ports.DeadLetterStore
+
+	transforms []TalkTransform
+	scrubber   *Scrubber
+	catalog    ports.ConferenceCatalog
+	series     ports.SeriesCatalog
+	retention  *RetentionService
+	capacity   *CapacityChecker
+	shrink     *ShrinkGuard
+
+	// conflicts are the conflicts of interest registered by committee members, matching organizations
+	// against the speaker data field organizationField
+	conflicts         ports.ReviewerConflicts
+	organizationField string
+
+	// maxFailedPercent is the share of conferences whose talks may fail to fetch before a full
+	// reindex is aborted; 100 never aborts
+	maxFailedPercent int
+
+	// concurrency is the number of conferences whose talks are fetched, and by a full reindex staged, at the same time
+	concurrency int
+
+	lastReindex   map[string]time.Time
+	lastReindexMu sync.RWMutex
 }
 
 // NewIndexerService creates a new IndexerService, receiving context as first parameter
@@ -31,18 +75,27 @@ func NewIndexerService(
 	publicIndexMapping string,
 ) *IndexerService {
 	cfg := config.GetConfig(ctx)
-	return &IndexerService{
+	s := &IndexerService{
 		source:              source,
+		conferences:         NewConferenceResolverService(source),
 		searchIndex:         searchIndex,
-		privateIndex:        cfg.Index.Private,
-		publicIndex:         cfg.Index.Public,
+		privateIndex:        cfg.Index.PrivateName(),
+		publicIndex:         cfg.Index.PublicName(),
 		privateIndexMapping: privateIndexMapping,
 		publicIndexMapping:  publicIndexMapping,
+		maxFailedPercent:    cfg.Reindex.MaxFailedPercent,
+		concurrency:         max(cfg.Reindex.Concurrency, 1),
 		logger:              slog.Default().With("component", "indexer"),
+		events:              NewEventBus(),
+		lastReindex:         make(map[string]time.Time),
+		running:             &runningReindexes{jobs: make(map[domain.JobScope]domain.Job)},
 	}
+	s.events.Subscribe(IndexEventHandlerFunc(s.recordReindex))
+	return s
 }
 
-// NewIndexerServiceWithConfig creates a new IndexerService with explicit configuration.
+// NewIndexerServiceWithConfig creates a new IndexerService with explicit configuration. Full reindexes
+// fetch one conference at a time and skip conferences whose talks cannot be fetched however many fail.
 // This constructor is primarily intended for testing purposes.
 func NewIndexerServiceWithConfig(
 	source ports.TalkSource,
@@ -52,21 +105,74 @@ func NewIndexerServiceWithConfig(
 	privateIndexMapping string,
 	publicIndexMapping string,
 ) *IndexerService {
-	return &IndexerService{
+	s := &IndexerService{
 		source:              source,
+		conferences:         NewConferenceResolverService(source),
 		searchIndex:         searchIndex,
 		privateIndex:        privateIndex,
 		publicIndex:         publicIndex,
 		privateIndexMapping: privateIndexMapping,
 		publicIndexMapping:  publicIndexMapping,
+		maxFailedPercent:    100,
+		concurrency:         1,
 		logger:              slog.Default().With("component", "indexer"),
+		events:              NewEventBus(),
+		lastReindex:         make(map[string]time.Time),
+		running:             &runningReindexes{jobs: make(map[domain.JobScope]domain.Job)},
 	}
+	s.events.Subscribe(IndexEventHandlerFunc(s.recordReindex))
+	return s
+}
+
+// Events returns the bus the service publishes index events on, for subscribing further features
+func (s *IndexerService) Events() *EventBus {
+	return s.events
+}
+
+// SetCachePurger enables purging the given paths from the CDN after the public index changes.
+// Paths containing {conferenceSlug} are expanded for each affected conference.
+func (s *IndexerService) SetCachePurger(purger ports.CachePurger, paths []string) {
+	s.events.Subscribe(s.purgingHandler(purger, paths))
+}
+
+// SetJobStore enables recording every reindex as a job with its outcome and report
+func (s *IndexerService) SetJobStore(jobs ports.JobStore) {
+	s.jobs = jobs
+}
+
+// SetDeadLetterStore enables keeping documents that failed indexing even after retries for inspection and retry
+func (s *IndexerService) SetDeadLetterStore(store ports.DeadLetterStore) {
+	s.deadLetters = store
+}
+
+// SetNotifier enables raising events, such as completed reindexes and published talks, to the notifier
+func (s *IndexerService) SetNotifier(notifier ports.EventNotifier) {
+	s.events.Subscribe(notifyingHandler(notifier))
+}
+
+// ConferenceResolver returns the resolver the service looks up conferences with. Share it with the
+// talk source so resolving the conference of fetched talks uses the same in-process list.
+func (s *IndexerService) ConferenceResolver() *ConferenceResolverService {
+	return s.conferences
 }
 
 // ReindexAll fetches all conferences and their talks, then indexes them
 // to both private (all talks) and public (only approved talks) indexes.
 func (s *IndexerService) ReindexAll(ctx context.Context) error {
-	s.logger.Info("starting full reindex of all conferences")
+	return s.runJob(ctx, domain.JobScope{Kind: domain.JobKindReindexAll}, s.reindexAll)
+}
+
+// stagingSuffix is appended to the names of the live indexes for the indexes a full reindex is
+// written to before the live indexes are replaced
+const stagingSuffix = "_staging"
+
+// reindexAll performs a full reindex. Each conference is fetched, transformed and written to staging
+// indexes by the worker that fetched it, so no more than the configured number of conferences are held
+// in memory. The live indexes are only recreated, and filled from the staging indexes a conference at
+// a time, once every conference is staged and the dataset passed the completeness, shrink and
+// capacity checks.
+func (s *IndexerService) reindexAll(ctx context.Context) error {
+	s.logger.InfoContext(ctx, "starting full reindex of all conferences")
 
 	// Fetch all conferences
 	conferences, err := s.source.GetConferences(ctx)
@@ -74,7 +180,73 @@ func (s *IndexerService) ReindexAll(ctx context.Context) error {
 		return fmt.Errorf("failed to fetch conferences: %w", err)
 	}
 
-	s.logger.Info("fetched conferences", "count", len(conferences))
+	s.conferences.Store(conferences)
+
+	s.logger.InfoContext(ctx, "fetched conferences", "count", len(conferences))
+
+	staging := domain.IndexNames{Private: s.privateIndex + stagingSuffix, Public: s.publicIndex + stagingSuffix}
+	if err := s.recreateIndex(ctx, staging.Private); err != nil {
+		return fmt.Errorf("failed to create private staging index: %w", err)
+	}
+	defer s.dropIndexes(ctx, staging.Private, staging.Public)
+	if err := s.recreateIndex(ctx, staging.Public); err != nil {
+		return fmt.Errorf("failed to create public staging index: %w", err)
+	}
+
+	// Stage the talks of all conferences, before anything live is deleted
+	var privateCount, publicCount atomic.Int64
+	stagedSlugs := make([][]string, len(conferences))
+	errs, err := s.fetchConferences(ctx, conferences, func(i int, talks []domain.Talk) error {
+		if len(talks) == 0 {
+			return nil
+		}
+		talks = s.applyTransforms(ctx, talks)
+
+		// Private index gets all talks with privateData merged into data, the public index only
+		// approved talks with private data removed and free text scrubbed
+		privateTalks := prepareTalksForPrivateIndex(s.withoutExpired(ctx, talks))
+		publicTalks := filterApprovedTalksForPublic(s.scrubPublic(ctx, talks))
+
+		if err := s.stage(ctx, staging.Private, s.privateIndex, privateTalks); err != nil {
+			return fmt.Errorf("failed to stage talks of %s for private index: %w", conferences[i].Slug, err)
+		}
+		if err := s.stage(ctx, staging.Public, s.publicIndex, publicTalks); err != nil {
+			return fmt.Errorf("failed to stage talks of %s for public index: %w", conferences[i].Slug, err)
+		}
+		privateCount.Add(int64(len(privateTalks)))
+		publicCount.Add(int64(len(publicTalks)))
+		stagedSlugs[i] = talkSlugs(privateTalks)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.checkFetched(ctx, conferences, errs); err != nil {
+		return err
+	}
+
+	privateTalks, publicTalks := int(privateCount.Load()), int(publicCount.Load())
+	s.logger.InfoContext(ctx, "staged talks of all conferences",
+		"private", privateTalks,
+		"public", publicTalks,
+	)
+
+	// Refuse to replace the live public index with far fewer talks, such as after an empty response
+	if err := s.checkShrink(ctx, publicTalks); err != nil {
+		return err
+	}
+
+	// Verify the new documents fit on the cluster before anything is deleted.
+	// The current indexes are deleted first, so their space counts as available.
+	if err := s.checkCapacity(ctx, privateTalks+publicTalks, true); err != nil {
+		return err
+	}
+
+	// Remember which talks are public, to tell the talks entering the public index
+	wasPublic, err := s.publicTalkIDs(ctx, conferences)
+	if err != nil {
+		return err
+	}
 
 	// Recreate both indexes
 	if err := s.recreateIndex(ctx, s.privateIndex); err != nil {
@@ -84,95 +256,300 @@ func (s *IndexerService) ReindexAll(ctx context.Context) error {
 		return fmt.Errorf("failed to recreate public index: %w", err)
 	}
 
-	// Collect all talks from all conferences
-	var allTalks []domain.Talk
+	if privateTalks+publicTalks == 0 {
+		s.logger.WarnContext(ctx, "no talks found to index")
+		s.events.Publish(ctx, domain.ConferenceReindexed{
+			Slugs:      conferenceSlugs(conferences),
+			IndexNames: []string{s.privateIndex, s.publicIndex},
+			All:        true,
+		})
+		return nil
+	}
 
-	for _, conf := range conferences {
-		talks, err := s.source.GetTalks(ctx, conf.ID)
+	var slugs []string
+	for _, staged := range stagedSlugs {
+		for _, slug := range staged {
+			if !slices.Contains(slugs, slug) {
+				slugs = append(slugs, slug)
+			}
+		}
+	}
+	if err := s.promote(ctx, staging.Private, s.privateIndex, slugs, nil); err != nil {
+		return fmt.Errorf("failed to index to private index: %w", err)
+	}
+	if err := s.promote(ctx, staging.Public, s.publicIndex, slugs, wasPublic); err != nil {
+		return fmt.Errorf("failed to index to public index: %w", err)
+	}
+
+	s.events.Publish(ctx, domain.ConferenceReindexed{
+		Slugs:        conferenceSlugs(conferences),
+		IndexNames:   []string{s.privateIndex, s.publicIndex},
+		PrivateTalks: privateTalks,
+		PublicTalks:  publicTalks,
+		All:          true,
+	})
+
+	s.logger.InfoContext(ctx, "full reindex completed successfully",
+		"privateCount", privateTalks,
+		"publicCount", publicTalks,
+	)
+
+	return nil
+}
+
+// stage writes talks to the staging index of a full reindex. Documents that failed indexing are kept
+// in the dead-letter store under the live index, which they would otherwise never reach.
+func (s *IndexerService) stage(ctx context.Context, stagingIndex, indexName string, talks []domain.Talk) error {
+	result, err := s.searchIndex.BulkIndex(ctx, stagingIndex, talks)
+	if s.deadLetters != nil {
+		saveDeadLetters(ctx, s.deadLetters, s.logger, time.Now().UTC(), indexName, result.Failed)
+	}
+	return err
+}
+
+// promote copies the staged talks with the given conference slugs to the live index, one conference
+// at a time. Talks without a conference slug cannot be read by conference, so the whole staging index
+// is copied at once when a talk has none. Talks entering the index are published as newly public
+// unless wasPublic is nil.
+func (s *IndexerService) promote(ctx context.Context, stagingIndex, indexName string, slugs []string, wasPublic map[string]bool) error {
+	if slices.Contains(slugs, "") {
+		s.logger.WarnContext(ctx, "talks without conference slug, copying the staging index at once", "index", stagingIndex)
+		slugs = []string{""}
+	}
+	for _, slug := range slugs {
+		talks, err := s.searchIndex.FetchTalks(ctx, stagingIndex, slug)
 		if err != nil {
-			s.logger.Error("failed to fetch talks for conference",
-				"conferenceID", conf.ID,
-				"conferenceName", conf.Name,
-				"error", err,
-			)
-			continue
+			return fmt.Errorf("failed to read staged talks of %s: %w", slug, err)
+		}
+		if err := s.bulkIndex(ctx, indexName, talks); err != nil {
+			return err
 		}
+		if err := s.publishNewlyPublic(ctx, wasPublic, talks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		s.logger.Info("fetched talks for conference",
-			"conferenceID", conf.ID,
-			"conferenceName", conf.Name,
-			"count", len(talks),
-		)
+// dropIndexes deletes the given indexes, logging failures, even when the context was canceled
+func (s *IndexerService) dropIndexes(ctx context.Context, indexNames ...string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, indexName := range indexNames {
+		if err := s.searchIndex.DeleteIndex(ctx, indexName); err != nil {
+			s.logger.ErrorContext(ctx, "failed to delete index", "index", indexName, "error", err)
+		}
+	}
+}
 
-		allTalks = append(allTalks, talks...)
+// fetchTalks collects the talks of all conferences, fetching up to the configured number of conferences
+// at a time. Conferences whose talks cannot be fetched are skipped as checkFetched allows.
+func (s *IndexerService) fetchTalks(ctx context.Context, conferences []domain.Conference) ([]domain.Talk, error) {
+	talks := make([][]domain.Talk, len(conferences))
+	errs, _ := s.fetchConferences(ctx, conferences, func(i int, fetched []domain.Talk) error {
+		talks[i] = fetched
+		return nil
+	})
+	if err := s.checkFetched(ctx, conferences, errs); err != nil {
+		return nil, err
 	}
 
-	if len(allTalks) == 0 {
-		s.logger.Warn("no talks found to index")
+	var allTalks []domain.Talk
+	for i := range conferences {
+		allTalks = append(allTalks, talks[i]...)
+	}
+	return allTalks, nil
+}
+
+// checkFetched checks the fetch errors of the conferences. Conferences whose talks cannot be fetched are
+// logged and skipped, unless more than the allowed share of them failed: moresleep is then taken to be
+// partly down, and an error wrapping domain.ErrSourceIncomplete is returned so the indexes are not rebuilt
+// without the missing conferences. Failures are added to the report of the running job.
+func (s *IndexerService) checkFetched(ctx context.Context, conferences []domain.Conference, errs []error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var failed []string
+	for i, conf := range conferences {
+		if errs[i] != nil {
+			failed = append(failed, conf.Slug)
+		}
+	}
+
+	if len(failed) == 0 {
 		return nil
 	}
 
-	// Index all talks to private index (with privateData merged into data)
-	privateTalks := prepareTalksForPrivateIndex(allTalks)
-	if err := s.searchIndex.BulkIndex(ctx, s.privateIndex, privateTalks); err != nil {
-		return fmt.Errorf("failed to index to private index: %w", err)
+	detail := fmt.Sprintf("talks of %d of %d conferences could not be fetched: %s", len(failed), len(conferences), strings.Join(failed, ", "))
+	var err error
+	if len(failed)*100 > s.maxFailedPercent*len(conferences) {
+		err = fmt.Errorf("%w: %s, more than the %d%% allowed", domain.ErrSourceIncomplete, detail, s.maxFailedPercent)
+		s.logger.ErrorContext(ctx, "aborting full reindex, too many conferences could not be fetched",
+			"failed", len(failed),
+			"conferences", len(conferences),
+			"maxFailedPercent", s.maxFailedPercent,
+		)
 	}
 
-	// Filter approved talks for public index (with private data removed)
-	publicTalks := filterApprovedTalksForPublic(allTalks)
+	if report := jobReportFromContext(ctx); report != nil {
+		step := domain.JobStep{
+			Name:       "fetch talks",
+			State:      domain.JobStepSucceeded,
+			Detail:     detail,
+			FinishedAt: time.Now().UTC(),
+		}
+		if err != nil {
+			step.State = domain.JobStepFailed
+			step.Error = err.Error()
+		}
+		report.step(step)
+	}
+	return err
+}
 
-	s.logger.Info("filtered approved talks for public index",
-		"total", len(allTalks),
-		"approved", len(publicTalks),
-	)
+// fetchConferences fetches the talks of the conferences with a bounded number of concurrent requests
+// and hands the talks of each conference to handle in the worker that fetched them, so no more than
+// that number of conferences are held at a time. The fetch error of each conference is returned at its
+// position, so the result does not depend on which request finishes first. The first error returned by
+// handle stops the remaining conferences from being fetched and is returned.
+func (s *IndexerService) fetchConferences(ctx context.Context, conferences []domain.Conference, handle func(i int, talks []domain.Talk) error) ([]error, error) {
+	errs := make([]error, len(conferences))
+	var fetched atomic.Int64
+	var wg sync.WaitGroup
 
-	// Index approved talks to public index
-	if err := s.searchIndex.BulkIndex(ctx, s.publicIndex, publicTalks); err != nil {
-		return fmt.Errorf("failed to index to public index: %w", err)
+	var handleErr error
+	var handleOnce sync.Once
+	stopped := make(chan struct{})
+
+	queue := make(chan int)
+	for range min(s.concurrency, len(conferences)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				select {
+				case <-stopped:
+					continue
+				default:
+				}
+				conf := conferences[i]
+				talks, err := s.source.GetTalks(ctx, conf.ID)
+				errs[i] = err
+				s.publishFetched(ctx, conf, int(fetched.Add(1)), len(conferences), talks, err)
+				if err != nil {
+					s.logger.ErrorContext(ctx, "failed to fetch talks for conference",
+						"conferenceID", conf.ID,
+						"conferenceName", conf.Name,
+						"error", err,
+					)
+					continue
+				}
+
+				s.logger.InfoContext(ctx, "fetched talks for conference",
+					"conferenceID", conf.ID,
+					"conferenceName", conf.Name,
+					"count", len(talks),
+				)
+				if err := handle(i, talks); err != nil {
+					handleOnce.Do(func() {
+						handleErr = err
+						close(stopped)
+					})
+				}
+			}
+		}()
 	}
 
-	s.logger.Info("full reindex completed successfully",
-		"privateCount", len(allTalks),
-		"publicCount", len(publicTalks),
-	)
+dispatch:
+	for i := range conferences {
+		if ctx.Err() != nil {
+			errs[i] = ctx.Err()
+			continue
+		}
+		select {
+		case queue <- i:
+		case <-stopped:
+			break dispatch
+		}
+	}
+	close(queue)
+	wg.Wait()
 
-	return nil
+	return errs, handleErr
+}
+
+// publishFetched publishes the progress of a reindex after the talks of a conference were fetched
+func (s *IndexerService) publishFetched(ctx context.Context, conf domain.Conference, fetched, conferences int, talks []domain.Talk, err error) {
+	progress := domain.ReindexProgress{
+		Stage:          domain.ReindexStageFetched,
+		ConferenceSlug: conf.Slug,
+		Fetched:        fetched,
+		Conferences:    conferences,
+		Talks:          len(talks),
+	}
+	if err != nil {
+		progress.Error = err.Error()
+	}
+	s.events.Publish(ctx, progress)
 }
 
 // ReindexConference reindexes talks for a specific conference by its slug.
 // It updates both private and public indexes for that conference's talks.
 func (s *IndexerService) ReindexConference(ctx context.Context, slug string) error {
-	s.logger.Info("starting reindex for conference", "slug", slug)
-
-	// Find the conference by slug
-	conferences, err := s.source.GetConferences(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to fetch conferences: %w", err)
-	}
+	return s.runJob(ctx, domain.JobScope{Kind: domain.JobKindReindexConference, Target: slug}, func(ctx context.Context) error {
+		return s.reindexConference(ctx, slug)
+	})
+}
 
-	var targetConference *domain.Conference
-	for _, conf := range conferences {
-		if conf.Slug == slug {
-			targetConference = &conf
-			break
+// ReindexConferenceByID reindexes talks for a specific conference by its ID, which is unambiguous
+// when several conferences share a slug
+func (s *IndexerService) ReindexConferenceByID(ctx context.Context, conferenceID string) error {
+	return s.runJob(ctx, domain.JobScope{Kind: domain.JobKindReindexConferenceID, Target: conferenceID}, func(ctx context.Context) error {
+		conf, err := s.conferences.ConferenceByID(ctx, conferenceID)
+		if errors.Is(err, domain.ErrConferenceNotFound) {
+			return fmt.Errorf("%w with ID: %s", err, conferenceID)
 		}
-	}
+		if err != nil {
+			return err
+		}
+		return s.indexConference(ctx, conf)
+	})
+}
+
+// reindexConference performs a reindex of a single conference
+func (s *IndexerService) reindexConference(ctx context.Context, slug string) error {
+	s.logger.InfoContext(ctx, "starting reindex for conference", "slug", slug)
 
-	if targetConference == nil {
-		return fmt.Errorf("conference not found with slug: %s", slug)
+	targetConference, err := s.conferenceBySlug(ctx, slug)
+	if err != nil {
+		return err
 	}
+	return s.indexConference(ctx, targetConference)
+}
+
+// indexConference writes the talks of the conference to both indexes
+func (s *IndexerService) indexConference(ctx context.Context, targetConference *domain.Conference) error {
+	slug := targetConference.Slug
 
 	// Fetch talks for this conference
 	talks, err := s.source.GetTalks(ctx, targetConference.ID)
+	s.publishFetched(ctx, *targetConference, 1, 1, talks, err)
 	if err != nil {
 		return fmt.Errorf("failed to fetch talks for conference %s: %w", slug, err)
 	}
 
-	s.logger.Info("fetched talks for conference",
+	s.logger.InfoContext(ctx, "fetched talks for conference",
 		"slug", slug,
 		"conferenceID", targetConference.ID,
 		"count", len(talks),
 	)
+	talks = s.applyTransforms(ctx, talks)
+
+	// Remember which talks are public, to tell the talks entering the public index
+	wasPublic, err := s.publicTalkIDs(ctx, []domain.Conference{*targetConference})
+	if err != nil {
+		return err
+	}
 
 	// Ensure indexes exist
 	if err := s.ensureIndexExists(ctx, s.privateIndex); err != nil {
@@ -183,32 +560,65 @@ func (s *IndexerService) ReindexConference(ctx context.Context, slug string) err
 	}
 
 	// Index all talks to private index (with privateData merged into data)
-	privateTalks := prepareTalksForPrivateIndex(talks)
-	if err := s.searchIndex.BulkIndex(ctx, s.privateIndex, privateTalks); err != nil {
+	privateTalks := prepareTalksForPrivateIndex(s.withoutExpired(ctx, talks))
+	if err := s.bulkIndex(ctx, s.privateIndex, privateTalks); err != nil {
 		return fmt.Errorf("failed to index to private index: %w", err)
 	}
 
-	// Filter approved talks for public index (with private data removed)
-	publicTalks := filterApprovedTalksForPublic(talks)
+	// Filter approved talks for public index (with private data removed and free text scrubbed)
+	publicTalks := filterApprovedTalksForPublic(s.scrubPublic(ctx, talks))
 
 	// Index approved talks to public index
-	if err := s.searchIndex.BulkIndex(ctx, s.publicIndex, publicTalks); err != nil {
+	if err := s.bulkIndex(ctx, s.publicIndex, publicTalks); err != nil {
 		return fmt.Errorf("failed to index to public index: %w", err)
 	}
+	if err := s.publishNewlyPublic(ctx, wasPublic, publicTalks); err != nil {
+		return err
+	}
+
+	// Remove talks deleted in the source since the conference was last indexed
+	removed, err := s.removeOrphans(ctx, targetConference.ID, talks)
+	if err != nil {
+		return fmt.Errorf("failed to remove deleted talks: %w", err)
+	}
+
+	s.events.Publish(ctx, domain.ConferenceReindexed{
+		Slugs:        []string{slug},
+		IndexNames:   []string{s.privateIndex, s.publicIndex},
+		PrivateTalks: len(privateTalks),
+		PublicTalks:  len(publicTalks),
+	})
 
-	s.logger.Info("conference reindex completed successfully",
+	s.logger.InfoContext(ctx, "conference reindex completed successfully",
 		"slug", slug,
 		"privateCount", len(talks),
 		"publicCount", len(publicTalks),
+		"removedCount", removed,
 	)
 
 	return nil
 }
 
+// conferenceBySlug finds the conference with the given slug in the source
+func (s *IndexerService) conferenceBySlug(ctx context.Context, slug string) (*domain.Conference, error) {
+	conf, err := s.conferences.ConferenceBySlug(ctx, slug)
+	if errors.Is(err, domain.ErrConferenceNotFound) {
+		return nil, fmt.Errorf("%w with slug: %s", err, slug)
+	}
+	return conf, err
+}
+
 // ReindexTalk reindexes a specific talk by its ID.
 // It fetches the talk directly and updates both indexes.
 func (s *IndexerService) ReindexTalk(ctx context.Context, talkID string) error {
-	s.logger.Info("starting reindex for talk", "talkID", talkID)
+	return s.runJob(ctx, domain.JobScope{Kind: domain.JobKindReindexTalk, Target: talkID}, func(ctx context.Context) error {
+		return s.reindexTalk(ctx, talkID)
+	})
+}
+
+// reindexTalk performs a reindex of a single talk
+func (s *IndexerService) reindexTalk(ctx context.Context, talkID string) error {
+	s.logger.InfoContext(ctx, "starting reindex for talk", "talkID", talkID)
 
 	// Fetch the talk directly by ID
 	targetTalk, err := s.source.GetTalk(ctx, talkID)
@@ -216,10 +626,21 @@ func (s *IndexerService) ReindexTalk(ctx context.Context, talkID string) error {
 		return fmt.Errorf("failed to fetch talk %s: %w", talkID, err)
 	}
 
-	s.logger.Info("fetched talk",
+	s.logger.InfoContext(ctx, "fetched talk",
 		"talkID", talkID,
 		"conferenceSlug", targetTalk.ConferenceSlug,
 	)
+	transformed := s.applyTransforms(ctx, []domain.Talk{*targetTalk})
+	targetTalk = &transformed[0]
+
+	// Talks past their retention period are never public, so there is nothing to write
+	if s.retention != nil && s.retention.Expired(*targetTalk) {
+		s.logger.InfoContext(ctx, "talk is past its retention period, not indexed",
+			"talkID", talkID,
+			"status", targetTalk.Status,
+		)
+		return nil
+	}
 
 	// Ensure indexes exist
 	if err := s.ensureIndexExists(ctx, s.privateIndex); err != nil {
@@ -231,22 +652,40 @@ func (s *IndexerService) ReindexTalk(ctx context.Context, talkID string) error {
 
 	// Index to private index (with privateData merged into data)
 	privateTalk := targetTalk.ToPrivate()
-	if err := s.searchIndex.BulkIndex(ctx, s.privateIndex, []domain.Talk{privateTalk}); err != nil {
+	if err := s.bulkIndex(ctx, s.privateIndex, []domain.Talk{privateTalk}); err != nil {
 		return fmt.Errorf("failed to index to private index: %w", err)
 	}
+	s.events.Publish(ctx, domain.TalkIndexed{
+		TalkID:         targetTalk.ID,
+		ConferenceSlug: targetTalk.ConferenceSlug,
+		Title:          targetTalk.Data["title"],
+		IndexName:      s.privateIndex,
+	})
 
 	// Index to public index only if the talk status is public
 	if domain.TalkStatus(targetTalk.Status).IsPublic() {
-		publicTalk := targetTalk.ToPublic()
-		if err := s.searchIndex.BulkIndex(ctx, s.publicIndex, []domain.Talk{publicTalk}); err != nil {
+		published, err := s.newlyPublic(ctx, *targetTalk)
+		if err != nil {
+			return err
+		}
+		publicTalk := s.scrubPublic(ctx, []domain.Talk{*targetTalk})[0].ToPublic()
+		if err := s.bulkIndex(ctx, s.publicIndex, []domain.Talk{publicTalk}); err != nil {
 			return fmt.Errorf("failed to index to public index: %w", err)
 		}
-		s.logger.Info("talk reindex completed successfully",
+		s.events.Publish(ctx, domain.TalkIndexed{
+			TalkID:         targetTalk.ID,
+			ConferenceSlug: targetTalk.ConferenceSlug,
+			Title:          targetTalk.Data["title"],
+			IndexName:      s.publicIndex,
+			Public:         true,
+			Published:      published,
+		})
+		s.logger.InfoContext(ctx, "talk reindex completed successfully",
 			"talkID", talkID,
 			"indexedToPublic", true,
 		)
 	} else {
-		s.logger.Info("talk reindex completed successfully",
+		s.logger.InfoContext(ctx, "talk reindex completed successfully",
 			"talkID", talkID,
 			"indexedToPublic", false,
 			"status", targetTalk.Status,
@@ -256,6 +695,281 @@ func (s *IndexerService) ReindexTalk(ctx context.Context, talkID string) error {
 	return nil
 }
 
+// newlyPublic reports whether the talk is missing from the public index, that is whether its indexed
+// status was not public before this reindex. Like removeOrphans, it lists the talks of the conference
+// in the index.
+func (s *IndexerService) newlyPublic(ctx context.Context, talk domain.Talk) (bool, error) {
+	ids, err := s.searchIndex.ListTalkIDs(ctx, s.publicIndex, talk.ConferenceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list talks in %s: %w", s.publicIndex, err)
+	}
+	return !slices.Contains(ids, talk.ID), nil
+}
+
+// publicTalkIDs returns the IDs of the talks of the conferences in the public index, or nil if the
+// public index does not exist: a new public index holds every public talk, which would otherwise all
+// be announced as newly public.
+func (s *IndexerService) publicTalkIDs(ctx context.Context, conferences []domain.Conference) (map[string]bool, error) {
+	exists, err := s.searchIndex.IndexExists(ctx, s.publicIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if index exists: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	ids := make(map[string]bool)
+	for _, conf := range conferences {
+		listed, err := s.searchIndex.ListTalkIDs(ctx, s.publicIndex, conf.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list talks in %s: %w", s.publicIndex, err)
+		}
+		for _, id := range listed {
+			ids[id] = true
+		}
+	}
+	return ids, nil
+}
+
+// publishNewlyPublic publishes TalkIndexed with Published set for the talks just written to the public
+// index that are listed in it now but were not in wasPublic, that is whose status became public.
+// Nothing is published when wasPublic is nil.
+func (s *IndexerService) publishNewlyPublic(ctx context.Context, wasPublic map[string]bool, talks []domain.Talk) error {
+	if wasPublic == nil || len(talks) == 0 {
+		return nil
+	}
+
+	isPublic := make(map[string]bool)
+	var listed []string
+	for _, talk := range talks {
+		if slices.Contains(listed, talk.ConferenceID) {
+			continue
+		}
+		listed = append(listed, talk.ConferenceID)
+		ids, err := s.searchIndex.ListTalkIDs(ctx, s.publicIndex, talk.ConferenceID)
+		if err != nil {
+			return fmt.Errorf("failed to list talks in %s: %w", s.publicIndex, err)
+		}
+		for _, id := range ids {
+			isPublic[id] = true
+		}
+	}
+
+	for _, talk := range talks {
+		if !isPublic[talk.ID] || wasPublic[talk.ID] {
+			continue
+		}
+		s.events.Publish(ctx, domain.TalkIndexed{
+			TalkID:         talk.ID,
+			ConferenceSlug: talk.ConferenceSlug,
+			Title:          talk.Data["title"],
+			IndexName:      s.publicIndex,
+			Public:         true,
+			Published:      true,
+			Bulk:           true,
+		})
+	}
+	return nil
+}
+
+// DeleteTalk removes a talk from both indexes, such as a talk withdrawn in moresleep that would otherwise
+// stay indexed until the next full reindex. The deletion is recorded as a job.
+func (s *IndexerService) DeleteTalk(ctx context.Context, talkID string) error {
+	_, err := recordJob(ctx, s.jobs, s.logger, domain.JobScope{Kind: domain.JobKindDeleteTalk, Target: talkID}, func(ctx context.Context) error {
+		return s.deleteTalk(ctx, talkID)
+	})
+	return err
+}
+
+// deleteTalk deletes a talk from the private and public indexes. Talks in neither index are not found.
+func (s *IndexerService) deleteTalk(ctx context.Context, talkID string) error {
+	s.logger.InfoContext(ctx, "deleting talk", "talkID", talkID)
+
+	deleted := domain.TalkDeleted{TalkID: talkID}
+	for _, indexName := range []string{s.privateIndex, s.publicIndex} {
+		talk, err := s.searchIndex.DeleteTalk(ctx, indexName, talkID)
+		if err != nil {
+			return fmt.Errorf("failed to delete talk %s from %s: %w", talkID, indexName, err)
+		}
+		if talk == nil {
+			continue
+		}
+		deleted.ConferenceSlug = talk.ConferenceSlug
+		deleted.IndexNames = append(deleted.IndexNames, indexName)
+		deleted.Public = deleted.Public || indexName == s.publicIndex
+	}
+	if len(deleted.IndexNames) == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTalkNotFound, talkID)
+	}
+
+	s.events.Publish(ctx, deleted)
+
+	s.logger.InfoContext(ctx, "talk deleted",
+		"talkID", talkID,
+		"conferenceSlug", deleted.ConferenceSlug,
+		"indexes", deleted.IndexNames,
+	)
+	return nil
+}
+
+// removeOrphans deletes the talks of the conference stored in either index that are no longer in the
+// source, and returns the number of talks removed. Talks the source still has are kept, even when they
+// were not written to an index, so talks past their retention period or no longer approved are not
+// removed here. Nothing is removed when the source returned no talks, and with a shrink guard the
+// reindex fails rather than remove more than REINDEX_MAX_SHRINK_PERCENT of the talks in an index,
+// unless the caller overrides the guard.
+func (s *IndexerService) removeOrphans(ctx context.Context, conferenceID string, talks []domain.Talk) (int, error) {
+	if len(talks) == 0 {
+		s.logger.WarnContext(ctx, "source returned no talks, not removing any from the indexes", "conferenceID", conferenceID)
+		return 0, nil
+	}
+
+	current := make(map[string]bool, len(talks))
+	for _, talk := range talks {
+		current[talk.ID] = true
+	}
+
+	indexNames := []string{s.privateIndex, s.publicIndex}
+	orphans := make(map[string][]string, len(indexNames))
+	for _, indexName := range indexNames {
+		ids, err := s.searchIndex.ListTalkIDs(ctx, indexName, conferenceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list talks in %s: %w", indexName, err)
+		}
+		for _, id := range ids {
+			if !current[id] {
+				orphans[indexName] = append(orphans[indexName], id)
+			}
+		}
+		if err := s.checkRemoval(ctx, indexName, len(ids), len(orphans[indexName])); err != nil {
+			return 0, err
+		}
+	}
+
+	deleted := make(map[string]*domain.TalkDeleted)
+	var order []string
+	for _, indexName := range indexNames {
+		removed := 0
+		for _, id := range orphans[indexName] {
+			talk, err := s.searchIndex.DeleteTalk(ctx, indexName, id)
+			if err != nil {
+				return 0, fmt.Errorf("failed to delete talk %s from %s: %w", id, indexName, err)
+			}
+			if talk == nil {
+				continue
+			}
+			removed++
+
+			event, ok := deleted[id]
+			if !ok {
+				event = &domain.TalkDeleted{TalkID: id, ConferenceSlug: talk.ConferenceSlug}
+				deleted[id] = event
+				order = append(order, id)
+			}
+			event.IndexNames = append(event.IndexNames, indexName)
+			event.Public = event.Public || indexName == s.publicIndex
+		}
+		if report := jobReportFromContext(ctx); report != nil && removed > 0 {
+			report.remove(indexName, removed)
+		}
+	}
+
+	for _, id := range order {
+		s.events.Publish(ctx, *deleted[id])
+	}
+	if len(order) > 0 {
+		s.logger.InfoContext(ctx, "removed talks deleted in the source",
+			"conferenceID", conferenceID,
+			"talkIDs", order,
+		)
+	}
+	return len(order), nil
+}
+
+// LastReindex returns when the given index was last successfully written to,
+// or the zero time if it has not been written to since startup
+func (s *IndexerService) LastReindex(indexName string) time.Time {
+	s.lastReindexMu.RLock()
+	defer s.lastReindexMu.RUnlock()
+	return s.lastReindex[indexName]
+}
+
+// IndexNames returns the effective names of the private and public indexes
+func (s *IndexerService) IndexNames() domain.IndexNames {
+	return domain.IndexNames{Private: s.privateIndex, Public: s.publicIndex}
+}
+
+// markReindexed records the current time as the last reindex time of the given indexes
+func (s *IndexerService) markReindexed(indexNames ...string) {
+	now := time.Now().UTC()
+	s.lastReindexMu.Lock()
+	defer s.lastReindexMu.Unlock()
+	for _, name := range indexNames {
+		s.lastReindex[name] = now
+	}
+}
+
+// expandPurgePaths expands {conferenceSlug} in the path templates for each conference slug
+func expandPurgePaths(templates []string, slugs []string) []string {
+	paths := make([]string, 0, len(templates))
+	for _, template := range templates {
+		if !strings.Contains(template, "{conferenceSlug}") {
+			paths = append(paths, template)
+			continue
+		}
+		for _, slug := range slugs {
+			if slug != "" {
+				paths = append(paths, strings.ReplaceAll(template, "{conferenceSlug}", url.PathEscape(slug)))
+			}
+		}
+	}
+	return paths
+}
+
+// talkSlugs returns the distinct conference slugs of the given talks
+func talkSlugs(talks []domain.Talk) []string {
+	var slugs []string
+	for _, talk := range talks {
+		if !slices.Contains(slugs, talk.ConferenceSlug) {
+			slugs = append(slugs, talk.ConferenceSlug)
+		}
+	}
+	return slugs
+}
+
+// conferenceSlugs returns the slugs of the given conferences
+func conferenceSlugs(conferences []domain.Conference) []string {
+	slugs := make([]string, 0, len(conferences))
+	for _, conf := range conferences {
+		slugs = append(slugs, conf.Slug)
+	}
+	return slugs
+}
+
+// bulkIndex writes talks to the given index, logging documents skipped as stale
+// and adding the outcome to the report of the running job.
+// Documents that failed indexing are kept in the dead-letter store if one is set.
+func (s *IndexerService) bulkIndex(ctx context.Context, indexName string, talks []domain.Talk) error {
+	result, err := s.searchIndex.BulkIndex(ctx, indexName, talks)
+	if s.deadLetters != nil {
+		saveDeadLetters(ctx, s.deadLetters, s.logger, time.Now().UTC(), indexName, result.Failed)
+	}
+	if err != nil {
+		return err
+	}
+	if report := jobReportFromContext(ctx); report != nil {
+		report.add(indexName, result)
+	}
+	s.events.Publish(ctx, domain.ReindexProgress{Stage: domain.ReindexStageIndexed, IndexName: indexName, Talks: result.Indexed})
+	if len(result.Conflicts) > 0 {
+		s.logger.WarnContext(ctx, "kept newer indexed versions of talks",
+			"index", indexName,
+			"talkIDs", result.Conflicts,
+		)
+	}
+	return nil
+}
+
 // recreateIndex deletes and recreates an index with the appropriate mapping
 func (s *IndexerService) recreateIndex(ctx context.Context, indexName string) error {
 	// Delete the index if it exists
@@ -291,7 +1005,7 @@ func (s *IndexerService) ensureIndexExists(ctx context.Context, indexName string
 
 // getMappingForIndex returns the appropriate mapping for the given index name
 func (s *IndexerService) getMappingForIndex(indexName string) string {
-	if indexName == s.privateIndex {
+	if indexName == s.privateIndex || indexName == s.privateIndex+stagingSuffix {
 		return s.privateIndexMapping
 	}
 	return s.publicIndexMapping
@@ -316,3 +1030,25 @@ func filterApprovedTalksForPublic(talks []domain.Talk) []domain.Talk {
 	}
 	return approved
 }
+
+// indexedTalk returns the document of the talk in the index, or nil if the index or the document does
+// not exist. The talks of its conference are read, so it works with every search backend.
+func (s *IndexerService) indexedTalk(ctx context.Context, reader ports.TalkReader, indexName, slug, talkID string) (*domain.Talk, error) {
+	exists, err := s.searchIndex.IndexExists(ctx, indexName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if index exists: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	talks, err := reader.FetchTalks(ctx, indexName, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks of %s from %s: %w", slug, indexName, err)
+	}
+	for _, talk := range talks {
+		if talk.ID == talkID {
+			return &talk, nil
+		}
+	}
+	return nil, nil
+}
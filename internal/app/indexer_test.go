@@ -3,7 +3,11 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/javaBin/talks-indexer/internal/config"
 	"github.com/javaBin/talks-indexer/internal/domain"
@@ -57,12 +61,20 @@ func (m *mockTalkSource) GetTalk(ctx context.Context, talkID string) (*domain.Ta
 // mockSearchIndex is a mock implementation of ports.SearchIndex
 type mockSearchIndex struct {
 	bulkIndexFunc    func(ctx context.Context, indexName string, talks []domain.Talk) error
+	bulkConflicts    []string
+	bulkFailed       []domain.FailedDocument
 	deleteIndexFunc  func(ctx context.Context, indexName string) error
 	createIndexFunc  func(ctx context.Context, indexName string, mapping string) error
 	indexExistsFunc  func(ctx context.Context, indexName string) (bool, error)
+	deleteTalkFunc   func(ctx context.Context, indexName string, talkID string) (*domain.Talk, error)
+	listTalkIDsFunc  func(ctx context.Context, indexName string, conferenceID string) ([]string, error)
+	fetchTalksFunc   func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error)
 	bulkIndexCalls   []bulkIndexCall
 	deleteIndexCalls []string
 	createIndexCalls []string
+
+	// mu guards bulkIndexCalls, which full reindexes append to from several workers
+	mu sync.Mutex
 }
 
 type bulkIndexCall struct {
@@ -70,12 +82,15 @@ type bulkIndexCall struct {
 	Talks     []domain.Talk
 }
 
-func (m *mockSearchIndex) BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) error {
+func (m *mockSearchIndex) BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) (domain.BulkResult, error) {
+	m.mu.Lock()
 	m.bulkIndexCalls = append(m.bulkIndexCalls, bulkIndexCall{IndexName: indexName, Talks: talks})
+	m.mu.Unlock()
+	result := domain.BulkResult{Indexed: len(talks) - len(m.bulkConflicts) - len(m.bulkFailed), Conflicts: m.bulkConflicts, Failed: m.bulkFailed}
 	if m.bulkIndexFunc != nil {
-		return m.bulkIndexFunc(ctx, indexName, talks)
+		return result, m.bulkIndexFunc(ctx, indexName, talks)
 	}
-	return nil
+	return result, nil
 }
 
 func (m *mockSearchIndex) DeleteIndex(ctx context.Context, indexName string) error {
@@ -101,6 +116,54 @@ func (m *mockSearchIndex) IndexExists(ctx context.Context, indexName string) (bo
 	return true, nil
 }
 
+func (m *mockSearchIndex) DeleteTalk(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+	if m.deleteTalkFunc != nil {
+		return m.deleteTalkFunc(ctx, indexName, talkID)
+	}
+	return nil, nil
+}
+
+func (m *mockSearchIndex) ListTalkIDs(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+	if m.listTalkIDsFunc != nil {
+		return m.listTalkIDsFunc(ctx, indexName, conferenceID)
+	}
+	return nil, nil
+}
+
+// FetchTalks returns the talks of the conference written to the index, unless fetchTalksFunc is set
+func (m *mockSearchIndex) FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+	if m.fetchTalksFunc != nil {
+		return m.fetchTalksFunc(ctx, indexName, conferenceSlug)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var talks []domain.Talk
+	for _, call := range m.bulkIndexCalls {
+		if call.IndexName != indexName {
+			continue
+		}
+		for _, talk := range call.Talks {
+			if conferenceSlug == "" || talk.ConferenceSlug == conferenceSlug {
+				talks = append(talks, talk)
+			}
+		}
+	}
+	return talks, nil
+}
+
+// callsTo returns the bulk writes to the index, leaving out the staging indexes of full reindexes
+func (m *mockSearchIndex) callsTo(indexName string) []bulkIndexCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []bulkIndexCall
+	for _, call := range m.bulkIndexCalls {
+		if call.IndexName == indexName {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
 func TestNewIndexerService(t *testing.T) {
 	t.Run("with context config", func(t *testing.T) {
 		source := &mockTalkSource{}
@@ -120,6 +183,16 @@ func TestNewIndexerService(t *testing.T) {
 		assert.Equal(t, testPublicMapping, service.publicIndexMapping)
 	})
 
+	t.Run("applies index prefix", func(t *testing.T) {
+		cfg := testIndexConfig()
+		cfg.Index.Prefix = "staging_"
+		ctx := config.WithConfig(context.Background(), cfg)
+
+		service := NewIndexerService(ctx, &mockTalkSource{}, &mockSearchIndex{}, testPrivateMapping, testPublicMapping)
+
+		assert.Equal(t, domain.IndexNames{Private: "staging_private", Public: "staging_public"}, service.IndexNames())
+	})
+
 	t.Run("panics when config not in context", func(t *testing.T) {
 		source := &mockTalkSource{}
 		index := &mockSearchIndex{}
@@ -173,24 +246,20 @@ func TestReindexAll_Success(t *testing.T) {
 
 	require.NoError(t, err)
 
-	// Verify indexes were recreated
-	assert.Contains(t, index.deleteIndexCalls, "private")
-	assert.Contains(t, index.deleteIndexCalls, "public")
-	assert.Contains(t, index.createIndexCalls, "private")
-	assert.Contains(t, index.createIndexCalls, "public")
-
-	// Verify bulk index calls
-	require.Len(t, index.bulkIndexCalls, 2)
+	// Verify indexes were recreated, and the staging indexes written first are dropped
+	assert.Equal(t, []string{"private_staging", "public_staging", "private", "public"}, index.createIndexCalls)
+	assert.Equal(t, []string{"private_staging", "public_staging", "private", "public", "private_staging", "public_staging"}, index.deleteIndexCalls)
 
-	// First call should be private index with all talks
-	privateCall := index.bulkIndexCalls[0]
-	assert.Equal(t, "private", privateCall.IndexName)
-	assert.Len(t, privateCall.Talks, 3)
+	// Private index gets all talks
+	privateCalls := index.callsTo("private")
+	require.Len(t, privateCalls, 1)
+	assert.Len(t, privateCalls[0].Talks, 3)
+	assert.Len(t, index.callsTo("private_staging"), 1)
 
-	// Second call should be public index with only approved talks
-	publicCall := index.bulkIndexCalls[1]
-	assert.Equal(t, "public", publicCall.IndexName)
-	assert.Len(t, publicCall.Talks, 2)
+	// Public index gets only approved talks
+	publicCalls := index.callsTo("public")
+	require.Len(t, publicCalls, 1)
+	assert.Len(t, publicCalls[0].Talks, 2)
 }
 
 func TestReindexAll_NoConferences(t *testing.T) {
@@ -260,7 +329,141 @@ func TestReindexAll_FetchTalksError_ContinuesWithOtherConferences(t *testing.T)
 	require.NoError(t, err)
 
 	// Should have indexed talks from conf-2
-	require.Len(t, index.bulkIndexCalls, 2)
+	require.Len(t, index.callsTo("private"), 1)
+	require.Len(t, index.callsTo("public"), 1)
+}
+
+func TestReindexAll_TooManyFetchTalksErrors_KeepsIndexes(t *testing.T) {
+	conferences := []domain.Conference{
+		{ID: "conf-1", Name: "Conference 1", Slug: "conf1"},
+		{ID: "conf-2", Name: "Conference 2", Slug: "conf2"},
+		{ID: "conf-3", Name: "Conference 3", Slug: "conf3"},
+		{ID: "conf-4", Name: "Conference 4", Slug: "conf4"},
+	}
+
+	tests := []struct {
+		name      string
+		failing   int
+		wantError bool
+	}{
+		{name: "within threshold", failing: 1, wantError: false},
+		{name: "above threshold", failing: 2, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &mockTalkSource{
+				getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+					return conferences, nil
+				},
+				getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+					for _, conf := range conferences[:tt.failing] {
+						if conf.ID == conferenceID {
+							return nil, errors.New("500 Internal Server Error")
+						}
+					}
+					return []domain.Talk{{ID: "talk-" + conferenceID, Status: "APPROVED"}}, nil
+				},
+			}
+			index := &mockSearchIndex{}
+
+			cfg := testIndexConfig()
+			cfg.Reindex.MaxFailedPercent = 25
+			service := NewIndexerService(config.WithConfig(context.Background(), cfg), source, index, testPrivateMapping, testPublicMapping)
+
+			err := service.ReindexAll(context.Background())
+
+			if tt.wantError {
+				require.ErrorIs(t, err, domain.ErrSourceIncomplete)
+				assert.Contains(t, err.Error(), "conf1, conf2")
+				assert.Equal(t, []string{"private_staging", "public_staging"}, index.createIndexCalls, "the live indexes are kept")
+				assert.Empty(t, index.callsTo("private"))
+				assert.Empty(t, index.callsTo("public"))
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, index.callsTo("private"), 1)
+			assert.Len(t, index.callsTo("public"), 1)
+		})
+	}
+}
+
+func TestReindexAll_StagingFailureKeepsIndexes(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "conf1"}, {ID: "conf-2", Slug: "conf2"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-" + conferenceID, Status: "APPROVED"}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		bulkIndexFunc: func(ctx context.Context, indexName string, talks []domain.Talk) error {
+			return errors.New("disk full")
+		},
+	}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	err := service.ReindexAll(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to stage talks of conf1")
+	assert.Equal(t, []string{"private_staging", "public_staging"}, index.createIndexCalls, "the live indexes are kept")
+	assert.Len(t, index.bulkIndexCalls, 1, "no further conference is fetched")
+	assert.Equal(t, []string{"private_staging", "public_staging", "private_staging", "public_staging"}, index.deleteIndexCalls)
+}
+
+func TestReindexAll_FetchesConferencesConcurrently(t *testing.T) {
+	var conferences []domain.Conference
+	for i := range 10 {
+		id := fmt.Sprintf("conf-%d", i)
+		conferences = append(conferences, domain.Conference{ID: id, Name: id, Slug: id})
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return conferences, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				current := maxInFlight.Load()
+				if n <= current || maxInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if conferenceID == "conf-3" {
+				return nil, errors.New("500 Internal Server Error")
+			}
+			return []domain.Talk{{ID: "talk-" + conferenceID, ConferenceSlug: conferenceID, Status: "APPROVED"}}, nil
+		},
+	}
+	index := &mockSearchIndex{}
+
+	cfg := testIndexConfig()
+	cfg.Reindex.MaxFailedPercent = 20
+	cfg.Reindex.Concurrency = 3
+	service := NewIndexerService(config.WithConfig(context.Background(), cfg), source, index, testPrivateMapping, testPublicMapping)
+
+	require.NoError(t, service.ReindexAll(context.Background()))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+
+	// Each conference is staged by the worker that fetched it and copied to the live index on its own,
+	// so no write holds more than one conference
+	assert.Len(t, index.callsTo("private_staging"), 9)
+	privateCalls := index.callsTo("private")
+	require.Len(t, privateCalls, 9)
+	var ids []string
+	for _, call := range privateCalls {
+		require.Len(t, call.Talks, 1)
+		ids = append(ids, call.Talks[0].ID)
+	}
+	assert.ElementsMatch(t, []string{"talk-conf-0", "talk-conf-1", "talk-conf-2", "talk-conf-4", "talk-conf-5",
+		"talk-conf-6", "talk-conf-7", "talk-conf-8", "talk-conf-9"}, ids)
 }
 
 func TestReindexConference_Success(t *testing.T) {
@@ -308,6 +511,244 @@ func TestReindexConference_Success(t *testing.T) {
 	assert.Len(t, publicCall.Talks, 1) // Only approved
 }
 
+func TestReindexConference_RemovesDeletedTalks(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{
+				{ID: "talk-1", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "APPROVED"},
+				{ID: "talk-2", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "SUBMITTED"},
+			}, nil
+		},
+	}
+	stored := map[string][]string{
+		"private": {"talk-1", "talk-2", "talk-3", "talk-4"},
+		"public":  {"talk-1", "talk-3"},
+	}
+	var deleted []string
+	index := &mockSearchIndex{
+		listTalkIDsFunc: func(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+			assert.Equal(t, "conf-1", conferenceID)
+			return stored[indexName], nil
+		},
+		deleteTalkFunc: func(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+			deleted = append(deleted, indexName+"/"+talkID)
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024"}, nil
+		},
+	}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+	handler := &recordingHandler{}
+	service.Events().Subscribe(handler)
+
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	assert.Equal(t, []string{"private/talk-3", "private/talk-4", "public/talk-3"}, deleted)
+	assert.Contains(t, handler.events, domain.IndexEvent(domain.TalkDeleted{
+		TalkID:         "talk-3",
+		ConferenceSlug: "javazone2024",
+		IndexNames:     []string{"private", "public"},
+		Public:         true,
+	}))
+	assert.Contains(t, handler.events, domain.IndexEvent(domain.TalkDeleted{
+		TalkID:         "talk-4",
+		ConferenceSlug: "javazone2024",
+		IndexNames:     []string{"private"},
+	}))
+	assert.Equal(t, map[string]int{"private": 2, "public": 1}, jobs.jobs["job-1"].Report.Removed)
+}
+
+func TestReindexConference_RemoveError(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-1", ConferenceID: "conf-1", Status: "APPROVED"}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		listTalkIDsFunc: func(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+			return nil, errors.New("search failed")
+		},
+	}
+	handler := &recordingHandler{}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.Events().Subscribe(handler)
+
+	err := service.ReindexConference(context.Background(), "javazone2024")
+	assert.ErrorContains(t, err, "search failed")
+	for _, event := range handler.events {
+		assert.NotEqual(t, "conference_reindexed", event.IndexEventName(), "the reindex is not reported as completed")
+	}
+}
+
+func TestReindexConference_RemovalGuards(t *testing.T) {
+	newService := func(talks []domain.Talk, deleted *[]string) *IndexerService {
+		source := &mockTalkSource{
+			getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+				return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+			},
+			getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+				return talks, nil
+			},
+		}
+		index := &mockSearchIndex{
+			listTalkIDsFunc: func(ctx context.Context, indexName string, conferenceID string) ([]string, error) {
+				return []string{"talk-1", "talk-2", "talk-3", "talk-4"}, nil
+			},
+			deleteTalkFunc: func(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+				*deleted = append(*deleted, indexName+"/"+talkID)
+				return &domain.Talk{ID: talkID}, nil
+			},
+		}
+		service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+		service.SetShrinkGuard(NewShrinkGuardWithConfig(&mockIndexVersioner{}, config.ReindexConfig{MaxShrinkPercent: 50}))
+		return service
+	}
+	oneTalk := []domain.Talk{{ID: "talk-1", ConferenceID: "conf-1", Status: "APPROVED"}}
+
+	t.Run("keeps the indexes when the source returns no talks", func(t *testing.T) {
+		var deleted []string
+		require.NoError(t, newService(nil, &deleted).ReindexConference(context.Background(), "javazone2024"))
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("refuses to remove most talks", func(t *testing.T) {
+		var deleted []string
+		err := newService(oneTalk, &deleted).ReindexConference(context.Background(), "javazone2024")
+		require.ErrorIs(t, err, domain.ErrIndexShrink)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("removes most talks when overridden", func(t *testing.T) {
+		var deleted []string
+		err := newService(oneTalk, &deleted).ReindexConference(domain.WithShrinkAllowed(context.Background()), "javazone2024")
+		require.NoError(t, err)
+		assert.Len(t, deleted, 6)
+	})
+}
+
+func TestLastReindex(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-1", ConferenceID: "conf-1", Status: "APPROVED"}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	assert.True(t, service.LastReindex("public").IsZero())
+
+	before := time.Now()
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	assert.False(t, service.LastReindex("private").Before(before))
+	assert.False(t, service.LastReindex("public").Before(before))
+	assert.True(t, service.LastReindex("other").IsZero())
+}
+
+// mockCachePurger is a mock implementation of ports.CachePurger
+type mockCachePurger struct {
+	purgeErr error
+	paths    [][]string
+}
+
+func (m *mockCachePurger) Purge(ctx context.Context, paths []string) error {
+	m.paths = append(m.paths, paths)
+	return m.purgeErr
+}
+
+func TestReindexConference_PurgesCache(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-1", ConferenceID: "conf-1", Status: "APPROVED"}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+	purger := &mockCachePurger{purgeErr: errors.New("purge failed")}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetCachePurger(purger, []string{"/api/conferences", "/public/allSessions/{conferenceSlug}"})
+
+	// A failing purge must not fail the reindex
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	require.Len(t, purger.paths, 1)
+	assert.Equal(t, []string{"/api/conferences", "/public/allSessions/javazone2024"}, purger.paths[0])
+}
+
+func TestReindexConference_NoPurgeOnIndexError(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+		bulkIndexFunc: func(ctx context.Context, indexName string, talks []domain.Talk) error {
+			return errors.New("bulk failed")
+		},
+	}
+	purger := &mockCachePurger{}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetCachePurger(purger, []string{"/api/conferences"})
+
+	require.Error(t, service.ReindexConference(context.Background(), "javazone2024"))
+	assert.Empty(t, purger.paths)
+}
+
+func TestReindexTalk_VersionConflictIsNotAnError(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "APPROVED"}, nil
+		},
+	}
+	index := &mockSearchIndex{bulkConflicts: []string{"talk-1"}}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+	assert.Len(t, index.bulkIndexCalls, 2)
+}
+
+func TestExpandPurgePaths(t *testing.T) {
+	paths := expandPurgePaths(
+		[]string{"/api/conferences", "/public/allSessions/{conferenceSlug}", "/program/{conferenceSlug}"},
+		[]string{"javazone2023", "", "javazone2024"},
+	)
+
+	assert.Equal(t, []string{
+		"/api/conferences",
+		"/public/allSessions/javazone2023",
+		"/public/allSessions/javazone2024",
+		"/program/javazone2023",
+		"/program/javazone2024",
+	}, paths)
+}
+
 func TestReindexConference_NotFound(t *testing.T) {
 	conferences := []domain.Conference{
 		{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"},
@@ -468,6 +909,94 @@ func TestReindexTalk_CreateIndexIfNotExists(t *testing.T) {
 	assert.Contains(t, index.createIndexCalls, "public")
 }
 
+func TestDeleteTalk(t *testing.T) {
+	var deletedFrom []string
+	index := &mockSearchIndex{
+		deleteTalkFunc: func(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+			assert.Equal(t, "talk-1", talkID)
+			deletedFrom = append(deletedFrom, indexName)
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024"}, nil
+		},
+	}
+	purger := &mockCachePurger{}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(&mockTalkSource{}, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetCachePurger(purger, []string{"/public/allSessions/{conferenceSlug}"})
+	service.SetJobStore(jobs)
+	handler := &recordingHandler{}
+	service.Events().Subscribe(handler)
+
+	require.NoError(t, service.DeleteTalk(context.Background(), "talk-1"))
+
+	assert.Equal(t, []string{"private", "public"}, deletedFrom)
+	assert.Equal(t, []domain.IndexEvent{domain.TalkDeleted{
+		TalkID:         "talk-1",
+		ConferenceSlug: "javazone2024",
+		IndexNames:     []string{"private", "public"},
+		Public:         true,
+	}}, handler.events)
+	assert.Empty(t, handler.progress, "deletions are not reindexes")
+	assert.Equal(t, [][]string{{"/public/allSessions/javazone2024"}}, purger.paths)
+	assert.False(t, service.LastReindex("public").IsZero())
+
+	job := jobs.jobs["job-1"]
+	assert.Equal(t, domain.JobScope{Kind: domain.JobKindDeleteTalk, Target: "talk-1"}, job.Scope)
+	assert.Equal(t, domain.JobStateSucceeded, job.State)
+}
+
+func TestDeleteTalk_PrivateOnly(t *testing.T) {
+	index := &mockSearchIndex{
+		deleteTalkFunc: func(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+			if indexName == "public" {
+				return nil, nil
+			}
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024"}, nil
+		},
+	}
+	purger := &mockCachePurger{}
+
+	service := NewIndexerServiceWithConfig(&mockTalkSource{}, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetCachePurger(purger, []string{"/public/allSessions/{conferenceSlug}"})
+	handler := &recordingHandler{}
+	service.Events().Subscribe(handler)
+
+	require.NoError(t, service.DeleteTalk(context.Background(), "talk-1"))
+
+	assert.Equal(t, []domain.IndexEvent{domain.TalkDeleted{
+		TalkID:         "talk-1",
+		ConferenceSlug: "javazone2024",
+		IndexNames:     []string{"private"},
+	}}, handler.events)
+	assert.Empty(t, purger.paths, "the public index did not change")
+}
+
+func TestDeleteTalk_Errors(t *testing.T) {
+	t.Run("talk in neither index", func(t *testing.T) {
+		service := NewIndexerServiceWithConfig(&mockTalkSource{}, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+		handler := &recordingHandler{}
+		service.Events().Subscribe(handler)
+
+		err := service.DeleteTalk(context.Background(), "missing")
+
+		assert.ErrorIs(t, err, domain.ErrTalkNotFound)
+		assert.Empty(t, handler.events)
+	})
+
+	t.Run("index error", func(t *testing.T) {
+		index := &mockSearchIndex{
+			deleteTalkFunc: func(ctx context.Context, indexName string, talkID string) (*domain.Talk, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		service := NewIndexerServiceWithConfig(&mockTalkSource{}, index, "private", "public", testPrivateMapping, testPublicMapping)
+
+		err := service.DeleteTalk(context.Background(), "talk-1")
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
+
 func TestFilterApprovedTalksForPublic(t *testing.T) {
 	talks := []domain.Talk{
 		{ID: "1", Status: "APPROVED"},
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// IndexLifecycleService lists the indexes of this environment and maintains stale generations and aliases.
+// Only indexes matching the configured prefix are visible, and indexes the indexer uses are never deleted.
+// Without a prefix the cluster may be shared with other applications, so only the talk indexes and their
+// generations are listed and nothing can be deleted or repointed.
+type IndexLifecycleService struct {
+	admin   ports.IndexAdmin
+	prefix  string
+	private string
+	public  string
+	inUse   []string
+	logger  *slog.Logger
+}
+
+// NewIndexLifecycleService creates a new IndexLifecycleService, receiving context as first parameter
+// to retrieve configuration.
+func NewIndexLifecycleService(ctx context.Context, admin ports.IndexAdmin) *IndexLifecycleService {
+	cfg := config.GetConfig(ctx)
+	return NewIndexLifecycleServiceWithConfig(admin, cfg.Index)
+}
+
+// NewIndexLifecycleServiceWithConfig creates a new IndexLifecycleService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewIndexLifecycleServiceWithConfig(admin ports.IndexAdmin, cfg config.IndexConfig) *IndexLifecycleService {
+	return &IndexLifecycleService{
+		admin:   admin,
+		prefix:  cfg.Prefix,
+		private: cfg.PrivateName(),
+		public:  cfg.PublicName(),
+		inUse: []string{
+			cfg.PrivateName(),
+			cfg.PublicName(),
+			cfg.SettingsName(),
+			cfg.JobsName(),
+			cfg.DeadLettersName(),
+		},
+		logger: slog.Default().With("component", "indices"),
+	}
+}
+
+// ListIndexes returns the indexes matching the prefix, marking those the indexer uses directly or through an alias.
+// Without a prefix only the talk indexes and their generations are listed.
+func (s *IndexLifecycleService) ListIndexes(ctx context.Context) ([]domain.IndexInfo, error) {
+	patterns := []string{s.prefix + "*"}
+	if s.prefix == "" {
+		patterns = []string{s.private + "*", s.public + "*"}
+	}
+
+	var indexes []domain.IndexInfo
+	for _, pattern := range patterns {
+		listed, err := s.admin.ListIndexes(ctx, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list indexes: %w", err)
+		}
+		for _, index := range listed {
+			if !slices.ContainsFunc(indexes, func(other domain.IndexInfo) bool { return other.Name == index.Name }) {
+				indexes = append(indexes, index)
+			}
+		}
+	}
+	slices.SortFunc(indexes, func(a, b domain.IndexInfo) int { return strings.Compare(a.Name, b.Name) })
+
+	for i := range indexes {
+		indexes[i].InUse = slices.Contains(s.inUse, indexes[i].Name) ||
+			slices.ContainsFunc(indexes[i].Aliases, func(alias string) bool { return slices.Contains(s.inUse, alias) })
+	}
+	return indexes, nil
+}
+
+// DeleteIndex deletes a stale index. Indexes outside the prefix or in use are refused, as is every index
+// when no prefix is configured.
+func (s *IndexLifecycleService) DeleteIndex(ctx context.Context, indexName string) error {
+	if err := s.requirePrefix(); err != nil {
+		return err
+	}
+	index, err := s.findIndex(ctx, indexName)
+	if err != nil {
+		return err
+	}
+	if index.InUse {
+		return fmt.Errorf("index %s is in use and cannot be deleted", indexName)
+	}
+
+	if err := s.admin.DeleteIndex(ctx, indexName); err != nil {
+		return fmt.Errorf("failed to delete index %s: %w", indexName, err)
+	}
+
+	s.logger.InfoContext(ctx, "deleted stale index", "index", indexName, "docCount", index.DocCount)
+	return nil
+}
+
+// PointAlias makes the alias point to the index. The alias must carry the prefix and must not
+// be the name of an existing index. An alias named like a talk index may only point to an index or
+// generation of the same talk index, so the public alias never exposes the private documents.
+func (s *IndexLifecycleService) PointAlias(ctx context.Context, alias string, indexName string) error {
+	if err := s.requirePrefix(); err != nil {
+		return err
+	}
+	alias = strings.TrimSpace(alias)
+	if alias == "" || !strings.HasPrefix(alias, s.prefix) {
+		return fmt.Errorf("alias %q must start with the index prefix %q", alias, s.prefix)
+	}
+
+	indexes, err := s.ListIndexes(ctx)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(indexes, func(index domain.IndexInfo) bool { return index.Name == alias }) {
+		return fmt.Errorf("%s is an index, not an alias", alias)
+	}
+	if !slices.ContainsFunc(indexes, func(index domain.IndexInfo) bool { return index.Name == indexName }) {
+		return fmt.Errorf("index %s not found", indexName)
+	}
+	if kind := s.talkIndexOf(alias); kind != "" && s.talkIndexOf(indexName) != kind {
+		return fmt.Errorf("alias %s may only point to %s or one of its generations", alias, kind)
+	}
+
+	if err := s.admin.PointAlias(ctx, alias, indexName); err != nil {
+		return fmt.Errorf("failed to point alias %s to %s: %w", alias, indexName, err)
+	}
+
+	s.logger.InfoContext(ctx, "pointed alias", "alias", alias, "index", indexName)
+	return nil
+}
+
+// findIndex returns the listed index with the given name
+func (s *IndexLifecycleService) findIndex(ctx context.Context, indexName string) (domain.IndexInfo, error) {
+	indexes, err := s.ListIndexes(ctx)
+	if err != nil {
+		return domain.IndexInfo{}, err
+	}
+
+	i := slices.IndexFunc(indexes, func(index domain.IndexInfo) bool { return index.Name == indexName })
+	if i < 0 {
+		return domain.IndexInfo{}, fmt.Errorf("index %s not found", indexName)
+	}
+	return indexes[i], nil
+}
+
+// requirePrefix refuses changes to the cluster when no prefix separates this environment's indexes
+// from those of other applications
+func (s *IndexLifecycleService) requirePrefix() error {
+	if s.prefix == "" {
+		return errors.New("indexes can only be deleted or repointed when INDEX_PREFIX is set")
+	}
+	return nil
+}
+
+// talkIndexOf returns the talk index the name is an index, generation or alias of, or "" if it belongs
+// to neither. When one talk index name starts with the other, the longer match wins.
+func (s *IndexLifecycleService) talkIndexOf(name string) string {
+	var match string
+	for _, talkIndex := range []string{s.private, s.public} {
+		if strings.HasPrefix(name, talkIndex) && len(talkIndex) > len(match) {
+			match = talkIndex
+		}
+	}
+	return match
+}
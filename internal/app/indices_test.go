@@ -0,0 +1,164 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIndexAdmin is a mock implementation of ports.IndexAdmin. Wildcard patterns list the indexes
+// starting with the pattern, other patterns list every index.
+type mockIndexAdmin struct {
+	indexes          []domain.IndexInfo
+	listPatterns     []string
+	deleteIndexCalls []string
+	pointAliasCalls  [][2]string
+}
+
+func (m *mockIndexAdmin) ListIndexes(ctx context.Context, pattern string) ([]domain.IndexInfo, error) {
+	m.listPatterns = append(m.listPatterns, pattern)
+	prefix, wildcard := strings.CutSuffix(pattern, "*")
+	if !wildcard {
+		return append([]domain.IndexInfo(nil), m.indexes...), nil
+	}
+	var indexes []domain.IndexInfo
+	for _, index := range m.indexes {
+		if strings.HasPrefix(index.Name, prefix) {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes, nil
+}
+
+func (m *mockIndexAdmin) DeleteIndex(ctx context.Context, indexName string) error {
+	m.deleteIndexCalls = append(m.deleteIndexCalls, indexName)
+	return nil
+}
+
+func (m *mockIndexAdmin) PointAlias(ctx context.Context, alias string, indexName string) error {
+	m.pointAliasCalls = append(m.pointAliasCalls, [2]string{alias, indexName})
+	return nil
+}
+
+func newTestIndexAdmin() *mockIndexAdmin {
+	return &mockIndexAdmin{
+		indexes: []domain.IndexInfo{
+			{Name: "staging_javazone_private"},
+			{Name: "staging_javazone_public_v1"},
+			{Name: "staging_javazone_public_v2", Aliases: []string{"staging_javazone_public"}},
+		},
+	}
+}
+
+func newTestIndexLifecycleService(admin *mockIndexAdmin) *IndexLifecycleService {
+	return NewIndexLifecycleServiceWithConfig(admin, lifecycleIndexConfig("staging_"))
+}
+
+func lifecycleIndexConfig(prefix string) config.IndexConfig {
+	return config.IndexConfig{
+		Prefix:      prefix,
+		Private:     "javazone_private",
+		Public:      "javazone_public",
+		Settings:    "talks_indexer_settings",
+		Jobs:        "talks_indexer_jobs",
+		DeadLetters: "talks_indexer_dead_letters",
+	}
+}
+
+func TestIndexLifecycle_ListIndexes(t *testing.T) {
+	admin := newTestIndexAdmin()
+	service := newTestIndexLifecycleService(admin)
+
+	indexes, err := service.ListIndexes(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging_*"}, admin.listPatterns)
+	require.Len(t, indexes, 3)
+	assert.True(t, indexes[0].InUse, "used directly")
+	assert.False(t, indexes[1].InUse, "stale generation")
+	assert.True(t, indexes[2].InUse, "used through an alias")
+}
+
+func TestIndexLifecycle_DeleteIndex(t *testing.T) {
+	admin := newTestIndexAdmin()
+	service := newTestIndexLifecycleService(admin)
+	ctx := context.Background()
+
+	require.NoError(t, service.DeleteIndex(ctx, "staging_javazone_public_v1"))
+	assert.Equal(t, []string{"staging_javazone_public_v1"}, admin.deleteIndexCalls)
+
+	assert.Error(t, service.DeleteIndex(ctx, "staging_javazone_public_v2"), "in use through an alias")
+	assert.Error(t, service.DeleteIndex(ctx, "staging_javazone_private"), "in use directly")
+	assert.Error(t, service.DeleteIndex(ctx, "production_javazone_public"), "outside the prefix")
+	assert.Len(t, admin.deleteIndexCalls, 1)
+
+	// The indexes holding the indexer's own state are in use too
+	for _, name := range []string{"staging_talks_indexer_settings", "staging_talks_indexer_jobs", "staging_talks_indexer_dead_letters"} {
+		admin.indexes = append(admin.indexes, domain.IndexInfo{Name: name})
+		assert.ErrorContains(t, service.DeleteIndex(ctx, name), "in use", name)
+	}
+	assert.Len(t, admin.deleteIndexCalls, 1)
+}
+
+func TestIndexLifecycle_PointAlias(t *testing.T) {
+	admin := newTestIndexAdmin()
+	service := newTestIndexLifecycleService(admin)
+	ctx := context.Background()
+
+	require.NoError(t, service.PointAlias(ctx, " staging_javazone_public ", "staging_javazone_public_v1"))
+	assert.Equal(t, [][2]string{{"staging_javazone_public", "staging_javazone_public_v1"}}, admin.pointAliasCalls)
+
+	assert.Error(t, service.PointAlias(ctx, "javazone_public", "staging_javazone_public_v1"), "alias outside the prefix")
+	assert.Error(t, service.PointAlias(ctx, "staging_javazone_private", "staging_javazone_public_v1"), "alias is an index")
+	assert.Error(t, service.PointAlias(ctx, "staging_javazone_public", "staging_missing"), "unknown index")
+	assert.Len(t, admin.pointAliasCalls, 1)
+}
+
+func TestIndexLifecycle_PointAlias_KeepsPrivateDocumentsPrivate(t *testing.T) {
+	admin := newTestIndexAdmin()
+	admin.indexes = append(admin.indexes,
+		domain.IndexInfo{Name: "staging_javazone_private_20250101120000"},
+		domain.IndexInfo{Name: "staging_talks_indexer_settings"},
+	)
+	service := newTestIndexLifecycleService(admin)
+	ctx := context.Background()
+
+	assert.Error(t, service.PointAlias(ctx, "staging_javazone_public", "staging_javazone_private_20250101120000"), "private generation behind the public alias")
+	assert.Error(t, service.PointAlias(ctx, "staging_javazone_public", "staging_talks_indexer_settings"), "other index behind the public alias")
+	assert.Error(t, service.PointAlias(ctx, "staging_javazone_private_current", "staging_javazone_public_v1"), "public generation behind a private alias")
+	assert.Empty(t, admin.pointAliasCalls)
+
+	require.NoError(t, service.PointAlias(ctx, "staging_javazone_private_current", "staging_javazone_private_20250101120000"))
+	require.NoError(t, service.PointAlias(ctx, "staging_archive", "staging_javazone_public_v1"), "other aliases are not restricted")
+}
+
+func TestIndexLifecycle_WithoutPrefix(t *testing.T) {
+	admin := &mockIndexAdmin{
+		indexes: []domain.IndexInfo{
+			{Name: "another_app"},
+			{Name: "javazone_private"},
+			{Name: "javazone_public_20250101120000", Aliases: []string{"javazone_public"}},
+			{Name: "javazone_public_20240101120000"},
+		},
+	}
+	service := NewIndexLifecycleServiceWithConfig(admin, lifecycleIndexConfig(""))
+	ctx := context.Background()
+
+	indexes, err := service.ListIndexes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"javazone_private*", "javazone_public*"}, admin.listPatterns)
+	require.Len(t, indexes, 3, "indexes of other applications are not listed")
+	assert.Equal(t, "javazone_private", indexes[0].Name)
+	assert.Equal(t, "javazone_public_20240101120000", indexes[1].Name)
+
+	assert.Error(t, service.DeleteIndex(ctx, "javazone_public_20240101120000"))
+	assert.Error(t, service.DeleteIndex(ctx, "another_app"))
+	assert.Error(t, service.PointAlias(ctx, "javazone_public", "javazone_public_20240101120000"))
+	assert.Empty(t, admin.deleteIndexCalls)
+	assert.Empty(t, admin.pointAliasCalls)
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// speakerFindings returns the problems with the speakers of a talk: no speakers at all, speakers
+// without an ID and speaker IDs listed more than once. Frontends rely on every talk having speakers
+// with unique IDs.
+func speakerFindings(talk domain.Talk) []string {
+	if len(talk.Speakers) == 0 {
+		return []string{"no speakers"}
+	}
+
+	var findings []string
+	count := make(map[string]int)
+	for _, speaker := range talk.Speakers {
+		if speaker.ID == "" {
+			findings = append(findings, "speaker without ID ("+speaker.Name+")")
+			continue
+		}
+		count[speaker.ID]++
+		if count[speaker.ID] == 2 {
+			findings = append(findings, "duplicate speaker ID "+speaker.ID)
+		}
+	}
+	return findings
+}
+
+// checkIntegrity reports talks with malformed speakers. The talks are still indexed, but are logged
+// and flagged for review in the job report so they can be fixed in moresleep.
+func (s *IndexerService) checkIntegrity(ctx context.Context, talks []domain.Talk) {
+	report := jobReportFromContext(ctx)
+	for _, talk := range talks {
+		findings := speakerFindings(talk)
+		if len(findings) == 0 {
+			continue
+		}
+		s.logger.WarnContext(ctx, "talk has malformed speakers, flagged for review",
+			"talkID", talk.ID,
+			"findings", findings,
+		)
+		if report != nil {
+			report.flag(domain.ReviewFlag{TalkID: talk.ID, ConferenceSlug: talk.ConferenceSlug, Findings: findings})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeakerFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		speakers domain.Speakers
+		expected []string
+	}{
+		{
+			name:     "valid speakers",
+			speakers: domain.Speakers{{ID: "s1", Name: "Jane Doe"}, {ID: "s2", Name: "John Roe"}},
+		},
+		{
+			name:     "no speakers",
+			expected: []string{"no speakers"},
+		},
+		{
+			name:     "duplicate IDs are reported once",
+			speakers: domain.Speakers{{ID: "s1"}, {ID: "s1"}, {ID: "s1"}, {ID: "s2"}},
+			expected: []string{"duplicate speaker ID s1"},
+		},
+		{
+			name:     "missing ID",
+			speakers: domain.Speakers{{ID: "s1"}, {Name: "John Roe"}},
+			expected: []string{"speaker without ID (John Roe)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, speakerFindings(domain.Talk{ID: "talk-1", Speakers: tt.speakers}))
+		})
+	}
+}
+
+func TestReindexTalk_FlagsMalformedSpeakers(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{
+				ID:             "talk-1",
+				ConferenceSlug: "javazone2024",
+				Status:         "APPROVED",
+				Speakers:       domain.Speakers{{ID: "s1", Name: "Jane Doe"}, {ID: "s1", Name: "Jane Doe"}},
+				Data:           map[string]interface{}{"abstract": "Call +47 912 34 567"},
+			}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+	service.SetScrubber(testScrubber())
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	assert.Len(t, index.bulkIndexCalls, 2, "malformed talks are still indexed")
+	assert.Equal(t, []domain.ReviewFlag{
+		{TalkID: "talk-1", ConferenceSlug: "javazone2024", Findings: []string{"duplicate speaker ID s1", "phone in abstract"}},
+	}, jobs.jobs["job-1"].Report.Review, "findings for the same talk are merged")
+}
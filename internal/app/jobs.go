@@ -0,0 +1,348 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// runningReindexes holds the jobs of the full and conference reindexes that have not finished, by scope
+type runningReindexes struct {
+	mu   sync.Mutex
+	jobs map[domain.JobScope]domain.Job
+}
+
+// jobReportKey is the context key for the report of the running job
+type jobReportKey struct{}
+
+// queuedJobKey is the context key for a job created before its operation runs in the background
+type queuedJobKey struct{}
+
+// jobReport collects the outcome of the bulk requests made by a job
+type jobReport struct {
+	mu     sync.Mutex
+	report domain.JobReport
+}
+
+// add records the outcome of a bulk request to the given index
+func (r *jobReport) add(indexName string, result domain.BulkResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.report.Indexed == nil {
+		r.report.Indexed = make(map[string]int)
+	}
+	r.report.Indexed[indexName] += result.Indexed
+	r.report.Conflicts = append(r.report.Conflicts, result.Conflicts...)
+}
+
+// remove records the number of documents removed from the given index
+func (r *jobReport) remove(indexName string, count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.report.Removed == nil {
+		r.report.Removed = make(map[string]int)
+	}
+	r.report.Removed[indexName] += count
+}
+
+// flag records a talk for manual review, adding the findings to an earlier flag for the same talk
+func (r *jobReport) flag(review domain.ReviewFlag) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.report.Review {
+		if r.report.Review[i].TalkID == review.TalkID {
+			r.report.Review[i].Findings = append(r.report.Review[i].Findings, review.Findings...)
+			return
+		}
+	}
+	r.report.Review = append(r.report.Review, review)
+}
+
+// step records a checkpoint of a job made of several steps
+func (r *jobReport) step(step domain.JobStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.report.Steps = append(r.report.Steps, step)
+}
+
+// snapshot returns a copy of the collected report
+func (r *jobReport) snapshot() domain.JobReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := domain.JobReport{
+		Conflicts: append([]string(nil), r.report.Conflicts...),
+		Review:    append([]domain.ReviewFlag(nil), r.report.Review...),
+		Steps:     append([]domain.JobStep(nil), r.report.Steps...),
+	}
+	if r.report.Indexed != nil {
+		report.Indexed = make(map[string]int, len(r.report.Indexed))
+		for name, count := range r.report.Indexed {
+			report.Indexed[name] = count
+		}
+	}
+	if r.report.Removed != nil {
+		report.Removed = make(map[string]int, len(r.report.Removed))
+		for name, count := range r.report.Removed {
+			report.Removed[name] = count
+		}
+	}
+	return report
+}
+
+// jobReportFromContext returns the report of the running job, or nil outside a recorded job
+func jobReportFromContext(ctx context.Context) *jobReport {
+	report, _ := ctx.Value(jobReportKey{}).(*jobReport)
+	return report
+}
+
+// runJob runs the operation as a job, publishing its start, and raises a reindex.completed event when it finishes.
+// A full or conference reindex is refused while another of the same scope is running, see claimScope.
+func (s *IndexerService) runJob(ctx context.Context, scope domain.JobScope, run func(ctx context.Context) error) error {
+	if queued, _ := ctx.Value(queuedJobKey{}).(*domain.Job); queued == nil && exclusiveScope(scope) {
+		// Called directly rather than through StartReindex, which claims the scope itself
+		job, err := s.claimScope(ctx, scope, false)
+		if err != nil {
+			return err
+		}
+		defer s.finishRunning(scope)
+		if job.ID != "" {
+			ctx = context.WithValue(ctx, queuedJobKey{}, &job)
+		}
+	}
+
+	s.events.Publish(ctx, domain.ReindexProgress{Stage: domain.ReindexStageStarted, Scope: &scope})
+
+	job, runErr := recordJob(ctx, s.jobs, s.logger, scope, run)
+
+	s.events.Publish(context.WithoutCancel(ctx), domain.ReindexJobFinished{Job: job})
+
+	return runErr
+}
+
+// recordJob runs the operation, recording it as a job in the job store when one is configured,
+// and returns the finished job with the operation's error.
+// Failing to record the job is logged but never prevents the operation from running.
+func recordJob(ctx context.Context, jobs ports.JobStore, logger *slog.Logger, scope domain.JobScope, run func(ctx context.Context) error) (domain.Job, error) {
+	job := domain.NewJob(scope, time.Now())
+	job.Actor = domain.ActorFromContext(ctx)
+
+	// Job bookkeeping outlives a cancelled request so the final state is always recorded
+	storeCtx := context.WithoutCancel(ctx)
+
+	recorded := jobs != nil
+	if queued, ok := ctx.Value(queuedJobKey{}).(*domain.Job); ok && queued != nil && recorded {
+		// The job was created by StartReindex; nested jobs of the operation are recorded separately
+		job = *queued
+		ctx = context.WithValue(ctx, queuedJobKey{}, (*domain.Job)(nil))
+	} else if recorded {
+		created, err := jobs.CreateJob(storeCtx, job)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to record job", "kind", scope.Kind, "target", scope.Target, "error", err)
+			recorded = false
+		} else {
+			job = created
+		}
+	}
+
+	job.Start(time.Now())
+	report := &jobReport{}
+	stopProgress := func() {}
+	if recorded {
+		updateJob(storeCtx, jobs, logger, job)
+		stopProgress = trackJobProgress(storeCtx, jobs, logger, job, report)
+	}
+
+	runErr := run(context.WithValue(ctx, jobReportKey{}, report))
+	stopProgress()
+
+	job.Report = report.snapshot()
+	job.Finish(time.Now(), runErr)
+	if recorded {
+		updateJob(storeCtx, jobs, logger, job)
+		logger.InfoContext(ctx, "job finished", "jobID", job.ID, "kind", scope.Kind, "target", scope.Target,
+			"state", job.State, "duration", job.Duration(time.Now()))
+	}
+
+	return job, runErr
+}
+
+// jobProgressInterval is how often the report of a running job is stored, so its progress can be followed
+var jobProgressInterval = 5 * time.Second
+
+// trackJobProgress stores the report collected so far of the running job at every progress interval,
+// until the returned function is called
+func trackJobProgress(ctx context.Context, jobs ports.JobStore, logger *slog.Logger, job domain.Job, report *jobReport) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(jobProgressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				job.Report = report.snapshot()
+				updateJob(ctx, jobs, logger, job)
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// updateJob stores the job, logging failures
+func updateJob(ctx context.Context, jobs ports.JobStore, logger *slog.Logger, job domain.Job) {
+	if err := jobs.UpdateJob(ctx, job); err != nil {
+		logger.ErrorContext(ctx, "failed to update job", "jobID", job.ID, "state", job.State, "error", err)
+	}
+}
+
+// StartReindex creates a queued job for the reindex and runs it in the background, returning the job
+// right away so its progress can be followed by ID. Background reindexes need a job store. A full or
+// conference reindex started while another of the same scope is running is refused, see claimScope.
+func (s *IndexerService) StartReindex(ctx context.Context, scope domain.JobScope) (domain.Job, error) {
+	var run func(ctx context.Context) error
+	switch scope.Kind {
+	case domain.JobKindReindexAll:
+		run = s.ReindexAll
+	case domain.JobKindReindexConference:
+		run = func(ctx context.Context) error { return s.ReindexConference(ctx, scope.Target) }
+	case domain.JobKindReindexConferenceID:
+		run = func(ctx context.Context) error { return s.ReindexConferenceByID(ctx, scope.Target) }
+	case domain.JobKindReindexTalk:
+		run = func(ctx context.Context) error { return s.ReindexTalk(ctx, scope.Target) }
+	default:
+		return domain.Job{}, fmt.Errorf("%s is not a reindex job", scope.Kind)
+	}
+
+	if s.jobs == nil {
+		return domain.Job{}, fmt.Errorf("background reindexes need a job store")
+	}
+
+	job, err := s.claimScope(ctx, scope, true)
+	if err != nil {
+		return job, err
+	}
+
+	// The reindex outlives the request that started it
+	queued := job
+	runCtx := context.WithValue(context.WithoutCancel(ctx), queuedJobKey{}, &queued)
+
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		if exclusiveScope(scope) {
+			defer s.finishRunning(scope)
+		}
+		if err := run(runCtx); err != nil {
+			s.logger.ErrorContext(runCtx, "background reindex failed", "jobID", job.ID, "kind", scope.Kind, "target", scope.Target, "error", err)
+		}
+	}()
+
+	s.logger.InfoContext(ctx, "started background reindex", "jobID", job.ID, "kind", scope.Kind, "target", scope.Target)
+	return job, nil
+}
+
+// exclusiveScope reports whether only one reindex of the scope may run at a time. Full and conference
+// reindexes delete and rewrite documents the other would be writing, while talk reindexes are small.
+func exclusiveScope(scope domain.JobScope) bool {
+	return scope.Kind != domain.JobKindReindexTalk
+}
+
+// claimScope creates the job of a reindex in the job store, if there is one, and marks a full or
+// conference reindex as running until finishRunning is called. While another reindex of the same scope
+// is running, whether started through StartReindex or called directly, the reindex is refused with an
+// error wrapping domain.ErrJobRunning, along with the running job. Failing to create the job fails the
+// claim only when it is required; otherwise it is logged and the job is recorded later, if at all.
+func (s *IndexerService) claimScope(ctx context.Context, scope domain.JobScope, required bool) (domain.Job, error) {
+	exclusive := exclusiveScope(scope)
+	if exclusive {
+		s.running.mu.Lock()
+		defer s.running.mu.Unlock()
+		if running, ok := s.running.jobs[scope]; ok {
+			s.logger.WarnContext(ctx, "refused reindex already running", "jobID", running.ID, "kind", scope.Kind, "target", scope.Target)
+			return running, fmt.Errorf("%w: job %s", domain.ErrJobRunning, running.ID)
+		}
+	}
+
+	job := domain.NewJob(scope, time.Now())
+	job.Actor = domain.ActorFromContext(ctx)
+	if s.jobs != nil {
+		created, err := s.jobs.CreateJob(context.WithoutCancel(ctx), job)
+		switch {
+		case err == nil:
+			job = created
+		case required:
+			return domain.Job{}, fmt.Errorf("failed to record job: %w", err)
+		default:
+			s.logger.ErrorContext(ctx, "failed to record job", "kind", scope.Kind, "target", scope.Target, "error", err)
+		}
+	}
+	if exclusive {
+		s.running.jobs[scope] = job
+	}
+	return job, nil
+}
+
+// finishRunning lets reindexes of the scope start again
+func (s *IndexerService) finishRunning(scope domain.JobScope) {
+	s.running.mu.Lock()
+	defer s.running.mu.Unlock()
+	delete(s.running.jobs, scope)
+}
+
+// KeepRunningOf shares the running reindexes of the indexer this one replaces on a configuration
+// reload, so a reindex still running on the replaced indexer refuses new ones of its scope here
+func (s *IndexerService) KeepRunningOf(previous *IndexerService) {
+	s.running = previous.running
+}
+
+// GetJob returns the job with the given ID, or nil if there is no such job or no job store
+func (s *IndexerService) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	if s.jobs == nil {
+		return nil, nil
+	}
+	return s.jobs.GetJob(ctx, id)
+}
+
+// ListJobs returns up to limit jobs, most recently created first
+func (s *IndexerService) ListJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	if s.jobs == nil {
+		return nil, nil
+	}
+	return s.jobs.ListJobs(ctx, limit)
+}
+
+// WaitForBackgroundJobs waits until the reindexes started by StartReindex have finished, or until the
+// context is done
+func (s *IndexerService) WaitForBackgroundJobs(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background reindexes still running: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,312 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockJobStore is a mock implementation of ports.JobStore recording every stored state
+type mockJobStore struct {
+	mu        sync.Mutex
+	jobs      map[string]domain.Job
+	states    []domain.JobState
+	createErr error
+}
+
+func newMockJobStore() *mockJobStore {
+	return &mockJobStore{jobs: make(map[string]domain.Job)}
+}
+
+func (m *mockJobStore) CreateJob(ctx context.Context, job domain.Job) (domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.createErr != nil {
+		return domain.Job{}, m.createErr
+	}
+	job.ID = "job-1"
+	m.jobs[job.ID] = job
+	m.states = append(m.states, job.State)
+	return job, nil
+}
+
+func (m *mockJobStore) UpdateJob(ctx context.Context, job domain.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = job
+	m.states = append(m.states, job.State)
+	return nil
+}
+
+func (m *mockJobStore) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok {
+		return &job, nil
+	}
+	return nil, nil
+}
+
+func (m *mockJobStore) ListJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var jobs []domain.Job
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func TestReindexTalk_RecordsJob(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "APPROVED"}, nil
+		},
+	}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	assert.Equal(t, []domain.JobState{domain.JobStateQueued, domain.JobStateRunning, domain.JobStateSucceeded}, jobs.states)
+
+	job := jobs.jobs["job-1"]
+	assert.Equal(t, domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}, job.Scope)
+	assert.NotNil(t, job.StartedAt)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Empty(t, job.Error)
+	assert.Equal(t, map[string]int{"private": 1, "public": 1}, job.Report.Indexed)
+}
+
+func TestReindexConference_RecordsFailedJob(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return nil, errors.New("moresleep unavailable")
+		},
+	}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+
+	err := service.ReindexConference(context.Background(), "javazone2024")
+	require.Error(t, err)
+
+	job := jobs.jobs["job-1"]
+	assert.Equal(t, domain.JobStateFailed, job.State)
+	assert.Equal(t, domain.JobScope{Kind: domain.JobKindReindexConference, Target: "javazone2024"}, job.Scope)
+	assert.Equal(t, err.Error(), job.Error)
+}
+
+func TestReindexTalk_RecordsConflictsInJobReport(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, Status: "SUBMITTED"}, nil
+		},
+	}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{bulkConflicts: []string{"talk-1"}}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	report := jobs.jobs["job-1"].Report
+	assert.Equal(t, map[string]int{"private": 0}, report.Indexed)
+	assert.Equal(t, []string{"talk-1"}, report.Conflicts)
+}
+
+func TestRunJob_StoreFailureDoesNotBlockReindex(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, Status: "APPROVED"}, nil
+		},
+	}
+	index := &mockSearchIndex{}
+	jobs := newMockJobStore()
+	jobs.createErr = errors.New("jobs index unavailable")
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+	assert.Len(t, index.bulkIndexCalls, 2)
+	assert.Empty(t, jobs.jobs)
+}
+
+func TestRunJob_RecordsActor(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "SUBMITTED"}, nil
+		},
+	}
+	jobs := newMockJobStore()
+	notifier := &mockEventNotifier{}
+
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+	service.SetNotifier(notifier)
+
+	actor := domain.Actor{Kind: domain.ActorUser, Name: "admin@java.no"}
+	require.NoError(t, service.ReindexTalk(domain.WithActor(context.Background(), actor), "talk-1"))
+
+	assert.Equal(t, actor, jobs.jobs["job-1"].Actor)
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, actor, notifier.events[0].Data["actor"])
+}
+
+func TestRunJob_UnknownActor(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "SUBMITTED"}, nil
+		},
+	}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	assert.Equal(t, domain.UnknownActor, jobs.jobs["job-1"].Actor)
+}
+
+func TestStartReindex_RunsInBackground(t *testing.T) {
+	release := make(chan struct{})
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			<-release
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "APPROVED"}, nil
+		},
+	}
+	jobs := newMockJobStore()
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+
+	ctx, cancel := context.WithCancel(domain.WithActor(context.Background(), domain.Actor{Kind: domain.ActorAPIKey, Name: "ci"}))
+	job, err := service.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", job.ID)
+	assert.Equal(t, domain.JobStateQueued, job.State)
+
+	// The reindex outlives the request that started it
+	cancel()
+	close(release)
+	require.NoError(t, service.WaitForBackgroundJobs(context.Background()))
+
+	stored, err := service.GetJob(context.Background(), "job-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, domain.JobStateSucceeded, stored.State)
+	assert.Equal(t, "ci", stored.Actor.Name)
+	assert.Equal(t, map[string]int{"private": 1, "public": 1}, stored.Report.Indexed)
+	assert.Equal(t, []domain.JobState{domain.JobStateQueued, domain.JobStateRunning, domain.JobStateSucceeded}, jobs.states,
+		"the queued job is run rather than a second one created")
+}
+
+func TestStartReindex_RefusesSameScopeWhileRunning(t *testing.T) {
+	release := make(chan struct{})
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			<-release
+			return nil, nil
+		},
+	}
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(newMockJobStore())
+	ctx := context.Background()
+
+	first, err := service.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexAll})
+	require.NoError(t, err)
+
+	running, err := service.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexAll})
+	require.ErrorIs(t, err, domain.ErrJobRunning)
+	assert.Equal(t, first.ID, running.ID)
+
+	_, err = service.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexConference, Target: "javazone2024"})
+	require.NoError(t, err, "other scopes still start")
+
+	close(release)
+	require.NoError(t, service.WaitForBackgroundJobs(ctx))
+
+	_, err = service.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexAll})
+	require.NoError(t, err, "starts again once the running reindex finished")
+	require.NoError(t, service.WaitForBackgroundJobs(ctx))
+}
+
+func TestReindexAll_RefusedWhileSameScopeRuns(t *testing.T) {
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			started <- struct{}{}
+			<-release
+			return nil, nil
+		},
+	}
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(newMockJobStore())
+	ctx := context.Background()
+
+	// A direct call is refused while a background reindex of the same scope runs
+	background, err := service.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexAll})
+	require.NoError(t, err)
+	<-started
+	err = service.ReindexAll(ctx)
+	require.ErrorIs(t, err, domain.ErrJobRunning)
+	assert.ErrorContains(t, err, background.ID)
+
+	release <- struct{}{}
+	require.NoError(t, service.WaitForBackgroundJobs(ctx))
+
+	// And a background reindex is refused while a direct call runs
+	done := make(chan error)
+	go func() { done <- service.ReindexAll(ctx) }()
+	<-started
+	_, err = service.StartReindex(ctx, domain.JobScope{Kind: domain.JobKindReindexAll})
+	require.ErrorIs(t, err, domain.ErrJobRunning)
+
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, service.ReindexAll(ctx), "runs again once the running reindex finished")
+}
+
+func TestStartReindex_Errors(t *testing.T) {
+	service := NewIndexerServiceWithConfig(&mockTalkSource{}, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+
+	_, err := service.StartReindex(context.Background(), domain.JobScope{Kind: domain.JobKindReindexAll})
+	assert.ErrorContains(t, err, "need a job store")
+
+	service.SetJobStore(newMockJobStore())
+	_, err = service.StartReindex(context.Background(), domain.JobScope{Kind: domain.JobKindRetention})
+	assert.ErrorContains(t, err, "retention is not a reindex job")
+}
+
+func TestRecordJob_StoresProgress(t *testing.T) {
+	defer func(interval time.Duration) { jobProgressInterval = interval }(jobProgressInterval)
+	jobProgressInterval = time.Millisecond
+
+	jobs := newMockJobStore()
+	_, err := recordJob(context.Background(), jobs, slog.Default(), domain.JobScope{Kind: domain.JobKindReindexAll}, func(ctx context.Context) error {
+		jobReportFromContext(ctx).add("private", domain.BulkResult{Indexed: 3})
+
+		assert.Eventually(t, func() bool {
+			job, _ := jobs.GetJob(ctx, "job-1")
+			return job.State == domain.JobStateRunning && job.Report.Indexed["private"] == 3
+		}, time.Second, time.Millisecond, "the report of the running job is stored")
+		return nil
+	})
+	require.NoError(t, err)
+
+	job, _ := jobs.GetJob(context.Background(), "job-1")
+	assert.Equal(t, domain.JobStateSucceeded, job.State)
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// maxTrendKeywords bounds the keywords of one analysis, which stays readable as a chart
+const maxTrendKeywords = 20
+
+// KeywordTrendService analyses how the share of public talks carrying a keyword evolves across
+// conference years, answering questions such as how Kotlin talks compare to Java talks over time
+type KeywordTrendService struct {
+	reader      ports.TalkReader
+	publicIndex string
+	synonyms    map[string]string
+	top         int
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+// NewKeywordTrendService creates a new KeywordTrendService, receiving context as first parameter
+// to retrieve configuration.
+func NewKeywordTrendService(ctx context.Context, reader ports.TalkReader) *KeywordTrendService {
+	cfg := config.GetConfig(ctx)
+	return NewKeywordTrendServiceWithConfig(reader, cfg.Index.PublicName(), cfg.Keywords)
+}
+
+// NewKeywordTrendServiceWithConfig creates a new KeywordTrendService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewKeywordTrendServiceWithConfig(reader ports.TalkReader, publicIndex string, cfg config.KeywordsConfig) *KeywordTrendService {
+	logger := slog.Default().With("component", "keywords")
+
+	return &KeywordTrendService{
+		reader:      reader,
+		publicIndex: publicIndex,
+		synonyms:    keywordSynonyms(cfg.Synonyms, logger),
+		top:         cfg.Top,
+		now:         time.Now,
+		logger:      logger,
+	}
+}
+
+// KeywordTrends reads the public talks and counts, per conference year, the talks carrying each of the
+// requested keywords, or of the most frequent keywords if none are requested. Talks without a
+// recognizable year are left out.
+func (s *KeywordTrendService) KeywordTrends(ctx context.Context, req domain.KeywordTrendRequest) (*domain.KeywordTrendReport, error) {
+	var keywords []string
+	for _, keyword := range req.Keywords {
+		if keyword = s.normalize(keyword); keyword != "" && !slices.Contains(keywords, keyword) {
+			keywords = append(keywords, keyword)
+		}
+	}
+	top := req.Top
+	if top == 0 {
+		top = s.top
+	}
+	if len(keywords) > maxTrendKeywords || top < 1 || top > maxTrendKeywords {
+		return nil, fmt.Errorf("%w: compare between 1 and %d keywords", domain.ErrInvalidQuery, maxTrendKeywords)
+	}
+
+	talks, err := s.reader.FetchTalks(ctx, s.publicIndex, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from public index: %w", err)
+	}
+
+	byYear := make(map[int]*domain.KeywordYear)
+	totals := make(map[string]int)
+	for _, talk := range talks {
+		year := conferenceYear(talk)
+		if year == 0 {
+			continue
+		}
+		stats, ok := byYear[year]
+		if !ok {
+			stats = &domain.KeywordYear{Year: year, Counts: make(map[string]int)}
+			byYear[year] = stats
+		}
+		stats.Talks++
+
+		// A talk listing a keyword twice, for instance as two spellings, counts once
+		var seen []string
+		for _, keyword := range stringSlice(talk.Data["keywords"]) {
+			if keyword = s.normalize(keyword); keyword != "" && !slices.Contains(seen, keyword) {
+				seen = append(seen, keyword)
+				stats.Counts[keyword]++
+				totals[keyword]++
+			}
+		}
+	}
+
+	if len(keywords) == 0 {
+		keywords = mostFrequent(totals, top)
+	}
+
+	report := &domain.KeywordTrendReport{
+		GeneratedAt: s.now().UTC(),
+		Index:       s.publicIndex,
+		Keywords:    keywords,
+		Years:       make([]domain.KeywordYear, 0, len(byYear)),
+	}
+	for _, stats := range byYear {
+		// Only the compared keywords are reported, but every year is, so shares of zero show
+		counts := make(map[string]int, len(keywords))
+		for _, keyword := range keywords {
+			counts[keyword] = stats.Counts[keyword]
+		}
+		stats.Counts = counts
+		report.Years = append(report.Years, *stats)
+	}
+	sort.Slice(report.Years, func(i, j int) bool { return report.Years[i].Year < report.Years[j].Year })
+
+	s.logger.InfoContext(ctx, "built keyword trends", "talks", len(talks), "years", len(report.Years), "keywords", len(keywords))
+	return report, nil
+}
+
+// normalize normalizes the keyword and maps it to the keyword it is a synonym of, if any
+func (s *KeywordTrendService) normalize(keyword string) string {
+	keyword = normalizeKeyword(keyword)
+	if synonym, ok := s.synonyms[keyword]; ok {
+		return synonym
+	}
+	return keyword
+}
+
+// keywordSynonyms parses the from=to synonym pairs into a map of normalized keywords, logging and
+// skipping invalid pairs
+func keywordSynonyms(pairs []string, logger *slog.Logger) map[string]string {
+	synonyms := make(map[string]string, len(pairs))
+	for _, synonym := range pairs {
+		from, to, ok := strings.Cut(synonym, "=")
+		from, to = normalizeKeyword(from), normalizeKeyword(to)
+		if !ok || from == "" || to == "" {
+			logger.Warn("ignoring invalid keyword synonym", "synonym", synonym)
+			continue
+		}
+		synonyms[from] = to
+	}
+	return synonyms
+}
+
+// normalizeKeyword lowercases a keyword and collapses its whitespace, so "Kotlin " and "kotlin" are the same keyword
+func normalizeKeyword(keyword string) string {
+	return strings.ToLower(strings.Join(strings.Fields(keyword), " "))
+}
+
+// mostFrequent returns the n keywords with the highest counts, ordered by count and then alphabetically
+func mostFrequent(counts map[string]int, n int) []string {
+	keywords := make([]string, 0, len(counts))
+	for keyword := range counts {
+		keywords = append(keywords, keyword)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if counts[keywords[i]] != counts[keywords[j]] {
+			return counts[keywords[i]] > counts[keywords[j]]
+		}
+		return keywords[i] < keywords[j]
+	})
+	if len(keywords) > n {
+		keywords = keywords[:n]
+	}
+	return keywords
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keywordTalk(slug string, keywords ...interface{}) domain.Talk {
+	return domain.Talk{ConferenceSlug: slug, Data: map[string]interface{}{"keywords": keywords}}
+}
+
+func newTestKeywordTrendService(talks []domain.Talk) (*KeywordTrendService, *mockTalkReader) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return talks, nil
+		},
+	}
+	service := NewKeywordTrendServiceWithConfig(reader, "public", config.KeywordsConfig{
+		Synonyms: []string{"K8s=Kubernetes", "invalid"},
+		Top:      2,
+	})
+	return service, reader
+}
+
+func TestKeywordTrendService_KeywordTrends(t *testing.T) {
+	service, reader := newTestKeywordTrendService([]domain.Talk{
+		keywordTalk("javazone2023", "Java", "Kotlin"),
+		keywordTalk("javazone2023", "java "),
+		keywordTalk("javazone2024", "kotlin", "k8s", "Kubernetes"),
+		keywordTalk("javazone2024", "Kotlin"),
+		keywordTalk("javazone2024"),
+		keywordTalk("meetup", "java"),
+	})
+
+	report, err := service.KeywordTrends(context.Background(), domain.KeywordTrendRequest{Keywords: []string{"Kotlin", " JAVA", "kotlin"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"public"}, reader.fetchCalls)
+	assert.Equal(t, "public", report.Index)
+	assert.Equal(t, []string{"kotlin", "java"}, report.Keywords)
+	assert.Equal(t, []domain.KeywordYear{
+		{Year: 2023, Talks: 2, Counts: map[string]int{"kotlin": 1, "java": 2}},
+		{Year: 2024, Talks: 3, Counts: map[string]int{"kotlin": 2, "java": 0}},
+	}, report.Years, "talks without a year are left out")
+	assert.InDelta(t, 2.0/3, report.Years[1].Share("kotlin"), 0.001)
+}
+
+func TestKeywordTrendService_MostFrequentKeywords(t *testing.T) {
+	service, _ := newTestKeywordTrendService([]domain.Talk{
+		keywordTalk("javazone2023", "Kubernetes", "Java"),
+		keywordTalk("javazone2024", "k8s", "K8S", "Kotlin"),
+		keywordTalk("javazone2024", "kotlin", "Java"),
+	})
+
+	report, err := service.KeywordTrends(context.Background(), domain.KeywordTrendRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"java", "kotlin"}, report.Keywords, "ties are ordered alphabetically")
+	assert.Equal(t, map[string]int{"java": 1, "kotlin": 2}, report.Years[1].Counts)
+
+	report, err = service.KeywordTrends(context.Background(), domain.KeywordTrendRequest{Top: 1, Keywords: []string{"K8S"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kubernetes"}, report.Keywords)
+	assert.Equal(t, 1, report.Years[0].Counts["kubernetes"])
+	assert.Equal(t, 1, report.Years[1].Counts["kubernetes"], "synonyms on one talk count once")
+}
+
+func TestKeywordTrendService_Errors(t *testing.T) {
+	service, _ := newTestKeywordTrendService(nil)
+
+	_, err := service.KeywordTrends(context.Background(), domain.KeywordTrendRequest{Top: 100})
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+
+	service.reader = &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	_, err = service.KeywordTrends(context.Background(), domain.KeywordTrendRequest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch talks from public index")
+}
@@ -0,0 +1,288 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// linkReportKey is the settings key holding the report of the last link check
+const linkReportKey = "links:report"
+
+// abstractURLPattern matches http(s) URLs in free text, stopping at whitespace, quotes, brackets and parentheses
+var abstractURLPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+
+// linkOccurrence is a link found in a talk
+type linkOccurrence struct {
+	talk    int
+	field   domain.LinkField
+	speaker int
+	url     string
+}
+
+// LinkCheckService validates the URLs stored in the public index: video links, speaker pictures
+// and links in abstracts. Each distinct URL is checked once per run. Problems are stored as the
+// latest report, and broken video and picture links can optionally be cleared from the public documents.
+type LinkCheckService struct {
+	reader      ports.TalkReader
+	patcher     ports.TalkPatcher
+	checker     ports.LinkChecker
+	store       ports.SettingsStore
+	jobs        ports.JobStore
+	publicIndex string
+	clearBroken bool
+	concurrency int
+	now         func() time.Time
+	logger      *slog.Logger
+
+	running atomic.Bool
+}
+
+// NewLinkCheckService creates a new LinkCheckService, receiving context as first parameter
+// to retrieve configuration.
+func NewLinkCheckService(
+	ctx context.Context,
+	reader ports.TalkReader,
+	patcher ports.TalkPatcher,
+	checker ports.LinkChecker,
+	store ports.SettingsStore,
+) *LinkCheckService {
+	cfg := config.GetConfig(ctx)
+	return NewLinkCheckServiceWithConfig(reader, patcher, checker, store, cfg.Index.PublicName(), cfg.LinkCheck.ClearBroken, cfg.LinkCheck.Concurrency)
+}
+
+// NewLinkCheckServiceWithConfig creates a new LinkCheckService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewLinkCheckServiceWithConfig(
+	reader ports.TalkReader,
+	patcher ports.TalkPatcher,
+	checker ports.LinkChecker,
+	store ports.SettingsStore,
+	publicIndex string,
+	clearBroken bool,
+	concurrency int,
+) *LinkCheckService {
+	return &LinkCheckService{
+		reader:      reader,
+		patcher:     patcher,
+		checker:     checker,
+		store:       store,
+		publicIndex: publicIndex,
+		clearBroken: clearBroken,
+		concurrency: max(concurrency, 1),
+		now:         time.Now,
+		logger:      slog.Default().With("component", "links"),
+	}
+}
+
+// SetJobStore enables recording every link check as a job
+func (s *LinkCheckService) SetJobStore(jobs ports.JobStore) {
+	s.jobs = jobs
+}
+
+// CheckLinks validates the links in the public index and stores the report.
+// Only one check runs at a time; the run is recorded as a link check job.
+func (s *LinkCheckService) CheckLinks(ctx context.Context) error {
+	if !s.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("a link check is already running")
+	}
+	defer s.running.Store(false)
+
+	_, err := recordJob(ctx, s.jobs, s.logger, domain.JobScope{Kind: domain.JobKindLinkCheck}, s.checkLinks)
+	return err
+}
+
+// LatestReport returns the report of the last link check, or nil if no check has run
+func (s *LinkCheckService) LatestReport(ctx context.Context) (*domain.LinkReport, error) {
+	var report domain.LinkReport
+	found, err := s.store.LoadSetting(ctx, linkReportKey, &report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load link report: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return &report, nil
+}
+
+// checkLinks does the work of CheckLinks
+func (s *LinkCheckService) checkLinks(ctx context.Context) error {
+	talks, err := s.reader.FetchTalks(ctx, s.publicIndex, "")
+	if err != nil {
+		return fmt.Errorf("failed to fetch talks from public index: %w", err)
+	}
+
+	occurrences := collectLinks(talks)
+	urls := make(map[string]bool)
+	for _, occurrence := range occurrences {
+		urls[occurrence.url] = true
+	}
+	results := s.checkURLs(ctx, slices.Sorted(maps.Keys(urls)))
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("link check cancelled: %w", err)
+	}
+
+	report := domain.LinkReport{
+		CheckedAt: s.now().UTC(),
+		Talks:     len(talks),
+		Links:     len(urls),
+		Problems:  make([]domain.LinkProblem, 0),
+	}
+	broken := make(map[int][]linkOccurrence)
+	for _, occurrence := range occurrences {
+		check := results[occurrence.url]
+		if check.Status == domain.LinkOK {
+			continue
+		}
+
+		talk := talks[occurrence.talk]
+		problem := domain.LinkProblem{
+			TalkID:         talk.ID,
+			ConferenceSlug: talk.ConferenceSlug,
+			Title:          stringValue(talk.Data["title"]),
+			Field:          occurrence.field,
+			URL:            occurrence.url,
+			LinkCheck:      check,
+		}
+		if occurrence.field == domain.LinkFieldSpeakerPicture {
+			problem.SpeakerName = talk.Speakers[occurrence.speaker].Name
+		}
+		report.Problems = append(report.Problems, problem)
+
+		if s.clearBroken && check.Status == domain.LinkBroken && occurrence.field != domain.LinkFieldAbstract {
+			broken[occurrence.talk] = append(broken[occurrence.talk], occurrence)
+		}
+	}
+
+	cleared := 0
+	for i, links := range broken {
+		talk := talks[i]
+		if err := s.patcher.PatchTalk(ctx, s.publicIndex, talk.ID, clearLinksPatch(talk, links)); err != nil {
+			s.logger.ErrorContext(ctx, "failed to clear broken links", "talkID", talk.ID, "error", err)
+			continue
+		}
+		cleared++
+		for j := range report.Problems {
+			problem := &report.Problems[j]
+			if problem.TalkID == talk.ID && problem.Status == domain.LinkBroken && problem.Field != domain.LinkFieldAbstract {
+				problem.Cleared = true
+			}
+		}
+	}
+
+	slices.SortFunc(report.Problems, func(a, b domain.LinkProblem) int {
+		return cmp.Or(
+			strings.Compare(a.ConferenceSlug, b.ConferenceSlug),
+			strings.Compare(a.Title, b.Title),
+			strings.Compare(string(a.Field), string(b.Field)),
+			strings.Compare(a.URL, b.URL),
+		)
+	})
+
+	if err := s.store.SaveSetting(ctx, linkReportKey, report); err != nil {
+		return fmt.Errorf("failed to save link report: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "checked links", "talks", len(talks), "links", len(urls),
+		"problems", len(report.Problems), "clearedTalks", cleared)
+	return nil
+}
+
+// checkURLs checks the URLs with a bounded number of concurrent requests
+func (s *LinkCheckService) checkURLs(ctx context.Context, urls []string) map[string]domain.LinkCheck {
+	results := make(map[string]domain.LinkCheck, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	queue := make(chan string)
+	for range s.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range queue {
+				check := s.checker.CheckLink(ctx, url)
+				mu.Lock()
+				results[url] = check
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, url := range urls {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- url
+	}
+	close(queue)
+	wg.Wait()
+
+	return results
+}
+
+// collectLinks returns the video, speaker picture and abstract links of the talks
+func collectLinks(talks []domain.Talk) []linkOccurrence {
+	var occurrences []linkOccurrence
+	for i, talk := range talks {
+		if video := strings.TrimSpace(stringValue(talk.Data["video"])); video != "" {
+			occurrences = append(occurrences, linkOccurrence{talk: i, field: domain.LinkFieldVideo, url: video})
+		}
+		for j, speaker := range talk.Speakers {
+			if picture := strings.TrimSpace(stringValue(speaker.Data["pictureUrl"])); picture != "" {
+				occurrences = append(occurrences, linkOccurrence{talk: i, field: domain.LinkFieldSpeakerPicture, speaker: j, url: picture})
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, url := range abstractURLPattern.FindAllString(stringValue(talk.Data["abstract"]), -1) {
+			url = strings.TrimRight(url, ".,;:!?")
+			if !seen[url] {
+				seen[url] = true
+				occurrences = append(occurrences, linkOccurrence{talk: i, field: domain.LinkFieldAbstract, url: url})
+			}
+		}
+	}
+	return occurrences
+}
+
+// clearLinksPatch returns the partial document removing the broken video and speaker picture links.
+// The speakers array is sent in full since partial updates replace arrays.
+func clearLinksPatch(talk domain.Talk, links []linkOccurrence) map[string]interface{} {
+	doc := make(map[string]interface{})
+	var speakers domain.Speakers
+	for _, link := range links {
+		switch link.field {
+		case domain.LinkFieldVideo:
+			doc["data"] = map[string]interface{}{"video": nil}
+		case domain.LinkFieldSpeakerPicture:
+			if speakers == nil {
+				speakers = slices.Clone(talk.Speakers)
+			}
+			data := maps.Clone(speakers[link.speaker].Data)
+			delete(data, "pictureUrl")
+			speakers[link.speaker].Data = data
+		}
+	}
+	if speakers != nil {
+		doc["speakers"] = speakers
+	}
+	return doc
+}
+
+// stringValue returns the value if it is a string, or an empty string
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
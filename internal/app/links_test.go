@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockLinkChecker is a mock implementation of ports.LinkChecker
+type mockLinkChecker struct {
+	mu      sync.Mutex
+	results map[string]domain.LinkCheck
+	checked []string
+}
+
+func (m *mockLinkChecker) CheckLink(ctx context.Context, url string) domain.LinkCheck {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checked = append(m.checked, url)
+	if check, ok := m.results[url]; ok {
+		return check
+	}
+	return domain.LinkCheck{Status: domain.LinkOK, StatusCode: 200}
+}
+
+func newTestLinkTalks() []domain.Talk {
+	return []domain.Talk{
+		{
+			ID: "talk-1", ConferenceSlug: "javazone2024",
+			Data: map[string]interface{}{
+				"title":    "Virtual threads",
+				"video":    "https://vimeo.com/gone",
+				"abstract": "See https://example.com/slides. Code at [GitHub](https://github.com/gone/repo).",
+			},
+			Speakers: domain.Speakers{
+				{ID: "s1", Name: "Jane Doe", Data: map[string]interface{}{"pictureUrl": "https://cdn.example.com/jane.png", "bio": "Bio"}},
+				{ID: "s2", Name: "John Roe", Data: map[string]interface{}{"pictureUrl": "https://cdn.example.com/john.png"}},
+			},
+		},
+		{
+			ID: "talk-2", ConferenceSlug: "javazone2024",
+			Data: map[string]interface{}{"title": "Kotlin", "video": "https://vimeo.com/ok"},
+			Speakers: domain.Speakers{
+				{ID: "s1", Name: "Jane Doe", Data: map[string]interface{}{"pictureUrl": "https://cdn.example.com/jane.png"}},
+			},
+		},
+	}
+}
+
+func newTestLinkCheckService(checker *mockLinkChecker, patcher *mockTalkPatcher, store *mockSettingsStore, clearBroken bool) *LinkCheckService {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return newTestLinkTalks(), nil
+		},
+	}
+	return NewLinkCheckServiceWithConfig(reader, patcher, checker, store, "javazone_public", clearBroken, 2)
+}
+
+func newTestLinkChecker() *mockLinkChecker {
+	return &mockLinkChecker{results: map[string]domain.LinkCheck{
+		"https://vimeo.com/gone":           {Status: domain.LinkBroken, StatusCode: 404},
+		"https://cdn.example.com/john.png": {Status: domain.LinkBroken, StatusCode: 410},
+		"https://github.com/gone/repo":     {Status: domain.LinkBroken, StatusCode: 404},
+		"https://example.com/slides":       {Status: domain.LinkUnreachable, StatusCode: 503},
+	}}
+}
+
+func TestLinkCheck_ReportsProblems(t *testing.T) {
+	checker := newTestLinkChecker()
+	patcher := &mockTalkPatcher{}
+	store := newMockSettingsStore()
+	service := newTestLinkCheckService(checker, patcher, store, false)
+
+	report, err := service.LatestReport(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, report, "no report before the first check")
+
+	require.NoError(t, service.CheckLinks(context.Background()))
+
+	assert.Len(t, checker.checked, 6, "each distinct URL is checked once")
+	assert.Empty(t, patcher.docs, "links are only cleared when enabled")
+
+	report, err = service.LatestReport(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Equal(t, 2, report.Talks)
+	assert.Equal(t, 6, report.Links)
+	require.Len(t, report.Problems, 4)
+
+	assert.Equal(t, domain.LinkFieldAbstract, report.Problems[0].Field)
+	assert.Equal(t, "https://example.com/slides", report.Problems[0].URL, "trailing punctuation is not part of the URL")
+	assert.Equal(t, domain.LinkUnreachable, report.Problems[0].Status)
+	assert.Equal(t, "https://github.com/gone/repo", report.Problems[1].URL, "markdown link target")
+	assert.Equal(t, domain.LinkFieldSpeakerPicture, report.Problems[2].Field)
+	assert.Equal(t, "John Roe", report.Problems[2].SpeakerName)
+	assert.Equal(t, domain.LinkFieldVideo, report.Problems[3].Field)
+	for _, problem := range report.Problems {
+		assert.False(t, problem.Cleared)
+	}
+}
+
+func TestLinkCheck_ClearsBrokenLinks(t *testing.T) {
+	patcher := &mockTalkPatcher{}
+	service := newTestLinkCheckService(newTestLinkChecker(), patcher, newMockSettingsStore(), true)
+	jobs := newMockJobStore()
+	service.SetJobStore(jobs)
+
+	require.NoError(t, service.CheckLinks(context.Background()))
+
+	require.Len(t, patcher.docs, 1, "only talk-1 has broken video or picture links")
+	doc := patcher.docs[0]
+	assert.Equal(t, map[string]interface{}{"video": nil}, doc["data"])
+	speakers := doc["speakers"].(domain.Speakers)
+	require.Len(t, speakers, 2)
+	assert.Equal(t, "https://cdn.example.com/jane.png", speakers[0].Data["pictureUrl"])
+	assert.NotContains(t, speakers[1].Data, "pictureUrl")
+
+	report, err := service.LatestReport(context.Background())
+	require.NoError(t, err)
+	for _, problem := range report.Problems {
+		expected := problem.Status == domain.LinkBroken && problem.Field != domain.LinkFieldAbstract
+		assert.Equal(t, expected, problem.Cleared, problem.URL)
+	}
+
+	assert.Equal(t, domain.JobKindLinkCheck, jobs.jobs["job-1"].Scope.Kind)
+	assert.Equal(t, domain.JobStateSucceeded, jobs.jobs["job-1"].State)
+}
+
+func TestLinkCheck_RejectsConcurrentRuns(t *testing.T) {
+	service := newTestLinkCheckService(newTestLinkChecker(), &mockTalkPatcher{}, newMockSettingsStore(), false)
+	service.running.Store(true)
+
+	err := service.CheckLinks(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already running")
+}
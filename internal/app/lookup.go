@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// maxTalkMatches bounds the candidates of a talk lookup; a lookup matching more is not specific enough
+const maxTalkMatches = 10
+
+// TalkLookupService resolves talk slugs and titles to talk IDs using the private index, so single talks
+// can be reindexed without looking up their IDs in moresleep
+type TalkLookupService struct {
+	runner    ports.QueryRunner
+	indexName string
+	logger    *slog.Logger
+}
+
+// NewTalkLookupService creates a new TalkLookupService, receiving context as first parameter
+// to retrieve configuration.
+func NewTalkLookupService(ctx context.Context, runner ports.QueryRunner) *TalkLookupService {
+	cfg := config.GetConfig(ctx)
+	return NewTalkLookupServiceWithConfig(runner, cfg.Index.PrivateName())
+}
+
+// NewTalkLookupServiceWithConfig creates a new TalkLookupService with an explicit index name.
+// This constructor is primarily intended for testing purposes.
+func NewTalkLookupServiceWithConfig(runner ports.QueryRunner, indexName string) *TalkLookupService {
+	return &TalkLookupService{
+		runner:    runner,
+		indexName: indexName,
+		logger:    slog.Default().With("component", "lookup"),
+	}
+}
+
+// LookupTalk returns the talks matching the slug exactly or all words of the title, best match first
+func (s *TalkLookupService) LookupTalk(ctx context.Context, req domain.TalkLookupRequest) ([]domain.TalkMatch, error) {
+	title := strings.TrimSpace(req.Title)
+	slug := strings.TrimSpace(req.Slug)
+	if title == "" && slug == "" {
+		return nil, invalidQuery("title or slug is required")
+	}
+
+	filters := []interface{}{}
+	if req.ConferenceSlug != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": req.ConferenceSlug}})
+	}
+	if slug != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"data.slug": slug}})
+	}
+	query := map[string]interface{}{"filter": filters}
+	if title != "" {
+		query["must"] = []interface{}{
+			map[string]interface{}{"match": map[string]interface{}{"data.title": map[string]interface{}{"query": title, "operator": "and"}}},
+		}
+	}
+
+	body := map[string]interface{}{
+		"size":    maxTalkMatches,
+		"query":   map[string]interface{}{"bool": query},
+		"_source": []string{"id", "conferenceSlug", "status", "data.title", "data.slug"},
+	}
+
+	result, err := s.runner.RunQuery(ctx, s.indexName, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up talk: %w", err)
+	}
+
+	matches := make([]domain.TalkMatch, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		var talk domain.Talk
+		if err := json.Unmarshal(hit, &talk); err != nil {
+			return nil, fmt.Errorf("failed to decode talk: %w", err)
+		}
+		match := domain.TalkMatch{ID: talk.ID, ConferenceSlug: talk.ConferenceSlug, Status: talk.Status}
+		match.Title, _ = talk.Data["title"].(string)
+		match.Slug, _ = talk.Data["slug"].(string)
+		matches = append(matches, match)
+	}
+
+	s.logger.InfoContext(ctx, "looked up talk",
+		"conferenceSlug", req.ConferenceSlug,
+		"title", title,
+		"slug", slug,
+		"matches", len(matches),
+		"total", result.Total,
+	)
+	return matches, nil
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTalkLookupService_LookupTalk(t *testing.T) {
+	t.Run("title within a conference", func(t *testing.T) {
+		runner := &mockQueryRunner{result: domain.QueryResult{Total: 1, Hits: []json.RawMessage{
+			json.RawMessage(`{"id":"talk-1","conferenceSlug":"javazone2024","status":"APPROVED","data":{"title":"Kotlin in production","slug":"kotlin-in-production"}}`),
+		}}}
+		service := NewTalkLookupServiceWithConfig(runner, "javazone_private")
+
+		matches, err := service.LookupTalk(context.Background(), domain.TalkLookupRequest{ConferenceSlug: "javazone2024", Title: "kotlin production"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TalkMatch{
+			{ID: "talk-1", ConferenceSlug: "javazone2024", Status: "APPROVED", Title: "Kotlin in production", Slug: "kotlin-in-production"},
+		}, matches)
+		assert.Equal(t, "javazone_private", runner.indexName)
+
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": "javazone2024"}},
+		}, query["filter"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"match": map[string]interface{}{"data.title": map[string]interface{}{"query": "kotlin production", "operator": "and"}}},
+		}, query["must"])
+	})
+
+	t.Run("slug", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewTalkLookupServiceWithConfig(runner, "javazone_private")
+
+		matches, err := service.LookupTalk(context.Background(), domain.TalkLookupRequest{Slug: "kotlin-in-production"})
+
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"data.slug": "kotlin-in-production"}},
+		}, query["filter"])
+		assert.NotContains(t, query, "must")
+	})
+
+	t.Run("neither title nor slug", func(t *testing.T) {
+		service := NewTalkLookupServiceWithConfig(&mockQueryRunner{}, "javazone_private")
+
+		_, err := service.LookupTalk(context.Background(), domain.TalkLookupRequest{ConferenceSlug: "javazone2024", Title: " "})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	})
+}
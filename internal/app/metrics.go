@@ -0,0 +1,216 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// RequestMetricsService records latency and errors of HTTP requests per route and computes the
+// error budget burn rate of the routes with a service level objective. A request meets the
+// objective if it does not fail with a 5xx status and completes within the objective's latency.
+// SLIs are kept in per-minute buckets covering the longest burn rate window.
+type RequestMetricsService struct {
+	targets []config.SLOTarget
+	windows []time.Duration
+	bounds  []time.Duration
+	minutes int
+	now     func() time.Time
+
+	mu     sync.Mutex
+	routes map[string]*routeStats
+}
+
+// routeStats holds the recorded requests of one route
+type routeStats struct {
+	requests   map[string]uint64
+	buckets    []uint64
+	latencySum time.Duration
+	count      uint64
+	sli        []sliMinute
+}
+
+// sliMinute counts the requests of one minute and those missing the objective
+type sliMinute struct {
+	minute int64
+	total  uint64
+	bad    uint64
+}
+
+// NewRequestMetricsService creates a new RequestMetricsService, receiving context as first parameter
+// to retrieve configuration.
+func NewRequestMetricsService(ctx context.Context) *RequestMetricsService {
+	cfg := config.GetConfig(ctx)
+	return NewRequestMetricsServiceWithConfig(cfg.Metrics)
+}
+
+// NewRequestMetricsServiceWithConfig creates a new RequestMetricsService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewRequestMetricsServiceWithConfig(cfg config.MetricsConfig) *RequestMetricsService {
+	bounds := slices.Clone(cfg.LatencyBuckets)
+	slices.Sort(bounds)
+
+	var longest time.Duration
+	for _, window := range cfg.BurnRateWindows {
+		longest = max(longest, window)
+	}
+
+	return &RequestMetricsService{
+		targets: cfg.SLOTargets,
+		windows: cfg.BurnRateWindows,
+		bounds:  bounds,
+		minutes: int((longest+time.Minute-1)/time.Minute) + 1,
+		now:     time.Now,
+		routes:  make(map[string]*routeStats),
+	}
+}
+
+// RecordRequest records a completed request of the route with its status code and latency
+func (s *RequestMetricsService) RecordRequest(route string, status int, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.routeStats(route)
+	stats.requests[statusClass(status)]++
+	for i, bound := range s.bounds {
+		if latency <= bound {
+			stats.buckets[i]++
+		}
+	}
+	stats.latencySum += latency
+	stats.count++
+
+	target, ok := s.target(route)
+	if !ok {
+		return
+	}
+	minute := s.now().Unix() / 60
+	slot := &stats.sli[minute%int64(len(stats.sli))]
+	if slot.minute != minute {
+		*slot = sliMinute{minute: minute}
+	}
+	slot.total++
+	if status >= 500 || latency > target.Latency {
+		slot.bad++
+	}
+}
+
+// RouteMetrics returns the metrics of every route that has served requests or has an SLO, sorted by route
+func (s *RequestMetricsService) RouteMetrics() []domain.RouteMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, target := range s.targets {
+		s.routeStats(target.Route)
+	}
+
+	now := s.now().Unix() / 60
+	metrics := make([]domain.RouteMetrics, 0, len(s.routes))
+	for route, stats := range s.routes {
+		m := domain.RouteMetrics{
+			Route:      route,
+			Requests:   make(map[string]uint64, len(stats.requests)),
+			LatencySum: stats.latencySum,
+			Count:      stats.count,
+		}
+		for class, count := range stats.requests {
+			m.Requests[class] = count
+		}
+		for i, bound := range s.bounds {
+			m.LatencyBuckets = append(m.LatencyBuckets, domain.LatencyBucket{UpperBound: bound, Count: stats.buckets[i]})
+		}
+		if target, ok := s.target(route); ok {
+			m.SLO = &domain.SLOStatus{Objective: target.Objective, Latency: target.Latency}
+			for _, window := range s.windows {
+				m.SLO.BurnRates = append(m.SLO.BurnRates, domain.BurnRate{
+					Window: window,
+					Rate:   stats.burnRate(now, window, target.Objective),
+				})
+			}
+		}
+		metrics = append(metrics, m)
+	}
+	slices.SortFunc(metrics, func(a, b domain.RouteMetrics) int { return strings.Compare(a.Route, b.Route) })
+	return metrics
+}
+
+// routeStats returns the stats of a route, creating them on first use. Callers must hold the lock.
+func (s *RequestMetricsService) routeStats(route string) *routeStats {
+	stats, ok := s.routes[route]
+	if !ok {
+		stats = &routeStats{
+			requests: make(map[string]uint64),
+			buckets:  make([]uint64, len(s.bounds)),
+			sli:      make([]sliMinute, s.minutes),
+		}
+		s.routes[route] = stats
+	}
+	return stats
+}
+
+// target returns the SLO target of a route
+func (s *RequestMetricsService) target(route string) (config.SLOTarget, bool) {
+	for _, target := range s.targets {
+		if target.Route == route {
+			return target, true
+		}
+	}
+	return config.SLOTarget{}, false
+}
+
+// burnRate returns the share of requests missing the objective in the minutes of the window ending
+// at minute now, divided by the error budget. Without requests nothing is burnt.
+func (r *routeStats) burnRate(now int64, window time.Duration, objective float64) float64 {
+	first := now - int64(window/time.Minute) + 1
+	var total, bad uint64
+	for _, slot := range r.sli {
+		if slot.minute >= first && slot.minute <= now {
+			total += slot.total
+			bad += slot.bad
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(bad) / float64(total) / (1 - objective)
+}
+
+// statusClass returns the status class of an HTTP status code, such as "2xx"
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// IndexMetricsService counts the events the indexer publishes, for exposing them next to the request metrics
+type IndexMetricsService struct {
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// NewIndexMetricsService creates a new IndexMetricsService; subscribe it to the indexer's event bus
+func NewIndexMetricsService() *IndexMetricsService {
+	return &IndexMetricsService{counts: make(map[string]uint64)}
+}
+
+// HandleIndexEvent counts the event under its name
+func (s *IndexMetricsService) HandleIndexEvent(ctx context.Context, event domain.IndexEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[event.IndexEventName()]++
+}
+
+// IndexEventCounts returns the number of events seen per event name
+func (s *IndexMetricsService) IndexEventCounts() map[string]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]uint64, len(s.counts))
+	for name, count := range s.counts {
+		counts[name] = count
+	}
+	return counts
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMetricsConfig() config.MetricsConfig {
+	return config.MetricsConfig{
+		SLOTargets: []config.SLOTarget{
+			{Route: "GET /api/conferences", Objective: 0.99, Latency: 300 * time.Millisecond},
+		},
+		BurnRateWindows: []time.Duration{5 * time.Minute, time.Hour},
+		LatencyBuckets:  []time.Duration{time.Second, 100 * time.Millisecond},
+	}
+}
+
+func TestRequestMetricsService_RecordsRoutes(t *testing.T) {
+	service := NewRequestMetricsServiceWithConfig(testMetricsConfig())
+
+	service.RecordRequest("GET /health", 200, 50*time.Millisecond)
+	service.RecordRequest("GET /health", 503, 2*time.Second)
+	service.RecordRequest("GET /health", 404, 500*time.Millisecond)
+
+	metrics := service.RouteMetrics()
+	require.Len(t, metrics, 2)
+
+	// Routes with an SLO are listed before they serve requests
+	assert.Equal(t, "GET /api/conferences", metrics[0].Route)
+	assert.Zero(t, metrics[0].Count)
+	require.NotNil(t, metrics[0].SLO)
+
+	health := metrics[1]
+	assert.Equal(t, "GET /health", health.Route)
+	assert.Equal(t, map[string]uint64{"2xx": 1, "4xx": 1, "5xx": 1}, health.Requests)
+	assert.Equal(t, uint64(3), health.Count)
+	assert.Equal(t, 2550*time.Millisecond, health.LatencySum)
+	assert.Equal(t, []domain.LatencyBucket{
+		{UpperBound: 100 * time.Millisecond, Count: 1},
+		{UpperBound: time.Second, Count: 2},
+	}, health.LatencyBuckets)
+	assert.Nil(t, health.SLO)
+}
+
+func TestRequestMetricsService_BurnRate(t *testing.T) {
+	now := time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC)
+	service := NewRequestMetricsServiceWithConfig(testMetricsConfig())
+	service.now = func() time.Time { return now }
+
+	// Half an hour ago: 10 requests, 2 too slow
+	now = now.Add(-30 * time.Minute)
+	for i := 0; i < 8; i++ {
+		service.RecordRequest("GET /api/conferences", 200, 100*time.Millisecond)
+	}
+	service.RecordRequest("GET /api/conferences", 200, time.Second)
+	service.RecordRequest("GET /api/conferences", 200, 500*time.Millisecond)
+
+	// Now: 10 requests, 1 failed; client errors meet the objective
+	now = now.Add(30 * time.Minute)
+	for i := 0; i < 8; i++ {
+		service.RecordRequest("GET /api/conferences", 200, 100*time.Millisecond)
+	}
+	service.RecordRequest("GET /api/conferences", 404, 100*time.Millisecond)
+	service.RecordRequest("GET /api/conferences", 500, 100*time.Millisecond)
+
+	metrics := service.RouteMetrics()
+	require.Len(t, metrics, 1)
+	slo := metrics[0].SLO
+	require.NotNil(t, slo)
+	assert.Equal(t, 0.99, slo.Objective)
+	assert.Equal(t, 300*time.Millisecond, slo.Latency)
+	require.Len(t, slo.BurnRates, 2)
+
+	// 1 of 10 bad in the last 5 minutes against a 1% budget
+	assert.Equal(t, 5*time.Minute, slo.BurnRates[0].Window)
+	assert.InDelta(t, 10.0, slo.BurnRates[0].Rate, 1e-9)
+
+	// 3 of 20 bad in the last hour
+	assert.Equal(t, time.Hour, slo.BurnRates[1].Window)
+	assert.InDelta(t, 15.0, slo.BurnRates[1].Rate, 1e-9)
+
+	// Once the requests are older than the longest window, nothing is burnt
+	now = now.Add(2 * time.Hour)
+	metrics = service.RouteMetrics()
+	assert.Zero(t, metrics[0].SLO.BurnRates[0].Rate)
+	assert.Zero(t, metrics[0].SLO.BurnRates[1].Rate)
+}
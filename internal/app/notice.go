@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// noticeKey is the settings key holding the notice banner
+const noticeKey = "notice"
+
+// noticeCacheTTL is how long the stored notice is reused before it is read again, so every request
+// does not hit the settings store and other instances pick up changes within this time
+const noticeCacheTTL = 30 * time.Second
+
+// NoticeService manages the notice banner admins use to tell other users about ongoing work,
+// such as a migration. The notice is stored in a settings document and cached in memory.
+type NoticeService struct {
+	store  ports.SettingsStore
+	now    func() time.Time
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	cached   domain.Notice
+	loadedAt time.Time
+}
+
+// NewNoticeService creates a new NoticeService backed by the given settings store
+func NewNoticeService(store ports.SettingsStore) *NoticeService {
+	return &NoticeService{
+		store:  store,
+		now:    time.Now,
+		logger: slog.Default().With("component", "notice"),
+	}
+}
+
+// SetClock replaces the system clock deciding when notices expire
+func (s *NoticeService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// ActiveNotice returns the notice to show now, or false when there is none or it has expired.
+// If the settings store cannot be read, the last known notice is used.
+func (s *NoticeService) ActiveNotice(ctx context.Context) (domain.Notice, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.loadedAt.IsZero() || now.Sub(s.loadedAt) >= noticeCacheTTL {
+		notice, err := s.load(ctx)
+		if err != nil {
+			s.logger.WarnContext(ctx, "failed to refresh notice", "error", err)
+		} else {
+			s.cached = notice
+		}
+		s.loadedAt = now
+	}
+
+	return s.cached, s.cached.Active(now)
+}
+
+// GetNotice returns the stored notice, even if it has expired
+func (s *NoticeService) GetNotice(ctx context.Context) (domain.Notice, error) {
+	return s.load(ctx)
+}
+
+// SetNotice stores the notice, replacing the previous one
+func (s *NoticeService) SetNotice(ctx context.Context, notice domain.Notice, updatedBy string) error {
+	notice.Message = strings.TrimSpace(notice.Message)
+	if err := notice.Validate(); err != nil {
+		return err
+	}
+	if notice.ExpiresAt != nil && !notice.ExpiresAt.After(s.now()) {
+		return fmt.Errorf("notice expiry is in the past")
+	}
+	notice.UpdatedAt = s.now().UTC()
+	notice.UpdatedBy = updatedBy
+
+	if err := s.save(ctx, notice); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "notice set", "severity", notice.Severity, "expiresAt", notice.ExpiresAt, "updatedBy", updatedBy)
+	return nil
+}
+
+// ClearNotice removes the notice
+func (s *NoticeService) ClearNotice(ctx context.Context) error {
+	if err := s.save(ctx, domain.Notice{}); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "notice cleared")
+	return nil
+}
+
+// load reads the stored notice
+func (s *NoticeService) load(ctx context.Context) (domain.Notice, error) {
+	var notice domain.Notice
+	if _, err := s.store.LoadSetting(ctx, noticeKey, &notice); err != nil {
+		return domain.Notice{}, fmt.Errorf("failed to load notice: %w", err)
+	}
+	return notice, nil
+}
+
+// save stores the notice and shows it right away on this instance
+func (s *NoticeService) save(ctx context.Context, notice domain.Notice) error {
+	if err := s.store.SaveSetting(ctx, noticeKey, notice); err != nil {
+		return fmt.Errorf("failed to save notice: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cached = notice
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNoticeService(store *mockSettingsStore, now *time.Time) *NoticeService {
+	service := NewNoticeService(store)
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func TestNoticeService_SetAndClear(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	store := newMockSettingsStore()
+	service := newTestNoticeService(store, &now)
+	ctx := context.Background()
+
+	_, ok := service.ActiveNotice(ctx)
+	assert.False(t, ok)
+
+	expires := now.Add(2 * time.Hour)
+	require.NoError(t, service.SetNotice(ctx, domain.Notice{Message: " Migrating to ES 9 ", Severity: domain.NoticeWarning, ExpiresAt: &expires}, "admin@example.com"))
+
+	notice, ok := service.ActiveNotice(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "Migrating to ES 9", notice.Message)
+	assert.Equal(t, "admin@example.com", notice.UpdatedBy)
+	assert.Equal(t, now, notice.UpdatedAt)
+
+	// Other instances see the stored notice
+	stored, err := NewNoticeService(store).GetNotice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Migrating to ES 9", stored.Message)
+
+	now = now.Add(3 * time.Hour)
+	_, ok = service.ActiveNotice(ctx)
+	assert.False(t, ok, "expired notices are not shown")
+
+	require.NoError(t, service.ClearNotice(ctx))
+	stored, err = service.GetNotice(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Message)
+}
+
+func TestNoticeService_SetInvalid(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestNoticeService(newMockSettingsStore(), &now)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name   string
+		notice domain.Notice
+	}{
+		{"empty message", domain.Notice{Message: "  ", Severity: domain.NoticeInfo}},
+		{"multiple lines", domain.Notice{Message: "line one\nline two", Severity: domain.NoticeInfo}},
+		{"unknown severity", domain.Notice{Message: "Maintenance", Severity: "urgent"}},
+		{"expired", domain.Notice{Message: "Maintenance", Severity: domain.NoticeInfo, ExpiresAt: &past}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, service.SetNotice(context.Background(), tt.notice, "admin@example.com"))
+		})
+	}
+}
+
+func TestNoticeService_ActiveNoticeCaches(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	store := newMockSettingsStore()
+	require.NoError(t, store.SaveSetting(context.Background(), noticeKey, domain.Notice{Message: "Maintenance tonight", Severity: domain.NoticeInfo}))
+	service := newTestNoticeService(store, &now)
+
+	_, ok := service.ActiveNotice(context.Background())
+	require.True(t, ok)
+
+	// Until the cache expires, the notice is served without reading the store
+	store.loadErr = errors.New("cluster unavailable")
+	_, ok = service.ActiveNotice(context.Background())
+	assert.True(t, ok)
+
+	// A failed refresh keeps the last known notice
+	now = now.Add(noticeCacheTTL)
+	notice, ok := service.ActiveNotice(context.Background())
+	assert.True(t, ok)
+	assert.Equal(t, "Maintenance tonight", notice.Message)
+}
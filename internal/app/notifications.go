@@ -0,0 +1,180 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// ReindexNotificationService posts the outcome of reindexes to a Slack incoming webhook and a generic
+// webhook URL, with the documents written and the duration, so that failed unattended reindexes are
+// noticed. Full and conference reindexes are posted; single talk updates are too frequent.
+type ReindexNotificationService struct {
+	sender ports.WebhookSender
+	cfg    config.NotifyConfig
+	now    func() time.Time
+	logger *slog.Logger
+}
+
+// NewReindexNotificationService creates a new ReindexNotificationService, receiving context as first
+// parameter to retrieve configuration.
+func NewReindexNotificationService(ctx context.Context, sender ports.WebhookSender) *ReindexNotificationService {
+	cfg := config.GetConfig(ctx)
+	return NewReindexNotificationServiceWithConfig(sender, cfg.Notify)
+}
+
+// NewReindexNotificationServiceWithConfig creates a new ReindexNotificationService with explicit
+// configuration. This constructor is primarily intended for testing purposes.
+func NewReindexNotificationServiceWithConfig(sender ports.WebhookSender, cfg config.NotifyConfig) *ReindexNotificationService {
+	return &ReindexNotificationService{
+		sender: sender,
+		cfg:    cfg,
+		now:    time.Now,
+		logger: slog.Default().With("component", "reindex-notifications"),
+	}
+}
+
+// SetClock sets the clock durations are measured with
+func (s *ReindexNotificationService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// HandleIndexEvent posts the outcome of a finished reindex job. It waits for the posts, bounded by
+// the timeout, so one-shot reindexes do not exit before their outcome is posted.
+func (s *ReindexNotificationService) HandleIndexEvent(ctx context.Context, event domain.IndexEvent) {
+	finished, ok := event.(domain.ReindexJobFinished)
+	if !ok {
+		return
+	}
+	job := finished.Job
+	if job.Scope.Kind == domain.JobKindReindexTalk {
+		return
+	}
+	if s.cfg.FailuresOnly && job.State == domain.JobStateSucceeded {
+		return
+	}
+
+	if s.cfg.SlackWebhookURL != "" {
+		body, err := json.Marshal(map[string]string{"text": slackMessage(job, job.Duration(s.now()))})
+		if err == nil {
+			err = s.post(ctx, s.cfg.SlackWebhookURL, body, nil)
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to post reindex outcome to Slack", "jobID", job.ID, "error", err)
+		}
+	}
+
+	if s.cfg.WebhookURL != "" {
+		if err := s.postEvent(ctx, job); err != nil {
+			s.logger.ErrorContext(ctx, "failed to post reindex outcome to webhook", "jobID", job.ID, "error", err)
+		}
+	}
+}
+
+// postEvent posts the outcome as a reindex.completed event, shaped and signed like the deliveries to
+// outbound webhook subscriptions
+func (s *ReindexNotificationService) postEvent(ctx context.Context, job domain.Job) error {
+	body, err := json.Marshal(domain.Event{
+		Type:       domain.EventReindexCompleted,
+		OccurredAt: s.now().UTC(),
+		Data: map[string]interface{}{
+			"jobId":           job.ID,
+			"scope":           job.Scope,
+			"actor":           job.Actor,
+			"state":           job.State,
+			"error":           job.Error,
+			"report":          job.Report,
+			"durationSeconds": job.Duration(s.now()).Seconds(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reindex outcome: %w", err)
+	}
+
+	delivery, err := randomHex(8)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		webhookEventHeader:    string(domain.EventReindexCompleted),
+		webhookDeliveryHeader: delivery,
+	}
+	if s.cfg.WebhookSecret != "" {
+		timestamp := strconv.FormatInt(s.now().Unix(), 10)
+		headers[webhookTimestampHeader] = timestamp
+		headers[webhookSignatureHeader] = signWebhook(s.cfg.WebhookSecret, timestamp, body)
+	}
+	return s.post(ctx, s.cfg.WebhookURL, body, headers)
+}
+
+// post makes a single attempt bounded by the timeout, which outlives the job's own context
+func (s *ReindexNotificationService) post(ctx context.Context, url string, body []byte, headers map[string]string) error {
+	ctx = context.WithoutCancel(ctx)
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
+	statusCode, err := s.sender.SendWebhook(ctx, url, body, headers)
+	if err != nil {
+		return err
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	return nil
+}
+
+// slackMessage describes the outcome of the job for a Slack message
+func slackMessage(job domain.Job, duration time.Duration) string {
+	var b strings.Builder
+	if job.State == domain.JobStateSucceeded {
+		fmt.Fprintf(&b, ":white_check_mark: Reindex of %s succeeded in %s", describeScope(job.Scope), duration.Round(time.Second))
+	} else {
+		fmt.Fprintf(&b, ":x: Reindex of %s *failed* after %s: %s", describeScope(job.Scope), duration.Round(time.Second), job.Error)
+	}
+	if job.Actor.Name != "" {
+		fmt.Fprintf(&b, " (started by %s)", job.Actor.Name)
+	}
+
+	for _, index := range slices.Sorted(maps.Keys(job.Report.Indexed)) {
+		fmt.Fprintf(&b, "\n• `%s`: %d written", index, job.Report.Indexed[index])
+		if removed := job.Report.Removed[index]; removed > 0 {
+			fmt.Fprintf(&b, ", %d removed", removed)
+		}
+	}
+	if len(job.Report.Conflicts) > 0 {
+		fmt.Fprintf(&b, "\n• %d skipped as the index held newer versions", len(job.Report.Conflicts))
+	}
+	if len(job.Report.Review) > 0 {
+		fmt.Fprintf(&b, "\n• %d talks flagged for review", len(job.Report.Review))
+	}
+	return b.String()
+}
+
+// describeScope names what a reindex job covered
+func describeScope(scope domain.JobScope) string {
+	switch scope.Kind {
+	case domain.JobKindReindexAll:
+		return "all conferences"
+	case domain.JobKindReindexConference:
+		return "conference " + scope.Target
+	case domain.JobKindReindexConferenceID:
+		return "conference with ID " + scope.Target
+	}
+	if scope.Target != "" {
+		return string(scope.Kind) + " " + scope.Target
+	}
+	return string(scope.Kind)
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// finishedJob returns a job of the scope that ran for 95 seconds until now with the error, if any
+func finishedJob(scope domain.JobScope, now time.Time, err error) domain.Job {
+	started := now.Add(-95 * time.Second)
+	job := domain.Job{
+		ID:        "job-1",
+		Scope:     scope,
+		Actor:     domain.SchedulerActor,
+		StartedAt: &started,
+		Report: domain.JobReport{
+			Indexed: map[string]int{"javazone_public": 380, "javazone_private": 420},
+			Removed: map[string]int{"javazone_private": 2},
+		},
+	}
+	job.Finish(now, err)
+	return job
+}
+
+func TestReindexNotificationService_Slack(t *testing.T) {
+	now := time.Date(2025, 9, 3, 3, 0, 0, 0, time.UTC)
+	sender := &mockWebhookSender{}
+	service := NewReindexNotificationServiceWithConfig(sender, config.NotifyConfig{SlackWebhookURL: "https://hooks.slack.com/services/T/B/X"})
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: finishedJob(domain.JobScope{Kind: domain.JobKindReindexAll}, now, nil)})
+	service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: finishedJob(
+		domain.JobScope{Kind: domain.JobKindReindexConference, Target: "javazone2025"}, now, errors.New("moresleep unavailable"))})
+
+	require.Len(t, sender.sent, 2)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", sender.sent[0].url)
+
+	var message map[string]string
+	require.NoError(t, json.Unmarshal(sender.sent[0].body, &message))
+	assert.Equal(t, ":white_check_mark: Reindex of all conferences succeeded in 1m35s (started by scheduler)\n"+
+		"• `javazone_private`: 420 written, 2 removed\n"+
+		"• `javazone_public`: 380 written", message["text"])
+
+	require.NoError(t, json.Unmarshal(sender.sent[1].body, &message))
+	assert.Contains(t, message["text"], ":x: Reindex of conference javazone2025 *failed* after 1m35s: moresleep unavailable")
+}
+
+func TestReindexNotificationService_Webhook(t *testing.T) {
+	now := time.Date(2025, 9, 3, 3, 0, 0, 0, time.UTC)
+	sender := &mockWebhookSender{}
+	service := NewReindexNotificationServiceWithConfig(sender, config.NotifyConfig{
+		WebhookURL:    "https://ops.example.com/hooks/indexer",
+		WebhookSecret: "notify-secret",
+	})
+	service.now = func() time.Time { return now }
+
+	service.HandleIndexEvent(context.Background(), domain.ReindexJobFinished{Job: finishedJob(domain.JobScope{Kind: domain.JobKindReindexAll}, now, nil)})
+
+	require.Len(t, sender.sent, 1)
+	sent := sender.sent[0]
+	assert.Equal(t, "reindex.completed", sent.headers["X-Webhook-Event"])
+	assert.Equal(t, signWebhook("notify-secret", sent.headers["X-Webhook-Timestamp"], sent.body), sent.headers["X-Webhook-Signature"])
+
+	var event domain.Event
+	require.NoError(t, json.Unmarshal(sent.body, &event))
+	assert.Equal(t, domain.EventReindexCompleted, event.Type)
+	assert.Equal(t, "succeeded", event.Data["state"])
+	assert.Equal(t, 95.0, event.Data["durationSeconds"])
+}
+
+func TestReindexNotificationService_Filters(t *testing.T) {
+	now := time.Date(2025, 9, 3, 3, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	t.Run("single talk updates are not posted", func(t *testing.T) {
+		sender := &mockWebhookSender{}
+		service := NewReindexNotificationServiceWithConfig(sender, config.NotifyConfig{SlackWebhookURL: "https://hooks.slack.com/services/T/B/X"})
+		service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: finishedJob(domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}, now, errors.New("not found"))})
+		service.HandleIndexEvent(ctx, domain.TalkIndexed{TalkID: "talk-1", IndexName: "javazone_public"})
+		assert.Empty(t, sender.sent)
+	})
+
+	t.Run("failures only", func(t *testing.T) {
+		sender := &mockWebhookSender{}
+		service := NewReindexNotificationServiceWithConfig(sender, config.NotifyConfig{SlackWebhookURL: "https://hooks.slack.com/services/T/B/X", FailuresOnly: true})
+		service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: finishedJob(domain.JobScope{Kind: domain.JobKindReindexAll}, now, nil)})
+		assert.Empty(t, sender.sent)
+
+		service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: finishedJob(domain.JobScope{Kind: domain.JobKindReindexAll}, now, errors.New("cluster read-only"))})
+		assert.Len(t, sender.sent, 1)
+	})
+
+	t.Run("rejected posts are not retried", func(t *testing.T) {
+		sender := &mockWebhookSender{sendFunc: func(int) (int, error) { return http.StatusNotFound, nil }}
+		service := NewReindexNotificationServiceWithConfig(sender, config.NotifyConfig{SlackWebhookURL: "https://hooks.slack.com/services/T/B/X"})
+		service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: finishedJob(domain.JobScope{Kind: domain.JobKindReindexAll}, now, nil)})
+		assert.Len(t, sender.sent, 1)
+	})
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// preferencesKeyPrefix namespaces user preferences in the settings store
+const preferencesKeyPrefix = "preferences:"
+
+// PreferencesService stores per-user admin UI preferences, keyed by the user's email
+type PreferencesService struct {
+	store  ports.SettingsStore
+	logger *slog.Logger
+}
+
+// NewPreferencesService creates a new PreferencesService backed by the given settings store
+func NewPreferencesService(store ports.SettingsStore) *PreferencesService {
+	return &PreferencesService{
+		store:  store,
+		logger: slog.Default().With("component", "preferences"),
+	}
+}
+
+// GetPreferences returns the stored preferences of the given user. Users without stored
+// preferences get the defaults, and stored values that are no longer valid fall back to them.
+func (s *PreferencesService) GetPreferences(ctx context.Context, email string) (domain.UserPreferences, error) {
+	prefs := domain.DefaultUserPreferences()
+
+	if _, err := s.store.LoadSetting(ctx, preferencesKey(email), &prefs); err != nil {
+		return domain.DefaultUserPreferences(), fmt.Errorf("failed to load preferences: %w", err)
+	}
+
+	defaults := domain.DefaultUserPreferences()
+	if !slices.Contains(domain.PageSizes, prefs.PageSize) {
+		prefs.PageSize = defaults.PageSize
+	}
+	if !validTheme(prefs.Theme) {
+		prefs.Theme = defaults.Theme
+	}
+	if !slices.Contains(domain.Languages, prefs.Language) {
+		prefs.Language = defaults.Language
+	}
+	return prefs, nil
+}
+
+// SavePreferences validates and stores the preferences of the given user
+func (s *PreferencesService) SavePreferences(ctx context.Context, email string, prefs domain.UserPreferences) error {
+	if !slices.Contains(domain.PageSizes, prefs.PageSize) {
+		return fmt.Errorf("invalid page size %d", prefs.PageSize)
+	}
+	if !validTheme(prefs.Theme) {
+		return fmt.Errorf("invalid theme %q", prefs.Theme)
+	}
+	if !slices.Contains(domain.Languages, prefs.Language) {
+		return fmt.Errorf("invalid language %q", prefs.Language)
+	}
+
+	if err := s.store.SaveSetting(ctx, preferencesKey(email), prefs); err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	s.logger.DebugContext(ctx, "saved preferences", "email", email)
+	return nil
+}
+
+// preferencesKey returns the settings key for a user; emails are compared case-insensitively
+func preferencesKey(email string) string {
+	return preferencesKeyPrefix + normalizeEmail(email)
+}
+
+// validTheme reports whether theme is one of the supported UI themes
+func validTheme(theme string) bool {
+	return theme == domain.ThemeSystem || theme == domain.ThemeLight || theme == domain.ThemeDark
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSettingsStore is an in-memory implementation of ports.SettingsStore
+type mockSettingsStore struct {
+	settings map[string][]byte
+	loadErr  error
+}
+
+func newMockSettingsStore() *mockSettingsStore {
+	return &mockSettingsStore{settings: make(map[string][]byte)}
+}
+
+func (m *mockSettingsStore) LoadSetting(ctx context.Context, key string, value any) (bool, error) {
+	if m.loadErr != nil {
+		return false, m.loadErr
+	}
+	raw, ok := m.settings[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, value)
+}
+
+func (m *mockSettingsStore) SaveSetting(ctx context.Context, key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.settings[key] = raw
+	return nil
+}
+
+func TestPreferences_DefaultsWhenNotStored(t *testing.T) {
+	service := NewPreferencesService(newMockSettingsStore())
+
+	prefs, err := service.GetPreferences(context.Background(), "user@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultUserPreferences(), prefs)
+}
+
+func TestPreferences_SaveAndLoad(t *testing.T) {
+	store := newMockSettingsStore()
+	service := NewPreferencesService(store)
+	ctx := context.Background()
+
+	saved := domain.UserPreferences{DefaultConference: "javazone2024", PageSize: 100, Theme: domain.ThemeDark, Language: domain.LanguageNorwegian}
+	require.NoError(t, service.SavePreferences(ctx, "User@Example.com", saved))
+
+	// Emails are matched case-insensitively
+	prefs, err := service.GetPreferences(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, saved, prefs)
+	assert.Contains(t, store.settings, "preferences:user@example.com")
+
+	// Other users are unaffected
+	other, err := service.GetPreferences(ctx, "other@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultUserPreferences(), other)
+}
+
+func TestPreferences_SaveRejectsInvalidValues(t *testing.T) {
+	service := NewPreferencesService(newMockSettingsStore())
+	ctx := context.Background()
+
+	err := service.SavePreferences(ctx, "user@example.com", domain.UserPreferences{PageSize: 7, Theme: domain.ThemeLight, Language: domain.LanguageEnglish})
+	assert.ErrorContains(t, err, "invalid page size")
+
+	err = service.SavePreferences(ctx, "user@example.com", domain.UserPreferences{PageSize: 50, Theme: "neon", Language: domain.LanguageEnglish})
+	assert.ErrorContains(t, err, "invalid theme")
+
+	err = service.SavePreferences(ctx, "user@example.com", domain.UserPreferences{PageSize: 50, Theme: domain.ThemeLight, Language: "klingon"})
+	assert.ErrorContains(t, err, "invalid language")
+}
+
+func TestPreferences_InvalidStoredValuesFallBackToDefaults(t *testing.T) {
+	store := newMockSettingsStore()
+	store.settings["preferences:user@example.com"] = []byte(`{"defaultConference":"javazone2023","pageSize":3,"theme":"neon","language":"tlh"}`)
+	service := NewPreferencesService(store)
+
+	prefs, err := service.GetPreferences(context.Background(), "user@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, "javazone2023", prefs.DefaultConference)
+	assert.Equal(t, domain.DefaultPageSize, prefs.PageSize)
+	assert.Equal(t, domain.ThemeSystem, prefs.Theme)
+	assert.Equal(t, domain.LanguageEnglish, prefs.Language)
+}
+
+func TestPreferences_LoadError(t *testing.T) {
+	store := newMockSettingsStore()
+	store.loadErr = errors.New("cluster unavailable")
+	service := NewPreferencesService(store)
+
+	prefs, err := service.GetPreferences(context.Background(), "user@example.com")
+
+	assert.ErrorContains(t, err, "cluster unavailable")
+	assert.Equal(t, domain.DefaultUserPreferences(), prefs)
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// TalkPreviewService creates and verifies the preview links speakers use to see their talk before
+// publication. A token is the talk ID and expiry signed with HMAC-SHA256, so tokens need no storage
+// and stop working when the secret is changed. Previews build the public document from the current
+// submission the same way a reindex does, and compare it with the document in the public index.
+type TalkPreviewService struct {
+	indexer *IndexerService
+	reader  ports.TalkReader
+	secret  []byte
+	ttl     time.Duration
+	now     func() time.Time
+	logger  *slog.Logger
+}
+
+// NewTalkPreviewService creates a new TalkPreviewService, receiving context as first parameter
+// to retrieve configuration.
+func NewTalkPreviewService(ctx context.Context, indexer *IndexerService, reader ports.TalkReader) *TalkPreviewService {
+	cfg := config.GetConfig(ctx)
+	return NewTalkPreviewServiceWithConfig(indexer, reader, cfg.Preview)
+}
+
+// NewTalkPreviewServiceWithConfig creates a new TalkPreviewService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewTalkPreviewServiceWithConfig(indexer *IndexerService, reader ports.TalkReader, cfg config.PreviewConfig) *TalkPreviewService {
+	return &TalkPreviewService{
+		indexer: indexer,
+		reader:  reader,
+		secret:  []byte(cfg.Secret),
+		ttl:     cfg.LinkTTL,
+		now:     time.Now,
+		logger:  slog.Default().With("component", "preview"),
+	}
+}
+
+// SetClock replaces the system clock deciding when preview tokens expire
+func (s *TalkPreviewService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// previewClaims is the signed payload of a preview token
+type previewClaims struct {
+	TalkID    string `json:"talk"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// CreatePreviewToken returns a new preview token for the talk, after checking the talk exists in moresleep
+func (s *TalkPreviewService) CreatePreviewToken(ctx context.Context, talkID string) (domain.PreviewToken, error) {
+	if _, err := s.indexer.source.GetTalk(ctx, talkID); err != nil {
+		return domain.PreviewToken{}, fmt.Errorf("failed to fetch talk %s: %w", talkID, err)
+	}
+
+	expiresAt := s.now().Add(s.ttl).UTC().Truncate(time.Second)
+	payload, err := json.Marshal(previewClaims{TalkID: talkID, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return domain.PreviewToken{}, fmt.Errorf("failed to encode preview token: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	s.logger.InfoContext(ctx, "created preview token", "talkID", talkID, "expiresAt", expiresAt)
+	return domain.PreviewToken{
+		TalkID:    talkID,
+		Token:     encoded + "." + s.sign(encoded),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// PreviewTalk verifies the token and returns the public document of its talk built from the current
+// submission, along with the document in the public index and the fields that differ between them
+func (s *TalkPreviewService) PreviewTalk(ctx context.Context, token string) (domain.TalkPreview, error) {
+	claims, err := s.verify(token)
+	if err != nil {
+		return domain.TalkPreview{}, err
+	}
+
+	talk, err := s.indexer.source.GetTalk(ctx, claims.TalkID)
+	if err != nil {
+		return domain.TalkPreview{}, fmt.Errorf("failed to fetch talk %s: %w", claims.TalkID, err)
+	}
+	// Talks not yet accepted preview the document they will get once they are
+	pending := s.indexer.publicDocuments(ctx, []domain.Talk{*talk})[0]
+
+	preview := domain.TalkPreview{
+		TalkID:    claims.TalkID,
+		Talk:      pending,
+		Public:    domain.TalkStatus(pending.Status).IsPublic(),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+	}
+
+	preview.Published, err = s.indexer.indexedTalk(ctx, s.reader, s.indexer.publicIndex, pending.ConferenceSlug, claims.TalkID)
+	if err != nil {
+		return domain.TalkPreview{}, err
+	}
+	if preview.Published != nil {
+		if preview.Changes, err = changedFields(preview.Talk, *preview.Published); err != nil {
+			return domain.TalkPreview{}, err
+		}
+	}
+	return preview, nil
+}
+
+// verify checks the signature and expiry of a preview token and returns its claims
+func (s *TalkPreviewService) verify(token string) (previewClaims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.sign(encoded))) {
+		return previewClaims{}, domain.ErrInvalidPreviewToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return previewClaims{}, domain.ErrInvalidPreviewToken
+	}
+	var claims previewClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.TalkID == "" {
+		return previewClaims{}, domain.ErrInvalidPreviewToken
+	}
+	if !s.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return previewClaims{}, fmt.Errorf("%w: expired %s", domain.ErrInvalidPreviewToken, time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return claims, nil
+}
+
+// sign returns the base64url HMAC-SHA256 of the encoded claims using the preview secret
+func (s *TalkPreviewService) sign(encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// changedFields returns the top-level fields and data fields that differ between two documents, sorted
+func changedFields(talk, published domain.Talk) ([]string, error) {
+	document, err := documentOf(talk)
+	if err != nil {
+		return nil, err
+	}
+	publishedDocument, err := documentOf(published)
+	if err != nil {
+		return nil, err
+	}
+	fields, publishedFields := document.(map[string]interface{}), publishedDocument.(map[string]interface{})
+	data, _ := fields["data"].(map[string]interface{})
+	publishedData, _ := publishedFields["data"].(map[string]interface{})
+	delete(fields, "data")
+	delete(publishedFields, "data")
+
+	changes := differentKeys(fields, publishedFields)
+	changes = append(changes, differentKeys(data, publishedData)...)
+	slices.Sort(changes)
+	return changes, nil
+}
+
+// differentKeys returns the keys whose values differ between the maps, including keys in only one of them
+func differentKeys(a, b map[string]interface{}) []string {
+	var keys []string
+	for key := range maps.Keys(a) {
+		if !reflect.DeepEqual(a[key], b[key]) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range maps.Keys(b) {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
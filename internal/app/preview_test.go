@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// previewTestTalk returns a submitted talk whose abstract holds an email address, with private data
+func previewTestTalk() *domain.Talk {
+	return &domain.Talk{
+		ID: "talk-1", ConferenceSlug: "javazone2025", Status: "SUBMITTED",
+		Speakers:    domain.Speakers{{ID: "s1", Name: "Duke", PrivateData: map[string]interface{}{"email": "duke@example.com"}}},
+		Data:        map[string]interface{}{"title": "Records 2", "abstract": "Ask duke@example.com", "length": "45"},
+		PrivateData: map[string]interface{}{"comments": "for the program committee"},
+	}
+}
+
+func newTestPreviewService(source *mockTalkSource, reader *mockTalkReader, now *time.Time) *TalkPreviewService {
+	indexer := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	indexer.SetScrubber(NewScrubber(config.TransformConfig{ScrubFields: []string{"abstract"}}))
+	service := NewTalkPreviewServiceWithConfig(indexer, reader, config.PreviewConfig{Secret: "s3cret", LinkTTL: 24 * time.Hour})
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func TestTalkPreviewService_PreviewTalk(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			assert.Equal(t, "talk-1", talkID)
+			return previewTestTalk(), nil
+		},
+	}
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			assert.Equal(t, "public", indexName)
+			assert.Equal(t, "javazone2025", conferenceSlug)
+			return []domain.Talk{
+				{ID: "talk-0", ConferenceSlug: "javazone2025"},
+				{ID: "talk-1", ConferenceSlug: "javazone2025", Status: "SUBMITTED", Speakers: domain.Speakers{{ID: "s1", Name: "Duke"}},
+					Data: map[string]interface{}{"title": "Records", "abstract": "Ask duke@example.com", "level": "beginner"}},
+			}, nil
+		},
+	}
+	service := newTestPreviewService(source, reader, &now)
+
+	token, err := service.CreatePreviewToken(context.Background(), "talk-1")
+	require.NoError(t, err)
+	assert.Equal(t, "talk-1", token.TalkID)
+	assert.Equal(t, now.Add(24*time.Hour), token.ExpiresAt)
+
+	now = now.Add(23 * time.Hour)
+	preview, err := service.PreviewTalk(context.Background(), token.Token)
+	require.NoError(t, err)
+
+	assert.Equal(t, "talk-1", preview.TalkID)
+	assert.Equal(t, token.ExpiresAt, preview.ExpiresAt)
+	assert.False(t, preview.Public)
+	assert.Equal(t, "Records 2", preview.Talk.Data["title"])
+	assert.NotContains(t, preview.Talk.Data["abstract"], "duke@example.com", "talks are scrubbed as if they were public")
+	assert.Nil(t, preview.Talk.Speakers[0].PrivateData, "private fields are left out")
+	assert.Nil(t, preview.Talk.PrivateData)
+	require.NotNil(t, preview.Published)
+	assert.Equal(t, "Records", preview.Published.Data["title"])
+	assert.Equal(t, []string{"abstract", "length", "level", "title"}, preview.Changes)
+}
+
+func TestTalkPreviewService_PreviewTalk_NotPublished(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			talk := previewTestTalk()
+			talk.Status = "APPROVED"
+			return talk, nil
+		},
+	}
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return nil, nil
+		},
+	}
+	service := newTestPreviewService(source, reader, &now)
+
+	token, err := service.CreatePreviewToken(context.Background(), "talk-1")
+	require.NoError(t, err)
+	preview, err := service.PreviewTalk(context.Background(), token.Token)
+	require.NoError(t, err)
+
+	assert.True(t, preview.Public)
+	assert.Nil(t, preview.Published)
+	assert.Empty(t, preview.Changes)
+}
+
+func TestTalkPreviewService_InvalidTokens(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return previewTestTalk(), nil
+		},
+	}
+	service := newTestPreviewService(source, &mockTalkReader{}, &now)
+	token, err := service.CreatePreviewToken(context.Background(), "talk-1")
+	require.NoError(t, err)
+
+	payload, signature, _ := strings.Cut(token.Token, ".")
+	other := newTestPreviewService(source, &mockTalkReader{}, &now)
+	other.secret = []byte("another secret")
+	forged, err := other.CreatePreviewToken(context.Background(), "talk-2")
+	require.NoError(t, err)
+	forgedPayload, _, _ := strings.Cut(forged.Token, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", payload},
+		{"bad signature", payload + ".c2lnbmF0dXJl"},
+		{"payload of another talk", forgedPayload + "." + signature},
+		{"signed with another secret", forged.Token},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.PreviewTalk(context.Background(), tt.token)
+			assert.ErrorIs(t, err, domain.ErrInvalidPreviewToken)
+		})
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(24 * time.Hour)
+		_, err := service.PreviewTalk(context.Background(), token.Token)
+		assert.ErrorIs(t, err, domain.ErrInvalidPreviewToken)
+	})
+}
+
+func TestTalkPreviewService_CreatePreviewToken_UnknownTalk(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return nil, errors.New("talk not found in moresleep")
+		},
+	}
+	service := newTestPreviewService(source, &mockTalkReader{}, &now)
+
+	_, err := service.CreatePreviewToken(context.Background(), "missing")
+
+	assert.ErrorContains(t, err, "talk not found in moresleep")
+}
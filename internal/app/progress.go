@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"sync"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// progressBuffer is how much progress a subscriber may fall behind before progress is dropped for it
+const progressBuffer = 64
+
+// ReindexProgressService relays the progress of running reindexes from the indexer's event bus to live
+// subscribers, such as the dashboard. Only reindexes running on this instance are seen.
+type ReindexProgressService struct {
+	mu          sync.Mutex
+	subscribers map[chan domain.ReindexProgress]struct{}
+	closed      bool
+}
+
+// NewReindexProgressService creates a ReindexProgressService without subscribers
+func NewReindexProgressService() *ReindexProgressService {
+	return &ReindexProgressService{subscribers: make(map[chan domain.ReindexProgress]struct{})}
+}
+
+// HandleIndexEvent forwards reindex progress and the outcome of finished reindex jobs to every subscriber.
+// It never blocks the indexer: subscribers whose buffer is full miss the progress.
+func (s *ReindexProgressService) HandleIndexEvent(ctx context.Context, event domain.IndexEvent) {
+	var progress domain.ReindexProgress
+	switch e := event.(type) {
+	case domain.ReindexProgress:
+		progress = e
+	case domain.ReindexJobFinished:
+		progress = domain.ReindexProgress{
+			Stage: domain.ReindexStageFinished,
+			Scope: &e.Job.Scope,
+			State: e.Job.State,
+			Error: e.Job.Error,
+		}
+		for _, count := range e.Job.Report.Indexed {
+			progress.Talks += count
+		}
+	default:
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- progress:
+		default:
+		}
+	}
+}
+
+// SubscribeProgress returns a channel receiving the progress of the reindexes running from now on,
+// closed when the context is done or the service is closed
+func (s *ReindexProgressService) SubscribeProgress(ctx context.Context) <-chan domain.ReindexProgress {
+	subscriber := make(chan domain.ReindexProgress, progressBuffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		close(subscriber)
+		return subscriber
+	}
+	s.subscribers[subscriber] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		s.unsubscribe(subscriber)
+	}()
+
+	return subscriber
+}
+
+// Close ends every subscription, such as the streams of open dashboards when the server shuts down
+func (s *ReindexProgressService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for subscriber := range s.subscribers {
+		delete(s.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+// unsubscribe removes and closes the subscriber, unless Close already did
+func (s *ReindexProgressService) unsubscribe(subscriber chan domain.ReindexProgress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[subscriber]; ok {
+		delete(s.subscribers, subscriber)
+		close(subscriber)
+	}
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindexProgressService(t *testing.T) {
+	progress := NewReindexProgressService()
+	bus := NewEventBus()
+	bus.Subscribe(progress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := progress.SubscribeProgress(ctx)
+
+	bus.Publish(context.Background(), domain.TalkIndexed{TalkID: "talk-1", IndexName: "private"})
+	bus.Publish(context.Background(), domain.ReindexProgress{Stage: domain.ReindexStageFetched, ConferenceSlug: "javazone2024", Fetched: 1, Conferences: 2, Talks: 120})
+	bus.Publish(context.Background(), domain.ReindexJobFinished{Job: domain.Job{
+		Scope:  domain.JobScope{Kind: domain.JobKindReindexAll},
+		State:  domain.JobStateFailed,
+		Error:  "failed to index to public index",
+		Report: domain.JobReport{Indexed: map[string]int{"private": 120}},
+	}})
+
+	assert.Equal(t, domain.ReindexProgress{Stage: domain.ReindexStageFetched, ConferenceSlug: "javazone2024", Fetched: 1, Conferences: 2, Talks: 120}, <-updates)
+	assert.Equal(t, domain.ReindexProgress{
+		Stage: domain.ReindexStageFinished,
+		Scope: &domain.JobScope{Kind: domain.JobKindReindexAll},
+		Talks: 120,
+		State: domain.JobStateFailed,
+		Error: "failed to index to public index",
+	}, <-updates, "other index events are not relayed")
+
+	cancel()
+	select {
+	case _, ok := <-updates:
+		assert.False(t, ok, "the channel is closed when the context is done")
+	case <-time.After(time.Second):
+		t.Fatal("the channel was not closed when the context was done")
+	}
+}
+
+func TestReindexProgressService_DropsForSlowSubscribers(t *testing.T) {
+	progress := NewReindexProgressService()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := progress.SubscribeProgress(ctx)
+
+	for i := range progressBuffer + 10 {
+		progress.HandleIndexEvent(context.Background(), domain.ReindexProgress{Stage: domain.ReindexStageFetched, Fetched: i + 1})
+	}
+
+	require.Len(t, updates, progressBuffer)
+	assert.Equal(t, 1, (<-updates).Fetched, "the oldest progress is kept")
+}
+
+func TestReindexProgressService_Close(t *testing.T) {
+	progress := NewReindexProgressService()
+	updates := progress.SubscribeProgress(context.Background())
+
+	progress.Close()
+
+	_, ok := <-updates
+	assert.False(t, ok, "open subscriptions end")
+	_, ok = <-progress.SubscribeProgress(context.Background())
+	assert.False(t, ok, "new subscriptions end right away")
+}
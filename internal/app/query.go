@@ -0,0 +1,404 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// defaultQuerySize is the number of hits returned when a query does not set a size
+const defaultQuerySize = 10
+
+// queryTimeoutGrace leaves Elasticsearch time to return partial results after the query timeout
+// before the request is cancelled
+const queryTimeoutGrace = time.Second
+
+// leafQueryClauses are the query clauses whose body only holds field names and values
+var leafQueryClauses = []string{"match_all", "match_none", "match", "match_phrase", "multi_match", "term", "terms", "range", "exists", "prefix", "ids"}
+
+// boolOccurrences are the keys of a bool query holding clauses
+var boolOccurrences = []string{"must", "should", "filter", "must_not"}
+
+// metricAggregations are the aggregations computing a value over a field
+var metricAggregations = []string{"avg", "sum", "min", "max", "value_count", "cardinality", "stats", "percentiles"}
+
+// bucketAggregations are the aggregations grouping documents, which may hold sub-aggregations
+var bucketAggregations = []string{"terms", "histogram", "date_histogram", "range", "date_range", "missing", "filter", "nested", "reverse_nested"}
+
+// calendarIntervals are the calendar intervals of a date histogram with their shortest length, so a
+// bucket count computed from them is never too low
+var calendarIntervals = map[string]time.Duration{
+	"minute": time.Minute, "1m": time.Minute,
+	"hour": time.Hour, "1h": time.Hour,
+	"day": 24 * time.Hour, "1d": 24 * time.Hour,
+	"week": 7 * 24 * time.Hour, "1w": 7 * 24 * time.Hour,
+	"month": 28 * 24 * time.Hour, "1M": 28 * 24 * time.Hour,
+	"quarter": 89 * 24 * time.Hour, "1q": 89 * 24 * time.Hour,
+	"year": 365 * 24 * time.Hour, "1y": 365 * 24 * time.Hour,
+}
+
+// fixedIntervalUnits are the units of a fixed date histogram interval
+var fixedIntervalUnits = map[string]time.Duration{
+	"ms": time.Millisecond, "s": time.Second, "m": time.Minute, "h": time.Hour, "d": 24 * time.Hour,
+}
+
+// scriptKeys are rejected anywhere in a query, so no request can run a script on the cluster
+var scriptKeys = []string{"script", "_script"}
+
+// QueryService runs ad-hoc queries against the private index for power users. Requests are limited to
+// a subset of the query DSL without scripts, lookups into other indexes or expensive clauses such as
+// wildcard, regexp and query_string, and are bounded in size, nesting and run time.
+type QueryService struct {
+	runner    ports.QueryRunner
+	indexName string
+	cfg       config.QueryConfig
+	logger    *slog.Logger
+}
+
+// NewQueryService creates a new QueryService, receiving context as first parameter
+// to retrieve configuration.
+func NewQueryService(ctx context.Context, runner ports.QueryRunner) *QueryService {
+	cfg := config.GetConfig(ctx)
+	return NewQueryServiceWithConfig(runner, cfg.Index.PrivateName(), cfg.Query)
+}
+
+// NewQueryServiceWithConfig creates a new QueryService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewQueryServiceWithConfig(runner ports.QueryRunner, indexName string, cfg config.QueryConfig) *QueryService {
+	return &QueryService{
+		runner:    runner,
+		indexName: indexName,
+		cfg:       cfg,
+		logger:    slog.Default().With("component", "query"),
+	}
+}
+
+// Query validates the request and runs it against the private index
+func (s *QueryService) Query(ctx context.Context, req domain.QueryRequest) (domain.QueryResult, error) {
+	body, err := s.buildQuery(req)
+	if err != nil {
+		return domain.QueryResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout+queryTimeoutGrace)
+	defer cancel()
+
+	result, err := s.runner.RunQuery(ctx, s.indexName, body)
+	if err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to run query: %w", err)
+	}
+
+	query, _ := json.Marshal(req)
+	s.logger.InfoContext(ctx, "ad-hoc query",
+		"query", string(query),
+		"total", result.Total,
+		"tookMs", result.TookMillis,
+		"timedOut", result.TimedOut,
+	)
+	return result, nil
+}
+
+// buildQuery validates the request against the allowed subset and limits and returns the search body
+func (s *QueryService) buildQuery(req domain.QueryRequest) (map[string]interface{}, error) {
+	size := defaultQuerySize
+	if req.Size != nil {
+		size = *req.Size
+	}
+	if size < 0 || size > s.cfg.MaxSize {
+		return nil, invalidQuery("size must be between 0 and %d", s.cfg.MaxSize)
+	}
+	if req.From < 0 || req.From > s.cfg.MaxFrom {
+		return nil, invalidQuery("from must be between 0 and %d", s.cfg.MaxFrom)
+	}
+
+	for _, part := range []interface{}{req.Query, req.Aggregations, req.Sort} {
+		if key, ok := findKey(part, scriptKeys); ok {
+			return nil, invalidQuery("%s is not allowed", key)
+		}
+	}
+
+	body := map[string]interface{}{
+		"size":             size,
+		"from":             req.From,
+		"timeout":          fmt.Sprintf("%dms", s.cfg.Timeout.Milliseconds()),
+		"track_total_hits": true,
+	}
+	if req.Query != nil {
+		if err := s.validateClause(req.Query, 1); err != nil {
+			return nil, err
+		}
+		body["query"] = req.Query
+	}
+	if req.Aggregations != nil {
+		if err := s.validateAggregations(req.Aggregations, 1); err != nil {
+			return nil, err
+		}
+		body["aggs"] = req.Aggregations
+	}
+	if req.Sort != nil {
+		if err := validateSort(req.Sort); err != nil {
+			return nil, err
+		}
+		body["sort"] = req.Sort
+	}
+	if req.Source != nil {
+		body["_source"] = req.Source
+	}
+	return body, nil
+}
+
+// validateClause checks a query clause, an object holding exactly one allowed clause type
+func (s *QueryService) validateClause(clause interface{}, depth int) error {
+	if depth > s.cfg.MaxDepth {
+		return invalidQuery("query is nested deeper than %d levels", s.cfg.MaxDepth)
+	}
+
+	clauseType, clauseBody, err := singleEntry(clause, "query clause")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case clauseType == "bool":
+		for key, value := range clauseBody {
+			if !slices.Contains(boolOccurrences, key) {
+				if key == "minimum_should_match" || key == "boost" {
+					continue
+				}
+				return invalidQuery("bool does not support %s", key)
+			}
+			clauses, ok := value.([]interface{})
+			if !ok {
+				clauses = []interface{}{value}
+			}
+			for _, nested := range clauses {
+				if err := s.validateClause(nested, depth+1); err != nil {
+					return err
+				}
+			}
+		}
+	case clauseType == "nested":
+		nested, ok := clauseBody["query"]
+		if !ok {
+			return invalidQuery("nested requires a query")
+		}
+		return s.validateClause(nested, depth+1)
+	case clauseType == "terms":
+		// Terms lookups read values from a document in another index
+		for field, values := range clauseBody {
+			if _, ok := values.([]interface{}); !ok && field != "boost" {
+				return invalidQuery("terms on %s requires a list of values", field)
+			}
+		}
+	case slices.Contains(leafQueryClauses, clauseType):
+	default:
+		return invalidQuery("query clause %s is not allowed", clauseType)
+	}
+	return nil
+}
+
+// validateAggregations checks a map of named aggregations and their sub-aggregations
+func (s *QueryService) validateAggregations(aggregations map[string]interface{}, depth int) error {
+	if depth > s.cfg.MaxDepth {
+		return invalidQuery("aggregations are nested deeper than %d levels", s.cfg.MaxDepth)
+	}
+
+	for name, definition := range aggregations {
+		agg, ok := definition.(map[string]interface{})
+		if !ok {
+			return invalidQuery("aggregation %s must be an object", name)
+		}
+
+		var aggType string
+		for key, value := range agg {
+			switch {
+			case key == "aggs" || key == "aggregations":
+				sub, ok := value.(map[string]interface{})
+				if !ok {
+					return invalidQuery("sub-aggregations of %s must be an object", name)
+				}
+				if err := s.validateAggregations(sub, depth+1); err != nil {
+					return err
+				}
+			case aggType != "":
+				return invalidQuery("aggregation %s has more than one type", name)
+			default:
+				aggType = key
+			}
+		}
+
+		switch {
+		case aggType == "terms":
+			params, _ := agg[aggType].(map[string]interface{})
+			if size, ok := params["size"].(float64); ok && (size < 0 || size > float64(s.cfg.MaxBuckets)) {
+				return invalidQuery("terms aggregation %s size must be between 0 and %d", name, s.cfg.MaxBuckets)
+			}
+		case aggType == "histogram" || aggType == "date_histogram":
+			params, _ := agg[aggType].(map[string]interface{})
+			if err := s.validateHistogram(name, aggType, params); err != nil {
+				return err
+			}
+		case aggType == "filter":
+			if err := s.validateClause(agg[aggType], depth+1); err != nil {
+				return err
+			}
+		case slices.Contains(bucketAggregations, aggType):
+		case slices.Contains(metricAggregations, aggType):
+			_, hasAggs := agg["aggs"]
+			_, hasAggregations := agg["aggregations"]
+			if hasAggs || hasAggregations {
+				return invalidQuery("metric aggregation %s cannot have sub-aggregations", name)
+			}
+		case aggType == "":
+			return invalidQuery("aggregation %s has no type", name)
+		default:
+			return invalidQuery("aggregation %s of type %s is not allowed", name, aggType)
+		}
+	}
+	return nil
+}
+
+// validateHistogram checks that a histogram has hard bounds and an interval that splits them into at
+// most the allowed number of buckets. Without bounds, a small interval over a wide range of values
+// makes Elasticsearch build a bucket for every step in between.
+func (s *QueryService) validateHistogram(name, aggType string, params map[string]interface{}) error {
+	bounds, _ := params["hard_bounds"].(map[string]interface{})
+	if bounds == nil {
+		return invalidQuery("%s aggregation %s requires hard_bounds with min and max", aggType, name)
+	}
+
+	var span, interval float64
+	if aggType == "histogram" {
+		lower, lowerOK := bounds["min"].(float64)
+		upper, upperOK := bounds["max"].(float64)
+		if !lowerOK || !upperOK {
+			return invalidQuery("histogram aggregation %s hard_bounds min and max must be numbers", name)
+		}
+		span = upper - lower
+		interval, _ = params["interval"].(float64)
+	} else {
+		lower, lowerOK := boundTime(bounds["min"])
+		upper, upperOK := boundTime(bounds["max"])
+		if !lowerOK || !upperOK {
+			return invalidQuery("date_histogram aggregation %s hard_bounds min and max must be dates like 2024-09-04 or epoch milliseconds", name)
+		}
+		span = float64(upper.Sub(lower))
+		duration, ok := dateInterval(params)
+		if !ok {
+			return invalidQuery("date_histogram aggregation %s requires a calendar_interval or fixed_interval such as 1d", name)
+		}
+		interval = float64(duration)
+	}
+
+	if interval <= 0 {
+		return invalidQuery("%s aggregation %s requires a positive interval", aggType, name)
+	}
+	if span < 0 {
+		return invalidQuery("%s aggregation %s hard_bounds min must not be after max", aggType, name)
+	}
+	if buckets := math.Floor(span/interval) + 1; buckets > float64(s.cfg.MaxBuckets) {
+		return invalidQuery("%s aggregation %s would have %.0f buckets, more than %d", aggType, name, buckets, s.cfg.MaxBuckets)
+	}
+	return nil
+}
+
+// dateInterval returns the length of the calendar or fixed interval of a date histogram
+func dateInterval(params map[string]interface{}) (time.Duration, bool) {
+	if calendar, ok := params["calendar_interval"].(string); ok {
+		duration, ok := calendarIntervals[calendar]
+		return duration, ok
+	}
+	fixed, ok := params["fixed_interval"].(string)
+	if !ok {
+		return 0, false
+	}
+	for _, unit := range []string{"ms", "s", "m", "h", "d"} {
+		if value, ok := strings.CutSuffix(fixed, unit); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			return time.Duration(n) * fixedIntervalUnits[unit], true
+		}
+	}
+	return 0, false
+}
+
+// boundTime parses a date histogram bound given as a date, a date and time, or epoch milliseconds
+func boundTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case float64:
+		return time.UnixMilli(int64(v)), true
+	case string:
+		for _, layout := range []string{time.DateOnly, time.RFC3339} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// validateSort checks that sort entries are field names or objects naming a single field
+func validateSort(sort []interface{}) error {
+	for _, entry := range sort {
+		if _, ok := entry.(string); ok {
+			continue
+		}
+		if _, _, err := singleEntry(entry, "sort entry"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// singleEntry returns the only key of a JSON object and its value, which may be an object or a scalar.
+// A scalar value is returned as an empty object.
+func singleEntry(value interface{}, what string) (string, map[string]interface{}, error) {
+	object, ok := value.(map[string]interface{})
+	if !ok || len(object) != 1 {
+		return "", nil, invalidQuery("%s must be an object with a single key", what)
+	}
+	for key, entry := range object {
+		body, _ := entry.(map[string]interface{})
+		return key, body, nil
+	}
+	return "", nil, nil
+}
+
+// findKey reports the first of keys used anywhere in a decoded JSON value
+func findKey(value interface{}, keys []string) (string, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if slices.Contains(keys, key) {
+				return key, true
+			}
+			if found, ok := findKey(nested, keys); ok {
+				return found, true
+			}
+		}
+	case []interface{}:
+		for _, nested := range v {
+			if found, ok := findKey(nested, keys); ok {
+				return found, true
+			}
+		}
+	}
+	return "", false
+}
+
+// invalidQuery returns an error wrapping domain.ErrInvalidQuery
+func invalidQuery(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", domain.ErrInvalidQuery, fmt.Sprintf(format, args...))
+}
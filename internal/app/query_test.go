@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockQueryRunner records the search bodies it is asked to run
+type mockQueryRunner struct {
+	indexName string
+	body      map[string]interface{}
+	deadline  time.Time
+	result    domain.QueryResult
+}
+
+func (m *mockQueryRunner) RunQuery(ctx context.Context, indexName string, body map[string]interface{}) (domain.QueryResult, error) {
+	m.indexName = indexName
+	m.body = body
+	m.deadline, _ = ctx.Deadline()
+	return m.result, nil
+}
+
+var testQueryConfig = config.QueryConfig{MaxSize: 100, MaxFrom: 1000, MaxBuckets: 50, MaxDepth: 4, Timeout: 5 * time.Second}
+
+// decodeQuery decodes a query request the way the API handler does
+func decodeQuery(t *testing.T, body string) domain.QueryRequest {
+	var req domain.QueryRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &req))
+	return req
+}
+
+func TestQueryService_Query(t *testing.T) {
+	runner := &mockQueryRunner{result: domain.QueryResult{Total: 3}}
+	service := NewQueryServiceWithConfig(runner, "private", testQueryConfig)
+
+	result, err := service.Query(context.Background(), decodeQuery(t, `{
+		"query": {"bool": {
+			"filter": [{"term": {"conferenceSlug": "javazone2024"}}, {"range": {"length": {"gte": 45}}}],
+			"must_not": {"terms": {"status": ["REJECTED", "HISTORIC"]}},
+			"should": [{"nested": {"path": "speakers", "query": {"match": {"speakers.name": "Duke"}}}}],
+			"minimum_should_match": 1
+		}},
+		"aggs": {"formats": {"terms": {"field": "format", "size": 20}, "aggs": {"avgLength": {"avg": {"field": "length"}}}}},
+		"sort": ["lastUpdated", {"id": "asc"}],
+		"_source": ["id", "title"],
+		"size": 50,
+		"from": 100
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Total)
+
+	assert.Equal(t, "private", runner.indexName)
+	assert.Equal(t, 50, runner.body["size"])
+	assert.Equal(t, 100, runner.body["from"])
+	assert.Equal(t, "5000ms", runner.body["timeout"])
+	assert.Equal(t, true, runner.body["track_total_hits"])
+	assert.Contains(t, runner.body, "query")
+	assert.Contains(t, runner.body, "aggs")
+	assert.Equal(t, []string{"id", "title"}, runner.body["_source"])
+	assert.WithinDuration(t, time.Now().Add(6*time.Second), runner.deadline, time.Second)
+}
+
+func TestQueryService_QueryDefaults(t *testing.T) {
+	runner := &mockQueryRunner{}
+	service := NewQueryServiceWithConfig(runner, "private", testQueryConfig)
+
+	_, err := service.Query(context.Background(), domain.QueryRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultQuerySize, runner.body["size"])
+	assert.NotContains(t, runner.body, "query")
+}
+
+func TestQueryService_QueryHistograms(t *testing.T) {
+	runner := &mockQueryRunner{}
+	service := NewQueryServiceWithConfig(runner, "private", testQueryConfig)
+
+	_, err := service.Query(context.Background(), decodeQuery(t, `{"aggs": {
+		"lengths": {"histogram": {"field": "length", "interval": 15, "hard_bounds": {"min": 0, "max": 120}}},
+		"updates": {"date_histogram": {"field": "lastUpdated", "calendar_interval": "month", "hard_bounds": {"min": "2023-01-01", "max": 1735689600000}}},
+		"hours": {"date_histogram": {"field": "lastUpdated", "fixed_interval": "12h", "hard_bounds": {"min": "2024-09-01T00:00:00Z", "max": "2024-09-05T00:00:00Z"}}}
+	}}`))
+	require.NoError(t, err)
+	assert.Contains(t, runner.body, "aggs")
+}
+
+func TestQueryService_QueryRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+		message string
+	}{
+		{"size above limit", `{"size": 101}`, "size must be between 0 and 100"},
+		{"negative size", `{"size": -1}`, "size must be between 0 and 100"},
+		{"from above limit", `{"from": 1001}`, "from must be between 0 and 1000"},
+		{"script query", `{"query": {"script": {"script": "doc['length'].value > 1"}}}`, "script is not allowed"},
+		{"script in range", `{"query": {"bool": {"filter": {"range": {"length": {"gte": 1, "script": "x"}}}}}}`, "script is not allowed"},
+		{"script sort", `{"sort": [{"_script": {"type": "number"}}]}`, "_script is not allowed"},
+		{"script aggregation", `{"aggs": {"x": {"sum": {"script": "1"}}}}`, "script is not allowed"},
+		{"wildcard", `{"query": {"wildcard": {"title": "*java*"}}}`, "query clause wildcard is not allowed"},
+		{"query_string", `{"query": {"query_string": {"query": "title:java"}}}`, "query clause query_string is not allowed"},
+		{"terms lookup", `{"query": {"terms": {"id": {"index": "users", "id": "1", "path": "ids"}}}}`, "terms on id requires a list of values"},
+		{"two clauses in one object", `{"query": {"term": {"a": 1}, "match": {"b": 2}}}`, "query clause must be an object with a single key"},
+		{"unknown bool key", `{"query": {"bool": {"must": [], "filter_all": []}}}`, "bool does not support filter_all"},
+		{"nested too deep", `{"query": {"bool": {"must": {"bool": {"must": {"bool": {"must": {"bool": {"must": {"match_all": {}}}}}}}}}}}`, "query is nested deeper than 4 levels"},
+		{"nested in disallowed clause", `{"query": {"nested": {"path": "speakers", "query": {"regexp": {"speakers.name": ".*"}}}}}`, "query clause regexp is not allowed"},
+		{"too many buckets", `{"aggs": {"formats": {"terms": {"field": "format", "size": 51}}}}`, "terms aggregation formats size must be between 0 and 50"},
+		{"histogram without bounds", `{"aggs": {"lengths": {"histogram": {"field": "length", "interval": 1}}}}`, "histogram aggregation lengths requires hard_bounds with min and max"},
+		{"histogram with too many buckets", `{"aggs": {"lengths": {"histogram": {"field": "length", "interval": 1, "hard_bounds": {"min": 0, "max": 120}}}}}`, "histogram aggregation lengths would have 121 buckets, more than 50"},
+		{"histogram without interval", `{"aggs": {"lengths": {"histogram": {"field": "length", "hard_bounds": {"min": 0, "max": 120}}}}}`, "histogram aggregation lengths requires a positive interval"},
+		{"date histogram with too many buckets", `{"aggs": {"updates": {"date_histogram": {"field": "lastUpdated", "fixed_interval": "1m", "hard_bounds": {"min": "2024-01-01", "max": "2024-01-02"}}}}}`, "date_histogram aggregation updates would have 1441 buckets, more than 50"},
+		{"date histogram with calendar interval", `{"aggs": {"updates": {"date_histogram": {"field": "lastUpdated", "calendar_interval": "week", "hard_bounds": {"min": "2020-01-01", "max": "2024-01-01"}}}}}`, "would have 209 buckets"},
+		{"date histogram with date math bounds", `{"aggs": {"updates": {"date_histogram": {"field": "lastUpdated", "calendar_interval": "day", "hard_bounds": {"min": "now-1y", "max": "now"}}}}}`, "hard_bounds min and max must be dates"},
+		{"date histogram with legacy interval", `{"aggs": {"updates": {"date_histogram": {"field": "lastUpdated", "interval": "1d", "hard_bounds": {"min": "2024-01-01", "max": "2024-01-02"}}}}}`, "requires a calendar_interval or fixed_interval"},
+		{"disallowed aggregation", `{"aggs": {"top": {"top_hits": {}}}}`, "aggregation top of type top_hits is not allowed"},
+		{"aggregation without type", `{"aggs": {"x": {"aggs": {}}}}`, "aggregation x has no type"},
+		{"sub-aggregation of metric", `{"aggs": {"x": {"avg": {"field": "length"}, "aggs": {"y": {"max": {"field": "length"}}}}}}`, "metric aggregation x cannot have sub-aggregations"},
+		{"filter aggregation with disallowed query", `{"aggs": {"x": {"filter": {"fuzzy": {"title": "jva"}}}}}`, "query clause fuzzy is not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockQueryRunner{}
+			service := NewQueryServiceWithConfig(runner, "private", testQueryConfig)
+
+			_, err := service.Query(context.Background(), decodeQuery(t, tt.request))
+			require.ErrorIs(t, err, domain.ErrInvalidQuery)
+			assert.Contains(t, err.Error(), tt.message)
+			assert.Nil(t, runner.body, "rejected queries must not reach the index")
+		})
+	}
+}
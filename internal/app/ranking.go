@@ -0,0 +1,117 @@
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// noTrack names the talks without a track in exported rankings
+const noTrack = "No track"
+
+// rankingColumns are the columns of an exported ranking following the track
+var rankingColumns = []string{"rank", "score", "talk_id", "title", "status", "format", "speakers", "reviewers", "feedback_by", "conflicts"}
+
+// WriteRankingCSV writes the rankings with one row per talk, ordered by track and rank, for sorting and
+// annotating in a spreadsheet during the program selection
+func WriteRankingCSV(w io.Writer, rankings []domain.TrackRanking) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(append([]string{"track"}, rankingColumns...)); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, ranking := range rankings {
+		for _, ranked := range ranking.Talks {
+			row := []string{trackName(ranking.Track)}
+			for _, cell := range rankingRow(ranked) {
+				switch v := cell.(type) {
+				case nil:
+					row = append(row, "")
+				case float64:
+					row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+				default:
+					row = append(row, fmt.Sprint(v))
+				}
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("failed to write csv row: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteRankingXLSX writes the rankings as a workbook with a worksheet per track, where ranks and scores
+// are numbers
+func WriteRankingXLSX(w io.Writer, rankings []domain.TrackRanking) error {
+	header := make([]interface{}, len(rankingColumns))
+	for i, column := range rankingColumns {
+		header[i] = column
+	}
+
+	sheets := make([]xlsxSheet, 0, len(rankings))
+	for _, ranking := range rankings {
+		sheet := xlsxSheet{name: trackName(ranking.Track), rows: [][]interface{}{header}}
+		for _, ranked := range ranking.Talks {
+			sheet.rows = append(sheet.rows, rankingRow(ranked))
+		}
+		sheets = append(sheets, sheet)
+	}
+	if len(sheets) == 0 {
+		sheets = append(sheets, xlsxSheet{name: noTrack, rows: [][]interface{}{header}})
+	}
+
+	return writeXLSX(w, sheets)
+}
+
+// rankingRow returns the cells of the ranking columns of a talk, leaving the rank and score of unscored
+// talks empty
+func rankingRow(ranked domain.RankedTalk) []interface{} {
+	talk := ranked.Talk
+
+	var rank, score interface{}
+	if ranked.Rank > 0 {
+		rank = ranked.Rank
+	}
+	if s := talkScore(talk); s != nil {
+		score = *s
+	}
+
+	speakers := make([]string, 0, len(talk.Speakers))
+	for _, speaker := range talk.Speakers {
+		speakers = append(speakers, speaker.Name)
+	}
+
+	var review domain.ReviewAssignment
+	if talk.Review != nil {
+		review = *talk.Review
+	}
+
+	return []interface{}{
+		rank,
+		score,
+		talk.ID,
+		stringValue(talk.Data["title"]),
+		talk.Status,
+		stringValue(talk.Data["format"]),
+		strings.Join(speakers, "; "),
+		strings.Join(review.Reviewers, "; "),
+		strings.Join(review.FeedbackBy, "; "),
+		strings.Join(review.Conflicts, "; "),
+	}
+}
+
+// trackName returns the name of the track in exported rankings
+func trackName(track string) string {
+	if track == "" {
+		return noTrack
+	}
+	return track
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRankings returns rankings of two tracks, one of them without a name
+func testRankings() []domain.TrackRanking {
+	score := 2.5
+	return []domain.TrackRanking{
+		{Track: "Core Java", Talks: []domain.RankedTalk{
+			{Rank: 1, Talk: domain.Talk{
+				ID:       "talk-1",
+				Status:   "SUBMITTED",
+				Speakers: domain.Speakers{{ID: "s1", Name: "Grace Hopper"}, {ID: "s2", Name: "Alan Turing"}},
+				Data:     map[string]interface{}{"title": "Records & <patterns>", "format": "presentation"},
+				Review: &domain.ReviewAssignment{
+					Reviewers:  []string{"ada@javazone.no", "grace@javazone.no"},
+					FeedbackBy: []string{"ada@javazone.no"},
+					Conflicts:  []string{"grace@javazone.no"},
+					Score:      &score,
+				},
+			}},
+			{Talk: domain.Talk{ID: "talk-2", Status: "SUBMITTED", Data: map[string]interface{}{"title": "Unscored"}}},
+		}},
+		{Track: ""},
+	}
+}
+
+func TestWriteRankingCSV(t *testing.T) {
+	rankings := testRankings()
+	rankings[1].Talks = []domain.RankedTalk{{Talk: domain.Talk{ID: "talk-3", Status: "APPROVED", Data: map[string]interface{}{"title": "Keynote"}}}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRankingCSV(&buf, rankings))
+
+	assert.Equal(t, "track,rank,score,talk_id,title,status,format,speakers,reviewers,feedback_by,conflicts\n"+
+		"Core Java,1,2.5,talk-1,Records & <patterns>,SUBMITTED,presentation,Grace Hopper; Alan Turing,ada@javazone.no; grace@javazone.no,ada@javazone.no,grace@javazone.no\n"+
+		"Core Java,,,talk-2,Unscored,SUBMITTED,,,,,\n"+
+		"No track,,,talk-3,Keynote,APPROVED,,,,,\n", buf.String())
+}
+
+func TestWriteRankingXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteRankingXLSX(&buf, testRankings()))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		parts[f.Name] = string(content)
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "_rels/.rels")
+	assert.Contains(t, parts["[Content_Types].xml"], `PartName="/xl/worksheets/sheet2.xml"`)
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Core Java" sheetId="1" r:id="rId1"/><sheet name="No track" sheetId="2" r:id="rId2"/>`)
+	assert.Contains(t, parts["xl/_rels/workbook.xml.rels"], `Target="worksheets/sheet2.xml"`)
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">rank</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2"><v>1</v></c><c r="B2"><v>2.5</v></c>`)
+	assert.Contains(t, sheet, `<t xml:space="preserve">Records &amp; &lt;patterns&gt;</t>`)
+	assert.Contains(t, sheet, `<row r="3"><c r="C3" t="inlineStr">`, "the rank and score of unscored talks are empty")
+	assert.Contains(t, parts["xl/worksheets/sheet2.xml"], `<row r="1">`)
+}
+
+func TestSheetName(t *testing.T) {
+	used := make(map[string]bool)
+
+	assert.Equal(t, "Cloud - Infra", sheetName("Cloud / Infra", used))
+	assert.Equal(t, "cloud - infra (2)", sheetName("cloud - infra", used), "names are unique regardless of case")
+	assert.Equal(t, "Sheet", sheetName("'  '", used))
+	assert.Equal(t, "Architecture, design and method", sheetName("Architecture, design and methodology", used))
+	assert.Equal(t, "Architecture, design and me (2)", sheetName("Architecture, design and methodology", used))
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// RegistrationService keeps the capacity and registration counts of workshops from the registration
+// system on their indexed talks. Each sync patches the talks whose counts changed since the previous one
+// with partial updates, and the indexer applies the latest counts whenever it rewrites a talk, so a
+// reindex does not drop them. The counts are kept in memory, so the first sync after a restart patches
+// every workshop.
+type RegistrationService struct {
+	source       ports.RegistrationSource
+	patcher      ports.TalkPatcher
+	privateIndex string
+	publicIndex  string
+	logger       *slog.Logger
+
+	mu    sync.RWMutex
+	seats map[string]domain.WorkshopSeats
+}
+
+// NewRegistrationService creates a new RegistrationService, receiving context as first parameter
+// to retrieve configuration.
+func NewRegistrationService(ctx context.Context, source ports.RegistrationSource, patcher ports.TalkPatcher) *RegistrationService {
+	cfg := config.GetConfig(ctx)
+	return NewRegistrationServiceWithConfig(source, patcher, cfg.Index.PrivateName(), cfg.Index.PublicName())
+}
+
+// NewRegistrationServiceWithConfig creates a new RegistrationService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewRegistrationServiceWithConfig(source ports.RegistrationSource, patcher ports.TalkPatcher, privateIndex, publicIndex string) *RegistrationService {
+	return &RegistrationService{
+		source:       source,
+		patcher:      patcher,
+		privateIndex: privateIndex,
+		publicIndex:  publicIndex,
+		logger:       slog.Default().With("component", "registration"),
+	}
+}
+
+// SyncRegistrations reads the registration counts and patches the workshops whose counts changed into
+// both indexes. Workshops no longer listed by the registration system have their counts cleared.
+// Talks missing from an index, such as workshops not yet approved, are skipped; the indexer adds the
+// counts when it writes them. Workshops that fail to patch are retried on the next sync.
+func (s *RegistrationService) SyncRegistrations(ctx context.Context) error {
+	registrations, err := s.source.ListRegistrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list workshop registrations: %w", err)
+	}
+
+	s.mu.RLock()
+	previous := s.seats
+	s.mu.RUnlock()
+
+	current := make(map[string]domain.WorkshopSeats, len(registrations))
+	for _, registration := range registrations {
+		current[registration.TalkID] = registration.Seats()
+	}
+
+	var patched, failed []string
+	for _, talkID := range slices.Sorted(maps.Keys(current)) {
+		seats := current[talkID]
+		if old, ok := previous[talkID]; ok && old == seats {
+			continue
+		}
+		if err := s.patch(ctx, talkID, &seats); err != nil {
+			s.logger.ErrorContext(ctx, "failed to patch workshop registrations", "talkID", talkID, "error", err)
+			delete(current, talkID)
+			failed = append(failed, talkID)
+			continue
+		}
+		patched = append(patched, talkID)
+	}
+	for _, talkID := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := current[talkID]; ok || slices.Contains(failed, talkID) {
+			continue
+		}
+		if err := s.patch(ctx, talkID, nil); err != nil {
+			s.logger.ErrorContext(ctx, "failed to clear workshop registrations", "talkID", talkID, "error", err)
+			current[talkID] = previous[talkID]
+			failed = append(failed, talkID)
+			continue
+		}
+		patched = append(patched, talkID)
+	}
+
+	s.mu.Lock()
+	s.seats = current
+	s.mu.Unlock()
+
+	s.logger.InfoContext(ctx, "synced workshop registrations", "workshops", len(registrations), "patched", len(patched), "failed", len(failed))
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to patch registrations of %d workshop(s): %v", len(failed), failed)
+	}
+	return nil
+}
+
+// patch writes the seats into the talk in both indexes, or clears them if seats is nil
+func (s *RegistrationService) patch(ctx context.Context, talkID string, seats *domain.WorkshopSeats) error {
+	doc := map[string]interface{}{"workshop": seats}
+	for _, indexName := range []string{s.privateIndex, s.publicIndex} {
+		err := s.patcher.PatchTalk(ctx, indexName, talkID, doc)
+		if errors.Is(err, domain.ErrTalkNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to patch %s: %w", indexName, err)
+		}
+	}
+	return nil
+}
+
+// ApplySeats is a TalkTransform setting the latest known registration counts on a workshop
+func (s *RegistrationService) ApplySeats(talk domain.Talk) domain.Talk {
+	s.mu.RLock()
+	seats, ok := s.seats[talk.ID]
+	s.mu.RUnlock()
+
+	if ok {
+		talk.Workshop = &seats
+	}
+	return talk
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRegistrationSource is a mock implementation of ports.RegistrationSource
+type mockRegistrationSource struct {
+	registrations []domain.WorkshopRegistration
+	err           error
+}
+
+func (m *mockRegistrationSource) ListRegistrations(ctx context.Context) ([]domain.WorkshopRegistration, error) {
+	return m.registrations, m.err
+}
+
+// recordingPatcher is a ports.TalkPatcher recording each patch as "index/talk" with the patched workshop
+type recordingPatcher struct {
+	patches  map[string]interface{}
+	patchErr func(indexName, talkID string) error
+}
+
+func (m *recordingPatcher) PatchTalk(ctx context.Context, indexName string, talkID string, doc map[string]interface{}) error {
+	if m.patchErr != nil {
+		if err := m.patchErr(indexName, talkID); err != nil {
+			return err
+		}
+	}
+	if m.patches == nil {
+		m.patches = make(map[string]interface{})
+	}
+	m.patches[indexName+"/"+talkID] = doc["workshop"]
+	return nil
+}
+
+func TestSyncRegistrations(t *testing.T) {
+	source := &mockRegistrationSource{registrations: []domain.WorkshopRegistration{
+		{TalkID: "talk-1", Capacity: 30, Registered: 28},
+		{TalkID: "talk-2", Capacity: 20, Registered: 25},
+	}}
+	patcher := &recordingPatcher{}
+	service := NewRegistrationServiceWithConfig(source, patcher, "private", "public")
+	ctx := context.Background()
+
+	require.NoError(t, service.SyncRegistrations(ctx))
+	assert.Equal(t, map[string]interface{}{
+		"private/talk-1": &domain.WorkshopSeats{Capacity: 30, Registered: 28, SpotsLeft: 2},
+		"public/talk-1":  &domain.WorkshopSeats{Capacity: 30, Registered: 28, SpotsLeft: 2},
+		"private/talk-2": &domain.WorkshopSeats{Capacity: 20, Registered: 25, SpotsLeft: 0},
+		"public/talk-2":  &domain.WorkshopSeats{Capacity: 20, Registered: 25, SpotsLeft: 0},
+	}, patcher.patches)
+
+	t.Run("only changed workshops are patched", func(t *testing.T) {
+		patcher.patches = nil
+		source.registrations = []domain.WorkshopRegistration{
+			{TalkID: "talk-1", Capacity: 30, Registered: 29},
+			{TalkID: "talk-2", Capacity: 20, Registered: 25},
+		}
+
+		require.NoError(t, service.SyncRegistrations(ctx))
+		assert.Equal(t, []string{"private/talk-1", "public/talk-1"}, slices.Sorted(maps.Keys(patcher.patches)))
+	})
+
+	t.Run("workshops no longer listed are cleared", func(t *testing.T) {
+		patcher.patches = nil
+		source.registrations = []domain.WorkshopRegistration{{TalkID: "talk-1", Capacity: 30, Registered: 29}}
+
+		require.NoError(t, service.SyncRegistrations(ctx))
+		assert.Equal(t, []string{"private/talk-2", "public/talk-2"}, slices.Sorted(maps.Keys(patcher.patches)))
+		assert.Nil(t, patcher.patches["public/talk-2"].(*domain.WorkshopSeats))
+	})
+}
+
+func TestSyncRegistrations_MissingAndFailedTalks(t *testing.T) {
+	source := &mockRegistrationSource{registrations: []domain.WorkshopRegistration{
+		{TalkID: "talk-1", Capacity: 30, Registered: 10},
+		{TalkID: "talk-2", Capacity: 20, Registered: 5},
+	}}
+	failing := true
+	patcher := &recordingPatcher{patchErr: func(indexName, talkID string) error {
+		if talkID == "talk-1" && indexName == "public" {
+			return fmt.Errorf("%w: talk-1", domain.ErrTalkNotFound)
+		}
+		if talkID == "talk-2" && failing {
+			return errors.New("cluster unavailable")
+		}
+		return nil
+	}}
+	service := NewRegistrationServiceWithConfig(source, patcher, "private", "public")
+	ctx := context.Background()
+
+	err := service.SyncRegistrations(ctx)
+	assert.ErrorContains(t, err, "talk-2")
+	assert.Equal(t, []string{"private/talk-1"}, slices.Sorted(maps.Keys(patcher.patches)), "talks missing from an index are skipped")
+
+	// The failed workshop is patched on the next sync, the other one is unchanged
+	failing = false
+	patcher.patches = nil
+	require.NoError(t, service.SyncRegistrations(ctx))
+	assert.Equal(t, []string{"private/talk-2", "public/talk-2"}, slices.Sorted(maps.Keys(patcher.patches)))
+}
+
+func TestSyncRegistrations_SourceError(t *testing.T) {
+	service := NewRegistrationServiceWithConfig(&mockRegistrationSource{err: errors.New("connection refused")}, &recordingPatcher{}, "private", "public")
+
+	err := service.SyncRegistrations(context.Background())
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestApplySeats(t *testing.T) {
+	source := &mockRegistrationSource{registrations: []domain.WorkshopRegistration{{TalkID: "talk-1", Capacity: 30, Registered: 28}}}
+	service := NewRegistrationServiceWithConfig(source, &recordingPatcher{}, "private", "public")
+	require.NoError(t, service.SyncRegistrations(context.Background()))
+
+	index := &mockSearchIndex{}
+	talkSource := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "APPROVED"}, nil
+		},
+	}
+	indexer := NewIndexerServiceWithConfig(talkSource, index, "private", "public", testPrivateMapping, testPublicMapping)
+	indexer.AddTransform(service.ApplySeats)
+
+	require.NoError(t, indexer.ReindexTalk(context.Background(), "talk-1"))
+	require.Len(t, index.bulkIndexCalls, 2)
+	for _, call := range index.bulkIndexCalls {
+		assert.Equal(t, &domain.WorkshopSeats{Capacity: 30, Registered: 28, SpotsLeft: 2}, call.Talks[0].Workshop, call.IndexName)
+	}
+
+	assert.Nil(t, service.ApplySeats(domain.Talk{ID: "talk-2"}).Workshop, "talks without registrations are unchanged")
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// reindexStatusKey is the settings key of the status document holding the last successful reindex of
+// each index
+const reindexStatusKey = "reindex:status"
+
+// ReindexStatusService records the last successful reindex of each index in a status document, so that
+// every instance, also after a restart, can tell how fresh the indexes are. Full and conference
+// reindexes and republishes count; single talk updates do not. As a health check it reports the
+// indexes as degraded once a reindex is older than HEALTH_REINDEX_MAX_AGE.
+type ReindexStatusService struct {
+	store   ports.SettingsStore
+	indexes domain.IndexNames
+	maxAge  time.Duration
+	now     func() time.Time
+	logger  *slog.Logger
+
+	mu sync.Mutex
+}
+
+// NewReindexStatusService creates a new ReindexStatusService, receiving context as first parameter
+// to retrieve configuration.
+func NewReindexStatusService(ctx context.Context, store ports.SettingsStore) *ReindexStatusService {
+	cfg := config.GetConfig(ctx)
+	indexes := domain.IndexNames{Private: cfg.Index.PrivateName(), Public: cfg.Index.PublicName()}
+	return NewReindexStatusServiceWithConfig(store, indexes, cfg.Health.ReindexMaxAge)
+}
+
+// NewReindexStatusServiceWithConfig creates a new ReindexStatusService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewReindexStatusServiceWithConfig(store ports.SettingsStore, indexes domain.IndexNames, maxAge time.Duration) *ReindexStatusService {
+	return &ReindexStatusService{
+		store:   store,
+		indexes: indexes,
+		maxAge:  maxAge,
+		now:     time.Now,
+		logger:  slog.Default().With("component", "reindex-status"),
+	}
+}
+
+// SetClock replaces the system clock the age of the reindexes is measured with
+func (s *ReindexStatusService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// HandleIndexEvent records the indexes written by a succeeded reindex job or swapped in by a republish
+func (s *ReindexStatusService) HandleIndexEvent(ctx context.Context, event domain.IndexEvent) {
+	var statuses []domain.ReindexStatus
+	switch e := event.(type) {
+	case domain.ReindexJobFinished:
+		job := e.Job
+		if job.State != domain.JobStateSucceeded || job.Scope.Kind == domain.JobKindReindexTalk {
+			return
+		}
+		finishedAt := s.now()
+		if job.FinishedAt != nil {
+			finishedAt = *job.FinishedAt
+		}
+		for index, documents := range job.Report.Indexed {
+			statuses = append(statuses, domain.ReindexStatus{
+				Index:      index,
+				Scope:      job.Scope,
+				JobID:      job.ID,
+				Actor:      job.Actor,
+				FinishedAt: finishedAt.UTC(),
+				Documents:  documents,
+			})
+		}
+	case domain.IndexSwapped:
+		for alias := range e.Indexes {
+			documents := e.PrivateTalks
+			if alias == s.indexes.Public {
+				documents = e.PublicTalks
+			}
+			statuses = append(statuses, domain.ReindexStatus{
+				Index:      alias,
+				Scope:      domain.JobScope{Kind: domain.JobKindRepublish, Target: e.Generation},
+				Actor:      e.Actor,
+				FinishedAt: s.now().UTC(),
+				Documents:  documents,
+			})
+		}
+	default:
+		return
+	}
+	if len(statuses) == 0 {
+		return
+	}
+
+	if err := s.record(ctx, statuses); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record reindex status", "error", err)
+	}
+}
+
+// record merges the statuses into the status document
+func (s *ReindexStatusService) record(ctx context.Context, statuses []domain.ReindexStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	recorded := make(map[string]domain.ReindexStatus)
+	if _, err := s.store.LoadSetting(ctx, reindexStatusKey, &recorded); err != nil {
+		return fmt.Errorf("failed to load reindex status: %w", err)
+	}
+	for _, status := range statuses {
+		status.Stale = false
+		recorded[status.Index] = status
+	}
+	if err := s.store.SaveSetting(ctx, reindexStatusKey, recorded); err != nil {
+		return fmt.Errorf("failed to save reindex status: %w", err)
+	}
+	return nil
+}
+
+// ReindexStatuses returns the last successful reindex of the private and public index, marking those
+// older than the maximum age as stale
+func (s *ReindexStatusService) ReindexStatuses(ctx context.Context) ([]domain.ReindexStatus, error) {
+	recorded := make(map[string]domain.ReindexStatus)
+	if _, err := s.store.LoadSetting(ctx, reindexStatusKey, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to load reindex status: %w", err)
+	}
+
+	now := s.now()
+	var statuses []domain.ReindexStatus
+	for _, index := range []string{s.indexes.Private, s.indexes.Public} {
+		status, ok := recorded[index]
+		if !ok {
+			continue
+		}
+		status.Stale = s.maxAge > 0 && status.Age(now) > s.maxAge
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CheckHealth reports when each index was last reindexed, degraded if a reindex is older than the
+// maximum age. Indexes without a recorded reindex, such as right after upgrading, are not reported as
+// stale, so a missing status document cannot take every instance out of service.
+func (s *ReindexStatusService) CheckHealth(ctx context.Context) domain.DependencyHealth {
+	start := time.Now()
+	health := domain.DependencyHealth{Name: "reindex", Status: domain.HealthStatusOK}
+
+	statuses, err := s.ReindexStatuses(ctx)
+	health.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Status = domain.HealthStatusError
+		health.Error = err.Error()
+		return health
+	}
+
+	now := s.now()
+	details := make(map[string]interface{}, len(statuses))
+	for _, status := range statuses {
+		details[status.Index] = map[string]interface{}{
+			"finishedAt": status.FinishedAt,
+			"ageSeconds": int64(status.Age(now).Seconds()),
+			"scope":      status.Scope,
+			"documents":  status.Documents,
+		}
+		if status.Stale && health.Status == domain.HealthStatusOK {
+			health.Status = domain.HealthStatusDegraded
+			health.Error = fmt.Sprintf("%s was last reindexed %s ago, more than %s",
+				status.Index, status.Age(now).Truncate(time.Minute), s.maxAge)
+		}
+	}
+	health.Details = details
+	return health
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindexStatusService_RecordsSucceededReindexes(t *testing.T) {
+	now := time.Date(2025, 9, 3, 8, 0, 0, 0, time.UTC)
+	finished := now.Add(-time.Hour)
+	indexes := domain.IndexNames{Private: "private", Public: "public"}
+	store := newMockSettingsStore()
+	service := NewReindexStatusServiceWithConfig(store, indexes, 0)
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: domain.Job{
+		ID:         "job-1",
+		Scope:      domain.JobScope{Kind: domain.JobKindReindexAll},
+		Actor:      domain.SchedulerActor,
+		State:      domain.JobStateSucceeded,
+		FinishedAt: &finished,
+		Report:     domain.JobReport{Indexed: map[string]int{"private": 120, "public": 100}},
+	}})
+
+	// Failed reindexes and single talk updates do not make the indexes fresh
+	service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: domain.Job{
+		ID: "job-2", Scope: domain.JobScope{Kind: domain.JobKindReindexAll}, State: domain.JobStateFailed,
+		FinishedAt: &now, Report: domain.JobReport{Indexed: map[string]int{"private": 3}},
+	}})
+	service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: domain.Job{
+		ID: "job-3", Scope: domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}, State: domain.JobStateSucceeded,
+		FinishedAt: &now, Report: domain.JobReport{Indexed: map[string]int{"private": 1, "public": 1}},
+	}})
+
+	// A restarted instance reads the statuses from the status document
+	restarted := NewReindexStatusServiceWithConfig(store, indexes, 0)
+	statuses, err := restarted.ReindexStatuses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ReindexStatus{
+		{Index: "private", Scope: domain.JobScope{Kind: domain.JobKindReindexAll}, JobID: "job-1", Actor: domain.SchedulerActor, FinishedAt: finished, Documents: 120},
+		{Index: "public", Scope: domain.JobScope{Kind: domain.JobKindReindexAll}, JobID: "job-1", Actor: domain.SchedulerActor, FinishedAt: finished, Documents: 100},
+	}, statuses)
+
+	// A republish replaces the status of both indexes
+	service.HandleIndexEvent(ctx, domain.IndexSwapped{
+		Generation:   "g2",
+		Indexes:      map[string]string{"private": "private-g2", "public": "public-g2"},
+		PrivateTalks: 121,
+		PublicTalks:  101,
+	})
+	statuses, err = service.ReindexStatuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, domain.JobScope{Kind: domain.JobKindRepublish, Target: "g2"}, statuses[1].Scope)
+	assert.Equal(t, 101, statuses[1].Documents)
+	assert.Equal(t, now, statuses[1].FinishedAt)
+}
+
+func TestReindexStatusService_CheckHealth(t *testing.T) {
+	now := time.Date(2025, 9, 3, 8, 0, 0, 0, time.UTC)
+	indexes := domain.IndexNames{Private: "private", Public: "public"}
+	ctx := context.Background()
+
+	newService := func(maxAge time.Duration, finished ...time.Time) (*ReindexStatusService, *mockSettingsStore) {
+		store := newMockSettingsStore()
+		service := NewReindexStatusServiceWithConfig(store, indexes, maxAge)
+		service.now = func() time.Time { return now }
+		for _, at := range finished {
+			service.HandleIndexEvent(ctx, domain.ReindexJobFinished{Job: domain.Job{
+				Scope: domain.JobScope{Kind: domain.JobKindReindexAll}, State: domain.JobStateSucceeded,
+				FinishedAt: &at, Report: domain.JobReport{Indexed: map[string]int{"private": 1, "public": 1}},
+			}})
+		}
+		return service, store
+	}
+
+	t.Run("fresh", func(t *testing.T) {
+		service, _ := newService(24*time.Hour, now.Add(-2*time.Hour))
+		health := service.CheckHealth(ctx)
+		assert.Equal(t, domain.HealthStatusOK, health.Status)
+		assert.Len(t, health.Details, 2)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		service, _ := newService(24*time.Hour, now.Add(-30*time.Hour))
+		health := service.CheckHealth(ctx)
+		assert.Equal(t, domain.HealthStatusDegraded, health.Status)
+		assert.Contains(t, health.Error, "30h0m0s ago")
+
+		statuses, err := service.ReindexStatuses(ctx)
+		require.NoError(t, err)
+		assert.True(t, statuses[0].Stale)
+	})
+
+	t.Run("no maximum age", func(t *testing.T) {
+		service, _ := newService(0, now.AddDate(0, -1, 0))
+		assert.Equal(t, domain.HealthStatusOK, service.CheckHealth(ctx).Status)
+	})
+
+	t.Run("never recorded", func(t *testing.T) {
+		service, _ := newService(24 * time.Hour)
+		health := service.CheckHealth(ctx)
+		assert.Equal(t, domain.HealthStatusOK, health.Status, "a missing status document does not take instances out of service")
+		assert.Empty(t, health.Details)
+	})
+
+	t.Run("status document unavailable", func(t *testing.T) {
+		service, store := newService(24 * time.Hour)
+		store.loadErr = errors.New("cluster unavailable")
+		health := service.CheckHealth(ctx)
+		assert.Equal(t, domain.HealthStatusError, health.Status)
+		assert.Contains(t, health.Error, "cluster unavailable")
+	})
+}
@@ -0,0 +1,332 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// yearPattern matches a four digit year in a conference slug or name
+var yearPattern = regexp.MustCompile(`(19|20)\d{2}`)
+
+// ReportService builds reports from the data stored in the private index
+type ReportService struct {
+	reader       ports.TalkReader
+	privateIndex string
+	rules        AnonymizationRules
+	logger       *slog.Logger
+}
+
+// NewReportService creates a new ReportService, receiving context as first parameter
+// to retrieve configuration.
+func NewReportService(ctx context.Context, reader ports.TalkReader) *ReportService {
+	cfg := config.GetConfig(ctx)
+	return NewReportServiceWithConfig(reader, cfg.Index.PrivateName(), NewAnonymizationRules(cfg.Anonymize))
+}
+
+// NewReportServiceWithConfig creates a new ReportService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewReportServiceWithConfig(reader ports.TalkReader, privateIndex string, rules AnonymizationRules) *ReportService {
+	return &ReportService{
+		reader:       reader,
+		privateIndex: privateIndex,
+		rules:        rules,
+		logger:       slog.Default().With("component", "report"),
+	}
+}
+
+// Statistics reads all talks from the private index and aggregates them per conference.
+// Conferences are ordered by year and then by slug so keyword trends read chronologically.
+func (s *ReportService) Statistics(ctx context.Context) (*domain.StatisticsReport, error) {
+	talks, err := s.reader.FetchTalks(ctx, s.privateIndex, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from private index: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "building statistics report", "talks", len(talks))
+
+	byConference := make(map[string]*domain.ConferenceStatistics)
+	var order []string
+
+	for _, talk := range talks {
+		stats, ok := byConference[talk.ConferenceSlug]
+		if !ok {
+			stats = &domain.ConferenceStatistics{
+				ConferenceSlug: talk.ConferenceSlug,
+				ConferenceName: talk.ConferenceName,
+				Year:           conferenceYear(talk),
+				ByStatus:       make(map[string]int),
+				ByFormat:       make(map[string]int),
+				Keywords:       make(map[string]int),
+			}
+			byConference[talk.ConferenceSlug] = stats
+			order = append(order, talk.ConferenceSlug)
+		}
+		addTalkToStatistics(stats, talk)
+	}
+
+	report := &domain.StatisticsReport{
+		GeneratedAt: time.Now().UTC(),
+		Index:       s.privateIndex,
+		Conferences: make([]domain.ConferenceStatistics, 0, len(order)),
+	}
+	for _, slug := range order {
+		stats := byConference[slug]
+		stats.AcceptanceRate = acceptanceRate(stats.ByStatus)
+		report.Conferences = append(report.Conferences, *stats)
+	}
+
+	sort.Slice(report.Conferences, func(i, j int) bool {
+		a, b := report.Conferences[i], report.Conferences[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		return a.ConferenceSlug < b.ConferenceSlug
+	})
+
+	return report, nil
+}
+
+// AnonymizedDataset reads all talks from the private index and returns them with speaker
+// identity and private free-text fields removed according to the anonymization rules.
+func (s *ReportService) AnonymizedDataset(ctx context.Context) ([]domain.AnonymizedTalk, error) {
+	talks, err := s.reader.FetchTalks(ctx, s.privateIndex, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from private index: %w", err)
+	}
+
+	dataset := make([]domain.AnonymizedTalk, 0, len(talks))
+	for _, talk := range talks {
+		if !s.rules.Includes(talk.Status) {
+			continue
+		}
+		dataset = append(dataset, s.rules.Anonymize(talk))
+	}
+
+	s.logger.InfoContext(ctx, "built anonymized dataset", "talks", len(talks), "exported", len(dataset))
+	return dataset, nil
+}
+
+// SpeakerContacts reads the approved talks of the conference from the private index and lists their
+// speakers with contact emails, ordered by name. A speaker of several talks is listed once with all
+// their talk titles; speakers without an email are listed with an empty one so they can be followed up.
+func (s *ReportService) SpeakerContacts(ctx context.Context, conferenceSlug string) ([]domain.SpeakerContact, error) {
+	talks, err := s.reader.FetchTalks(ctx, s.privateIndex, conferenceSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from private index: %w", err)
+	}
+
+	bySpeaker := make(map[string]*domain.SpeakerContact)
+	for _, talk := range talks {
+		if domain.TalkStatus(talk.Status) != domain.StatusApproved {
+			continue
+		}
+		for _, speaker := range talk.Speakers {
+			email := speakerEmail(speaker)
+
+			// Speakers are matched by email, as the same person may have a speaker ID per talk
+			key := strings.ToLower(email)
+			if key == "" {
+				key = "id:" + speaker.ID
+			}
+			contact, ok := bySpeaker[key]
+			if !ok {
+				contact = &domain.SpeakerContact{
+					ConferenceSlug: talk.ConferenceSlug,
+					SpeakerID:      speaker.ID,
+					Name:           speaker.Name,
+					Email:          email,
+				}
+				bySpeaker[key] = contact
+			}
+			contact.Talks = append(contact.Talks, stringValue(talk.Data["title"]))
+		}
+	}
+
+	contacts := make([]domain.SpeakerContact, 0, len(bySpeaker))
+	for _, contact := range bySpeaker {
+		contacts = append(contacts, *contact)
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		if contacts[i].Name != contacts[j].Name {
+			return contacts[i].Name < contacts[j].Name
+		}
+		return contacts[i].Email < contacts[j].Email
+	})
+
+	s.logger.InfoContext(ctx, "built speaker contacts", "conference", conferenceSlug, "talks", len(talks), "speakers", len(contacts))
+	return contacts, nil
+}
+
+// speakerEmail returns the contact email of a speaker, which the private index stores in the speaker data
+func speakerEmail(speaker domain.Speaker) string {
+	if email := stringValue(speaker.PrivateData["email"]); email != "" {
+		return email
+	}
+	return stringValue(speaker.Data["email"])
+}
+
+// addTalkToStatistics adds a single talk to the running conference statistics
+func addTalkToStatistics(stats *domain.ConferenceStatistics, talk domain.Talk) {
+	stats.Total++
+	stats.ByStatus[talk.Status]++
+
+	if format, ok := talk.Data["format"].(string); ok && format != "" {
+		stats.ByFormat[format]++
+	} else {
+		stats.ByFormat["unknown"]++
+	}
+
+	for _, keyword := range stringSlice(talk.Data["keywords"]) {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword != "" {
+			stats.Keywords[keyword]++
+		}
+	}
+
+	for _, speaker := range talk.Speakers {
+		gender, ok := speaker.Data["gender"].(string)
+		if !ok || gender == "" {
+			continue
+		}
+		if stats.ByGender == nil {
+			stats.ByGender = make(map[string]int)
+		}
+		stats.ByGender[strings.ToLower(gender)]++
+	}
+}
+
+// acceptanceRate returns the share of non-draft submissions that were approved
+func acceptanceRate(byStatus map[string]int) float64 {
+	considered := 0
+	for status, count := range byStatus {
+		if domain.TalkStatus(status) != domain.StatusDraft {
+			considered += count
+		}
+	}
+	if considered == 0 {
+		return 0
+	}
+	return float64(byStatus[string(domain.StatusApproved)]) / float64(considered)
+}
+
+// conferenceYear derives the conference year from its slug or name,
+// falling back to the year the talk was created
+func conferenceYear(talk domain.Talk) int {
+	for _, s := range []string{talk.ConferenceSlug, talk.ConferenceName} {
+		if match := yearPattern.FindString(s); match != "" {
+			year, _ := strconv.Atoi(match)
+			return year
+		}
+	}
+	if talk.Created != nil {
+		return talk.Created.Year()
+	}
+	return 0
+}
+
+// stringSlice converts a decoded JSON value into a slice of strings
+func stringSlice(v interface{}) []string {
+	switch values := v.(type) {
+	case []string:
+		return values
+	case []interface{}:
+		result := make([]string, 0, len(values))
+		for _, item := range values {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	case string:
+		return []string{values}
+	}
+	return nil
+}
+
+// WriteStatisticsCSV writes the statistics report in long format
+// (one row per conference, dimension and key) which pivots easily in a spreadsheet.
+func WriteStatisticsCSV(w io.Writer, report *domain.StatisticsReport) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"conference", "conference_name", "year", "dimension", "key", "value"}); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, stats := range report.Conferences {
+		year := ""
+		if stats.Year != 0 {
+			year = strconv.Itoa(stats.Year)
+		}
+		row := func(dimension, key, value string) error {
+			return writer.Write([]string{stats.ConferenceSlug, stats.ConferenceName, year, dimension, key, value})
+		}
+
+		if err := row("total", "", strconv.Itoa(stats.Total)); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+		if err := row("acceptance_rate", "", strconv.FormatFloat(stats.AcceptanceRate, 'f', 4, 64)); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+
+		dimensions := []struct {
+			name   string
+			values map[string]int
+		}{
+			{"status", stats.ByStatus},
+			{"format", stats.ByFormat},
+			{"gender", stats.ByGender},
+			{"keyword", stats.Keywords},
+		}
+		for _, dim := range dimensions {
+			for _, key := range sortedKeys(dim.values) {
+				if err := row(dim.name, key, strconv.Itoa(dim.values[key])); err != nil {
+					return fmt.Errorf("failed to write csv row: %w", err)
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteSpeakerContactsCSV writes the speaker contacts with one row per speaker, ready for a mail merge
+func WriteSpeakerContactsCSV(w io.Writer, contacts []domain.SpeakerContact) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"conference", "speaker_id", "name", "email", "talks"}); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, contact := range contacts {
+		row := []string{contact.ConferenceSlug, contact.SpeakerID, contact.Name, contact.Email, strings.Join(contact.Talks, "; ")}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// sortedKeys returns the keys of the map in sorted order
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,205 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTalkReader is a mock implementation of ports.TalkReader
+type mockTalkReader struct {
+	fetchTalksFunc func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error)
+	fetchCalls     []string
+	conferences    []domain.ConferenceSummary
+}
+
+func (m *mockTalkReader) FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+	m.fetchCalls = append(m.fetchCalls, indexName)
+	if m.fetchTalksFunc != nil {
+		return m.fetchTalksFunc(ctx, indexName, conferenceSlug)
+	}
+	return nil, nil
+}
+
+func (m *mockTalkReader) ListConferences(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error) {
+	return m.conferences, nil
+}
+
+func TestNewReportService(t *testing.T) {
+	reader := &mockTalkReader{}
+	service := NewReportServiceWithConfig(reader, "private", AnonymizationRules{})
+
+	assert.NotNil(t, service)
+	assert.Equal(t, reader, service.reader)
+	assert.Equal(t, "private", service.privateIndex)
+}
+
+func TestStatistics_AggregatesPerConference(t *testing.T) {
+	talks := []domain.Talk{
+		{
+			ID: "talk-1", ConferenceSlug: "javazone2024", ConferenceName: "JavaZone 2024", Status: "APPROVED",
+			Data: map[string]interface{}{"format": "presentation", "keywords": []interface{}{"Java", "kotlin"}},
+			Speakers: domain.Speakers{
+				{ID: "s1", Data: map[string]interface{}{"gender": "Female"}},
+				{ID: "s2", Data: map[string]interface{}{"gender": "male"}},
+			},
+		},
+		{
+			ID: "talk-2", ConferenceSlug: "javazone2024", ConferenceName: "JavaZone 2024", Status: "REJECTED",
+			Data: map[string]interface{}{"format": "lightning-talk", "keywords": []interface{}{"java"}},
+		},
+		{
+			ID: "talk-3", ConferenceSlug: "javazone2024", ConferenceName: "JavaZone 2024", Status: "DRAFT",
+			Data: map[string]interface{}{},
+		},
+		{
+			ID: "talk-4", ConferenceSlug: "javazone2023", ConferenceName: "JavaZone 2023", Status: "APPROVED",
+			Data: map[string]interface{}{"format": "workshop"},
+		},
+	}
+
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return talks, nil
+		},
+	}
+
+	service := NewReportServiceWithConfig(reader, "private", AnonymizationRules{})
+	report, err := service.Statistics(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"private"}, reader.fetchCalls)
+	assert.Equal(t, "private", report.Index)
+	require.Len(t, report.Conferences, 2)
+
+	// Conferences are ordered chronologically
+	older := report.Conferences[0]
+	assert.Equal(t, "javazone2023", older.ConferenceSlug)
+	assert.Equal(t, 2023, older.Year)
+	assert.Equal(t, 1, older.Total)
+	assert.Equal(t, 1.0, older.AcceptanceRate)
+	assert.Nil(t, older.ByGender)
+
+	stats := report.Conferences[1]
+	assert.Equal(t, "javazone2024", stats.ConferenceSlug)
+	assert.Equal(t, 2024, stats.Year)
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, map[string]int{"APPROVED": 1, "REJECTED": 1, "DRAFT": 1}, stats.ByStatus)
+	assert.Equal(t, map[string]int{"presentation": 1, "lightning-talk": 1, "unknown": 1}, stats.ByFormat)
+	assert.Equal(t, map[string]int{"female": 1, "male": 1}, stats.ByGender)
+	assert.Equal(t, map[string]int{"java": 2, "kotlin": 1}, stats.Keywords)
+
+	// Drafts are excluded from the acceptance rate
+	assert.Equal(t, 0.5, stats.AcceptanceRate)
+}
+
+func TestStatistics_FetchError(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return nil, errors.New("index unavailable")
+		},
+	}
+
+	service := NewReportServiceWithConfig(reader, "private", AnonymizationRules{})
+	report, err := service.Statistics(context.Background())
+
+	require.Error(t, err)
+	assert.Nil(t, report)
+	assert.Contains(t, err.Error(), "failed to fetch talks from private index")
+}
+
+func TestConferenceYear(t *testing.T) {
+	created := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		talk     domain.Talk
+		expected int
+	}{
+		{"year from slug", domain.Talk{ConferenceSlug: "javazone2024"}, 2024},
+		{"year from name", domain.Talk{ConferenceSlug: "jz", ConferenceName: "JavaZone 2022"}, 2022},
+		{"fallback to created", domain.Talk{ConferenceSlug: "jz", Created: &created}, 2019},
+		{"unknown year", domain.Talk{ConferenceSlug: "jz"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, conferenceYear(tt.talk))
+		})
+	}
+}
+
+func TestWriteStatisticsCSV(t *testing.T) {
+	report := &domain.StatisticsReport{
+		Conferences: []domain.ConferenceStatistics{
+			{
+				ConferenceSlug: "javazone2024",
+				ConferenceName: "JavaZone 2024",
+				Year:           2024,
+				Total:          2,
+				AcceptanceRate: 0.5,
+				ByStatus:       map[string]int{"APPROVED": 1, "REJECTED": 1},
+				ByFormat:       map[string]int{"presentation": 2},
+				Keywords:       map[string]int{"java": 2},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteStatisticsCSV(&buf, report)
+	require.NoError(t, err)
+
+	expected := "conference,conference_name,year,dimension,key,value\n" +
+		"javazone2024,JavaZone 2024,2024,total,,2\n" +
+		"javazone2024,JavaZone 2024,2024,acceptance_rate,,0.5000\n" +
+		"javazone2024,JavaZone 2024,2024,status,APPROVED,1\n" +
+		"javazone2024,JavaZone 2024,2024,status,REJECTED,1\n" +
+		"javazone2024,JavaZone 2024,2024,format,presentation,2\n" +
+		"javazone2024,JavaZone 2024,2024,keyword,java,2\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestSpeakerContacts(t *testing.T) {
+	var requestedSlug string
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			requestedSlug = conferenceSlug
+			return []domain.Talk{
+				{ConferenceSlug: "javazone2024", Status: "APPROVED", Data: map[string]interface{}{"title": "Go at scale"}, Speakers: []domain.Speaker{
+					{ID: "s1", Name: "Kari Nordmann", Data: map[string]interface{}{"email": "kari@example.com"}},
+					{ID: "s2", Name: "Ola Nordmann"},
+				}},
+				{ConferenceSlug: "javazone2024", Status: "APPROVED", Data: map[string]interface{}{"title": "Kotlin for Java developers"}, Speakers: []domain.Speaker{
+					{ID: "s3", Name: "Kari Nordmann", PrivateData: map[string]interface{}{"email": "Kari@example.com"}},
+				}},
+				{ConferenceSlug: "javazone2024", Status: "REJECTED", Data: map[string]interface{}{"title": "Rejected"}, Speakers: []domain.Speaker{
+					{ID: "s4", Name: "Per Hansen", Data: map[string]interface{}{"email": "per@example.com"}},
+				}},
+			}, nil
+		},
+	}
+	service := NewReportServiceWithConfig(reader, "private", AnonymizationRules{})
+
+	contacts, err := service.SpeakerContacts(context.Background(), "javazone2024")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"private"}, reader.fetchCalls)
+	assert.Equal(t, "javazone2024", requestedSlug)
+	assert.Equal(t, []domain.SpeakerContact{
+		{ConferenceSlug: "javazone2024", SpeakerID: "s1", Name: "Kari Nordmann", Email: "kari@example.com", Talks: []string{"Go at scale", "Kotlin for Java developers"}},
+		{ConferenceSlug: "javazone2024", SpeakerID: "s2", Name: "Ola Nordmann", Talks: []string{"Go at scale"}},
+	}, contacts, "only speakers of approved talks are listed, once per email")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSpeakerContactsCSV(&buf, contacts))
+	expected := "conference,speaker_id,name,email,talks\n" +
+		"javazone2024,s1,Kari Nordmann,kari@example.com,Go at scale; Kotlin for Java developers\n" +
+		"javazone2024,s2,Ola Nordmann,,Go at scale\n"
+	assert.Equal(t, expected, buf.String())
+}
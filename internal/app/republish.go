@@ -0,0 +1,362 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// generationLayout is the timestamp suffix of the index generations built by a republish
+const generationLayout = "20060102150405"
+
+// errRepublishRunning is returned when a republish is started while another one is running
+var errRepublishRunning = errors.New("a republish is already running")
+
+// RepublishService runs the safe path for a full republish as a single job: a dry-run diff against
+// the live public index, a snapshot of the live indexes, a build of both indexes as a new generation,
+// a reconciliation check of the new generation, an atomic alias swap, a warm-up of the new public
+// index and a notification. Each step is a checkpoint recorded in the job report, and the job stops
+// at the first failed step, before the aliases are swapped.
+type RepublishService struct {
+	indexer   *IndexerService
+	admin     ports.IndexAdmin
+	reader    ports.TalkReader
+	snapshots ports.SnapshotCreator
+	jobs      ports.JobStore
+	cfg       config.RepublishConfig
+	now       func() time.Time
+	logger    *slog.Logger
+
+	running sync.Mutex
+}
+
+// NewRepublishService creates a new RepublishService, receiving context as first parameter
+// to retrieve configuration.
+func NewRepublishService(ctx context.Context, indexer *IndexerService, admin ports.IndexAdmin, reader ports.TalkReader, snapshots ports.SnapshotCreator) *RepublishService {
+	return NewRepublishServiceWithConfig(indexer, admin, reader, snapshots, config.GetConfig(ctx).Republish)
+}
+
+// NewRepublishServiceWithConfig creates a new RepublishService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewRepublishServiceWithConfig(indexer *IndexerService, admin ports.IndexAdmin, reader ports.TalkReader, snapshots ports.SnapshotCreator, cfg config.RepublishConfig) *RepublishService {
+	return &RepublishService{
+		indexer:   indexer,
+		admin:     admin,
+		reader:    reader,
+		snapshots: snapshots,
+		cfg:       cfg,
+		now:       time.Now,
+		logger:    slog.Default().With("component", "republish"),
+	}
+}
+
+// SetJobStore enables recording every republish as a job with its checkpoints
+func (s *RepublishService) SetJobStore(jobs ports.JobStore) {
+	s.jobs = jobs
+}
+
+// republishContent is what a republish writes: the talks of every conference prepared for each index
+type republishContent struct {
+	slugs   []string
+	private []domain.Talk
+	public  []domain.Talk
+}
+
+// PlanRepublish fetches and transforms all talks like a full reindex and compares the public talks
+// with the live public index, without changing any index
+func (s *RepublishService) PlanRepublish(ctx context.Context) (domain.RepublishPlan, error) {
+	content, err := s.collect(ctx)
+	if err != nil {
+		return domain.RepublishPlan{}, err
+	}
+	return s.plan(ctx, content)
+}
+
+// Republish runs all steps of the republish as a job and returns the finished job.
+// Only one republish runs at a time.
+func (s *RepublishService) Republish(ctx context.Context) (domain.Job, error) {
+	if !s.running.TryLock() {
+		return domain.Job{}, errRepublishRunning
+	}
+	defer s.running.Unlock()
+
+	return recordJob(ctx, s.jobs, s.logger, domain.JobScope{Kind: domain.JobKindRepublish}, s.republish)
+}
+
+// republish runs the steps in order, stopping at the first failure
+func (s *RepublishService) republish(ctx context.Context) error {
+	generation := s.now().UTC().Format(generationLayout)
+	aliases := []string{s.indexer.privateIndex, s.indexer.publicIndex}
+	indexes := []string{s.indexer.privateIndex + "_" + generation, s.indexer.publicIndex + "_" + generation}
+
+	var content republishContent
+	var plan domain.RepublishPlan
+
+	if err := s.checkpoint(ctx, domain.RepublishStepDiff, func(step *domain.JobStep) error {
+		var err error
+		if content, err = s.collect(ctx); err != nil {
+			return err
+		}
+		if plan, err = s.plan(ctx, content); err != nil {
+			return err
+		}
+		step.Detail = describePlan(plan)
+		return s.indexer.checkShrink(ctx, plan.PublicAfter)
+	}); err != nil {
+		return err
+	}
+
+	if err := s.checkpoint(ctx, domain.RepublishStepSnapshot, func(step *domain.JobStep) error {
+		if s.cfg.SnapshotRepository == "" || s.snapshots == nil {
+			step.State = domain.JobStepSkipped
+			step.Detail = "no snapshot repository configured"
+			return nil
+		}
+		name := "republish-" + generation
+		if err := s.snapshots.CreateSnapshot(ctx, s.cfg.SnapshotRepository, name, aliases); err != nil {
+			return err
+		}
+		step.Detail = fmt.Sprintf("snapshot %s in repository %s", name, s.cfg.SnapshotRepository)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.checkpoint(ctx, domain.RepublishStepBuild, func(step *domain.JobStep) error {
+		// The live indexes stay until the stale generation is cleaned up, so nothing is reclaimed
+		if err := s.indexer.checkCapacity(ctx, len(content.private)+len(content.public), false); err != nil {
+			return err
+		}
+		if err := s.build(ctx, indexes, content); err != nil {
+			return err
+		}
+		step.Detail = fmt.Sprintf("built %s with %d talks and %s with %d talks", indexes[0], len(content.private), indexes[1], len(content.public))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.checkpoint(ctx, domain.RepublishStepReconcile, func(step *domain.JobStep) error {
+		if err := s.reconcile(ctx, indexes, content); err != nil {
+			return fmt.Errorf("%w; the new generation is kept for inspection on the indexes page", err)
+		}
+		step.Detail = fmt.Sprintf("document counts match, public talks %d → %d", plan.PublicBefore, plan.PublicAfter)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.checkpoint(ctx, domain.RepublishStepSwap, func(step *domain.JobStep) error {
+		var swapped []string
+		for i, alias := range aliases {
+			if err := s.admin.PointAlias(ctx, alias, indexes[i]); err != nil {
+				return fmt.Errorf("failed to point %s to %s (already swapped: %s): %w", alias, indexes[i], strings.Join(swapped, ", "), err)
+			}
+			swapped = append(swapped, alias+" → "+indexes[i])
+		}
+		step.Detail = strings.Join(swapped, ", ")
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.checkpoint(ctx, domain.RepublishStepWarmUp, func(step *domain.JobStep) error {
+		step.Detail = s.warmUp(ctx, plan)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return s.checkpoint(ctx, domain.RepublishStepNotify, func(step *domain.JobStep) error {
+		event := domain.IndexSwapped{
+			Generation:   generation,
+			Indexes:      map[string]string{aliases[0]: indexes[0], aliases[1]: indexes[1]},
+			Slugs:        content.slugs,
+			PrivateTalks: len(content.private),
+			PublicTalks:  len(content.public),
+			Actor:        domain.ActorFromContext(ctx),
+		}
+		s.indexer.events.Publish(ctx, event)
+		step.Detail = "published " + event.IndexEventName()
+		return nil
+	})
+}
+
+// checkpoint runs a step and records its outcome in the report of the running job
+func (s *RepublishService) checkpoint(ctx context.Context, name string, run func(step *domain.JobStep) error) error {
+	step := domain.JobStep{Name: name, State: domain.JobStepSucceeded}
+	err := run(&step)
+	step.FinishedAt = s.now().UTC()
+	if err != nil {
+		step.State = domain.JobStepFailed
+		step.Error = err.Error()
+	}
+
+	if report := jobReportFromContext(ctx); report != nil {
+		report.step(step)
+	}
+
+	if err != nil {
+		s.logger.ErrorContext(ctx, "republish step failed", "step", name, "error", err)
+		return fmt.Errorf("republish stopped at %s: %w", name, err)
+	}
+	s.logger.InfoContext(ctx, "republish step finished", "step", name, "state", step.State, "detail", step.Detail)
+	return nil
+}
+
+// collect fetches all talks and prepares them for both indexes like a full reindex.
+// A republish without any talks, or missing too many conferences, is refused, since it would empty
+// the public index.
+func (s *RepublishService) collect(ctx context.Context) (republishContent, error) {
+	conferences, err := s.indexer.source.GetConferences(ctx)
+	if err != nil {
+		return republishContent{}, fmt.Errorf("failed to fetch conferences: %w", err)
+	}
+	s.indexer.conferences.Store(conferences)
+
+	talks, err := s.indexer.fetchTalks(ctx, conferences)
+	if err != nil {
+		return republishContent{}, err
+	}
+	if len(talks) == 0 {
+		return republishContent{}, fmt.Errorf("no talks fetched from %d conferences", len(conferences))
+	}
+	talks = s.indexer.applyTransforms(ctx, talks)
+
+	return republishContent{
+		slugs:   conferenceSlugs(conferences),
+		private: prepareTalksForPrivateIndex(s.indexer.withoutExpired(ctx, talks)),
+		public:  filterApprovedTalksForPublic(s.indexer.scrubPublic(ctx, talks)),
+	}, nil
+}
+
+// plan compares the number of public talks per conference with the live public index
+func (s *RepublishService) plan(ctx context.Context, content republishContent) (domain.RepublishPlan, error) {
+	diffs := make(map[string]*domain.ConferenceDiff)
+	diff := func(slug string) *domain.ConferenceDiff {
+		if diffs[slug] == nil {
+			diffs[slug] = &domain.ConferenceDiff{Slug: slug}
+		}
+		return diffs[slug]
+	}
+
+	plan := domain.RepublishPlan{PrivateTalks: len(content.private), PublicAfter: len(content.public)}
+
+	exists, err := s.indexer.searchIndex.IndexExists(ctx, s.indexer.publicIndex)
+	if err != nil {
+		return domain.RepublishPlan{}, fmt.Errorf("failed to check the live public index: %w", err)
+	}
+	if exists {
+		live, err := s.reader.ListConferences(ctx, s.indexer.publicIndex)
+		if err != nil {
+			return domain.RepublishPlan{}, fmt.Errorf("failed to list conferences in the live public index: %w", err)
+		}
+		for _, conference := range live {
+			diff(conference.Slug).Before = conference.TalkCount
+			plan.PublicBefore += conference.TalkCount
+		}
+	}
+
+	for _, talk := range content.public {
+		diff(talk.ConferenceSlug).After++
+	}
+
+	for _, d := range diffs {
+		plan.Conferences = append(plan.Conferences, *d)
+	}
+	slices.SortFunc(plan.Conferences, func(a, b domain.ConferenceDiff) int { return strings.Compare(a.Slug, b.Slug) })
+	return plan, nil
+}
+
+// build creates the indexes of the new generation and writes the talks to them. On failure the
+// indexes created by this build are removed again.
+func (s *RepublishService) build(ctx context.Context, indexes []string, content republishContent) error {
+	mappings := []string{s.indexer.privateIndexMapping, s.indexer.publicIndexMapping}
+	talks := [][]domain.Talk{content.private, content.public}
+
+	var created []string
+	for i, indexName := range indexes {
+		err := s.indexer.searchIndex.CreateIndex(ctx, indexName, mappings[i])
+		if err == nil {
+			created = append(created, indexName)
+			err = s.indexer.bulkIndex(ctx, indexName, talks[i])
+		}
+		if err != nil {
+			for _, name := range created {
+				if err := s.indexer.searchIndex.DeleteIndex(context.WithoutCancel(ctx), name); err != nil {
+					s.logger.ErrorContext(ctx, "failed to remove incomplete index generation", "index", name, "error", err)
+				}
+			}
+			return fmt.Errorf("failed to build %s: %w", indexName, err)
+		}
+	}
+	return nil
+}
+
+// reconcile checks that the new generation holds every talk
+func (s *RepublishService) reconcile(ctx context.Context, indexes []string, content republishContent) error {
+	expected := []int{len(content.private), len(content.public)}
+	for i, indexName := range indexes {
+		listed, err := s.admin.ListIndexes(ctx, indexName)
+		if err != nil {
+			return fmt.Errorf("failed to look up %s: %w", indexName, err)
+		}
+		j := slices.IndexFunc(listed, func(index domain.IndexInfo) bool { return index.Name == indexName })
+		if j < 0 {
+			return fmt.Errorf("index %s not found", indexName)
+		}
+		if listed[j].DocCount != int64(expected[i]) {
+			return fmt.Errorf("index %s holds %d documents, expected %d", indexName, listed[j].DocCount, expected[i])
+		}
+	}
+	return nil
+}
+
+// warmUp queries the conference list and every conference in the new public index, so the first
+// visitors after the swap do not pay for cold caches. Failed queries are reported, not fatal.
+func (s *RepublishService) warmUp(ctx context.Context, plan domain.RepublishPlan) string {
+	failed := 0
+	if _, err := s.reader.ListConferences(ctx, s.indexer.publicIndex); err != nil {
+		s.logger.WarnContext(ctx, "warm-up query failed", "error", err)
+		failed++
+	}
+
+	queried := 1
+	for _, diff := range plan.Conferences {
+		if diff.After == 0 {
+			continue
+		}
+		queried++
+		if _, err := s.reader.FetchTalks(ctx, s.indexer.publicIndex, diff.Slug); err != nil {
+			s.logger.WarnContext(ctx, "warm-up query failed", "conference", diff.Slug, "error", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Sprintf("ran %d queries, %d failed", queried, failed)
+	}
+	return fmt.Sprintf("ran %d queries", queried)
+}
+
+// describePlan summarizes a plan for the job report
+func describePlan(plan domain.RepublishPlan) string {
+	detail := fmt.Sprintf("%d talks, %d public (live: %d)", plan.PrivateTalks, plan.PublicAfter, plan.PublicBefore)
+	var changes []string
+	for _, diff := range plan.Changed() {
+		changes = append(changes, fmt.Sprintf("%s %d → %d", diff.Slug, diff.Before, diff.After))
+	}
+	if len(changes) > 0 {
+		detail += "; changed: " + strings.Join(changes, ", ")
+	}
+	return detail
+}
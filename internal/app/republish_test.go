@@ -0,0 +1,239 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSnapshotCreator is a mock implementation of ports.SnapshotCreator
+type mockSnapshotCreator struct {
+	snapshots []string
+	err       error
+}
+
+func (m *mockSnapshotCreator) CreateSnapshot(ctx context.Context, repository string, name string, indexes []string) error {
+	m.snapshots = append(m.snapshots, repository+"/"+name)
+	return m.err
+}
+
+// republishTestTime is the fixed time of test republishes, giving generation 20250901120000
+var republishTestTime = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+// republishTestSource returns two conferences: three talks in javazone2024, two of them approved,
+// and one approved talk in javazone2025
+func republishTestSource() *mockTalkSource {
+	speakers := domain.Speakers{{ID: "s1"}}
+	return &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "c1", Slug: "javazone2024"}, {ID: "c2", Slug: "javazone2025"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			if conferenceID == "c1" {
+				return []domain.Talk{
+					{ID: "t1", ConferenceSlug: "javazone2024", Status: "APPROVED", Speakers: speakers},
+					{ID: "t2", ConferenceSlug: "javazone2024", Status: "APPROVED", Speakers: speakers},
+					{ID: "t3", ConferenceSlug: "javazone2024", Status: "REJECTED", Speakers: speakers},
+				}, nil
+			}
+			return []domain.Talk{{ID: "t4", ConferenceSlug: "javazone2025", Status: "APPROVED", Speakers: speakers}}, nil
+		},
+	}
+}
+
+// newTestRepublishService returns a republish service whose new generation reconciles
+func newTestRepublishService(index *mockSearchIndex, cfg config.RepublishConfig) (*RepublishService, *mockIndexAdmin, *mockTalkReader) {
+	indexer := NewIndexerServiceWithConfig(republishTestSource(), index, "private", "public", testPrivateMapping, testPublicMapping)
+	admin := &mockIndexAdmin{indexes: []domain.IndexInfo{
+		{Name: "private_20250901120000", DocCount: 4},
+		{Name: "public_20250901120000", DocCount: 3},
+	}}
+	reader := &mockTalkReader{conferences: []domain.ConferenceSummary{{Slug: "javazone2024", TalkCount: 3}}}
+
+	service := NewRepublishServiceWithConfig(indexer, admin, reader, &mockSnapshotCreator{}, cfg)
+	service.now = func() time.Time { return republishTestTime }
+	return service, admin, reader
+}
+
+// stepStates returns the state of each recorded step by name
+func stepStates(steps []domain.JobStep) map[string]domain.JobStepState {
+	states := make(map[string]domain.JobStepState, len(steps))
+	for _, step := range steps {
+		states[step.Name] = step.State
+	}
+	return states
+}
+
+func TestRepublishService_PlanRepublish(t *testing.T) {
+	index := &mockSearchIndex{}
+	service, admin, _ := newTestRepublishService(index, config.RepublishConfig{})
+
+	plan, err := service.PlanRepublish(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, plan.PrivateTalks)
+	assert.Equal(t, 3, plan.PublicBefore)
+	assert.Equal(t, 3, plan.PublicAfter)
+	assert.Equal(t, []domain.ConferenceDiff{
+		{Slug: "javazone2024", Before: 3, After: 2},
+		{Slug: "javazone2025", Before: 0, After: 1},
+	}, plan.Conferences)
+	assert.Len(t, plan.Changed(), 2)
+
+	// A dry run never writes
+	assert.Empty(t, index.bulkIndexCalls)
+	assert.Empty(t, index.createIndexCalls)
+	assert.Empty(t, admin.pointAliasCalls)
+}
+
+func TestRepublishService_Republish(t *testing.T) {
+	index := &mockSearchIndex{}
+	service, admin, reader := newTestRepublishService(index, config.RepublishConfig{SnapshotRepository: "backups"})
+	snapshots := &mockSnapshotCreator{}
+	service.snapshots = snapshots
+	notifier := &mockEventNotifier{}
+	service.indexer.SetNotifier(notifier)
+	jobs := newMockJobStore()
+	service.SetJobStore(jobs)
+
+	job, err := service.Republish(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.JobKindRepublish, job.Scope.Kind)
+	assert.Equal(t, domain.JobStateSucceeded, job.State)
+	require.Len(t, job.Report.Steps, len(domain.RepublishSteps))
+	for i, step := range job.Report.Steps {
+		assert.Equal(t, domain.RepublishSteps[i], step.Name)
+		assert.Equal(t, domain.JobStepSucceeded, step.State, step.Name)
+	}
+	assert.Equal(t, domain.JobStateSucceeded, jobs.jobs["job-1"].State)
+
+	assert.Equal(t, []string{"backups/republish-20250901120000"}, snapshots.snapshots)
+	assert.Equal(t, []string{"private_20250901120000", "public_20250901120000"}, index.createIndexCalls)
+	require.Len(t, index.bulkIndexCalls, 2)
+	assert.Len(t, index.bulkIndexCalls[0].Talks, 4)
+	assert.Len(t, index.bulkIndexCalls[1].Talks, 3)
+	assert.Empty(t, index.deleteIndexCalls)
+
+	assert.Equal(t, [][2]string{
+		{"private", "private_20250901120000"},
+		{"public", "public_20250901120000"},
+	}, admin.pointAliasCalls)
+	assert.False(t, service.indexer.LastReindex("public").IsZero())
+
+	assert.Equal(t, []string{"public", "public"}, reader.fetchCalls)
+
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, domain.EventRepublishCompleted, notifier.events[0].Type)
+	assert.Equal(t, "20250901120000", notifier.events[0].Data["generation"])
+}
+
+func TestRepublishService_SkipsOptionalSteps(t *testing.T) {
+	service, _, _ := newTestRepublishService(&mockSearchIndex{}, config.RepublishConfig{})
+
+	job, err := service.Republish(context.Background())
+	require.NoError(t, err)
+
+	states := stepStates(job.Report.Steps)
+	assert.Equal(t, domain.JobStepSkipped, states[domain.RepublishStepSnapshot])
+	assert.Equal(t, domain.JobStepSucceeded, states[domain.RepublishStepSwap])
+
+	// The swap is always published on the event bus, whether or not a notifier subscribes
+	assert.Equal(t, domain.JobStepSucceeded, states[domain.RepublishStepNotify])
+}
+
+func TestRepublishService_ShrinkGuard(t *testing.T) {
+	t.Run("refuses to shrink the live public index", func(t *testing.T) {
+		index := &mockSearchIndex{}
+		service, admin, _ := newTestRepublishService(index, config.RepublishConfig{})
+		service.indexer.SetShrinkGuard(NewShrinkGuardWithConfig(&mockIndexVersioner{docCount: 100}, config.ReindexConfig{MaxShrinkPercent: 10}))
+
+		job, err := service.Republish(context.Background())
+		assert.ErrorIs(t, err, domain.ErrIndexShrink)
+		assert.Contains(t, err.Error(), "republish stopped at dry-run diff")
+		assert.Contains(t, err.Error(), "drop from 100 to 3")
+
+		assert.Equal(t, domain.JobStateFailed, job.State)
+		assert.Equal(t, domain.JobStepFailed, stepStates(job.Report.Steps)[domain.RepublishStepDiff])
+		assert.Empty(t, index.createIndexCalls)
+		assert.Empty(t, admin.pointAliasCalls)
+	})
+
+	t.Run("shrinks on request", func(t *testing.T) {
+		service, admin, _ := newTestRepublishService(&mockSearchIndex{}, config.RepublishConfig{})
+		service.indexer.SetShrinkGuard(NewShrinkGuardWithConfig(&mockIndexVersioner{docCount: 100}, config.ReindexConfig{MaxShrinkPercent: 10}))
+
+		job, err := service.Republish(domain.WithShrinkAllowed(context.Background()))
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStepSucceeded, stepStates(job.Report.Steps)["check shrink"])
+		assert.Len(t, admin.pointAliasCalls, 2)
+	})
+}
+
+func TestRepublishService_StopsBeforeSwap(t *testing.T) {
+	t.Run("document count mismatch", func(t *testing.T) {
+		service, admin, _ := newTestRepublishService(&mockSearchIndex{}, config.RepublishConfig{})
+		admin.indexes[1].DocCount = 2
+
+		_, err := service.Republish(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "public_20250901120000 holds 2 documents, expected 3")
+		assert.Empty(t, admin.pointAliasCalls)
+	})
+
+	t.Run("build fails", func(t *testing.T) {
+		index := &mockSearchIndex{
+			bulkIndexFunc: func(ctx context.Context, indexName string, talks []domain.Talk) error {
+				if indexName == "public_20250901120000" {
+					return errors.New("bulk failed")
+				}
+				return nil
+			},
+		}
+		service, admin, _ := newTestRepublishService(index, config.RepublishConfig{})
+
+		job, err := service.Republish(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, domain.JobStepFailed, stepStates(job.Report.Steps)[domain.RepublishStepBuild])
+		assert.ElementsMatch(t, []string{"private_20250901120000", "public_20250901120000"}, index.deleteIndexCalls)
+		assert.Empty(t, admin.pointAliasCalls)
+	})
+
+	t.Run("snapshot fails", func(t *testing.T) {
+		index := &mockSearchIndex{}
+		service, admin, _ := newTestRepublishService(index, config.RepublishConfig{SnapshotRepository: "backups"})
+		service.snapshots = &mockSnapshotCreator{err: errors.New("repository_missing_exception")}
+
+		_, err := service.Republish(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "republish stopped at snapshot")
+		assert.Empty(t, index.createIndexCalls)
+		assert.Empty(t, admin.pointAliasCalls)
+	})
+
+	t.Run("no talks", func(t *testing.T) {
+		index := &mockSearchIndex{}
+		service, _, _ := newTestRepublishService(index, config.RepublishConfig{})
+		service.indexer.source = &mockTalkSource{}
+
+		_, err := service.Republish(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "republish stopped at dry-run diff")
+		assert.Empty(t, index.createIndexCalls)
+	})
+}
+
+func TestRepublishService_OneAtATime(t *testing.T) {
+	service, _, _ := newTestRepublishService(&mockSearchIndex{}, config.RepublishConfig{})
+	service.running.Lock()
+	defer service.running.Unlock()
+
+	_, err := service.Republish(context.Background())
+	assert.ErrorIs(t, err, errRepublishRunning)
+}
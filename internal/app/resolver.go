@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// ConferenceResolverService resolves conferences by ID or slug from an in-process copy of the
+// source's conference list, so reindexing a single conference or talk does not list every
+// conference from moresleep again. Full reindexes and republishes replace the copy with the
+// conferences they fetched. A lookup that misses reloads the list once, picking up conferences
+// created since it was loaded.
+type ConferenceResolverService struct {
+	provider ports.ConferenceProvider
+	logger   *slog.Logger
+
+	mu          sync.Mutex
+	conferences []domain.Conference
+	loaded      bool
+}
+
+// NewConferenceResolverService creates a new ConferenceResolverService listing conferences from the provider
+func NewConferenceResolverService(provider ports.ConferenceProvider) *ConferenceResolverService {
+	return &ConferenceResolverService{
+		provider: provider,
+		logger:   slog.Default().With("component", "conference-resolver"),
+	}
+}
+
+// ConferenceByID returns the conference with the given ID, or domain.ErrConferenceNotFound
+func (s *ConferenceResolverService) ConferenceByID(ctx context.Context, id string) (*domain.Conference, error) {
+	matches, err := s.find(ctx, func(conf domain.Conference) bool { return conf.ID == id })
+	if err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// ConferenceBySlug returns the conference with the given slug, domain.ErrConferenceNotFound, or
+// domain.ErrAmbiguousConference listing the candidates if several conferences have the slug
+func (s *ConferenceResolverService) ConferenceBySlug(ctx context.Context, slug string) (*domain.Conference, error) {
+	matches, err := s.find(ctx, func(conf domain.Conference) bool { return conf.Slug == slug })
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 1 {
+		candidates := make([]string, 0, len(matches))
+		for _, conf := range matches {
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", conf.ID, conf.Name))
+		}
+		return nil, fmt.Errorf("%w %s: %s; reindex one of them by conference ID", domain.ErrAmbiguousConference, slug, strings.Join(candidates, ", "))
+	}
+	return &matches[0], nil
+}
+
+// Store replaces the known conferences with a list just fetched from the source
+func (s *ConferenceResolverService) Store(conferences []domain.Conference) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conferences = conferences
+	s.loaded = true
+}
+
+// Invalidate drops the known conferences so the next lookup lists them from the source
+func (s *ConferenceResolverService) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conferences = nil
+	s.loaded = false
+}
+
+// find returns the known conferences matching, reloading the conferences when none does.
+// The lock is held while loading so concurrent lookups share a single request to the source.
+func (s *ConferenceResolverService) find(ctx context.Context, match func(domain.Conference) bool) ([]domain.Conference, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded {
+		if matches := findConferences(s.conferences, match); len(matches) > 0 {
+			return matches, nil
+		}
+	}
+
+	conferences, err := s.provider.GetConferences(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch conferences: %w", err)
+	}
+	s.conferences = conferences
+	s.loaded = true
+	s.logger.DebugContext(ctx, "loaded conferences", "count", len(conferences))
+
+	if matches := findConferences(conferences, match); len(matches) > 0 {
+		return matches, nil
+	}
+	return nil, domain.ErrConferenceNotFound
+}
+
+// findConferences returns copies of the conferences matching
+func findConferences(conferences []domain.Conference, match func(domain.Conference) bool) []domain.Conference {
+	var matches []domain.Conference
+	for _, conf := range conferences {
+		if match(conf) {
+			matches = append(matches, conf)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingConferenceSource returns the conferences and counts how often they were listed
+func countingConferenceSource(conferences *[]domain.Conference, calls *int) *mockTalkSource {
+	return &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			*calls++
+			return *conferences, nil
+		},
+	}
+}
+
+func TestConferenceResolverService_CachesLookups(t *testing.T) {
+	conferences := []domain.Conference{{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"}}
+	calls := 0
+	resolver := NewConferenceResolverService(countingConferenceSource(&conferences, &calls))
+
+	conf, err := resolver.ConferenceBySlug(context.Background(), "javazone2024")
+	require.NoError(t, err)
+	assert.Equal(t, "conf-1", conf.ID)
+
+	conf, err = resolver.ConferenceByID(context.Background(), "conf-1")
+	require.NoError(t, err)
+	assert.Equal(t, "javazone2024", conf.Slug)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestConferenceResolverService_ReloadsOnMiss(t *testing.T) {
+	conferences := []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}
+	calls := 0
+	resolver := NewConferenceResolverService(countingConferenceSource(&conferences, &calls))
+
+	_, err := resolver.ConferenceBySlug(context.Background(), "javazone2024")
+	require.NoError(t, err)
+
+	// A conference created since the list was loaded is found by reloading once
+	conferences = append(conferences, domain.Conference{ID: "conf-2", Slug: "javazone2025"})
+	conf, err := resolver.ConferenceBySlug(context.Background(), "javazone2025")
+	require.NoError(t, err)
+	assert.Equal(t, "conf-2", conf.ID)
+	assert.Equal(t, 2, calls)
+
+	_, err = resolver.ConferenceBySlug(context.Background(), "nonexistent")
+	assert.ErrorIs(t, err, domain.ErrConferenceNotFound)
+	assert.Equal(t, 3, calls)
+}
+
+func TestConferenceResolverService_StoreAndInvalidate(t *testing.T) {
+	conferences := []domain.Conference{{ID: "conf-1", Name: "JavaZone", Slug: "javazone2024"}}
+	calls := 0
+	resolver := NewConferenceResolverService(countingConferenceSource(&conferences, &calls))
+
+	resolver.Store([]domain.Conference{{ID: "conf-1", Name: "Stored", Slug: "javazone2024"}})
+	conf, err := resolver.ConferenceByID(context.Background(), "conf-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Stored", conf.Name)
+	assert.Equal(t, 0, calls)
+
+	resolver.Invalidate()
+	conf, err = resolver.ConferenceByID(context.Background(), "conf-1")
+	require.NoError(t, err)
+	assert.Equal(t, "JavaZone", conf.Name)
+	assert.Equal(t, 1, calls)
+}
+
+func TestConferenceResolverService_FetchError(t *testing.T) {
+	resolver := NewConferenceResolverService(&mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	_, err := resolver.ConferenceBySlug(context.Background(), "javazone2024")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrConferenceNotFound)
+	assert.Contains(t, err.Error(), "failed to fetch conferences")
+}
+
+func TestReindexConference_ReusesResolvedConferences(t *testing.T) {
+	conferences := []domain.Conference{{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"}}
+	calls := 0
+	source := countingConferenceSource(&conferences, &calls)
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", "{}", "{}")
+
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+	assert.Equal(t, 1, calls)
+
+	// A full reindex lists the conferences and keeps them for later lookups
+	require.NoError(t, service.ReindexAll(context.Background()))
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+	assert.Equal(t, 2, calls)
+}
+
+func TestReindexConference_DuplicateSlug(t *testing.T) {
+	conferences := []domain.Conference{
+		{ID: "conf-1", Name: "JavaZone 2024", Slug: "javazone2024"},
+		{ID: "conf-2", Name: "JavaZone 2024 (copy)", Slug: "javazone2024"},
+	}
+	calls := 0
+	index := &mockSearchIndex{}
+	service := NewIndexerServiceWithConfig(countingConferenceSource(&conferences, &calls), index, "private", "public", "{}", "{}")
+
+	err := service.ReindexConference(context.Background(), "javazone2024")
+	require.ErrorIs(t, err, domain.ErrAmbiguousConference)
+	assert.Contains(t, err.Error(), "conf-1 (JavaZone 2024)")
+	assert.Contains(t, err.Error(), "conf-2 (JavaZone 2024 (copy))")
+	assert.Empty(t, index.bulkIndexCalls)
+
+	// The conference ID picks one of them
+	require.NoError(t, service.ReindexConferenceByID(context.Background(), "conf-2"))
+
+	err = service.ReindexConferenceByID(context.Background(), "conf-3")
+	assert.ErrorIs(t, err, domain.ErrConferenceNotFound)
+}
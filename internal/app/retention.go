@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// RetentionService keeps talks that were never published, such as rejected submissions, out of the
+// private index once their retention period has passed. The indexer skips expired talks when writing,
+// and a retention job deletes those already indexed, so a reindex never brings them back.
+// Approved talks and talks without a last update time never expire.
+type RetentionService struct {
+	deleter      ports.TalkDeleter
+	jobs         ports.JobStore
+	privateIndex string
+	statuses     []string
+	period       time.Duration
+	now          func() time.Time
+	logger       *slog.Logger
+
+	running atomic.Bool
+}
+
+// NewRetentionService creates a new RetentionService, receiving context as first parameter
+// to retrieve configuration.
+func NewRetentionService(ctx context.Context, deleter ports.TalkDeleter) *RetentionService {
+	cfg := config.GetConfig(ctx)
+	return NewRetentionServiceWithConfig(deleter, cfg.Index.PrivateName(), cfg.Retention)
+}
+
+// NewRetentionServiceWithConfig creates a new RetentionService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewRetentionServiceWithConfig(deleter ports.TalkDeleter, privateIndex string, cfg config.RetentionConfig) *RetentionService {
+	var statuses []string
+	for _, status := range cfg.Statuses {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if status != "" && !domain.TalkStatus(status).IsPublic() {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return &RetentionService{
+		deleter:      deleter,
+		privateIndex: privateIndex,
+		statuses:     statuses,
+		period:       cfg.Period,
+		now:          time.Now,
+		logger:       slog.Default().With("component", "retention"),
+	}
+}
+
+// SetJobStore enables recording every retention run as a job
+func (s *RetentionService) SetJobStore(jobs ports.JobStore) {
+	s.jobs = jobs
+}
+
+// SetClock replaces the system clock deciding when retention periods have passed
+func (s *RetentionService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// Expired reports whether the talk's retention period has passed
+func (s *RetentionService) Expired(talk domain.Talk) bool {
+	if s.period <= 0 || talk.LastUpdated == nil || !slices.Contains(s.statuses, talk.Status) {
+		return false
+	}
+	return talk.LastUpdated.Before(s.cutoff())
+}
+
+// ApplyRetention deletes the expired talks from the private index.
+// Only one run happens at a time; the run is recorded as a retention job.
+func (s *RetentionService) ApplyRetention(ctx context.Context) error {
+	if !s.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("retention is already being applied")
+	}
+	defer s.running.Store(false)
+
+	_, err := recordJob(ctx, s.jobs, s.logger, domain.JobScope{Kind: domain.JobKindRetention}, s.applyRetention)
+	return err
+}
+
+// applyRetention does the work of ApplyRetention
+func (s *RetentionService) applyRetention(ctx context.Context) error {
+	if s.period <= 0 || len(s.statuses) == 0 {
+		return nil
+	}
+
+	cutoff := s.cutoff()
+	deleted, err := s.deleter.DeleteTalksUpdatedBefore(ctx, s.privateIndex, s.statuses, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired talks: %w", err)
+	}
+
+	if report := jobReportFromContext(ctx); report != nil {
+		report.step(domain.JobStep{
+			Name:       "delete expired talks",
+			State:      domain.JobStepSucceeded,
+			Detail:     fmt.Sprintf("deleted %d %s talks last updated before %s", deleted, strings.Join(s.statuses, "/"), cutoff.Format(time.DateOnly)),
+			FinishedAt: s.now().UTC(),
+		})
+	}
+	s.logger.InfoContext(ctx, "applied retention", "index", s.privateIndex, "statuses", s.statuses, "updatedBefore", cutoff, "deleted", deleted)
+	return nil
+}
+
+// cutoff returns the last update time before which talks are expired
+func (s *RetentionService) cutoff() time.Time {
+	return s.now().Add(-s.period)
+}
+
+// SetRetention enables keeping talks whose retention period has passed out of the private index
+func (s *IndexerService) SetRetention(retention *RetentionService) {
+	s.retention = retention
+}
+
+// withoutExpired returns the talks whose retention period has not passed, if retention is set
+func (s *IndexerService) withoutExpired(ctx context.Context, talks []domain.Talk) []domain.Talk {
+	if s.retention == nil {
+		return talks
+	}
+
+	retained := make([]domain.Talk, 0, len(talks))
+	for _, talk := range talks {
+		if !s.retention.Expired(talk) {
+			retained = append(retained, talk)
+		}
+	}
+	if expired := len(talks) - len(retained); expired > 0 {
+		s.logger.InfoContext(ctx, "skipped talks past their retention period", "count", expired)
+	}
+	return retained
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTalkDeleter is a mock implementation of ports.TalkDeleter
+type mockTalkDeleter struct {
+	indexName string
+	statuses  []string
+	before    time.Time
+	deleted   int
+	err       error
+}
+
+func (m *mockTalkDeleter) DeleteTalksUpdatedBefore(ctx context.Context, indexName string, statuses []string, before time.Time) (int, error) {
+	m.indexName = indexName
+	m.statuses = statuses
+	m.before = before
+	return m.deleted, m.err
+}
+
+func newTestRetentionService(deleter *mockTalkDeleter, now time.Time) *RetentionService {
+	service := NewRetentionServiceWithConfig(deleter, "private", config.RetentionConfig{
+		Statuses: []string{"REJECTED", "draft", "APPROVED"},
+		Period:   365 * 24 * time.Hour,
+	})
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestRetentionService_Expired(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestRetentionService(&mockTalkDeleter{}, now)
+	old := now.AddDate(-2, 0, 0)
+	recent := now.AddDate(0, -1, 0)
+
+	assert.True(t, service.Expired(domain.Talk{Status: "REJECTED", LastUpdated: &old}))
+	assert.True(t, service.Expired(domain.Talk{Status: "DRAFT", LastUpdated: &old}), "statuses are matched in upper case")
+	assert.False(t, service.Expired(domain.Talk{Status: "REJECTED", LastUpdated: &recent}))
+	assert.False(t, service.Expired(domain.Talk{Status: "SUBMITTED", LastUpdated: &old}))
+	assert.False(t, service.Expired(domain.Talk{Status: "APPROVED", LastUpdated: &old}), "approved talks never expire")
+	assert.False(t, service.Expired(domain.Talk{Status: "REJECTED"}), "talks without last update time never expire")
+}
+
+func TestRetentionService_ApplyRetention(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	deleter := &mockTalkDeleter{deleted: 12}
+	service := newTestRetentionService(deleter, now)
+	jobs := newMockJobStore()
+	service.SetJobStore(jobs)
+
+	require.NoError(t, service.ApplyRetention(context.Background()))
+
+	assert.Equal(t, "private", deleter.indexName)
+	assert.Equal(t, []string{"REJECTED", "DRAFT"}, deleter.statuses)
+	assert.Equal(t, now.Add(-365*24*time.Hour), deleter.before)
+
+	job := jobs.jobs["job-1"]
+	assert.Equal(t, domain.JobKindRetention, job.Scope.Kind)
+	assert.Equal(t, domain.JobStateSucceeded, job.State)
+	require.Len(t, job.Report.Steps, 1)
+	assert.Equal(t, "deleted 12 REJECTED/DRAFT talks last updated before 2024-09-01", job.Report.Steps[0].Detail)
+}
+
+func TestRetentionService_ApplyRetentionError(t *testing.T) {
+	deleter := &mockTalkDeleter{err: errors.New("cluster unavailable")}
+	service := newTestRetentionService(deleter, time.Now())
+
+	err := service.ApplyRetention(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete expired talks")
+}
+
+func TestIndexerService_SkipsExpiredTalks(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(-2, 0, 0)
+	talks := []domain.Talk{
+		{ID: "talk-1", ConferenceSlug: "javazone2023", Status: "APPROVED", LastUpdated: &old},
+		{ID: "talk-2", ConferenceSlug: "javazone2023", Status: "REJECTED", LastUpdated: &old},
+		{ID: "talk-3", ConferenceSlug: "javazone2023", Status: "REJECTED", LastUpdated: &now},
+	}
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2023"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return talks, nil
+		},
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &talks[1], nil
+		},
+	}
+	index := &mockSearchIndex{}
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetRetention(newTestRetentionService(&mockTalkDeleter{}, now))
+
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2023"))
+	require.Len(t, index.bulkIndexCalls, 2)
+	assert.Equal(t, "private", index.bulkIndexCalls[0].IndexName)
+	require.Len(t, index.bulkIndexCalls[0].Talks, 2)
+	assert.Equal(t, "talk-1", index.bulkIndexCalls[0].Talks[0].ID)
+	assert.Equal(t, "talk-3", index.bulkIndexCalls[0].Talks[1].ID)
+
+	// Reindexing the expired talk on its own does not bring it back either
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-2"))
+	assert.Len(t, index.bulkIndexCalls, 2)
+}
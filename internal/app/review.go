@@ -0,0 +1,287 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// ReviewAssignments returns a TalkTransform reading the committee review assignments of a talk from
+// moresleep: reviewers are assigned with tags made of the tag prefix and their email, and have given
+// feedback once they authored an entry of the feedback field. Committee members declare conflicts of
+// interest with tags made of the conflict tag prefix and their email, and reviewers speaking in the
+// talk are conflicted too. With score weights, the talk is scored from the types of the feedback.
+// Talks without assigned reviewers, feedback or conflicts get no assignment.
+func ReviewAssignments(cfg config.ReviewConfig) TalkTransform {
+	return func(talk domain.Talk) domain.Talk {
+		var review domain.ReviewAssignment
+		for _, tag := range listValue(talkField(talk, cfg.TagsField)) {
+			if reviewer, ok := strings.CutPrefix(stringValue(tag), cfg.TagPrefix); ok {
+				review.Reviewers = appendReviewer(review.Reviewers, reviewer)
+			} else if member, ok := strings.CutPrefix(stringValue(tag), cfg.ConflictTagPrefix); ok && cfg.ConflictTagPrefix != "" {
+				review.Conflicts = appendReviewer(review.Conflicts, member)
+			}
+		}
+		feedbacks := listValue(talkField(talk, cfg.FeedbackField))
+		for _, feedback := range feedbacks {
+			if entry, ok := feedback.(map[string]interface{}); ok {
+				review.FeedbackBy = appendReviewer(review.FeedbackBy, stringValue(entry["author"]))
+			}
+		}
+		for _, speaker := range talk.Speakers {
+			email := strings.ToLower(strings.TrimSpace(speakerEmail(speaker)))
+			if slices.Contains(review.Reviewers, email) || slices.Contains(review.FeedbackBy, email) {
+				review.Conflicts = appendReviewer(review.Conflicts, email)
+			}
+		}
+		review.Score = reviewScore(feedbacks, cfg.ScoreWeights)
+
+		if len(review.Reviewers) == 0 && len(review.FeedbackBy) == 0 && len(review.Conflicts) == 0 {
+			return talk
+		}
+		for _, reviewer := range review.Reviewers {
+			if !slices.Contains(review.FeedbackBy, reviewer) && !slices.Contains(review.Conflicts, reviewer) {
+				review.Pending = append(review.Pending, reviewer)
+			}
+		}
+		talk.Review = &review
+		return talk
+	}
+}
+
+// reviewScore sums the weights of the feedback types, counting only the latest weighted feedback of each
+// author by its created time, so a committee member changing their mind is not counted twice. Feedback
+// types are matched ignoring case. It returns nil if no feedback is weighted.
+func reviewScore(feedbacks []interface{}, weights map[string]float64) *float64 {
+	if len(weights) == 0 {
+		return nil
+	}
+
+	type vote struct {
+		created string
+		weight  float64
+	}
+	latest := make(map[string]vote)
+	for _, feedback := range feedbacks {
+		entry, ok := feedback.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		weight, ok := feedbackWeight(weights, stringValue(entry["feedbacktype"]))
+		author := strings.ToLower(strings.TrimSpace(stringValue(entry["author"])))
+		if !ok || author == "" {
+			continue
+		}
+		created := stringValue(entry["created"])
+		if previous, ok := latest[author]; ok && created < previous.created {
+			continue
+		}
+		latest[author] = vote{created: created, weight: weight}
+	}
+	if len(latest) == 0 {
+		return nil
+	}
+
+	var score float64
+	for _, author := range slices.Sorted(maps.Keys(latest)) {
+		score += latest[author].weight
+	}
+	return &score
+}
+
+// feedbackWeight returns the weight of the feedback type, matched ignoring case
+func feedbackWeight(weights map[string]float64, feedbackType string) (float64, bool) {
+	feedbackType = strings.TrimSpace(feedbackType)
+	if weight, ok := weights[feedbackType]; ok {
+		return weight, true
+	}
+	for name, weight := range weights {
+		if strings.EqualFold(name, feedbackType) {
+			return weight, true
+		}
+	}
+	return 0, false
+}
+
+// talkField returns the value of the talk data field, looking in the private data first
+func talkField(talk domain.Talk, field string) interface{} {
+	if value, ok := talk.PrivateData[field]; ok {
+		return value
+	}
+	return talk.Data[field]
+}
+
+// listValue returns the value as a list, or nil if it is not one
+func listValue(v interface{}) []interface{} {
+	switch list := v.(type) {
+	case []interface{}:
+		return list
+	case []string:
+		values := make([]interface{}, len(list))
+		for i, s := range list {
+			values[i] = s
+		}
+		return values
+	}
+	return nil
+}
+
+// appendReviewer adds the reviewer, lowercased, unless it is empty or already listed
+func appendReviewer(reviewers []string, reviewer string) []string {
+	reviewer = strings.ToLower(strings.TrimSpace(reviewer))
+	if reviewer == "" || slices.Contains(reviewers, reviewer) {
+		return reviewers
+	}
+	return append(reviewers, reviewer)
+}
+
+// ReviewService lists the talks assigned to a program committee member and ranks the talks of a
+// conference from the private index, using the review assignments and scores indexed by the
+// ReviewAssignments transform
+type ReviewService struct {
+	searcher   ports.Searcher
+	trackField string
+	logger     *slog.Logger
+}
+
+// NewReviewService creates a new ReviewService searching with the searcher, receiving context as first
+// parameter to retrieve configuration.
+func NewReviewService(ctx context.Context, searcher ports.Searcher) *ReviewService {
+	return NewReviewServiceWithConfig(searcher, config.GetConfig(ctx).Review)
+}
+
+// NewReviewServiceWithConfig creates a new ReviewService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewReviewServiceWithConfig(searcher ports.Searcher, cfg config.ReviewConfig) *ReviewService {
+	return &ReviewService{
+		searcher:   searcher,
+		trackField: cfg.TrackField,
+		logger:     slog.Default().With("component", "review"),
+	}
+}
+
+// AssignedTalks returns the first page of talks assigned to the reviewer. Reviewers without an email,
+// such as users of a development login, have no assignments.
+func (s *ReviewService) AssignedTalks(ctx context.Context, reviewer, conferenceSlug string, includeReviewed bool) (domain.ReviewQueue, error) {
+	queue := domain.ReviewQueue{Reviewer: strings.ToLower(strings.TrimSpace(reviewer))}
+	if queue.Reviewer == "" {
+		return queue, nil
+	}
+
+	result, err := s.searcher.Search(ctx, domain.SearchRequest{
+		ConferenceSlug: conferenceSlug,
+		Reviewer:       queue.Reviewer,
+		ReviewPending:  !includeReviewed,
+		Private:        true,
+		Size:           maxSearchSize,
+	})
+	if err != nil {
+		return queue, fmt.Errorf("failed to search assigned talks: %w", err)
+	}
+
+	queue.Total = result.Total
+	for _, hit := range result.Hits {
+		var talk domain.Talk
+		if err := json.Unmarshal(hit, &talk); err != nil {
+			return queue, fmt.Errorf("failed to parse assigned talk: %w", err)
+		}
+		queue.Talks = append(queue.Talks, talk)
+	}
+	return queue, nil
+}
+
+// Ranking ranks the submitted talks of the conference within their tracks by review score, highest
+// first, for the program selection. Talks with the same score share their rank and are ordered by title;
+// unscored talks come last without a rank. Tracks are ordered by name, with talks without a track last.
+func (s *ReviewService) Ranking(ctx context.Context, conferenceSlug string) ([]domain.TrackRanking, error) {
+	var talks []domain.Talk
+	for from := 0; from < maxSearchWindow; from += maxSearchSize {
+		result, err := s.searcher.Search(ctx, domain.SearchRequest{
+			ConferenceSlug: conferenceSlug,
+			Private:        true,
+			Sort:           domain.SearchSortReviewScore,
+			Size:           min(maxSearchSize, maxSearchWindow-from),
+			From:           from,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search talks to rank: %w", err)
+		}
+		for _, hit := range result.Hits {
+			var talk domain.Talk
+			if err := json.Unmarshal(hit, &talk); err != nil {
+				return nil, fmt.Errorf("failed to parse talk to rank: %w", err)
+			}
+			if domain.TalkStatus(talk.Status) != domain.StatusDraft {
+				talks = append(talks, talk)
+			}
+		}
+		if len(result.Hits) == 0 || from+len(result.Hits) >= result.Total {
+			break
+		}
+	}
+
+	byTrack := make(map[string][]domain.Talk)
+	for _, talk := range talks {
+		track := strings.TrimSpace(stringValue(talk.Data[s.trackField]))
+		byTrack[track] = append(byTrack[track], talk)
+	}
+
+	tracks := slices.Sorted(maps.Keys(byTrack))
+	if len(tracks) > 0 && tracks[0] == "" {
+		tracks = append(tracks[1:], "")
+	}
+	rankings := make([]domain.TrackRanking, 0, len(tracks))
+	for _, track := range tracks {
+		rankings = append(rankings, domain.TrackRanking{Track: track, Talks: rankTalks(byTrack[track])})
+	}
+
+	s.logger.InfoContext(ctx, "ranked talks", "conference", conferenceSlug, "talks", len(talks), "tracks", len(tracks))
+	return rankings, nil
+}
+
+// rankTalks orders the talks by score and title and ranks the scored ones, sharing the rank of equal scores
+func rankTalks(talks []domain.Talk) []domain.RankedTalk {
+	slices.SortStableFunc(talks, func(a, b domain.Talk) int {
+		scoreA, scoreB := talkScore(a), talkScore(b)
+		switch {
+		case scoreA == nil && scoreB != nil:
+			return 1
+		case scoreA != nil && scoreB == nil:
+			return -1
+		case scoreA != nil && *scoreA != *scoreB:
+			return cmp.Compare(*scoreB, *scoreA)
+		}
+		return cmp.Compare(stringValue(a.Data["title"]), stringValue(b.Data["title"]))
+	})
+
+	ranked := make([]domain.RankedTalk, len(talks))
+	for i, talk := range talks {
+		ranked[i].Talk = talk
+		score := talkScore(talk)
+		switch {
+		case score == nil:
+		case i > 0 && ranked[i-1].Rank > 0 && *talkScore(talks[i-1]) == *score:
+			ranked[i].Rank = ranked[i-1].Rank
+		default:
+			ranked[i].Rank = i + 1
+		}
+	}
+	return ranked
+}
+
+// talkScore returns the review score of the talk, or nil if it has none
+func talkScore(talk domain.Talk) *float64 {
+	if talk.Review == nil {
+		return nil
+	}
+	return talk.Review.Score
+}
@@ -0,0 +1,262 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testReviewConfig = config.ReviewConfig{TagPrefix: "reviewer:", ConflictTagPrefix: "conflict:", TagsField: "tags", FeedbackField: "pkomfeedbacks", TrackField: "track"}
+
+func TestReviewAssignments(t *testing.T) {
+	transform := ReviewAssignments(testReviewConfig)
+
+	t.Run("reviewers and feedback", func(t *testing.T) {
+		talk := transform(domain.Talk{
+			ID: "talk-1",
+			PrivateData: map[string]interface{}{
+				"tags": []interface{}{"reviewer:Ada@JavaZone.no", "keynote", "reviewer:grace@javazone.no", "reviewer:ada@javazone.no"},
+				"pkomfeedbacks": []interface{}{
+					map[string]interface{}{"author": "Ada@javazone.no", "talkrating": "good"},
+					map[string]interface{}{"author": "linus@javazone.no"},
+				},
+			},
+		})
+
+		require.NotNil(t, talk.Review)
+		assert.Equal(t, []string{"ada@javazone.no", "grace@javazone.no"}, talk.Review.Reviewers)
+		assert.Equal(t, []string{"ada@javazone.no", "linus@javazone.no"}, talk.Review.FeedbackBy)
+		assert.Equal(t, []string{"grace@javazone.no"}, talk.Review.Pending)
+	})
+
+	t.Run("public tags", func(t *testing.T) {
+		talk := transform(domain.Talk{ID: "talk-1", Data: map[string]interface{}{"tags": []string{"reviewer:ada@javazone.no"}}})
+
+		require.NotNil(t, talk.Review)
+		assert.Equal(t, []string{"ada@javazone.no"}, talk.Review.Pending)
+	})
+
+	t.Run("no assignments", func(t *testing.T) {
+		talk := transform(domain.Talk{ID: "talk-1", PrivateData: map[string]interface{}{"tags": []interface{}{"keynote"}}})
+		assert.Nil(t, talk.Review)
+	})
+
+	t.Run("not indexed publicly", func(t *testing.T) {
+		talk := transform(domain.Talk{ID: "talk-1", PrivateData: map[string]interface{}{"tags": []interface{}{"reviewer:ada@javazone.no"}}})
+		assert.NotNil(t, talk.ToPrivate().Review)
+		assert.Nil(t, talk.ToPublic().Review)
+	})
+
+	t.Run("conflicts of interest", func(t *testing.T) {
+		talk := transform(domain.Talk{
+			ID: "talk-1",
+			Speakers: domain.Speakers{
+				{ID: "s1", Name: "Grace Hopper", PrivateData: map[string]interface{}{"email": "Grace@JavaZone.no"}},
+				{ID: "s2", Name: "Alan Turing", PrivateData: map[string]interface{}{"email": "alan@example.com"}},
+			},
+			PrivateData: map[string]interface{}{
+				"tags": []interface{}{"reviewer:ada@javazone.no", "reviewer:grace@javazone.no", "conflict:Linus@javazone.no"},
+			},
+		})
+
+		require.NotNil(t, talk.Review)
+		assert.Equal(t, []string{"linus@javazone.no", "grace@javazone.no"}, talk.Review.Conflicts)
+		assert.Equal(t, []string{"ada@javazone.no"}, talk.Review.Pending, "conflicted reviewers are not asked for feedback")
+	})
+
+	t.Run("declared conflict only", func(t *testing.T) {
+		talk := transform(domain.Talk{ID: "talk-1", PrivateData: map[string]interface{}{"tags": []interface{}{"conflict:ada@javazone.no"}}})
+
+		require.NotNil(t, talk.Review)
+		assert.Equal(t, []string{"ada@javazone.no"}, talk.Review.Conflicts)
+		assert.Empty(t, talk.Review.Reviewers)
+	})
+
+	t.Run("not scored without weights", func(t *testing.T) {
+		talk := transform(domain.Talk{ID: "talk-1", PrivateData: map[string]interface{}{
+			"pkomfeedbacks": []interface{}{map[string]interface{}{"author": "ada@javazone.no", "feedbacktype": "LIKE"}},
+		}})
+		require.NotNil(t, talk.Review)
+		assert.Nil(t, talk.Review.Score)
+	})
+}
+
+func TestReviewAssignments_Score(t *testing.T) {
+	cfg := testReviewConfig
+	cfg.ScoreWeights = map[string]float64{"LIKE": 1, "DISLIKE": -1, "STRONG_LIKE": 2.5}
+	transform := ReviewAssignments(cfg)
+
+	score := func(feedbacks ...interface{}) *float64 {
+		talk := transform(domain.Talk{ID: "talk-1", PrivateData: map[string]interface{}{"pkomfeedbacks": feedbacks}})
+		if talk.Review == nil {
+			return nil
+		}
+		return talk.Review.Score
+	}
+	feedback := func(author, feedbackType, created string) interface{} {
+		return map[string]interface{}{"author": author, "feedbacktype": feedbackType, "created": created}
+	}
+
+	t.Run("sum of weights", func(t *testing.T) {
+		got := score(
+			feedback("ada@javazone.no", "STRONG_LIKE", "2026-03-01T10:00:00"),
+			feedback("grace@javazone.no", "like", "2026-03-01T11:00:00"),
+			feedback("linus@javazone.no", "DISLIKE", "2026-03-02T09:00:00"),
+		)
+		require.NotNil(t, got)
+		assert.Equal(t, 2.5, *got)
+	})
+
+	t.Run("latest feedback of each author", func(t *testing.T) {
+		got := score(
+			feedback("ada@javazone.no", "LIKE", "2026-03-03T10:00:00"),
+			feedback("Ada@JavaZone.no", "DISLIKE", "2026-03-01T10:00:00"),
+			feedback("grace@javazone.no", "DISLIKE", "2026-03-01T10:00:00"),
+			feedback("grace@javazone.no", "STRONG_LIKE", "2026-03-02T10:00:00"),
+		)
+		require.NotNil(t, got)
+		assert.Equal(t, 3.5, *got)
+	})
+
+	t.Run("unweighted feedback", func(t *testing.T) {
+		got := score(
+			feedback("ada@javazone.no", "LIKE", "2026-03-01T10:00:00"),
+			feedback("ada@javazone.no", "COMMENT", "2026-03-02T10:00:00"),
+		)
+		require.NotNil(t, got)
+		assert.Equal(t, 1.0, *got)
+
+		assert.Nil(t, score(feedback("ada@javazone.no", "COMMENT", "2026-03-01T10:00:00")))
+	})
+
+	t.Run("zero score", func(t *testing.T) {
+		got := score(
+			feedback("ada@javazone.no", "LIKE", "2026-03-01T10:00:00"),
+			feedback("grace@javazone.no", "DISLIKE", "2026-03-01T10:00:00"),
+		)
+		require.NotNil(t, got)
+		assert.Equal(t, 0.0, *got)
+	})
+}
+
+func TestReviewService_AssignedTalks(t *testing.T) {
+	hit, err := json.Marshal(domain.Talk{ID: "talk-1", Review: &domain.ReviewAssignment{Reviewers: []string{"ada@javazone.no"}}})
+	require.NoError(t, err)
+
+	t.Run("pending talks of the reviewer", func(t *testing.T) {
+		runner := &mockQueryRunner{result: domain.QueryResult{Total: 150, Hits: []json.RawMessage{hit}}}
+		service := NewReviewServiceWithConfig(NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public"), testReviewConfig)
+
+		queue, err := service.AssignedTalks(context.Background(), "Ada@JavaZone.no", "javazone2024", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ada@javazone.no", queue.Reviewer)
+		assert.Equal(t, 150, queue.Total)
+		require.Len(t, queue.Talks, 1)
+		assert.Equal(t, "talk-1", queue.Talks[0].ID)
+
+		assert.Equal(t, "javazone_private", runner.indexName)
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": "javazone2024"}},
+			map[string]interface{}{"term": map[string]interface{}{"review.pending": "ada@javazone.no"}},
+		}, query["filter"])
+	})
+
+	t.Run("including reviewed talks", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewReviewServiceWithConfig(NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public"), testReviewConfig)
+
+		_, err := service.AssignedTalks(context.Background(), "ada@javazone.no", "", true)
+
+		require.NoError(t, err)
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"review.reviewers": "ada@javazone.no"}},
+		}, query["filter"])
+	})
+
+	t.Run("no email", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewReviewServiceWithConfig(NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public"), testReviewConfig)
+
+		queue, err := service.AssignedTalks(context.Background(), "", "", false)
+
+		require.NoError(t, err)
+		assert.Empty(t, queue.Talks)
+		assert.Nil(t, runner.body, "no search is run")
+	})
+}
+
+// mockSearcher is a mock implementation of ports.Searcher returning pages of the hits
+type mockSearcher struct {
+	hits     []json.RawMessage
+	requests []domain.SearchRequest
+}
+
+func (m *mockSearcher) Search(ctx context.Context, req domain.SearchRequest) (domain.QueryResult, error) {
+	m.requests = append(m.requests, req)
+	start, end := min(req.From, len(m.hits)), min(req.From+req.Size, len(m.hits))
+	return domain.QueryResult{Total: len(m.hits), Hits: m.hits[start:end]}, nil
+}
+
+func TestReviewService_Ranking(t *testing.T) {
+	talk := func(id, track, status string, score *float64) json.RawMessage {
+		hit, err := json.Marshal(domain.Talk{
+			ID:     id,
+			Status: status,
+			Data:   map[string]interface{}{"title": "Talk " + id, "track": track},
+			Review: &domain.ReviewAssignment{Score: score},
+		})
+		require.NoError(t, err)
+		return hit
+	}
+	score := func(f float64) *float64 { return &f }
+
+	searcher := &mockSearcher{hits: []json.RawMessage{
+		talk("a", "Core Java", "SUBMITTED", score(3)),
+		talk("b", "Core Java", "SUBMITTED", score(3)),
+		talk("c", "Core Java", "APPROVED", score(1)),
+		talk("d", "Core Java", "SUBMITTED", nil),
+		talk("e", "Cloud", "SUBMITTED", score(-1)),
+		talk("f", "", "SUBMITTED", score(2)),
+		talk("g", "Cloud", "DRAFT", score(5)),
+	}}
+	// Pages of the search are read until the total is reached
+	for i := 0; i < 200; i++ {
+		searcher.hits = append(searcher.hits, talk(fmt.Sprintf("z%03d", i), "Cloud", "SUBMITTED", nil))
+	}
+	service := NewReviewServiceWithConfig(searcher, testReviewConfig)
+
+	rankings, err := service.Ranking(context.Background(), "javazone2024")
+	require.NoError(t, err)
+
+	require.Len(t, searcher.requests, 3)
+	assert.Equal(t, domain.SearchRequest{ConferenceSlug: "javazone2024", Private: true, Sort: domain.SearchSortReviewScore, Size: 100, From: 200}, searcher.requests[2])
+
+	require.Len(t, rankings, 3)
+	assert.Equal(t, "Cloud", rankings[0].Track)
+	assert.Len(t, rankings[0].Talks, 201, "drafts are not ranked")
+	assert.Equal(t, "e", rankings[0].Talks[0].Talk.ID)
+	assert.Equal(t, 1, rankings[0].Talks[0].Rank)
+	assert.Equal(t, 0, rankings[0].Talks[1].Rank)
+
+	assert.Equal(t, "Core Java", rankings[1].Track)
+	var ids []string
+	var ranks []int
+	for _, ranked := range rankings[1].Talks {
+		ids = append(ids, ranked.Talk.ID)
+		ranks = append(ranks, ranked.Rank)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids)
+	assert.Equal(t, []int{1, 1, 3, 0}, ranks)
+
+	assert.Equal(t, "", rankings[2].Track, "talks without a track come last")
+	assert.Equal(t, "f", rankings[2].Talks[0].Talk.ID)
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// DefaultSampleSize is the number of documents returned when a sample request does not set a size
+const DefaultSampleSize = 5
+
+// maxSampleSize bounds the documents of one sample, which is meant for spot checks rather than exports
+const maxSampleSize = 50
+
+// SampleService returns a few random documents of the private or public index, so support questions
+// such as "is talk X in the public index and what does it look like?" can be answered without
+// access to Elasticsearch
+type SampleService struct {
+	runner  ports.QueryRunner
+	indexes map[string]string
+	logger  *slog.Logger
+}
+
+// NewSampleService creates a new SampleService, receiving context as first parameter
+// to retrieve configuration.
+func NewSampleService(ctx context.Context, runner ports.QueryRunner) *SampleService {
+	cfg := config.GetConfig(ctx)
+	return NewSampleServiceWithConfig(runner, cfg.Index.PrivateName(), cfg.Index.PublicName())
+}
+
+// NewSampleServiceWithConfig creates a new SampleService with explicit index names.
+// This constructor is primarily intended for testing purposes.
+func NewSampleServiceWithConfig(runner ports.QueryRunner, privateIndex, publicIndex string) *SampleService {
+	return &SampleService{
+		runner: runner,
+		// Indexes are named by their full name or simply as private and public
+		indexes: map[string]string{
+			"private":    privateIndex,
+			"public":     publicIndex,
+			privateIndex: privateIndex,
+			publicIndex:  publicIndex,
+		},
+		logger: slog.Default().With("component", "sample"),
+	}
+}
+
+// Sample returns random documents of the requested index, optionally of one conference only
+func (s *SampleService) Sample(ctx context.Context, req domain.SampleRequest) (domain.QueryResult, error) {
+	indexName, ok := s.indexes[req.IndexName]
+	if !ok {
+		return domain.QueryResult{}, fmt.Errorf("%w: %s", domain.ErrIndexNotFound, req.IndexName)
+	}
+
+	size := req.Size
+	if size == 0 {
+		size = DefaultSampleSize
+	}
+	if size < 1 || size > maxSampleSize {
+		return domain.QueryResult{}, invalidQuery("n must be between 1 and %d", maxSampleSize)
+	}
+
+	var filter interface{} = map[string]interface{}{"match_all": map[string]interface{}{}}
+	if req.ConferenceSlug != "" {
+		filter = map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": req.ConferenceSlug}}
+	}
+
+	body := map[string]interface{}{
+		"size":             size,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"function_score": map[string]interface{}{
+				"query":        filter,
+				"random_score": map[string]interface{}{},
+				"boost_mode":   "replace",
+			},
+		},
+	}
+
+	result, err := s.runner.RunQuery(ctx, indexName, body)
+	if err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to sample index %s: %w", indexName, err)
+	}
+
+	s.logger.InfoContext(ctx, "sampled index",
+		"index", indexName,
+		"conferenceSlug", req.ConferenceSlug,
+		"size", size,
+		"total", result.Total,
+	)
+	return result, nil
+}
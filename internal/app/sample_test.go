@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleService_Sample(t *testing.T) {
+	t.Run("random documents of one conference", func(t *testing.T) {
+		runner := &mockQueryRunner{result: domain.QueryResult{Total: 42}}
+		service := NewSampleServiceWithConfig(runner, "javazone_private", "javazone_public")
+
+		result, err := service.Sample(context.Background(), domain.SampleRequest{IndexName: "public", ConferenceSlug: "javazone2024"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 42, result.Total)
+		assert.Equal(t, "javazone_public", runner.indexName)
+		assert.Equal(t, DefaultSampleSize, runner.body["size"])
+
+		functionScore := runner.body["query"].(map[string]interface{})["function_score"].(map[string]interface{})
+		assert.Contains(t, functionScore, "random_score")
+		assert.Equal(t, map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": "javazone2024"}}, functionScore["query"])
+	})
+
+	t.Run("full index name", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewSampleServiceWithConfig(runner, "javazone_private", "javazone_public")
+
+		_, err := service.Sample(context.Background(), domain.SampleRequest{IndexName: "javazone_private", Size: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, "javazone_private", runner.indexName)
+		assert.Equal(t, 3, runner.body["size"])
+	})
+
+	t.Run("unknown index", func(t *testing.T) {
+		service := NewSampleServiceWithConfig(&mockQueryRunner{}, "javazone_private", "javazone_public")
+
+		_, err := service.Sample(context.Background(), domain.SampleRequest{IndexName: ".security"})
+
+		assert.ErrorIs(t, err, domain.ErrIndexNotFound)
+	})
+
+	t.Run("size out of range", func(t *testing.T) {
+		service := NewSampleServiceWithConfig(&mockQueryRunner{}, "javazone_private", "javazone_public")
+
+		_, err := service.Sample(context.Background(), domain.SampleRequest{IndexName: "public", Size: 500})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	})
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/clock"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// scheduledTask is a task run by the Scheduler at a fixed interval
+type scheduledTask struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// Scheduler runs background tasks at fixed intervals, attributed to the scheduler actor.
+// The first run of a task happens one interval after Start, and a run that is still going
+// when the next one is due delays it rather than overlapping.
+type Scheduler struct {
+	tasks  []scheduledTask
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	clock  ports.Clock
+	logger *slog.Logger
+}
+
+// NewScheduler creates a new Scheduler without tasks
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		clock:  clock.System{},
+		logger: slog.Default().With("component", "scheduler"),
+	}
+}
+
+// Every registers a task to run at the given interval. Tasks with a non-positive interval are ignored.
+// Tasks must be registered before Start.
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	if interval <= 0 {
+		return
+	}
+	s.tasks = append(s.tasks, scheduledTask{name: name, interval: interval, run: run})
+}
+
+// SetClock replaces the system clock deciding when tasks are due.
+// Must be called before Start.
+func (s *Scheduler) SetClock(clock ports.Clock) {
+	s.clock = clock
+}
+
+// Start runs the registered tasks in the background until Stop is called or the context is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(domain.WithActor(ctx, domain.SchedulerActor))
+
+	for _, task := range s.tasks {
+		s.wg.Add(1)
+		go s.loop(ctx, task)
+		s.logger.InfoContext(ctx, "scheduled task", "task", task.name, "interval", task.interval)
+	}
+}
+
+// Stop cancels the running tasks and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// loop runs a task at its interval until the context is cancelled. Runs are due at fixed
+// intervals from Start; runs missed while the previous one was going are skipped.
+func (s *Scheduler) loop(ctx context.Context, task scheduledTask) {
+	defer s.wg.Done()
+
+	due := s.clock.Now().Add(task.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clock.After(due.Sub(s.clock.Now())):
+		}
+
+		start := s.clock.Now()
+		if err := task.run(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "scheduled task failed", "task", task.name, "error", err)
+		} else {
+			s.logger.InfoContext(ctx, "scheduled task completed", "task", task.name, "duration", s.clock.Now().Sub(start))
+		}
+
+		now := s.clock.Now()
+		for !due.After(now) {
+			due = due.Add(task.interval)
+		}
+	}
+}
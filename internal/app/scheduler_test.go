@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/clock"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_RunsTasksAsScheduler(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var runs atomic.Int32
+	actors := make(chan domain.Actor, 10)
+	scheduler.Every("test", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		select {
+		case actors <- domain.ActorFromContext(ctx):
+		default:
+		}
+		return errors.New("failures are logged and the task keeps running")
+	})
+
+	scheduler.Start(context.Background())
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+	scheduler.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load(), "no runs after Stop")
+	assert.Equal(t, domain.SchedulerActor, <-actors)
+}
+
+func TestScheduler_Clock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC))
+	scheduler := NewScheduler()
+	scheduler.SetClock(fake)
+
+	runs := make(chan time.Time, 10)
+	scheduler.Every("hourly", time.Hour, func(ctx context.Context) error {
+		runs <- fake.Now()
+		return nil
+	})
+	scheduler.Start(context.Background())
+	defer scheduler.Stop()
+
+	waiting := func() bool { return fake.Waiters() == 1 }
+	assert.Eventually(t, waiting, time.Second, time.Millisecond)
+	fake.Advance(59 * time.Minute)
+	assert.Empty(t, runs, "not due yet")
+
+	fake.Advance(time.Minute)
+	assert.Equal(t, time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC), <-runs)
+
+	// Runs missed while the clock jumped ahead are skipped
+	assert.Eventually(t, waiting, time.Second, time.Millisecond)
+	fake.Advance(3*time.Hour + 30*time.Minute)
+	<-runs
+	assert.Eventually(t, waiting, time.Second, time.Millisecond)
+	fake.Advance(30 * time.Minute)
+	assert.Equal(t, time.Date(2026, 9, 1, 14, 0, 0, 0, time.UTC), <-runs)
+}
+
+func TestScheduler_IgnoresDisabledTasks(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.Every("disabled", 0, func(ctx context.Context) error { return nil })
+
+	assert.Empty(t, scheduler.tasks)
+
+	scheduler.Start(context.Background())
+	scheduler.Stop()
+}
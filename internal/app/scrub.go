@@ -0,0 +1,187 @@
+package app
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// emailPattern matches email addresses in free text
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// phonePattern matches candidate phone numbers; matches are only masked when they contain
+// enough digits to avoid scrubbing years and version ranges
+var phonePattern = regexp.MustCompile(`\+?\d[\d\s\-()]{6,}\d`)
+
+// minPhoneDigits is the minimum number of digits for a match to be treated as a phone number
+const minPhoneDigits = 8
+
+// Kinds of text masked by the scrubber, as named in review findings
+const (
+	findingEmail       = "email"
+	findingPhone       = "phone"
+	findingBlockedWord = "blocked word"
+)
+
+// Scrubber masks emails, phone numbers and blocked words in the free-text fields of public documents.
+// Masked talks are reported with their findings so they can be fixed at the source.
+type Scrubber struct {
+	talkFields    []string
+	speakerFields []string
+	words         *regexp.Regexp
+}
+
+// NewScrubber creates a Scrubber from configuration
+func NewScrubber(cfg config.TransformConfig) *Scrubber {
+	scrubber := &Scrubber{
+		talkFields:    trimmedValues(cfg.ScrubFields),
+		speakerFields: trimmedValues(cfg.ScrubSpeakerFields),
+	}
+	var words []string
+	for _, word := range trimmedValues(cfg.ScrubWords) {
+		words = append(words, regexp.QuoteMeta(word))
+	}
+	if len(words) > 0 {
+		scrubber.words = regexp.MustCompile(`(?i)\b(?:` + strings.Join(words, "|") + `)\b`)
+	}
+	return scrubber
+}
+
+// Scrub returns the talk with personal details and blocked words masked in the configured fields,
+// along with findings such as "email in abstract". The given talk is not modified.
+func (s *Scrubber) Scrub(talk domain.Talk) (domain.Talk, []string) {
+	var findings []string
+
+	if data, found := s.scrubFields(talk.Data, s.talkFields); data != nil {
+		talk.Data = data
+		findings = append(findings, found...)
+	}
+
+	var speakers domain.Speakers
+	for i, speaker := range talk.Speakers {
+		data, found := s.scrubFields(speaker.Data, s.speakerFields)
+		if data == nil {
+			continue
+		}
+		if speakers == nil {
+			speakers = slices.Clone(talk.Speakers)
+		}
+		speakers[i].Data = data
+		for _, finding := range found {
+			findings = append(findings, "speaker "+finding+" ("+speaker.Name+")")
+		}
+	}
+	if speakers != nil {
+		talk.Speakers = speakers
+	}
+
+	return talk, findings
+}
+
+// scrubFields masks the given string fields, returning a copy of the data with findings,
+// or nil if nothing was masked
+func (s *Scrubber) scrubFields(data map[string]interface{}, fields []string) (map[string]interface{}, []string) {
+	var result map[string]interface{}
+	var findings []string
+	for _, field := range fields {
+		text, ok := data[field].(string)
+		if !ok {
+			continue
+		}
+		masked, kinds := s.maskText(text)
+		if len(kinds) == 0 {
+			continue
+		}
+		if result == nil {
+			result = maps.Clone(data)
+		}
+		result[field] = masked
+		for _, kind := range kinds {
+			findings = append(findings, kind+" in "+field)
+		}
+	}
+	return result, findings
+}
+
+// maskText masks emails, phone numbers and blocked words, returning the kinds of text masked
+func (s *Scrubber) maskText(text string) (string, []string) {
+	var kinds []string
+	if emailPattern.MatchString(text) {
+		text = emailPattern.ReplaceAllString(text, "[email]")
+		kinds = append(kinds, findingEmail)
+	}
+	if masked, count := maskPhoneNumbers(text); count > 0 {
+		text = masked
+		kinds = append(kinds, findingPhone)
+	}
+	if s.words != nil && s.words.MatchString(text) {
+		text = s.words.ReplaceAllString(text, "[removed]")
+		kinds = append(kinds, findingBlockedWord)
+	}
+	return text, kinds
+}
+
+// maskPhoneNumbers replaces phone numbers with a placeholder, returning the number replaced.
+// Digit runs attached to words or URLs, such as video IDs, are left alone.
+func maskPhoneNumbers(text string) (string, int) {
+	count := 0
+	var out strings.Builder
+	last := 0
+	for _, match := range phonePattern.FindAllStringIndex(text, -1) {
+		start, end := match[0], match[1]
+		if !isPhoneNumber(text, start, end) {
+			continue
+		}
+		out.WriteString(text[last:start])
+		out.WriteString("[phone]")
+		last = end
+		count++
+	}
+	if count == 0 {
+		return text, 0
+	}
+	out.WriteString(text[last:])
+	return out.String(), count
+}
+
+// isPhoneNumber returns true if the match has enough digits and stands apart from surrounding words
+func isPhoneNumber(text string, start, end int) bool {
+	digits := 0
+	for _, r := range text[start:end] {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	if digits < minPhoneDigits {
+		return false
+	}
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && (isWordRune(before) || strings.ContainsRune("/=.-_?&#%", before)) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && (isWordRune(after) || after == '/') {
+		return false
+	}
+	return true
+}
+
+// isWordRune returns true for letters and digits
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// trimmedValues returns the non-empty values with surrounding whitespace removed
+func trimmedValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScrubber() *Scrubber {
+	return NewScrubber(config.TransformConfig{
+		ScrubFields:        []string{"abstract", " abstractHtml "},
+		ScrubSpeakerFields: []string{"bio"},
+		ScrubWords:         []string{"darn", ""},
+	})
+}
+
+func TestScrubber_MasksTalkFields(t *testing.T) {
+	talk := domain.Talk{
+		ID: "talk-1",
+		Data: map[string]interface{}{
+			"title":        "Contact me at jane@example.com",
+			"abstract":     "Mail jane.doe@example.com or call +47 912 34 567. Darn fast! Java 8 - 21, 2014 to 2024.",
+			"abstractHtml": `<p>Mail <a href="mailto:jane.doe@example.com">me</a></p>`,
+		},
+	}
+
+	result, findings := testScrubber().Scrub(talk)
+
+	assert.Equal(t, "Mail [email] or call [phone]. [removed] fast! Java 8 - 21, 2014 to 2024.", result.Data["abstract"])
+	assert.Equal(t, `<p>Mail <a href="mailto:[email]">me</a></p>`, result.Data["abstractHtml"])
+	assert.Equal(t, "Contact me at jane@example.com", result.Data["title"], "only configured fields are scrubbed")
+	assert.Equal(t, []string{"email in abstract", "phone in abstract", "blocked word in abstract", "email in abstractHtml"}, findings)
+	assert.Contains(t, talk.Data["abstract"], "jane.doe@example.com", "the original talk is not modified")
+}
+
+func TestScrubber_MasksSpeakerFields(t *testing.T) {
+	talk := domain.Talk{
+		ID: "talk-1",
+		Speakers: domain.Speakers{
+			{Name: "Jane Doe", Data: map[string]interface{}{"bio": "Jane writes Java."}},
+			{Name: "John Roe", Data: map[string]interface{}{"bio": "Reach John on 98765432."}},
+		},
+	}
+
+	result, findings := testScrubber().Scrub(talk)
+
+	assert.Equal(t, "Jane writes Java.", result.Speakers[0].Data["bio"])
+	assert.Equal(t, "Reach John on [phone].", result.Speakers[1].Data["bio"])
+	assert.Equal(t, []string{"speaker phone in bio (John Roe)"}, findings)
+	assert.Equal(t, "Reach John on 98765432.", talk.Speakers[1].Data["bio"], "the original speakers are not modified")
+}
+
+func TestScrubber_LeavesCleanTextAlone(t *testing.T) {
+	talk := domain.Talk{
+		ID: "talk-1",
+		Data: map[string]interface{}{
+			"abstract": "Watch https://vimeo.com/123456789 or see ISBN978-3-16-148410-0, darning socks and Java 17 (2021).",
+		},
+	}
+
+	result, findings := testScrubber().Scrub(talk)
+
+	assert.Empty(t, findings)
+	assert.Equal(t, talk.Data["abstract"], result.Data["abstract"])
+}
+
+func TestReindexConference_ScrubsPublicAndFlagsForReview(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{
+				{ID: "talk-1", ConferenceSlug: "javazone2024", Speakers: domain.Speakers{{ID: "s1"}}, Status: "APPROVED", Data: map[string]interface{}{"abstract": "Mail jane@example.com"}},
+				{ID: "talk-2", ConferenceSlug: "javazone2024", Speakers: domain.Speakers{{ID: "s1"}}, Status: "APPROVED", Data: map[string]interface{}{"abstract": "Nothing personal"}},
+				{ID: "talk-3", ConferenceSlug: "javazone2024", Speakers: domain.Speakers{{ID: "s1"}}, Status: "SUBMITTED", Data: map[string]interface{}{"abstract": "Mail john@example.com"}},
+			}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+	jobs := newMockJobStore()
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetJobStore(jobs)
+	service.SetScrubber(testScrubber())
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	require.Len(t, index.bulkIndexCalls, 2)
+	assert.Equal(t, "Mail jane@example.com", index.bulkIndexCalls[0].Talks[0].Data["abstract"], "private index is not scrubbed")
+	assert.Equal(t, "Mail [email]", index.bulkIndexCalls[1].Talks[0].Data["abstract"], "masked instead of dropped by the email field filter")
+
+	assert.Equal(t, []domain.ReviewFlag{
+		{TalkID: "talk-1", ConferenceSlug: "javazone2024", Findings: []string{"email in abstract"}},
+	}, jobs.jobs["job-1"].Report.Review, "talks that are not public are not flagged")
+}
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// DefaultSearchSize is the number of hits returned when a search does not set a size
+const DefaultSearchSize = 20
+
+// maxSearchSize bounds the hits of one search page
+const maxSearchSize = 100
+
+// maxSearchWindow is the deepest hit a search can page to, matching the Elasticsearch result window
+const maxSearchWindow = 10000
+
+// searchFields are the talk fields matched by the search text, with the title and keywords weighted higher
+var searchFields = []string{"data.title^3", "data.keywords^2", "data.abstract"}
+
+// reviewScoreSort ranks hits by their review score, leaving unscored talks last, then by relevance.
+// Indexes written before review scores were mapped sort as if no talk had a score.
+var reviewScoreSort = []interface{}{
+	map[string]interface{}{"review.score": map[string]interface{}{"order": "desc", "missing": "_last", "unmapped_type": "float"}},
+	"_score",
+}
+
+// SearchService runs full-text searches for talks against the public index, or the private index for
+// logged-in users, so consumers do not need direct access to Elasticsearch. Searches are built from a
+// few parameters rather than the query DSL.
+type SearchService struct {
+	runner       ports.QueryRunner
+	privateIndex string
+	publicIndex  string
+	logger       *slog.Logger
+}
+
+// NewSearchService creates a new SearchService, receiving context as first parameter
+// to retrieve configuration.
+func NewSearchService(ctx context.Context, runner ports.QueryRunner) *SearchService {
+	cfg := config.GetConfig(ctx)
+	return NewSearchServiceWithConfig(runner, cfg.Index.PrivateName(), cfg.Index.PublicName())
+}
+
+// NewSearchServiceWithConfig creates a new SearchService with explicit index names.
+// This constructor is primarily intended for testing purposes.
+func NewSearchServiceWithConfig(runner ports.QueryRunner, privateIndex, publicIndex string) *SearchService {
+	return &SearchService{
+		runner:       runner,
+		privateIndex: privateIndex,
+		publicIndex:  publicIndex,
+		logger:       slog.Default().With("component", "search"),
+	}
+}
+
+// Search runs the search against the public index, or the private index for private searches
+func (s *SearchService) Search(ctx context.Context, req domain.SearchRequest) (domain.QueryResult, error) {
+	size := req.Size
+	if size == 0 {
+		size = DefaultSearchSize
+	}
+	if size < 1 || size > maxSearchSize {
+		return domain.QueryResult{}, invalidQuery("size must be between 1 and %d", maxSearchSize)
+	}
+	if req.From < 0 || req.From+size > maxSearchWindow {
+		return domain.QueryResult{}, invalidQuery("from must be at least 0 and from + size at most %d", maxSearchWindow)
+	}
+	if strings.TrimSpace(req.Reviewer) != "" && !req.Private {
+		return domain.QueryResult{}, invalidQuery("review assignments can only be searched by logged-in users")
+	}
+	if req.ReviewPending && strings.TrimSpace(req.Reviewer) == "" {
+		return domain.QueryResult{}, invalidQuery("pending reviews can only be searched for a reviewer")
+	}
+	switch req.Sort {
+	case "":
+	case domain.SearchSortReviewScore:
+		if !req.Private {
+			return domain.QueryResult{}, invalidQuery("review scores can only be sorted on by logged-in users")
+		}
+	default:
+		return domain.QueryResult{}, invalidQuery("sort must be %s", domain.SearchSortReviewScore)
+	}
+
+	indexName := s.publicIndex
+	if req.Private {
+		indexName = s.privateIndex
+	}
+
+	body := map[string]interface{}{
+		"size":             size,
+		"from":             req.From,
+		"track_total_hits": true,
+		"query":            searchQuery(req),
+	}
+	if req.Sort == domain.SearchSortReviewScore {
+		body["sort"] = reviewScoreSort
+	}
+
+	result, err := s.runner.RunQuery(ctx, indexName, body)
+	if err != nil {
+		return domain.QueryResult{}, fmt.Errorf("failed to search index %s: %w", indexName, err)
+	}
+
+	s.logger.InfoContext(ctx, "searched talks",
+		"index", indexName,
+		"conferenceSlug", req.ConferenceSlug,
+		"status", req.Status,
+		"seriesID", req.SeriesID,
+		"sort", req.Sort,
+		"total", result.Total,
+	)
+	return result, nil
+}
+
+// searchQuery translates the search request into a bool query. The text matches the search fields
+// or a speaker name; without text every talk matching the filters is a hit.
+func searchQuery(req domain.SearchRequest) map[string]interface{} {
+	filters := []interface{}{}
+	if req.ConferenceSlug != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": req.ConferenceSlug}})
+	}
+	if status := strings.ToUpper(strings.TrimSpace(req.Status)); status != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"status": status}})
+	}
+	if seriesID := strings.TrimSpace(req.SeriesID); seriesID != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"seriesId": seriesID}})
+	}
+	if reviewer := strings.ToLower(strings.TrimSpace(req.Reviewer)); reviewer != "" {
+		field := "review.reviewers"
+		if req.ReviewPending {
+			field = "review.pending"
+		}
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{field: reviewer}})
+	}
+
+	query := map[string]interface{}{"filter": filters}
+	if text := strings.TrimSpace(req.Text); text != "" {
+		query["should"] = []interface{}{
+			map[string]interface{}{"multi_match": map[string]interface{}{"query": text, "fields": searchFields}},
+			map[string]interface{}{"nested": map[string]interface{}{
+				"path":  "speakers",
+				"query": map[string]interface{}{"match": map[string]interface{}{"speakers.name": text}},
+			}},
+		}
+		query["minimum_should_match"] = 1
+	}
+
+	return map[string]interface{}{"bool": query}
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	t.Run("text and filters on the public index", func(t *testing.T) {
+		runner := &mockQueryRunner{result: domain.QueryResult{Total: 3}}
+		service := NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public")
+
+		result, err := service.Search(context.Background(), domain.SearchRequest{
+			Text:           "kotlin",
+			ConferenceSlug: "javazone2024",
+			Status:         "approved",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Total)
+		assert.Equal(t, "javazone_public", runner.indexName)
+		assert.Equal(t, DefaultSearchSize, runner.body["size"])
+
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": "javazone2024"}},
+			map[string]interface{}{"term": map[string]interface{}{"status": "APPROVED"}},
+		}, query["filter"])
+		assert.Len(t, query["should"], 2)
+		assert.Equal(t, 1, query["minimum_should_match"])
+	})
+
+	t.Run("private search without text", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public")
+
+		_, err := service.Search(context.Background(), domain.SearchRequest{Private: true, Size: 5, From: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, "javazone_private", runner.indexName)
+		assert.Equal(t, 5, runner.body["size"])
+		assert.Equal(t, 10, runner.body["from"])
+
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.NotContains(t, query, "should")
+		assert.Empty(t, query["filter"])
+	})
+
+	t.Run("series filter", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public")
+
+		_, err := service.Search(context.Background(), domain.SearchRequest{SeriesID: " kotlin-workshop "})
+
+		require.NoError(t, err)
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"seriesId": "kotlin-workshop"}},
+		}, query["filter"])
+	})
+
+	t.Run("reviewer filters", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public")
+
+		_, err := service.Search(context.Background(), domain.SearchRequest{Private: true, Reviewer: " Ada@JavaZone.no "})
+		require.NoError(t, err)
+		query := runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"review.reviewers": "ada@javazone.no"}},
+		}, query["filter"])
+
+		_, err = service.Search(context.Background(), domain.SearchRequest{Private: true, Reviewer: "ada@javazone.no", ReviewPending: true})
+		require.NoError(t, err)
+		query = runner.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"review.pending": "ada@javazone.no"}},
+		}, query["filter"])
+	})
+
+	t.Run("reviewer filters need a private search", func(t *testing.T) {
+		service := NewSearchServiceWithConfig(&mockQueryRunner{}, "javazone_private", "javazone_public")
+
+		_, err := service.Search(context.Background(), domain.SearchRequest{Reviewer: "ada@javazone.no"})
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+
+		_, err = service.Search(context.Background(), domain.SearchRequest{Private: true, ReviewPending: true})
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	})
+
+	t.Run("sorted by review score", func(t *testing.T) {
+		runner := &mockQueryRunner{}
+		service := NewSearchServiceWithConfig(runner, "javazone_private", "javazone_public")
+
+		_, err := service.Search(context.Background(), domain.SearchRequest{Private: true, Sort: domain.SearchSortReviewScore})
+		require.NoError(t, err)
+		assert.Equal(t, "javazone_private", runner.indexName)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"review.score": map[string]interface{}{"order": "desc", "missing": "_last", "unmapped_type": "float"}},
+			"_score",
+		}, runner.body["sort"])
+
+		_, err = service.Search(context.Background(), domain.SearchRequest{Private: true})
+		require.NoError(t, err)
+		assert.NotContains(t, runner.body, "sort")
+	})
+
+	t.Run("review score sort needs a private search", func(t *testing.T) {
+		service := NewSearchServiceWithConfig(&mockQueryRunner{}, "javazone_private", "javazone_public")
+
+		_, err := service.Search(context.Background(), domain.SearchRequest{Sort: domain.SearchSortReviewScore})
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+
+		_, err = service.Search(context.Background(), domain.SearchRequest{Private: true, Sort: "title"})
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		service := NewSearchServiceWithConfig(&mockQueryRunner{}, "javazone_private", "javazone_public")
+
+		_, err := service.Search(context.Background(), domain.SearchRequest{Size: 500})
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+
+		_, err = service.Search(context.Background(), domain.SearchRequest{From: maxSearchWindow})
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+	})
+}
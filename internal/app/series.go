@@ -0,0 +1,206 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// seriesKey is the settings key holding the series saved in the admin UI
+const seriesKey = "talks:series"
+
+// SeriesCatalogService manages the series linking related sessions, such as the parts of a workshop,
+// which the indexer writes onto their talks. Series come from an optional JSON file and from entries
+// saved in the admin UI, which are stored in a settings document and override the file series with
+// the same ID.
+type SeriesCatalogService struct {
+	store  ports.SettingsStore
+	file   map[string][]string
+	now    func() time.Time
+	logger *slog.Logger
+
+	mu sync.Mutex
+}
+
+// NewSeriesCatalogService creates a new SeriesCatalogService, receiving context as first parameter
+// to retrieve configuration. It fails if the configured series file cannot be read or is invalid.
+func NewSeriesCatalogService(ctx context.Context, store ports.SettingsStore) (*SeriesCatalogService, error) {
+	cfg := config.GetConfig(ctx)
+	file, err := LoadSeriesFile(cfg.Series.File)
+	if err != nil {
+		return nil, err
+	}
+	return NewSeriesCatalogServiceWithConfig(store, file), nil
+}
+
+// NewSeriesCatalogServiceWithConfig creates a new SeriesCatalogService with explicit file series.
+// This constructor is primarily intended for testing purposes.
+func NewSeriesCatalogServiceWithConfig(store ports.SettingsStore, file map[string][]string) *SeriesCatalogService {
+	return &SeriesCatalogService{
+		store:  store,
+		file:   file,
+		now:    time.Now,
+		logger: slog.Default().With("component", "series"),
+	}
+}
+
+// LoadSeriesFile reads a JSON object mapping series IDs to the IDs of their talks in order.
+// An empty path returns no series.
+func LoadSeriesFile(path string) (map[string][]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read series file: %w", err)
+	}
+
+	var entries map[string][]string
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse series file: %w", err)
+	}
+
+	file := make(map[string][]string, len(entries))
+	for id, talkIDs := range entries {
+		entry := domain.SeriesEntry{ID: strings.TrimSpace(id), TalkIDs: trimTalkIDs(talkIDs)}
+		if err := entry.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid series in %s: %w", path, err)
+		}
+		file[entry.ID] = entry.TalkIDs
+	}
+	return file, nil
+}
+
+// ListSeries returns all series sorted by ID, including series from the series file that are not overridden
+func (s *SeriesCatalogService) ListSeries(ctx context.Context) ([]domain.SeriesEntry, error) {
+	entries, err := s.loadEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, talkIDs := range s.file {
+		if !slices.ContainsFunc(entries, func(entry domain.SeriesEntry) bool { return entry.ID == id }) {
+			entries = append(entries, domain.SeriesEntry{ID: id, TalkIDs: talkIDs, FromFile: true})
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b domain.SeriesEntry) int { return strings.Compare(a.ID, b.ID) })
+	return entries, nil
+}
+
+// SetSeries stores the series with the talks in order, overriding any series with the same ID from the
+// series file. A talk can only be in one series. Indexed talks are linked on their next reindex.
+func (s *SeriesCatalogService) SetSeries(ctx context.Context, id string, talkIDs []string, updatedBy string) error {
+	entry := domain.SeriesEntry{ID: strings.TrimSpace(id), TalkIDs: trimTalkIDs(talkIDs), UpdatedAt: s.now().UTC(), UpdatedBy: updatedBy}
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.ListSeries(ctx)
+	if err != nil {
+		return err
+	}
+	for _, other := range entries {
+		if other.ID == entry.ID {
+			continue
+		}
+		for _, talkID := range entry.TalkIDs {
+			if slices.Contains(other.TalkIDs, talkID) {
+				return fmt.Errorf("talk %s is already in series %s", talkID, other.ID)
+			}
+		}
+	}
+
+	stored := slices.DeleteFunc(entries, func(other domain.SeriesEntry) bool { return other.FromFile || other.ID == entry.ID })
+	if err := s.saveEntries(ctx, append(stored, entry)); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "series set", "seriesID", entry.ID, "talks", len(entry.TalkIDs), "updatedBy", updatedBy)
+	return nil
+}
+
+// RemoveSeries removes a stored series. Series from the series file cannot be removed; removing an
+// override restores the file series.
+func (s *SeriesCatalogService) RemoveSeries(ctx context.Context, id string) error {
+	id = strings.TrimSpace(id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.loadEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := slices.DeleteFunc(entries, func(entry domain.SeriesEntry) bool { return entry.ID == id })
+	if len(remaining) == len(entries) {
+		if _, ok := s.file[id]; ok {
+			return fmt.Errorf("series %s is from the series file and cannot be removed here", id)
+		}
+		return fmt.Errorf("%w: %s", domain.ErrSeriesNotFound, id)
+	}
+
+	if err := s.saveEntries(ctx, remaining); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "series removed", "seriesID", id)
+	return nil
+}
+
+// loadEntries reads the series saved in the admin UI
+func (s *SeriesCatalogService) loadEntries(ctx context.Context) ([]domain.SeriesEntry, error) {
+	var entries []domain.SeriesEntry
+	if _, err := s.store.LoadSetting(ctx, seriesKey, &entries); err != nil {
+		return nil, fmt.Errorf("failed to load series: %w", err)
+	}
+	return entries, nil
+}
+
+// saveEntries stores the series saved in the admin UI
+func (s *SeriesCatalogService) saveEntries(ctx context.Context, entries []domain.SeriesEntry) error {
+	if err := s.store.SaveSetting(ctx, seriesKey, entries); err != nil {
+		return fmt.Errorf("failed to save series: %w", err)
+	}
+	return nil
+}
+
+// trimTalkIDs returns the talk IDs with surrounding space removed and empty IDs dropped
+func trimTalkIDs(talkIDs []string) []string {
+	trimmed := make([]string, 0, len(talkIDs))
+	for _, talkID := range talkIDs {
+		if talkID = strings.TrimSpace(talkID); talkID != "" {
+			trimmed = append(trimmed, talkID)
+		}
+	}
+	return trimmed
+}
+
+// SeriesFromField returns a TalkTransform linking talks by the series ID in the talk data field, for
+// talks not already in a series configured by admins
+func SeriesFromField(field string) TalkTransform {
+	return func(talk domain.Talk) domain.Talk {
+		if talk.SeriesID != "" {
+			return talk
+		}
+		if id := strings.TrimSpace(stringValue(talk.Data[field])); id != "" {
+			talk.SeriesID = id
+		}
+		return talk
+	}
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSeriesCatalog(store *mockSettingsStore) *SeriesCatalogService {
+	return NewSeriesCatalogServiceWithConfig(store, map[string][]string{
+		"kotlin-workshop": {"talk-1", "talk-2"},
+	})
+}
+
+func TestLoadSeriesFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("no file configured", func(t *testing.T) {
+		file, err := LoadSeriesFile("")
+		require.NoError(t, err)
+		assert.Nil(t, file)
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		path := filepath.Join(dir, "valid.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"kotlin-workshop": ["talk-1", " talk-2 "]}`), 0o600))
+
+		file, err := LoadSeriesFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"kotlin-workshop": {"talk-1", "talk-2"}}, file)
+	})
+
+	t.Run("single talk", func(t *testing.T) {
+		path := filepath.Join(dir, "single.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"kotlin-workshop": ["talk-1"]}`), 0o600))
+
+		_, err := LoadSeriesFile(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kotlin-workshop")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadSeriesFile(filepath.Join(dir, "missing.json"))
+		require.Error(t, err)
+	})
+}
+
+func TestSeriesCatalog_SetOverridesFile(t *testing.T) {
+	service := newTestSeriesCatalog(newMockSettingsStore())
+	ctx := context.Background()
+
+	require.NoError(t, service.SetSeries(ctx, " kotlin-workshop ", []string{"talk-2", "talk-1", "talk-3"}, "admin@example.com"))
+	require.NoError(t, service.SetSeries(ctx, "lightning-ai", []string{"talk-4", "talk-5"}, "admin@example.com"))
+
+	entries, err := service.ListSeries(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "kotlin-workshop", entries[0].ID)
+	assert.Equal(t, []string{"talk-2", "talk-1", "talk-3"}, entries[0].TalkIDs)
+	assert.False(t, entries[0].FromFile)
+	assert.Equal(t, "admin@example.com", entries[0].UpdatedBy)
+	assert.Equal(t, "lightning-ai", entries[1].ID)
+
+	require.NoError(t, service.RemoveSeries(ctx, "kotlin-workshop"))
+
+	entries, err = service.ListSeries(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, []string{"talk-1", "talk-2"}, entries[0].TalkIDs, "removing the override restores the file series")
+	assert.True(t, entries[0].FromFile)
+}
+
+func TestSeriesCatalog_SetValidates(t *testing.T) {
+	service := newTestSeriesCatalog(newMockSettingsStore())
+	ctx := context.Background()
+
+	assert.Error(t, service.SetSeries(ctx, "", []string{"talk-4", "talk-5"}, ""))
+	assert.Error(t, service.SetSeries(ctx, "lightning-ai", []string{"talk-4", " "}, ""))
+	assert.Error(t, service.SetSeries(ctx, "lightning-ai", []string{"talk-4", "talk-4"}, ""))
+
+	err := service.SetSeries(ctx, "lightning-ai", []string{"talk-4", "talk-1"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already in series kotlin-workshop")
+}
+
+func TestSeriesCatalog_Remove(t *testing.T) {
+	service := newTestSeriesCatalog(newMockSettingsStore())
+	ctx := context.Background()
+
+	err := service.RemoveSeries(ctx, "kotlin-workshop")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "series file")
+
+	assert.ErrorIs(t, service.RemoveSeries(ctx, "missing"), domain.ErrSeriesNotFound)
+}
+
+func TestSeriesFromField(t *testing.T) {
+	transform := SeriesFromField("seriesId")
+
+	talk := transform(domain.Talk{ID: "talk-1", Data: map[string]interface{}{"seriesId": " kotlin-workshop "}})
+	assert.Equal(t, "kotlin-workshop", talk.SeriesID)
+	assert.Zero(t, talk.SeriesPart)
+
+	talk = transform(domain.Talk{ID: "talk-1", SeriesID: "configured", SeriesPart: 2, Data: map[string]interface{}{"seriesId": "kotlin-workshop"}})
+	assert.Equal(t, "configured", talk.SeriesID, "series configured by admins take precedence")
+
+	talk = transform(domain.Talk{ID: "talk-1"})
+	assert.Empty(t, talk.SeriesID)
+}
+
+func TestReindexConference_LinksSeries(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{
+				{ID: "talk-1", ConferenceSlug: "javazone2024", Status: "APPROVED", Data: map[string]interface{}{"seriesId": "ignored"}},
+				{ID: "talk-2", ConferenceSlug: "javazone2024", Status: "APPROVED"},
+				{ID: "talk-3", ConferenceSlug: "javazone2024", Status: "APPROVED", Data: map[string]interface{}{"seriesId": "lightning-ai"}},
+			}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetSeriesCatalog(newTestSeriesCatalog(newMockSettingsStore()))
+	service.AddTransform(SeriesFromField("seriesId"))
+	require.NoError(t, service.ReindexConference(context.Background(), "javazone2024"))
+
+	require.Len(t, index.bulkIndexCalls, 2)
+	for _, call := range index.bulkIndexCalls {
+		require.Len(t, call.Talks, 3, call.IndexName)
+		assert.Equal(t, "kotlin-workshop", call.Talks[0].SeriesID, call.IndexName)
+		assert.Equal(t, 1, call.Talks[0].SeriesPart, call.IndexName)
+		assert.Equal(t, "kotlin-workshop", call.Talks[1].SeriesID, call.IndexName)
+		assert.Equal(t, 2, call.Talks[1].SeriesPart, call.IndexName)
+		assert.Equal(t, "lightning-ai", call.Talks[2].SeriesID, call.IndexName)
+		assert.Zero(t, call.Talks[2].SeriesPart, call.IndexName)
+	}
+}
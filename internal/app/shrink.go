@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// ShrinkGuard refuses to replace the live public index with a dataset holding far fewer talks, the
+// usual sign of an accidentally empty or truncated upstream response rather than of talks being
+// withdrawn. Callers that mean it override the guard with domain.WithShrinkAllowed.
+type ShrinkGuard struct {
+	versions         ports.IndexVersioner
+	maxShrinkPercent int
+	logger           *slog.Logger
+}
+
+// NewShrinkGuard creates a new ShrinkGuard, receiving context as first parameter
+// to retrieve configuration.
+func NewShrinkGuard(ctx context.Context, versions ports.IndexVersioner) *ShrinkGuard {
+	return NewShrinkGuardWithConfig(versions, config.GetConfig(ctx).Reindex)
+}
+
+// NewShrinkGuardWithConfig creates a new ShrinkGuard with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewShrinkGuardWithConfig(versions ports.IndexVersioner, cfg config.ReindexConfig) *ShrinkGuard {
+	return &ShrinkGuard{
+		versions:         versions,
+		maxShrinkPercent: cfg.MaxShrinkPercent,
+		logger:           slog.Default().With("component", "shrink-guard"),
+	}
+}
+
+// Check compares the number of documents about to replace the index with the documents it holds, and
+// returns an error wrapping domain.ErrIndexShrink if more than the allowed share would be lost.
+// It returns the number of documents in the live index.
+func (g *ShrinkGuard) Check(ctx context.Context, indexName string, documents int) (int64, error) {
+	version, err := g.versions.IndexVersion(ctx, indexName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count the documents in %s: %w", indexName, err)
+	}
+
+	before := version.DocCount
+	if g.allows(before, int64(documents)) {
+		return before, nil
+	}
+
+	g.logger.WarnContext(ctx, "refused to shrink live index",
+		"index", indexName,
+		"before", before,
+		"after", documents,
+		"maxShrinkPercent", g.maxShrinkPercent,
+	)
+	return before, fmt.Errorf("%w: %s would drop from %d to %d documents, more than the %d%% allowed",
+		domain.ErrIndexShrink, indexName, before, documents, g.maxShrinkPercent)
+}
+
+// CheckRemoval returns an error wrapping domain.ErrIndexShrink if removing documents from the
+// documents a reindex found in an index would lose more than the allowed share
+func (g *ShrinkGuard) CheckRemoval(ctx context.Context, indexName string, indexed, removed int) error {
+	if g.allows(int64(indexed), int64(indexed-removed)) {
+		return nil
+	}
+
+	g.logger.WarnContext(ctx, "refused to remove talks from index",
+		"index", indexName,
+		"indexed", indexed,
+		"removed", removed,
+		"maxShrinkPercent", g.maxShrinkPercent,
+	)
+	return fmt.Errorf("%w: removing %d of the %d talks in %s is more than the %d%% allowed",
+		domain.ErrIndexShrink, removed, indexed, indexName, g.maxShrinkPercent)
+}
+
+// allows reports whether going from before to after documents stays within the allowed shrink
+func (g *ShrinkGuard) allows(before, after int64) bool {
+	return after*100 >= before*int64(100-g.maxShrinkPercent)
+}
+
+// SetShrinkGuard enables refusing full reindexes that would shrink the live public index too much
+func (s *IndexerService) SetShrinkGuard(guard *ShrinkGuard) {
+	s.shrink = guard
+}
+
+// checkShrink verifies that the public talks do not shrink the live public index too much, if a
+// shrink guard is set and the index exists. An override by the caller is logged and reported.
+func (s *IndexerService) checkShrink(ctx context.Context, publicTalks int) error {
+	if s.shrink == nil {
+		return nil
+	}
+	exists, err := s.searchIndex.IndexExists(ctx, s.publicIndex)
+	if err != nil {
+		return fmt.Errorf("failed to check the live public index: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+
+	before, err := s.shrink.Check(ctx, s.publicIndex, publicTalks)
+	step := domain.JobStep{
+		Name:       "check shrink",
+		State:      domain.JobStepSucceeded,
+		Detail:     fmt.Sprintf("public talks %d → %d", before, publicTalks),
+		FinishedAt: time.Now().UTC(),
+	}
+	switch {
+	case err != nil && domain.ShrinkAllowed(ctx):
+		s.logger.WarnContext(ctx, "shrinking live public index on request", "actor", domain.ActorFromContext(ctx), "error", err)
+		step.Detail += ", overridden on request"
+		err = nil
+	case err != nil:
+		step.State = domain.JobStepFailed
+		step.Error = err.Error()
+	}
+
+	if report := jobReportFromContext(ctx); report != nil {
+		report.step(step)
+	}
+	return err
+}
+
+// checkRemoval verifies that removing talks deleted in the source does not empty an index too much, if
+// a shrink guard is set. An override by the caller is logged.
+func (s *IndexerService) checkRemoval(ctx context.Context, indexName string, indexed, removed int) error {
+	if s.shrink == nil || removed == 0 {
+		return nil
+	}
+	err := s.shrink.CheckRemoval(ctx, indexName, indexed, removed)
+	if err != nil && domain.ShrinkAllowed(ctx) {
+		s.logger.WarnContext(ctx, "removing talks on request", "actor", domain.ActorFromContext(ctx), "error", err)
+		return nil
+	}
+	return err
+}
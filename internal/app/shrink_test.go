@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIndexVersioner is a mock implementation of ports.IndexVersioner
+type mockIndexVersioner struct {
+	docCount int64
+	version  int64
+}
+
+func (m *mockIndexVersioner) IndexVersion(ctx context.Context, indexName string) (domain.IndexVersion, error) {
+	return domain.IndexVersion{Index: indexName, Version: m.version, DocCount: m.docCount}, nil
+}
+
+func TestShrinkGuard_Check(t *testing.T) {
+	guard := NewShrinkGuardWithConfig(&mockIndexVersioner{docCount: 100}, config.ReindexConfig{MaxShrinkPercent: 50})
+
+	tests := []struct {
+		name      string
+		documents int
+		wantError bool
+	}{
+		{name: "grows", documents: 120},
+		{name: "shrinks within limit", documents: 50},
+		{name: "shrinks beyond limit", documents: 49, wantError: true},
+		{name: "empty", documents: 0, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := guard.Check(context.Background(), "public", tt.documents)
+
+			assert.Equal(t, int64(100), before)
+			if tt.wantError {
+				assert.ErrorIs(t, err, domain.ErrIndexShrink)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReindexAll_ShrinkGuard(t *testing.T) {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Name: "Conference 1", Slug: "conf1"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return []domain.Talk{{ID: "talk-1", Status: "APPROVED"}}, nil
+		},
+	}
+
+	t.Run("refuses to shrink the live index", func(t *testing.T) {
+		index := &mockSearchIndex{}
+		service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+		service.SetShrinkGuard(NewShrinkGuardWithConfig(&mockIndexVersioner{docCount: 10}, config.ReindexConfig{MaxShrinkPercent: 50}))
+
+		err := service.ReindexAll(context.Background())
+
+		require.ErrorIs(t, err, domain.ErrIndexShrink)
+		assert.NotContains(t, index.deleteIndexCalls, "public")
+		assert.Empty(t, index.callsTo("public"))
+	})
+
+	t.Run("shrinks when overridden", func(t *testing.T) {
+		index := &mockSearchIndex{}
+		service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+		service.SetShrinkGuard(NewShrinkGuardWithConfig(&mockIndexVersioner{docCount: 10}, config.ReindexConfig{MaxShrinkPercent: 50}))
+
+		err := service.ReindexAll(domain.WithShrinkAllowed(context.Background()))
+
+		require.NoError(t, err)
+		assert.Len(t, index.callsTo("public"), 1)
+	})
+
+	t.Run("skips a missing live index", func(t *testing.T) {
+		index := &mockSearchIndex{
+			indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) { return false, nil },
+		}
+		service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+		service.SetShrinkGuard(NewShrinkGuardWithConfig(&mockIndexVersioner{docCount: 10}, config.ReindexConfig{MaxShrinkPercent: 50}))
+
+		require.NoError(t, service.ReindexAll(context.Background()))
+	})
+}
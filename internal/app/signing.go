@@ -0,0 +1,86 @@
+package app
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// Signer signs exported content with an ed25519 key
+type Signer struct {
+	privateKey ed25519.PrivateKey
+	key        domain.SigningKey
+}
+
+// NewSigner creates a Signer from the configured base64-encoded seed or private key
+func NewSigner(cfg config.SigningConfig) (*Signer, error) {
+	raw, err := base64.StdEncoding.DecodeString(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signing key: %w", err)
+	}
+
+	var privateKey ed25519.PrivateKey
+	switch len(raw) {
+	case ed25519.SeedSize:
+		privateKey = ed25519.NewKeyFromSeed(raw)
+	case ed25519.PrivateKeySize:
+		privateKey = ed25519.PrivateKey(raw)
+	default:
+		return nil, fmt.Errorf("invalid signing key length %d, expected %d or %d bytes", len(raw), ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
+
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	keyID := cfg.KeyID
+	if keyID == "" {
+		sum := sha256.Sum256(publicKey)
+		keyID = hex.EncodeToString(sum[:])[:16]
+	}
+
+	return &Signer{
+		privateKey: privateKey,
+		key: domain.SigningKey{
+			KeyID:     keyID,
+			Algorithm: domain.SigningAlgorithmEd25519,
+			PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+		},
+	}, nil
+}
+
+// Sign returns the base64-encoded ed25519 signature of the payload
+func (s *Signer) Sign(payload []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, payload))
+}
+
+// PublicKey returns the published verification key
+func (s *Signer) PublicKey() domain.SigningKey {
+	return s.key
+}
+
+// VerifySignature checks a base64-encoded signature of the payload against a published key
+func VerifySignature(key domain.SigningKey, payload []byte, signature string) error {
+	if key.Algorithm != domain.SigningAlgorithmEd25519 {
+		return fmt.Errorf("unsupported signing algorithm: %s", key.Algorithm)
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid public key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), payload, sig) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSeed is a fixed ed25519 seed so key IDs are stable across runs
+var testSeed = base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize))
+
+func TestNewSigner(t *testing.T) {
+	t.Run("from seed", func(t *testing.T) {
+		signer, err := NewSigner(config.SigningConfig{PrivateKey: testSeed})
+		require.NoError(t, err)
+
+		key := signer.PublicKey()
+		assert.Equal(t, domain.SigningAlgorithmEd25519, key.Algorithm)
+		assert.Len(t, key.KeyID, 16)
+		assert.NotEmpty(t, key.PublicKey)
+	})
+
+	t.Run("from full private key with explicit key ID", func(t *testing.T) {
+		privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+		signer, err := NewSigner(config.SigningConfig{
+			PrivateKey: base64.StdEncoding.EncodeToString(privateKey),
+			KeyID:      "2024-09",
+		})
+		require.NoError(t, err)
+
+		seedSigner, err := NewSigner(config.SigningConfig{PrivateKey: testSeed})
+		require.NoError(t, err)
+
+		assert.Equal(t, "2024-09", signer.PublicKey().KeyID)
+		assert.Equal(t, seedSigner.PublicKey().PublicKey, signer.PublicKey().PublicKey)
+	})
+
+	t.Run("invalid base64", func(t *testing.T) {
+		_, err := NewSigner(config.SigningConfig{PrivateKey: "not base64!"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		_, err := NewSigner(config.SigningConfig{PrivateKey: base64.StdEncoding.EncodeToString([]byte("short"))})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid signing key length")
+	})
+}
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	signer, err := NewSigner(config.SigningConfig{PrivateKey: testSeed})
+	require.NoError(t, err)
+
+	payload := []byte(`{"sessions":[]}` + "\n")
+	signature := signer.Sign(payload)
+
+	assert.NoError(t, VerifySignature(signer.PublicKey(), payload, signature))
+	assert.Error(t, VerifySignature(signer.PublicKey(), []byte(`{"sessions":[{}]}`), signature))
+	assert.Error(t, VerifySignature(signer.PublicKey(), payload, "invalid"))
+
+	otherKey := signer.PublicKey()
+	otherKey.Algorithm = "rsa"
+	assert.Error(t, VerifySignature(otherKey, payload, signature))
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// siteRenderConcurrency is the number of documents sent to the website's preview renderer in parallel
+const siteRenderConcurrency = 4
+
+// SitePreviewService checks the public documents talks get once they are published against the fields
+// the website needs, and renders them with the website's own preview renderer if one is set, so missing
+// fields are caught before publication day rather than on the program page. The documents are built from
+// moresleep the same way a reindex builds them; nothing is indexed.
+type SitePreviewService struct {
+	indexer        *IndexerService
+	renderer       ports.SiteRenderer
+	requiredFields []string
+	speakerFields  []string
+	now            func() time.Time
+	logger         *slog.Logger
+}
+
+// NewSitePreviewService creates a new SitePreviewService, receiving context as first parameter
+// to retrieve configuration.
+func NewSitePreviewService(ctx context.Context, indexer *IndexerService) *SitePreviewService {
+	cfg := config.GetConfig(ctx)
+	return NewSitePreviewServiceWithConfig(indexer, cfg.SitePreview)
+}
+
+// NewSitePreviewServiceWithConfig creates a new SitePreviewService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewSitePreviewServiceWithConfig(indexer *IndexerService, cfg config.SitePreviewConfig) *SitePreviewService {
+	return &SitePreviewService{
+		indexer:        indexer,
+		requiredFields: cfg.RequiredFields,
+		speakerFields:  cfg.RequiredSpeakerFields,
+		now:            time.Now,
+		logger:         slog.Default().With("component", "site-preview"),
+	}
+}
+
+// SetRenderer enables rendering documents with the website's preview renderer
+func (s *SitePreviewService) SetRenderer(renderer ports.SiteRenderer) {
+	s.renderer = renderer
+}
+
+// PreviewConference checks the talks of the conference with a public status, rendering each with the
+// website's preview renderer if set to catch documents it fails on. The rendered pages are not kept.
+func (s *SitePreviewService) PreviewConference(ctx context.Context, slug string) (domain.SitePreviewReport, error) {
+	conference, err := s.indexer.conferenceBySlug(ctx, slug)
+	if err != nil {
+		return domain.SitePreviewReport{}, err
+	}
+	talks, err := s.indexer.source.GetTalks(ctx, conference.ID)
+	if err != nil {
+		return domain.SitePreviewReport{}, fmt.Errorf("failed to fetch talks for conference %s: %w", slug, err)
+	}
+	talks = slices.DeleteFunc(talks, func(talk domain.Talk) bool { return !domain.TalkStatus(talk.Status).IsPublic() })
+
+	report := domain.SitePreviewReport{
+		ConferenceSlug: slug,
+		Talks:          make([]domain.SitePreview, len(talks)),
+		Rendered:       s.renderer != nil,
+		CheckedAt:      s.now().UTC(),
+	}
+	for i, document := range s.indexer.publicDocuments(ctx, talks) {
+		report.Talks[i] = s.check(document)
+	}
+	if s.renderer != nil {
+		s.renderAll(ctx, report.Talks)
+	}
+
+	slices.SortStableFunc(report.Talks, func(a, b domain.SitePreview) int {
+		if a.Ready() != b.Ready() {
+			if a.Ready() {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+
+	s.logger.InfoContext(ctx, "previewed conference on the website",
+		"slug", slug,
+		"talks", len(report.Talks),
+		"notReady", report.NotReady(),
+		"rendered", report.Rendered,
+	)
+	return report, nil
+}
+
+// PreviewTalk checks a single talk, whatever its status, and renders it with the website's preview
+// renderer if set, keeping the rendered page
+func (s *SitePreviewService) PreviewTalk(ctx context.Context, talkID string) (domain.SitePreview, error) {
+	talk, err := s.indexer.source.GetTalk(ctx, talkID)
+	if err != nil {
+		return domain.SitePreview{}, fmt.Errorf("failed to fetch talk %s: %w", talkID, err)
+	}
+
+	preview := s.check(s.indexer.publicDocuments(ctx, []domain.Talk{*talk})[0])
+	if s.renderer != nil {
+		s.render(ctx, &preview)
+	}
+	return preview, nil
+}
+
+// check returns the site preview of a public document with the required fields it lacks
+func (s *SitePreviewService) check(document domain.Talk) domain.SitePreview {
+	preview := domain.SitePreview{
+		TalkID: document.ID,
+		Title:  stringValue(document.Data["title"]),
+		Status: document.Status,
+		Talk:   document,
+	}
+	for _, field := range s.requiredFields {
+		if isBlank(document.Data[field]) {
+			preview.Missing = append(preview.Missing, field)
+		}
+	}
+	if len(document.Speakers) == 0 {
+		preview.Missing = append(preview.Missing, "speakers")
+	}
+	for i, speaker := range document.Speakers {
+		name := speaker.Name
+		if strings.TrimSpace(name) == "" {
+			name = fmt.Sprintf("speaker %d", i+1)
+			preview.Missing = append(preview.Missing, "speaker name ("+name+")")
+		}
+		for _, field := range s.speakerFields {
+			if isBlank(speaker.Data[field]) {
+				preview.Missing = append(preview.Missing, "speaker "+field+" ("+name+")")
+			}
+		}
+	}
+	return preview
+}
+
+// render renders the document with the website's preview renderer, recording the page or the error
+func (s *SitePreviewService) render(ctx context.Context, preview *domain.SitePreview) {
+	preview.Rendered = true
+	html, err := s.renderer.RenderTalk(ctx, preview.Talk)
+	if err != nil {
+		s.logger.WarnContext(ctx, "website failed to render talk", "talkID", preview.TalkID, "error", err)
+		preview.RenderError = err.Error()
+		return
+	}
+	preview.HTML = html
+}
+
+// renderAll renders the documents with a bounded number of concurrent requests, dropping the pages
+func (s *SitePreviewService) renderAll(ctx context.Context, previews []domain.SitePreview) {
+	var wg sync.WaitGroup
+	queue := make(chan int)
+	for range min(siteRenderConcurrency, len(previews)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				s.render(ctx, &previews[i])
+				previews[i].HTML = ""
+			}
+		}()
+	}
+	for i := range previews {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// isBlank returns true if a data field is absent, null, an empty string or an empty list
+func isBlank(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSiteRenderer is a mock implementation of ports.SiteRenderer
+type mockSiteRenderer struct {
+	renderFunc func(ctx context.Context, talk domain.Talk) (string, error)
+}
+
+func (m *mockSiteRenderer) RenderTalk(ctx context.Context, talk domain.Talk) (string, error) {
+	return m.renderFunc(ctx, talk)
+}
+
+// sitePreviewTestTalks returns a complete talk, a talk without abstract whose speaker has no bio, and a
+// rejected talk that is never published
+func sitePreviewTestTalks() []domain.Talk {
+	return []domain.Talk{
+		{ID: "talk-1", ConferenceSlug: "javazone2025", Status: "APPROVED",
+			Speakers: domain.Speakers{{ID: "s1", Name: "Duke", Data: map[string]interface{}{"bio": "Mascot"}}},
+			Data:     map[string]interface{}{"title": "Records", "abstract": "Data carriers", "format": "presentation"}},
+		{ID: "talk-2", ConferenceSlug: "javazone2025", Status: "APPROVED",
+			Speakers: domain.Speakers{{ID: "s2", Name: "Jane Doe", Data: map[string]interface{}{"bio": "  "}}},
+			Data:     map[string]interface{}{"title": "Loom", "format": "lightning-talk"}},
+		{ID: "talk-3", ConferenceSlug: "javazone2025", Status: "REJECTED",
+			Data: map[string]interface{}{"title": "Applets"}},
+	}
+}
+
+func newTestSitePreviewService() *SitePreviewService {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2025"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return sitePreviewTestTalks(), nil
+		},
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			for _, talk := range sitePreviewTestTalks() {
+				if talk.ID == talkID {
+					return &talk, nil
+				}
+			}
+			return nil, errors.New("talk not found in moresleep")
+		},
+	}
+	indexer := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service := NewSitePreviewServiceWithConfig(indexer, config.SitePreviewConfig{
+		RequiredFields:        []string{"title", "abstract", "format"},
+		RequiredSpeakerFields: []string{"bio"},
+	})
+	service.now = func() time.Time { return time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC) }
+	return service
+}
+
+func TestSitePreviewService_PreviewConference(t *testing.T) {
+	service := newTestSitePreviewService()
+
+	report, err := service.PreviewConference(context.Background(), "javazone2025")
+	require.NoError(t, err)
+
+	assert.Equal(t, "javazone2025", report.ConferenceSlug)
+	assert.False(t, report.Rendered)
+	assert.Equal(t, time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC), report.CheckedAt)
+	require.Len(t, report.Talks, 2, "talks that are not published are left out")
+	assert.Equal(t, 1, report.NotReady())
+
+	assert.Equal(t, "talk-2", report.Talks[0].TalkID, "talks that are not ready come first")
+	assert.Equal(t, "Loom", report.Talks[0].Title)
+	assert.Equal(t, []string{"abstract", "speaker bio (Jane Doe)"}, report.Talks[0].Missing)
+	assert.Equal(t, "talk-1", report.Talks[1].TalkID)
+	assert.True(t, report.Talks[1].Ready())
+}
+
+func TestSitePreviewService_PreviewConference_Rendered(t *testing.T) {
+	service := newTestSitePreviewService()
+	service.SetRenderer(&mockSiteRenderer{
+		renderFunc: func(ctx context.Context, talk domain.Talk) (string, error) {
+			assert.Nil(t, talk.PrivateData, "the renderer gets the public document")
+			if talk.ID == "talk-1" {
+				return "", errors.New("preview renderer returned 500 Internal Server Error: format unknown")
+			}
+			return "<h1>" + talk.Data["title"].(string) + "</h1>", nil
+		},
+	})
+
+	report, err := service.PreviewConference(context.Background(), "javazone2025")
+	require.NoError(t, err)
+
+	assert.True(t, report.Rendered)
+	assert.Equal(t, 2, report.NotReady())
+	for _, talk := range report.Talks {
+		assert.Empty(t, talk.HTML, "rendered pages are not kept for a whole conference")
+	}
+	assert.Equal(t, "talk-1", report.Talks[1].TalkID, "talks that are not ready are sorted by title")
+	assert.Equal(t, "preview renderer returned 500 Internal Server Error: format unknown", report.Talks[1].RenderError)
+}
+
+func TestSitePreviewService_PreviewConference_UnknownConference(t *testing.T) {
+	service := newTestSitePreviewService()
+
+	_, err := service.PreviewConference(context.Background(), "nosuchconf")
+
+	assert.ErrorIs(t, err, domain.ErrConferenceNotFound)
+}
+
+func TestSitePreviewService_PreviewTalk(t *testing.T) {
+	service := newTestSitePreviewService()
+
+	t.Run("embedded", func(t *testing.T) {
+		preview, err := service.PreviewTalk(context.Background(), "talk-3")
+		require.NoError(t, err)
+
+		assert.Equal(t, "REJECTED", preview.Status, "any talk can be previewed")
+		assert.Equal(t, []string{"abstract", "format", "speakers"}, preview.Missing)
+		assert.False(t, preview.Rendered)
+		assert.Empty(t, preview.HTML)
+	})
+
+	t.Run("rendered", func(t *testing.T) {
+		service.SetRenderer(&mockSiteRenderer{
+			renderFunc: func(ctx context.Context, talk domain.Talk) (string, error) {
+				return "<h1>Records</h1>", nil
+			},
+		})
+
+		preview, err := service.PreviewTalk(context.Background(), "talk-1")
+		require.NoError(t, err)
+
+		assert.True(t, preview.Ready())
+		assert.True(t, preview.Rendered)
+		assert.Equal(t, "<h1>Records</h1>", preview.HTML)
+	})
+
+	t.Run("unknown talk", func(t *testing.T) {
+		_, err := service.PreviewTalk(context.Background(), "missing")
+		assert.ErrorContains(t, err, "talk not found in moresleep")
+	})
+}
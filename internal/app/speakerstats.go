@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// otherGroup is the value small groups of speakers are counted under
+const otherGroup = "other"
+
+// SpeakerStatisticsService aggregates captured speaker attributes, such as residence, from the private
+// index for the program committee. Values shared by fewer speakers than the minimum group size are
+// counted as other, and the results are only returned, never indexed.
+type SpeakerStatisticsService struct {
+	reader       ports.TalkReader
+	privateIndex string
+	fields       []string
+	minGroupSize int
+	now          func() time.Time
+	logger       *slog.Logger
+}
+
+// NewSpeakerStatisticsService creates a new SpeakerStatisticsService, receiving context as first parameter
+// to retrieve configuration.
+func NewSpeakerStatisticsService(ctx context.Context, reader ports.TalkReader) *SpeakerStatisticsService {
+	cfg := config.GetConfig(ctx)
+	return NewSpeakerStatisticsServiceWithConfig(reader, cfg.Index.PrivateName(), cfg.SpeakerStats)
+}
+
+// NewSpeakerStatisticsServiceWithConfig creates a new SpeakerStatisticsService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewSpeakerStatisticsServiceWithConfig(reader ports.TalkReader, privateIndex string, cfg config.SpeakerStatsConfig) *SpeakerStatisticsService {
+	var fields []string
+	for _, field := range cfg.Fields {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return &SpeakerStatisticsService{
+		reader:       reader,
+		privateIndex: privateIndex,
+		fields:       fields,
+		minGroupSize: max(cfg.MinGroupSize, 1),
+		now:          time.Now,
+		logger:       slog.Default().With("component", "speaker-statistics"),
+	}
+}
+
+// SpeakerStatistics reads the requested talks from the private index and counts the distinct speakers
+// per value of each configured attribute. Values are compared case-insensitively.
+func (s *SpeakerStatisticsService) SpeakerStatistics(ctx context.Context, req domain.SpeakerStatisticsRequest) (*domain.SpeakerStatistics, error) {
+	var statuses []string
+	for _, status := range req.Statuses {
+		if status = strings.ToUpper(strings.TrimSpace(status)); status != "" && !slices.Contains(statuses, status) {
+			statuses = append(statuses, status)
+		}
+	}
+
+	talks, err := s.reader.FetchTalks(ctx, s.privateIndex, req.ConferenceSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from private index: %w", err)
+	}
+
+	stats := &domain.SpeakerStatistics{
+		GeneratedAt:    s.now().UTC(),
+		Index:          s.privateIndex,
+		ConferenceSlug: req.ConferenceSlug,
+		Statuses:       statuses,
+		MinGroupSize:   s.minGroupSize,
+	}
+
+	// Speakers are keyed by ID, keeping the first value captured for each attribute
+	speakers := make(map[string]map[string]string)
+	for _, talk := range talks {
+		if len(statuses) > 0 && !slices.Contains(statuses, talk.Status) {
+			continue
+		}
+		stats.Talks++
+
+		for _, speaker := range talk.Speakers {
+			key := speaker.ID
+			if key == "" {
+				key = "name:" + speaker.Name
+			}
+			values, ok := speakers[key]
+			if !ok {
+				values = make(map[string]string, len(s.fields))
+				speakers[key] = values
+			}
+			for _, field := range s.fields {
+				if values[field] == "" {
+					values[field] = speakerAttribute(speaker, field)
+				}
+			}
+		}
+	}
+	stats.Speakers = len(speakers)
+
+	for _, field := range s.fields {
+		counts := domain.SpeakerAttributeCounts{Field: field, Values: make(map[string]int)}
+		for _, values := range speakers {
+			if value := values[field]; value != "" {
+				counts.Values[value]++
+			} else {
+				counts.Unknown++
+			}
+		}
+		for value, count := range counts.Values {
+			if count < s.minGroupSize || value == otherGroup {
+				counts.Other += count
+				delete(counts.Values, value)
+			}
+		}
+		stats.Attributes = append(stats.Attributes, counts)
+	}
+
+	s.logger.InfoContext(ctx, "built speaker statistics", "conference", req.ConferenceSlug, "talks", stats.Talks, "speakers", stats.Speakers)
+	return stats, nil
+}
+
+// speakerAttribute returns the normalized value of a speaker data field, public or private
+func speakerAttribute(speaker domain.Speaker, field string) string {
+	for _, data := range []map[string]interface{}{speaker.Data, speaker.PrivateData} {
+		if value, ok := data[field].(string); ok {
+			if value = strings.ToLower(strings.Join(strings.Fields(value), " ")); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func residentSpeaker(id, residence string) domain.Speaker {
+	return domain.Speaker{ID: id, Data: map[string]interface{}{"residence": residence}}
+}
+
+func TestSpeakerStatisticsService_SpeakerStatistics(t *testing.T) {
+	var requestedSlug string
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			requestedSlug = conferenceSlug
+			return []domain.Talk{
+				{Status: "APPROVED", Speakers: []domain.Speaker{residentSpeaker("s1", "Oslo"), residentSpeaker("s2", "oslo ")}},
+				{Status: "APPROVED", Speakers: []domain.Speaker{
+					residentSpeaker("s1", "Bergen"),
+					{ID: "s3", PrivateData: map[string]interface{}{"residence": "Oslo", "gender": "F"}},
+				}},
+				{Status: "APPROVED", Speakers: []domain.Speaker{residentSpeaker("s4", "Trondheim"), {ID: "s5"}}},
+				{Status: "REJECTED", Speakers: []domain.Speaker{residentSpeaker("s6", "Bergen")}},
+			}, nil
+		},
+	}
+	service := NewSpeakerStatisticsServiceWithConfig(reader, "private", config.SpeakerStatsConfig{
+		Fields:       []string{"residence", " gender"},
+		MinGroupSize: 2,
+	})
+
+	stats, err := service.SpeakerStatistics(context.Background(), domain.SpeakerStatisticsRequest{
+		ConferenceSlug: "javazone2024",
+		Statuses:       []string{"approved"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"private"}, reader.fetchCalls)
+	assert.Equal(t, "javazone2024", requestedSlug)
+	assert.Equal(t, []string{"APPROVED"}, stats.Statuses)
+	assert.Equal(t, 3, stats.Talks)
+	assert.Equal(t, 5, stats.Speakers, "speakers of several talks count once")
+	assert.Equal(t, 2, stats.MinGroupSize)
+	assert.Equal(t, []domain.SpeakerAttributeCounts{
+		{Field: "residence", Values: map[string]int{"oslo": 3}, Other: 1, Unknown: 1},
+		{Field: "gender", Values: map[string]int{}, Other: 1, Unknown: 4},
+	}, stats.Attributes, "values of fewer speakers than the minimum group size are counted as other")
+}
+
+func TestSpeakerStatisticsService_Error(t *testing.T) {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	service := NewSpeakerStatisticsServiceWithConfig(reader, "private", config.SpeakerStatsConfig{Fields: []string{"residence"}})
+
+	_, err := service.SpeakerStatistics(context.Background(), domain.SpeakerStatisticsRequest{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch talks from private index")
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// Default and maximum number of suggestions, and the longest prefix completed
+const (
+	defaultSuggestions = 10
+	maxSuggestions     = 20
+	maxSuggestPrefix   = 100
+)
+
+// SuggestService completes what visitors type into the public search with the titles, speaker names
+// and keywords of the public talks, using the completion suggester of the public index
+type SuggestService struct {
+	suggester   ports.CompletionSuggester
+	publicIndex string
+	logger      *slog.Logger
+}
+
+// NewSuggestService creates a new SuggestService, receiving context as first parameter
+// to retrieve configuration.
+func NewSuggestService(ctx context.Context, suggester ports.CompletionSuggester) *SuggestService {
+	cfg := config.GetConfig(ctx)
+	return NewSuggestServiceWithConfig(suggester, cfg.Index.PublicName())
+}
+
+// NewSuggestServiceWithConfig creates a new SuggestService with an explicit index name.
+// This constructor is primarily intended for testing purposes.
+func NewSuggestServiceWithConfig(suggester ports.CompletionSuggester, publicIndex string) *SuggestService {
+	return &SuggestService{
+		suggester:   suggester,
+		publicIndex: publicIndex,
+		logger:      slog.Default().With("component", "suggest"),
+	}
+}
+
+// Suggest returns the distinct titles, speaker names and keywords of public talks starting with the
+// prefix, best match first. Leading whitespace of the prefix is ignored.
+func (s *SuggestService) Suggest(ctx context.Context, req domain.SuggestRequest) ([]string, error) {
+	prefix := strings.TrimLeft(req.Prefix, " \t")
+	if strings.TrimSpace(prefix) == "" {
+		return nil, invalidQuery("q is required")
+	}
+	if utf8.RuneCountInString(prefix) > maxSuggestPrefix {
+		return nil, invalidQuery("q must be at most %d characters", maxSuggestPrefix)
+	}
+	size := req.Size
+	if size == 0 {
+		size = defaultSuggestions
+	}
+	if size < 1 || size > maxSuggestions {
+		return nil, invalidQuery("size must be between 1 and %d", maxSuggestions)
+	}
+
+	suggestions, err := s.suggester.Suggest(ctx, s.publicIndex, prefix, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest talks: %w", err)
+	}
+	s.logger.DebugContext(ctx, "suggested talks", "prefix", prefix, "suggestions", len(suggestions))
+	return suggestions, nil
+}
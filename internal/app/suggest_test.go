@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCompletionSuggester records the last suggestion request and returns the suggestions or err
+type mockCompletionSuggester struct {
+	indexName   string
+	prefix      string
+	size        int
+	suggestions []string
+	err         error
+}
+
+func (m *mockCompletionSuggester) Suggest(ctx context.Context, indexName string, prefix string, size int) ([]string, error) {
+	m.indexName, m.prefix, m.size = indexName, prefix, size
+	return m.suggestions, m.err
+}
+
+func TestSuggestService_Suggest(t *testing.T) {
+	suggester := &mockCompletionSuggester{suggestions: []string{"Kotlin for Java developers", "kotlin"}}
+	service := NewSuggestServiceWithConfig(suggester, "javazone_public")
+
+	suggestions, err := service.Suggest(context.Background(), domain.SuggestRequest{Prefix: "  kotlin f"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Kotlin for Java developers", "kotlin"}, suggestions)
+	assert.Equal(t, "javazone_public", suggester.indexName)
+	assert.Equal(t, "kotlin f", suggester.prefix, "leading whitespace is ignored, inner whitespace kept")
+	assert.Equal(t, defaultSuggestions, suggester.size)
+}
+
+func TestSuggestService_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  domain.SuggestRequest
+	}{
+		{name: "missing prefix", req: domain.SuggestRequest{Prefix: " "}},
+		{name: "long prefix", req: domain.SuggestRequest{Prefix: strings.Repeat("k", maxSuggestPrefix+1)}},
+		{name: "size", req: domain.SuggestRequest{Prefix: "kot", Size: maxSuggestions + 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggester := &mockCompletionSuggester{}
+			service := NewSuggestServiceWithConfig(suggester, "javazone_public")
+
+			_, err := service.Suggest(context.Background(), tt.req)
+			assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+			assert.Empty(t, suggester.indexName, "invalid requests never reach the index")
+		})
+	}
+}
+
+func TestSuggestService_IndexError(t *testing.T) {
+	service := NewSuggestServiceWithConfig(&mockCompletionSuggester{err: errors.New("index unavailable")}, "javazone_public")
+
+	_, err := service.Suggest(context.Background(), domain.SuggestRequest{Prefix: "kot"})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrInvalidQuery)
+}
@@ -0,0 +1,250 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// summaryLastSentKey is the settings key holding the end of the period of the last summary sent
+const summaryLastSentKey = "summary:last-sent"
+
+// SummaryCheckInterval is how often the scheduler checks whether a summary is due
+const SummaryCheckInterval = 15 * time.Minute
+
+// SummaryService emails a daily or weekly summary of the indexing runs to the program committee leads:
+// the jobs that succeeded, the documents written, the talks approved and the jobs that failed. The end
+// of the last period sent is stored, so restarts neither skip nor repeat a summary.
+type SummaryService struct {
+	jobs       ports.JobStore
+	trends     ports.TalkTrends
+	store      ports.SettingsStore
+	mailer     ports.MailSender
+	recipients []string
+	period     string
+	weekday    time.Weekday
+	hour       int
+	location   *time.Location
+	now        func() time.Time
+	logger     *slog.Logger
+}
+
+// NewSummaryService creates a new SummaryService, receiving context as first parameter to retrieve
+// configuration. It fails if the period, weekday, hour or time zone is invalid.
+func NewSummaryService(ctx context.Context, jobs ports.JobStore, trends ports.TalkTrends, store ports.SettingsStore, mailer ports.MailSender) (*SummaryService, error) {
+	cfg := config.GetConfig(ctx)
+	return NewSummaryServiceWithConfig(jobs, trends, store, mailer, cfg.Summary)
+}
+
+// NewSummaryServiceWithConfig creates a new SummaryService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewSummaryServiceWithConfig(jobs ports.JobStore, trends ports.TalkTrends, store ports.SettingsStore, mailer ports.MailSender, cfg config.SummaryConfig) (*SummaryService, error) {
+	if cfg.Period != config.SummaryDaily && cfg.Period != config.SummaryWeekly {
+		return nil, fmt.Errorf("invalid summary period %q, must be %q or %q", cfg.Period, config.SummaryDaily, config.SummaryWeekly)
+	}
+	weekday, err := parseWeekday(cfg.Weekday)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Hour < 0 || cfg.Hour > 23 {
+		return nil, fmt.Errorf("invalid summary hour %d", cfg.Hour)
+	}
+	location, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid summary time zone %q: %w", cfg.TimeZone, err)
+	}
+
+	return &SummaryService{
+		jobs:       jobs,
+		trends:     trends,
+		store:      store,
+		mailer:     mailer,
+		recipients: cfg.Recipients,
+		period:     cfg.Period,
+		weekday:    weekday,
+		hour:       cfg.Hour,
+		location:   location,
+		now:        time.Now,
+		logger:     slog.Default().With("component", "summary"),
+	}, nil
+}
+
+// SetClock replaces the system clock deciding when a summary is due
+func (s *SummaryService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// SendDueSummary sends the summary of the last period if it has not been sent yet. The first check
+// after enabling the summary only records the current period, so the first summary covers a full one.
+// A summary that fails to send is tried again on the next check.
+func (s *SummaryService) SendDueSummary(ctx context.Context) error {
+	due := s.lastDue(s.now())
+
+	var lastSent time.Time
+	found, err := s.store.LoadSetting(ctx, summaryLastSentKey, &lastSent)
+	if err != nil {
+		return fmt.Errorf("failed to load the last summary sent: %w", err)
+	}
+	if !found {
+		return s.store.SaveSetting(ctx, summaryLastSentKey, due)
+	}
+	if !lastSent.Before(due) {
+		return nil
+	}
+
+	from := due.AddDate(0, 0, -1)
+	if s.period == config.SummaryWeekly {
+		from = due.AddDate(0, 0, -7)
+	}
+	summary, err := s.Summarize(ctx, from, due)
+	if err != nil {
+		return err
+	}
+
+	subject, body := s.format(summary)
+	if err := s.mailer.SendMail(ctx, s.recipients, subject, body); err != nil {
+		return fmt.Errorf("failed to send summary email: %w", err)
+	}
+	s.logger.InfoContext(ctx, "sent summary email", "from", from, "to", due, "recipients", len(s.recipients))
+
+	if err := s.store.SaveSetting(ctx, summaryLastSentKey, due); err != nil {
+		return fmt.Errorf("failed to save the last summary sent: %w", err)
+	}
+	return nil
+}
+
+// Summarize describes the jobs that finished from the start of the period until its end, and the talks
+// approved in between according to the daily talk count snapshots
+func (s *SummaryService) Summarize(ctx context.Context, from, to time.Time) (domain.IndexingSummary, error) {
+	summary := domain.IndexingSummary{From: from, To: to, Indexed: make(map[string]int)}
+
+	jobs, err := s.jobs.ListJobs(ctx, 0)
+	if err != nil {
+		return summary, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs {
+		if job.FinishedAt == nil || job.FinishedAt.Before(from) || !job.FinishedAt.Before(to) {
+			continue
+		}
+		for index, count := range job.Report.Indexed {
+			summary.Indexed[index] += count
+		}
+		if job.State == domain.JobStateFailed {
+			summary.Failures = append(summary.Failures, job)
+		} else {
+			summary.Succeeded++
+		}
+	}
+	slices.SortFunc(summary.Failures, func(a, b domain.Job) int { return a.FinishedAt.Compare(*b.FinishedAt) })
+
+	if s.trends == nil {
+		return summary, nil
+	}
+	trends, err := s.trends.TalkCountTrends(ctx)
+	if err != nil {
+		return summary, err
+	}
+	fromDate, toDate := from.UTC().Format(time.DateOnly), to.UTC().Format(time.DateOnly)
+	for _, trend := range trends {
+		var start, end *domain.TalkCountSnapshot
+		for i, snapshot := range trend.Snapshots {
+			if snapshot.Date < fromDate {
+				start = &trend.Snapshots[i]
+			}
+			if snapshot.Date >= fromDate && snapshot.Date <= toDate {
+				end = &trend.Snapshots[i]
+			}
+		}
+		if end == nil {
+			continue
+		}
+
+		approvals := domain.ConferenceApprovals{
+			ConferenceSlug: trend.ConferenceSlug,
+			ConferenceName: trend.ConferenceName,
+			Approved:       end.ByStatus[string(domain.StatusApproved)],
+		}
+		approvals.New = approvals.Approved
+		if start != nil {
+			approvals.New = max(0, approvals.Approved-start.ByStatus[string(domain.StatusApproved)])
+		}
+		summary.Approvals = append(summary.Approvals, approvals)
+	}
+	return summary, nil
+}
+
+// lastDue returns the most recent time a summary was due at or before now
+func (s *SummaryService) lastDue(now time.Time) time.Time {
+	now = now.In(s.location)
+	due := time.Date(now.Year(), now.Month(), now.Day(), s.hour, 0, 0, 0, s.location)
+	if s.period == config.SummaryWeekly {
+		due = due.AddDate(0, 0, -((int(now.Weekday()) - int(s.weekday) + 7) % 7))
+		if due.After(now) {
+			due = due.AddDate(0, 0, -7)
+		}
+		return due
+	}
+	if due.After(now) {
+		due = due.AddDate(0, 0, -1)
+	}
+	return due
+}
+
+// format returns the subject and plain text body of the summary email
+func (s *SummaryService) format(summary domain.IndexingSummary) (string, string) {
+	from, to := summary.From.In(s.location), summary.To.In(s.location)
+	period := "Daily"
+	if s.period == config.SummaryWeekly {
+		period = "Weekly"
+	}
+	subject := fmt.Sprintf("%s indexing summary %s – %s", period, from.Format(time.DateOnly), to.Format(time.DateOnly))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Indexing from %s to %s (%s)\n\n", from.Format("2 Jan 15:04"), to.Format("2 Jan 15:04"), s.location)
+	fmt.Fprintf(&b, "Jobs succeeded: %d\n", summary.Succeeded)
+	fmt.Fprintf(&b, "Jobs failed: %d\n", len(summary.Failures))
+
+	if len(summary.Indexed) > 0 {
+		b.WriteString("\nDocuments written:\n")
+		for _, index := range slices.Sorted(maps.Keys(summary.Indexed)) {
+			fmt.Fprintf(&b, "  %s: %d\n", index, summary.Indexed[index])
+		}
+	}
+
+	if len(summary.Approvals) > 0 {
+		b.WriteString("\nApproved talks:\n")
+		for _, approvals := range summary.Approvals {
+			name := approvals.ConferenceName
+			if name == "" {
+				name = approvals.ConferenceSlug
+			}
+			fmt.Fprintf(&b, "  %s: %d new, %d in total\n", name, approvals.New, approvals.Approved)
+		}
+	}
+
+	if len(summary.Failures) > 0 {
+		b.WriteString("\nFailures:\n")
+		for _, job := range summary.Failures {
+			fmt.Fprintf(&b, "  %s %s (job %s): %s\n", job.FinishedAt.In(s.location).Format("2 Jan 15:04"), describeScope(job.Scope), job.ID, job.Error)
+		}
+	}
+	return subject, b.String()
+}
+
+// parseWeekday parses the English name of a weekday, in any case
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid summary weekday %q", name)
+}
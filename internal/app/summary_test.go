@@ -0,0 +1,183 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentMail is an email recorded by mockMailSender
+type sentMail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+// mockMailSender records the emails sent, failing with err if set
+type mockMailSender struct {
+	sent []sentMail
+	err  error
+}
+
+func (m *mockMailSender) SendMail(ctx context.Context, to []string, subject, body string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+// summaryJob returns a job that finished at the given time with the error, if any
+func summaryJob(id string, scope domain.JobScope, finished time.Time, indexed map[string]int, err error) domain.Job {
+	started := finished.Add(-time.Minute)
+	job := domain.Job{ID: id, Scope: scope, StartedAt: &started, Report: domain.JobReport{Indexed: indexed}}
+	job.Finish(finished, err)
+	return job
+}
+
+func newTestSummaryService(t *testing.T, jobs *mockJobStore, store *mockSettingsStore, mailer *mockMailSender, period string) *SummaryService {
+	t.Helper()
+	service, err := NewSummaryServiceWithConfig(jobs, NewTrendServiceWithConfig(nil, store, "private", config.TrendsConfig{Days: 30}), store, mailer, config.SummaryConfig{
+		Recipients: []string{"program@java.no"},
+		Period:     period,
+		Weekday:    "Monday",
+		Hour:       7,
+		TimeZone:   "Europe/Oslo",
+	})
+	require.NoError(t, err)
+	return service
+}
+
+func TestSummaryService_Summarize(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	require.NoError(t, err)
+	from := time.Date(2025, 9, 1, 7, 0, 0, 0, oslo)
+	to := from.AddDate(0, 0, 7)
+
+	jobs := newMockJobStore()
+	for _, job := range []domain.Job{
+		summaryJob("before", domain.JobScope{Kind: domain.JobKindReindexAll}, from.Add(-time.Hour), map[string]int{"private": 900}, nil),
+		summaryJob("full", domain.JobScope{Kind: domain.JobKindReindexAll}, from.Add(20*time.Hour), map[string]int{"private": 400, "public": 300}, nil),
+		summaryJob("talk", domain.JobScope{Kind: domain.JobKindReindexTalk, Target: "talk-1"}, from.Add(30*time.Hour), map[string]int{"private": 1, "public": 1}, nil),
+		summaryJob("failed-late", domain.JobScope{Kind: domain.JobKindLinkCheck}, from.Add(50*time.Hour), nil, errors.New("timeout")),
+		summaryJob("failed", domain.JobScope{Kind: domain.JobKindReindexConference, Target: "javazone2025"}, from.Add(40*time.Hour), nil, errors.New("moresleep unavailable")),
+		summaryJob("after", domain.JobScope{Kind: domain.JobKindReindexAll}, to, map[string]int{"private": 900}, nil),
+	} {
+		jobs.jobs[job.ID] = job
+	}
+
+	store := newMockSettingsStore()
+	require.NoError(t, store.SaveSetting(context.Background(), talkTrendsKey, []domain.TalkCountTrend{
+		{ConferenceSlug: "javazone2025", ConferenceName: "JavaZone 2025", Snapshots: []domain.TalkCountSnapshot{
+			{Date: "2025-08-31", ByStatus: map[string]int{"APPROVED": 100}},
+			{Date: "2025-09-04", ByStatus: map[string]int{"APPROVED": 110}},
+			{Date: "2025-09-08", ByStatus: map[string]int{"APPROVED": 112}},
+		}},
+		{ConferenceSlug: "javazone2026", Snapshots: []domain.TalkCountSnapshot{
+			{Date: "2025-09-05", ByStatus: map[string]int{"SUBMITTED": 40, "APPROVED": 2}},
+		}},
+		{ConferenceSlug: "javazone2024", Snapshots: []domain.TalkCountSnapshot{
+			{Date: "2024-09-10", ByStatus: map[string]int{"APPROVED": 150}},
+		}},
+	}))
+
+	service := newTestSummaryService(t, jobs, store, &mockMailSender{}, config.SummaryWeekly)
+	summary, err := service.Summarize(context.Background(), from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, summary.Succeeded)
+	assert.Equal(t, map[string]int{"private": 401, "public": 301}, summary.Indexed)
+	require.Len(t, summary.Failures, 2)
+	assert.Equal(t, "failed", summary.Failures[0].ID)
+	assert.Equal(t, "failed-late", summary.Failures[1].ID)
+	assert.ElementsMatch(t, []domain.ConferenceApprovals{
+		{ConferenceSlug: "javazone2025", ConferenceName: "JavaZone 2025", Approved: 112, New: 12},
+		{ConferenceSlug: "javazone2026", Approved: 2, New: 2},
+	}, summary.Approvals)
+}
+
+func TestSummaryService_SendDueSummary(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	jobs := newMockJobStore()
+	failed := summaryJob("job-7", domain.JobScope{Kind: domain.JobKindReindexAll}, time.Date(2025, 9, 3, 3, 0, 0, 0, oslo), nil, errors.New("moresleep unavailable"))
+	jobs.jobs[failed.ID] = failed
+
+	store := newMockSettingsStore()
+	mailer := &mockMailSender{}
+	service := newTestSummaryService(t, jobs, store, mailer, config.SummaryWeekly)
+
+	// Wednesday: the first check only records the period that just passed
+	service.now = func() time.Time { return time.Date(2025, 9, 3, 12, 0, 0, 0, oslo) }
+	require.NoError(t, service.SendDueSummary(ctx))
+	assert.Empty(t, mailer.sent)
+
+	// Monday before the hour: not due yet
+	service.now = func() time.Time { return time.Date(2025, 9, 8, 6, 59, 0, 0, oslo) }
+	require.NoError(t, service.SendDueSummary(ctx))
+	assert.Empty(t, mailer.sent)
+
+	// Monday after the hour: sent once, and not again on the next check
+	service.now = func() time.Time { return time.Date(2025, 9, 8, 7, 10, 0, 0, oslo) }
+	require.NoError(t, service.SendDueSummary(ctx))
+	service.now = func() time.Time { return time.Date(2025, 9, 8, 7, 25, 0, 0, oslo) }
+	require.NoError(t, service.SendDueSummary(ctx))
+
+	require.Len(t, mailer.sent, 1)
+	mail := mailer.sent[0]
+	assert.Equal(t, []string{"program@java.no"}, mail.to)
+	assert.Equal(t, "Weekly indexing summary 2025-09-01 – 2025-09-08", mail.subject)
+	assert.Contains(t, mail.body, "Jobs failed: 1\n")
+	assert.Contains(t, mail.body, "3 Sep 03:00 all conferences (job job-7): moresleep unavailable")
+}
+
+func TestSummaryService_SendDueSummary_RetriesFailedSend(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	store := newMockSettingsStore()
+	mailer := &mockMailSender{}
+	service := newTestSummaryService(t, newMockJobStore(), store, mailer, config.SummaryDaily)
+
+	service.now = func() time.Time { return time.Date(2025, 9, 3, 12, 0, 0, 0, oslo) }
+	require.NoError(t, service.SendDueSummary(ctx))
+
+	service.now = func() time.Time { return time.Date(2025, 9, 4, 7, 0, 0, 0, oslo) }
+	mailer.err = errors.New("connection refused")
+	assert.Error(t, service.SendDueSummary(ctx))
+
+	mailer.err = nil
+	require.NoError(t, service.SendDueSummary(ctx))
+	require.Len(t, mailer.sent, 1)
+	assert.Equal(t, "Daily indexing summary 2025-09-03 – 2025-09-04", mailer.sent[0].subject)
+}
+
+func TestNewSummaryServiceWithConfig_Invalid(t *testing.T) {
+	valid := config.SummaryConfig{Period: config.SummaryWeekly, Weekday: "monday", Hour: 7, TimeZone: "Europe/Oslo"}
+	tests := []struct {
+		name   string
+		modify func(cfg *config.SummaryConfig)
+	}{
+		{name: "period", modify: func(cfg *config.SummaryConfig) { cfg.Period = "monthly" }},
+		{name: "weekday", modify: func(cfg *config.SummaryConfig) { cfg.Weekday = "mandag" }},
+		{name: "hour", modify: func(cfg *config.SummaryConfig) { cfg.Hour = 24 }},
+		{name: "time zone", modify: func(cfg *config.SummaryConfig) { cfg.TimeZone = "Europe/Bergen" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			_, err := NewSummaryServiceWithConfig(newMockJobStore(), nil, newMockSettingsStore(), &mockMailSender{}, cfg)
+			assert.Error(t, err)
+		})
+	}
+}
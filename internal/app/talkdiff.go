@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// TalkDiffService compares a talk in moresleep with its documents in the private and public indexes.
+// The expected documents are built from the current submission the same way a reindex of the talk
+// builds them, and compared field by field with the indexed ones; nothing is indexed.
+type TalkDiffService struct {
+	indexer *IndexerService
+	reader  ports.TalkReader
+	logger  *slog.Logger
+}
+
+// NewTalkDiffService creates a new TalkDiffService
+func NewTalkDiffService(indexer *IndexerService, reader ports.TalkReader) *TalkDiffService {
+	return &TalkDiffService{
+		indexer: indexer,
+		reader:  reader,
+		logger:  slog.Default().With("component", "talk-diff"),
+	}
+}
+
+// DiffTalk fetches the talk from moresleep and compares the documents the next reindex writes with the
+// documents in both indexes. Talks past their retention period are expected in neither index, and
+// talks without a public status only in the private one.
+func (s *TalkDiffService) DiffTalk(ctx context.Context, talkID string) (domain.TalkDiff, error) {
+	talk, err := s.indexer.source.GetTalk(ctx, talkID)
+	if err != nil {
+		return domain.TalkDiff{}, fmt.Errorf("failed to fetch talk %s: %w", talkID, err)
+	}
+	transformed := s.indexer.applyTransforms(ctx, []domain.Talk{*talk})[0]
+	expired := s.indexer.retention != nil && s.indexer.retention.Expired(transformed)
+
+	diff := domain.TalkDiff{
+		TalkID:         talkID,
+		Title:          stringValue(transformed.Data["title"]),
+		ConferenceSlug: transformed.ConferenceSlug,
+		Status:         transformed.Status,
+	}
+	diff.Private, err = s.diffIndex(ctx, s.indexer.privateIndex, transformed.ToPrivate(), !expired)
+	if err != nil {
+		return domain.TalkDiff{}, err
+	}
+	public := domain.TalkStatus(transformed.Status).IsPublic() && !expired
+	diff.Public, err = s.diffIndex(ctx, s.indexer.publicIndex, s.indexer.publicDocument(transformed), public)
+	if err != nil {
+		return domain.TalkDiff{}, err
+	}
+
+	s.logger.InfoContext(ctx, "compared talk with indexes",
+		"talkID", talkID,
+		"privateInSync", diff.Private.InSync(),
+		"publicInSync", diff.Public.InSync(),
+	)
+	return diff, nil
+}
+
+// diffIndex compares the document built from moresleep with the document of the talk in the index.
+// Fields are only compared when the document is expected in the index and found there.
+func (s *TalkDiffService) diffIndex(ctx context.Context, indexName string, document domain.Talk, expected bool) (domain.IndexDiff, error) {
+	diff := domain.IndexDiff{IndexName: indexName, Expected: expected}
+
+	indexed, err := s.indexer.indexedTalk(ctx, s.reader, indexName, document.ConferenceSlug, document.ID)
+	if err != nil {
+		return diff, err
+	}
+	diff.Found = indexed != nil
+	if !expected || indexed == nil {
+		return diff, nil
+	}
+
+	diff.Fields, err = fieldDiffs(document, *indexed)
+	return diff, err
+}
+
+// fieldDiffs returns the fields whose values differ between the document built from moresleep and the
+// indexed document, including fields in only one of them, sorted by field. Objects are compared per
+// field and lists of objects per element, while other lists, such as keywords, are compared as a whole.
+func fieldDiffs(source, indexed domain.Talk) ([]domain.FieldDiff, error) {
+	sourceDocument, err := documentOf(source)
+	if err != nil {
+		return nil, err
+	}
+	indexedDocument, err := documentOf(indexed)
+	if err != nil {
+		return nil, err
+	}
+	sourceFields, indexedFields := make(map[string]interface{}), make(map[string]interface{})
+	flattenDocument("", sourceDocument, sourceFields)
+	flattenDocument("", indexedDocument, indexedFields)
+
+	var diffs []domain.FieldDiff
+	for field, value := range sourceFields {
+		indexedValue, inIndex := indexedFields[field]
+		if inIndex && reflect.DeepEqual(value, indexedValue) {
+			continue
+		}
+		diff := domain.FieldDiff{Field: field, Source: fieldValue(value), InSource: true, InIndex: inIndex}
+		if inIndex {
+			diff.Indexed = fieldValue(indexedValue)
+		}
+		diffs = append(diffs, diff)
+	}
+	for field, value := range indexedFields {
+		if _, ok := sourceFields[field]; !ok {
+			diffs = append(diffs, domain.FieldDiff{Field: field, Indexed: fieldValue(value), InIndex: true})
+		}
+	}
+	slices.SortFunc(diffs, func(a, b domain.FieldDiff) int { return strings.Compare(a.Field, b.Field) })
+	return diffs, nil
+}
+
+// flattenDocument adds the fields of a decoded JSON document to fields by their path
+func flattenDocument(path string, value interface{}, fields map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if path == "" {
+				flattenDocument(key, child, fields)
+			} else {
+				flattenDocument(path+"."+key, child, fields)
+			}
+		}
+	case []interface{}:
+		objects := slices.ContainsFunc(v, func(element interface{}) bool {
+			_, ok := element.(map[string]interface{})
+			return ok
+		})
+		if !objects {
+			fields[path] = v
+			return
+		}
+		for i, child := range v {
+			flattenDocument(path+"["+strconv.Itoa(i)+"]", child, fields)
+		}
+	default:
+		fields[path] = v
+	}
+}
+
+// fieldValue returns a field value for display: strings as is, other values as JSON
+func fieldValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
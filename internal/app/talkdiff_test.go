@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTalkDiffService(talk *domain.Talk, indexed map[string][]domain.Talk) *TalkDiffService {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return talk, nil
+		},
+	}
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return indexed[indexName], nil
+		},
+	}
+	indexer := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	return NewTalkDiffService(indexer, reader)
+}
+
+func TestTalkDiffService_DiffTalk(t *testing.T) {
+	talk := &domain.Talk{
+		ID: "talk-1", ConferenceSlug: "javazone2025", Status: "APPROVED",
+		Speakers:    domain.Speakers{{ID: "s1", Name: "Duke"}},
+		Data:        map[string]interface{}{"title": "Records", "abstract": "New abstract", "keywords": []interface{}{"java", "records"}},
+		PrivateData: map[string]interface{}{"comments": "for the program committee"},
+	}
+	service := newTestTalkDiffService(talk, map[string][]domain.Talk{
+		"private": {talk.ToPrivate()},
+		"public": {
+			{ID: "talk-0", ConferenceSlug: "javazone2025"},
+			{ID: "talk-1", ConferenceSlug: "javazone2025", Status: "APPROVED", Speakers: domain.Speakers{{ID: "s1", Name: "Duke Java"}},
+				Data: map[string]interface{}{"title": "Records", "abstract": "Old abstract", "keywords": []interface{}{"java"}, "level": "beginner"}},
+		},
+	})
+
+	diff, err := service.DiffTalk(context.Background(), "talk-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Records", diff.Title)
+	assert.Equal(t, "javazone2025", diff.ConferenceSlug)
+	assert.Equal(t, domain.IndexDiff{IndexName: "private", Expected: true, Found: true}, diff.Private)
+	assert.True(t, diff.Private.InSync())
+
+	assert.Equal(t, "public", diff.Public.IndexName)
+	assert.False(t, diff.Public.InSync())
+	assert.Equal(t, []domain.FieldDiff{
+		{Field: "data.abstract", Source: "New abstract", InSource: true, Indexed: "Old abstract", InIndex: true},
+		{Field: "data.keywords", Source: `["java","records"]`, InSource: true, Indexed: `["java"]`, InIndex: true},
+		{Field: "data.level", Indexed: "beginner", InIndex: true},
+		{Field: "speakers[0].name", Source: "Duke", InSource: true, Indexed: "Duke Java", InIndex: true},
+	}, diff.Public.Fields)
+}
+
+func TestTalkDiffService_DiffTalk_NotPublic(t *testing.T) {
+	talk := &domain.Talk{ID: "talk-1", ConferenceSlug: "javazone2025", Status: "REJECTED", Data: map[string]interface{}{"title": "Records"}}
+	service := newTestTalkDiffService(talk, map[string][]domain.Talk{
+		"public": {{ID: "talk-1", ConferenceSlug: "javazone2025", Status: "APPROVED", Data: map[string]interface{}{"title": "Records"}}},
+	})
+
+	diff, err := service.DiffTalk(context.Background(), "talk-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.IndexDiff{IndexName: "private", Expected: true}, diff.Private, "missing from the private index")
+	assert.Equal(t, domain.IndexDiff{IndexName: "public", Found: true}, diff.Public, "still published after being rejected")
+	assert.False(t, diff.Public.InSync())
+}
+
+func TestTalkDiffService_DiffTalk_SourceError(t *testing.T) {
+	service := newTestTalkDiffService(nil, nil)
+	service.indexer.source = &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return nil, errors.New("moresleep unavailable")
+		},
+	}
+
+	_, err := service.DiffTalk(context.Background(), "talk-1")
+	assert.ErrorContains(t, err, "moresleep unavailable")
+}
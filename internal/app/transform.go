@@ -0,0 +1,188 @@
+package app
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/markup"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// TalkTransform derives indexed fields from a talk before it is split into its private and public documents.
+// Transforms must not modify the maps of the given talk in place.
+type TalkTransform func(talk domain.Talk) domain.Talk
+
+// AddTransform registers a transform applied to every talk before it is indexed, in registration order
+func (s *IndexerService) AddTransform(transform TalkTransform) {
+	s.transforms = append(s.transforms, transform)
+}
+
+// SetConferenceCatalog enables denormalizing the conference metadata onto every indexed talk
+func (s *IndexerService) SetConferenceCatalog(catalog ports.ConferenceCatalog) {
+	s.catalog = catalog
+}
+
+// SetSeriesCatalog enables linking the talks of the series configured by admins
+func (s *IndexerService) SetSeriesCatalog(series ports.SeriesCatalog) {
+	s.series = series
+}
+
+// SetReviewerConflicts enables flagging the conflicts of interest registered by committee members on the
+// talks of their speakers, matching organizations against the speaker data field
+func (s *IndexerService) SetReviewerConflicts(conflicts ports.ReviewerConflicts, organizationField string) {
+	s.conflicts = conflicts
+	s.organizationField = organizationField
+}
+
+// applyTransforms checks the integrity of the talks, then returns them with their conference metadata
+// and series attached, all registered transforms applied and registered conflicts flagged. Metadata,
+// series or conflicts that cannot be loaded are logged and left out rather than failing the reindex.
+func (s *IndexerService) applyTransforms(ctx context.Context, talks []domain.Talk) []domain.Talk {
+	s.checkIntegrity(ctx, talks)
+
+	metadata := s.conferenceMetadata(ctx)
+	series := s.seriesParts(ctx)
+	conflicts := s.reviewerConflicts(ctx)
+	if len(s.transforms) == 0 && len(metadata) == 0 && len(series) == 0 && len(conflicts) == 0 {
+		return talks
+	}
+	result := make([]domain.Talk, len(talks))
+	for i, talk := range talks {
+		if conference, ok := metadata[normalizeSlug(talk.ConferenceSlug)]; ok {
+			talk.Conference = &conference
+		}
+		if part, ok := series[talk.ID]; ok {
+			talk.SeriesID, talk.SeriesPart = part.id, part.part
+		}
+		for _, transform := range s.transforms {
+			talk = transform(talk)
+		}
+		result[i] = flagReviewerConflicts(talk, conflicts, s.organizationField)
+	}
+	return result
+}
+
+// conferenceMetadata returns the conference metadata keyed by slug, or nil if no catalog is set or it fails
+func (s *IndexerService) conferenceMetadata(ctx context.Context) map[string]domain.ConferenceMetadata {
+	if s.catalog == nil {
+		return nil
+	}
+	entries, err := s.catalog.ListConferenceMetadata(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to load conference metadata, indexing talks without it", "error", err)
+		return nil
+	}
+	metadata := make(map[string]domain.ConferenceMetadata, len(entries))
+	for _, entry := range entries {
+		metadata[entry.Slug] = entry.ConferenceMetadata
+	}
+	return metadata
+}
+
+// seriesPart is the series of a talk and its position in it
+type seriesPart struct {
+	id   string
+	part int
+}
+
+// seriesParts returns the series of the talks keyed by talk ID, or nil if no series catalog is set or it fails
+func (s *IndexerService) seriesParts(ctx context.Context) map[string]seriesPart {
+	if s.series == nil {
+		return nil
+	}
+	entries, err := s.series.ListSeries(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to load series, indexing talks without them", "error", err)
+		return nil
+	}
+	parts := make(map[string]seriesPart)
+	for _, entry := range entries {
+		for i, talkID := range entry.TalkIDs {
+			parts[talkID] = seriesPart{id: entry.ID, part: i + 1}
+		}
+	}
+	return parts
+}
+
+// reviewerConflicts returns the registered conflicts of interest, or nil if none are set or they fail to load
+func (s *IndexerService) reviewerConflicts(ctx context.Context) []domain.ReviewerConflicts {
+	if s.conflicts == nil {
+		return nil
+	}
+	entries, err := s.conflicts.ListConflicts(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to load reviewer conflicts, indexing talks without them", "error", err)
+		return nil
+	}
+	return entries
+}
+
+// SetScrubber enables masking personal details in public documents. Every scrubbed talk is
+// flagged for manual review in the job report.
+func (s *IndexerService) SetScrubber(scrubber *Scrubber) {
+	s.scrubber = scrubber
+}
+
+// scrubPublic returns the talks with personal details masked in those with a public status, if a
+// scrubber is set. It runs before the split into public documents, since the email field filter
+// would otherwise drop a whole abstract for containing an email address.
+func (s *IndexerService) scrubPublic(ctx context.Context, talks []domain.Talk) []domain.Talk {
+	if s.scrubber == nil {
+		return talks
+	}
+	report := jobReportFromContext(ctx)
+	talks = slices.Clone(talks)
+	for i, talk := range talks {
+		if !domain.TalkStatus(talk.Status).IsPublic() {
+			continue
+		}
+		scrubbed, findings := s.scrubber.Scrub(talk)
+		if len(findings) == 0 {
+			continue
+		}
+		talks[i] = scrubbed
+		s.logger.WarnContext(ctx, "masked text in public talk, flagged for review",
+			"talkID", talk.ID,
+			"findings", findings,
+		)
+		if report != nil {
+			report.flag(domain.ReviewFlag{TalkID: talk.ID, ConferenceSlug: talk.ConferenceSlug, Findings: findings})
+		}
+	}
+	return talks
+}
+
+// publicDocuments returns the public documents the talks get once they are published: transformed, scrubbed
+// whatever their status, and without private data. Scrubbing findings are not reported, as nothing is indexed.
+func (s *IndexerService) publicDocuments(ctx context.Context, talks []domain.Talk) []domain.Talk {
+	talks = s.applyTransforms(ctx, talks)
+	documents := make([]domain.Talk, len(talks))
+	for i, talk := range talks {
+		documents[i] = s.publicDocument(talk)
+	}
+	return documents
+}
+
+// publicDocument returns the public document of a transformed talk: scrubbed whatever its status, and
+// without private data
+func (s *IndexerService) publicDocument(talk domain.Talk) domain.Talk {
+	if s.scrubber != nil {
+		talk, _ = s.scrubber.Scrub(talk)
+	}
+	return talk.ToPublic()
+}
+
+// RenderAbstractHTML renders the markdown abstract to sanitized HTML in data.abstractHtml,
+// next to the raw text, so consumers no longer render abstracts each in their own way
+func RenderAbstractHTML(talk domain.Talk) domain.Talk {
+	abstract := stringValue(talk.Data["abstract"])
+	if strings.TrimSpace(abstract) == "" {
+		return talk
+	}
+	talk.Data = maps.Clone(talk.Data)
+	talk.Data["abstractHtml"] = markup.Sanitize(markup.RenderMarkdown(abstract))
+	return talk
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderAbstractHTML(t *testing.T) {
+	talk := domain.Talk{ID: "talk-1", Data: map[string]interface{}{
+		"abstract": "Learn **virtual threads**.\n\n<script>alert(1)</script>",
+	}}
+
+	result := RenderAbstractHTML(talk)
+
+	assert.Equal(t, "<p>Learn <strong>virtual threads</strong>.</p>\n<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n", result.Data["abstractHtml"])
+	assert.Equal(t, talk.Data["abstract"], result.Data["abstract"], "raw text is kept")
+	assert.NotContains(t, talk.Data, "abstractHtml", "the original talk is not modified")
+}
+
+func TestRenderAbstractHTML_NoAbstract(t *testing.T) {
+	talk := domain.Talk{ID: "talk-1", Data: map[string]interface{}{"title": "Untitled"}}
+
+	result := RenderAbstractHTML(talk)
+
+	assert.NotContains(t, result.Data, "abstractHtml")
+}
+
+func TestReindexTalk_AppliesTransforms(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{
+				ID:     "talk-1",
+				Status: "APPROVED",
+				Data:   map[string]interface{}{"title": "Test Talk", "abstract": "*Fast* startup"},
+			}, nil
+		},
+	}
+	index := &mockSearchIndex{
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	service := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service.AddTransform(RenderAbstractHTML)
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	require.Len(t, index.bulkIndexCalls, 2)
+	for _, call := range index.bulkIndexCalls {
+		require.Len(t, call.Talks, 1)
+		assert.Equal(t, "<p><em>Fast</em> startup</p>\n", call.Talks[0].Data["abstractHtml"], call.IndexName)
+	}
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// talkTrendsKey is the settings key holding the daily talk count snapshots
+const talkTrendsKey = "trends:talk-counts"
+
+// TrendService records daily snapshots of the talk counts per status of the active conferences,
+// so the dashboard can chart submission volume during the CFP window. A conference is active from
+// the day its CFP opens until it ends, according to its metadata. Without CFP dates on any conference,
+// the newest conference by the year in its slug is tracked.
+type TrendService struct {
+	reader       ports.TalkReader
+	store        ports.SettingsStore
+	privateIndex string
+	days         int
+	now          func() time.Time
+	logger       *slog.Logger
+
+	mu sync.Mutex
+}
+
+// NewTrendService creates a new TrendService, receiving context as first parameter
+// to retrieve configuration.
+func NewTrendService(ctx context.Context, reader ports.TalkReader, store ports.SettingsStore) *TrendService {
+	cfg := config.GetConfig(ctx)
+	return NewTrendServiceWithConfig(reader, store, cfg.Index.PrivateName(), cfg.Trends)
+}
+
+// NewTrendServiceWithConfig creates a new TrendService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewTrendServiceWithConfig(reader ports.TalkReader, store ports.SettingsStore, privateIndex string, cfg config.TrendsConfig) *TrendService {
+	return &TrendService{
+		reader:       reader,
+		store:        store,
+		privateIndex: privateIndex,
+		days:         cfg.Days,
+		now:          time.Now,
+		logger:       slog.Default().With("component", "trends"),
+	}
+}
+
+// RecordTalkCounts stores today's talk counts per status of the active conferences, replacing an
+// earlier snapshot of the same day, and drops snapshots older than the configured number of days
+func (s *TrendService) RecordTalkCounts(ctx context.Context) error {
+	today := s.now().UTC()
+	date := today.Format(time.DateOnly)
+
+	conferences, err := s.reader.ListConferences(ctx, s.privateIndex)
+	if err != nil {
+		return fmt.Errorf("failed to list conferences: %w", err)
+	}
+	active := activeConferences(conferences, date)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trends, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, conference := range active {
+		talks, err := s.reader.FetchTalks(ctx, s.privateIndex, conference.Slug)
+		if err != nil {
+			return fmt.Errorf("failed to fetch talks of %s: %w", conference.Slug, err)
+		}
+
+		snapshot := domain.TalkCountSnapshot{Date: date, Total: len(talks), ByStatus: make(map[string]int)}
+		for _, talk := range talks {
+			snapshot.ByStatus[talk.Status]++
+		}
+
+		i := slices.IndexFunc(trends, func(trend domain.TalkCountTrend) bool { return trend.ConferenceSlug == conference.Slug })
+		if i < 0 {
+			trends = append(trends, domain.TalkCountTrend{ConferenceSlug: conference.Slug})
+			i = len(trends) - 1
+		}
+		trends[i].ConferenceName = conference.Name
+		trends[i].Snapshots = slices.DeleteFunc(trends[i].Snapshots, func(existing domain.TalkCountSnapshot) bool { return existing.Date == date })
+		trends[i].Snapshots = append(trends[i].Snapshots, snapshot)
+
+		s.logger.InfoContext(ctx, "recorded talk counts", "conference", conference.Slug, "total", snapshot.Total)
+	}
+
+	// Drop snapshots past the retention, and conferences left without any
+	cutoff := today.AddDate(0, 0, -s.days).Format(time.DateOnly)
+	for i := range trends {
+		trends[i].Snapshots = slices.DeleteFunc(trends[i].Snapshots, func(snapshot domain.TalkCountSnapshot) bool { return snapshot.Date < cutoff })
+	}
+	trends = slices.DeleteFunc(trends, func(trend domain.TalkCountTrend) bool { return len(trend.Snapshots) == 0 })
+
+	if err := s.store.SaveSetting(ctx, talkTrendsKey, trends); err != nil {
+		return fmt.Errorf("failed to save talk count trends: %w", err)
+	}
+	return nil
+}
+
+// TalkCountTrends returns the recorded daily talk counts, most recently recorded conference first
+func (s *TrendService) TalkCountTrends(ctx context.Context) ([]domain.TalkCountTrend, error) {
+	trends, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(trends, func(a, b domain.TalkCountTrend) int {
+		if c := strings.Compare(b.Latest().Date, a.Latest().Date); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ConferenceSlug, b.ConferenceSlug)
+	})
+	return trends, nil
+}
+
+// load reads the stored trends, with snapshots oldest first
+func (s *TrendService) load(ctx context.Context) ([]domain.TalkCountTrend, error) {
+	var trends []domain.TalkCountTrend
+	if _, err := s.store.LoadSetting(ctx, talkTrendsKey, &trends); err != nil {
+		return nil, fmt.Errorf("failed to load talk count trends: %w", err)
+	}
+	for _, trend := range trends {
+		slices.SortFunc(trend.Snapshots, func(a, b domain.TalkCountSnapshot) int { return strings.Compare(a.Date, b.Date) })
+	}
+	return trends, nil
+}
+
+// activeConferences returns the conferences whose CFP has opened and that have not ended on the given
+// date, formatted as YYYY-MM-DD. Conferences without an end date are active until their CFP closes.
+// If no conference has CFP dates, the newest conference by the year in its slug is returned.
+func activeConferences(conferences []domain.ConferenceSummary, date string) []domain.ConferenceSummary {
+	var active []domain.ConferenceSummary
+	var hasWindow bool
+	for _, conference := range conferences {
+		if conference.CFPOpens == "" {
+			continue
+		}
+		hasWindow = true
+
+		until := conference.EndDate
+		if until == "" {
+			until = conference.CFPCloses
+		}
+		if conference.CFPOpens <= date && (until == "" || date <= until) {
+			active = append(active, conference)
+		}
+	}
+	if hasWindow {
+		return active
+	}
+
+	var newest *domain.ConferenceSummary
+	var newestYear int
+	for i, conference := range conferences {
+		year, _ := strconv.Atoi(yearPattern.FindString(conference.Slug))
+		if year > newestYear {
+			newest, newestYear = &conferences[i], year
+		}
+	}
+	if newest == nil {
+		return nil
+	}
+	return []domain.ConferenceSummary{*newest}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendService_RecordTalkCounts(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	talks := []domain.Talk{
+		{ID: "talk-1", Status: "SUBMITTED"},
+		{ID: "talk-2", Status: "SUBMITTED"},
+		{ID: "talk-3", Status: "DRAFT"},
+	}
+	reader := &mockTalkReader{
+		conferences: []domain.ConferenceSummary{
+			{Slug: "javazone2024", Name: "JavaZone 2024", ConferenceMetadata: domain.ConferenceMetadata{CFPOpens: "2024-01-15", EndDate: "2024-09-05"}},
+			{Slug: "javazone2025", Name: "JavaZone 2025", ConferenceMetadata: domain.ConferenceMetadata{CFPOpens: "2025-01-15", CFPCloses: "2025-04-15", EndDate: "2025-09-04"}},
+		},
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			assert.Equal(t, "javazone2025", conferenceSlug, "only active conferences are counted")
+			return talks, nil
+		},
+	}
+	store := newMockSettingsStore()
+	service := NewTrendServiceWithConfig(reader, store, "private", config.TrendsConfig{Days: 30})
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, service.RecordTalkCounts(ctx))
+
+	// A later run on the same day replaces the snapshot
+	talks = append(talks, domain.Talk{ID: "talk-4", Status: "SUBMITTED"})
+	require.NoError(t, service.RecordTalkCounts(ctx))
+
+	now = now.AddDate(0, 0, 1)
+	require.NoError(t, service.RecordTalkCounts(ctx))
+
+	trends, err := service.TalkCountTrends(ctx)
+	require.NoError(t, err)
+	require.Len(t, trends, 1)
+	assert.Equal(t, "JavaZone 2025", trends[0].ConferenceName)
+	require.Len(t, trends[0].Snapshots, 2)
+	assert.Equal(t, domain.TalkCountSnapshot{Date: "2025-03-10", Total: 4, ByStatus: map[string]int{"SUBMITTED": 3, "DRAFT": 1}}, trends[0].Snapshots[0])
+	assert.Equal(t, "2025-03-11", trends[0].Latest().Date)
+
+	// Snapshots older than the retention are dropped
+	now = now.AddDate(0, 0, 30)
+	require.NoError(t, service.RecordTalkCounts(ctx))
+	trends, err = service.TalkCountTrends(ctx)
+	require.NoError(t, err)
+	require.Len(t, trends[0].Snapshots, 2)
+	assert.Equal(t, "2025-03-11", trends[0].Snapshots[0].Date)
+}
+
+func TestActiveConferences(t *testing.T) {
+	conferences := []domain.ConferenceSummary{
+		{Slug: "javazone2024", ConferenceMetadata: domain.ConferenceMetadata{CFPOpens: "2024-01-15", EndDate: "2024-09-05"}},
+		{Slug: "javazone2025", ConferenceMetadata: domain.ConferenceMetadata{CFPOpens: "2025-01-15", CFPCloses: "2025-04-15"}},
+	}
+
+	assert.Empty(t, activeConferences(conferences, "2025-01-01"))
+	assert.Equal(t, "javazone2025", activeConferences(conferences, "2025-04-15")[0].Slug)
+	assert.Empty(t, activeConferences(conferences, "2025-04-16"), "without an end date the conference is active until the CFP closes")
+
+	// Without any CFP dates, the newest conference is tracked
+	undated := []domain.ConferenceSummary{{Slug: "javazone2023"}, {Slug: "javazone2025"}, {Slug: "javabin-meetup"}}
+	active := activeConferences(undated, "2025-03-10")
+	require.Len(t, active, 1)
+	assert.Equal(t, "javazone2025", active[0].Slug)
+}
@@ -0,0 +1,274 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// apiUsageKey is the settings key holding the hourly API usage per route and client
+const apiUsageKey = "usage:api"
+
+// usageKey identifies the bucket of a request
+type usageKey struct {
+	hour   time.Time
+	route  string
+	client string
+}
+
+// UsageService counts the API requests per route and client by the hour, so admins can see which
+// clients call the API the most and plan capacity before the conference. Requests are counted in
+// memory and added to the stored usage on every flush, keeping the configured number of days.
+type UsageService struct {
+	store  ports.SettingsStore
+	days   int
+	now    func() time.Time
+	logger *slog.Logger
+
+	// mu guards the usage counted since the last flush
+	mu      sync.Mutex
+	pending map[usageKey]*domain.UsageBucket
+
+	// flushMu serializes updates of the stored usage
+	flushMu sync.Mutex
+}
+
+// NewUsageService creates a new UsageService, receiving context as first parameter
+// to retrieve configuration.
+func NewUsageService(ctx context.Context, store ports.SettingsStore) *UsageService {
+	cfg := config.GetConfig(ctx)
+	return NewUsageServiceWithConfig(store, cfg.Usage)
+}
+
+// NewUsageServiceWithConfig creates a new UsageService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewUsageServiceWithConfig(store ports.SettingsStore, cfg config.UsageConfig) *UsageService {
+	return &UsageService{
+		store:   store,
+		days:    max(cfg.Days, 1),
+		now:     time.Now,
+		logger:  slog.Default().With("component", "usage"),
+		pending: make(map[usageKey]*domain.UsageBucket),
+	}
+}
+
+// SetClock replaces the system clock deciding the hour requests are counted in
+func (s *UsageService) SetClock(clock ports.Clock) {
+	s.now = clock.Now
+}
+
+// RecordUsage counts a completed request of the client to the route in the current hour. Requests
+// failing with a 5xx status are counted as errors.
+func (s *UsageService) RecordUsage(route, client string, status int, latency time.Duration) {
+	if client == "" {
+		client = domain.UsageAnonymousClient
+	}
+	key := usageKey{hour: s.now().UTC().Truncate(time.Hour), route: route, client: client}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.pending[key]
+	if !ok {
+		bucket = &domain.UsageBucket{Hour: key.hour, Route: route, Client: client}
+		s.pending[key] = bucket
+	}
+	bucket.Requests++
+	if status >= http.StatusInternalServerError {
+		bucket.Errors++
+	}
+	bucket.LatencySum += latency
+}
+
+// Flush adds the usage counted since the last flush to the stored usage and drops usage older than
+// the configured number of days. If the usage cannot be stored, it is kept for the next flush.
+func (s *UsageService) Flush(ctx context.Context) error {
+	pending := s.takePending()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	stored, err := s.load(ctx)
+	if err != nil {
+		s.restorePending(pending)
+		return err
+	}
+
+	cutoff := s.now().UTC().Truncate(time.Hour).Add(-time.Duration(s.days) * 24 * time.Hour)
+	buckets := slices.DeleteFunc(mergeUsage(stored, pending), func(bucket domain.UsageBucket) bool {
+		return bucket.Hour.Before(cutoff)
+	})
+
+	if err := s.store.SaveSetting(ctx, apiUsageKey, buckets); err != nil {
+		s.restorePending(pending)
+		return fmt.Errorf("failed to save API usage: %w", err)
+	}
+
+	s.logger.DebugContext(ctx, "flushed API usage", "buckets", len(pending))
+	return nil
+}
+
+// UsageReport summarizes the usage of the last days, today included, limited to the days kept.
+// Usage counted since the last flush is included.
+func (s *UsageService) UsageReport(ctx context.Context, days int) (domain.UsageReport, error) {
+	days = min(max(days, 1), s.days)
+
+	stored, err := s.load(ctx)
+	if err != nil {
+		return domain.UsageReport{}, err
+	}
+	buckets := mergeUsage(stored, s.pendingSnapshot())
+
+	today := s.now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+	report := domain.UsageReport{Since: since}
+
+	dayIndex := make(map[string]int, days)
+	for date := since; !date.After(today); date = date.AddDate(0, 0, 1) {
+		dayIndex[date.Format(time.DateOnly)] = len(report.Days)
+		report.Days = append(report.Days, domain.UsageDay{Date: date.Format(time.DateOnly)})
+	}
+
+	hourly := make(map[time.Time]uint64)
+	byRoute := make(map[string]*domain.UsageTotal)
+	byClient := make(map[string]*domain.UsageTotal)
+	byClientRoute := make(map[[2]string]*domain.UsageTotal)
+	for _, bucket := range buckets {
+		i, ok := dayIndex[bucket.Hour.Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+		report.Days[i].Requests += bucket.Requests
+		report.Days[i].Errors += bucket.Errors
+		hourly[bucket.Hour] += bucket.Requests
+
+		addUsage(byRoute, bucket.Route, domain.UsageTotal{Route: bucket.Route}, bucket)
+		addUsage(byClient, bucket.Client, domain.UsageTotal{Client: bucket.Client}, bucket)
+		addUsage(byClientRoute, [2]string{bucket.Client, bucket.Route}, domain.UsageTotal{Route: bucket.Route, Client: bucket.Client}, bucket)
+	}
+	for hour, requests := range hourly {
+		day := &report.Days[dayIndex[hour.Format(time.DateOnly)]]
+		day.PeakHourRequests = max(day.PeakHourRequests, requests)
+	}
+
+	report.ByRoute = sortedUsage(byRoute)
+	report.ByClient = sortedUsage(byClient)
+	report.ByClientRoute = sortedUsage(byClientRoute)
+	return report, nil
+}
+
+// load reads the stored usage
+func (s *UsageService) load(ctx context.Context) ([]domain.UsageBucket, error) {
+	var buckets []domain.UsageBucket
+	if _, err := s.store.LoadSetting(ctx, apiUsageKey, &buckets); err != nil {
+		return nil, fmt.Errorf("failed to load API usage: %w", err)
+	}
+	return buckets, nil
+}
+
+// takePending returns the usage counted since the last flush and starts counting anew
+func (s *UsageService) takePending() []domain.UsageBucket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := make([]domain.UsageBucket, 0, len(s.pending))
+	for _, bucket := range s.pending {
+		pending = append(pending, *bucket)
+	}
+	s.pending = make(map[usageKey]*domain.UsageBucket)
+	return pending
+}
+
+// restorePending adds usage that could not be stored back to the usage counted since the last flush
+func (s *UsageService) restorePending(buckets []domain.UsageBucket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, bucket := range buckets {
+		key := usageKey{hour: bucket.Hour, route: bucket.Route, client: bucket.Client}
+		if current, ok := s.pending[key]; ok {
+			current.Requests += bucket.Requests
+			current.Errors += bucket.Errors
+			current.LatencySum += bucket.LatencySum
+			continue
+		}
+		s.pending[key] = &bucket
+	}
+}
+
+// pendingSnapshot returns a copy of the usage counted since the last flush
+func (s *UsageService) pendingSnapshot() []domain.UsageBucket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := make([]domain.UsageBucket, 0, len(s.pending))
+	for _, bucket := range s.pending {
+		pending = append(pending, *bucket)
+	}
+	return pending
+}
+
+// mergeUsage adds the buckets to the stored buckets, summing those of the same hour, route and client,
+// and returns them ordered by hour, route and client
+func mergeUsage(stored, buckets []domain.UsageBucket) []domain.UsageBucket {
+	merged := make(map[usageKey]domain.UsageBucket, len(stored)+len(buckets))
+	for _, bucket := range slices.Concat(stored, buckets) {
+		bucket.Hour = bucket.Hour.UTC()
+		key := usageKey{hour: bucket.Hour, route: bucket.Route, client: bucket.Client}
+		current, ok := merged[key]
+		if !ok {
+			merged[key] = bucket
+			continue
+		}
+		current.Requests += bucket.Requests
+		current.Errors += bucket.Errors
+		current.LatencySum += bucket.LatencySum
+		merged[key] = current
+	}
+
+	result := make([]domain.UsageBucket, 0, len(merged))
+	for _, bucket := range merged {
+		result = append(result, bucket)
+	}
+	slices.SortFunc(result, func(a, b domain.UsageBucket) int {
+		return cmp.Or(a.Hour.Compare(b.Hour), strings.Compare(a.Route, b.Route), strings.Compare(a.Client, b.Client))
+	})
+	return result
+}
+
+// addUsage adds the bucket to the total under the key, starting from the given total
+func addUsage[K comparable](totals map[K]*domain.UsageTotal, key K, initial domain.UsageTotal, bucket domain.UsageBucket) {
+	total, ok := totals[key]
+	if !ok {
+		total = &initial
+		totals[key] = total
+	}
+	total.Requests += bucket.Requests
+	total.Errors += bucket.Errors
+	total.LatencySum += bucket.LatencySum
+}
+
+// sortedUsage returns the totals with the most requests first, then by client and route
+func sortedUsage[K comparable](totals map[K]*domain.UsageTotal) []domain.UsageTotal {
+	result := make([]domain.UsageTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	slices.SortFunc(result, func(a, b domain.UsageTotal) int {
+		return cmp.Or(cmp.Compare(b.Requests, a.Requests), strings.Compare(a.Client, b.Client), strings.Compare(a.Route, b.Route))
+	})
+	return result
+}
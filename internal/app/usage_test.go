@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageService_Report(t *testing.T) {
+	now := time.Date(2025, 8, 20, 9, 15, 0, 0, time.UTC)
+	store := newMockSettingsStore()
+	service := NewUsageServiceWithConfig(store, config.UsageConfig{Days: 30})
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	service.RecordUsage("GET /api/search", "mobile-app", 200, 20*time.Millisecond)
+	service.RecordUsage("GET /api/search", "mobile-app", 500, 40*time.Millisecond)
+	service.RecordUsage("GET /api/conferences", "", 200, 10*time.Millisecond)
+	require.NoError(t, service.Flush(ctx))
+
+	// Usage not flushed yet is reported too
+	now = now.Add(time.Hour)
+	service.RecordUsage("GET /api/search", "mobile-app", 200, 30*time.Millisecond)
+	now = now.AddDate(0, 0, 1)
+	service.RecordUsage("GET /api/search", "schedule-screen", 200, 10*time.Millisecond)
+
+	report, err := service.UsageReport(ctx, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC), report.Since)
+	assert.Equal(t, []domain.UsageDay{
+		{Date: "2025-08-20", Requests: 4, Errors: 1, PeakHourRequests: 3},
+		{Date: "2025-08-21", Requests: 1, PeakHourRequests: 1},
+	}, report.Days)
+
+	require.Len(t, report.ByRoute, 2)
+	assert.Equal(t, "GET /api/search", report.ByRoute[0].Route)
+	assert.Equal(t, uint64(4), report.ByRoute[0].Requests)
+	assert.Equal(t, 25*time.Millisecond, report.ByRoute[0].AverageLatency())
+
+	require.Len(t, report.ByClient, 3)
+	assert.Equal(t, domain.UsageTotal{Client: "mobile-app", Requests: 3, Errors: 1, LatencySum: 90 * time.Millisecond}, report.ByClient[0])
+	assert.Equal(t, domain.UsageAnonymousClient, report.ByClient[1].Client)
+
+	require.Len(t, report.ByClientRoute, 3)
+	assert.Equal(t, "mobile-app", report.ByClientRoute[0].Client)
+	assert.Equal(t, "GET /api/search", report.ByClientRoute[0].Route)
+
+	// Only the requested days are reported
+	report, err = service.UsageReport(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, report.Days, 1)
+	assert.Equal(t, uint64(1), report.Days[0].Requests)
+}
+
+func TestUsageService_FlushMergesAndPrunes(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	store := newMockSettingsStore()
+	service := NewUsageServiceWithConfig(store, config.UsageConfig{Days: 7})
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	service.RecordUsage("GET /api/search", "mobile-app", 200, time.Millisecond)
+	require.NoError(t, service.Flush(ctx))
+	service.RecordUsage("GET /api/search", "mobile-app", 200, time.Millisecond)
+	require.NoError(t, service.Flush(ctx))
+
+	var buckets []domain.UsageBucket
+	_, err := store.LoadSetting(ctx, apiUsageKey, &buckets)
+	require.NoError(t, err)
+	require.Len(t, buckets, 1, "requests of the same hour, route and client share a bucket")
+	assert.Equal(t, uint64(2), buckets[0].Requests)
+
+	// Usage older than the configured days is dropped on the next flush
+	now = now.AddDate(0, 0, 8)
+	service.RecordUsage("GET /api/conferences", "mobile-app", 200, time.Millisecond)
+	require.NoError(t, service.Flush(ctx))
+
+	buckets = nil
+	_, err = store.LoadSetting(ctx, apiUsageKey, &buckets)
+	require.NoError(t, err)
+	require.Len(t, buckets, 1)
+	assert.Equal(t, "GET /api/conferences", buckets[0].Route)
+}
+
+func TestUsageService_FlushKeepsUsageOnError(t *testing.T) {
+	store := newMockSettingsStore()
+	service := NewUsageServiceWithConfig(store, config.UsageConfig{Days: 30})
+	service.now = func() time.Time { return time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	service.RecordUsage("GET /api/search", "mobile-app", 200, time.Millisecond)
+
+	store.loadErr = errors.New("cluster unavailable")
+	require.Error(t, service.Flush(ctx))
+
+	store.loadErr = nil
+	service.RecordUsage("GET /api/search", "mobile-app", 200, time.Millisecond)
+	require.NoError(t, service.Flush(ctx))
+
+	report, err := service.UsageReport(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, report.ByClient, 1)
+	assert.Equal(t, uint64(2), report.ByClient[0].Requests)
+}
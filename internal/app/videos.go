@@ -0,0 +1,443 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// videoProposalsKey is the settings key holding the video proposals
+const videoProposalsKey = "video:proposals"
+
+// speakerMatchBonus is added to the title score when a speaker's name appears in the video title or description
+const speakerMatchBonus = 0.2
+
+// talkTimeFormats are the formats accepted for a talk's startTime, tried in order
+var talkTimeFormats = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// VideoService reports published talks from past conferences without a video link and proposes
+// links from the conference video channel. Proposals are stored in a settings document and only
+// patched into the indexes once accepted. The indexer applies the accepted links whenever it rewrites
+// a talk without a video link of its own, so a reindex does not drop them.
+type VideoService struct {
+	reader       ports.TalkReader
+	patcher      ports.TalkPatcher
+	store        ports.SettingsStore
+	source       ports.VideoSource
+	jobs         ports.JobStore
+	privateIndex string
+	publicIndex  string
+	threshold    float64
+	now          func() time.Time
+	logger       *slog.Logger
+
+	mu sync.Mutex
+
+	// accepted holds the accepted video links by talk ID, as of the last time the proposals were read
+	accepted   map[string]string
+	acceptedMu sync.RWMutex
+}
+
+// NewVideoService creates a new VideoService, receiving context as first parameter
+// to retrieve configuration.
+func NewVideoService(ctx context.Context, reader ports.TalkReader, patcher ports.TalkPatcher, store ports.SettingsStore) *VideoService {
+	cfg := config.GetConfig(ctx)
+	return NewVideoServiceWithConfig(reader, patcher, store, cfg.Index.PrivateName(), cfg.Index.PublicName(), cfg.Video.MatchThreshold)
+}
+
+// NewVideoServiceWithConfig creates a new VideoService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewVideoServiceWithConfig(
+	reader ports.TalkReader,
+	patcher ports.TalkPatcher,
+	store ports.SettingsStore,
+	privateIndex string,
+	publicIndex string,
+	threshold float64,
+) *VideoService {
+	return &VideoService{
+		reader:       reader,
+		patcher:      patcher,
+		store:        store,
+		privateIndex: privateIndex,
+		publicIndex:  publicIndex,
+		threshold:    threshold,
+		now:          time.Now,
+		logger:       slog.Default().With("component", "video"),
+	}
+}
+
+// SetVideoSource enables proposing video links from the given video channel
+func (s *VideoService) SetVideoSource(source ports.VideoSource) {
+	s.source = source
+}
+
+// SetJobStore enables recording every video backfill as a job
+func (s *VideoService) SetJobStore(jobs ports.JobStore) {
+	s.jobs = jobs
+}
+
+// IsConfigured returns true if a video channel is configured for proposing links
+func (s *VideoService) IsConfigured() bool {
+	return s.source != nil
+}
+
+// MissingVideos returns the talks in the public index without a video link whose conference is over,
+// ordered by newest conference first. A talk is over when its start time has passed or, without a
+// start time, when its conference year is before the current year.
+func (s *VideoService) MissingVideos(ctx context.Context) ([]domain.MissingVideoTalk, error) {
+	talks, err := s.reader.FetchTalks(ctx, s.publicIndex, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch talks from public index: %w", err)
+	}
+
+	now := s.now()
+	missing := make([]domain.MissingVideoTalk, 0)
+	years := make(map[string]int)
+	for _, talk := range talks {
+		if video, _ := talk.Data["video"].(string); strings.TrimSpace(video) != "" {
+			continue
+		}
+
+		startTime := talkStartTime(talk)
+		if startTime != nil && !startTime.Before(now) {
+			continue
+		}
+		if startTime == nil {
+			if year := conferenceYear(talk); year == 0 || year >= now.Year() {
+				continue
+			}
+		}
+
+		title, _ := talk.Data["title"].(string)
+		missing = append(missing, domain.MissingVideoTalk{
+			TalkID:         talk.ID,
+			ConferenceSlug: talk.ConferenceSlug,
+			ConferenceName: talk.ConferenceName,
+			Title:          title,
+			Speakers:       speakerNames(talk),
+			StartTime:      startTime,
+		})
+		years[talk.ConferenceSlug] = conferenceYear(talk)
+	}
+
+	slices.SortFunc(missing, func(a, b domain.MissingVideoTalk) int {
+		return cmp.Or(
+			cmp.Compare(years[b.ConferenceSlug], years[a.ConferenceSlug]),
+			strings.Compare(a.ConferenceSlug, b.ConferenceSlug),
+			strings.Compare(a.Title, b.Title),
+		)
+	})
+	return missing, nil
+}
+
+// ProposeVideos matches the talks missing a video against the videos on the channel and stores
+// the best match per talk as a pending proposal. Pending proposals from earlier runs are replaced;
+// decided proposals are kept, and a rejected video is never proposed again for the same talk.
+// The run is recorded as a video backfill job.
+func (s *VideoService) ProposeVideos(ctx context.Context) error {
+	if s.source == nil {
+		return fmt.Errorf("no video channel is configured")
+	}
+
+	_, err := recordJob(ctx, s.jobs, s.logger, domain.JobScope{Kind: domain.JobKindVideoBackfill}, s.proposeVideos)
+	return err
+}
+
+// proposeVideos does the work of ProposeVideos
+func (s *VideoService) proposeVideos(ctx context.Context) error {
+	missing, err := s.MissingVideos(ctx)
+	if err != nil {
+		return err
+	}
+
+	videos, err := s.source.ListVideos(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list channel videos: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.loadProposals(ctx)
+	if err != nil {
+		return err
+	}
+
+	proposals := slices.DeleteFunc(existing, func(p domain.VideoProposal) bool { return p.Status == domain.ProposalPending })
+	proposedAt := s.now().UTC()
+	pending := 0
+	for _, talk := range missing {
+		rejected := func(video domain.Video) bool {
+			return slices.ContainsFunc(proposals, func(p domain.VideoProposal) bool {
+				return p.Status == domain.ProposalRejected && p.TalkID == talk.TalkID && p.VideoURL == video.URL
+			})
+		}
+
+		video, score, ok := bestVideoMatch(talk, videos, rejected)
+		if !ok || score < s.threshold {
+			continue
+		}
+
+		proposals = append(proposals, domain.VideoProposal{
+			TalkID:         talk.TalkID,
+			ConferenceSlug: talk.ConferenceSlug,
+			TalkTitle:      talk.Title,
+			Speakers:       talk.Speakers,
+			VideoURL:       video.URL,
+			VideoTitle:     video.Title,
+			Score:          score,
+			Status:         domain.ProposalPending,
+			ProposedAt:     proposedAt,
+		})
+		pending++
+	}
+
+	if err := s.saveProposals(ctx, proposals); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "proposed video links", "missing", len(missing), "videos", len(videos), "proposed", pending)
+	return nil
+}
+
+// ListProposals returns the stored proposals: pending ones first by score, then decided ones newest first
+func (s *VideoService) ListProposals(ctx context.Context) ([]domain.VideoProposal, error) {
+	proposals, err := s.loadProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(proposals, func(a, b domain.VideoProposal) int {
+		aPending, bPending := a.Status == domain.ProposalPending, b.Status == domain.ProposalPending
+		switch {
+		case aPending && !bPending:
+			return -1
+		case !aPending && bPending:
+			return 1
+		case aPending:
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return decidedAt(b).Compare(decidedAt(a))
+	})
+	return proposals, nil
+}
+
+// AcceptProposal patches the proposed video link into the talk in the private and public index
+// and marks the proposal as accepted by the acting user
+func (s *VideoService) AcceptProposal(ctx context.Context, talkID string) error {
+	return s.decide(ctx, talkID, domain.ProposalAccepted, func(proposal domain.VideoProposal) error {
+		doc := map[string]interface{}{"data": map[string]interface{}{"video": proposal.VideoURL}}
+		for _, indexName := range []string{s.privateIndex, s.publicIndex} {
+			if err := s.patcher.PatchTalk(ctx, indexName, talkID, doc); err != nil {
+				return fmt.Errorf("failed to patch video link into %s: %w", indexName, err)
+			}
+		}
+		return nil
+	})
+}
+
+// LoadAcceptedVideos reads the accepted video links for ApplyVideos, at startup before the first reindex
+func (s *VideoService) LoadAcceptedVideos(ctx context.Context) error {
+	_, err := s.loadProposals(ctx)
+	return err
+}
+
+// ApplyVideos is a TalkTransform setting the accepted video link on a talk without a video link in
+// moresleep. A link added in moresleep later takes precedence.
+func (s *VideoService) ApplyVideos(talk domain.Talk) domain.Talk {
+	s.acceptedMu.RLock()
+	videoURL, ok := s.accepted[talk.ID]
+	s.acceptedMu.RUnlock()
+
+	if !ok {
+		return talk
+	}
+	if video, _ := talk.Data["video"].(string); strings.TrimSpace(video) != "" {
+		return talk
+	}
+	data := maps.Clone(talk.Data)
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	data["video"] = videoURL
+	talk.Data = data
+	return talk
+}
+
+// RejectProposal marks the proposal as rejected by the acting user so the video is not proposed again
+func (s *VideoService) RejectProposal(ctx context.Context, talkID string) error {
+	return s.decide(ctx, talkID, domain.ProposalRejected, nil)
+}
+
+// decide applies the decision to the pending proposal for the talk, running apply first if given
+func (s *VideoService) decide(ctx context.Context, talkID string, status domain.ProposalStatus, apply func(domain.VideoProposal) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proposals, err := s.loadProposals(ctx)
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(proposals, func(p domain.VideoProposal) bool {
+		return p.TalkID == talkID && p.Status == domain.ProposalPending
+	})
+	if i < 0 {
+		return domain.ErrProposalNotFound
+	}
+
+	if apply != nil {
+		if err := apply(proposals[i]); err != nil {
+			return err
+		}
+	}
+
+	decided := s.now().UTC()
+	proposals[i].Status = status
+	proposals[i].DecidedBy = domain.ActorFromContext(ctx).Name
+	proposals[i].DecidedAt = &decided
+
+	if err := s.saveProposals(ctx, proposals); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "decided video proposal", "talkID", talkID, "status", status, "videoUrl", proposals[i].VideoURL)
+	return nil
+}
+
+// loadProposals reads the stored proposals
+func (s *VideoService) loadProposals(ctx context.Context) ([]domain.VideoProposal, error) {
+	var proposals []domain.VideoProposal
+	if _, err := s.store.LoadSetting(ctx, videoProposalsKey, &proposals); err != nil {
+		return nil, fmt.Errorf("failed to load video proposals: %w", err)
+	}
+	s.rememberAccepted(proposals)
+	return proposals, nil
+}
+
+// saveProposals stores the proposals
+func (s *VideoService) saveProposals(ctx context.Context, proposals []domain.VideoProposal) error {
+	if err := s.store.SaveSetting(ctx, videoProposalsKey, proposals); err != nil {
+		return fmt.Errorf("failed to save video proposals: %w", err)
+	}
+	s.rememberAccepted(proposals)
+	return nil
+}
+
+// rememberAccepted keeps the accepted video links of the stored proposals for ApplyVideos
+func (s *VideoService) rememberAccepted(proposals []domain.VideoProposal) {
+	accepted := make(map[string]string)
+	for _, proposal := range proposals {
+		if proposal.Status == domain.ProposalAccepted {
+			accepted[proposal.TalkID] = proposal.VideoURL
+		}
+	}
+
+	s.acceptedMu.Lock()
+	s.accepted = accepted
+	s.acceptedMu.Unlock()
+}
+
+// bestVideoMatch returns the video scoring highest against the talk, skipping excluded videos
+func bestVideoMatch(talk domain.MissingVideoTalk, videos []domain.Video, excluded func(domain.Video) bool) (domain.Video, float64, bool) {
+	var best domain.Video
+	bestScore := 0.0
+	found := false
+	for _, video := range videos {
+		if excluded(video) {
+			continue
+		}
+		if score := videoMatchScore(talk, video); !found || score > bestScore {
+			best, bestScore, found = video, score, true
+		}
+	}
+	return best, bestScore, found
+}
+
+// videoMatchScore scores how well a video matches a talk between 0 and 1: the Dice coefficient of
+// the title words, plus a bonus when a speaker's name appears in the video title or description
+func videoMatchScore(talk domain.MissingVideoTalk, video domain.Video) float64 {
+	score := diceCoefficient(matchTokens(talk.Title), matchTokens(video.Title))
+
+	text := " " + strings.Join(matchTokens(video.Title+" "+video.Description), " ") + " "
+	for _, speaker := range talk.Speakers {
+		name := strings.Join(matchTokens(speaker), " ")
+		if name != "" && strings.Contains(text, " "+name+" ") {
+			score += speakerMatchBonus
+			break
+		}
+	}
+	return min(score, 1)
+}
+
+// diceCoefficient returns twice the number of shared words over the total number of distinct words
+func diceCoefficient(a, b []string) float64 {
+	setA, setB := make(map[string]bool), make(map[string]bool)
+	for _, word := range a {
+		setA[word] = true
+	}
+	for _, word := range b {
+		setB[word] = true
+	}
+	if len(setA)+len(setB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range setA {
+		if setB[word] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(setA)+len(setB))
+}
+
+// matchTokens splits text into lowercase words of letters and digits
+func matchTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// talkStartTime parses the talk's startTime, returning nil if it is missing or unparseable
+func talkStartTime(talk domain.Talk) *time.Time {
+	value, _ := talk.Data["startTime"].(string)
+	if value == "" {
+		return nil
+	}
+	for _, format := range talkTimeFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// speakerNames returns the names of the talk's speakers
+func speakerNames(talk domain.Talk) []string {
+	names := make([]string, 0, len(talk.Speakers))
+	for _, speaker := range talk.Speakers {
+		names = append(names, speaker.Name)
+	}
+	return names
+}
+
+// decidedAt returns when the proposal was decided, or the zero time if it is pending
+func decidedAt(proposal domain.VideoProposal) time.Time {
+	if proposal.DecidedAt == nil {
+		return time.Time{}
+	}
+	return *proposal.DecidedAt
+}
@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockVideoSource is a mock implementation of ports.VideoSource
+type mockVideoSource struct {
+	videos []domain.Video
+	err    error
+}
+
+func (m *mockVideoSource) ListVideos(ctx context.Context) ([]domain.Video, error) {
+	return m.videos, m.err
+}
+
+// mockTalkPatcher is a mock implementation of ports.TalkPatcher
+type mockTalkPatcher struct {
+	patches []string
+	docs    []map[string]interface{}
+	err     error
+}
+
+func (m *mockTalkPatcher) PatchTalk(ctx context.Context, indexName string, talkID string, doc map[string]interface{}) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.docs = append(m.docs, doc)
+	if data, ok := doc["data"].(map[string]interface{}); ok {
+		if video, ok := data["video"].(string); ok {
+			m.patches = append(m.patches, indexName+"/"+talkID+"="+video)
+		}
+	}
+	return nil
+}
+
+func newTestVideoTalks() []domain.Talk {
+	return []domain.Talk{
+		{
+			ID: "talk-1", ConferenceSlug: "javazone2024", ConferenceName: "JavaZone 2024",
+			Data:     map[string]interface{}{"title": "Virtual threads in practice", "startTime": "2024-09-04T10:00"},
+			Speakers: domain.Speakers{{Name: "Jane Doe"}},
+		},
+		{
+			ID: "talk-2", ConferenceSlug: "javazone2024", ConferenceName: "JavaZone 2024",
+			Data:     map[string]interface{}{"title": "Already recorded", "video": "https://vimeo.com/1"},
+			Speakers: domain.Speakers{{Name: "John Roe"}},
+		},
+		{
+			ID: "talk-3", ConferenceSlug: "javazone2023", ConferenceName: "JavaZone 2023",
+			Data:     map[string]interface{}{"title": "Kotlin coroutines deep dive"},
+			Speakers: domain.Speakers{{Name: "Ola Nordmann"}},
+		},
+		{
+			ID: "talk-4", ConferenceSlug: "javazone2025", ConferenceName: "JavaZone 2025",
+			Data: map[string]interface{}{"title": "Upcoming talk", "startTime": "2025-09-03T10:00"},
+		},
+	}
+}
+
+func newTestVideoService(store *mockSettingsStore, patcher *mockTalkPatcher, source *mockVideoSource) *VideoService {
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return newTestVideoTalks(), nil
+		},
+	}
+	service := NewVideoServiceWithConfig(reader, patcher, store, "javazone_private", "javazone_public", 0.6)
+	service.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	if source != nil {
+		service.SetVideoSource(source)
+	}
+	return service
+}
+
+func TestVideoService_MissingVideos(t *testing.T) {
+	service := newTestVideoService(newMockSettingsStore(), &mockTalkPatcher{}, nil)
+
+	missing, err := service.MissingVideos(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, missing, 2)
+	assert.Equal(t, "talk-1", missing[0].TalkID, "newest conference first")
+	assert.Equal(t, []string{"Jane Doe"}, missing[0].Speakers)
+	require.NotNil(t, missing[0].StartTime)
+	assert.Equal(t, "talk-3", missing[1].TalkID, "past conference year without start time")
+	assert.Nil(t, missing[1].StartTime)
+	assert.False(t, service.IsConfigured())
+}
+
+func TestVideoService_ProposeVideos(t *testing.T) {
+	store := newMockSettingsStore()
+	source := &mockVideoSource{videos: []domain.Video{
+		{ID: "10", Title: "Virtual threads in practice - Jane Doe", URL: "https://vimeo.com/10"},
+		{ID: "11", Title: "Something else entirely", URL: "https://vimeo.com/11"},
+	}}
+	service := newTestVideoService(store, &mockTalkPatcher{}, source)
+	jobs := newMockJobStore()
+	service.SetJobStore(jobs)
+
+	require.NoError(t, service.ProposeVideos(context.Background()))
+
+	proposals, err := service.ListProposals(context.Background())
+	require.NoError(t, err)
+	require.Len(t, proposals, 1, "talk-3 has no match above the threshold")
+	assert.Equal(t, "talk-1", proposals[0].TalkID)
+	assert.Equal(t, "https://vimeo.com/10", proposals[0].VideoURL)
+	assert.Equal(t, domain.ProposalPending, proposals[0].Status)
+	assert.Greater(t, proposals[0].Score, 0.6)
+
+	require.Contains(t, jobs.jobs, "job-1")
+	assert.Equal(t, domain.JobKindVideoBackfill, jobs.jobs["job-1"].Scope.Kind)
+	assert.Equal(t, domain.JobStateSucceeded, jobs.jobs["job-1"].State)
+}
+
+func TestVideoService_ProposeVideos_NotConfigured(t *testing.T) {
+	service := newTestVideoService(newMockSettingsStore(), &mockTalkPatcher{}, nil)
+
+	err := service.ProposeVideos(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no video channel")
+}
+
+func TestVideoService_ProposeVideos_SkipsRejected(t *testing.T) {
+	store := newMockSettingsStore()
+	source := &mockVideoSource{videos: []domain.Video{
+		{ID: "10", Title: "Virtual threads in practice", URL: "https://vimeo.com/10"},
+	}}
+	service := newTestVideoService(store, &mockTalkPatcher{}, source)
+
+	require.NoError(t, service.ProposeVideos(context.Background()))
+	require.NoError(t, service.RejectProposal(context.Background(), "talk-1"))
+	require.NoError(t, service.ProposeVideos(context.Background()))
+
+	proposals, err := service.ListProposals(context.Background())
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	assert.Equal(t, domain.ProposalRejected, proposals[0].Status)
+}
+
+func TestVideoService_AcceptProposal(t *testing.T) {
+	store := newMockSettingsStore()
+	patcher := &mockTalkPatcher{}
+	source := &mockVideoSource{videos: []domain.Video{
+		{ID: "10", Title: "Virtual threads in practice", URL: "https://vimeo.com/10"},
+	}}
+	service := newTestVideoService(store, patcher, source)
+	require.NoError(t, service.ProposeVideos(context.Background()))
+
+	ctx := domain.WithActor(context.Background(), domain.Actor{Kind: domain.ActorUser, Name: "admin@example.com"})
+	require.NoError(t, service.AcceptProposal(ctx, "talk-1"))
+
+	assert.Equal(t, []string{
+		"javazone_private/talk-1=https://vimeo.com/10",
+		"javazone_public/talk-1=https://vimeo.com/10",
+	}, patcher.patches)
+
+	proposals, err := service.ListProposals(context.Background())
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	assert.Equal(t, domain.ProposalAccepted, proposals[0].Status)
+	assert.Equal(t, "admin@example.com", proposals[0].DecidedBy)
+	assert.NotNil(t, proposals[0].DecidedAt)
+
+	assert.ErrorIs(t, service.AcceptProposal(ctx, "talk-1"), domain.ErrProposalNotFound)
+}
+
+func TestVideoService_ApplyVideos(t *testing.T) {
+	store := newMockSettingsStore()
+	source := &mockVideoSource{videos: []domain.Video{
+		{ID: "10", Title: "Virtual threads in practice", URL: "https://vimeo.com/10"},
+	}}
+	service := newTestVideoService(store, &mockTalkPatcher{}, source)
+	require.NoError(t, service.ProposeVideos(context.Background()))
+
+	talk := domain.Talk{ID: "talk-1", Data: map[string]interface{}{"title": "Virtual threads in practice"}}
+	assert.Nil(t, service.ApplyVideos(talk).Data["video"], "pending proposals are not applied")
+
+	require.NoError(t, service.AcceptProposal(context.Background(), "talk-1"))
+
+	// A restarted instance reads the accepted links from the settings store
+	restarted := newTestVideoService(store, &mockTalkPatcher{}, nil)
+	require.NoError(t, restarted.LoadAcceptedVideos(context.Background()))
+
+	applied := restarted.ApplyVideos(talk)
+	assert.Equal(t, "https://vimeo.com/10", applied.Data["video"])
+	assert.Nil(t, talk.Data["video"], "the talk's data is not modified in place")
+
+	withVideo := domain.Talk{ID: "talk-1", Data: map[string]interface{}{"video": "https://vimeo.com/99"}}
+	assert.Equal(t, "https://vimeo.com/99", restarted.ApplyVideos(withVideo).Data["video"], "moresleep's link takes precedence")
+}
+
+func TestVideoService_AcceptProposal_PatchFailureKeepsPending(t *testing.T) {
+	store := newMockSettingsStore()
+	patcher := &mockTalkPatcher{}
+	source := &mockVideoSource{videos: []domain.Video{
+		{ID: "10", Title: "Virtual threads in practice", URL: "https://vimeo.com/10"},
+	}}
+	service := newTestVideoService(store, patcher, source)
+	require.NoError(t, service.ProposeVideos(context.Background()))
+
+	patcher.err = errors.New("document missing")
+	require.Error(t, service.AcceptProposal(context.Background(), "talk-1"))
+
+	proposals, err := service.ListProposals(context.Background())
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	assert.Equal(t, domain.ProposalPending, proposals[0].Status)
+}
+
+func TestVideoMatchScore(t *testing.T) {
+	talk := domain.MissingVideoTalk{Title: "Virtual threads in practice", Speakers: []string{"Jane Doe"}}
+
+	exact := videoMatchScore(talk, domain.Video{Title: "Virtual Threads in Practice"})
+	withSpeaker := videoMatchScore(talk, domain.Video{Title: "Virtual threads in practice", Description: "Talk by Jane Doe"})
+	unrelated := videoMatchScore(talk, domain.Video{Title: "Kotlin coroutines deep dive"})
+
+	assert.Equal(t, 1.0, exact)
+	assert.Equal(t, 1.0, withSpeaker, "score is capped at 1")
+	assert.Equal(t, 0.0, unrelated)
+	assert.InDelta(t, 0.5+speakerMatchBonus, videoMatchScore(talk, domain.Video{Title: "Virtual threads - Jane Doe"}), 0.001)
+}
@@ -0,0 +1,362 @@
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// webhookSubscriptionsKey is the settings key holding all webhook subscriptions
+const webhookSubscriptionsKey = "webhooks:subscriptions"
+
+// Headers sent with every outbound webhook. The signature uses the same scheme the
+// indexer verifies on inbound webhooks: hex HMAC-SHA256 of "<timestamp>.<body>".
+const (
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookDeliveryHeader  = "X-Webhook-Delivery"
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+	webhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookService manages outbound webhook subscriptions and delivers events to them.
+// Deliveries run in the background and are retried with exponential backoff; the
+// most recent deliveries are kept in memory for the delivery log.
+type WebhookService struct {
+	store  ports.SettingsStore
+	sender ports.WebhookSender
+	cfg    config.WebhookDeliveryConfig
+	logger *slog.Logger
+
+	// now and sleep are replaceable in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	subscriptionsMu sync.Mutex
+
+	log *deliveryLog
+
+	inflight sync.WaitGroup
+}
+
+// deliveryLog holds the most recent deliveries, oldest first
+type deliveryLog struct {
+	mu         sync.Mutex
+	deliveries []domain.WebhookDelivery
+}
+
+// NewWebhookService creates a new WebhookService storing subscriptions in the given settings store
+func NewWebhookService(store ports.SettingsStore, sender ports.WebhookSender, cfg config.WebhookDeliveryConfig) *WebhookService {
+	return &WebhookService{
+		store:  store,
+		sender: sender,
+		cfg:    cfg,
+		logger: slog.Default().With("component", "webhooks"),
+		now:    time.Now,
+		sleep:  sleepContext,
+		log:    &deliveryLog{},
+	}
+}
+
+// KeepDeliveriesOf shares the delivery log of the service this one replaces on a configuration reload,
+// so the log keeps its history and deliveries still retried by the replaced service are updated in it
+func (s *WebhookService) KeepDeliveriesOf(previous *WebhookService) {
+	s.log = previous.log
+}
+
+// ListSubscriptions returns all webhook subscriptions
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	if _, err := s.store.LoadSetting(ctx, webhookSubscriptionsKey, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// CreateSubscription validates and stores a new subscription with a generated ID and secret
+func (s *WebhookService) CreateSubscription(ctx context.Context, rawURL string, events []domain.EventType, description string) (domain.WebhookSubscription, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return domain.WebhookSubscription{}, fmt.Errorf("invalid webhook URL %q: must be an absolute http or https URL", rawURL)
+	}
+
+	if len(events) == 0 {
+		return domain.WebhookSubscription{}, errors.New("at least one event type is required")
+	}
+	for _, eventType := range events {
+		if !slices.Contains(domain.EventTypes, eventType) {
+			return domain.WebhookSubscription{}, fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("failed to generate subscription ID: %w", err)
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("failed to generate subscription secret: %w", err)
+	}
+
+	subscription := domain.WebhookSubscription{
+		ID:          id,
+		URL:         rawURL,
+		Events:      slices.Compact(slices.Sorted(slices.Values(events))),
+		Secret:      secret,
+		Description: strings.TrimSpace(description),
+		CreatedAt:   s.now().UTC(),
+	}
+
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+
+	subscriptions, err := s.ListSubscriptions(ctx)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	subscriptions = append(subscriptions, subscription)
+	if err := s.store.SaveSetting(ctx, webhookSubscriptionsKey, subscriptions); err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("failed to save webhook subscriptions: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "created webhook subscription", "subscriptionID", id, "url", rawURL, "events", subscription.Events)
+	return subscription, nil
+}
+
+// DeleteSubscription removes a subscription by ID
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+
+	subscriptions, err := s.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := slices.DeleteFunc(subscriptions, func(sub domain.WebhookSubscription) bool {
+		return sub.ID == id
+	})
+	// DeleteFunc shrinks in place, so an unchanged length means nothing matched
+	if len(remaining) == len(subscriptions) {
+		return domain.ErrSubscriptionNotFound
+	}
+
+	if err := s.store.SaveSetting(ctx, webhookSubscriptionsKey, remaining); err != nil {
+		return fmt.Errorf("failed to save webhook subscriptions: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "deleted webhook subscription", "subscriptionID", id)
+	return nil
+}
+
+// Deliveries returns the most recent deliveries, newest first. A limit <= 0 returns all kept deliveries.
+func (s *WebhookService) Deliveries(limit int) []domain.WebhookDelivery {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+
+	count := len(s.log.deliveries)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+
+	result := make([]domain.WebhookDelivery, 0, count)
+	for i := len(s.log.deliveries) - 1; i >= 0 && len(result) < count; i-- {
+		result = append(result, s.log.deliveries[i])
+	}
+	return result
+}
+
+// Notify delivers the event to every subscription that wants it. Deliveries run in the
+// background so a slow or unavailable receiver never holds up indexing.
+func (s *WebhookService) Notify(ctx context.Context, event domain.Event) {
+	subscriptions, err := s.ListSubscriptions(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to load webhook subscriptions for event", "eventType", event.Type, "error", err)
+		return
+	}
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.now().UTC()
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to marshal webhook event", "eventType", event.Type, "error", err)
+		return
+	}
+
+	// Deliveries outlive the request that raised the event
+	deliveryCtx := context.WithoutCancel(ctx)
+
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.Type) {
+			continue
+		}
+
+		delivery, err := s.newDelivery(subscription, event.Type)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to create webhook delivery", "subscriptionID", subscription.ID, "error", err)
+			continue
+		}
+
+		s.inflight.Add(1)
+		go func() {
+			defer s.inflight.Done()
+			s.deliver(deliveryCtx, subscription, delivery, body)
+		}()
+	}
+}
+
+// Wait blocks until all background deliveries have finished
+func (s *WebhookService) Wait() {
+	s.inflight.Wait()
+}
+
+// newDelivery creates a pending delivery and adds it to the delivery log
+func (s *WebhookService) newDelivery(subscription domain.WebhookSubscription, eventType domain.EventType) (domain.WebhookDelivery, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	now := s.now().UTC()
+	delivery := domain.WebhookDelivery{
+		ID:             id,
+		SubscriptionID: subscription.ID,
+		URL:            subscription.URL,
+		EventType:      eventType,
+		Status:         domain.DeliveryPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	s.recordDelivery(delivery)
+	return delivery, nil
+}
+
+// deliver sends the payload, retrying with exponential backoff until it is accepted
+// with a 2xx response or the configured number of attempts is used up
+func (s *WebhookService) deliver(ctx context.Context, subscription domain.WebhookSubscription, delivery domain.WebhookDelivery, body []byte) {
+	maxAttempts := max(s.cfg.MaxAttempts, 1)
+	backoff := s.cfg.InitialBackoff
+
+	for delivery.Attempts < maxAttempts {
+		if delivery.Attempts > 0 {
+			if err := s.sleep(ctx, backoff); err != nil {
+				break
+			}
+			backoff = min(backoff*2, s.cfg.MaxBackoff)
+		}
+
+		delivery.Attempts++
+		statusCode, err := s.send(ctx, subscription, delivery, body)
+		delivery.LastStatusCode = statusCode
+		delivery.UpdatedAt = s.now().UTC()
+
+		switch {
+		case err != nil:
+			delivery.LastError = err.Error()
+		case statusCode < 200 || statusCode > 299:
+			delivery.LastError = fmt.Sprintf("unexpected status code %d", statusCode)
+		default:
+			delivery.LastError = ""
+			delivery.Status = domain.DeliveryDelivered
+			s.recordDelivery(delivery)
+			s.logger.DebugContext(ctx, "delivered webhook", "deliveryID", delivery.ID, "subscriptionID", subscription.ID,
+				"eventType", delivery.EventType, "attempts", delivery.Attempts)
+			return
+		}
+
+		s.recordDelivery(delivery)
+		s.logger.WarnContext(ctx, "webhook delivery attempt failed", "deliveryID", delivery.ID, "subscriptionID", subscription.ID,
+			"attempt", delivery.Attempts, "statusCode", statusCode, "error", delivery.LastError)
+	}
+
+	delivery.Status = domain.DeliveryFailed
+	delivery.UpdatedAt = s.now().UTC()
+	s.recordDelivery(delivery)
+	s.logger.ErrorContext(ctx, "webhook delivery failed", "deliveryID", delivery.ID, "subscriptionID", subscription.ID,
+		"eventType", delivery.EventType, "attempts", delivery.Attempts, "error", delivery.LastError)
+}
+
+// send makes a single signed delivery attempt bounded by the configured timeout
+func (s *WebhookService) send(ctx context.Context, subscription domain.WebhookSubscription, delivery domain.WebhookDelivery, body []byte) (int, error) {
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	headers := map[string]string{
+		webhookEventHeader:     string(delivery.EventType),
+		webhookDeliveryHeader:  delivery.ID,
+		webhookTimestampHeader: timestamp,
+		webhookSignatureHeader: signWebhook(subscription.Secret, timestamp, body),
+	}
+
+	return s.sender.SendWebhook(ctx, subscription.URL, body, headers)
+}
+
+// recordDelivery adds or updates a delivery in the log, dropping the oldest entries beyond the log size
+func (s *WebhookService) recordDelivery(delivery domain.WebhookDelivery) {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+
+	for i := range s.log.deliveries {
+		if s.log.deliveries[i].ID == delivery.ID {
+			s.log.deliveries[i] = delivery
+			return
+		}
+	}
+
+	s.log.deliveries = append(s.log.deliveries, delivery)
+	if size := max(s.cfg.LogSize, 1); len(s.log.deliveries) > size {
+		s.log.deliveries = slices.Delete(s.log.deliveries, 0, len(s.log.deliveries)-size)
+	}
+}
+
+// signWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>" using the subscription secret
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// sleepContext waits for the duration or until the context is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,295 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentWebhook is a webhook request recorded by mockWebhookSender
+type sentWebhook struct {
+	url     string
+	body    []byte
+	headers map[string]string
+}
+
+// mockWebhookSender is a mock implementation of ports.WebhookSender
+type mockWebhookSender struct {
+	mu       sync.Mutex
+	sent     []sentWebhook
+	sendFunc func(attempt int) (int, error)
+}
+
+func (m *mockWebhookSender) SendWebhook(ctx context.Context, url string, body []byte, headers map[string]string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, sentWebhook{url: url, body: body, headers: headers})
+	if m.sendFunc != nil {
+		return m.sendFunc(len(m.sent))
+	}
+	return http.StatusOK, nil
+}
+
+// mockEventNotifier is a mock implementation of ports.EventNotifier recording every event
+type mockEventNotifier struct {
+	events []domain.Event
+}
+
+func (m *mockEventNotifier) Notify(ctx context.Context, event domain.Event) {
+	m.events = append(m.events, event)
+}
+
+var testWebhookDeliveryConfig = config.WebhookDeliveryConfig{
+	MaxAttempts:    3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     90 * time.Second,
+	LogSize:        10,
+}
+
+// newTestWebhookService creates a WebhookService recording backoff waits instead of sleeping
+func newTestWebhookService(sender *mockWebhookSender, cfg config.WebhookDeliveryConfig) (*WebhookService, *[]time.Duration) {
+	service := NewWebhookService(newMockSettingsStore(), sender, cfg)
+
+	var mu sync.Mutex
+	waits := &[]time.Duration{}
+	service.sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*waits = append(*waits, d)
+		return nil
+	}
+	return service, waits
+}
+
+func TestWebhooks_CreateSubscription(t *testing.T) {
+	service, _ := newTestWebhookService(&mockWebhookSender{}, testWebhookDeliveryConfig)
+	ctx := context.Background()
+
+	sub, err := service.CreateSubscription(ctx, " https://example.com/hook ",
+		[]domain.EventType{domain.EventTalkPublished, domain.EventReindexCompleted, domain.EventTalkPublished}, "Program site")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, sub.ID)
+	assert.Len(t, sub.Secret, 64)
+	assert.Equal(t, "https://example.com/hook", sub.URL)
+	assert.Equal(t, []domain.EventType{domain.EventReindexCompleted, domain.EventTalkPublished}, sub.Events)
+
+	subscriptions, err := service.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.WebhookSubscription{sub}, subscriptions)
+}
+
+func TestWebhooks_CreateSubscription_Invalid(t *testing.T) {
+	service, _ := newTestWebhookService(&mockWebhookSender{}, testWebhookDeliveryConfig)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		url    string
+		events []domain.EventType
+	}{
+		{name: "relative URL", url: "/hook", events: []domain.EventType{domain.EventTalkPublished}},
+		{name: "unsupported scheme", url: "ftp://example.com/hook", events: []domain.EventType{domain.EventTalkPublished}},
+		{name: "no events", url: "https://example.com/hook"},
+		{name: "unknown event", url: "https://example.com/hook", events: []domain.EventType{"talk.archived"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateSubscription(ctx, tt.url, tt.events, "")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWebhooks_DeleteSubscription(t *testing.T) {
+	service, _ := newTestWebhookService(&mockWebhookSender{}, testWebhookDeliveryConfig)
+	ctx := context.Background()
+
+	sub, err := service.CreateSubscription(ctx, "https://example.com/hook", []domain.EventType{domain.EventTalkPublished}, "")
+	require.NoError(t, err)
+
+	require.NoError(t, service.DeleteSubscription(ctx, sub.ID))
+	assert.ErrorIs(t, service.DeleteSubscription(ctx, sub.ID), domain.ErrSubscriptionNotFound)
+
+	subscriptions, err := service.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, subscriptions)
+}
+
+func TestWebhooks_Notify_SignsMatchingSubscriptions(t *testing.T) {
+	sender := &mockWebhookSender{}
+	service, _ := newTestWebhookService(sender, testWebhookDeliveryConfig)
+	ctx := context.Background()
+
+	sub, err := service.CreateSubscription(ctx, "https://example.com/published", []domain.EventType{domain.EventTalkPublished}, "")
+	require.NoError(t, err)
+	_, err = service.CreateSubscription(ctx, "https://example.com/reindex", []domain.EventType{domain.EventReindexCompleted}, "")
+	require.NoError(t, err)
+
+	service.Notify(ctx, domain.Event{Type: domain.EventTalkPublished, Data: map[string]interface{}{"talkId": "talk-1"}})
+	service.Wait()
+
+	require.Len(t, sender.sent, 1)
+	sent := sender.sent[0]
+	assert.Equal(t, "https://example.com/published", sent.url)
+	assert.Equal(t, "talk.published", sent.headers[webhookEventHeader])
+	assert.Equal(t, signWebhook(sub.Secret, sent.headers[webhookTimestampHeader], sent.body), sent.headers[webhookSignatureHeader])
+
+	var event domain.Event
+	require.NoError(t, json.Unmarshal(sent.body, &event))
+	assert.Equal(t, domain.EventTalkPublished, event.Type)
+	assert.Equal(t, "talk-1", event.Data["talkId"])
+	assert.False(t, event.OccurredAt.IsZero())
+
+	deliveries := service.Deliveries(0)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, domain.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, sent.headers[webhookDeliveryHeader], deliveries[0].ID)
+}
+
+func TestWebhooks_Notify_RetriesWithBackoff(t *testing.T) {
+	sender := &mockWebhookSender{
+		sendFunc: func(attempt int) (int, error) {
+			if attempt < 3 {
+				return http.StatusBadGateway, nil
+			}
+			return http.StatusNoContent, nil
+		},
+	}
+	service, waits := newTestWebhookService(sender, testWebhookDeliveryConfig)
+	ctx := context.Background()
+
+	_, err := service.CreateSubscription(ctx, "https://example.com/hook", []domain.EventType{domain.EventReindexCompleted}, "")
+	require.NoError(t, err)
+
+	service.Notify(ctx, domain.Event{Type: domain.EventReindexCompleted})
+	service.Wait()
+
+	assert.Len(t, sender.sent, 3)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *waits)
+
+	deliveries := service.Deliveries(0)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, domain.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].LastStatusCode)
+	assert.Empty(t, deliveries[0].LastError)
+}
+
+func TestWebhooks_Notify_FailsAfterMaxAttempts(t *testing.T) {
+	sender := &mockWebhookSender{
+		sendFunc: func(attempt int) (int, error) {
+			return 0, errors.New("connection refused")
+		},
+	}
+	cfg := testWebhookDeliveryConfig
+	cfg.MaxAttempts = 5
+	cfg.MaxBackoff = 3 * time.Second
+	service, waits := newTestWebhookService(sender, cfg)
+	ctx := context.Background()
+
+	_, err := service.CreateSubscription(ctx, "https://example.com/hook", []domain.EventType{domain.EventReindexCompleted}, "")
+	require.NoError(t, err)
+
+	service.Notify(ctx, domain.Event{Type: domain.EventReindexCompleted})
+	service.Wait()
+
+	assert.Len(t, sender.sent, 5)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}, *waits)
+
+	deliveries := service.Deliveries(0)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, domain.DeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, 5, deliveries[0].Attempts)
+	assert.Contains(t, deliveries[0].LastError, "connection refused")
+}
+
+func TestWebhooks_DeliveryLogIsBounded(t *testing.T) {
+	cfg := testWebhookDeliveryConfig
+	cfg.LogSize = 2
+	service, _ := newTestWebhookService(&mockWebhookSender{}, cfg)
+	ctx := context.Background()
+
+	_, err := service.CreateSubscription(ctx, "https://example.com/hook", []domain.EventType{domain.EventReindexCompleted}, "")
+	require.NoError(t, err)
+
+	for range 3 {
+		service.Notify(ctx, domain.Event{Type: domain.EventReindexCompleted})
+		service.Wait()
+	}
+
+	assert.Len(t, service.Deliveries(0), 2)
+	assert.Len(t, service.Deliveries(1), 1)
+}
+
+func TestWebhooks_KeepDeliveriesOf(t *testing.T) {
+	previous, _ := newTestWebhookService(&mockWebhookSender{}, testWebhookDeliveryConfig)
+	ctx := context.Background()
+
+	_, err := previous.CreateSubscription(ctx, "https://example.com/hook", []domain.EventType{domain.EventReindexCompleted}, "")
+	require.NoError(t, err)
+	previous.Notify(ctx, domain.Event{Type: domain.EventReindexCompleted})
+	previous.Wait()
+
+	next, _ := newTestWebhookService(&mockWebhookSender{}, testWebhookDeliveryConfig)
+	next.KeepDeliveriesOf(previous)
+
+	assert.Equal(t, previous.Deliveries(0), next.Deliveries(0))
+	assert.Len(t, next.Deliveries(0), 1)
+}
+
+func TestReindexTalk_RaisesEvents(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{
+				ID:             talkID,
+				ConferenceSlug: "javazone2024",
+				Status:         "APPROVED",
+				Data:           map[string]interface{}{"title": "Go in production"},
+			}, nil
+		},
+	}
+	notifier := &mockEventNotifier{}
+
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetNotifier(notifier)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	require.Len(t, notifier.events, 2)
+	assert.Equal(t, domain.EventTalkPublished, notifier.events[0].Type)
+	assert.Equal(t, "talk-1", notifier.events[0].Data["talkId"])
+	assert.Equal(t, "Go in production", notifier.events[0].Data["title"])
+
+	assert.Equal(t, domain.EventReindexCompleted, notifier.events[1].Type)
+	assert.Equal(t, domain.JobStateSucceeded, notifier.events[1].Data["state"])
+}
+
+func TestReindexTalk_NotPublicRaisesOnlyCompletion(t *testing.T) {
+	source := &mockTalkSource{
+		getTalkFunc: func(ctx context.Context, talkID string) (*domain.Talk, error) {
+			return &domain.Talk{ID: talkID, ConferenceSlug: "javazone2024", Status: "SUBMITTED"}, nil
+		},
+	}
+	notifier := &mockEventNotifier{}
+
+	service := NewIndexerServiceWithConfig(source, &mockSearchIndex{}, "private", "public", testPrivateMapping, testPublicMapping)
+	service.SetNotifier(notifier)
+
+	require.NoError(t, service.ReindexTalk(context.Background(), "talk-1"))
+
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, domain.EventReindexCompleted, notifier.events[0].Type)
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// WhatIfService builds scratch indexes from the talks of one conference with an alternative transformation
+// config, such as a proposed redaction policy or analyzer, so its effect can be evaluated against real data.
+// The scratch indexes are named after the live indexes with a what-if suffix and are never read by the
+// public endpoints; they are deleted on the indexes page once evaluated.
+type WhatIfService struct {
+	indexer  *IndexerService
+	reader   ports.TalkReader
+	defaults domain.WhatIfSettings
+	jobs     ports.JobStore
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+// NewWhatIfService creates a new WhatIfService, receiving context as first parameter
+// to retrieve the live transformation config.
+func NewWhatIfService(ctx context.Context, indexer *IndexerService, reader ports.TalkReader) *WhatIfService {
+	cfg := config.GetConfig(ctx)
+	return NewWhatIfServiceWithConfig(indexer, reader, cfg.Transform)
+}
+
+// NewWhatIfServiceWithConfig creates a new WhatIfService with explicit configuration.
+// This constructor is primarily intended for testing purposes.
+func NewWhatIfServiceWithConfig(indexer *IndexerService, reader ports.TalkReader, transform config.TransformConfig) *WhatIfService {
+	return &WhatIfService{
+		indexer: indexer,
+		reader:  reader,
+		defaults: domain.WhatIfSettings{
+			AbstractHTML:       transform.AbstractHTML,
+			ScrubPublic:        transform.ScrubPublic,
+			ScrubFields:        transform.ScrubFields,
+			ScrubSpeakerFields: transform.ScrubSpeakerFields,
+			ScrubWords:         transform.ScrubWords,
+		},
+		now:    time.Now,
+		logger: slog.Default().With("component", "whatif"),
+	}
+}
+
+// SetJobStore enables recording every what-if build as a job
+func (s *WhatIfService) SetJobStore(jobs ports.JobStore) {
+	s.jobs = jobs
+}
+
+// WhatIfDefaults returns the settings the live indexes are built with
+func (s *WhatIfService) WhatIfDefaults() domain.WhatIfSettings {
+	return s.defaults
+}
+
+// BuildWhatIf builds a private and a public scratch index for the conference with the given settings,
+// recorded as a what-if job, and lists the public talks whose document differs from the live public index.
+// Talks flagged for review under the given settings are in the job report.
+func (s *WhatIfService) BuildWhatIf(ctx context.Context, slug string, settings domain.WhatIfSettings) (domain.WhatIfResult, error) {
+	if settings.Analyzer != "" && !slices.Contains(domain.WhatIfAnalyzers, settings.Analyzer) {
+		return domain.WhatIfResult{}, fmt.Errorf("unsupported analyzer %q", settings.Analyzer)
+	}
+
+	generation := s.now().UTC().Format(generationLayout)
+	suffix := "_whatif_" + strings.ToLower(slug) + "_" + generation
+	result := domain.WhatIfResult{
+		PrivateIndex: s.indexer.privateIndex + suffix,
+		PublicIndex:  s.indexer.publicIndex + suffix,
+	}
+
+	job, err := recordJob(ctx, s.jobs, s.logger, domain.JobScope{Kind: domain.JobKindWhatIf, Target: slug}, func(ctx context.Context) error {
+		return s.build(ctx, slug, settings, &result)
+	})
+	result.Job = job
+	return result, err
+}
+
+// build fetches and transforms the talks with the given settings and writes them to the scratch indexes
+func (s *WhatIfService) build(ctx context.Context, slug string, settings domain.WhatIfSettings, result *domain.WhatIfResult) error {
+	conference, err := s.indexer.conferenceBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	talks, err := s.indexer.source.GetTalks(ctx, conference.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch talks for conference %s: %w", slug, err)
+	}
+
+	scratch := s.scratchIndexer(settings)
+	talks = scratch.applyTransforms(ctx, talks)
+	privateTalks := prepareTalksForPrivateIndex(scratch.withoutExpired(ctx, talks))
+	publicTalks := filterApprovedTalksForPublic(scratch.scrubPublic(ctx, talks))
+
+	privateMapping, err := withDefaultAnalyzer(s.indexer.privateIndexMapping, settings.Analyzer)
+	if err != nil {
+		return err
+	}
+	publicMapping, err := withDefaultAnalyzer(s.indexer.publicIndexMapping, settings.Analyzer)
+	if err != nil {
+		return err
+	}
+
+	if err := s.writeIndex(ctx, result.PrivateIndex, privateMapping, privateTalks); err != nil {
+		return err
+	}
+	if err := s.writeIndex(ctx, result.PublicIndex, publicMapping, publicTalks); err != nil {
+		s.deleteIndexes(ctx, result.PrivateIndex)
+		return err
+	}
+	result.PrivateTalks = len(privateTalks)
+	result.PublicTalks = len(publicTalks)
+
+	changed, err := s.compareWithLive(ctx, slug, publicTalks)
+	if err != nil {
+		return err
+	}
+	result.Changed = changed
+
+	s.logger.InfoContext(ctx, "what-if indexes built",
+		"slug", slug,
+		"privateIndex", result.PrivateIndex,
+		"publicIndex", result.PublicIndex,
+		"changed", len(changed),
+	)
+	return nil
+}
+
+// scratchIndexer returns an indexer building documents like the live indexer, with its transforms,
+// catalogs, conflicts and retention, except that abstract HTML rendering and scrubbing follow the
+// given settings. Only the transforms the settings change differ from the live indexes, so the
+// comparison with the live public index shows the effect of the settings alone.
+func (s *WhatIfService) scratchIndexer(settings domain.WhatIfSettings) *IndexerService {
+	live := s.indexer
+	scratch := NewIndexerServiceWithConfig(live.source, live.searchIndex, live.privateIndex, live.publicIndex,
+		live.privateIndexMapping, live.publicIndexMapping)
+	scratch.SetConferenceCatalog(live.catalog)
+	scratch.SetSeriesCatalog(live.series)
+	scratch.SetReviewerConflicts(live.conflicts, live.organizationField)
+	scratch.SetRetention(live.retention)
+	scratch.transforms = slices.Clone(live.transforms)
+
+	switch {
+	case settings.AbstractHTML && !s.defaults.AbstractHTML:
+		scratch.AddTransform(RenderAbstractHTML)
+	case !settings.AbstractHTML && s.defaults.AbstractHTML:
+		scratch.AddTransform(withoutAbstractHTML)
+	}
+	if settings.ScrubPublic {
+		scratch.SetScrubber(NewScrubber(config.TransformConfig{
+			ScrubFields:        settings.ScrubFields,
+			ScrubSpeakerFields: settings.ScrubSpeakerFields,
+			ScrubWords:         settings.ScrubWords,
+		}))
+	}
+	return scratch
+}
+
+// withoutAbstractHTML removes the abstract HTML rendered by the live transforms, for evaluating
+// the indexes without it
+func withoutAbstractHTML(talk domain.Talk) domain.Talk {
+	if _, ok := talk.Data["abstractHtml"]; !ok {
+		return talk
+	}
+	talk.Data = maps.Clone(talk.Data)
+	delete(talk.Data, "abstractHtml")
+	return talk
+}
+
+// writeIndex creates a scratch index and writes the talks to it, deleting the index again if writing fails.
+// Failed documents are not kept as dead letters, since the index is only for evaluation.
+func (s *WhatIfService) writeIndex(ctx context.Context, indexName, mapping string, talks []domain.Talk) error {
+	if err := s.indexer.searchIndex.CreateIndex(ctx, indexName, mapping); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", indexName, err)
+	}
+
+	bulk, err := s.indexer.searchIndex.BulkIndex(ctx, indexName, talks)
+	if err != nil {
+		s.deleteIndexes(ctx, indexName)
+		return fmt.Errorf("failed to index talks to %s: %w", indexName, err)
+	}
+	if report := jobReportFromContext(ctx); report != nil {
+		report.add(indexName, bulk)
+	}
+	return nil
+}
+
+// deleteIndexes removes scratch indexes of a failed build, logging failures
+func (s *WhatIfService) deleteIndexes(ctx context.Context, indexNames ...string) {
+	for _, indexName := range indexNames {
+		if err := s.indexer.searchIndex.DeleteIndex(ctx, indexName); err != nil {
+			s.logger.ErrorContext(ctx, "failed to delete what-if index", "index", indexName, "error", err)
+		}
+	}
+}
+
+// compareWithLive returns the IDs of the public talks whose document differs from the one in the live
+// public index, including talks present in only one of them, sorted by ID
+func (s *WhatIfService) compareWithLive(ctx context.Context, slug string, talks []domain.Talk) ([]string, error) {
+	live := make(map[string]interface{})
+	exists, err := s.indexer.searchIndex.IndexExists(ctx, s.indexer.publicIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if index exists: %w", err)
+	}
+	if exists {
+		liveTalks, err := s.reader.FetchTalks(ctx, s.indexer.publicIndex, slug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch live talks of %s: %w", slug, err)
+		}
+		for _, talk := range liveTalks {
+			if live[talk.ID], err = documentOf(talk); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var changed []string
+	for _, talk := range talks {
+		document, err := documentOf(talk)
+		if err != nil {
+			return nil, err
+		}
+		liveDocument, ok := live[talk.ID]
+		if !ok || !reflect.DeepEqual(document, liveDocument) {
+			changed = append(changed, talk.ID)
+		}
+		delete(live, talk.ID)
+	}
+	for id := range live {
+		changed = append(changed, id)
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
+
+// documentOf returns the talk as the generic JSON value stored in the index, so talks built in memory
+// compare equal to talks read back from the index
+func documentOf(talk domain.Talk) (interface{}, error) {
+	data, err := json.Marshal(talk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal talk %s: %w", talk.ID, err)
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal talk %s: %w", talk.ID, err)
+	}
+	return document, nil
+}
+
+// withDefaultAnalyzer returns the index mapping with the default analyzer replaced by the given
+// built-in analyzer, or the mapping unchanged if analyzer is empty
+func withDefaultAnalyzer(mapping, analyzer string) (string, error) {
+	if analyzer == "" {
+		return mapping, nil
+	}
+
+	var index map[string]interface{}
+	if err := json.Unmarshal([]byte(mapping), &index); err != nil {
+		return "", fmt.Errorf("failed to parse index mapping: %w", err)
+	}
+
+	settings, _ := index["settings"].(map[string]interface{})
+	if settings == nil {
+		settings = make(map[string]interface{})
+		index["settings"] = settings
+	}
+	analysis, _ := settings["analysis"].(map[string]interface{})
+	if analysis == nil {
+		analysis = make(map[string]interface{})
+		settings["analysis"] = analysis
+	}
+	analyzers, _ := analysis["analyzer"].(map[string]interface{})
+	if analyzers == nil {
+		analyzers = make(map[string]interface{})
+		analysis["analyzer"] = analyzers
+	}
+	analyzers["default"] = map[string]interface{}{"type": analyzer}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal index mapping: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// whatIfTestTalks returns a conference with a public talk whose abstract holds an email, a plain
+// public talk and a rejected talk
+func whatIfTestTalks() []domain.Talk {
+	speakers := domain.Speakers{{ID: "s1", Name: "Duke"}}
+	return []domain.Talk{
+		{ID: "talk-1", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "APPROVED", Speakers: speakers,
+			Data: map[string]interface{}{"title": "Records", "abstract": "Questions to duke@example.com"}},
+		{ID: "talk-2", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "APPROVED", Speakers: speakers,
+			Data: map[string]interface{}{"title": "Loom", "abstract": "Virtual threads"}},
+		{ID: "talk-3", ConferenceID: "conf-1", ConferenceSlug: "javazone2024", Status: "REJECTED", Speakers: speakers,
+			Data: map[string]interface{}{"title": "Applets"}},
+	}
+}
+
+func newTestWhatIfService(index *mockSearchIndex, reader *mockTalkReader) *WhatIfService {
+	source := &mockTalkSource{
+		getConferencesFunc: func(ctx context.Context) ([]domain.Conference, error) {
+			return []domain.Conference{{ID: "conf-1", Slug: "javazone2024"}}, nil
+		},
+		getTalksFunc: func(ctx context.Context, conferenceID string) ([]domain.Talk, error) {
+			return whatIfTestTalks(), nil
+		},
+	}
+	indexer := NewIndexerServiceWithConfig(source, index, "private", "public", testPrivateMapping, testPublicMapping)
+	service := NewWhatIfServiceWithConfig(indexer, reader, config.TransformConfig{ScrubFields: []string{"abstract"}})
+	service.now = func() time.Time { return time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC) }
+	return service
+}
+
+func TestWhatIfService_BuildWhatIf(t *testing.T) {
+	// The live public index was built without scrubbing, and still holds a talk that was withdrawn since
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			talks := whatIfTestTalks()
+			return []domain.Talk{talks[0].ToPublic(), talks[1].ToPublic(), {ID: "talk-4", ConferenceSlug: "javazone2024"}}, nil
+		},
+	}
+	var mappings = make(map[string]string)
+	index := &mockSearchIndex{
+		createIndexFunc: func(ctx context.Context, indexName string, mapping string) error {
+			mappings[indexName] = mapping
+			return nil
+		},
+	}
+	service := newTestWhatIfService(index, reader)
+
+	result, err := service.BuildWhatIf(context.Background(), "javazone2024", domain.WhatIfSettings{
+		ScrubPublic: true,
+		ScrubFields: []string{"abstract"},
+		Analyzer:    "norwegian",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "private_whatif_javazone2024_20250901120000", result.PrivateIndex)
+	assert.Equal(t, "public_whatif_javazone2024_20250901120000", result.PublicIndex)
+	assert.Equal(t, []string{result.PrivateIndex, result.PublicIndex}, index.createIndexCalls)
+	assert.Empty(t, index.deleteIndexCalls)
+	assert.Equal(t, 3, result.PrivateTalks)
+	assert.Equal(t, 2, result.PublicTalks)
+	assert.Equal(t, []string{"talk-1", "talk-4"}, result.Changed)
+
+	// The live indexes are only read
+	require.Len(t, index.bulkIndexCalls, 2)
+	assert.Equal(t, result.PrivateIndex, index.bulkIndexCalls[0].IndexName)
+	assert.Equal(t, result.PublicIndex, index.bulkIndexCalls[1].IndexName)
+	assert.Equal(t, "Questions to [email]", index.bulkIndexCalls[1].Talks[0].Data["abstract"])
+	assert.Equal(t, []string{"public"}, reader.fetchCalls)
+
+	var mapping map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(mappings[result.PublicIndex]), &mapping))
+	assert.Equal(t, "norwegian", mapping["settings"].(map[string]interface{})["analysis"].(map[string]interface{})["analyzer"].(map[string]interface{})["default"].(map[string]interface{})["type"])
+
+	assert.Equal(t, domain.JobKindWhatIf, result.Job.Scope.Kind)
+	assert.Equal(t, "javazone2024", result.Job.Scope.Target)
+	assert.Equal(t, domain.JobStateSucceeded, result.Job.State)
+	require.Len(t, result.Job.Report.Review, 1)
+	assert.Equal(t, "talk-1", result.Job.Report.Review[0].TalkID)
+}
+
+func TestWhatIfService_BuildWhatIfKeepsLiveMapping(t *testing.T) {
+	var mappings []string
+	index := &mockSearchIndex{
+		createIndexFunc: func(ctx context.Context, indexName string, mapping string) error {
+			mappings = append(mappings, mapping)
+			return nil
+		},
+		indexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+			return false, nil
+		},
+	}
+	reader := &mockTalkReader{}
+	service := newTestWhatIfService(index, reader)
+
+	result, err := service.BuildWhatIf(context.Background(), "javazone2024", domain.WhatIfSettings{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPrivateMapping, testPublicMapping}, mappings)
+
+	// Without a live public index every public talk is new
+	assert.Equal(t, []string{"talk-1", "talk-2"}, result.Changed)
+	assert.Empty(t, reader.fetchCalls)
+}
+
+func TestWhatIfService_BuildWhatIfKeepsLiveTransforms(t *testing.T) {
+	// The live indexes render abstract HTML and add the accepted video links
+	addVideo := func(talk domain.Talk) domain.Talk {
+		talk.Data = maps.Clone(talk.Data)
+		talk.Data["video"] = "https://vimeo.com/" + talk.ID
+		return talk
+	}
+	index := &mockSearchIndex{}
+	service := newTestWhatIfService(index, &mockTalkReader{})
+	service.indexer.AddTransform(RenderAbstractHTML)
+	service.indexer.AddTransform(addVideo)
+	service.defaults.AbstractHTML = true
+
+	reader := &mockTalkReader{
+		fetchTalksFunc: func(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error) {
+			return service.indexer.publicDocuments(ctx, whatIfTestTalks()[:2]), nil
+		},
+	}
+	service.reader = reader
+
+	result, err := service.BuildWhatIf(context.Background(), "javazone2024", domain.WhatIfSettings{AbstractHTML: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Changed, "the live settings build the live documents")
+
+	result, err = service.BuildWhatIf(context.Background(), "javazone2024", domain.WhatIfSettings{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"talk-2"}, result.Changed, "only the public talk with an abstract loses its HTML")
+
+	public := index.bulkIndexCalls[len(index.bulkIndexCalls)-1].Talks
+	require.Len(t, public, 2)
+	assert.Equal(t, "https://vimeo.com/talk-2", public[1].Data["video"])
+	assert.NotContains(t, public[1].Data, "abstractHtml")
+}
+
+func TestWhatIfService_BuildWhatIfFailures(t *testing.T) {
+	t.Run("unsupported analyzer", func(t *testing.T) {
+		index := &mockSearchIndex{}
+		service := newTestWhatIfService(index, &mockTalkReader{})
+
+		_, err := service.BuildWhatIf(context.Background(), "javazone2024", domain.WhatIfSettings{Analyzer: "icu_analyzer"})
+		require.Error(t, err)
+		assert.Empty(t, index.createIndexCalls)
+	})
+
+	t.Run("unknown conference", func(t *testing.T) {
+		index := &mockSearchIndex{}
+		service := newTestWhatIfService(index, &mockTalkReader{})
+
+		result, err := service.BuildWhatIf(context.Background(), "javazone1999", domain.WhatIfSettings{})
+		require.Error(t, err)
+		assert.Equal(t, domain.JobStateFailed, result.Job.State)
+		assert.Empty(t, index.createIndexCalls)
+	})
+
+	t.Run("public index fails", func(t *testing.T) {
+		index := &mockSearchIndex{
+			bulkIndexFunc: func(ctx context.Context, indexName string, talks []domain.Talk) error {
+				if indexName == "public_whatif_javazone2024_20250901120000" {
+					return errors.New("bulk index had errors")
+				}
+				return nil
+			},
+		}
+		service := newTestWhatIfService(index, &mockTalkReader{})
+
+		_, err := service.BuildWhatIf(context.Background(), "javazone2024", domain.WhatIfSettings{})
+		require.Error(t, err)
+		assert.ElementsMatch(t, []string{"private_whatif_javazone2024_20250901120000", "public_whatif_javazone2024_20250901120000"}, index.deleteIndexCalls)
+	})
+}
+
+func TestWhatIfService_WhatIfDefaults(t *testing.T) {
+	service := NewWhatIfServiceWithConfig(nil, nil, config.TransformConfig{
+		AbstractHTML: true,
+		ScrubPublic:  true,
+		ScrubFields:  []string{"abstract"},
+		ScrubWords:   []string{"secret"},
+	})
+
+	defaults := service.WhatIfDefaults()
+	assert.True(t, defaults.AbstractHTML)
+	assert.True(t, defaults.ScrubPublic)
+	assert.Equal(t, []string{"abstract"}, defaults.ScrubFields)
+	assert.Equal(t, []string{"secret"}, defaults.ScrubWords)
+	assert.Empty(t, defaults.Analyzer)
+}
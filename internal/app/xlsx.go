@@ -0,0 +1,168 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxSheetNameLength is the longest worksheet name spreadsheet applications accept
+const maxSheetNameLength = 31
+
+// xlsxSheet is a worksheet of an XLSX workbook. Cells are strings, ints, float64 or nil for an empty cell.
+type xlsxSheet struct {
+	name string
+	rows [][]interface{}
+}
+
+// xlsxContentTypes, xlsxRelationships and xlsxWorkbook are the fixed parts of a workbook; the
+// worksheets are added to the workbook parts by writeXLSX
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`
+
+	xlsxRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`
+)
+
+// writeXLSX writes the sheets as an Office Open XML workbook. Strings are written inline, so the
+// workbook needs no shared string table or styles. Sheet names are made valid and unique.
+func writeXLSX(w io.Writer, sheets []xlsxSheet) error {
+	archive := zip.NewWriter(w)
+
+	var contentTypes, workbook, workbookRelationships strings.Builder
+	contentTypes.WriteString(xlsxContentTypes)
+	workbook.WriteString(xlsxWorkbook)
+	workbookRelationships.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	used := make(map[string]bool)
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheetName(sheet.name, used)), n, n)
+		fmt.Fprintf(&workbookRelationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+
+		if err := writeZipFile(archive, fmt.Sprintf("xl/worksheets/sheet%d.xml", n), worksheetXML(sheet.rows)); err != nil {
+			return err
+		}
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRelationships.WriteString(`</Relationships>`)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xlsxRelationships},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRelationships.String()},
+	}
+	for _, part := range parts {
+		if err := writeZipFile(archive, part.name, part.content); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
+
+// writeZipFile adds a file to the archive
+func writeZipFile(archive *zip.Writer, name, content string) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to write xlsx part %s: %w", name, err)
+	}
+	if _, err := io.WriteString(f, content); err != nil {
+		return fmt.Errorf("failed to write xlsx part %s: %w", name, err)
+	}
+	return nil
+}
+
+// worksheetXML returns the XML of a worksheet holding the rows
+func worksheetXML(rows [][]interface{}) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			ref := columnName(j) + strconv.Itoa(i+1)
+			switch v := cell.(type) {
+			case nil:
+			case int:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(fmt.Sprint(v)))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// columnName returns the letters of the zero-based column, such as A, Z or AA
+func columnName(column int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return name
+}
+
+// sheetName makes the name a valid worksheet name not yet used: without the characters spreadsheet
+// applications reject, at most 31 characters and unique within the workbook
+func sheetName(name string, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	name = strings.TrimFunc(name, func(r rune) bool { return r == '\'' || unicode.IsSpace(r) })
+	if name == "" {
+		name = "Sheet"
+	}
+
+	candidate := truncateRunes(name, maxSheetNameLength)
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		candidate = truncateRunes(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// truncateRunes returns the first n characters of the string
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// xmlEscape escapes the text for XML content and attribute values, replacing characters XML cannot hold
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
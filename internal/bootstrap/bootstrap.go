@@ -0,0 +1,844 @@
+// Package bootstrap is the composition root of the indexer. It assembles the adapters and services
+// selected by configuration into a runnable App, so the server binary, alternate binaries such as a
+// CLI or worker, and tests wire the system the same way.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/api"
+	"github.com/javaBin/talks-indexer/internal/adapters/auth"
+	"github.com/javaBin/talks-indexer/internal/adapters/bleve"
+	"github.com/javaBin/talks-indexer/internal/adapters/cdn"
+	"github.com/javaBin/talks-indexer/internal/adapters/elasticsearch"
+	grpcadapter "github.com/javaBin/talks-indexer/internal/adapters/grpc"
+	"github.com/javaBin/talks-indexer/internal/adapters/linkcheck"
+	"github.com/javaBin/talks-indexer/internal/adapters/memory"
+	"github.com/javaBin/talks-indexer/internal/adapters/moresleep"
+	"github.com/javaBin/talks-indexer/internal/adapters/registration"
+	"github.com/javaBin/talks-indexer/internal/adapters/sitepreview"
+	"github.com/javaBin/talks-indexer/internal/adapters/smtp"
+	"github.com/javaBin/talks-indexer/internal/adapters/sqlite"
+	"github.com/javaBin/talks-indexer/internal/adapters/video"
+	"github.com/javaBin/talks-indexer/internal/adapters/web"
+	"github.com/javaBin/talks-indexer/internal/adapters/webhook"
+	"github.com/javaBin/talks-indexer/internal/app"
+	"github.com/javaBin/talks-indexer/internal/clock"
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/ports"
+	"github.com/javaBin/talks-indexer/internal/systemd"
+	"github.com/javaBin/talks-indexer/internal/tlsconfig"
+)
+
+// shutdownTimeout bounds the graceful shutdown, including waiting for background reindexes
+const shutdownTimeout = 30 * time.Second
+
+// SearchBackend is what the indexer, the public read endpoints, reports and talk search need from the
+// index store. The Elasticsearch client and the embedded SQLite and Bleve stores provide it.
+type SearchBackend interface {
+	ports.SearchIndex
+	ports.IndexReader
+	ports.QueryRunner
+	ports.HealthCheck
+}
+
+// App is the assembled indexer: the HTTP handler with the API, auth routes and admin UI, the indexer
+// service and its background jobs, the scheduled tasks and the optional gRPC server
+type App struct {
+	// Indexer runs reindexes, including the background jobs started through the API and the admin UI
+	Indexer *app.IndexerService
+
+	// Handler serves every HTTP route, with request metrics, the notice header and body limits applied.
+	// It keeps serving through reloads, passing requests to the routes of the current configuration.
+	Handler http.Handler
+
+	// mu guards the services replaced when the configuration is reloaded
+	mu        sync.RWMutex
+	cfg       *config.Config
+	routes    http.Handler
+	clock     ports.Clock
+	backend   SearchBackend
+	esClient  *elasticsearch.Client
+	jobStore  ports.JobStore
+	api       *api.Adapter
+	web       *web.Adapter
+	auth      *auth.Adapter
+	scheduler *app.Scheduler
+	progress  *app.ReindexProgressService
+	grpc      *grpcadapter.Adapter
+	closers   []io.Closer
+	logger    *slog.Logger
+
+	// webhooks and usage keep deliveries and request counts in memory, nil without Elasticsearch
+	webhooks *app.WebhookService
+	usage    *app.UsageService
+
+	// opts are the options of Build, reused when the configuration is reloaded
+	opts options
+	// reloadMu runs one reload at a time
+	reloadMu sync.Mutex
+	// retired are the services replaced by reloads that are still finishing their background work,
+	// which shutdown waits for; each is released once it is done, see release
+	retired []*App
+	// running is the context of Run and serveErrs its channel of server failures, nil until Run is called
+	running   context.Context
+	serveErrs chan error
+}
+
+// Build assembles the application from the configuration. Nothing is served and no scheduled task
+// runs until Run is called. The caller must Close the app when done with it.
+func Build(ctx context.Context, cfg *config.Config, opts ...Option) (*App, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx = config.WithConfig(ctx, cfg)
+	a := &App{
+		cfg:       cfg,
+		scheduler: app.NewScheduler(),
+		logger:    slog.Default(),
+	}
+	// The API reloads this app, whichever services are current
+	if o.loader != nil {
+		o.reloader = a
+	}
+	a.opts = o
+	if err := a.build(ctx, o); err != nil {
+		a.Close()
+		return nil, err
+	}
+	a.Handler = http.HandlerFunc(a.serveHTTP)
+	return a, nil
+}
+
+// serveHTTP passes the request to the routes of the current configuration
+func (a *App) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	routes := a.routes
+	a.mu.RUnlock()
+	routes.ServeHTTP(w, r)
+}
+
+// build wires the adapters and services in dependency order
+func (a *App) build(ctx context.Context, o options) error {
+	cfg := a.cfg
+
+	// Scheduled runs, session expiry, notice expiry and retention periods follow the clock, which
+	// travels in time in development if CLOCK_OFFSET is set
+	a.clock = o.clock
+	if a.clock == nil {
+		var err error
+		if a.clock, err = a.newClock(); err != nil {
+			return err
+		}
+	}
+	a.scheduler.SetClock(a.clock)
+
+	// Inject latency and errors into the moresleep and Elasticsearch requests if configured
+	if cfg.Chaos.IsConfigured() {
+		if !cfg.Mode.IsDevelopment() {
+			return errors.New("CHAOS_ fault injection is only allowed in development mode")
+		}
+		a.logger.Warn("fault injection enabled", "moresleep", cfg.Chaos.Moresleep, "elasticsearch", cfg.Chaos.Elasticsearch)
+	}
+
+	// Skipping certificate verification is only for local clusters with self-signed certificates
+	if cfg.Moresleep.TLS.InsecureSkipVerify || cfg.Elasticsearch.TLS.InsecureSkipVerify {
+		if !cfg.Mode.IsDevelopment() {
+			return errors.New("TLS_INSECURE_SKIP_VERIFY is only allowed in development mode")
+		}
+		a.logger.Warn("TLS certificate verification disabled", "moresleep", cfg.Moresleep.TLS.InsecureSkipVerify, "elasticsearch", cfg.Elasticsearch.TLS.InsecureSkipVerify)
+	}
+
+	moresleepClient := o.moresleep
+	if moresleepClient == nil {
+		client, err := moresleep.New(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create moresleep client: %w", err)
+		}
+		moresleepClient = client
+		a.logger.Info("moresleep client initialized")
+	}
+
+	backend := o.backend
+	if backend == nil {
+		var err error
+		if backend, err = a.openBackend(ctx); err != nil {
+			return err
+		}
+	}
+	// Features working on the cluster itself are only enabled with Elasticsearch (esClient is nil otherwise)
+	a.backend = backend
+	a.esClient, _ = backend.(*elasticsearch.Client)
+	if a.esClient == nil {
+		a.logger.Warn("embedded search backend: only reindexes, the public read endpoints, reports and talk search are available", "backend", cfg.Search.Backend)
+	}
+
+	// Create the talk indexes with the mappings from PRIVATE_MAPPING_FILE and PUBLIC_MAPPING_FILE if set
+	privateMapping, publicMapping, err := elasticsearch.TalkIndexMappings(cfg.Index)
+	if err != nil {
+		return fmt.Errorf("failed to load index mappings: %w", err)
+	}
+	a.Indexer = app.NewIndexerService(
+		ctx,
+		moresleepClient,
+		backend,
+		privateMapping,
+		publicMapping,
+	)
+	a.logger.Info("indexer service initialized")
+
+	// Resolve the conference of fetched talks from the indexer's conference list instead of
+	// listing all conferences from moresleep for every talk fetch
+	moresleepClient.SetConferenceResolver(a.Indexer.ConferenceResolver())
+
+	// Enable CDN cache purging after public reindexes if configured
+	if cfg.CDN.IsConfigured() {
+		cdnClient, err := cdn.New(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create CDN client: %w", err)
+		}
+		a.Indexer.SetCachePurger(cdnClient, cfg.CDN.PurgePaths)
+		a.logger.Info("CDN cache purging enabled", "provider", cfg.CDN.Provider)
+	}
+
+	// Render abstracts to sanitized HTML alongside the raw text if enabled
+	if cfg.Transform.AbstractHTML {
+		a.Indexer.AddTransform(app.RenderAbstractHTML)
+		a.logger.Info("abstract HTML rendering enabled")
+	}
+
+	// Link the sessions of a series by the series ID in the talk data, if the field is configured
+	if cfg.Series.Field != "" {
+		a.Indexer.AddTransform(app.SeriesFromField(cfg.Series.Field))
+		a.logger.Info("series from talk data enabled", "field", cfg.Series.Field)
+	}
+
+	// Index the committee review assignments from the reviewer tags and feedback of each talk
+	a.Indexer.AddTransform(app.ReviewAssignments(cfg.Review))
+
+	// Mask personal details in public free text if enabled
+	if cfg.Transform.ScrubPublic {
+		a.Indexer.SetScrubber(app.NewScrubber(cfg.Transform))
+		a.logger.Info("public text scrubbing enabled", "fields", cfg.Transform.ScrubFields, "speakerFields", cfg.Transform.ScrubSpeakerFields)
+	}
+
+	// Record every reindex as a job, keeping the jobs of the in-memory store through reloads
+	a.jobStore = o.jobStore
+	if a.jobStore == nil {
+		if err := a.openJobStore(); err != nil {
+			return err
+		}
+	}
+	a.Indexer.SetJobStore(a.jobStore)
+	a.logger.Info("job store initialized", "store", cfg.Jobs.Store)
+
+	// Keep never-published talks out of the private index once their retention period has passed
+	if cfg.Retention.IsConfigured() {
+		if a.esClient == nil {
+			return errors.New("talk retention needs the elasticsearch search backend")
+		}
+		retentionService := app.NewRetentionService(ctx, a.esClient)
+		retentionService.SetJobStore(a.jobStore)
+		retentionService.SetClock(a.clock)
+		a.Indexer.SetRetention(retentionService)
+		a.scheduler.Every("retention", cfg.Retention.Interval, retentionService.ApplyRetention)
+		a.logger.Info("talk retention enabled", "statuses", cfg.Retention.Statuses, "period", cfg.Retention.Period)
+	}
+
+	// Refuse full reindexes that would replace the live public index with far fewer talks
+	a.Indexer.SetShrinkGuard(app.NewShrinkGuard(ctx, backend))
+
+	// Refuse full reindexes and republishes that would fill the cluster's disks
+	if cfg.Capacity.Check && a.esClient != nil {
+		a.Indexer.SetCapacityChecker(app.NewCapacityChecker(ctx, a.esClient, a.esClient))
+		a.logger.Info("capacity check enabled", "maxDiskPercent", cfg.Capacity.MaxDiskPercent)
+	}
+
+	mux := http.NewServeMux()
+
+	// API routes are registered once the cluster features are wired, as API tokens from the admin UI
+	// decide whether the reindex and job routes are available in production
+	a.api = api.New(ctx, a.Indexer, backend)
+	// API reindexes run in the background as jobs, followed through the job status endpoints
+	a.api.SetReindexJobs(a.Indexer)
+	// Admins reload the configuration through the API if the binary can load it again
+	if o.reloader != nil {
+		a.api.SetConfigReloader(o.reloader)
+	}
+
+	// Initialize auth adapter and register routes, keeping the login sessions through reloads
+	authAdapter, err := auth.NewWithSessions(ctx, o.sessions)
+	if err != nil {
+		return fmt.Errorf("failed to initialize auth: %w", err)
+	}
+	a.auth = authAdapter
+	a.auth.SetClock(a.clock)
+	a.auth.RegisterRoutes(mux)
+
+	// Detailed health output is limited to trusted networks and logged-in users
+	a.api.SetHealthChecks(backend, moresleepClient)
+	a.api.SetHealthAuthorizer(a.auth.IsAuthenticated)
+
+	// Talk search for consumers without access to Elasticsearch; logged-in users search the private index
+	searchService := app.NewSearchService(ctx, backend)
+	a.api.SetSearcher(searchService, a.auth.IsAuthenticated)
+	// Talk counts per year, format, language and keyword, for the search filters of the website
+	a.api.SetSearchFacets(app.NewFacetService(ctx, backend))
+
+	// Register web admin routes (protected if auth middleware is available)
+	a.web = web.New(a.Indexer, moresleepClient, app.NewReportService(ctx, backend))
+	a.web.SetReadOnly(cfg.ReadOnly)
+	a.web.SetDiagnostics(a.diagnostics(ctx, o, backend, moresleepClient, map[string]string{
+		cfg.Index.PrivateName(): privateMapping,
+		cfg.Index.PublicName():  publicMapping,
+	}))
+
+	// Let speakers preview their talk through signed links if a preview secret is configured
+	if cfg.Preview.IsConfigured() {
+		previewService := app.NewTalkPreviewService(ctx, a.Indexer, backend)
+		previewService.SetClock(a.clock)
+		a.web.SetTalkPreviews(previewService)
+		a.api.SetTalkPreviews(previewService)
+		a.logger.Info("speaker previews enabled", "linkTTL", cfg.Preview.LinkTTL)
+	}
+
+	// Check the talks of a conference against the fields the website needs, rendered by the website if configured
+	sitePreviewService := app.NewSitePreviewService(ctx, a.Indexer)
+	if cfg.SitePreview.IsConfigured() {
+		sitePreviewService.SetRenderer(sitepreview.New(ctx))
+		a.logger.Info("website preview renderer enabled", "url", cfg.SitePreview.URL)
+	}
+	a.web.SetSitePreviews(sitePreviewService)
+
+	// Compare a talk in moresleep with its documents in both indexes, field by field
+	a.web.SetTalkDiffs(app.NewTalkDiffService(a.Indexer, backend))
+
+	// List the talks assigned to the logged-in committee member for review
+	a.web.SetReviews(app.NewReviewService(ctx, searchService))
+
+	a.web.RegisterRoutes(mux, web.MiddlewareFunc(a.auth.Middleware()))
+
+	// The remaining features are stored in or work on the Elasticsearch cluster itself
+	if a.esClient != nil {
+		if err := a.addClusterFeatures(ctx); err != nil {
+			return err
+		}
+	}
+	a.api.RegisterRoutes(mux)
+	a.api.RegisterAuthenticatedRoutes(mux, a.auth.Middleware())
+
+	// Enable signing of exported snapshots if a key is configured
+	if cfg.Signing.IsConfigured() {
+		signer, err := app.NewSigner(cfg.Signing)
+		if err != nil {
+			return fmt.Errorf("failed to create content signer: %w", err)
+		}
+		a.api.SetSigner(signer)
+		a.web.SetSigner(signer)
+		a.logger.Info("content signing enabled", "keyID", signer.PublicKey().KeyID)
+	}
+
+	// Record latency and errors per route, with SLO burn rates for the routes in METRICS_SLO_TARGETS
+	a.api.SetRequestMetrics(app.NewRequestMetricsService(ctx))
+
+	// Count the events the indexer publishes after changing an index
+	indexMetrics := app.NewIndexMetricsService()
+	a.Indexer.Events().Subscribe(indexMetrics)
+	a.api.SetIndexMetrics(indexMetrics)
+
+	// Stream the progress of running reindexes to open dashboards
+	a.progress = app.NewReindexProgressService()
+	a.Indexer.Events().Subscribe(a.progress)
+	a.web.SetReindexProgress(a.progress)
+	a.web.SetReindexJobs(a.Indexer)
+
+	// Post the outcome of reindexes to Slack or a webhook URL, including one-shot reindexes
+	if cfg.Notify.IsConfigured() {
+		notifications := app.NewReindexNotificationService(ctx, webhook.New(ctx))
+		notifications.SetClock(a.clock)
+		a.Indexer.Events().Subscribe(notifications)
+	}
+
+	a.routes = a.api.RecordRequests(a.api.CompressResponses(a.api.AnnounceNotice(a.api.LimitRequestBodies(mux))))
+
+	// Serve reindex triggers over gRPC for other internal services when a token is configured
+	if cfg.Grpc.IsConfigured() {
+		a.grpc = grpcadapter.New(ctx, a.Indexer)
+	}
+	return nil
+}
+
+// diagnostics assembles the diagnostics bundle from the dependencies' health, the job reports, the
+// mappings of both indexes with Elasticsearch and the recent logs when a log history is given
+func (a *App) diagnostics(ctx context.Context, o options, backend SearchBackend, moresleepClient *moresleep.Client, mappings map[string]string) *app.DiagnosticsService {
+	diagnostics := app.NewDiagnosticsService(ctx, backend, moresleepClient)
+	diagnostics.SetJobStore(a.jobStore)
+	if a.esClient != nil {
+		diagnostics.SetMappingReader(a.esClient, mappings)
+	}
+	if o.logs != nil {
+		diagnostics.SetLogHistory(o.logs)
+	}
+	return diagnostics
+}
+
+// openBackend opens the index store selected by SEARCH_BACKEND: Elasticsearch, OpenSearch, or an embedded store
+// for deployments without a cluster
+func (a *App) openBackend(ctx context.Context) (SearchBackend, error) {
+	switch a.cfg.Search.Backend {
+	case config.SearchBackendElasticsearch:
+		client, err := elasticsearch.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create elasticsearch client: %w", err)
+		}
+		a.logger.Info("elasticsearch client initialized")
+		return client, nil
+	case config.SearchBackendOpenSearch:
+		client, err := elasticsearch.NewOpenSearch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create opensearch client: %w", err)
+		}
+		a.logger.Info("opensearch client initialized")
+		return client, nil
+	case config.SearchBackendSQLite:
+		store, err := sqlite.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open sqlite store: %w", err)
+		}
+		a.closers = append(a.closers, store)
+		return store, nil
+	case config.SearchBackendBleve:
+		store, err := bleve.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open bleve store: %w", err)
+		}
+		a.closers = append(a.closers, store)
+		return store, nil
+	}
+	return nil, fmt.Errorf("unknown search backend %q", a.cfg.Search.Backend)
+}
+
+// newClock creates the system clock, shifted by CLOCK_OFFSET for time travel in development
+func (a *App) newClock() (ports.Clock, error) {
+	offset := a.cfg.Clock.Offset
+	if offset == 0 {
+		return clock.System{}, nil
+	}
+	if !a.cfg.Mode.IsDevelopment() {
+		return nil, errors.New("CLOCK_OFFSET is only allowed in development mode")
+	}
+	a.logger.Warn("time travel enabled", "offset", offset)
+	return clock.NewOffset(offset), nil
+}
+
+// openJobStore creates the job store selected by JOBS_STORE
+func (a *App) openJobStore() error {
+	switch a.cfg.Jobs.Store {
+	case config.JobStoreMemory:
+		a.jobStore = memory.NewJobStore(a.cfg.Jobs.MemoryCapacity)
+		return nil
+	case config.JobStoreElasticsearch:
+		if a.esClient == nil {
+			return errors.New("the elasticsearch job store needs the elasticsearch search backend, set JOBS_STORE=memory")
+		}
+		a.jobStore = elasticsearch.NewJobStore(a.esClient, a.cfg.Index.JobsName())
+		return nil
+	}
+	return fmt.Errorf("unknown job store %q", a.cfg.Jobs.Store)
+}
+
+// addClusterFeatures enables the features stored in or working on the Elasticsearch cluster itself
+func (a *App) addClusterFeatures(ctx context.Context) error {
+	cfg, esClient := a.cfg, a.esClient
+
+	// Ad-hoc queries on the private index for logged-in operators, limited to a safe subset of the query DSL
+	a.api.SetQuerier(app.NewQueryService(ctx, esClient))
+	// Random documents of either index for answering support questions without cluster access
+	a.api.SetSampler(app.NewSampleService(ctx, esClient))
+	// Talk IDs by slug or title, for reindexing single talks without looking them up in moresleep
+	a.api.SetTalkLookup(app.NewTalkLookupService(ctx, esClient))
+	// Typeahead for the public search, from the completion suggester of the public index
+	a.api.SetSuggester(app.NewSuggestService(ctx, esClient))
+	// Keyword frequencies of the public talks across conference years
+	keywordService := app.NewKeywordTrendService(ctx, esClient)
+	a.api.SetKeywordTrends(keywordService)
+	a.web.SetKeywordTrends(keywordService)
+	// Speaker attributes aggregated from the private index for the program committee
+	a.api.SetSpeakerStatistics(app.NewSpeakerStatisticsService(ctx, esClient))
+
+	// Remember per-user preferences such as the default conference
+	settingsStore := elasticsearch.NewSettingsStore(esClient, cfg.Index.SettingsName())
+	a.web.SetPreferences(app.NewPreferencesService(settingsStore))
+
+	// Show the notice admins set, such as an ongoing migration, on admin pages and in API response headers
+	noticeService := app.NewNoticeService(settingsStore)
+	noticeService.SetClock(a.clock)
+	a.web.SetNotices(noticeService)
+	a.api.SetNotices(noticeService)
+
+	// Version the public documents of each conference so the mobile apps know when to resync
+	datasetService := app.NewDatasetVersionService(ctx, esClient, settingsStore)
+	datasetService.SetClock(a.clock)
+	a.Indexer.Events().Subscribe(datasetService)
+	a.api.SetDatasetVersions(datasetService)
+
+	// Chart the daily talk counts of the active conferences on the dashboard
+	trendService := app.NewTrendService(ctx, esClient, settingsStore)
+	a.web.SetTalkTrends(trendService)
+
+	// Record the last successful reindex of each index for readiness, detailed health and the dashboard
+	reindexStatus := app.NewReindexStatusService(ctx, settingsStore)
+	reindexStatus.SetClock(a.clock)
+	a.Indexer.Events().Subscribe(reindexStatus)
+	a.api.AddHealthCheck(reindexStatus)
+	a.web.SetReindexStatuses(reindexStatus)
+
+	// Count the API requests per route and client for the usage page
+	usageService := app.NewUsageService(ctx, settingsStore)
+	usageService.SetClock(a.clock)
+	a.usage = usageService
+	a.web.SetUsageAnalytics(usageService)
+	if cfg.Usage.FlushInterval > 0 {
+		a.api.SetUsageRecorder(usageService)
+	}
+
+	// Restrict access to the allowlist managed in the admin UI
+	accessService := app.NewAccessService(settingsStore, cfg.Access.AdminEmails)
+	if cfg.OIDC.HasGroupRoles() {
+		accessService.RequireAllowlist()
+	}
+	a.auth.SetUserDirectory(accessService)
+	a.web.SetUserDirectory(accessService)
+
+	// Let admins create API tokens for their own automation, accepted next to those from API_TOKENS
+	apiTokenService := app.NewAPITokenService(settingsStore)
+	apiTokenService.SetClock(a.clock)
+	apiTokenService.SetUserDirectory(accessService)
+	a.web.SetAPITokens(apiTokenService)
+	a.api.SetTokenAuthenticator(apiTokenService)
+
+	// Denormalize conference metadata from the metadata file and the admin UI onto indexed talks
+	catalogService, err := app.NewConferenceCatalogService(ctx, settingsStore)
+	if err != nil {
+		return fmt.Errorf("failed to load conference metadata: %w", err)
+	}
+	a.Indexer.SetConferenceCatalog(catalogService)
+	a.web.SetConferenceCatalog(catalogService)
+
+	// Link the sessions of the series from the series file and the admin UI on indexed talks
+	seriesService, err := app.NewSeriesCatalogService(ctx, settingsStore)
+	if err != nil {
+		return fmt.Errorf("failed to load series: %w", err)
+	}
+	a.Indexer.SetSeriesCatalog(seriesService)
+	a.web.SetSeriesCatalog(seriesService)
+
+	// Flag the conflicts of interest committee members register on the reviews page
+	conflictsService := app.NewReviewerConflictsService(settingsStore)
+	a.Indexer.SetReviewerConflicts(conflictsService, cfg.Review.OrganizationField)
+	a.web.SetReviewerConflicts(conflictsService)
+
+	// Keep documents that fail indexing even after retries for inspection and retry from the admin UI
+	deadLetterStore := elasticsearch.NewDeadLetterStore(esClient, cfg.Index.DeadLettersName())
+	a.Indexer.SetDeadLetterStore(deadLetterStore)
+	a.web.SetDeadLetters(app.NewDeadLetterService(deadLetterStore, esClient))
+
+	// List index generations and aliases for maintenance from the admin UI
+	a.web.SetIndexManager(app.NewIndexLifecycleService(ctx, esClient))
+
+	// Republish both indexes as a new generation through the guided workflow in the admin UI
+	republishService := app.NewRepublishService(ctx, a.Indexer, esClient, esClient, esClient)
+	republishService.SetJobStore(a.jobStore)
+	a.web.SetRepublisher(republishService)
+
+	// Evaluate alternative transformation settings on one conference in scratch indexes from the admin UI
+	whatIfService := app.NewWhatIfService(ctx, a.Indexer, esClient)
+	whatIfService.SetJobStore(a.jobStore)
+	a.web.SetWhatIfBuilder(whatIfService)
+
+	// Deliver events to outbound webhook subscriptions managed in the admin UI
+	webhookService := app.NewWebhookService(settingsStore, webhook.New(ctx), cfg.WebhookDelivery)
+	a.webhooks = webhookService
+	a.Indexer.SetNotifier(webhookService)
+	a.web.SetWebhooks(webhookService)
+
+	// Report talks without video and propose links from the video channel if configured
+	videoService := app.NewVideoService(ctx, esClient, esClient, settingsStore)
+	videoService.SetJobStore(a.jobStore)
+	if err := videoService.LoadAcceptedVideos(ctx); err != nil {
+		a.logger.Warn("failed to load accepted video links, reindexes drop them until a proposal is decided", "error", err)
+	}
+	a.Indexer.AddTransform(videoService.ApplyVideos)
+	if cfg.Video.IsConfigured() {
+		videoClient, err := video.New(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create video client: %w", err)
+		}
+		videoService.SetVideoSource(videoClient)
+		a.logger.Info("video backfill enabled", "provider", cfg.Video.Provider, "channel", cfg.Video.Channel)
+	}
+	a.web.SetVideoBackfill(videoService)
+
+	// Validate links stored in the public index from the admin UI and on a schedule
+	linkService := app.NewLinkCheckService(ctx, esClient, esClient, linkcheck.New(ctx), settingsStore)
+	linkService.SetJobStore(a.jobStore)
+	a.web.SetLinkReporter(linkService)
+
+	a.scheduler.Every("link-check", cfg.LinkCheck.Interval, linkService.CheckLinks)
+	a.scheduler.Every("talk-trends", cfg.Trends.Interval, trendService.RecordTalkCounts)
+	a.scheduler.Every("api-usage", cfg.Usage.FlushInterval, usageService.Flush)
+
+	// Email a summary of the indexing runs to the program committee leads who do not watch the dashboard
+	if cfg.Summary.IsConfigured() {
+		if !cfg.SMTP.IsConfigured() {
+			return errors.New("the summary email needs a mail server, set SMTP_HOST and SMTP_FROM")
+		}
+		summaryService, err := app.NewSummaryService(ctx, a.jobStore, trendService, settingsStore, smtp.New(ctx))
+		if err != nil {
+			return err
+		}
+		summaryService.SetClock(a.clock)
+		a.scheduler.Every("summary-email", app.SummaryCheckInterval, summaryService.SendDueSummary)
+		a.logger.Info("summary email enabled", "period", cfg.Summary.Period, "recipients", len(cfg.Summary.Recipients))
+	}
+
+	// Keep workshop capacity and registration counts from the registration system on the workshops
+	if cfg.Registration.IsConfigured() {
+		registrationService := app.NewRegistrationService(ctx, registration.New(ctx), esClient)
+		a.Indexer.AddTransform(registrationService.ApplySeats)
+		a.scheduler.Every("workshop-registrations", cfg.Registration.Interval, registrationService.SyncRegistrations)
+		a.logger.Info("workshop registration sync enabled", "interval", cfg.Registration.Interval)
+	}
+	return nil
+}
+
+// Run serves HTTP, and gRPC if a token is configured, and runs the scheduled tasks until the context
+// is cancelled or a server fails. It then shuts down gracefully, letting background reindexes finish
+// within the shutdown timeout. The servers keep running through reloads of the configuration.
+func (a *App) Run(ctx context.Context) error {
+	// Reloads wait until the servers are started, and restart the gRPC server and scheduled tasks after
+	a.reloadMu.Lock()
+	cfg := a.cfg
+	ctx = config.WithConfig(ctx, cfg)
+	a.serveErrs = make(chan error, 3)
+
+	// Serve HTTPS with the certificate files or ACME if configured, which a reload does not change
+	var serverTLS *tlsconfig.Server
+	if cfg.Http.TLS.IsConfigured() {
+		var err error
+		if serverTLS, err = tlsconfig.NewServer(cfg.Http.TLS, cfg.Http.Port); err != nil {
+			a.reloadMu.Unlock()
+			return fmt.Errorf("failed to configure HTTPS: %w", err)
+		}
+	}
+
+	if a.grpc != nil {
+		if err := a.serveGRPC(a.grpc); err != nil {
+			a.reloadMu.Unlock()
+			return err
+		}
+	}
+
+	// Scheduled jobs write to the cluster, so they are paused in read-only mode
+	if cfg.ReadOnly {
+		a.logger.Warn("read-only mode: writes are disabled and scheduled tasks are paused")
+	} else {
+		a.scheduler.Start(ctx)
+	}
+	a.running = ctx
+	a.reloadMu.Unlock()
+
+	server := &http.Server{
+		Addr:         cfg.Http.Addr(),
+		Handler:      a.Handler,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+	// Shutdown waits for open connections, so the progress streams of open dashboards are ended first
+	server.RegisterOnShutdown(func() {
+		a.mu.RLock()
+		defer a.mu.RUnlock()
+		a.progress.Close()
+	})
+
+	var redirectServer *http.Server
+	if serverTLS != nil {
+		server.TLSConfig = serverTLS.Config
+		go func() {
+			if err := serverTLS.Watch(ctx); err != nil {
+				a.logger.Error("certificate files are not reloaded", "error", err)
+			}
+		}()
+
+		if cfg.Http.TLS.RedirectPort > 0 {
+			redirectServer = &http.Server{
+				Addr:         net.JoinHostPort(cfg.Http.Host, strconv.Itoa(cfg.Http.TLS.RedirectPort)),
+				Handler:      serverTLS.RedirectHandler,
+				ReadTimeout:  15 * time.Second,
+				WriteTimeout: 15 * time.Second,
+			}
+			go func() {
+				a.logger.Info("starting HTTP redirect server", "addr", redirectServer.Addr)
+				if err := redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					a.serveErrs <- fmt.Errorf("HTTP redirect server error: %w", err)
+				}
+			}()
+		}
+	}
+
+	lis, err := a.listenHTTP(server.Addr)
+	if err != nil {
+		a.serveErrs <- err
+	} else {
+		go func() {
+			var err error
+			if serverTLS != nil {
+				a.logger.Info("starting HTTPS server", "addr", lis.Addr(), "acme", cfg.Http.TLS.UsesACME())
+				err = server.ServeTLS(lis, "", "")
+			} else {
+				a.logger.Info("starting HTTP server", "addr", lis.Addr())
+				err = server.Serve(lis)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				a.serveErrs <- fmt.Errorf("HTTP server error: %w", err)
+			}
+		}()
+		a.notify(systemd.Ready)
+		go a.pingWatchdog(ctx)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+	case runErr = <-a.serveErrs:
+	}
+
+	a.logger.Info("shutting down server...")
+	a.notify(systemd.Stopping)
+	// A reload in progress finishes before the services are stopped
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+	a.running = nil
+	a.scheduler.Stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+
+	if redirectServer != nil {
+		if err := redirectServer.Shutdown(shutdownCtx); err != nil {
+			a.logger.Error("redirect server shutdown error", "error", err)
+		}
+	}
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return errors.Join(runErr, fmt.Errorf("server shutdown error: %w", err))
+	}
+	if a.grpc != nil {
+		a.grpc.Stop()
+	}
+
+	// Let reindexes started through the API finish so their jobs are not left running, including
+	// those of the indexers replaced by reloads
+	a.mu.RLock()
+	indexers := []*app.IndexerService{a.Indexer}
+	for _, retired := range a.retired {
+		indexers = append(indexers, retired.Indexer)
+	}
+	a.mu.RUnlock()
+	for _, indexer := range indexers {
+		if err := indexer.WaitForBackgroundJobs(shutdownCtx); err != nil {
+			a.logger.Error("shutdown before background reindexes finished", "error", err)
+		}
+	}
+
+	a.logger.Info("server stopped")
+	return runErr
+}
+
+// listenHTTP returns the socket passed by systemd socket activation, or listens on the address. With
+// an activated socket, connections arriving while the server restarts wait in its backlog instead of
+// being refused.
+func (a *App) listenHTTP(addr string) (net.Listener, error) {
+	lis, err := systemd.Listener()
+	if err != nil {
+		return nil, err
+	}
+	if lis != nil {
+		a.logger.Info("listening on the socket passed by systemd", "addr", lis.Addr())
+		return lis, nil
+	}
+
+	if lis, err = net.Listen("tcp", addr); err != nil {
+		return nil, fmt.Errorf("failed to listen for HTTP on %s: %w", addr, err)
+	}
+	return lis, nil
+}
+
+// notify sends the state to systemd if the server runs as a Type=notify service
+func (a *App) notify(state string) {
+	if _, err := systemd.Notify(state); err != nil {
+		a.logger.Warn("failed to notify systemd", "state", state, "error", err)
+	}
+}
+
+// pingWatchdog notifies the systemd watchdog at half its interval until the context is cancelled, so
+// a server that stops responding is restarted
+func (a *App) pingWatchdog(ctx context.Context) {
+	interval, err := systemd.WatchdogInterval()
+	if err != nil {
+		a.logger.Warn("systemd watchdog is not notified", "error", err)
+		return
+	}
+	if interval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.notify(systemd.Watchdog)
+		}
+	}
+}
+
+// serveGRPC starts serving the gRPC adapter in the background, reporting failures to Run
+func (a *App) serveGRPC(server *grpcadapter.Adapter) error {
+	addr := a.cfg.Grpc.Addr()
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC on %s: %w", addr, err)
+	}
+
+	go func() {
+		a.logger.Info("starting gRPC server", "addr", addr)
+		if err := server.Serve(lis); err != nil {
+			a.serveErrs <- fmt.Errorf("gRPC server error: %w", err)
+		}
+	}()
+	return nil
+}
+
+// Close releases the embedded index store opened by Build, if any
+func (a *App) Close() error {
+	var errs []error
+	for _, closer := range a.closers {
+		errs = append(errs, closer.Close())
+	}
+	a.closers = nil
+	return errors.Join(errs...)
+}
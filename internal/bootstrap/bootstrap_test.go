@@ -0,0 +1,198 @@
+package bootstrap
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/sqlite"
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig loads the configuration for a development instance on an in-memory SQLite store,
+// with moresleep replaced by a server without conferences
+func testConfig(t *testing.T) *config.Config {
+	moresleep := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"conferences":[]}`))
+	}))
+	t.Cleanup(moresleep.Close)
+
+	t.Setenv("MODE", "development")
+	t.Setenv("MORESLEEP_URL", moresleep.URL)
+	t.Setenv("HTTP_HOST", "127.0.0.1")
+	t.Setenv("HTTP_PORT", "0")
+	t.Setenv("SEARCH_BACKEND", config.SearchBackendSQLite)
+	t.Setenv("SEARCH_SQLITE_PATH", ":memory:")
+	t.Setenv("JOBS_STORE", config.JobStoreMemory)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestBuild(t *testing.T) {
+	application, err := Build(context.Background(), testConfig(t))
+	require.NoError(t, err)
+	defer application.Close()
+
+	assert.NotNil(t, application.Indexer)
+
+	for _, path := range []string{"/health", "/api/conferences", "/admin", "/admin/diagnostics.zip"} {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			application.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestBuild_WithSearchBackend(t *testing.T) {
+	store, err := sqlite.Open(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+
+	cfg := testConfig(t)
+	cfg.Search.Backend = "unused"
+
+	application, err := Build(context.Background(), cfg, WithSearchBackend(store))
+	require.NoError(t, err)
+	require.NoError(t, application.Close())
+
+	// The caller keeps ownership of the store
+	_, err = store.IndexExists(context.Background(), "javazone_private")
+	assert.NoError(t, err)
+}
+
+func TestBuild_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+	}{
+		{name: "unknown search backend", modify: func(cfg *config.Config) { cfg.Search.Backend = "solr" }},
+		{name: "unknown job store", modify: func(cfg *config.Config) { cfg.Jobs.Store = "redis" }},
+		{name: "elasticsearch job store without elasticsearch", modify: func(cfg *config.Config) { cfg.Jobs.Store = config.JobStoreElasticsearch }},
+		{name: "production mode without OIDC", modify: func(cfg *config.Config) { cfg.Mode = config.ModeProduction }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t)
+			tt.modify(cfg)
+
+			_, err := Build(context.Background(), cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBuild_TimeTravel(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Clock.Offset = 30 * 24 * time.Hour
+
+	application, err := Build(context.Background(), cfg)
+	require.NoError(t, err)
+	defer application.Close()
+	assert.WithinDuration(t, time.Now().Add(cfg.Clock.Offset), application.clock.Now(), time.Second)
+
+	cfg.Mode = config.ModeProduction
+	_, err = Build(context.Background(), cfg)
+	assert.ErrorContains(t, err, "CLOCK_OFFSET")
+}
+
+func TestBuild_Chaos(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Chaos.Moresleep.ErrorPercent = 100
+	cfg.Moresleep.MaxRetries = 0
+
+	application, err := Build(context.Background(), cfg)
+	require.NoError(t, err)
+	defer application.Close()
+
+	err = application.Indexer.ReindexAll(context.Background())
+	assert.Error(t, err, "listing conferences fails with injected errors")
+
+	cfg.Mode = config.ModeProduction
+	_, err = Build(context.Background(), cfg)
+	assert.ErrorContains(t, err, "CHAOS_")
+}
+
+func TestBuild_InsecureSkipVerify(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Moresleep.TLS.InsecureSkipVerify = true
+
+	application, err := Build(context.Background(), cfg)
+	require.NoError(t, err)
+	application.Close()
+
+	cfg.Mode = config.ModeProduction
+	_, err = Build(context.Background(), cfg)
+	assert.ErrorContains(t, err, "TLS_INSECURE_SKIP_VERIFY")
+}
+
+func TestBuild_InvalidMappingFile(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Index.PrivateMappingFile = filepath.Join(t.TempDir(), "missing.json")
+
+	_, err := Build(context.Background(), cfg)
+	assert.ErrorContains(t, err, "PRIVATE_MAPPING_FILE")
+}
+
+func TestBuild_ReindexProgressStream(t *testing.T) {
+	application, err := Build(context.Background(), testConfig(t))
+	require.NoError(t, err)
+	defer application.Close()
+
+	server := httptest.NewServer(application.Handler)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/admin/reindex/stream", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	require.NoError(t, application.Indexer.ReindexAll(context.Background()))
+
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if event, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+			events = append(events, event)
+			if event == "finished" {
+				break
+			}
+		}
+	}
+	assert.Equal(t, []string{"started", "finished"}, events, "moresleep has no conferences to fetch")
+}
+
+func TestRun_StopsWhenCancelled(t *testing.T) {
+	application, err := Build(context.Background(), testConfig(t))
+	require.NoError(t, err)
+	defer application.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- application.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,72 @@
+package bootstrap
+
+import (
+	"log/slog"
+
+	"github.com/javaBin/talks-indexer/internal/adapters/moresleep"
+	"github.com/javaBin/talks-indexer/internal/adapters/session"
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/ports"
+)
+
+// Option customizes how Build assembles the application
+type Option func(*options)
+
+type options struct {
+	moresleep *moresleep.Client
+	backend   SearchBackend
+	logs      ports.LogHistory
+	clock     ports.Clock
+	loader    func() (*config.Config, error)
+	logLevel  *slog.LevelVar
+
+	// Carried over from the replaced services when the configuration is reloaded
+	jobStore ports.JobStore
+	sessions *session.InMemoryStore
+	reloader ports.ConfigReloader
+}
+
+// WithMoresleepClient uses the given moresleep client instead of one created from configuration
+func WithMoresleepClient(client *moresleep.Client) Option {
+	return func(o *options) {
+		o.moresleep = client
+	}
+}
+
+// WithSearchBackend uses the given index store instead of opening the one selected by SEARCH_BACKEND.
+// Features working on the Elasticsearch cluster itself are only enabled if the store is an
+// *elasticsearch.Client. The caller keeps ownership of the store and closes it.
+func WithSearchBackend(backend SearchBackend) Option {
+	return func(o *options) {
+		o.backend = backend
+	}
+}
+
+// WithLogHistory includes the recent log records kept by the history in the diagnostics bundle
+func WithLogHistory(logs ports.LogHistory) Option {
+	return func(o *options) {
+		o.logs = logs
+	}
+}
+
+// WithClock uses the given clock, such as a fake one in tests, instead of the one selected by CLOCK_OFFSET
+func WithClock(clock ports.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithConfigLoader enables reloading the configuration with the loader, on ReloadConfig and through
+// the API. It should read the configuration the way it was read for Build.
+func WithConfigLoader(load func() (*config.Config, error)) Option {
+	return func(o *options) {
+		o.loader = load
+	}
+}
+
+// WithLogLevel sets the level of the logger to LOG_LEVEL whenever the configuration is reloaded
+func WithLogLevel(level *slog.LevelVar) Option {
+	return func(o *options) {
+		o.logLevel = level
+	}
+}
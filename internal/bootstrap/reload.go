@@ -0,0 +1,164 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/app"
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// ReloadConfig loads the configuration with the loader given to Build and reloads the application
+// with it. The process calls it on SIGHUP, and admins through the API.
+func (a *App) ReloadConfig(ctx context.Context) (domain.ConfigReload, error) {
+	if a.opts.loader == nil {
+		return domain.ConfigReload{}, errors.New("no configuration loader to reload from")
+	}
+
+	cfg, err := a.opts.loader()
+	if err != nil {
+		return domain.ConfigReload{}, err
+	}
+	return a.Reload(ctx, cfg)
+}
+
+// Reload rebuilds the services from the configuration and switches the running servers over to them,
+// so the settings read when services are created, such as index names, schedules and credentials,
+// take effect without a restart. Login sessions, the jobs of the in-memory job store, the clock, an
+// embedded index store, the responses kept for Idempotency-Key, the signatures of accepted webhooks,
+// the running reindexes and the webhook delivery log carry over; the scheduled tasks and the gRPC
+// server are restarted.
+//
+// Settings that need a restart, such as the listen addresses or the search backend, must be unchanged.
+// Otherwise, or if the services fail to build, the reload is refused and the running services are kept.
+// Reindexes running in the background finish on the services they were started on, which are released
+// once they are done.
+func (a *App) Reload(ctx context.Context, cfg *config.Config) (domain.ConfigReload, error) {
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+
+	if names := a.cfg.RestartRequired(cfg); len(names) > 0 {
+		return domain.ConfigReload{}, fmt.Errorf("%w: %s", domain.ErrRestartRequired, strings.Join(names, ", "))
+	}
+	level, err := cfg.Log.SlogLevel(cfg.Mode)
+	if err != nil {
+		return domain.ConfigReload{}, err
+	}
+
+	o := a.opts
+	o.clock = a.clock
+	o.sessions = a.auth.Sessions()
+	// The embedded store stays open, owned by this app; Elasticsearch clients are created again
+	keepStore := o.backend == nil && cfg.Search.IsEmbedded()
+	if keepStore {
+		o.backend = a.backend
+	}
+	if cfg.Jobs.Store == config.JobStoreMemory {
+		o.jobStore = a.jobStore
+	}
+
+	next := &App{
+		cfg:       cfg,
+		scheduler: app.NewScheduler(),
+		logger:    a.logger,
+	}
+	// Services may keep the context they are built with, so it must outlive an API request
+	if err := next.build(config.WithConfig(context.WithoutCancel(ctx), cfg), o); err != nil {
+		next.Close()
+		return domain.ConfigReload{}, fmt.Errorf("failed to build services from the reloaded configuration: %w", err)
+	}
+
+	// Requests seen by the replaced API are still replayed or refused, reindexes running on the replaced
+	// indexer still hold their scope, and the webhook delivery log keeps its history
+	next.api.KeepStateOf(a.api)
+	next.Indexer.KeepRunningOf(a.Indexer)
+	if next.webhooks != nil && a.webhooks != nil {
+		next.webhooks.KeepDeliveriesOf(a.webhooks)
+	}
+
+	reload := domain.ConfigReload{Changed: a.cfg.Changed(cfg), ReloadedAt: a.clock.Now()}
+
+	a.mu.Lock()
+	previousScheduler, previousProgress, previousGRPC := a.scheduler, a.progress, a.grpc
+
+	previous := &App{Indexer: a.Indexer, webhooks: a.webhooks, usage: a.usage, logger: a.logger}
+	if !keepStore {
+		previous.closers, a.closers = a.closers, next.closers
+	}
+	a.retired = append(a.retired, previous)
+	a.cfg = cfg
+	a.Indexer = next.Indexer
+	a.routes = next.routes
+	a.backend = next.backend
+	a.esClient = next.esClient
+	a.jobStore = next.jobStore
+	a.api = next.api
+	a.web = next.web
+	a.auth = next.auth
+	a.scheduler = next.scheduler
+	a.progress = next.progress
+	a.grpc = next.grpc
+	a.webhooks = next.webhooks
+	a.usage = next.usage
+	a.mu.Unlock()
+	go a.release(previous)
+
+	if a.opts.logLevel != nil {
+		a.opts.logLevel.Set(level)
+	}
+
+	// Open dashboards reconnect to the progress stream of the new indexer
+	previousProgress.Close()
+	previousScheduler.Stop()
+
+	if a.running != nil {
+		if cfg.ReadOnly {
+			a.logger.Warn("read-only mode: writes are disabled and scheduled tasks are paused")
+		} else {
+			a.scheduler.Start(config.WithConfig(a.running, cfg))
+		}
+
+		// The gRPC server is restarted on the same address; failing to listen stops Run as at startup
+		if previousGRPC != nil {
+			previousGRPC.Stop()
+		}
+		if a.grpc != nil {
+			if err := a.serveGRPC(a.grpc); err != nil {
+				a.serveErrs <- err
+			}
+		}
+	}
+
+	a.logger.Info("configuration reloaded", "changed", reload.Changed)
+	return reload, nil
+}
+
+// release closes the services replaced by a reload once their background reindexes, and the webhook
+// deliveries of the events they raised, have finished, storing the API usage they counted, and drops
+// them from the services shutdown waits for
+func (a *App) release(previous *App) {
+	ctx := context.Background()
+	if err := previous.Indexer.WaitForBackgroundJobs(ctx); err != nil {
+		a.logger.Error("failed to wait for the reindexes of replaced services", "error", err)
+	}
+	if previous.webhooks != nil {
+		previous.webhooks.Wait()
+	}
+	if previous.usage != nil {
+		if err := previous.usage.Flush(ctx); err != nil {
+			a.logger.Error("failed to store the API usage counted by replaced services", "error", err)
+		}
+	}
+	if err := previous.Close(); err != nil {
+		a.logger.Error("failed to close replaced services", "error", err)
+	}
+
+	a.mu.Lock()
+	a.retired = slices.DeleteFunc(a.retired, func(retired *App) bool { return retired == previous })
+	a.mu.Unlock()
+	a.logger.Info("released services replaced by reload")
+}
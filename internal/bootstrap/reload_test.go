@@ -0,0 +1,194 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	cfg := testConfig(t)
+	level := new(slog.LevelVar)
+
+	application, err := Build(context.Background(), cfg, WithLogLevel(level))
+	require.NoError(t, err)
+	defer application.Close()
+
+	job, err := application.Indexer.StartReindex(context.Background(), domain.JobScope{Kind: domain.JobKindReindexAll})
+	require.NoError(t, err)
+	require.NoError(t, application.Indexer.WaitForBackgroundJobs(context.Background()))
+	previous := application.Indexer
+
+	next := *cfg
+	next.Index.Prefix = "staging_"
+	next.Log.Level = "warn"
+	reload, err := application.Reload(context.Background(), &next)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"INDEX_PREFIX", "LOG_LEVEL"}, reload.Changed)
+	assert.NotSame(t, previous, application.Indexer)
+	assert.Equal(t, "staging_javazone_private", application.Indexer.IndexNames().Private)
+	assert.Equal(t, slog.LevelWarn, level.Level())
+
+	// The in-memory job history and the embedded store carry over
+	stored, err := application.Indexer.GetJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored)
+
+	w := httptest.NewRecorder()
+	application.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/conferences", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReload_ReleasesReplacedServices(t *testing.T) {
+	cfg := testConfig(t)
+	var blocked atomic.Bool
+	release := make(chan struct{})
+	moresleep := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if blocked.Load() {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"conferences":[]}`))
+	}))
+	defer moresleep.Close()
+	cfg.Moresleep.URL = moresleep.URL
+
+	application, err := Build(context.Background(), cfg)
+	require.NoError(t, err)
+	defer application.Close()
+
+	blocked.Store(true)
+	scope := domain.JobScope{Kind: domain.JobKindReindexAll}
+	running, err := application.Indexer.StartReindex(context.Background(), scope)
+	require.NoError(t, err)
+
+	next := *cfg
+	next.Index.Prefix = "staging_"
+	_, err = application.Reload(context.Background(), &next)
+	require.NoError(t, err)
+
+	// The reindex running on the replaced indexer still holds its scope
+	refused, err := application.Indexer.StartReindex(context.Background(), scope)
+	require.ErrorIs(t, err, domain.ErrJobRunning)
+	assert.Equal(t, running.ID, refused.ID)
+
+	retired := func() int {
+		application.mu.RLock()
+		defer application.mu.RUnlock()
+		return len(application.retired)
+	}
+	assert.Equal(t, 1, retired(), "the replaced services are kept while their reindex runs")
+
+	blocked.Store(false)
+	close(release)
+	assert.Eventually(t, func() bool { return retired() == 0 }, 5*time.Second, 10*time.Millisecond,
+		"the replaced services are released once their reindex finished")
+
+	_, err = application.Indexer.StartReindex(context.Background(), scope)
+	require.NoError(t, err)
+	require.NoError(t, application.Indexer.WaitForBackgroundJobs(context.Background()))
+}
+
+func TestReload_RestartRequired(t *testing.T) {
+	cfg := testConfig(t)
+	application, err := Build(context.Background(), cfg)
+	require.NoError(t, err)
+	defer application.Close()
+	previous := application.Indexer
+
+	next := *cfg
+	next.Index.Prefix = "staging_"
+	next.Http.Port = 9000
+	_, err = application.Reload(context.Background(), &next)
+
+	assert.ErrorIs(t, err, domain.ErrRestartRequired)
+	assert.ErrorContains(t, err, "HTTP_PORT")
+	assert.Same(t, previous, application.Indexer, "the running services are kept")
+}
+
+func TestReload_InvalidConfiguration(t *testing.T) {
+	cfg := testConfig(t)
+	application, err := Build(context.Background(), cfg)
+	require.NoError(t, err)
+	defer application.Close()
+	previous := application.Indexer
+
+	next := *cfg
+	next.Log.Level = "verbose"
+	_, err = application.Reload(context.Background(), &next)
+	assert.ErrorContains(t, err, "LOG_LEVEL")
+
+	next = *cfg
+	next.Retention.Statuses = []string{"REJECTED"}
+	next.Retention.Period = time.Hour
+	_, err = application.Reload(context.Background(), &next)
+	assert.ErrorContains(t, err, "elasticsearch", "retention fails to build without elasticsearch")
+	assert.Same(t, previous, application.Indexer)
+}
+
+func TestReloadConfig_API(t *testing.T) {
+	cfg := testConfig(t)
+	loads := 0
+	loader := func() (*config.Config, error) {
+		loads++
+		next := *cfg
+		next.Index.Private = "sessions_private"
+		return &next, nil
+	}
+
+	application, err := Build(context.Background(), cfg, WithConfigLoader(loader))
+	require.NoError(t, err)
+	defer application.Close()
+
+	w := httptest.NewRecorder()
+	application.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/config/reload", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var reload domain.ConfigReload
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reload))
+	assert.Equal(t, []string{"PRIVATE_INDEX"}, reload.Changed)
+	assert.Equal(t, 1, loads)
+	assert.Equal(t, "sessions_private", application.Indexer.IndexNames().Private)
+
+	// The rebuilt API reloads the same application
+	w = httptest.NewRecorder()
+	application.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/config/reload", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, loads)
+}
+
+func TestReload_WhileRunning(t *testing.T) {
+	cfg := testConfig(t)
+	application, err := Build(context.Background(), cfg)
+	require.NoError(t, err)
+	defer application.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- application.Run(ctx) }()
+	time.Sleep(50 * time.Millisecond)
+
+	next := *cfg
+	next.LinkCheck.Interval = time.Hour
+	_, err = application.Reload(context.Background(), &next)
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
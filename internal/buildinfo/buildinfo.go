@@ -0,0 +1,57 @@
+// Package buildinfo tells which build of the indexer is running and since when.
+package buildinfo
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Version and Commit are set at build time, such as with
+// -ldflags "-X github.com/javaBin/talks-indexer/internal/buildinfo.Version=v1.2.0". When unset they are
+// read from the module and version control information the Go toolchain embeds in the binary.
+var (
+	Version string
+	Commit  string
+)
+
+// started is when the process started
+var started = time.Now()
+
+// Info describes the running build
+type Info struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit,omitempty"`
+	GoVersion string    `json:"goVersion"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Read returns the information of the running build
+func Read() Info {
+	info := Info{Version: Version, Commit: Commit, StartedAt: started.UTC()}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		if info.Version == "" {
+			info.Version = "unknown"
+		}
+		return info
+	}
+
+	info.GoVersion = build.GoVersion
+	if info.Version == "" {
+		info.Version = build.Main.Version
+	}
+	if info.Commit == "" {
+		for _, setting := range build.Settings {
+			if setting.Key == "vcs.revision" {
+				info.Commit = setting.Value
+			}
+		}
+	}
+	return info
+}
+
+// Uptime returns how long the process has been running
+func Uptime() time.Duration {
+	return time.Since(started)
+}
@@ -0,0 +1,25 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	t.Run("embedded information", func(t *testing.T) {
+		info := Read()
+		assert.NotEmpty(t, info.Version)
+		assert.NotEmpty(t, info.GoVersion)
+		assert.Equal(t, started.UTC(), info.StartedAt)
+	})
+
+	t.Run("set at build time", func(t *testing.T) {
+		Version, Commit = "v1.2.0", "8c3f1d2"
+		defer func() { Version, Commit = "", "" }()
+
+		info := Read()
+		assert.Equal(t, "v1.2.0", info.Version)
+		assert.Equal(t, "8c3f1d2", info.Commit)
+	})
+}
@@ -0,0 +1,80 @@
+package chaos
+
+import (
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+)
+
+// injectedError is the body of the responses standing in for a failed dependency
+const injectedError = "fault injected by CHAOS_ settings"
+
+// Transport is an http.RoundTripper injecting the configured latency and errors into the requests
+// of an adapter, so retries and partial-failure reporting can be exercised without a failing dependency
+type Transport struct {
+	next   http.RoundTripper
+	faults config.FaultConfig
+	roll   func(n int) int
+	logger *slog.Logger
+}
+
+// NewTransport wraps the transport with the faults, naming the adapter in the log
+func NewTransport(name string, next http.RoundTripper, faults config.FaultConfig) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{
+		next:   next,
+		faults: faults,
+		roll:   rand.IntN,
+		logger: slog.Default().With("component", "chaos", "adapter", name),
+	}
+}
+
+// RoundTrip delays the request and answers it with 503 Service Unavailable at the configured rates,
+// and sends it otherwise
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.faults.Latency > 0 && t.hit(t.faults.LatencyPercent) {
+		t.logger.DebugContext(ctx, "injecting latency", "method", req.Method, "path", req.URL.Path, "latency", t.faults.Latency)
+		select {
+		case <-ctx.Done():
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, ctx.Err()
+		case <-time.After(t.faults.Latency):
+		}
+	}
+
+	if t.hit(t.faults.ErrorPercent) {
+		t.logger.DebugContext(ctx, "injecting error", "method", req.Method, "path", req.URL.Path)
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return &http.Response{
+			Status:        "503 Service Unavailable",
+			StatusCode:    http.StatusServiceUnavailable,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+			Body:          io.NopCloser(strings.NewReader(injectedError)),
+			ContentLength: int64(len(injectedError)),
+			Request:       req,
+		}, nil
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// hit reports whether a request falls within the given percentage
+func (t *Transport) hit(percent int) bool {
+	return percent > 0 && t.roll(100) < percent
+}
@@ -0,0 +1,71 @@
+package chaos
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		faults     config.FaultConfig
+		roll       int
+		wantStatus int
+		wantSent   bool
+		wantDelay  bool
+	}{
+		{name: "no faults", faults: config.FaultConfig{LatencyPercent: 100}, roll: 0, wantStatus: http.StatusOK, wantSent: true},
+		{name: "error within rate", faults: config.FaultConfig{ErrorPercent: 30}, roll: 29, wantStatus: http.StatusServiceUnavailable},
+		{name: "error outside rate", faults: config.FaultConfig{ErrorPercent: 30}, roll: 30, wantStatus: http.StatusOK, wantSent: true},
+		{name: "latency within rate", faults: config.FaultConfig{Latency: 20 * time.Millisecond, LatencyPercent: 50}, roll: 49, wantStatus: http.StatusOK, wantSent: true, wantDelay: true},
+		{name: "latency outside rate", faults: config.FaultConfig{Latency: time.Hour, LatencyPercent: 50}, roll: 50, wantStatus: http.StatusOK, wantSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent.Store(0)
+			transport := NewTransport("test", nil, tt.faults)
+			transport.roll = func(n int) int { return tt.roll }
+			client := &http.Client{Transport: transport}
+
+			start := time.Now()
+			res, err := client.Get(server.URL)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode, string(body))
+			assert.Equal(t, tt.wantSent, sent.Load() == 1)
+			assert.Equal(t, tt.wantDelay, time.Since(start) >= tt.faults.Latency && tt.faults.Latency > 0)
+		})
+	}
+}
+
+func TestTransport_LatencyRespectsContext(t *testing.T) {
+	transport := NewTransport("test", nil, config.FaultConfig{Latency: time.Hour, LatencyPercent: 100})
+	client := &http.Client{Transport: transport}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.invalid", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
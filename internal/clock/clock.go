@@ -0,0 +1,113 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// System is the clock of the machine
+type System struct{}
+
+// Now returns the current time
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to pass
+func (System) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Offset is the clock of the machine shifted by a fixed duration. It is used for time travel in
+// development, where the application behaves as if it runs at another time while durations stay real.
+type Offset struct {
+	offset time.Duration
+}
+
+// NewOffset creates a clock running the given duration ahead of the machine, or behind if negative
+func NewOffset(offset time.Duration) *Offset {
+	return &Offset{offset: offset}
+}
+
+// Now returns the current time shifted by the offset
+func (c *Offset) Now() time.Time {
+	return time.Now().Add(c.offset)
+}
+
+// After waits for the duration to pass
+func (c *Offset) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	go func() {
+		ch <- (<-time.After(d)).Add(c.offset)
+	}()
+	return ch
+}
+
+// waiter is a caller of Fake.After waiting for the clock to reach the deadline
+type waiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// Fake is a clock that only moves when told to, for deterministic tests of time-dependent behavior
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+// NewFake creates a fake clock stopped at the given time
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the time the clock is stopped at
+func (c *Fake) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the time once the clock has been moved past the duration
+func (c *Fake) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by the duration
+func (c *Fake) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to the given time, releasing every waiter whose deadline has been reached
+func (c *Fake) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(now) {
+			remaining = append(remaining, w)
+			continue
+		}
+		w.ch <- now
+	}
+	c.waiters = remaining
+}
+
+// Waiters returns the number of callers of After still waiting, so tests can move the clock once
+// a goroutine has started waiting
+func (c *Fake) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
@@ -0,0 +1,45 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/ports"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ ports.Clock = System{}
+	_ ports.Clock = (*Offset)(nil)
+	_ ports.Clock = (*Fake)(nil)
+)
+
+func TestOffset_Now(t *testing.T) {
+	clock := NewOffset(-24 * time.Hour)
+
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), clock.Now(), time.Second)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), <-clock.After(time.Millisecond), time.Second)
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewFake(start)
+
+	soon := clock.After(time.Minute)
+	later := clock.After(time.Hour)
+	assert.Equal(t, 2, clock.Waiters())
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(30*time.Second), clock.Now())
+	assert.Empty(t, soon, "deadline not reached")
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-soon)
+	assert.Equal(t, 1, clock.Waiters())
+
+	clock.Set(start.Add(2 * time.Hour))
+	assert.Equal(t, start.Add(2*time.Hour), <-later)
+	assert.Zero(t, clock.Waiters())
+
+	assert.Equal(t, start.Add(2*time.Hour), <-clock.After(0), "non-positive durations have passed already")
+}
@@ -3,9 +3,48 @@ package config
 // Config holds all application configuration loaded from environment variables
 type Config struct {
 	ApplicationConfig
-	Http          HttpConfig          `envPrefix:"HTTP_"`
-	Moresleep     MoresleepConfig     `envPrefix:"MORESLEEP_"`
-	Elasticsearch ElasticsearchConfig `envPrefix:"ELASTICSEARCH_"`
-	Index         IndexConfig
-	OIDC          OIDCConfig `envPrefix:"OIDC_"`
+	Log             LogConfig           `envPrefix:"LOG_"`
+	Http            HttpConfig          `envPrefix:"HTTP_"`
+	Grpc            GrpcConfig          `envPrefix:"GRPC_"`
+	Moresleep       MoresleepConfig     `envPrefix:"MORESLEEP_"`
+	Elasticsearch   ElasticsearchConfig `envPrefix:"ELASTICSEARCH_"`
+	Search          SearchConfig        `envPrefix:"SEARCH_"`
+	Index           IndexConfig
+	OIDC            OIDCConfig            `envPrefix:"OIDC_"`
+	Access          AccessConfig          `envPrefix:"ACCESS_"`
+	API             APIConfig             `envPrefix:"API_"`
+	Anonymize       AnonymizeConfig       `envPrefix:"ANONYMIZE_"`
+	CDN             CDNConfig             `envPrefix:"CDN_"`
+	Signing         SigningConfig         `envPrefix:"SIGNING_"`
+	Preview         PreviewConfig         `envPrefix:"PREVIEW_"`
+	SitePreview     SitePreviewConfig     `envPrefix:"SITE_PREVIEW_"`
+	Registration    RegistrationConfig    `envPrefix:"REGISTRATION_"`
+	Health          HealthConfig          `envPrefix:"HEALTH_"`
+	Jobs            JobsConfig            `envPrefix:"JOBS_"`
+	Webhook         WebhookConfig         `envPrefix:"WEBHOOK_"`
+	WebhookDelivery WebhookDeliveryConfig `envPrefix:"WEBHOOK_DELIVERY_"`
+	Video           VideoConfig           `envPrefix:"VIDEO_"`
+	LinkCheck       LinkCheckConfig       `envPrefix:"LINK_CHECK_"`
+	Transform       TransformConfig       `envPrefix:"TRANSFORM_"`
+	Retention       RetentionConfig       `envPrefix:"RETENTION_"`
+	Conference      ConferenceConfig      `envPrefix:"CONFERENCE_"`
+	Series          SeriesConfig          `envPrefix:"SERIES_"`
+	Review          ReviewConfig          `envPrefix:"REVIEW_"`
+	Reindex         ReindexConfig         `envPrefix:"REINDEX_"`
+	Republish       RepublishConfig       `envPrefix:"REPUBLISH_"`
+	Capacity        CapacityConfig        `envPrefix:"CAPACITY_"`
+	Trends          TrendsConfig          `envPrefix:"TRENDS_"`
+	Keywords        KeywordsConfig        `envPrefix:"KEYWORDS_"`
+	SpeakerStats    SpeakerStatsConfig    `envPrefix:"SPEAKER_STATS_"`
+	Query           QueryConfig           `envPrefix:"QUERY_"`
+	Metrics         MetricsConfig         `envPrefix:"METRICS_"`
+	Usage           UsageConfig           `envPrefix:"USAGE_"`
+	Notify          NotifyConfig          `envPrefix:"NOTIFY_"`
+	SMTP            SMTPConfig            `envPrefix:"SMTP_"`
+	Summary         SummaryConfig         `envPrefix:"SUMMARY_"`
+	Diagnostics     DiagnosticsConfig     `envPrefix:"DIAGNOSTICS_"`
+	Clock           ClockConfig           `envPrefix:"CLOCK_"`
+	Vault           VaultConfig           `envPrefix:"VAULT_"`
+	AWS             AWSConfig             `envPrefix:"AWS_"`
+	Chaos           ChaosConfig           `envPrefix:"CHAOS_"`
 }
@@ -0,0 +1,7 @@
+package config
+
+// AccessConfig holds settings for the admin allowlist
+type AccessConfig struct {
+	// AdminEmails are always allowed as admins, so the allowlist can be managed before anyone is added to it
+	AdminEmails []string `env:"ADMIN_EMAILS"`
+}
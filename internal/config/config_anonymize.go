@@ -0,0 +1,16 @@
+package config
+
+// AnonymizeConfig holds the rules applied when exporting the anonymized research dataset
+type AnonymizeConfig struct {
+	// KeepFields lists the talk data fields retained in the export; everything else is dropped
+	KeepFields []string `env:"KEEP_FIELDS" envDefault:"title,abstract,format,language,length,level,keywords" envSeparator:","`
+
+	// Statuses limits the export to talks with these statuses (empty means all statuses)
+	Statuses []string `env:"STATUSES" envSeparator:","`
+
+	// IDSalt enables stable pseudonymous talk IDs; without it no ID is exported
+	IDSalt string `env:"ID_SALT"`
+
+	// ScrubText masks emails, URLs, handles and phone numbers in retained text fields
+	ScrubText bool `env:"SCRUB_TEXT" envDefault:"true"`
+}
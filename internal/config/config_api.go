@@ -0,0 +1,14 @@
+package config
+
+// APIConfig holds settings for machine callers of the JSON API
+type APIConfig struct {
+	// Tokens are the bearer tokens of machine callers such as CI pipelines, as name=token pairs. A token
+	// given as "sha256:" followed by the hex digest of the token keeps the token itself out of the
+	// configuration. The name attributes the caller's reindexes in the audit log.
+	Tokens map[string]string `env:"TOKENS" envKeyValSeparator:"="`
+}
+
+// IsConfigured returns true if any API tokens are configured
+func (c *APIConfig) IsConfigured() bool {
+	return len(c.Tokens) > 0
+}
@@ -21,4 +21,8 @@ func (m Mode) IsProduction() bool {
 // ApplicationConfig holds application-level configuration
 type ApplicationConfig struct {
 	Mode Mode `env:"MODE" envDefault:"production"`
+
+	// ReadOnly disables all writes to the cluster, such as reindexes and scheduled jobs, while searches,
+	// reports and admin views keep working. Used during Elasticsearch maintenance windows.
+	ReadOnly bool `env:"READ_ONLY" envDefault:"false"`
 }
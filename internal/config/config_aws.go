@@ -0,0 +1,13 @@
+package config
+
+// AWSConfig holds the region and access key AWS Secrets Manager secrets are read with, for settings
+// referring to a secret as "aws-sm:<secret-id>[#<key>]"
+type AWSConfig struct {
+	Region          string `env:"REGION"`
+	AccessKeyID     string `env:"ACCESS_KEY_ID"`
+	SecretAccessKey string `env:"SECRET_ACCESS_KEY"`
+	SessionToken    string `env:"SESSION_TOKEN"`
+
+	// EndpointURL replaces the regional endpoint of Secrets Manager, such as for a VPC endpoint or LocalStack
+	EndpointURL string `env:"ENDPOINT_URL"`
+}
@@ -0,0 +1,17 @@
+package config
+
+// CapacityConfig holds the disk headroom check run before large bulk jobs such as a full reindex
+type CapacityConfig struct {
+	// Check verifies the cluster has room for the documents before a full reindex or republish writes them
+	Check bool `env:"CHECK" envDefault:"true"`
+
+	// MaxDiskPercent is the disk usage of the data nodes a bulk job may bring the cluster up to.
+	// Keep it below the cluster's high disk watermark (90% by default).
+	MaxDiskPercent int `env:"MAX_DISK_PERCENT" envDefault:"85"`
+
+	// DefaultDocBytes is the assumed size of a document when the live indexes hold none to measure
+	DefaultDocBytes int64 `env:"DEFAULT_DOC_BYTES" envDefault:"16384"`
+
+	// OverheadPercent is added to the estimate for segment merges while the bulk requests are written
+	OverheadPercent int `env:"OVERHEAD_PERCENT" envDefault:"20"`
+}
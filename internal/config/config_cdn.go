@@ -0,0 +1,29 @@
+package config
+
+const (
+	CDNProviderFastly     = "fastly"
+	CDNProviderCloudflare = "cloudflare"
+)
+
+// CDNConfig holds the configuration for purging CDN caches after a public reindex
+type CDNConfig struct {
+	// Provider selects the CDN purge API ("fastly" or "cloudflare"); empty disables purging
+	Provider string `env:"PROVIDER"`
+
+	// PublicURL is the base URL the CDN serves the public endpoints from, e.g. https://sessions.javazone.no
+	PublicURL string `env:"PUBLIC_URL"`
+
+	// APIToken authenticates against the provider's purge API
+	APIToken string `env:"API_TOKEN"`
+
+	// ZoneID identifies the Cloudflare zone (Cloudflare only)
+	ZoneID string `env:"ZONE_ID"`
+
+	// PurgePaths lists the paths to purge; {conferenceSlug} expands to each affected conference
+	PurgePaths []string `env:"PURGE_PATHS" envDefault:"/api/conferences,/public/allSessions/{conferenceSlug}" envSeparator:","`
+}
+
+// IsConfigured returns true if a CDN provider is selected
+func (c *CDNConfig) IsConfigured() bool {
+	return c.Provider != ""
+}
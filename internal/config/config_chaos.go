@@ -0,0 +1,32 @@
+package config
+
+import "time"
+
+// ChaosConfig holds the faults injected into the requests of adapters in development, to exercise
+// retries and partial-failure reporting locally. Only allowed in development mode.
+type ChaosConfig struct {
+	Moresleep     FaultConfig `envPrefix:"MORESLEEP_"`
+	Elasticsearch FaultConfig `envPrefix:"ELASTICSEARCH_"`
+}
+
+// IsConfigured returns true if faults are injected into any adapter
+func (c *ChaosConfig) IsConfigured() bool {
+	return c.Moresleep.IsConfigured() || c.Elasticsearch.IsConfigured()
+}
+
+// FaultConfig holds the faults injected into the requests of one adapter
+type FaultConfig struct {
+	// Latency is added to the requests picked by LatencyPercent
+	Latency time.Duration `env:"LATENCY" envDefault:"0"`
+
+	// LatencyPercent is the share of requests delayed by Latency
+	LatencyPercent int `env:"LATENCY_PERCENT" envDefault:"100"`
+
+	// ErrorPercent is the share of requests answered with 503 Service Unavailable without being sent
+	ErrorPercent int `env:"ERROR_PERCENT" envDefault:"0"`
+}
+
+// IsConfigured returns true if any fault is injected
+func (c *FaultConfig) IsConfigured() bool {
+	return (c.Latency > 0 && c.LatencyPercent > 0) || c.ErrorPercent > 0
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+// ClockConfig holds the time travel settings of development instances
+type ClockConfig struct {
+	// Offset shifts the time the application runs at, such as "720h" to see notices expire and
+	// retention periods pass a month from now. Only allowed in development mode.
+	Offset time.Duration `env:"OFFSET" envDefault:"0"`
+}
@@ -0,0 +1,8 @@
+package config
+
+// ConferenceConfig holds the source of the conference metadata denormalized onto indexed talks
+type ConferenceConfig struct {
+	// MetadataFile is a JSON file mapping conference slugs to their metadata; entries saved in the
+	// admin UI override the file
+	MetadataFile string `env:"METADATA_FILE"`
+}
@@ -0,0 +1,10 @@
+package config
+
+// DiagnosticsConfig holds the contents of the diagnostics bundle admins download for support tickets
+type DiagnosticsConfig struct {
+	// LogLines is the number of recent log records kept in memory for the bundle (0 keeps none)
+	LogLines int `env:"LOG_LINES" envDefault:"1000"`
+
+	// Jobs is the number of most recent jobs whose reports are included
+	Jobs int `env:"JOBS" envDefault:"50"`
+}
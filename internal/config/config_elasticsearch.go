@@ -1,10 +1,22 @@
 package config
 
+import "time"
+
 // ElasticsearchConfig holds Elasticsearch client configuration
 type ElasticsearchConfig struct {
 	URL      string `env:"URL" envDefault:"http://localhost:9200"`
 	User     string `env:"USER"`
 	Password string `env:"PASSWORD"`
+
+	// Bulk indexing limits; batch size and concurrency shrink automatically when the cluster rejects writes
+	BulkBatchSize     int           `env:"BULK_BATCH_SIZE" envDefault:"500"`
+	BulkMaxBatchBytes int           `env:"BULK_MAX_BATCH_BYTES" envDefault:"5242880"`
+	BulkConcurrency   int           `env:"BULK_CONCURRENCY" envDefault:"2"`
+	BulkMaxRetries    int           `env:"BULK_MAX_RETRIES" envDefault:"5"`
+	BulkRetryBackoff  time.Duration `env:"BULK_RETRY_BACKOFF" envDefault:"500ms"`
+
+	// TLS configures the certificates trusted and presented on HTTPS connections
+	TLS TLSConfig `envPrefix:"TLS_"`
 }
 
 // HasCredentials returns true if authentication credentials are configured
@@ -0,0 +1,22 @@
+package config
+
+import "fmt"
+
+// GrpcConfig holds the gRPC server configuration for reindexes triggered by other internal services
+type GrpcConfig struct {
+	Host string `env:"HOST" envDefault:"0.0.0.0"`
+	Port int    `env:"PORT" envDefault:"9090"`
+
+	// Token is the bearer token callers send in the authorization metadata; the server is not started while it is empty
+	Token string `env:"TOKEN"`
+}
+
+// IsConfigured returns true if a token is configured
+func (c *GrpcConfig) IsConfigured() bool {
+	return c.Token != ""
+}
+
+// Addr returns the address string for the gRPC server
+func (c *GrpcConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// HealthConfig controls who may see detailed health output and when the indexes count as stale
+type HealthConfig struct {
+	// TrustedNetworks lists CIDRs (e.g. 10.0.0.0/8) whose callers may request detailed health output
+	// without being logged in
+	TrustedNetworks []string `env:"TRUSTED_NETWORKS" envSeparator:","`
+
+	// ReindexMaxAge is how old the last successful reindex of an index may get before health and
+	// readiness report the indexes as degraded. Zero never reports them as stale.
+	ReindexMaxAge time.Duration `env:"REINDEX_MAX_AGE" envDefault:"0"`
+}
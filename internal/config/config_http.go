@@ -1,11 +1,68 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // HttpConfig holds HTTP server configuration
 type HttpConfig struct {
 	Host string `env:"HOST" envDefault:"0.0.0.0"`
 	Port int    `env:"PORT" envDefault:"8080"`
+
+	// PublicCacheMaxAge is the max-age advertised to clients and CDNs on public read endpoints
+	PublicCacheMaxAge time.Duration `env:"PUBLIC_CACHE_MAX_AGE" envDefault:"60s"`
+
+	// IdempotencyWindow is how long responses to requests with an Idempotency-Key are replayed
+	IdempotencyWindow time.Duration `env:"IDEMPOTENCY_WINDOW" envDefault:"10m"`
+
+	// MaxBodyBytes is the largest request body accepted on POST, PUT and PATCH requests
+	MaxBodyBytes int64 `env:"MAX_BODY_BYTES" envDefault:"1048576"`
+
+	// AllowedContentTypes are the media types accepted for request bodies
+	AllowedContentTypes []string `env:"ALLOWED_CONTENT_TYPES" envDefault:"application/json,application/x-www-form-urlencoded,multipart/form-data"`
+
+	// Compression compresses responses with brotli or gzip for clients sending a matching Accept-Encoding
+	Compression bool `env:"COMPRESSION" envDefault:"true"`
+
+	// CompressionMinBytes is the smallest response body that is compressed; smaller bodies are sent as is
+	CompressionMinBytes int `env:"COMPRESSION_MIN_BYTES" envDefault:"1024"`
+
+	// TLS serves HTTPS on the port instead of plain HTTP
+	TLS ServerTLSConfig `envPrefix:"TLS_"`
+}
+
+// ServerTLSConfig holds the certificate the HTTP server presents, either read from files or obtained
+// from an ACME certificate authority such as Let's Encrypt
+type ServerTLSConfig struct {
+	// CertFile and KeyFile are the PEM certificate chain and private key; they are reloaded when changed
+	CertFile string `env:"CERT_FILE"`
+	KeyFile  string `env:"KEY_FILE"`
+
+	// ACMEDomains are the host names certificates are obtained for over ACME, instead of the files
+	ACMEDomains []string `env:"ACME_DOMAINS"`
+
+	// ACMEEmail is the contact address of the ACME account, for expiry notices
+	ACMEEmail string `env:"ACME_EMAIL"`
+
+	// ACMEDirectoryURL is the directory of the ACME certificate authority, Let's Encrypt if empty
+	ACMEDirectoryURL string `env:"ACME_DIRECTORY_URL"`
+
+	// ACMECacheDir keeps the ACME account key and certificates across restarts
+	ACMECacheDir string `env:"ACME_CACHE_DIR" envDefault:"acme-cache"`
+
+	// RedirectPort serves plain HTTP redirects to HTTPS, and ACME HTTP challenges, on the port; 0 disables it
+	RedirectPort int `env:"REDIRECT_PORT"`
+}
+
+// IsConfigured returns true if the HTTP server serves HTTPS
+func (c ServerTLSConfig) IsConfigured() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.UsesACME()
+}
+
+// UsesACME returns true if certificates are obtained over ACME
+func (c ServerTLSConfig) UsesACME() bool {
+	return len(c.ACMEDomains) > 0
 }
 
 // Addr returns the address string for the HTTP server
@@ -4,4 +4,48 @@ package config
 type IndexConfig struct {
 	Private string `env:"PRIVATE_INDEX" envDefault:"javazone_private"`
 	Public  string `env:"PUBLIC_INDEX" envDefault:"javazone_public"`
+
+	// Settings is the index holding small application settings documents such as user preferences
+	Settings string `env:"SETTINGS_INDEX" envDefault:"talks_indexer_settings"`
+
+	// Jobs is the index holding job records when jobs are stored in Elasticsearch
+	Jobs string `env:"JOBS_INDEX" envDefault:"talks_indexer_jobs"`
+
+	// DeadLetters is the index holding documents that failed indexing even after retries
+	DeadLetters string `env:"DEAD_LETTER_INDEX" envDefault:"talks_indexer_dead_letters"`
+
+	// PrivateMappingFile and PublicMappingFile are JSON files with the settings and mappings the talk
+	// indexes are created with, replacing the built-in ones. New mappings apply to indexes created by
+	// the next full reindex.
+	PrivateMappingFile string `env:"PRIVATE_MAPPING_FILE"`
+	PublicMappingFile  string `env:"PUBLIC_MAPPING_FILE"`
+
+	// Prefix is prepended to all index and alias names (e.g. "staging_") so several
+	// environments can share one Elasticsearch cluster
+	Prefix string `env:"INDEX_PREFIX"`
+}
+
+// PrivateName returns the private index name including the environment prefix
+func (c *IndexConfig) PrivateName() string {
+	return c.Prefix + c.Private
+}
+
+// PublicName returns the public index name including the environment prefix
+func (c *IndexConfig) PublicName() string {
+	return c.Prefix + c.Public
+}
+
+// SettingsName returns the settings index name including the environment prefix
+func (c *IndexConfig) SettingsName() string {
+	return c.Prefix + c.Settings
+}
+
+// JobsName returns the jobs index name including the environment prefix
+func (c *IndexConfig) JobsName() string {
+	return c.Prefix + c.Jobs
+}
+
+// DeadLettersName returns the dead-letter index name including the environment prefix
+func (c *IndexConfig) DeadLettersName() string {
+	return c.Prefix + c.DeadLetters
 }
@@ -0,0 +1,17 @@
+package config
+
+// Job store backends
+const (
+	JobStoreMemory        = "memory"
+	JobStoreElasticsearch = "elasticsearch"
+)
+
+// JobsConfig holds job store configuration
+type JobsConfig struct {
+	// Store selects where jobs are kept: "elasticsearch" keeps history across restarts,
+	// "memory" keeps only the most recent jobs of the running process
+	Store string `env:"STORE" envDefault:"elasticsearch"`
+
+	// MemoryCapacity is the number of jobs retained by the in-memory store
+	MemoryCapacity int `env:"MEMORY_CAPACITY" envDefault:"100"`
+}
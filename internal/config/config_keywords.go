@@ -0,0 +1,10 @@
+package config
+
+// KeywordsConfig holds the normalization of talk keywords in the keyword trend analysis
+type KeywordsConfig struct {
+	// Synonyms maps keyword spellings to the keyword they are counted as, as from=to pairs such as k8s=kubernetes
+	Synonyms []string `env:"SYNONYMS" envSeparator:","`
+
+	// Top is the number of most frequent keywords charted when no keywords are requested
+	Top int `env:"TOP" envDefault:"10"`
+}
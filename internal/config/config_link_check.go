@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+// LinkCheckConfig holds the settings for validating URLs stored in indexed talks
+type LinkCheckConfig struct {
+	// Interval between scheduled link checks; 0 disables the schedule, checks can still be started from the admin UI
+	Interval time.Duration `env:"INTERVAL" envDefault:"0"`
+
+	// ClearBroken removes broken video and speaker picture links from the public documents
+	ClearBroken bool `env:"CLEAR_BROKEN" envDefault:"false"`
+
+	// Timeout bounds each link request
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+
+	// Concurrency is the number of links checked in parallel
+	Concurrency int `env:"CONCURRENCY" envDefault:"4"`
+}
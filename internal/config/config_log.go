@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// LogConfig holds logging configuration
+type LogConfig struct {
+	// Level is the minimum level of logged records: "debug", "info", "warn" or "error". Defaults to
+	// debug in development mode and info in production. Changes apply when the configuration is reloaded.
+	Level string `env:"LEVEL"`
+
+	// File is the file logs are appended to instead of standard output. Sending SIGUSR1 reopens it, so
+	// logs go to a new file after logrotate moved the old one away.
+	File string `env:"FILE"`
+}
+
+// SlogLevel returns the configured level, or the default level of the mode if none is configured
+func (c *LogConfig) SlogLevel(mode Mode) (slog.Level, error) {
+	if c.Level == "" {
+		if mode.IsDevelopment() {
+			return slog.LevelDebug, nil
+		}
+		return slog.LevelInfo, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		return 0, fmt.Errorf("invalid LOG_LEVEL %q: %w", c.Level, err)
+	}
+	return level, nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricsConfig holds the request metrics settings and the service level objectives of HTTP routes
+type MetricsConfig struct {
+	// SLOTargets sets service level objectives for routes as route=objective:latency, e.g.
+	// "GET /api/conferences=99.9:300ms". A request meets the objective if it does not fail
+	// with a 5xx status and completes within the latency.
+	SLOTargets []SLOTarget `env:"SLO_TARGETS" envSeparator:","`
+
+	// BurnRateWindows are the windows the error budget burn rate of each SLO is computed over
+	BurnRateWindows []time.Duration `env:"BURN_RATE_WINDOWS" envDefault:"5m,30m,1h,6h" envSeparator:","`
+
+	// LatencyBuckets are the upper bounds of the request latency histogram
+	LatencyBuckets []time.Duration `env:"LATENCY_BUCKETS" envDefault:"5ms,10ms,25ms,50ms,100ms,250ms,500ms,1s,2.5s,5s,10s" envSeparator:","`
+}
+
+// SLOTarget is the service level objective of one route, identified by its mux pattern
+type SLOTarget struct {
+	Route string
+
+	// Objective is the fraction of requests that must meet the objective, e.g. 0.999
+	Objective float64
+
+	// Latency is the slowest response that still meets the objective
+	Latency time.Duration
+}
+
+// UnmarshalText parses a target in the form route=objective:latency, with the objective in percent
+func (t *SLOTarget) UnmarshalText(text []byte) error {
+	route, target, ok := strings.Cut(string(text), "=")
+	if !ok {
+		return fmt.Errorf("invalid SLO target %q: expected route=objective:latency", text)
+	}
+	percent, latency, ok := strings.Cut(target, ":")
+	if !ok {
+		return fmt.Errorf("invalid SLO target %q: expected route=objective:latency", text)
+	}
+
+	objective, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+	if err != nil || objective <= 0 || objective >= 100 {
+		return fmt.Errorf("invalid SLO objective %q: expected a percentage between 0 and 100", percent)
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(latency))
+	if err != nil || duration <= 0 {
+		return fmt.Errorf("invalid SLO latency %q: expected a positive duration", latency)
+	}
+
+	t.Route = strings.TrimSpace(route)
+	t.Objective = objective / 100
+	t.Latency = duration
+	return nil
+}
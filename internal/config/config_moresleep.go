@@ -1,10 +1,29 @@
 package config
 
+import "time"
+
 // MoresleepConfig holds moresleep API client configuration
 type MoresleepConfig struct {
 	URL      string `env:"URL" envDefault:"http://localhost:8082"`
 	User     string `env:"USER"`
 	Password string `env:"PASSWORD"`
+
+	// MaxRetries is how many times a request failing with a 5xx status or a network error is retried,
+	// so a single flaky response does not leave a conference out of a reindex
+	MaxRetries int `env:"MAX_RETRIES" envDefault:"3"`
+
+	// RetryBackoff is the wait before the first retry, doubled for each further retry and jittered
+	RetryBackoff time.Duration `env:"RETRY_BACKOFF" envDefault:"500ms"`
+
+	// RequestTimeout bounds each attempt of a request
+	RequestTimeout time.Duration `env:"REQUEST_TIMEOUT" envDefault:"10s"`
+
+	// ConferenceCacheTTL is how long the conference list is reused to look up the slug and name of the
+	// conference of fetched talks (0 lists the conferences for every lookup)
+	ConferenceCacheTTL time.Duration `env:"CONFERENCE_CACHE_TTL" envDefault:"5m"`
+
+	// TLS configures the certificates trusted and presented on HTTPS connections
+	TLS TLSConfig `envPrefix:"TLS_"`
 }
 
 // HasCredentials returns true if authentication credentials are configured
@@ -0,0 +1,27 @@
+package config
+
+import "time"
+
+// NotifyConfig holds the destinations the outcome of reindexes is posted to, so that failed unattended
+// reindexes are noticed
+type NotifyConfig struct {
+	// SlackWebhookURL is a Slack incoming webhook URL the outcome is posted to as a message
+	SlackWebhookURL string `env:"SLACK_WEBHOOK_URL"`
+
+	// WebhookURL receives the outcome as a reindex.completed event, like outbound webhook subscriptions
+	WebhookURL string `env:"WEBHOOK_URL"`
+
+	// WebhookSecret signs the events posted to WebhookURL; they are sent unsigned while it is empty
+	WebhookSecret string `env:"WEBHOOK_SECRET"`
+
+	// FailuresOnly leaves out reindexes that succeeded
+	FailuresOnly bool `env:"FAILURES_ONLY" envDefault:"false"`
+
+	// Timeout bounds each post
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+}
+
+// IsConfigured returns true if reindex outcomes are posted anywhere
+func (c *NotifyConfig) IsConfigured() bool {
+	return c.SlackWebhookURL != "" || c.WebhookURL != ""
+}
@@ -6,6 +6,15 @@ type OIDCConfig struct {
 	ClientID     string `env:"CLIENT_ID"`
 	ClientSecret string `env:"CLIENT_SECRET"`
 	RedirectURL  string `env:"REDIRECT_URL"`
+
+	// GroupsClaim is the ID token claim listing the user's groups; nested claims are separated by dots,
+	// such as "realm_access.roles"
+	GroupsClaim string `env:"GROUPS_CLAIM" envDefault:"groups"`
+
+	// RoleGroups grants roles to members of OIDC groups, as group=role pairs such as
+	// "javabin-program=operator,javabin-readonly=viewer". Users get the highest role of their groups
+	// and the allowlist, and users in neither are kept out.
+	RoleGroups map[string]string `env:"ROLE_GROUPS" envKeyValSeparator:"="`
 }
 
 // IsConfigured returns true if OIDC is fully configured
@@ -14,3 +23,8 @@ func (c *OIDCConfig) IsConfigured() bool {
 		c.ClientID != "" &&
 		c.ClientSecret != ""
 }
+
+// HasGroupRoles returns true if roles are granted by OIDC group membership
+func (c *OIDCConfig) HasGroupRoles() bool {
+	return len(c.RoleGroups) > 0
+}
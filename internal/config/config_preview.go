@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// PreviewConfig holds the settings of the preview links speakers use to see their talk before publication
+type PreviewConfig struct {
+	// Secret signs the preview links; empty disables speaker previews
+	Secret string `env:"SECRET"`
+
+	// LinkTTL is how long a preview link stays valid after it is created
+	LinkTTL time.Duration `env:"LINK_TTL" envDefault:"336h"`
+}
+
+// IsConfigured returns true if a secret for signing preview links is configured
+func (c *PreviewConfig) IsConfigured() bool {
+	return c.Secret != ""
+}
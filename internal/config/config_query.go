@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// QueryConfig holds the limits of the ad-hoc query endpoint on the private index
+type QueryConfig struct {
+	// MaxSize is the largest number of hits a query may return
+	MaxSize int `env:"MAX_SIZE" envDefault:"100"`
+
+	// MaxFrom is the largest offset a query may page to
+	MaxFrom int `env:"MAX_FROM" envDefault:"1000"`
+
+	// MaxBuckets is the largest size of a terms aggregation and the most buckets a histogram may have
+	MaxBuckets int `env:"MAX_BUCKETS" envDefault:"500"`
+
+	// MaxDepth is how deeply query clauses and aggregations may be nested
+	MaxDepth int `env:"MAX_DEPTH" envDefault:"8"`
+
+	// Timeout bounds the time a query may run in Elasticsearch
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"5s"`
+}
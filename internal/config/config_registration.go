@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// RegistrationConfig holds the settings for indexing workshop capacity and registration counts
+// from the registration system
+type RegistrationConfig struct {
+	// URL of the registration system's workshop listing; empty disables the sync
+	URL string `env:"URL"`
+
+	// Token authenticates against the registration system as a bearer token
+	Token string `env:"TOKEN"`
+
+	// Interval between syncs of the registration counts into the indexes
+	Interval time.Duration `env:"INTERVAL" envDefault:"5m"`
+
+	// Timeout bounds each request to the registration system
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+}
+
+// IsConfigured returns true if the registration system is configured
+func (c *RegistrationConfig) IsConfigured() bool {
+	return c.URL != ""
+}
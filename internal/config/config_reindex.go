@@ -0,0 +1,18 @@
+package config
+
+// ReindexConfig holds the safeguards and concurrency of full reindexes
+type ReindexConfig struct {
+	// MaxFailedPercent is the share of conferences whose talks may fail to fetch before a full reindex
+	// or republish is aborted, leaving the indexes as they are. Below it, failed conferences are
+	// skipped. 100 never aborts.
+	MaxFailedPercent int `env:"MAX_FAILED_PERCENT" envDefault:"20"`
+
+	// MaxShrinkPercent is the largest drop in public talks, in percent of the live public index, a full
+	// reindex or republish accepts before it is refused unless explicitly overridden. 100 never refuses.
+	MaxShrinkPercent int `env:"MAX_SHRINK_PERCENT" envDefault:"50"`
+
+	// Concurrency is the number of conferences whose talks are fetched from moresleep at the same time
+	// during full reindexes and republishes. A full reindex stages each conference as soon as it is
+	// fetched, so it also bounds the talks held in memory; a republish holds all talks.
+	Concurrency int `env:"CONCURRENCY" envDefault:"4"`
+}
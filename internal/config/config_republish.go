@@ -0,0 +1,8 @@
+package config
+
+// RepublishConfig holds the safeguards of the guided full republish
+type RepublishConfig struct {
+	// SnapshotRepository is the Elasticsearch snapshot repository the live indexes are snapshotted to
+	// before a republish replaces them; without it the snapshot step is skipped
+	SnapshotRepository string `env:"SNAPSHOT_REPOSITORY"`
+}
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// RetentionConfig holds the retention period of talks that were never published, such as rejected
+// submissions, in the private index
+type RetentionConfig struct {
+	// Statuses lists the talk statuses the retention period applies to; approved talks are never expired
+	Statuses []string `env:"STATUSES" envDefault:"REJECTED,DRAFT" envSeparator:","`
+
+	// Period is how long after their last update talks with these statuses are kept; 0 keeps them forever
+	Period time.Duration `env:"PERIOD" envDefault:"0"`
+
+	// Interval between scheduled deletions of expired talks from the private index
+	Interval time.Duration `env:"INTERVAL" envDefault:"24h"`
+}
+
+// IsConfigured returns true if expired talks should be kept out of the private index
+func (c *RetentionConfig) IsConfigured() bool {
+	return c.Period > 0 && len(c.Statuses) > 0
+}
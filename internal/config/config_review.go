@@ -0,0 +1,28 @@
+package config
+
+// ReviewConfig holds how the committee review assignments of talks are read from moresleep
+type ReviewConfig struct {
+	// TagPrefix marks the talk tags assigning a reviewer, followed by the reviewer's email (e.g. "reviewer:ola@java.no")
+	TagPrefix string `env:"TAG_PREFIX" envDefault:"reviewer:"`
+
+	// TagsField is the talk data field holding the tags
+	TagsField string `env:"TAGS_FIELD" envDefault:"tags"`
+
+	// FeedbackField is the talk data field holding the committee feedback, whose author is the reviewer
+	FeedbackField string `env:"FEEDBACK_FIELD" envDefault:"pkomfeedbacks"`
+
+	// ConflictTagPrefix marks the talk tags declaring a conflict of interest of a committee member,
+	// followed by their email (e.g. "conflict:ola@java.no")
+	ConflictTagPrefix string `env:"CONFLICT_TAG_PREFIX" envDefault:"conflict:"`
+
+	// OrganizationField is the speaker data field holding the organization of the speaker, matched
+	// against the organizations committee members registered conflicts of interest with
+	OrganizationField string `env:"ORGANIZATION_FIELD" envDefault:"company"`
+
+	// TrackField is the talk data field naming the track talks are ranked within for the program selection
+	TrackField string `env:"TRACK_FIELD" envDefault:"track"`
+
+	// ScoreWeights weights the feedback types of the committee feedback, as type=weight pairs such as
+	// "LIKE=1,DISLIKE=-1", to score talks for ranking. Talks are not scored while empty.
+	ScoreWeights map[string]float64 `env:"SCORE_WEIGHTS" envKeyValSeparator:"="`
+}
@@ -0,0 +1,30 @@
+package config
+
+// Search backends
+const (
+	SearchBackendElasticsearch = "elasticsearch"
+	SearchBackendOpenSearch    = "opensearch"
+	SearchBackendSQLite        = "sqlite"
+	SearchBackendBleve         = "bleve"
+)
+
+// SearchConfig holds search backend configuration
+type SearchConfig struct {
+	// Backend selects where talks are indexed: "elasticsearch" supports every feature, as does
+	// "opensearch" for OpenSearch clusters such as AWS OpenSearch Service, "sqlite" keeps the indexes in a
+	// local SQLite database for small deployments and CI, and "bleve" in embedded Bleve indexes for
+	// single-binary deployments. The embedded backends support reindexes, the public read
+	// endpoints and talk search only.
+	Backend string `env:"BACKEND" envDefault:"elasticsearch"`
+
+	// SQLitePath is the database file used by the SQLite backend (":memory:" keeps it in memory)
+	SQLitePath string `env:"SQLITE_PATH" envDefault:"talks-indexer.db"`
+
+	// BlevePath is the directory holding one Bleve index per index name
+	BlevePath string `env:"BLEVE_PATH" envDefault:"talks-indexer.bleve"`
+}
+
+// IsEmbedded returns true if talks are indexed in an embedded store instead of Elasticsearch
+func (c *SearchConfig) IsEmbedded() bool {
+	return c.Backend == SearchBackendSQLite || c.Backend == SearchBackendBleve
+}
@@ -0,0 +1,11 @@
+package config
+
+// SeriesConfig holds the sources of the series linking related sessions, such as the parts of a workshop
+type SeriesConfig struct {
+	// Field is the talk data field holding the series ID of talks linked in moresleep, if any
+	Field string `env:"FIELD"`
+
+	// File is a JSON file mapping series IDs to their talk IDs in order; series saved in the admin UI
+	// override the file, and both override the series ID of the talk data field
+	File string `env:"FILE"`
+}
@@ -0,0 +1,15 @@
+package config
+
+// SigningConfig holds the key used to sign exported public snapshots
+type SigningConfig struct {
+	// PrivateKey is a base64-encoded ed25519 seed (32 bytes) or private key (64 bytes); empty disables signing
+	PrivateKey string `env:"PRIVATE_KEY"`
+
+	// KeyID identifies the key to downstream consumers; derived from the public key when empty
+	KeyID string `env:"KEY_ID"`
+}
+
+// IsConfigured returns true if a signing key is configured
+func (c *SigningConfig) IsConfigured() bool {
+	return c.PrivateKey != ""
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// SitePreviewConfig holds the settings for previewing how the website renders public documents
+type SitePreviewConfig struct {
+	// URL of the website's preview renderer, which receives a public document as JSON and returns the
+	// talk page as HTML; empty renders talks with the embedded renderer of the admin UI
+	URL string `env:"URL"`
+
+	// RequiredFields lists the talk data fields the website needs to render a talk
+	RequiredFields []string `env:"REQUIRED_FIELDS" envDefault:"title,abstract,format,language,length" envSeparator:","`
+
+	// RequiredSpeakerFields lists the speaker data fields the website needs, in addition to the name
+	RequiredSpeakerFields []string `env:"REQUIRED_SPEAKER_FIELDS" envDefault:"bio" envSeparator:","`
+
+	// Timeout bounds each request to the preview renderer
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+}
+
+// IsConfigured returns true if the website's preview renderer is configured
+func (c *SitePreviewConfig) IsConfigured() bool {
+	return c.URL != ""
+}
@@ -0,0 +1,26 @@
+package config
+
+import "time"
+
+// SMTPConfig holds the mail server emails are sent through
+type SMTPConfig struct {
+	Host string `env:"HOST"`
+
+	// Port is the submission port; the connection is upgraded with STARTTLS when the server offers it
+	Port int `env:"PORT" envDefault:"587"`
+
+	// Username and Password authenticate with PLAIN, which is only done over TLS or to localhost
+	Username string `env:"USERNAME"`
+	Password string `env:"PASSWORD"`
+
+	// From is the sender address of the emails
+	From string `env:"FROM"`
+
+	// Timeout bounds sending each email
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"30s"`
+}
+
+// IsConfigured returns true if emails can be sent
+func (c *SMTPConfig) IsConfigured() bool {
+	return c.Host != "" && c.From != ""
+}
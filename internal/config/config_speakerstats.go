@@ -0,0 +1,12 @@
+package config
+
+// SpeakerStatsConfig holds the private aggregation of captured speaker attributes, used by the program
+// committee to balance the program
+type SpeakerStatsConfig struct {
+	// Fields lists the speaker data fields aggregated, such as residence
+	Fields []string `env:"FIELDS" envDefault:"residence,gender" envSeparator:","`
+
+	// MinGroupSize is the smallest number of speakers reported for one value; smaller groups are
+	// counted as other so single speakers cannot be singled out
+	MinGroupSize int `env:"MIN_GROUP_SIZE" envDefault:"5"`
+}
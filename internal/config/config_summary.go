@@ -0,0 +1,31 @@
+package config
+
+// Summary email periods
+const (
+	SummaryDaily  = "daily"
+	SummaryWeekly = "weekly"
+)
+
+// SummaryConfig holds the summary email of the indexing runs, for program committee leads who do not
+// watch the dashboard
+type SummaryConfig struct {
+	// Recipients of the summary; no summary is sent while empty
+	Recipients []string `env:"RECIPIENTS" envSeparator:","`
+
+	// Period is "daily" or "weekly"
+	Period string `env:"PERIOD" envDefault:"weekly"`
+
+	// Weekday the weekly summary is sent on
+	Weekday string `env:"WEEKDAY" envDefault:"monday"`
+
+	// Hour of the day the summary is sent at, in TimeZone
+	Hour int `env:"HOUR" envDefault:"7"`
+
+	// TimeZone the days of the summary follow
+	TimeZone string `env:"TIME_ZONE" envDefault:"Europe/Oslo"`
+}
+
+// IsConfigured returns true if a summary is sent to anyone
+func (c *SummaryConfig) IsConfigured() bool {
+	return len(c.Recipients) > 0
+}
@@ -0,0 +1,20 @@
+package config
+
+// TLSConfig holds the TLS settings of an outbound HTTP client, such as a custom CA for clusters behind an
+// internal certificate authority
+type TLSConfig struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition to the system roots
+	CAFile string `env:"CA_FILE"`
+
+	// CertFile and KeyFile are the PEM client certificate and key presented to servers requiring one
+	CertFile string `env:"CERT_FILE"`
+	KeyFile  string `env:"KEY_FILE"`
+
+	// InsecureSkipVerify accepts any server certificate. Only allowed in development mode.
+	InsecureSkipVerify bool `env:"INSECURE_SKIP_VERIFY" envDefault:"false"`
+}
+
+// IsConfigured returns true if any TLS setting differs from the defaults
+func (c *TLSConfig) IsConfigured() bool {
+	return c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" || c.InsecureSkipVerify
+}
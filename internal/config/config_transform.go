@@ -0,0 +1,19 @@
+package config
+
+// TransformConfig holds the optional transformations applied to talks before they are indexed
+type TransformConfig struct {
+	// AbstractHTML renders the markdown abstract to sanitized HTML in data.abstractHtml
+	AbstractHTML bool `env:"ABSTRACT_HTML" envDefault:"false"`
+
+	// ScrubPublic masks emails, phone numbers and blocked words in the free-text fields of public documents
+	ScrubPublic bool `env:"SCRUB_PUBLIC" envDefault:"false"`
+
+	// ScrubFields lists the talk data fields scrubbed in public documents
+	ScrubFields []string `env:"SCRUB_FIELDS" envDefault:"abstract,abstractHtml" envSeparator:","`
+
+	// ScrubSpeakerFields lists the speaker data fields scrubbed in public documents
+	ScrubSpeakerFields []string `env:"SCRUB_SPEAKER_FIELDS" envDefault:"bio" envSeparator:","`
+
+	// ScrubWords lists words masked wherever they appear as whole words, matched case-insensitively
+	ScrubWords []string `env:"SCRUB_WORDS" envSeparator:","`
+}
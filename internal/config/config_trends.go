@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// TrendsConfig holds the daily talk count snapshots shown as trend charts on the dashboard
+type TrendsConfig struct {
+	// Interval between snapshots of the active conferences' talk counts; a later snapshot on the same
+	// day replaces the earlier one. 0 disables recording.
+	Interval time.Duration `env:"INTERVAL" envDefault:"1h"`
+
+	// Days is how many days of snapshots are kept per conference
+	Days int `env:"DAYS" envDefault:"120"`
+}
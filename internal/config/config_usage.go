@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// UsageConfig holds the recording of the API usage per route and client shown on the usage page
+type UsageConfig struct {
+	// FlushInterval is how often the usage counted in memory is added to the stored usage. Usage counted
+	// since the last flush is lost on shutdown. 0 disables recording.
+	FlushInterval time.Duration `env:"FLUSH_INTERVAL" envDefault:"1m"`
+
+	// Days is how many days of hourly usage are kept
+	Days int `env:"DAYS" envDefault:"30"`
+}
@@ -0,0 +1,8 @@
+package config
+
+// VaultConfig holds the HashiCorp Vault server secrets are read from, for settings referring to a
+// secret as "vault:<path>#<key>"
+type VaultConfig struct {
+	Addr  string `env:"ADDR"`
+	Token string `env:"TOKEN"`
+}
@@ -0,0 +1,26 @@
+package config
+
+const (
+	VideoProviderVimeo   = "vimeo"
+	VideoProviderYouTube = "youtube"
+)
+
+// VideoConfig holds the configuration for backfilling video links from the conference video channel
+type VideoConfig struct {
+	// Provider selects the video platform ("vimeo" or "youtube"); empty disables the backfill job
+	Provider string `env:"PROVIDER"`
+
+	// Channel is the Vimeo user or YouTube channel ID the conference videos are published on
+	Channel string `env:"CHANNEL"`
+
+	// APIToken authenticates against the provider API (Vimeo access token or YouTube API key)
+	APIToken string `env:"API_TOKEN"`
+
+	// MatchThreshold is the minimum match score, between 0 and 1, for a video to be proposed for a talk
+	MatchThreshold float64 `env:"MATCH_THRESHOLD" envDefault:"0.6"`
+}
+
+// IsConfigured returns true if a video provider is selected
+func (c *VideoConfig) IsConfigured() bool {
+	return c.Provider != ""
+}
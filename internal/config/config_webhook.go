@@ -0,0 +1,37 @@
+package config
+
+import "time"
+
+// Webhook signature encodings
+const (
+	WebhookEncodingHex    = "hex"
+	WebhookEncodingBase64 = "base64"
+)
+
+// WebhookConfig holds the verification settings for inbound webhooks.
+// Payloads are signed with HMAC-SHA256 over "<timestamp>.<body>", or over the body alone
+// when timestamps are not required.
+type WebhookConfig struct {
+	// Secret is the shared HMAC secret; inbound webhooks are rejected while it is empty
+	Secret string `env:"SECRET"`
+
+	// SignatureHeader carries the signature, prefixed with SignaturePrefix (e.g. "sha256=") if set
+	SignatureHeader string `env:"SIGNATURE_HEADER" envDefault:"X-Webhook-Signature"`
+	SignaturePrefix string `env:"SIGNATURE_PREFIX"`
+
+	// SignatureEncoding is the encoding of the signature: "hex" or "base64"
+	SignatureEncoding string `env:"SIGNATURE_ENCODING" envDefault:"hex"`
+
+	// RequireTimestamp enables replay protection: the signed payload includes the Unix time
+	// from TimestampHeader, which must be within TimestampTolerance of the current time
+	RequireTimestamp bool   `env:"REQUIRE_TIMESTAMP" envDefault:"true"`
+	TimestampHeader  string `env:"TIMESTAMP_HEADER" envDefault:"X-Webhook-Timestamp"`
+
+	// TimestampTolerance is how far the timestamp may differ from the current time
+	TimestampTolerance time.Duration `env:"TIMESTAMP_TOLERANCE" envDefault:"5m"`
+}
+
+// IsConfigured returns true if a webhook secret is configured
+func (c *WebhookConfig) IsConfigured() bool {
+	return c.Secret != ""
+}
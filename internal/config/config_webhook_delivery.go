@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// WebhookDeliveryConfig holds settings for delivering outbound webhooks to subscribers
+type WebhookDeliveryConfig struct {
+	// MaxAttempts is the number of delivery attempts before a delivery is marked as failed
+	MaxAttempts int `env:"MAX_ATTEMPTS" envDefault:"5"`
+
+	// InitialBackoff is the wait before the first retry; it doubles with every further retry
+	InitialBackoff time.Duration `env:"INITIAL_BACKOFF" envDefault:"2s"`
+
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration `env:"MAX_BACKOFF" envDefault:"5m"`
+
+	// Timeout bounds each delivery attempt
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+
+	// LogSize is the number of recent deliveries shown in the delivery log
+	LogSize int `env:"LOG_SIZE" envDefault:"200"`
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readFile reads a YAML configuration file into environment variables. Keys are the names of the
+// environment variables, either flat (HTTP_PORT) or nested by their prefixes (http: port:); keys are
+// case-insensitive and dashes may be used for underscores. Lists and mappings are joined with the
+// separators of their variables, so they can be written as YAML rather than as strings.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if len(doc.Content) == 0 {
+		return values, nil
+	}
+	root := resolveAlias(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("configuration file %s must be a mapping of settings", path)
+	}
+
+	fields := make(map[string]reflect.StructField)
+	configFields(reflect.TypeOf(Config{}), "", fields)
+
+	if err := flattenFile(root, "", fields, values); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// configFields collects the fields of the struct by environment variable name, following envPrefix
+// tags and embedded structs as redactFields does
+func configFields(t reflect.Type, prefix string, fields map[string]reflect.StructField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Tag.Get("env") == "" {
+			configFields(field.Type, prefix+field.Tag.Get("envPrefix"), fields)
+			continue
+		}
+
+		if env := field.Tag.Get("env"); env != "" {
+			fields[prefix+env] = field
+		}
+	}
+}
+
+// flattenFile adds the settings of the mapping to the values, descending into nested mappings until
+// the keys name an environment variable. Unknown keys are rejected so typos do not go unnoticed.
+func flattenFile(node *yaml.Node, prefix string, fields map[string]reflect.StructField, values map[string]string) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolveAlias(node.Content[i+1])
+		name := prefix + strings.ToUpper(strings.ReplaceAll(key.Value, "-", "_"))
+
+		if field, ok := fields[name]; ok {
+			s, err := fileValue(value, field)
+			if err != nil {
+				return fmt.Errorf("%s (line %d): %w", name, key.Line, err)
+			}
+			values[name] = s
+			continue
+		}
+
+		if value.Kind == yaml.MappingNode {
+			if err := flattenFile(value, name+"_", fields, values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		return fmt.Errorf("unknown setting %s (line %d)", name, key.Line)
+	}
+	return nil
+}
+
+// fileValue formats the YAML value like the environment variable of the field
+func fileValue(node *yaml.Node, field reflect.StructField) (string, error) {
+	separator := field.Tag.Get("envSeparator")
+	if separator == "" {
+		separator = ","
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return "", nil
+		}
+		return node.Value, nil
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			item = resolveAlias(item)
+			if item.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("list items must be plain values")
+			}
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, separator), nil
+	case yaml.MappingNode:
+		keyValSeparator := field.Tag.Get("envKeyValSeparator")
+		if keyValSeparator == "" {
+			keyValSeparator = ":"
+		}
+		pairs := make([]string, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value := resolveAlias(node.Content[i+1])
+			if value.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("mapping values must be plain values")
+			}
+			pairs = append(pairs, node.Content[i].Value+keyValSeparator+value.Value)
+		}
+		slices.Sort(pairs)
+		return strings.Join(pairs, separator), nil
+	}
+	return "", fmt.Errorf("unsupported value")
+}
+
+// resolveAlias returns the node an alias refers to, or the node itself
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
@@ -8,24 +8,73 @@ import (
 	"github.com/joho/godotenv"
 )
 
-// Load reads configuration from environment variables and optionally from a .env file.
+// Load reads configuration from environment variables and optionally from a .env file and the
+// configuration file named by CONFIG_FILE. Every call reads the files again, so reloading the
+// configuration picks up changes to them.
 // It returns a pointer to the Config struct or an error if parsing fails.
 func Load() (*Config, error) {
-	// Try to load .env file, but ignore error if it doesn't exist
-	_ = godotenv.Load()
+	return LoadFile("")
+}
+
+// LoadFile reads configuration like Load, taking settings from the YAML configuration file at path.
+// If path is empty, the file named by CONFIG_FILE is read when set. Environment variables override
+// the settings of the file, so single settings can still be changed per deployment. The .env file
+// sets the variables missing from the environment without changing the environment of the process.
+// Secrets may instead be read from the file named by the variable with a _FILE suffix, or refer to a
+// secret in Vault or AWS Secrets Manager, which is read on every call.
+func LoadFile(path string) (*Config, error) {
+	// Try to read the .env file, but ignore error if it doesn't exist
+	variables, _ := godotenv.Read()
+	if variables == nil {
+		variables = make(map[string]string)
+	}
+	for name, value := range env.ToMap(os.Environ()) {
+		variables[name] = value
+	}
+
+	if path == "" {
+		path = variables["CONFIG_FILE"]
+	}
+
+	environment := make(map[string]string)
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		environment = values
+	}
+	for name, value := range variables {
+		environment[name] = value
+	}
+	if err := readSecretFiles(environment); err != nil {
+		return nil, err
+	}
 
 	cfg := &Config{}
-	if err := env.Parse(cfg); err != nil {
+	if err := env.ParseWithOptions(cfg, env.Options{Environment: environment}); err != nil {
 		return nil, fmt.Errorf("failed to parse configuration: %w", err)
 	}
 
+	// Secrets kept in a secrets backend are read with the settings parsed above, then parsed again
+	resolved, err := resolveSecrets(cfg, environment)
+	if err != nil {
+		return nil, err
+	}
+	if resolved {
+		cfg = &Config{}
+		if err := env.ParseWithOptions(cfg, env.Options{Environment: environment}); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration: %w", err)
+		}
+	}
+
 	return cfg, nil
 }
 
-// MustLoad loads the configuration and panics if it fails.
-// This is useful for initialization in main() where we want to fail fast.
-func MustLoad() *Config {
-	cfg, err := Load()
+// MustLoad loads the configuration, reading the configuration file at path if not empty, and panics
+// if it fails. This is useful for initialization in main() where we want to fail fast.
+func MustLoad(path string) *Config {
+	cfg, err := LoadFile(path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
 		os.Exit(1)
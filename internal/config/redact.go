@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces the value of a secret in the redacted configuration
+const redactedValue = "[redacted]"
+
+// secretNameParts mark the environment variables holding secrets. Webhook URLs such as Slack's carry
+// the credential in their path, and salts let anyone holding them reverse pseudonymized IDs.
+var secretNameParts = []string{"PASSWORD", "SECRET", "TOKEN", "PRIVATE_KEY", "WEBHOOK_URL", "SALT"}
+
+// Redacted returns the configuration as environment variables with their effective values, fit for
+// sharing in support tickets. Secrets are replaced with "[redacted]" when set, only the names of API
+// tokens are kept, and passwords are removed from URLs.
+func (c *Config) Redacted() map[string]string {
+	values := make(map[string]string)
+	redactFields(reflect.ValueOf(*c), "", true, values)
+	return values
+}
+
+// redactFields adds the fields of the struct to the values, following envPrefix tags and embedded
+// structs. Secrets are only redacted if redact is set.
+func redactFields(v reflect.Value, prefix string, redact bool, values map[string]string) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Tag.Get("env") == "" {
+			redactFields(value, prefix+field.Tag.Get("envPrefix"), redact, values)
+			continue
+		}
+
+		env := field.Tag.Get("env")
+		if env == "" {
+			continue
+		}
+		name := prefix + env
+		values[name] = redactValue(name, value, redact)
+	}
+}
+
+// redactValue formats the value like its environment variable, with secrets redacted if redact is set
+func redactValue(name string, value reflect.Value, redact bool) string {
+	secret := redact && isSecret(name)
+
+	switch value.Kind() {
+	case reflect.Map:
+		pairs := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			v := fmt.Sprint(value.MapIndex(key).Interface())
+			if secret {
+				v = redactedValue
+			}
+			pairs = append(pairs, fmt.Sprint(key.Interface())+"="+v)
+		}
+		slices.Sort(pairs)
+		return strings.Join(pairs, ",")
+	case reflect.Slice:
+		items := make([]string, value.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(value.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+
+	formatted := fmt.Sprint(value.Interface())
+	if secret && formatted != "" {
+		return redactedValue
+	}
+	if u, err := url.Parse(formatted); redact && err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return formatted
+}
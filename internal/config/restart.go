@@ -0,0 +1,62 @@
+package config
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// restartSettings are the settings fixing what the process listens on, stores its state in or keeps in
+// memory from startup. A reloaded configuration must leave them unchanged.
+var restartSettings = []string{
+	"MODE",
+	"HTTP_HOST",
+	"HTTP_PORT",
+	"HTTP_TLS_CERT_FILE",
+	"HTTP_TLS_KEY_FILE",
+	"HTTP_TLS_ACME_DOMAINS",
+	"HTTP_TLS_ACME_EMAIL",
+	"HTTP_TLS_ACME_DIRECTORY_URL",
+	"HTTP_TLS_ACME_CACHE_DIR",
+	"HTTP_TLS_REDIRECT_PORT",
+	"GRPC_HOST",
+	"GRPC_PORT",
+	"SEARCH_BACKEND",
+	"SEARCH_SQLITE_PATH",
+	"SEARCH_BLEVE_PATH",
+	"JOBS_STORE",
+	"JOBS_MEMORY_CAPACITY",
+	"DIAGNOSTICS_LOG_LINES",
+	"LOG_FILE",
+	"CLOCK_OFFSET",
+}
+
+// Changed returns the environment variables of the settings that differ in next, sorted by name.
+// Secrets are compared too, but only their names are returned.
+func (c *Config) Changed(next *Config) []string {
+	current, changed := make(map[string]string), make(map[string]string)
+	redactFields(reflect.ValueOf(*c), "", false, current)
+	redactFields(reflect.ValueOf(*next), "", false, changed)
+
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if current[name] != changed[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// RestartRequired returns the environment variables of the settings that differ in next and only take
+// effect after a restart, such as the listen addresses and the search backend
+func (c *Config) RestartRequired(next *Config) []string {
+	changed := c.Changed(next)
+
+	var names []string
+	for _, name := range restartSettings {
+		if slices.Contains(changed, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/secrets"
+)
+
+const (
+	// secretFileSuffix names the variable holding the path of a file to read a secret from, as
+	// MORESLEEP_PASSWORD_FILE for MORESLEEP_PASSWORD
+	secretFileSuffix = "_FILE"
+
+	// secretTimeout bounds reading the secrets of the configuration from their backends
+	secretTimeout = 30 * time.Second
+)
+
+// isSecret returns true if the environment variable holds a secret
+func isSecret(name string) bool {
+	return slices.ContainsFunc(secretNameParts, func(part string) bool { return strings.Contains(name, part) })
+}
+
+// secretSettings returns the names of the settings holding secrets
+func secretSettings() []string {
+	fields := make(map[string]reflect.StructField)
+	configFields(reflect.TypeOf(Config{}), "", fields)
+
+	var names []string
+	for name := range fields {
+		if isSecret(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// readSecretFiles sets the secrets named with the _FILE suffix to the contents of their files, as Docker
+// and Kubernetes mount secrets. A trailing newline is removed.
+func readSecretFiles(environment map[string]string) error {
+	for _, name := range secretSettings() {
+		path := environment[name+secretFileSuffix]
+		if path == "" {
+			continue
+		}
+		if environment[name] != "" {
+			return fmt.Errorf("both %s and %s%s are set", name, name, secretFileSuffix)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s%s: %w", name, secretFileSuffix, err)
+		}
+		environment[name] = strings.TrimRight(string(data), "\r\n")
+	}
+	return nil
+}
+
+// resolveSecrets replaces the secrets referring to a secrets backend, such as "vault:<path>#<key>",
+// with the secrets read from it using the VAULT_ and AWS_ settings of the configuration. It returns
+// true if any secret was replaced.
+func resolveSecrets(cfg *Config, environment map[string]string) (bool, error) {
+	var resolvers map[string]secrets.Resolver
+	ctx, cancel := context.WithTimeout(context.Background(), secretTimeout)
+	defer cancel()
+
+	for _, name := range secretSettings() {
+		scheme, reference, ok := secrets.ParseReference(environment[name])
+		if !ok {
+			continue
+		}
+		if resolvers == nil {
+			resolvers = cfg.secretResolvers()
+		}
+
+		value, err := resolvers[scheme].Resolve(ctx, reference)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		environment[name] = value
+	}
+	return resolvers != nil, nil
+}
+
+// secretResolvers returns the resolvers of the secrets backends by the schemes of their references
+func (c *Config) secretResolvers() map[string]secrets.Resolver {
+	httpClient := &http.Client{Timeout: secretTimeout}
+	return map[string]secrets.Resolver{
+		secrets.SchemeVault: secrets.NewVault(c.Vault.Addr, c.Vault.Token, httpClient),
+		secrets.SchemeAWS: secrets.NewAWSSecretsManager(c.AWS.Region, c.AWS.EndpointURL, secrets.AWSCredentials{
+			AccessKeyID:     c.AWS.AccessKeyID,
+			SecretAccessKey: c.AWS.SecretAccessKey,
+			SessionToken:    c.AWS.SessionToken,
+		}, httpClient),
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Role grants access to the admin UI. Each role includes the permissions of the roles below it.
+type Role string
+
+// Roles, from least to most privileged
+const (
+	// RoleViewer can view the dashboard and download reports
+	RoleViewer Role = "viewer"
+
+	// RoleOperator can also trigger reindexes
+	RoleOperator Role = "operator"
+
+	// RoleAdmin can also manage users and webhooks
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists all roles from least to most privileged
+var Roles = []Role{RoleViewer, RoleOperator, RoleAdmin}
+
+// IsValid returns true if the role is one of the known roles
+func (r Role) IsValid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// Allows returns true if the role includes the permissions of the required role
+func (r Role) Allows(required Role) bool {
+	return r.IsValid() && slices.Index(Roles, r) >= slices.Index(Roles, required)
+}
+
+// UserAccess is an entry in the admin allowlist
+type UserAccess struct {
+	Email     string    `json:"email"`
+	Role      Role      `json:"role"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+
+	// FromConfig marks bootstrap admins from ACCESS_ADMIN_EMAILS, which cannot be changed in the UI
+	FromConfig bool `json:"-"`
+}
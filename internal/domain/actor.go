@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ActorKind identifies what kind of principal performed an action
+type ActorKind string
+
+// Actor kinds
+const (
+	// ActorUser is a person logged in to the admin UI, named by email
+	ActorUser ActorKind = "user"
+
+	// ActorAPIKey is an API client, named by its API key
+	ActorAPIKey ActorKind = "api_key"
+
+	// ActorSystem is the indexer itself, such as the scheduler or an inbound webhook
+	ActorSystem ActorKind = "system"
+)
+
+// Actor is the principal an action is attributed to in logs, job records and audit entries
+type Actor struct {
+	Kind ActorKind `json:"kind"`
+	Name string    `json:"name"`
+}
+
+// Well-known system actors
+var (
+	// SchedulerActor performs scheduled reindexes
+	SchedulerActor = Actor{Kind: ActorSystem, Name: "scheduler"}
+
+	// WebhookActor performs work requested by verified inbound webhooks
+	WebhookActor = Actor{Kind: ActorSystem, Name: "webhook"}
+
+	// UnknownActor is reported when no actor was attached to the context
+	UnknownActor = Actor{Kind: ActorSystem, Name: "unknown"}
+)
+
+// String returns the actor as "kind:name"
+func (a Actor) String() string {
+	return string(a.Kind) + ":" + a.Name
+}
+
+// LogValue logs the actor as a group of kind and name
+func (a Actor) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("kind", string(a.Kind)), slog.String("name", a.Name))
+}
+
+// actorContextKey is the context key for the actor. The type is unexported so the actor can only be
+// attached through WithActor, which is called by the authenticating middleware and never from request input.
+type actorContextKey struct{}
+
+// WithActor returns a context attributing all work done with it to the actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor attached to the context, or UnknownActor if there is none
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorContextKey{}).(Actor); ok {
+		return actor
+	}
+	return UnknownActor
+}
@@ -0,0 +1,13 @@
+package domain
+
+// AnonymizedTalk is a talk stripped of speaker identity and private free-text fields,
+// suitable for sharing with researchers.
+type AnonymizedTalk struct {
+	// ID is a salted pseudonym of the talk ID, omitted when no salt is configured
+	ID             string                 `json:"id,omitempty"`
+	ConferenceSlug string                 `json:"conferenceSlug"`
+	Year           int                    `json:"year,omitempty"`
+	Status         string                 `json:"status"`
+	SpeakerCount   int                    `json:"speakerCount"`
+	Data           map[string]interface{} `json:"data"`
+}
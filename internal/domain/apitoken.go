@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAPITokenNotFound is returned when an API token does not exist or belongs to someone else
+var ErrAPITokenNotFound = errors.New("API token not found")
+
+// APIToken is an API token an admin created in the admin UI for their automation. Only the
+// SHA-256 digest of the token is stored; the token itself is shown once when created or rotated.
+type APIToken struct {
+	ID         string     `json:"id"`
+	Label      string     `json:"label"`
+	Owner      string     `json:"owner"`
+	Digest     string     `json:"digest"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RotatedAt  *time.Time `json:"rotatedAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// ActorName names the token's caller in the audit log, along with the admin who created it
+func (t APIToken) ActorName() string {
+	return t.Label + " (" + t.Owner + ")"
+}
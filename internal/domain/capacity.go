@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInsufficientCapacity is returned when a bulk job would fill the cluster's disks beyond the allowed usage
+var ErrInsufficientCapacity = errors.New("insufficient cluster disk capacity")
+
+// DiskUsage is the disk space of the cluster's data nodes
+type DiskUsage struct {
+	TotalBytes int64 `json:"totalBytes"`
+	UsedBytes  int64 `json:"usedBytes"`
+}
+
+// CapacityEstimate compares the disk space a bulk job is expected to need with the room left on the cluster
+type CapacityEstimate struct {
+	Documents       int   `json:"documents"`
+	AverageDocBytes int64 `json:"averageDocBytes"`
+
+	// RequiredBytes is the estimated size of the written documents, including replicas and overhead
+	RequiredBytes int64 `json:"requiredBytes"`
+
+	// ReclaimedBytes is the size of indexes the job deletes before writing
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+
+	Disk DiskUsage `json:"disk"`
+
+	// LimitBytes is the disk usage the job may bring the cluster up to
+	LimitBytes int64 `json:"limitBytes"`
+}
+
+// AvailableBytes returns how much the job may write before reaching the limit
+func (e CapacityEstimate) AvailableBytes() int64 {
+	return max(e.LimitBytes-e.Disk.UsedBytes+e.ReclaimedBytes, 0)
+}
+
+// Fits returns true if the estimated documents fit below the limit
+func (e CapacityEstimate) Fits() bool {
+	return e.RequiredBytes <= e.AvailableBytes()
+}
+
+// String describes the estimate for job reports and error messages
+func (e CapacityEstimate) String() string {
+	return fmt.Sprintf("%d documents need about %s, %s available below the disk limit",
+		e.Documents, FormatBytes(e.RequiredBytes), FormatBytes(e.AvailableBytes()))
+}
+
+// FormatBytes formats a byte count with a binary unit, such as "1.5 GiB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
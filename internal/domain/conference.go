@@ -1,8 +1,98 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ErrConferenceNotFound is returned when the source has no conference with the requested ID or slug
+var ErrConferenceNotFound = errors.New("conference not found")
+
+// ErrAmbiguousConference is returned when the source has several conferences with the requested slug
+var ErrAmbiguousConference = errors.New("several conferences have the slug")
+
+// ErrSourceIncomplete is returned when the talks of too many conferences could not be fetched for a
+// full reindex, which is then aborted so the indexes are not rebuilt without them
+var ErrSourceIncomplete = errors.New("too many conferences could not be fetched")
+
+// ErrConferenceMetadataNotFound is returned when no metadata is stored for a conference
+var ErrConferenceMetadataNotFound = errors.New("conference metadata not found")
+
+// conferenceDateLayout is the format of the dates in ConferenceMetadata
+const conferenceDateLayout = "2006-01-02"
+
 // Conference represents a conference where talks are submitted and presented.
 type Conference struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 	Slug string `json:"slug"`
 }
+
+// ConferenceSummary describes a conference as it appears in an index, with the number of indexed talks
+// and the metadata denormalized onto its talks.
+type ConferenceSummary struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	TalkCount int    `json:"talkCount"`
+	ConferenceMetadata
+}
+
+// ConferenceMetadata holds the details of a conference that moresleep does not provide.
+// Dates are formatted as YYYY-MM-DD; every field is optional.
+type ConferenceMetadata struct {
+	Venue     string `json:"venue,omitempty"`
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+	LogoURL   string `json:"logoUrl,omitempty"`
+	CFPOpens  string `json:"cfpOpens,omitempty"`
+	CFPCloses string `json:"cfpCloses,omitempty"`
+}
+
+// IsZero returns true if no field is set
+func (m ConferenceMetadata) IsZero() bool {
+	return m == ConferenceMetadata{}
+}
+
+// Validate checks the date formats, that ranges do not end before they start, and that the logo URL is absolute
+func (m ConferenceMetadata) Validate() error {
+	dates := make(map[string]time.Time)
+	for name, value := range map[string]string{
+		"startDate": m.StartDate, "endDate": m.EndDate, "cfpOpens": m.CFPOpens, "cfpCloses": m.CFPCloses,
+	} {
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(conferenceDateLayout, value)
+		if err != nil {
+			return fmt.Errorf("%s must be a date formatted as YYYY-MM-DD: %q", name, value)
+		}
+		dates[name] = date
+	}
+	if end, ok := dates["endDate"]; ok && end.Before(dates["startDate"]) {
+		return fmt.Errorf("endDate is before startDate")
+	}
+	if closes, ok := dates["cfpCloses"]; ok && closes.Before(dates["cfpOpens"]) {
+		return fmt.Errorf("cfpCloses is before cfpOpens")
+	}
+	if m.LogoURL != "" {
+		u, err := url.Parse(m.LogoURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("logoUrl must be an absolute http or https URL: %q", m.LogoURL)
+		}
+	}
+	return nil
+}
+
+// ConferenceEntry is the metadata configured for a conference
+type ConferenceEntry struct {
+	Slug string `json:"slug"`
+	ConferenceMetadata
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+
+	// FromFile marks metadata from CONFERENCE_METADATA_FILE that is not overridden in the settings store
+	FromFile bool `json:"-"`
+}
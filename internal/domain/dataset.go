@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrDatasetVersionUnavailable is returned when the changes since a dataset version cannot be listed,
+// because the version is older than the tracked changes or newer than the current version. The client
+// then resyncs the whole conference.
+var ErrDatasetVersionUnavailable = errors.New("changes since the dataset version are not available")
+
+// ErrDatasetRebuilding is returned when the public documents cannot be compared with the stored dataset
+// version because a full reindex is rebuilding the public index. The client retries later.
+var ErrDatasetRebuilding = errors.New("the public index is being rebuilt")
+
+// DatasetVersion identifies the state of the public documents of one conference, so clients such as
+// the mobile apps know when to resync their local copy of the program
+type DatasetVersion struct {
+	ConferenceSlug string `json:"conferenceSlug"`
+
+	// Version increases by one every time the public documents of the conference change
+	Version int64 `json:"version"`
+
+	// Hash is the SHA-256 digest of the public documents of the conference
+	Hash string `json:"hash"`
+
+	// Talks is the number of public documents of the conference
+	Talks int `json:"talks"`
+
+	// ChangedAt is when the change leading to this version was noticed
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// DatasetChanges lists how the public documents of a conference changed between two dataset versions,
+// so clients can sync incrementally. Added and updated talks are both complete documents to upsert.
+type DatasetChanges struct {
+	ConferenceSlug string `json:"conferenceSlug"`
+
+	// Since is the version the client had, and Version the current one to ask from next time
+	Since   int64 `json:"since"`
+	Version int64 `json:"version"`
+
+	Added   []Talk `json:"added"`
+	Updated []Talk `json:"updated"`
+
+	// Removed lists the IDs of the talks no longer public
+	Removed []string `json:"removed"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrDeadLetterNotFound is returned when no dead letter exists with the given ID
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a document that failed indexing even after retries, kept with the payload that was
+// sent so it can be inspected and retried. There is at most one dead letter per talk and index.
+type DeadLetter struct {
+	ID       string          `json:"id"`
+	Index    string          `json:"index"`
+	TalkID   string          `json:"talkId"`
+	Payload  json.RawMessage `json:"payload"`
+	Error    string          `json:"error"`
+	Failures int             `json:"failures"`
+	FailedAt time.Time       `json:"failedAt"`
+}
+
+// DeadLetterID returns the ID of the dead letter for a talk in an index
+func DeadLetterID(indexName, talkID string) string {
+	return indexName + ":" + talkID
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// DiagnosticsBundle collects the context operators attach to support tickets and issues.
+// Parts that could not be collected are listed in Errors instead of failing the bundle.
+type DiagnosticsBundle struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Config is the effective configuration as environment variables, with secrets redacted
+	Config map[string]string `json:"config"`
+
+	Health   []DependencyHealth `json:"health"`
+	Jobs     []Job              `json:"jobs"`
+	Mappings []MappingDiff      `json:"mappings"`
+
+	// Logs are the most recent log records as JSON lines, oldest first
+	Logs [][]byte `json:"-"`
+
+	Errors []string `json:"errors,omitempty"`
+}
+
+// MappingDiff compares the live mapping of an index with the mapping the indexer creates it with.
+// Fields are dotted paths, including multi-fields such as "title.keyword".
+type MappingDiff struct {
+	Index string `json:"index"`
+
+	// Missing lists the fields of the expected mapping absent from the live index
+	Missing []string `json:"missing,omitempty"`
+
+	// Unexpected lists the fields of the live index absent from the expected mapping, usually
+	// added by dynamic mapping
+	Unexpected []string `json:"unexpected,omitempty"`
+
+	// Changed lists the fields whose type differs, as "field: expected → live"
+	Changed []string `json:"changed,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// EventType identifies what happened
+type EventType string
+
+// Event types
+const (
+	// EventReindexCompleted is raised when a reindex job finishes, successfully or not
+	EventReindexCompleted EventType = "reindex.completed"
+
+	// EventTalkPublished is raised when a reindexed talk enters the public index, as its status became public
+	EventTalkPublished EventType = "talk.published"
+
+	// EventTalkDeleted is raised when a talk is deleted from the public index
+	EventTalkDeleted EventType = "talk.deleted"
+
+	// EventRepublishCompleted is raised when a republish has swapped the aliases to a new index generation
+	EventRepublishCompleted EventType = "republish.completed"
+)
+
+// EventTypes lists all event types external systems can subscribe to
+var EventTypes = []EventType{EventReindexCompleted, EventTalkPublished, EventTalkDeleted, EventRepublishCompleted}
+
+// Event is a notification about something the indexer did
+type Event struct {
+	Type       EventType              `json:"type"`
+	OccurredAt time.Time              `json:"occurredAt"`
+	Data       map[string]interface{} `json:"data"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+// FacetRequest selects the talks of the search facets. ConferenceSlug optionally limits them to one
+// conference, and KeywordLimit bounds the keyword facet, 0 for the default.
+type FacetRequest struct {
+	ConferenceSlug string
+	KeywordLimit   int
+}
+
+// FacetCount is the number of talks with a value of a facet
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets holds the number of public talks per value of the fields the talk search can be
+// filtered on by consumers such as the javazone.no frontend. Years are newest first, the other facets
+// most frequent first.
+type SearchFacets struct {
+	Total     int          `json:"total"`
+	Years     []FacetCount `json:"years"`
+	Formats   []FacetCount `json:"formats"`
+	Languages []FacetCount `json:"languages"`
+	Keywords  []FacetCount `json:"keywords"`
+}
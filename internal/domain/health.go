@@ -0,0 +1,16 @@
+package domain
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusError    = "error"
+)
+
+// DependencyHealth is the result of checking a single external dependency
+type DependencyHealth struct {
+	Name      string                 `json:"name"`
+	Status    string                 `json:"status"`
+	LatencyMS int64                  `json:"latencyMs"`
+	Error     string                 `json:"error,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
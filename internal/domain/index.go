@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IndexVersion identifies the state of an index for cache validation.
+// Generation changes whenever the index is recreated, Version whenever a document is written or deleted.
+type IndexVersion struct {
+	Index      string `json:"index"`
+	Generation string `json:"generation"`
+	Version    int64  `json:"version"`
+	DocCount   int64  `json:"docCount"`
+}
+
+// BulkResult summarizes the outcome of a bulk indexing request
+type BulkResult struct {
+	// Indexed is the number of documents written
+	Indexed int `json:"indexed"`
+
+	// Conflicts lists the IDs of documents skipped because the index already
+	// holds a newer version (e.g. an out-of-order webhook payload)
+	Conflicts []string `json:"conflicts,omitempty"`
+
+	// Failed lists the documents that could not be indexed, even after retries
+	Failed []FailedDocument `json:"-"`
+}
+
+// FailedDocument is a document that could not be indexed, with the payload that was sent
+type FailedDocument struct {
+	ID      string
+	Payload json.RawMessage
+	Error   string
+}
+
+// IndexNames holds the effective (prefixed) names of the private and public indexes
+type IndexNames struct {
+	Private string `json:"private"`
+	Public  string `json:"public"`
+}
+
+// IndexInfo describes an index in the cluster for the index lifecycle page
+type IndexInfo struct {
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	Health    string    `json:"health"`
+	CreatedAt time.Time `json:"createdAt"`
+	DocCount  int64     `json:"docCount"`
+	SizeBytes int64     `json:"sizeBytes"`
+
+	// InUse is true if the indexer reads or writes this index, directly or through an alias.
+	// Indexes in use cannot be deleted from the admin UI.
+	InUse bool `json:"inUse"`
+}
@@ -0,0 +1,128 @@
+package domain
+
+// IndexEvent is a typed event the indexer publishes on its internal event bus after changing an index,
+// or while a reindex runs.
+// Cross-cutting features such as CDN purging, webhooks and metrics subscribe to these events instead
+// of being called by the indexing logic.
+type IndexEvent interface {
+	// IndexEventName identifies the kind of event, such as "talk_indexed"
+	IndexEventName() string
+}
+
+// TalkIndexed is published when a single talk was written to an index, and for each talk a conference
+// or full reindex brought into the public index
+type TalkIndexed struct {
+	TalkID         string
+	ConferenceSlug string
+	Title          interface{}
+	IndexName      string
+
+	// Public is set when IndexName is the public index
+	Public bool
+
+	// Published is set when the talk was not in the public index before, so its status just became public
+	Published bool
+
+	// Bulk is set when the talk was written by a conference or full reindex, whose ConferenceReindexed
+	// event covers the conference
+	Bulk bool
+}
+
+// IndexEventName implements IndexEvent
+func (TalkIndexed) IndexEventName() string { return "talk_indexed" }
+
+// TalkDeleted is published when a talk was deleted from the indexes holding it
+type TalkDeleted struct {
+	TalkID         string
+	ConferenceSlug string
+	IndexNames     []string
+
+	// Public is set when the talk was deleted from the public index
+	Public bool
+}
+
+// IndexEventName implements IndexEvent
+func (TalkDeleted) IndexEventName() string { return "talk_deleted" }
+
+// ConferenceReindexed is published when the talks of one or all conferences were written to both indexes
+type ConferenceReindexed struct {
+	Slugs        []string
+	IndexNames   []string
+	PrivateTalks int
+	PublicTalks  int
+
+	// All is set for a full reindex of every conference
+	All bool
+}
+
+// IndexEventName implements IndexEvent
+func (ConferenceReindexed) IndexEventName() string { return "conference_reindexed" }
+
+// IndexSwapped is published when a republish pointed the aliases to a new index generation
+type IndexSwapped struct {
+	Generation string
+
+	// Indexes maps each alias to the index it now points to
+	Indexes      map[string]string
+	Slugs        []string
+	PrivateTalks int
+	PublicTalks  int
+	Actor        Actor
+}
+
+// IndexEventName implements IndexEvent
+func (IndexSwapped) IndexEventName() string { return "index_swapped" }
+
+// ReindexJobFinished is published when a reindex job finishes, successfully or not
+type ReindexJobFinished struct {
+	Job Job
+}
+
+// IndexEventName implements IndexEvent
+func (ReindexJobFinished) IndexEventName() string { return "reindex_job_finished" }
+
+// ReindexStage identifies what a ReindexProgress reports
+type ReindexStage string
+
+// Reindex stages
+const (
+	// ReindexStageStarted is reported when a reindex job starts
+	ReindexStageStarted ReindexStage = "started"
+
+	// ReindexStageFetched is reported after the talks of a conference were fetched, or failed to be
+	ReindexStageFetched ReindexStage = "fetched"
+
+	// ReindexStageIndexed is reported after talks were written to an index
+	ReindexStageIndexed ReindexStage = "indexed"
+
+	// ReindexStageFinished is reported when a reindex job finishes, successfully or not
+	ReindexStageFinished ReindexStage = "finished"
+)
+
+// ReindexProgress is published while a reindex runs, so it can be followed live from the dashboard
+type ReindexProgress struct {
+	Stage ReindexStage `json:"stage"`
+
+	// Scope is the job being run, set when it starts and finishes
+	Scope *JobScope `json:"scope,omitempty"`
+
+	// ConferenceSlug is the conference whose talks were fetched
+	ConferenceSlug string `json:"conferenceSlug,omitempty"`
+
+	// Fetched counts the conferences fetched so far out of the Conferences being reindexed
+	Fetched     int `json:"fetched,omitempty"`
+	Conferences int `json:"conferences,omitempty"`
+
+	// IndexName is the index talks were written to
+	IndexName string `json:"indexName,omitempty"`
+
+	// Talks is the number of talks fetched for the conference or written to the index
+	Talks int `json:"talks"`
+
+	// State is the outcome of a finished job
+	State JobState `json:"state,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+// IndexEventName implements IndexEvent
+func (ReindexProgress) IndexEventName() string { return "reindex_progress" }
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrJobRunning is returned when a reindex is started while another of the same scope is running
+var ErrJobRunning = errors.New("a reindex of the same scope is already running")
+
+// JobState is the lifecycle state of a job
+type JobState string
+
+// Job states
+const (
+	JobStateQueued    JobState = "queued"
+	JobStateRunning   JobState = "running"
+	JobStateSucceeded JobState = "succeeded"
+	JobStateFailed    JobState = "failed"
+)
+
+// IsDone returns true if the job has finished, successfully or not
+func (s JobState) IsDone() bool {
+	return s == JobStateSucceeded || s == JobStateFailed
+}
+
+// JobKind identifies what a job operates on
+type JobKind string
+
+// Job kinds
+const (
+	JobKindReindexAll          JobKind = "reindex-all"
+	JobKindReindexConference   JobKind = "reindex-conference"
+	JobKindReindexConferenceID JobKind = "reindex-conference-id"
+	JobKindReindexTalk         JobKind = "reindex-talk"
+	JobKindDeleteTalk          JobKind = "delete-talk"
+	JobKindVideoBackfill       JobKind = "video-backfill"
+	JobKindLinkCheck           JobKind = "link-check"
+	JobKindRepublish           JobKind = "republish"
+	JobKindWhatIf              JobKind = "what-if"
+	JobKindRetention           JobKind = "retention"
+)
+
+// JobScope describes what a job operates on; Target is the conference slug, conference ID or talk ID
+// for scoped kinds and empty otherwise
+type JobScope struct {
+	Kind   JobKind `json:"kind"`
+	Target string  `json:"target,omitempty"`
+}
+
+// JobReport summarizes what a job did
+type JobReport struct {
+	// Indexed is the number of documents written per index
+	Indexed map[string]int `json:"indexed,omitempty"`
+
+	// Removed is the number of documents deleted per index, such as talks deleted in the source
+	Removed map[string]int `json:"removed,omitempty"`
+
+	// Conflicts lists the IDs of documents skipped because the index held a newer version
+	Conflicts []string `json:"conflicts,omitempty"`
+
+	// Review lists talks with problems found during indexing, such as scrubbed text or malformed
+	// speakers, that should be fixed at the source
+	Review []ReviewFlag `json:"review,omitempty"`
+
+	// Steps lists the checkpoints passed by a job made of several steps, such as a republish
+	Steps []JobStep `json:"steps,omitempty"`
+}
+
+// JobStepState is the outcome of a job step
+type JobStepState string
+
+// Job step states
+const (
+	JobStepSucceeded JobStepState = "succeeded"
+	JobStepSkipped   JobStepState = "skipped"
+	JobStepFailed    JobStepState = "failed"
+)
+
+// JobStep is a checkpoint of a job with its outcome; a job stops at the first failed step
+type JobStep struct {
+	Name       string       `json:"name"`
+	State      JobStepState `json:"state"`
+	Detail     string       `json:"detail,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	FinishedAt time.Time    `json:"finishedAt"`
+}
+
+// ReviewFlag marks a talk for manual review, listing the problems found in it
+type ReviewFlag struct {
+	TalkID         string   `json:"talkId"`
+	ConferenceSlug string   `json:"conferenceSlug,omitempty"`
+	Findings       []string `json:"findings"`
+}
+
+// Job is a unit of indexing work shared by the API, web UI, scheduler and CLI
+type Job struct {
+	ID         string     `json:"id"`
+	Scope      JobScope   `json:"scope"`
+	Actor      Actor      `json:"actor"`
+	State      JobState   `json:"state"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Report     JobReport  `json:"report"`
+}
+
+// NewJob returns a queued job for the given scope. The ID is assigned by the job store.
+func NewJob(scope JobScope, now time.Time) Job {
+	return Job{
+		Scope:     scope,
+		State:     JobStateQueued,
+		CreatedAt: now,
+	}
+}
+
+// Start marks the job as running
+func (j *Job) Start(now time.Time) {
+	j.State = JobStateRunning
+	j.StartedAt = &now
+}
+
+// Finish marks the job as succeeded, or as failed with the error if err is non-nil
+func (j *Job) Finish(now time.Time, err error) {
+	j.FinishedAt = &now
+	if err != nil {
+		j.State = JobStateFailed
+		j.Error = err.Error()
+		return
+	}
+	j.State = JobStateSucceeded
+}
+
+// Duration returns how long the job ran, or has been running if it is not done
+func (j *Job) Duration(now time.Time) time.Duration {
+	if j.StartedAt == nil {
+		return 0
+	}
+	if j.FinishedAt != nil {
+		return j.FinishedAt.Sub(*j.StartedAt)
+	}
+	return now.Sub(*j.StartedAt)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// KeywordTrendRequest selects the keywords of a keyword trend analysis
+type KeywordTrendRequest struct {
+	// Keywords are the keywords to compare; when empty the most frequent keywords are used
+	Keywords []string
+
+	// Top is the number of most frequent keywords used when no keywords are given, 0 for the default
+	Top int
+}
+
+// KeywordYear holds the number of public talks of a conference year and how many of them carry each keyword
+type KeywordYear struct {
+	Year   int            `json:"year"`
+	Talks  int            `json:"talks"`
+	Counts map[string]int `json:"counts"`
+}
+
+// Share returns the share of the year's talks carrying the keyword, from 0 to 1
+func (y KeywordYear) Share(keyword string) float64 {
+	if y.Talks == 0 {
+		return 0
+	}
+	return float64(y.Counts[keyword]) / float64(y.Talks)
+}
+
+// KeywordTrendReport holds the frequency of keywords in the public talks of every conference year,
+// with keywords normalized so different spellings are counted together
+type KeywordTrendReport struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Index       string    `json:"index"`
+
+	// Keywords are the normalized keywords compared, in the requested order or most frequent first
+	Keywords []string      `json:"keywords"`
+	Years    []KeywordYear `json:"years"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// LinkField identifies where in a talk a link was found
+type LinkField string
+
+// Link fields
+const (
+	LinkFieldVideo          LinkField = "video"
+	LinkFieldSpeakerPicture LinkField = "speaker.pictureUrl"
+	LinkFieldAbstract       LinkField = "abstract"
+)
+
+// LinkStatus is the outcome of checking a link
+type LinkStatus string
+
+// Link statuses
+const (
+	// LinkOK means the link answered with a success or redirect status
+	LinkOK LinkStatus = "ok"
+
+	// LinkBroken means the target is gone: 404 or 410, or the host does not exist
+	LinkBroken LinkStatus = "broken"
+
+	// LinkUnreachable means the check failed in a way that may be temporary,
+	// such as a timeout, a server error or the request being refused
+	LinkUnreachable LinkStatus = "unreachable"
+)
+
+// LinkCheck is the outcome of checking one URL
+type LinkCheck struct {
+	Status     LinkStatus `json:"status"`
+	StatusCode int        `json:"statusCode,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// LinkProblem is a link in an indexed talk that did not check out
+type LinkProblem struct {
+	TalkID         string    `json:"talkId"`
+	ConferenceSlug string    `json:"conferenceSlug"`
+	Title          string    `json:"title"`
+	Field          LinkField `json:"field"`
+	SpeakerName    string    `json:"speakerName,omitempty"`
+	URL            string    `json:"url"`
+	LinkCheck
+	Cleared bool `json:"cleared,omitempty"`
+}
+
+// LinkReport is the outcome of a link check over the public index
+type LinkReport struct {
+	CheckedAt time.Time     `json:"checkedAt"`
+	Talks     int           `json:"talks"`
+	Links     int           `json:"links"`
+	Problems  []LinkProblem `json:"problems"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// UnmatchedRoute is the route requests are recorded under when no mux pattern matched them,
+// keeping the number of routes bounded
+const UnmatchedRoute = "unmatched"
+
+// RouteMetrics holds the recorded requests of one route, identified by its mux pattern
+type RouteMetrics struct {
+	Route string
+
+	// Requests counts requests per status class, such as "2xx" or "5xx"
+	Requests map[string]uint64
+
+	// LatencyBuckets are cumulative: each counts the requests completed within its upper bound
+	LatencyBuckets []LatencyBucket
+	LatencySum     time.Duration
+	Count          uint64
+
+	// SLO is set when a service level objective is configured for the route
+	SLO *SLOStatus
+}
+
+// LatencyBucket counts the requests completed within UpperBound
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+// SLOStatus is the configured objective of a route and how fast its error budget is burning
+type SLOStatus struct {
+	Objective float64
+	Latency   time.Duration
+	BurnRates []BurnRate
+}
+
+// BurnRate is the share of requests missing the objective within Window, relative to the error
+// budget: 1 spends exactly the budget over the SLO period, higher values exhaust it early
+type BurnRate struct {
+	Window time.Duration
+	Rate   float64
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// NoticeSeverity sets how prominently a notice is shown
+type NoticeSeverity string
+
+// Notice severities, from least to most urgent
+const (
+	NoticeInfo     NoticeSeverity = "info"
+	NoticeWarning  NoticeSeverity = "warning"
+	NoticeCritical NoticeSeverity = "critical"
+)
+
+// NoticeSeverities lists the severities an admin can choose between
+var NoticeSeverities = []NoticeSeverity{NoticeInfo, NoticeWarning, NoticeCritical}
+
+// MaxNoticeLength is the longest notice message, which must fit in a banner and an HTTP header
+const MaxNoticeLength = 300
+
+// Notice is a message admins show on every admin page and in a header on every API response,
+// such as an announcement of an ongoing migration. A notice without message is no notice.
+type Notice struct {
+	Message  string         `json:"message"`
+	Severity NoticeSeverity `json:"severity"`
+
+	// ExpiresAt hides the notice after the given time; notices without expiry stay until cleared
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+}
+
+// Active returns true if the notice has a message and has not expired at the given time
+func (n Notice) Active(now time.Time) bool {
+	return n.Message != "" && (n.ExpiresAt == nil || now.Before(*n.ExpiresAt))
+}
+
+// Validate checks that the message is a single line of limited length and the severity is known
+func (n Notice) Validate() error {
+	message := strings.TrimSpace(n.Message)
+	if message == "" {
+		return fmt.Errorf("notice message is required")
+	}
+	if len(message) > MaxNoticeLength {
+		return fmt.Errorf("notice message is longer than %d characters", MaxNoticeLength)
+	}
+	if strings.ContainsFunc(message, unicode.IsControl) {
+		return fmt.Errorf("notice message must be a single line")
+	}
+	if !slices.Contains(NoticeSeverities, n.Severity) {
+		return fmt.Errorf("unknown notice severity %q", n.Severity)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+// Theme values for the admin UI
+const (
+	ThemeSystem = "system"
+	ThemeLight  = "light"
+	ThemeDark   = "dark"
+)
+
+// Language values for the admin UI, as BCP 47 tags
+const (
+	LanguageEnglish   = "en"
+	LanguageNorwegian = "nb"
+)
+
+// Languages are the admin UI languages a user can choose between
+var Languages = []string{LanguageEnglish, LanguageNorwegian}
+
+// DefaultPageSize is the number of rows shown per page when a user has not chosen one
+const DefaultPageSize = 50
+
+// PageSizes are the page sizes a user can choose between
+var PageSizes = []int{25, 50, 100, 200}
+
+// UserPreferences holds per-user admin UI preferences
+type UserPreferences struct {
+	DefaultConference string `json:"defaultConference,omitempty"`
+	PageSize          int    `json:"pageSize"`
+	Theme             string `json:"theme"`
+	Language          string `json:"language"`
+}
+
+// DefaultUserPreferences returns the preferences used for users who have not saved any
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{
+		PageSize: DefaultPageSize,
+		Theme:    ThemeSystem,
+		Language: LanguageEnglish,
+	}
+}
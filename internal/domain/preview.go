@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidPreviewToken is returned when a preview token is malformed, has a bad signature or has expired
+var ErrInvalidPreviewToken = errors.New("invalid or expired preview token")
+
+// PreviewToken is a signed, expiring token letting the speakers of a talk see its public document
+// before publication without logging in
+type PreviewToken struct {
+	TalkID    string    `json:"talkId"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// TalkPreview shows a talk the way the public index has it and the way it will have it after the
+// next reindex, so speakers can check how their talk appears
+type TalkPreview struct {
+	TalkID string `json:"talkId"`
+
+	// Talk is the public document built from the current submission, including pending changes
+	Talk Talk `json:"talk"`
+
+	// Public is set when the talk has a public status, so Talk is published on the next reindex
+	Public bool `json:"public"`
+
+	// Published is the document in the public index now, or nil if the talk is not published
+	Published *Talk `json:"published,omitempty"`
+
+	// Changes lists the fields of Talk that differ from Published, such as "title" or "speakers"
+	Changes []string `json:"changes,omitempty"`
+
+	// ExpiresAt is when the token the preview was opened with expires
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidQuery is returned when an ad-hoc query uses a part of the query DSL that is not allowed
+// or exceeds the configured limits
+var ErrInvalidQuery = errors.New("invalid query")
+
+// QueryRequest is an ad-hoc query against the private index, using a restricted subset of the
+// Elasticsearch query DSL. Size defaults to 10 hits when omitted.
+type QueryRequest struct {
+	Query        map[string]interface{} `json:"query,omitempty"`
+	Aggregations map[string]interface{} `json:"aggs,omitempty"`
+	Sort         []interface{}          `json:"sort,omitempty"`
+	Source       []string               `json:"_source,omitempty"`
+	Size         *int                   `json:"size,omitempty"`
+	From         int                    `json:"from,omitempty"`
+}
+
+// QueryResult is the outcome of an ad-hoc query. TimedOut is set when the query hit the time limit
+// and the hits and aggregations only cover part of the index.
+type QueryResult struct {
+	Total        int               `json:"total"`
+	TookMillis   int               `json:"tookMs"`
+	TimedOut     bool              `json:"timedOut"`
+	Hits         []json.RawMessage `json:"hits"`
+	Aggregations json.RawMessage   `json:"aggregations,omitempty"`
+}
+
+// ErrIndexNotFound is returned when a request names an index the indexer does not write
+var ErrIndexNotFound = errors.New("index not found")
+
+// SampleRequest asks for random documents of the private or public index, for inspecting what is
+// indexed without access to Elasticsearch. ConferenceSlug optionally limits the sample to one conference.
+type SampleRequest struct {
+	IndexName      string
+	Size           int
+	ConferenceSlug string
+}
+
+// SearchRequest is a full-text search for talks, translated into an Elasticsearch query by the indexer
+// so consumers do not need access to Elasticsearch. Text is matched against the title, abstract,
+// keywords and speaker names; ConferenceSlug, Status and SeriesID optionally filter the hits.
+// Private searches the private index instead of the public one, where Reviewer limits the hits to the
+// talks assigned to a committee member, or with ReviewPending to those they have not given feedback on,
+// and Sort may rank the hits by their review score.
+type SearchRequest struct {
+	Text           string
+	ConferenceSlug string
+	Status         string
+	SeriesID       string
+	Reviewer       string
+	ReviewPending  bool
+	Private        bool
+	Sort           string
+	Size           int
+	From           int
+}
+
+// SearchSortReviewScore orders private search hits by their review score, highest first, with unscored
+// talks last and ties by relevance
+const SearchSortReviewScore = "reviewScore"
+
+// SuggestRequest asks for completions of what a visitor has typed into the public search, such as
+// talk titles, speaker names and keywords starting with Prefix. Size defaults to 10 when 0.
+type SuggestRequest struct {
+	Prefix string
+	Size   int
+}
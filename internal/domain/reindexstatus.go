@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// ReindexStatus is the last successful reindex of an index, recorded so every instance can tell how
+// fresh the index is, also after a restart
+type ReindexStatus struct {
+	Index      string    `json:"index"`
+	Scope      JobScope  `json:"scope"`
+	JobID      string    `json:"jobId,omitempty"`
+	Actor      Actor     `json:"actor"`
+	FinishedAt time.Time `json:"finishedAt"`
+
+	// Documents is the number of documents the reindex wrote to the index
+	Documents int `json:"documents"`
+
+	// Stale is set when the reindex is older than the configured maximum age; it is worked out when the
+	// status is read
+	Stale bool `json:"stale"`
+}
+
+// Age returns how long ago the reindex finished
+func (s ReindexStatus) Age(now time.Time) time.Duration {
+	return now.Sub(s.FinishedAt)
+}
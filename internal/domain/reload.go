@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRestartRequired is returned when a reloaded configuration changes settings that only take effect
+// after a restart, such as the listen addresses or the search backend
+var ErrRestartRequired = errors.New("settings changed that need a restart")
+
+// ConfigReload is the outcome of reloading the configuration without restarting the process
+type ConfigReload struct {
+	// Changed are the environment variables of the settings that changed, without their values
+	Changed    []string  `json:"changed"`
+	ReloadedAt time.Time `json:"reloadedAt"`
+}
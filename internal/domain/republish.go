@@ -0,0 +1,50 @@
+package domain
+
+// Republish steps, in the order they run
+const (
+	RepublishStepDiff      = "dry-run diff"
+	RepublishStepSnapshot  = "snapshot"
+	RepublishStepBuild     = "build generation"
+	RepublishStepReconcile = "reconciliation check"
+	RepublishStepSwap      = "alias swap"
+	RepublishStepWarmUp    = "warm-up"
+	RepublishStepNotify    = "notification"
+)
+
+// RepublishSteps lists the republish steps in the order they run
+var RepublishSteps = []string{
+	RepublishStepDiff,
+	RepublishStepSnapshot,
+	RepublishStepBuild,
+	RepublishStepReconcile,
+	RepublishStepSwap,
+	RepublishStepWarmUp,
+	RepublishStepNotify,
+}
+
+// ConferenceDiff is the number of public talks of a conference in the live index and after a republish
+type ConferenceDiff struct {
+	Slug   string `json:"slug"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// RepublishPlan is the outcome of the dry-run diff of a republish: what the public index would
+// look like if the talks were republished from moresleep now
+type RepublishPlan struct {
+	Conferences  []ConferenceDiff `json:"conferences"`
+	PrivateTalks int              `json:"privateTalks"`
+	PublicBefore int              `json:"publicBefore"`
+	PublicAfter  int              `json:"publicAfter"`
+}
+
+// Changed returns the conferences whose number of public talks would change
+func (p RepublishPlan) Changed() []ConferenceDiff {
+	var changed []ConferenceDiff
+	for _, diff := range p.Conferences {
+		if diff.Before != diff.After {
+			changed = append(changed, diff)
+		}
+	}
+	return changed
+}
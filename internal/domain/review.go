@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// ReviewAssignment holds the program committee members assigned to review a talk during the CFP
+// evaluation, and who gave feedback. Reviewers are identified by their email, lowercased.
+type ReviewAssignment struct {
+	// Reviewers are the committee members assigned to the talk
+	Reviewers []string `json:"reviewers,omitempty"`
+
+	// FeedbackBy are the committee members who gave feedback on the talk, assigned or not
+	FeedbackBy []string `json:"feedbackBy,omitempty"`
+
+	// Pending are the assigned reviewers who have not given feedback yet
+	Pending []string `json:"pending,omitempty"`
+
+	// Conflicts are the committee members with a conflict of interest, declared by a tag, registered in
+	// the admin UI or by being a speaker of the talk. They are not pending reviewers.
+	Conflicts []string `json:"conflicts,omitempty"`
+
+	// Score is the sum of the weights of the latest weighted feedback of each committee member, if any
+	// feedback type is weighted; higher scores rank first
+	Score *float64 `json:"score,omitempty"`
+}
+
+// ReviewQueue holds the talks assigned to a reviewer, limited to the first page of a long queue
+type ReviewQueue struct {
+	Reviewer string
+	Talks    []Talk
+	// Total is the number of assigned talks, which may exceed the talks listed
+	Total int
+}
+
+// TrackRanking holds the talks of one track of a conference in the order the program committee ranks
+// them during the program selection
+type TrackRanking struct {
+	// Track is the value of the track field of the talks, empty for talks without one
+	Track string
+	Talks []RankedTalk
+}
+
+// RankedTalk is a talk in the ranking of its track
+type RankedTalk struct {
+	// Rank is the position of the talk by review score, shared by talks with the same score; 0 for
+	// talks without a score, which are ranked last
+	Rank int
+	Talk Talk
+}
+
+// ReviewerConflicts are the conflicts of interest a program committee member registered in the admin UI.
+// Talks by one of the speakers, or by a speaker of one of the organizations, are flagged on reindex.
+type ReviewerConflicts struct {
+	// Reviewer is the email of the committee member, lowercased
+	Reviewer string `json:"reviewer"`
+
+	// SpeakerEmails are the emails of the speakers, lowercased
+	SpeakerEmails []string `json:"speakerEmails,omitempty"`
+
+	// Organizations match the organization of a speaker or the domain of their email, ignoring case
+	Organizations []string `json:"organizations,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
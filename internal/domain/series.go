@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrSeriesNotFound is returned when no series is stored with an ID
+var ErrSeriesNotFound = errors.New("series not found")
+
+// SeriesEntry is a series of linked sessions configured by admins, such as the parts of a workshop or
+// a group of lightning talks. The talks are listed in order, so their position gives their part number.
+type SeriesEntry struct {
+	ID        string    `json:"id"`
+	TalkIDs   []string  `json:"talkIds"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+
+	// FromFile marks series from SERIES_FILE that are not overridden in the settings store
+	FromFile bool `json:"-"`
+}
+
+// Validate checks that the series has an ID and links at least two distinct talks
+func (e SeriesEntry) Validate() error {
+	if strings.TrimSpace(e.ID) == "" {
+		return fmt.Errorf("series ID is required")
+	}
+	if len(e.TalkIDs) < 2 {
+		return fmt.Errorf("series %s must link at least two talks", e.ID)
+	}
+	for i, talkID := range e.TalkIDs {
+		if talkID == "" {
+			return fmt.Errorf("series %s has an empty talk ID", e.ID)
+		}
+		if slices.Contains(e.TalkIDs[:i], talkID) {
+			return fmt.Errorf("series %s lists talk %s more than once", e.ID, talkID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrIndexShrink is returned when a full reindex would replace the live public index with far fewer talks
+var ErrIndexShrink = errors.New("index would shrink more than allowed")
+
+// shrinkAllowedContextKey is the context key for overriding the shrink guard
+type shrinkAllowedContextKey struct{}
+
+// WithShrinkAllowed returns a context letting full reindexes replace the live public index even if it
+// shrinks by more than allowed. Adapters attach it only when the caller explicitly asks for it.
+func WithShrinkAllowed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, shrinkAllowedContextKey{}, true)
+}
+
+// ShrinkAllowed returns true if the context overrides the shrink guard
+func ShrinkAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(shrinkAllowedContextKey{}).(bool)
+	return allowed
+}
@@ -0,0 +1,20 @@
+package domain
+
+const (
+	// SignatureHeader carries the base64-encoded detached signature of a response body
+	SignatureHeader = "X-Content-Signature"
+
+	// SignatureKeyIDHeader identifies the key that produced SignatureHeader
+	SignatureKeyIDHeader = "X-Content-Signature-Key-Id"
+
+	// SigningAlgorithmEd25519 is the algorithm used for content signatures
+	SigningAlgorithmEd25519 = "ed25519"
+)
+
+// SigningKey is the public half of the content signing key, published so
+// downstream mirrors can verify exported snapshots
+type SigningKey struct {
+	KeyID     string `json:"keyId"`
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"publicKey"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// SitePreview is a public document checked against the fields the website needs to render it, and
+// rendered the way the website shows it
+type SitePreview struct {
+	TalkID string `json:"talkId"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+
+	// Talk is the public document the talk gets once it is published
+	Talk Talk `json:"talk"`
+
+	// Missing lists the required fields the document lacks, such as "abstract" or "speaker bio (Jane Doe)"
+	Missing []string `json:"missing,omitempty"`
+
+	// Rendered is set when the document was sent to the website's preview renderer
+	Rendered bool `json:"rendered"`
+
+	// HTML is the talk page returned by the website's preview renderer, empty when it is not configured
+	// or when previewing a whole conference
+	HTML string `json:"html,omitempty"`
+
+	// RenderError is why the website's preview renderer failed to render the document
+	RenderError string `json:"renderError,omitempty"`
+}
+
+// Ready returns true if the document has every required field and rendered without errors
+func (p SitePreview) Ready() bool {
+	return len(p.Missing) == 0 && p.RenderError == ""
+}
+
+// SitePreviewReport is the site preview of every talk of a conference that will be published
+type SitePreviewReport struct {
+	ConferenceSlug string        `json:"conferenceSlug"`
+	Talks          []SitePreview `json:"talks"`
+
+	// Rendered is set when the documents were rendered by the website's preview renderer
+	Rendered bool `json:"rendered"`
+
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// NotReady returns the number of talks missing fields or failing to render
+func (r SitePreviewReport) NotReady() int {
+	count := 0
+	for _, talk := range r.Talks {
+		if !talk.Ready() {
+			count++
+		}
+	}
+	return count
+}
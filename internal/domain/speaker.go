@@ -92,3 +92,13 @@ func looksLikeEmail(s string) bool {
 	atIndex := strings.Index(s, "@")
 	return atIndex > 0 && atIndex < len(s)-1 && strings.Contains(s[atIndex:], ".")
 }
+
+// SpeakerContact is a speaker of accepted talks with their contact email, as exported for the
+// speaker liaison team
+type SpeakerContact struct {
+	ConferenceSlug string   `json:"conferenceSlug"`
+	SpeakerID      string   `json:"speakerId"`
+	Name           string   `json:"name"`
+	Email          string   `json:"email"`
+	Talks          []string `json:"talks"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// SpeakerStatisticsRequest selects the talks whose speakers are aggregated
+type SpeakerStatisticsRequest struct {
+	// ConferenceSlug limits the aggregation to one conference, empty for all conferences
+	ConferenceSlug string
+
+	// Statuses limits the aggregation to talks with these statuses, empty for all statuses
+	Statuses []string
+}
+
+// SpeakerAttributeCounts holds the number of distinct speakers per value of one speaker attribute
+type SpeakerAttributeCounts struct {
+	Field  string         `json:"field"`
+	Values map[string]int `json:"values"`
+
+	// Other counts the speakers whose value is shared by fewer speakers than the minimum group size
+	Other int `json:"other"`
+
+	// Unknown counts the speakers without a value
+	Unknown int `json:"unknown"`
+}
+
+// SpeakerStatistics aggregates captured speaker attributes, such as residence, for program-balance
+// decisions. It is built from the private index for the program committee and never published.
+type SpeakerStatistics struct {
+	GeneratedAt    time.Time `json:"generatedAt"`
+	Index          string    `json:"index"`
+	ConferenceSlug string    `json:"conferenceSlug,omitempty"`
+	Statuses       []string  `json:"statuses,omitempty"`
+	Talks          int       `json:"talks"`
+
+	// Speakers counts distinct speakers, so a speaker of several talks counts once
+	Speakers     int                      `json:"speakers"`
+	MinGroupSize int                      `json:"minGroupSize"`
+	Attributes   []SpeakerAttributeCounts `json:"attributes"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// ConferenceStatistics holds aggregated submission statistics for a single conference.
+type ConferenceStatistics struct {
+	ConferenceSlug string `json:"conferenceSlug"`
+	ConferenceName string `json:"conferenceName"`
+	Year           int    `json:"year,omitempty"`
+	Total          int    `json:"total"`
+
+	// AcceptanceRate is the share of non-draft submissions that were approved
+	AcceptanceRate float64 `json:"acceptanceRate"`
+
+	ByStatus map[string]int `json:"byStatus"`
+	ByFormat map[string]int `json:"byFormat"`
+
+	// ByGender is only populated when speakers have a captured gender field
+	ByGender map[string]int `json:"byGender,omitempty"`
+
+	Keywords map[string]int `json:"keywords"`
+}
+
+// StatisticsReport is the aggregated statistics export used for the annual report.
+type StatisticsReport struct {
+	GeneratedAt time.Time              `json:"generatedAt"`
+	Index       string                 `json:"index"`
+	Conferences []ConferenceStatistics `json:"conferences"`
+}
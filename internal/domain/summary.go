@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// IndexingSummary describes the indexing runs of a period, as emailed to the program committee leads
+type IndexingSummary struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Succeeded is the number of jobs finished successfully in the period
+	Succeeded int `json:"succeeded"`
+
+	// Indexed is the number of documents written per index by the jobs of the period
+	Indexed map[string]int `json:"indexed"`
+
+	// Failures lists the jobs that failed in the period, oldest first
+	Failures []Job `json:"failures"`
+
+	// Approvals lists the talks approved in the period per tracked conference
+	Approvals []ConferenceApprovals `json:"approvals"`
+}
+
+// ConferenceApprovals is the number of approved talks of a conference at the end of a period, and how
+// many of them were approved during it
+type ConferenceApprovals struct {
+	ConferenceSlug string `json:"conferenceSlug"`
+	ConferenceName string `json:"conferenceName"`
+	Approved       int    `json:"approved"`
+	New            int    `json:"new"`
+}
@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrTalkNotFound is returned when a talk is in neither index
+var ErrTalkNotFound = errors.New("talk not found")
 
 // Talk represents a conference talk submission with all fields needed for indexing.
 // Data fields are stored dynamically to accommodate varying fields across conferences.
@@ -14,6 +20,21 @@ type Talk struct {
 	Created        *time.Time `json:"created,omitempty"`
 	LastUpdated    *time.Time `json:"lastUpdated,omitempty"`
 
+	// Conference holds the conference metadata denormalized onto the talk, if any is configured
+	Conference *ConferenceMetadata `json:"conference,omitempty"`
+
+	// Workshop holds the capacity and registrations of a workshop, if the registration system has it
+	Workshop *WorkshopSeats `json:"workshop,omitempty"`
+
+	// SeriesID links related sessions, such as the parts of a workshop, which all have the same series ID
+	SeriesID string `json:"seriesId,omitempty"`
+
+	// SeriesPart is the position of the talk in its series, starting at 1, if the series is ordered
+	SeriesPart int `json:"seriesPart,omitempty"`
+
+	// Review holds the committee review assignments of the talk (only indexed to private index)
+	Review *ReviewAssignment `json:"review,omitempty"`
+
 	// Data contains all public data fields from the talk submission
 	Data map[string]interface{} `json:"data,omitempty"`
 
@@ -32,8 +53,12 @@ func (t Talk) ToPublic() Talk {
 		Speakers:       t.Speakers.ToPublic(),
 		Created:        t.Created,
 		LastUpdated:    t.LastUpdated,
+		Conference:     t.Conference,
+		Workshop:       t.Workshop,
+		SeriesID:       t.SeriesID,
+		SeriesPart:     t.SeriesPart,
 		Data:           filterEmailFields(t.Data),
-		// PrivateData intentionally omitted
+		// Review and PrivateData intentionally omitted
 	}
 }
 
@@ -57,7 +82,29 @@ func (t Talk) ToPrivate() Talk {
 		Speakers:       t.Speakers.ToPrivate(),
 		Created:        t.Created,
 		LastUpdated:    t.LastUpdated,
+		Conference:     t.Conference,
+		Workshop:       t.Workshop,
+		SeriesID:       t.SeriesID,
+		SeriesPart:     t.SeriesPart,
+		Review:         t.Review,
 		Data:           mergedData,
 		// PrivateData intentionally omitted - merged into Data
 	}
 }
+
+// TalkLookupRequest resolves a human-friendly identifier of a talk to its ID: the talk slug, or words of
+// the title. ConferenceSlug optionally limits the lookup to one conference.
+type TalkLookupRequest struct {
+	ConferenceSlug string
+	Title          string
+	Slug           string
+}
+
+// TalkMatch is a talk found by a lookup, with enough detail to tell candidates apart
+type TalkMatch struct {
+	ID             string `json:"id"`
+	ConferenceSlug string `json:"conferenceSlug"`
+	Status         string `json:"status"`
+	Title          string `json:"title"`
+	Slug           string `json:"slug,omitempty"`
+}
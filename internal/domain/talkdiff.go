@@ -0,0 +1,50 @@
+package domain
+
+// TalkDiff compares a talk in moresleep with its documents in the private and public indexes, so
+// committee members can see why the website shows something other than what was submitted, such as
+// an old abstract after a missed webhook or a failed reindex
+type TalkDiff struct {
+	TalkID         string `json:"talkId"`
+	Title          string `json:"title"`
+	ConferenceSlug string `json:"conferenceSlug"`
+	Status         string `json:"status"`
+
+	Private IndexDiff `json:"private"`
+	Public  IndexDiff `json:"public"`
+}
+
+// IndexDiff compares the document the next reindex writes to an index with the document the index holds
+type IndexDiff struct {
+	IndexName string `json:"indexName"`
+
+	// Expected is set when the next reindex writes the talk to the index; talks without a public status
+	// and talks past their retention period are not written to the public index
+	Expected bool `json:"expected"`
+
+	// Found is set when the index holds a document of the talk
+	Found bool `json:"found"`
+
+	// Fields lists the fields that differ between the documents, sorted by field
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// InSync reports whether the index holds the document the next reindex would write, or no document
+// if none would be written
+func (d IndexDiff) InSync() bool {
+	return d.Expected == d.Found && len(d.Fields) == 0
+}
+
+// FieldDiff is a field whose value in the document built from moresleep differs from the indexed one.
+// Field is the path of the field, such as "data.abstract" or "speakers[0].name", and the values are
+// strings as is and JSON otherwise.
+type FieldDiff struct {
+	Field string `json:"field"`
+
+	// Source is the value the next reindex writes, if InSource is set
+	Source   string `json:"source,omitempty"`
+	InSource bool   `json:"inSource"`
+
+	// Indexed is the value in the index, if InIndex is set
+	Indexed string `json:"indexed,omitempty"`
+	InIndex bool   `json:"inIndex"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+// TalkCountSnapshot holds the number of talks per status of a conference on one day
+type TalkCountSnapshot struct {
+	// Date is formatted as YYYY-MM-DD
+	Date     string         `json:"date"`
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"byStatus"`
+}
+
+// TalkCountTrend holds the daily talk counts of a conference, oldest first
+type TalkCountTrend struct {
+	ConferenceSlug string              `json:"conferenceSlug"`
+	ConferenceName string              `json:"conferenceName"`
+	Snapshots      []TalkCountSnapshot `json:"snapshots"`
+}
+
+// Latest returns the most recent snapshot, or a zero snapshot if there is none
+func (t TalkCountTrend) Latest() TalkCountSnapshot {
+	if len(t.Snapshots) == 0 {
+		return TalkCountSnapshot{}
+	}
+	return t.Snapshots[len(t.Snapshots)-1]
+}
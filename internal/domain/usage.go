@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// UsageAnonymousClient names the API clients calling without a valid API token
+const UsageAnonymousClient = "anonymous"
+
+// UsageBucket counts the requests of one API client to one route within an hour
+type UsageBucket struct {
+	// Hour is the start of the hour, in UTC
+	Hour   time.Time `json:"hour"`
+	Route  string    `json:"route"`
+	Client string    `json:"client"`
+
+	Requests uint64 `json:"requests"`
+	// Errors are the requests that failed with a 5xx status
+	Errors     uint64        `json:"errors"`
+	LatencySum time.Duration `json:"latencySum"`
+}
+
+// UsageReport summarizes the recorded API usage of the last days
+type UsageReport struct {
+	Since time.Time
+
+	// Days are the requests per day, oldest first, including days without requests
+	Days []UsageDay
+
+	// ByRoute, ByClient and ByClientRoute total the requests per route, per client and per client and
+	// route, busiest first
+	ByRoute       []UsageTotal
+	ByClient      []UsageTotal
+	ByClientRoute []UsageTotal
+}
+
+// UsageDay counts the requests of one day
+type UsageDay struct {
+	Date     string
+	Requests uint64
+	Errors   uint64
+	// PeakHourRequests are the requests of the busiest hour of the day
+	PeakHourRequests uint64
+}
+
+// UsageTotal totals the requests of a route, a client or a client to a route; the other is empty
+type UsageTotal struct {
+	Route      string
+	Client     string
+	Requests   uint64
+	Errors     uint64
+	LatencySum time.Duration
+}
+
+// AverageLatency returns the mean latency of the requests
+func (t UsageTotal) AverageLatency() time.Duration {
+	if t.Requests == 0 {
+		return 0
+	}
+	return t.LatencySum / time.Duration(t.Requests)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrProposalNotFound is returned when a video proposal does not exist
+var ErrProposalNotFound = errors.New("video proposal not found")
+
+// Video is a recording published on the conference video channel
+type Video struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"publishedAt,omitempty"`
+}
+
+// MissingVideoTalk is a published talk from a past conference without a video link
+type MissingVideoTalk struct {
+	TalkID         string     `json:"talkId"`
+	ConferenceSlug string     `json:"conferenceSlug"`
+	ConferenceName string     `json:"conferenceName"`
+	Title          string     `json:"title"`
+	Speakers       []string   `json:"speakers"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+}
+
+// ProposalStatus is the review state of a video proposal
+type ProposalStatus string
+
+// Proposal statuses
+const (
+	ProposalPending  ProposalStatus = "pending"
+	ProposalAccepted ProposalStatus = "accepted"
+	ProposalRejected ProposalStatus = "rejected"
+)
+
+// VideoProposal is a video matched to a talk, awaiting confirmation before the talk is patched
+type VideoProposal struct {
+	TalkID         string         `json:"talkId"`
+	ConferenceSlug string         `json:"conferenceSlug"`
+	TalkTitle      string         `json:"talkTitle"`
+	Speakers       []string       `json:"speakers"`
+	VideoURL       string         `json:"videoUrl"`
+	VideoTitle     string         `json:"videoTitle"`
+	Score          float64        `json:"score"`
+	Status         ProposalStatus `json:"status"`
+	ProposedAt     time.Time      `json:"proposedAt"`
+	DecidedBy      string         `json:"decidedBy,omitempty"`
+	DecidedAt      *time.Time     `json:"decidedAt,omitempty"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrSubscriptionNotFound is returned when a webhook subscription does not exist
+var ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+// WebhookSubscription is an external URL notified about events.
+// Payloads are signed with the subscription's secret.
+type WebhookSubscription struct {
+	ID          string      `json:"id"`
+	URL         string      `json:"url"`
+	Events      []EventType `json:"events"`
+	Secret      string      `json:"secret"`
+	Description string      `json:"description,omitempty"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+// Matches returns true if the subscription wants events of the given type
+func (s WebhookSubscription) Matches(eventType EventType) bool {
+	return slices.Contains(s.Events, eventType)
+}
+
+// DeliveryStatus is the state of a webhook delivery
+type DeliveryStatus string
+
+// Delivery statuses
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// WebhookDelivery records the attempts to deliver one event to one subscription
+type WebhookDelivery struct {
+	ID             string         `json:"id"`
+	SubscriptionID string         `json:"subscriptionId"`
+	URL            string         `json:"url"`
+	EventType      EventType      `json:"eventType"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	LastStatusCode int            `json:"lastStatusCode,omitempty"`
+	LastError      string         `json:"lastError,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+// WhatIfAnalyzers lists the built-in Elasticsearch analyzers a what-if build may use as default analyzer.
+// An empty analyzer keeps the analyzer of the live mapping.
+var WhatIfAnalyzers = []string{"standard", "simple", "whitespace", "english", "norwegian"}
+
+// WhatIfSettings is an alternative transformation config to evaluate against the talks of a conference,
+// mirroring the TRANSFORM_* settings plus the default analyzer of the index mappings
+type WhatIfSettings struct {
+	AbstractHTML       bool     `json:"abstractHtml"`
+	ScrubPublic        bool     `json:"scrubPublic"`
+	ScrubFields        []string `json:"scrubFields,omitempty"`
+	ScrubSpeakerFields []string `json:"scrubSpeakerFields,omitempty"`
+	ScrubWords         []string `json:"scrubWords,omitempty"`
+	Analyzer           string   `json:"analyzer,omitempty"`
+}
+
+// WhatIfResult is the outcome of building scratch indexes for a conference with alternative settings.
+// The talks flagged for review under the alternative settings are in the job report.
+type WhatIfResult struct {
+	Job          Job    `json:"job"`
+	PrivateIndex string `json:"privateIndex"`
+	PublicIndex  string `json:"publicIndex"`
+	PrivateTalks int    `json:"privateTalks"`
+	PublicTalks  int    `json:"publicTalks"`
+
+	// Changed lists the public talks whose document differs from the live public index,
+	// including talks missing from either
+	Changed []string `json:"changed,omitempty"`
+}
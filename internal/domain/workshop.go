@@ -0,0 +1,26 @@
+package domain
+
+// WorkshopRegistration is the capacity and number of registered participants of a workshop, as
+// reported by the registration system
+type WorkshopRegistration struct {
+	TalkID     string `json:"talkId"`
+	Capacity   int    `json:"capacity"`
+	Registered int    `json:"registered"`
+}
+
+// Seats returns the registration state indexed on the workshop's talk
+func (r WorkshopRegistration) Seats() WorkshopSeats {
+	return WorkshopSeats{
+		Capacity:   r.Capacity,
+		Registered: r.Registered,
+		SpotsLeft:  max(r.Capacity-r.Registered, 0),
+	}
+}
+
+// WorkshopSeats is the registration state denormalized onto an indexed workshop, so the program app
+// can show how many spots are left without asking the registration system
+type WorkshopSeats struct {
+	Capacity   int `json:"capacity"`
+	Registered int `json:"registered"`
+	SpotsLeft  int `json:"spotsLeft"`
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// File is a log file that can be reopened at the same path, so that logs are written to a new file
+// once logrotate or an operator moved the current one away
+type File struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFile opens the log file at the path for appending, creating it if it does not exist
+func OpenFile(path string) (*File, error) {
+	f := &File{path: path}
+	if err := f.Reopen(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends the bytes to the current file
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Write(p)
+}
+
+// Reopen closes the current file and opens the path again. If the path cannot be opened, logs keep
+// going to the current file.
+func (f *File) Reopen() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+	f.file = file
+	return nil
+}
+
+// Close closes the current file
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "indexer.log")
+
+	file, err := OpenFile(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = io.WriteString(file, "before rotation\n")
+	require.NoError(t, err)
+
+	// logrotate moves the file away and signals the process to reopen the path
+	rotated := filepath.Join(dir, "indexer.log.1")
+	require.NoError(t, os.Rename(path, rotated))
+	_, err = io.WriteString(file, "still to the moved file\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Reopen())
+	_, err = io.WriteString(file, "after rotation\n")
+	require.NoError(t, err)
+
+	old, err := os.ReadFile(rotated)
+	require.NoError(t, err)
+	assert.Equal(t, "before rotation\nstill to the moved file\n", string(old))
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "after rotation\n", string(current))
+}
+
+func TestFile_ReopenFailureKeepsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "indexer.log")
+	require.NoError(t, os.Mkdir(filepath.Dir(path), 0o755))
+
+	file, err := OpenFile(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	require.NoError(t, os.RemoveAll(filepath.Dir(path)))
+	require.NoError(t, os.WriteFile(filepath.Dir(path), nil, 0o644))
+	assert.Error(t, file.Reopen())
+
+	_, err = io.WriteString(file, "kept\n")
+	assert.NoError(t, err, "logs keep going to the open file")
+}
@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// ActorHandler is a slog.Handler that adds the actor attached to the context to every record,
+// so log lines written with the *Context logging functions are attributed to whoever caused them.
+type ActorHandler struct {
+	next slog.Handler
+}
+
+// NewActorHandler wraps the handler with actor attribution
+func NewActorHandler(next slog.Handler) *ActorHandler {
+	return &ActorHandler{next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level
+func (h *ActorHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the actor to the record, if the context carries one, and passes it on
+func (h *ActorHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if actor := domain.ActorFromContext(ctx); actor != domain.UnknownActor {
+			record = record.Clone()
+			record.AddAttrs(slog.Any("actor", actor))
+		}
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler with the attributes added to the wrapped handler
+func (h *ActorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ActorHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler with the group added to the wrapped handler
+func (h *ActorHandler) WithGroup(name string) slog.Handler {
+	return &ActorHandler{next: h.next.WithGroup(name)}
+}
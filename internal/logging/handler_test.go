@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActorHandler_AddsActor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewActorHandler(slog.NewJSONHandler(&buf, nil))).With("component", "indexer")
+
+	ctx := domain.WithActor(context.Background(), domain.Actor{Kind: domain.ActorUser, Name: "admin@java.no"})
+	logger.InfoContext(ctx, "reindex started")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "indexer", record["component"])
+	assert.Equal(t, map[string]interface{}{"kind": "user", "name": "admin@java.no"}, record["actor"])
+}
+
+func TestActorHandler_WithoutActor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewActorHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.InfoContext(context.Background(), "started")
+	logger.Info("started without context")
+
+	assert.NotContains(t, buf.String(), "actor")
+}
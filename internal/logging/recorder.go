@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// Recorder is a slog.Handler that keeps the most recent log records as JSON lines in memory, besides
+// passing them on, so they can be included in a diagnostics bundle without access to the log pipeline
+type Recorder struct {
+	next    slog.Handler
+	encoder slog.Handler
+	lines   *lineBuffer
+}
+
+// NewRecorder wraps the handler, keeping the last size records it handles
+func NewRecorder(next slog.Handler, size int) *Recorder {
+	lines := &lineBuffer{size: size}
+	return &Recorder{
+		next:    next,
+		encoder: slog.NewJSONHandler(lines, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		lines:   lines,
+	}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level
+func (r *Recorder) Enabled(ctx context.Context, level slog.Level) bool {
+	return r.next.Enabled(ctx, level)
+}
+
+// Handle records the record and passes it on
+func (r *Recorder) Handle(ctx context.Context, record slog.Record) error {
+	if r.lines.size > 0 {
+		_ = r.encoder.Handle(ctx, record)
+	}
+	return r.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler with the attributes added to both the record and the wrapped handler
+func (r *Recorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Recorder{next: r.next.WithAttrs(attrs), encoder: r.encoder.WithAttrs(attrs), lines: r.lines}
+}
+
+// WithGroup returns a handler with the group added to both the record and the wrapped handler
+func (r *Recorder) WithGroup(name string) slog.Handler {
+	return &Recorder{next: r.next.WithGroup(name), encoder: r.encoder.WithGroup(name), lines: r.lines}
+}
+
+// RecentLogs returns the recorded records as JSON lines, oldest first
+func (r *Recorder) RecentLogs() [][]byte {
+	return r.lines.snapshot()
+}
+
+// lineBuffer keeps the last size lines written to it; the JSON handler writes one line per record
+type lineBuffer struct {
+	mu    sync.Mutex
+	size  int
+	lines [][]byte
+	next  int
+}
+
+func (b *lineBuffer) Write(p []byte) (int, error) {
+	line := bytes.Clone(bytes.TrimRight(p, "\n"))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.lines) < b.size {
+		b.lines = append(b.lines, line)
+	} else {
+		b.lines[b.next] = line
+		b.next = (b.next + 1) % b.size
+	}
+	return len(p), nil
+}
+
+func (b *lineBuffer) snapshot() [][]byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append(slices.Clone(b.lines[b.next:]), b.lines[:b.next]...)
+}
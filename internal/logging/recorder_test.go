@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_KeepsMostRecentRecords(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewRecorder(slog.NewJSONHandler(&buf, nil), 2)
+	logger := slog.New(recorder).With("component", "indexer")
+
+	logger.Info("first")
+	logger.Info("second")
+	logger.WithGroup("job").Info("third", "id", "job-1")
+
+	logs := recorder.RecentLogs()
+	require.Len(t, logs, 2)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs[0], &record))
+	assert.Equal(t, "second", record["msg"])
+	assert.Equal(t, "indexer", record["component"])
+
+	require.NoError(t, json.Unmarshal(logs[1], &record))
+	assert.Equal(t, "third", record["msg"])
+	assert.Equal(t, map[string]interface{}{"id": "job-1"}, record["job"])
+
+	// Every record is still passed on
+	assert.Equal(t, 3, bytes.Count(buf.Bytes(), []byte("\n")))
+}
+
+func TestRecorder_RecordsOnlyEnabledLevels(t *testing.T) {
+	recorder := NewRecorder(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo}), 10)
+	logger := slog.New(recorder)
+
+	logger.Debug("hidden")
+	logger.InfoContext(context.Background(), "shown")
+
+	logs := recorder.RecentLogs()
+	require.Len(t, logs, 1)
+	assert.Contains(t, string(logs[0]), "shown")
+}
+
+func TestRecorder_Disabled(t *testing.T) {
+	recorder := NewRecorder(slog.NewJSONHandler(&bytes.Buffer{}, nil), 0)
+
+	slog.New(recorder).Info("not kept")
+
+	assert.Empty(t, recorder.RecentLogs())
+}
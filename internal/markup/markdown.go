@@ -0,0 +1,143 @@
+// Package markup renders the markdown-ish free text of talks to HTML that is safe to embed.
+package markup
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	bulletPattern      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	orderedPattern     = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	quotePattern       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	rulePattern        = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	codeSpanPattern    = regexp.MustCompile("`([^`]+)`")
+	linkPattern        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	autolinkPattern    = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+	strongPattern      = regexp.MustCompile(`\*\*([^*]+?)\*\*|__([^_]+?)__`)
+	emphasisPattern    = regexp.MustCompile(`\*([^*\s][^*]*?)\*`)
+	placeholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// RenderMarkdown renders a subset of markdown to HTML: paragraphs with line breaks, headings, bullet
+// and numbered lists, block quotes, fenced code, rules, emphasis, code spans and links. All text is
+// escaped and only http, https and mailto links are rendered, but the result should still be passed
+// through Sanitize before it is published.
+func RenderMarkdown(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var out strings.Builder
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case headingPattern.MatchString(trimmed):
+			m := headingPattern.FindStringSubmatch(trimmed)
+			fmt.Fprintf(&out, "<h%d>%s</h%d>\n", len(m[1]), renderInline(m[2]), len(m[1]))
+			i++
+
+		case rulePattern.MatchString(line):
+			out.WriteString("<hr>\n")
+			i++
+
+		case bulletPattern.MatchString(line):
+			i = renderList(&out, lines, i, "ul", bulletPattern)
+
+		case orderedPattern.MatchString(line):
+			i = renderList(&out, lines, i, "ol", orderedPattern)
+
+		case quotePattern.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			out.WriteString("<blockquote>\n" + RenderMarkdown(strings.Join(quoted, "\n")) + "</blockquote>\n")
+
+		default:
+			var paragraph []string
+			for ; i < len(lines) && !startsBlock(lines[i]); i++ {
+				paragraph = append(paragraph, renderInline(strings.TrimSpace(lines[i])))
+			}
+			out.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+		}
+	}
+	return out.String()
+}
+
+// startsBlock returns true if the line ends a paragraph
+func startsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" ||
+		strings.HasPrefix(trimmed, "```") ||
+		headingPattern.MatchString(trimmed) ||
+		rulePattern.MatchString(line) ||
+		bulletPattern.MatchString(line) ||
+		orderedPattern.MatchString(line) ||
+		quotePattern.MatchString(line)
+}
+
+// renderList renders consecutive list items matching the pattern, returning the index of the next line
+func renderList(out *strings.Builder, lines []string, i int, tag string, item *regexp.Regexp) int {
+	out.WriteString("<" + tag + ">\n")
+	for ; i < len(lines) && item.MatchString(lines[i]); i++ {
+		out.WriteString("<li>" + renderInline(strings.TrimSpace(item.FindStringSubmatch(lines[i])[1])) + "</li>\n")
+	}
+	out.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// renderInline renders code spans, links and emphasis in a line, escaping everything else.
+// Code spans and links are replaced by placeholders first so their content is not formatted.
+func renderInline(text string) string {
+	var rendered []string
+	hold := func(s string) string {
+		rendered = append(rendered, s)
+		return fmt.Sprintf("\x00%d\x00", len(rendered)-1)
+	}
+
+	text = strings.ReplaceAll(text, "\x00", "")
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return hold("<code>" + html.EscapeString(codeSpanPattern.FindStringSubmatch(m)[1]) + "</code>")
+	})
+	text = linkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := linkPattern.FindStringSubmatch(m)
+		return hold(renderLink(parts[2], html.EscapeString(parts[1])))
+	})
+	text = autolinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		url := strings.TrimRight(m, ".,;:!?")
+		return hold(renderLink(url, html.EscapeString(url))) + m[len(url):]
+	})
+
+	text = html.EscapeString(text)
+	text = strongPattern.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = emphasisPattern.ReplaceAllString(text, "<em>$1</em>")
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+		index, _ := strconv.Atoi(placeholderPattern.FindStringSubmatch(m)[1])
+		return rendered[index]
+	})
+}
+
+// renderLink renders a link with already escaped content, or only the content if the URL is not safe
+func renderLink(url, content string) string {
+	if !safeURL(url) {
+		return content
+	}
+	return `<a href="` + html.EscapeString(url) + `">` + content + "</a>"
+}
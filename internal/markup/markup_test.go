@@ -0,0 +1,167 @@
+package markup
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "paragraphs and line breaks",
+			input:    "First line\nsecond line\n\nNext paragraph",
+			expected: "<p>First line<br>\nsecond line</p>\n<p>Next paragraph</p>\n",
+		},
+		{
+			name:     "emphasis and code",
+			input:    "Use **strong**, *em* and `a * b` in __text__",
+			expected: "<p>Use <strong>strong</strong>, <em>em</em> and <code>a * b</code> in <strong>text</strong></p>\n",
+		},
+		{
+			name:     "lists",
+			input:    "Topics:\n- one\n- two\n\n1. first\n2. second",
+			expected: "<p>Topics:</p>\n<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n",
+		},
+		{
+			name:     "heading, quote and rule",
+			input:    "## Agenda\n> quoted\n---",
+			expected: "<h2>Agenda</h2>\n<blockquote>\n<p>quoted</p>\n</blockquote>\n<hr>\n",
+		},
+		{
+			name:     "fenced code is not formatted",
+			input:    "```\nif a < b && **c** {\n```",
+			expected: "<pre><code>if a &lt; b &amp;&amp; **c** {</code></pre>\n",
+		},
+		{
+			name:     "links",
+			input:    "See [the slides](https://example.com/s?a=1&b=2) or https://example.com/demo.",
+			expected: "<p>See <a href=\"https://example.com/s?a=1&amp;b=2\">the slides</a> or <a href=\"https://example.com/demo\">https://example.com/demo</a>.</p>\n",
+		},
+		{
+			name:     "snake_case is not emphasis",
+			input:    "call my_long_name",
+			expected: "<p>call my_long_name</p>\n",
+		},
+		{
+			name:     "empty",
+			input:    "  \n\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RenderMarkdown(tt.input))
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "allowed markup is kept",
+			input:    "<p>A <strong>bold</strong> <em>claim</em></p>",
+			expected: "<p>A <strong>bold</strong> <em>claim</em></p>",
+		},
+		{
+			name:     "attributes are dropped",
+			input:    `<p class="x" style="color:red" onclick="evil()">text</p>`,
+			expected: "<p>text</p>",
+		},
+		{
+			name:     "links keep a safe href",
+			input:    `<a href="https://example.com" target="_blank">site</a>`,
+			expected: `<a href="https://example.com" rel="nofollow noopener noreferrer">site</a>`,
+		},
+		{
+			name:     "unknown elements are unwrapped",
+			input:    "<div><span>kept text</span></div>",
+			expected: "kept text",
+		},
+		{
+			name:     "unclosed elements are closed",
+			input:    "<ul><li><strong>item",
+			expected: "<ul><li><strong>item</strong></li></ul>",
+		},
+		{
+			name:     "stray end tags are dropped",
+			input:    "text</p></em>",
+			expected: "text",
+		},
+		{
+			name:     "text is escaped",
+			input:    "a &lt; b &amp; c",
+			expected: "a &lt; b &amp; c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Sanitize(tt.input))
+		})
+	}
+}
+
+// xssPayloads are common cross-site scripting vectors, both as raw HTML and as markdown
+var xssPayloads = []string{
+	`<script>alert(1)</script>`,
+	`<SCRIPT SRC=https://evil.example/x.js></SCRIPT>`,
+	`<img src=x onerror=alert(1)>`,
+	`<svg onload=alert(1)><script>alert(1)</script></svg>`,
+	`<iframe src="javascript:alert(1)"></iframe>`,
+	`<a href="javascript:alert(1)">click</a>`,
+	`<a href="JaVaScRiPt:alert(1)">click</a>`,
+	`<a href="  javascript:alert(1)">click</a>`,
+	`<a href="jav&#x09;ascript:alert(1)">click</a>`,
+	`<a href="&#106;&#97;&#118;&#97;&#115;&#99;&#114;&#105;&#112;&#116;&#58;alert(1)">click</a>`,
+	`<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">click</a>`,
+	`<a href="vbscript:msgbox(1)">click</a>`,
+	`<a href=//evil.example/x>click</a>`,
+	`<p style="background:url(javascript:alert(1))">x</p>`,
+	`<body onload=alert(1)>`,
+	`<math><mtext><table><mglyph><style><img src=x onerror=alert(1)></style></mglyph></table></mtext></math>`,
+	`<noscript><p title="</noscript><img src=x onerror=alert(1)>">`,
+	`<<script>script>alert(1)<</script>/script>`,
+	`<!--<img src=x onerror=alert(1)>-->`,
+	`"><script>alert(1)</script>`,
+	`<object data="javascript:alert(1)"></object>`,
+	`<form action="javascript:alert(1)"><button>go</button></form>`,
+	`[click](javascript:alert(1))`,
+	`[click](JAVASCRIPT:alert(1))`,
+	`[click](data:text/html,<script>alert(1)</script>)`,
+	`[<img src=x onerror=alert(1)>](https://example.com)`,
+	`[click](https://example.com/"onmouseover="alert(1))`,
+	"`<script>alert(1)</script>`",
+	"**<img src=x onerror=alert(1)>**",
+	"```\n<script>alert(1)</script>\n```",
+}
+
+// tagPattern matches the tags in sanitized output; text never contains a literal < since it is escaped
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// safeTagPattern matches the only tags sanitized output may contain
+var safeTagPattern = regexp.MustCompile(`^(</?(p|br|hr|strong|b|em|i|code|pre|blockquote|ul|ol|li|a|h[1-6])>|<a href="(https?://[^"]+|mailto:[^"]+)" rel="nofollow noopener noreferrer">)$`)
+
+func TestRenderAndSanitize_XSSPayloads(t *testing.T) {
+	for _, payload := range xssPayloads {
+		t.Run(payload, func(t *testing.T) {
+			for _, output := range []string{Sanitize(payload), Sanitize(RenderMarkdown(payload))} {
+				for _, tag := range tagPattern.FindAllString(output, -1) {
+					assert.Regexp(t, safeTagPattern, tag, "output: %s", output)
+				}
+				assert.NotContains(t, strings.ToLower(output), `href="javascript`, "output: %s", output)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package markup
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedTags are the elements kept by Sanitize; all attributes except a link's href are dropped
+var allowedTags = map[string]bool{
+	"p": true, "br": true, "hr": true,
+	"strong": true, "b": true, "em": true, "i": true,
+	"code": true, "pre": true, "blockquote": true,
+	"ul": true, "ol": true, "li": true, "a": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// voidTags are allowed elements without content or end tag
+var voidTags = map[string]bool{"br": true, "hr": true}
+
+// droppedContentTags are elements removed together with their content
+var droppedContentTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"template": true, "noscript": true, "textarea": true, "title": true, "xmp": true,
+	"svg": true, "math": true, "select": true,
+}
+
+// allowedSchemes are the URL schemes kept in links
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// linkRel is added to every kept link, since abstracts link to sites nobody vouches for
+const linkRel = "nofollow noopener noreferrer"
+
+// Sanitize returns the HTML with everything outside the allowlist removed. Allowed elements are kept
+// without attributes, except links which keep an absolute http, https or mailto href. Other elements
+// are unwrapped to their text, except script-like elements which are removed with their content.
+// The result is well-formed: unclosed elements are closed and stray end tags dropped.
+func Sanitize(input string) string {
+	var out strings.Builder
+	var open []string
+	skipping := ""
+
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		if tokenizer.Next() == html.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return ""
+			}
+			break
+		}
+		token := tokenizer.Token()
+
+		if skipping != "" {
+			if token.Type == html.EndTagToken && token.Data == skipping {
+				skipping = ""
+			}
+			continue
+		}
+
+		switch token.Type {
+		case html.TextToken:
+			out.WriteString(html.EscapeString(token.Data))
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedContentTags[token.Data] {
+				if token.Type == html.StartTagToken {
+					skipping = token.Data
+				}
+				continue
+			}
+			if !allowedTags[token.Data] {
+				continue
+			}
+			writeStartTag(&out, token)
+			if !voidTags[token.Data] {
+				open = append(open, token.Data)
+			}
+
+		case html.EndTagToken:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != token.Data {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					out.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return out.String()
+}
+
+// writeStartTag writes an allowed start tag, keeping only a safe href on links
+func writeStartTag(out *strings.Builder, token html.Token) {
+	out.WriteString("<" + token.Data)
+	if token.Data == "a" {
+		for _, attr := range token.Attr {
+			if attr.Key == "href" && attr.Namespace == "" && safeURL(attr.Val) {
+				out.WriteString(` href="` + html.EscapeString(attr.Val) + `" rel="` + linkRel + `"`)
+				break
+			}
+		}
+	}
+	out.WriteString(">")
+}
+
+// safeURL returns true if the URL is absolute with an allowed scheme
+func safeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return allowedSchemes[strings.ToLower(u.Scheme)] && (u.Host != "" || u.Scheme == "mailto")
+}
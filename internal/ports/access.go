@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// UserDirectory defines the interface for the admin allowlist and role assignments.
+// This is implemented by the app layer AccessService.
+type UserDirectory interface {
+	// RoleFor returns the role of the given user, or false if the user is not allowed in
+	RoleFor(ctx context.Context, email string) (domain.Role, bool, error)
+
+	// ListUsers returns all allowed users, including bootstrap admins from configuration
+	ListUsers(ctx context.Context) ([]domain.UserAccess, error)
+
+	// SetUserRole adds a user to the allowlist or changes their role
+	SetUserRole(ctx context.Context, email string, role domain.Role, updatedBy string) error
+
+	// RemoveUser removes a user from the allowlist
+	RemoveUser(ctx context.Context, email string) error
+}
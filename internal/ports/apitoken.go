@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// APITokens defines the interface for the API tokens admins manage for their own automation.
+// This is implemented by the app layer APITokenService.
+type APITokens interface {
+	// ListTokens returns the tokens of the given owner, ordered by label
+	ListTokens(ctx context.Context, owner string) ([]domain.APIToken, error)
+
+	// ListAllTokens returns the tokens of every owner, ordered by owner and label
+	ListAllTokens(ctx context.Context) ([]domain.APIToken, error)
+
+	// CreateToken creates a labelled token for the owner and returns it along with the token itself,
+	// which is not stored and cannot be shown again
+	CreateToken(ctx context.Context, owner, label string) (domain.APIToken, string, error)
+
+	// RotateToken replaces the owner's token with a new one, returned like by CreateToken
+	RotateToken(ctx context.Context, owner, id string) (domain.APIToken, string, error)
+
+	// RevokeToken deletes the owner's token
+	RevokeToken(ctx context.Context, owner, id string) error
+
+	// RevokeAnyToken deletes a token of any owner
+	RevokeAnyToken(ctx context.Context, id, revokedBy string) error
+}
+
+// TokenAuthenticator defines the interface for checking API tokens created in the admin UI.
+// This is implemented by the app layer APITokenService.
+type TokenAuthenticator interface {
+	// Authenticate returns the stored token matching the bearer token, or false if none does
+	Authenticate(ctx context.Context, token string) (domain.APIToken, bool, error)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// CachePurger defines the interface for invalidating cached copies of public endpoints
+type CachePurger interface {
+	// Purge invalidates the given URL paths so the next request is served fresh
+	Purge(ctx context.Context, paths []string) error
+}
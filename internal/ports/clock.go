@@ -0,0 +1,13 @@
+package ports
+
+import "time"
+
+// Clock defines the interface for telling the time, so time-dependent behavior such as scheduled runs,
+// session expiry and notice expiry can be faked in tests and shifted in development
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After returns a channel receiving the current time once the duration has passed
+	After(d time.Duration) <-chan time.Time
+}
@@ -13,3 +13,27 @@ type ConferenceProvider interface {
 	// GetConferences retrieves all available conferences
 	GetConferences(ctx context.Context) ([]domain.Conference, error)
 }
+
+// ConferenceResolver defines the interface for looking up conferences without listing them from the source
+// on every lookup. This is implemented by the app layer ConferenceResolverService.
+type ConferenceResolver interface {
+	// ConferenceByID returns the conference with the given ID, or domain.ErrConferenceNotFound
+	ConferenceByID(ctx context.Context, id string) (*domain.Conference, error)
+
+	// ConferenceBySlug returns the conference with the given slug, domain.ErrConferenceNotFound, or
+	// domain.ErrAmbiguousConference if several conferences have the slug
+	ConferenceBySlug(ctx context.Context, slug string) (*domain.Conference, error)
+}
+
+// ConferenceCatalog defines the interface for the per-conference metadata denormalized onto indexed talks.
+// This is implemented by the app layer ConferenceCatalogService.
+type ConferenceCatalog interface {
+	// ListConferenceMetadata returns the metadata of all conferences, including entries from the metadata file
+	ListConferenceMetadata(ctx context.Context) ([]domain.ConferenceEntry, error)
+
+	// SetConferenceMetadata stores the metadata of a conference, overriding any entry from the metadata file
+	SetConferenceMetadata(ctx context.Context, slug string, metadata domain.ConferenceMetadata, updatedBy string) error
+
+	// RemoveConferenceMetadata removes the stored metadata of a conference
+	RemoveConferenceMetadata(ctx context.Context, slug string) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// DatasetVersions defines the interface for the versions of the public documents of each conference.
+// This is implemented by the app layer DatasetVersionService.
+type DatasetVersions interface {
+	// DatasetVersion returns the current version of the public documents of the conference.
+	// It returns domain.ErrConferenceNotFound if the conference has never had public documents.
+	DatasetVersion(ctx context.Context, conferenceSlug string) (domain.DatasetVersion, error)
+
+	// DatasetChanges returns the public documents of the conference added, updated and removed since
+	// the given version; since 0 returns every document as added. It returns
+	// domain.ErrDatasetVersionUnavailable if the changes since that version are not known.
+	DatasetChanges(ctx context.Context, conferenceSlug string, since int64) (domain.DatasetChanges, error)
+}
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// DeadLetterStore defines the interface for keeping documents that failed indexing even after retries
+type DeadLetterStore interface {
+	// SaveDeadLetter stores a dead letter, replacing any dead letter with the same ID
+	SaveDeadLetter(ctx context.Context, letter domain.DeadLetter) error
+
+	// GetDeadLetter retrieves a dead letter by ID, returns nil if not found
+	GetDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error)
+
+	// ListDeadLetters returns up to limit dead letters, most recent failures first
+	ListDeadLetters(ctx context.Context, limit int) ([]domain.DeadLetter, error)
+
+	// DeleteDeadLetter removes a dead letter; removing a missing dead letter is not an error
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
+
+// DeadLetters defines the interface for the dead-letter admin page.
+// This is implemented by the app layer DeadLetterService.
+type DeadLetters interface {
+	// ListDeadLetters returns the documents that failed indexing, most recent failures first
+	ListDeadLetters(ctx context.Context) ([]domain.DeadLetter, error)
+
+	// RetryDeadLetter indexes the stored payload again, removing the dead letter if it succeeds
+	RetryDeadLetter(ctx context.Context, id string) error
+
+	// DiscardDeadLetter removes a dead letter without indexing it
+	DiscardDeadLetter(ctx context.Context, id string) error
+}
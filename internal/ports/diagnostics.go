@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// MappingReader defines the interface for reading the live mapping of an index
+type MappingReader interface {
+	// IndexMapping returns the mappings of the index, or of the index an alias points to
+	IndexMapping(ctx context.Context, indexName string) (map[string]interface{}, error)
+}
+
+// LogHistory defines the interface for reading the most recent log records
+type LogHistory interface {
+	// RecentLogs returns the recorded log records as JSON lines, oldest first
+	RecentLogs() [][]byte
+}
+
+// Diagnostics defines the interface for the diagnostics bundle download.
+// This is implemented by the app layer DiagnosticsService.
+type Diagnostics interface {
+	// DiagnosticsBundle collects the logs, job reports, redacted configuration, mapping diffs and
+	// dependency health
+	DiagnosticsBundle(ctx context.Context) (*domain.DiagnosticsBundle, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// HealthCheck defines the interface for dependencies reporting their health
+type HealthCheck interface {
+	// CheckHealth probes the dependency and reports its status and latency
+	CheckHealth(ctx context.Context) domain.DependencyHealth
+}
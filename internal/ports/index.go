@@ -2,14 +2,16 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/javaBin/talks-indexer/internal/domain"
 )
 
 // SearchIndex defines the interface for Elasticsearch operations
 type SearchIndex interface {
-	// BulkIndex indexes multiple talks into the specified index
-	BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) error
+	// BulkIndex indexes multiple talks into the specified index. Documents are versioned
+	// by their last update time, so stale payloads are reported as conflicts rather than written.
+	BulkIndex(ctx context.Context, indexName string, talks []domain.Talk) (domain.BulkResult, error)
 
 	// DeleteIndex removes an index from Elasticsearch
 	DeleteIndex(ctx context.Context, indexName string) error
@@ -19,4 +21,61 @@ type SearchIndex interface {
 
 	// IndexExists checks if an index exists in Elasticsearch
 	IndexExists(ctx context.Context, indexName string) (bool, error)
+
+	// DeleteTalk removes a talk from the specified index and returns the deleted talk,
+	// or nil if the index did not hold it
+	DeleteTalk(ctx context.Context, indexName string, talkID string) (*domain.Talk, error)
+
+	// ListTalkIDs returns the IDs of the talks of the conference stored in the specified index
+	ListTalkIDs(ctx context.Context, indexName string, conferenceID string) ([]string, error)
+
+	// FetchTalks retrieves all talks stored in the specified index.
+	// If conferenceSlug is non-empty, only talks for that conference are returned.
+	FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error)
+}
+
+// TalkPatcher defines the interface for updating single fields of an indexed talk in place
+type TalkPatcher interface {
+	// PatchTalk merges the partial document into the talk stored in the specified index.
+	// Objects are merged, arrays are replaced and nil values clear a field.
+	PatchTalk(ctx context.Context, indexName string, talkID string, doc map[string]interface{}) error
+}
+
+// TalkDeleter defines the interface for removing talks from an index in bulk
+type TalkDeleter interface {
+	// DeleteTalksUpdatedBefore deletes the talks with one of the statuses that were last updated before
+	// the given time, and returns the number of deleted talks. Talks without a last update time are kept.
+	DeleteTalksUpdatedBefore(ctx context.Context, indexName string, statuses []string, before time.Time) (int, error)
+}
+
+// ClusterCapacity defines the interface for reading how much disk space the cluster has left
+type ClusterCapacity interface {
+	// DiskUsage returns the total and used disk space of the cluster's data nodes
+	DiskUsage(ctx context.Context) (domain.DiskUsage, error)
+}
+
+// IndexAdmin defines the interface for inspecting and maintaining indexes and aliases in the cluster
+type IndexAdmin interface {
+	// ListIndexes returns the indexes matching the pattern with their aliases and statistics
+	ListIndexes(ctx context.Context, pattern string) ([]domain.IndexInfo, error)
+
+	// DeleteIndex removes an index from Elasticsearch
+	DeleteIndex(ctx context.Context, indexName string) error
+
+	// PointAlias makes the alias point to the index only, atomically removing it from other indexes.
+	// An index named like the alias is deleted in the same request.
+	PointAlias(ctx context.Context, alias string, indexName string) error
+}
+
+// IndexManager defines the interface for the index lifecycle admin page.
+// This is implemented by the app layer IndexLifecycleService.
+type IndexManager interface {
+	// ListIndexes returns the indexes belonging to this environment, marking those in use
+	ListIndexes(ctx context.Context) ([]domain.IndexInfo, error)
+
+	// DeleteIndex deletes a stale index; indexes in use are refused
+	DeleteIndex(ctx context.Context, indexName string) error
+
+	// PointAlias makes the alias point to the index
+	PointAlias(ctx context.Context, alias string, indexName string) error
 }
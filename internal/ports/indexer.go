@@ -1,6 +1,11 @@
 package ports
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
 
 // Indexer defines the interface for indexing operations.
 // This is implemented by the app layer IndexerService.
@@ -11,6 +16,41 @@ type Indexer interface {
 	// ReindexConference reindexes a specific conference by its slug
 	ReindexConference(ctx context.Context, slug string) error
 
+	// ReindexConferenceByID reindexes a specific conference by its ID
+	ReindexConferenceByID(ctx context.Context, conferenceID string) error
+
 	// ReindexTalk reindexes a specific talk by its ID
 	ReindexTalk(ctx context.Context, talkID string) error
+
+	// DeleteTalk removes a talk from both indexes, such as a talk withdrawn in moresleep
+	DeleteTalk(ctx context.Context, talkID string) error
+
+	// LastReindex returns when the given index was last successfully written to,
+	// or the zero time if it has not been written to since startup
+	LastReindex(indexName string) time.Time
+
+	// IndexNames returns the effective names of the indexes written to
+	IndexNames() domain.IndexNames
+}
+
+// IndexEventHandler defines the interface for subscribers to the events the indexer publishes after
+// changing an index. Handlers run synchronously on the indexing path and must not block on slow work.
+type IndexEventHandler interface {
+	// HandleIndexEvent reacts to an event; failures are handled by the subscriber, never returned
+	HandleIndexEvent(ctx context.Context, event domain.IndexEvent)
+}
+
+// ReindexProgressStream defines the interface for following the progress of running reindexes live.
+// This is implemented by the app layer ReindexProgressService.
+type ReindexProgressStream interface {
+	// SubscribeProgress returns a channel receiving the progress of the reindexes running from now on.
+	// The channel is closed when the context is done. Progress is dropped for subscribers that fall behind.
+	SubscribeProgress(ctx context.Context) <-chan domain.ReindexProgress
+}
+
+// IndexMetrics defines the interface for reading the counts of published index events.
+// This is implemented by the app layer IndexMetricsService.
+type IndexMetrics interface {
+	// IndexEventCounts returns the number of published events per event name
+	IndexEventCounts() map[string]uint64
 }
@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// JobStore defines the interface for persisting jobs
+type JobStore interface {
+	// CreateJob stores a new job and returns it with its assigned ID
+	CreateJob(ctx context.Context, job domain.Job) (domain.Job, error)
+
+	// UpdateJob replaces the stored job with the same ID
+	UpdateJob(ctx context.Context, job domain.Job) error
+
+	// GetJob retrieves a job by ID, returns nil if not found
+	GetJob(ctx context.Context, id string) (*domain.Job, error)
+
+	// ListJobs returns up to limit jobs, most recently created first.
+	// A limit of zero or less returns all jobs.
+	ListJobs(ctx context.Context, limit int) ([]domain.Job, error)
+}
+
+// ReindexJobs defines the interface for running reindexes in the background and following their progress.
+// This is implemented by the app layer IndexerService.
+type ReindexJobs interface {
+	// StartReindex creates a queued reindex job and runs it in the background, returning the job right away
+	StartReindex(ctx context.Context, scope domain.JobScope) (domain.Job, error)
+
+	// GetJob retrieves a job by ID, returns nil if not found
+	GetJob(ctx context.Context, id string) (*domain.Job, error)
+
+	// ListJobs returns up to limit jobs, most recently created first
+	ListJobs(ctx context.Context, limit int) ([]domain.Job, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// KeywordTrends defines the interface for analysing how keyword frequencies evolve across conference years.
+// This is implemented by the app layer KeywordTrendService.
+type KeywordTrends interface {
+	// KeywordTrends counts the requested keywords, or the most frequent ones, per conference year
+	KeywordTrends(ctx context.Context, req domain.KeywordTrendRequest) (*domain.KeywordTrendReport, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// LinkChecker defines the interface for checking whether a URL still resolves
+type LinkChecker interface {
+	// CheckLink requests the URL and classifies the outcome
+	CheckLink(ctx context.Context, url string) domain.LinkCheck
+}
+
+// LinkReporter defines the interface for the broken link report.
+// This is implemented by the app layer LinkCheckService.
+type LinkReporter interface {
+	// CheckLinks validates the links in the public index and stores the report
+	CheckLinks(ctx context.Context) error
+
+	// LatestReport returns the report of the last check, or nil if no check has run
+	LatestReport(ctx context.Context) (*domain.LinkReport, error)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// MailSender defines the interface for sending email
+type MailSender interface {
+	// SendMail sends a plain text email to the recipients
+	SendMail(ctx context.Context, to []string, subject, body string) error
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// RequestMetrics defines the interface for recording HTTP requests per route and reading the
+// resulting latency, error and SLO metrics.
+// This is implemented by the app layer RequestMetricsService.
+type RequestMetrics interface {
+	// RecordRequest records a completed request of the route with its status code and latency
+	RecordRequest(route string, status int, latency time.Duration)
+
+	// RouteMetrics returns the metrics of every route that has served requests or has an SLO,
+	// sorted by route
+	RouteMetrics() []domain.RouteMetrics
+}
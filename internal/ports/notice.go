@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// Notices defines the interface for the notice banner set by admins.
+// This is implemented by the app layer NoticeService.
+type Notices interface {
+	// ActiveNotice returns the notice to show now, or false when there is none or it has expired.
+	// It is called on every request, so implementations cache the stored notice.
+	ActiveNotice(ctx context.Context) (domain.Notice, bool)
+
+	// GetNotice returns the stored notice, even if it has expired
+	GetNotice(ctx context.Context) (domain.Notice, error)
+
+	// SetNotice stores the notice, replacing the previous one
+	SetNotice(ctx context.Context, notice domain.Notice, updatedBy string) error
+
+	// ClearNotice removes the notice
+	ClearNotice(ctx context.Context) error
+}
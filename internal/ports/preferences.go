@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// Preferences defines the interface for reading and storing per-user UI preferences.
+// This is implemented by the app layer PreferencesService.
+type Preferences interface {
+	// GetPreferences returns the preferences of the given user, or the defaults if none are stored
+	GetPreferences(ctx context.Context, email string) (domain.UserPreferences, error)
+
+	// SavePreferences validates and stores the preferences of the given user
+	SavePreferences(ctx context.Context, email string, prefs domain.UserPreferences) error
+}
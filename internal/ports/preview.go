@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// TalkPreviews defines the interface for the preview links speakers use to see their talk before publication.
+// This is implemented by the app layer TalkPreviewService.
+type TalkPreviews interface {
+	// CreatePreviewToken returns a new signed preview token for the talk, valid for the configured time
+	CreatePreviewToken(ctx context.Context, talkID string) (domain.PreviewToken, error)
+
+	// PreviewTalk verifies the token and returns the preview of its talk.
+	// It returns domain.ErrInvalidPreviewToken if the token is malformed, tampered with or expired.
+	PreviewTalk(ctx context.Context, token string) (domain.TalkPreview, error)
+}
@@ -0,0 +1,67 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// QueryRunner defines the interface for running a validated search request against an index
+type QueryRunner interface {
+	// RunQuery executes the search request body and returns the hit sources and aggregations
+	RunQuery(ctx context.Context, indexName string, body map[string]interface{}) (domain.QueryResult, error)
+}
+
+// CompletionSuggester defines the interface for completing prefixes of talk titles, speaker names and keywords
+type CompletionSuggester interface {
+	// Suggest returns up to size distinct suggestions starting with the prefix from the talks of the index
+	Suggest(ctx context.Context, indexName string, prefix string, size int) ([]string, error)
+}
+
+// Querier defines the interface for the ad-hoc query endpoint.
+// This is implemented by the app layer QueryService.
+type Querier interface {
+	// Query validates the request against the allowed query DSL subset and limits and runs it
+	// against the private index. Rejected requests return an error wrapping domain.ErrInvalidQuery.
+	Query(ctx context.Context, req domain.QueryRequest) (domain.QueryResult, error)
+}
+
+// Sampler defines the interface for the index sample endpoint.
+// This is implemented by the app layer SampleService.
+type Sampler interface {
+	// Sample returns random documents of the requested index. Unknown indexes return an error wrapping
+	// domain.ErrIndexNotFound and sizes outside the limit an error wrapping domain.ErrInvalidQuery.
+	Sample(ctx context.Context, req domain.SampleRequest) (domain.QueryResult, error)
+}
+
+// Searcher defines the interface for the talk search endpoint.
+// This is implemented by the app layer SearchService.
+type Searcher interface {
+	// Search runs a full-text search for talks. Sizes and offsets outside the limits return an error
+	// wrapping domain.ErrInvalidQuery.
+	Search(ctx context.Context, req domain.SearchRequest) (domain.QueryResult, error)
+}
+
+// SearchFacets defines the interface for the search facet endpoint.
+// This is implemented by the app layer FacetService.
+type SearchFacets interface {
+	// SearchFacets counts the public talks per year, format, language and keyword. Keyword limits
+	// outside the allowed range return an error wrapping domain.ErrInvalidQuery.
+	SearchFacets(ctx context.Context, req domain.FacetRequest) (*domain.SearchFacets, error)
+}
+
+// TalkLookup defines the interface for resolving talk slugs and titles to talk IDs.
+// This is implemented by the app layer TalkLookupService.
+type TalkLookup interface {
+	// LookupTalk returns the talks of the private index matching the slug or title, best match first.
+	// Requests with neither return an error wrapping domain.ErrInvalidQuery.
+	LookupTalk(ctx context.Context, req domain.TalkLookupRequest) ([]domain.TalkMatch, error)
+}
+
+// Suggester defines the interface for the talk suggestion endpoint.
+// This is implemented by the app layer SuggestService.
+type Suggester interface {
+	// Suggest returns talk titles, speaker names and keywords of public talks completing the prefix.
+	// Missing prefixes and sizes outside the limits return an error wrapping domain.ErrInvalidQuery.
+	Suggest(ctx context.Context, req domain.SuggestRequest) ([]string, error)
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// TalkReader defines the interface for reading indexed talks back from the search index
+type TalkReader interface {
+	// FetchTalks retrieves all talks stored in the specified index.
+	// If conferenceSlug is non-empty, only talks for that conference are returned.
+	FetchTalks(ctx context.Context, indexName string, conferenceSlug string) ([]domain.Talk, error)
+
+	// ListConferences returns the conferences present in the specified index with their talk counts
+	ListConferences(ctx context.Context, indexName string) ([]domain.ConferenceSummary, error)
+}
+
+// IndexVersioner defines the interface for looking up the current version of an index
+type IndexVersioner interface {
+	// IndexVersion returns the generation and document version of the specified index
+	IndexVersion(ctx context.Context, indexName string) (domain.IndexVersion, error)
+}
+
+// IndexReader combines read access to indexed talks with index version lookups,
+// as needed by the public read endpoints
+type IndexReader interface {
+	TalkReader
+	IndexVersioner
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// ReindexStatuses defines the interface for reading the last successful reindex of each index.
+// This is implemented by the app layer ReindexStatusService.
+type ReindexStatuses interface {
+	// ReindexStatuses returns the last successful reindex of the private and public index, in that
+	// order, leaving out indexes without a recorded reindex
+	ReindexStatuses(ctx context.Context) ([]domain.ReindexStatus, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// ConfigReloader defines the interface for applying a changed configuration without restarting the
+// process. This is implemented by the bootstrap App.
+type ConfigReloader interface {
+	// ReloadConfig reads the configuration again and rebuilds the services from it. Changes to settings
+	// that need a restart return an error wrapping domain.ErrRestartRequired, leaving everything as it was.
+	ReloadConfig(ctx context.Context) (domain.ConfigReload, error)
+}
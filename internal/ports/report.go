@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// Reporter defines the interface for generating reports from indexed data.
+// This is implemented by the app layer ReportService.
+type Reporter interface {
+	// Statistics builds aggregated per-conference statistics
+	Statistics(ctx context.Context) (*domain.StatisticsReport, error)
+
+	// AnonymizedDataset builds the anonymized research dataset
+	AnonymizedDataset(ctx context.Context) ([]domain.AnonymizedTalk, error)
+
+	// SpeakerContacts lists the speakers of the approved talks of a conference with their contact emails
+	SpeakerContacts(ctx context.Context, conferenceSlug string) ([]domain.SpeakerContact, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// SnapshotCreator defines the interface for taking snapshots of indexes before they are replaced
+type SnapshotCreator interface {
+	// CreateSnapshot snapshots the indexes into the repository and waits for the snapshot to complete
+	CreateSnapshot(ctx context.Context, repository string, name string, indexes []string) error
+}
+
+// Republisher defines the interface for the guided republish admin page.
+// This is implemented by the app layer RepublishService.
+type Republisher interface {
+	// PlanRepublish runs the dry-run diff without changing any index
+	PlanRepublish(ctx context.Context) (domain.RepublishPlan, error)
+
+	// Republish rebuilds both indexes as a new generation and swaps the aliases to it, returning
+	// the finished job with the outcome of each step
+	Republish(ctx context.Context) (domain.Job, error)
+}
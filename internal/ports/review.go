@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// Reviews defines the interface for the talks program committee members are assigned to review.
+// This is implemented by the app layer ReviewService.
+type Reviews interface {
+	// AssignedTalks returns the talks assigned to the reviewer, optionally of a single conference.
+	// Talks the reviewer gave feedback on are only included if includeReviewed is set.
+	AssignedTalks(ctx context.Context, reviewer, conferenceSlug string, includeReviewed bool) (domain.ReviewQueue, error)
+
+	// Ranking returns the submitted talks of the conference ranked by review score within each track
+	Ranking(ctx context.Context, conferenceSlug string) ([]domain.TrackRanking, error)
+}
+
+// ReviewerConflicts defines the interface for the conflicts of interest program committee members register.
+// This is implemented by the app layer ReviewerConflictsService.
+type ReviewerConflicts interface {
+	// ListConflicts returns the conflicts of every committee member
+	ListConflicts(ctx context.Context) ([]domain.ReviewerConflicts, error)
+
+	// GetConflicts returns the conflicts of the committee member, empty if none are registered
+	GetConflicts(ctx context.Context, reviewer string) (domain.ReviewerConflicts, error)
+
+	// SetConflicts replaces the conflicts of the committee member, removing them if both lists are empty
+	SetConflicts(ctx context.Context, reviewer string, speakerEmails, organizations []string) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// SeriesCatalog defines the interface for the series linking related sessions, configured by admins.
+// This is implemented by the app layer SeriesCatalogService.
+type SeriesCatalog interface {
+	// ListSeries returns all series, including series from the series file
+	ListSeries(ctx context.Context) ([]domain.SeriesEntry, error)
+
+	// SetSeries stores a series, overriding any series with the same ID from the series file
+	SetSeries(ctx context.Context, id string, talkIDs []string, updatedBy string) error
+
+	// RemoveSeries removes a stored series
+	RemoveSeries(ctx context.Context, id string) error
+}
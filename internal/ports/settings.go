@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// SettingsStore defines the interface for persisting small settings documents by key
+type SettingsStore interface {
+	// LoadSetting decodes the setting stored under key into value.
+	// It returns false without error if the setting does not exist.
+	LoadSetting(ctx context.Context, key string, value any) (bool, error)
+
+	// SaveSetting stores value under key, replacing any previous value
+	SaveSetting(ctx context.Context, key string, value any) error
+}
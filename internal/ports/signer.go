@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/javaBin/talks-indexer/internal/domain"
+
+// ContentSigner defines the interface for signing exported content
+type ContentSigner interface {
+	// Sign returns the base64-encoded detached signature of the payload
+	Sign(payload []byte) string
+
+	// PublicKey returns the key consumers use to verify signatures
+	PublicKey() domain.SigningKey
+}
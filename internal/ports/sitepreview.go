@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// SiteRenderer defines the interface for rendering a public document the way the website shows it.
+// This is implemented by the sitepreview adapter calling the website's preview renderer.
+type SiteRenderer interface {
+	// RenderTalk returns the talk page the website renders for the public document
+	RenderTalk(ctx context.Context, talk domain.Talk) (string, error)
+}
+
+// SitePreviews defines the interface for previewing public documents on the website before publication.
+// This is implemented by the app layer SitePreviewService.
+type SitePreviews interface {
+	// PreviewConference checks the talks of the conference that will be published, sorted with the
+	// talks that are not ready first. It returns domain.ErrConferenceNotFound for unknown slugs.
+	PreviewConference(ctx context.Context, slug string) (domain.SitePreviewReport, error)
+
+	// PreviewTalk checks a single talk and renders it with the website's preview renderer if configured
+	PreviewTalk(ctx context.Context, talkID string) (domain.SitePreview, error)
+}
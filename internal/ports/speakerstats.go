@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// SpeakerStatisticsReporter defines the interface for the private aggregation of speaker attributes.
+// This is implemented by the app layer SpeakerStatisticsService.
+type SpeakerStatisticsReporter interface {
+	// SpeakerStatistics counts the distinct speakers per value of each aggregated attribute
+	SpeakerStatistics(ctx context.Context, req domain.SpeakerStatisticsRequest) (*domain.SpeakerStatistics, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// TalkDiffs defines the interface for comparing a talk in moresleep with its indexed documents.
+// This is implemented by the app layer TalkDiffService.
+type TalkDiffs interface {
+	// DiffTalk builds the documents the next reindex writes for the talk and compares them field by
+	// field with the documents in the private and public indexes
+	DiffTalk(ctx context.Context, talkID string) (domain.TalkDiff, error)
+}
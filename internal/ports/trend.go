@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// TalkTrends defines the interface for the talk count trends shown on the dashboard.
+// This is implemented by the app layer TrendService.
+type TalkTrends interface {
+	// TalkCountTrends returns the recorded daily talk counts of the conferences that have any,
+	// most recently recorded conference first
+	TalkCountTrends(ctx context.Context) ([]domain.TalkCountTrend, error)
+}
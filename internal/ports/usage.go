@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// UsageRecorder defines the interface for counting the requests of API clients per route.
+// This is implemented by the app layer UsageService.
+type UsageRecorder interface {
+	// RecordUsage counts a completed request of the client to the route with its status code and latency
+	RecordUsage(route, client string, status int, latency time.Duration)
+}
+
+// UsageAnalytics defines the interface for reading the recorded API usage.
+// This is implemented by the app layer UsageService.
+type UsageAnalytics interface {
+	// UsageReport summarizes the usage of the last days, including usage not stored yet
+	UsageReport(ctx context.Context, days int) (domain.UsageReport, error)
+}
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// VideoSource defines the interface for listing the videos published on the conference video channel
+type VideoSource interface {
+	// ListVideos returns all videos on the configured channel
+	ListVideos(ctx context.Context) ([]domain.Video, error)
+}
+
+// VideoBackfill defines the interface for the missing video report and video link backfill.
+// This is implemented by the app layer VideoService.
+type VideoBackfill interface {
+	// IsConfigured returns true if a video channel is configured for proposing links
+	IsConfigured() bool
+
+	// MissingVideos returns the published talks from past conferences without a video link
+	MissingVideos(ctx context.Context) ([]domain.MissingVideoTalk, error)
+
+	// ProposeVideos matches the talks missing a video against the video channel and stores the proposals
+	ProposeVideos(ctx context.Context) error
+
+	// ListProposals returns the stored proposals, pending ones first
+	ListProposals(ctx context.Context) ([]domain.VideoProposal, error)
+
+	// AcceptProposal patches the proposed video link into the talk
+	AcceptProposal(ctx context.Context, talkID string) error
+
+	// RejectProposal marks the proposal as rejected so it is not proposed again
+	RejectProposal(ctx context.Context, talkID string) error
+}
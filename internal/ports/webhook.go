@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// EventNotifier defines the interface for receiving events raised by the indexer
+type EventNotifier interface {
+	// Notify handles an event; implementations must not block the caller on slow receivers
+	Notify(ctx context.Context, event domain.Event)
+}
+
+// WebhookSender defines the interface for delivering webhook payloads over HTTP
+type WebhookSender interface {
+	// SendWebhook posts the body to the URL with the given headers and returns the response
+	// status code. An error is returned only if no response was received.
+	SendWebhook(ctx context.Context, url string, body []byte, headers map[string]string) (int, error)
+}
+
+// Webhooks defines the interface for managing outbound webhook subscriptions.
+// This is implemented by the app layer WebhookService.
+type Webhooks interface {
+	// ListSubscriptions returns all webhook subscriptions
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+
+	// CreateSubscription registers a URL for the given events and returns the subscription with its generated secret
+	CreateSubscription(ctx context.Context, url string, events []domain.EventType, description string) (domain.WebhookSubscription, error)
+
+	// DeleteSubscription removes a subscription by ID
+	DeleteSubscription(ctx context.Context, id string) error
+
+	// Deliveries returns the most recent deliveries, newest first
+	Deliveries(limit int) []domain.WebhookDelivery
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// WhatIfBuilder defines the interface for evaluating an alternative transformation config
+// against the talks of a conference in scratch indexes, without touching the live indexes.
+// This is implemented by the app layer WhatIfService.
+type WhatIfBuilder interface {
+	// WhatIfDefaults returns the settings the live indexes are built with
+	WhatIfDefaults() domain.WhatIfSettings
+
+	// BuildWhatIf builds scratch indexes for the conference with the given settings and compares
+	// the public documents with the live public index
+	BuildWhatIf(ctx context.Context, slug string, settings domain.WhatIfSettings) (domain.WhatIfResult, error)
+}
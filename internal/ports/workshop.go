@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// RegistrationSource defines the interface for reading workshop capacity and registration counts
+// from the registration system
+type RegistrationSource interface {
+	// ListRegistrations returns the capacity and registration count of every workshop
+	ListRegistrations(ctx context.Context) ([]domain.WorkshopRegistration, error)
+}
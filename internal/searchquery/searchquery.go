@@ -0,0 +1,220 @@
+// Package searchquery reads the subset of the Elasticsearch query DSL built by the search service, so the
+// embedded index stores (SQLite and Bleve) can run talk searches without a cluster.
+package searchquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+)
+
+// DefaultSize is the number of hits returned when a query does not set a size, as in Elasticsearch
+const DefaultSize = 10
+
+// TermFields are the talk fields term filters may use
+var TermFields = []string{"id", "conferenceSlug", "status", "seriesId", "review.reviewers", "review.pending"}
+
+// SortFields are the numeric talk fields hits may be sorted on
+var SortFields = []string{"review.score"}
+
+// Search is the part of a query body an embedded store can run: term filters, and text matched against
+// the title, keywords, abstract and speaker names
+type Search struct {
+	// Filters maps fields of TermFields to the value talks must have
+	Filters map[string]string
+
+	// Texts are the distinct texts of the full-text clauses; talks matching any word are hits
+	Texts []string
+
+	// SortBy is the field of SortFields hits are ordered by, highest first with talks without it last
+	// and ties by relevance; hits are ordered by relevance alone while empty
+	SortBy string
+
+	Size int
+	From int
+}
+
+// Parse reads the size, offset, sort and query of a search request body. Supported are a bool query with
+// term filters on TermFields, and multi_match, match or nested match clauses whose text is matched
+// against all searchable fields, sorted by relevance or descending on a field of SortFields. Other
+// queries return an error wrapping domain.ErrInvalidQuery.
+func Parse(body map[string]interface{}) (Search, error) {
+	search := Search{Filters: map[string]string{}, Size: DefaultSize}
+
+	for key, value := range body {
+		switch key {
+		case "size", "from":
+			n, ok := intValue(value)
+			if !ok || n < 0 {
+				return Search{}, unsupported("%s must be a non-negative number", key)
+			}
+			if key == "size" {
+				search.Size = n
+			} else {
+				search.From = n
+			}
+		case "track_total_hits":
+			// The total is always counted
+		case "query":
+			if err := search.addClause(value); err != nil {
+				return Search{}, err
+			}
+		case "sort":
+			if err := search.addSort(value); err != nil {
+				return Search{}, err
+			}
+		default:
+			return Search{}, unsupported("%q is not supported without Elasticsearch", key)
+		}
+	}
+	return search, nil
+}
+
+// Words returns the words of the texts
+func (s Search) Words() []string {
+	var words []string
+	for _, text := range s.Texts {
+		words = append(words, strings.Fields(text)...)
+	}
+	return words
+}
+
+// addClause adds the filters and text of a query clause to the search
+func (s *Search) addClause(clause interface{}) error {
+	object, ok := clause.(map[string]interface{})
+	if !ok || len(object) != 1 {
+		return unsupported("query clauses must be objects with a single key")
+	}
+
+	for kind, value := range object {
+		params, _ := value.(map[string]interface{})
+		switch kind {
+		case "match_all":
+		case "bool":
+			for key, clauses := range params {
+				if key == "minimum_should_match" {
+					continue
+				}
+				if key != "filter" && key != "must" && key != "should" {
+					return unsupported("bool %q is not supported without Elasticsearch", key)
+				}
+				list, ok := clauses.([]interface{})
+				if !ok {
+					list = []interface{}{clauses}
+				}
+				for _, c := range list {
+					if err := s.addClause(c); err != nil {
+						return err
+					}
+				}
+			}
+		case "term":
+			for field, term := range params {
+				if !isTermField(field) {
+					return unsupported("term filters on %q are not supported without Elasticsearch", field)
+				}
+				text, ok := term.(string)
+				if !ok {
+					return unsupported("term filter on %q must be a string", field)
+				}
+				s.Filters[field] = text
+			}
+		case "multi_match":
+			text, _ := params["query"].(string)
+			s.addText(text)
+		case "match":
+			for _, match := range params {
+				switch m := match.(type) {
+				case string:
+					s.addText(m)
+				case map[string]interface{}:
+					text, _ := m["query"].(string)
+					s.addText(text)
+				}
+			}
+		case "nested":
+			if err := s.addClause(params["query"]); err != nil {
+				return err
+			}
+		default:
+			return unsupported("%q queries are not supported without Elasticsearch", kind)
+		}
+	}
+	return nil
+}
+
+// addSort reads the sort of the search: relevance, optionally preceded by a descending field of SortFields
+func (s *Search) addSort(sort interface{}) error {
+	list, ok := sort.([]interface{})
+	if !ok {
+		list = []interface{}{sort}
+	}
+	for i, entry := range list {
+		if entry == "_score" {
+			continue
+		}
+		object, ok := entry.(map[string]interface{})
+		if !ok || len(object) != 1 || i > 0 {
+			return unsupported("only sorting on %v before relevance is supported without Elasticsearch", SortFields)
+		}
+		for field, params := range object {
+			if !slices.Contains(SortFields, field) {
+				return unsupported("sorting on %q is not supported without Elasticsearch", field)
+			}
+			options, _ := params.(map[string]interface{})
+			if order, _ := options["order"].(string); order != "desc" {
+				return unsupported("sorting on %q is only supported in descending order without Elasticsearch", field)
+			}
+			s.SortBy = field
+		}
+	}
+	return nil
+}
+
+// addText adds the text of a full-text clause, skipping text already added by another clause
+func (s *Search) addText(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, existing := range s.Texts {
+		if existing == text {
+			return
+		}
+	}
+	s.Texts = append(s.Texts, text)
+}
+
+// isTermField returns true if term filters may use the field
+func isTermField(field string) bool {
+	for _, f := range TermFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// intValue returns the value as an int, accepting the number types of decoded and built query bodies
+func intValue(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	}
+	return 0, false
+}
+
+// unsupported returns an error wrapping domain.ErrInvalidQuery
+func unsupported(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{domain.ErrInvalidQuery}, args...)...)
+}
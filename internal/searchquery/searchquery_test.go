@@ -0,0 +1,103 @@
+package searchquery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/javaBin/talks-indexer/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("search service query", func(t *testing.T) {
+		body := map[string]interface{}{
+			"size":             5,
+			"from":             10,
+			"track_total_hits": true,
+			"query": map[string]interface{}{"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"conferenceSlug": "javazone2024"}},
+					map[string]interface{}{"term": map[string]interface{}{"status": "APPROVED"}},
+					map[string]interface{}{"term": map[string]interface{}{"seriesId": "kotlin-workshop"}},
+					map[string]interface{}{"term": map[string]interface{}{"review.pending": "ada@javazone.no"}},
+				},
+				"should": []interface{}{
+					map[string]interface{}{"multi_match": map[string]interface{}{"query": "kotlin coroutines", "fields": []string{"data.title^3"}}},
+					map[string]interface{}{"nested": map[string]interface{}{
+						"path":  "speakers",
+						"query": map[string]interface{}{"match": map[string]interface{}{"speakers.name": "kotlin coroutines"}},
+					}},
+				},
+				"minimum_should_match": 1,
+			}},
+		}
+
+		search, err := Parse(body)
+
+		require.NoError(t, err)
+		assert.Equal(t, Search{
+			Filters: map[string]string{"conferenceSlug": "javazone2024", "status": "APPROVED", "seriesId": "kotlin-workshop", "review.pending": "ada@javazone.no"},
+			Texts:   []string{"kotlin coroutines"},
+			Size:    5,
+			From:    10,
+		}, search)
+		assert.Equal(t, []string{"kotlin", "coroutines"}, search.Words())
+	})
+
+	t.Run("decoded JSON body", func(t *testing.T) {
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"size":3,"query":{"match":{"data.title":{"query":"rust"}}}}`), &body))
+
+		search, err := Parse(body)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, search.Size)
+		assert.Equal(t, []string{"rust"}, search.Texts)
+	})
+
+	t.Run("sorted by review score", func(t *testing.T) {
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"sort":[{"review.score":{"order":"desc","missing":"_last","unmapped_type":"float"}},"_score"]}`), &body))
+
+		search, err := Parse(body)
+
+		require.NoError(t, err)
+		assert.Equal(t, "review.score", search.SortBy)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		search, err := Parse(map[string]interface{}{})
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultSize, search.Size)
+		assert.Empty(t, search.Filters)
+		assert.Empty(t, search.Words())
+	})
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"aggregations", map[string]interface{}{"aggs": map[string]interface{}{}}},
+		{"range query", map[string]interface{}{"query": map[string]interface{}{"range": map[string]interface{}{"lastUpdated": map[string]interface{}{"gte": "now-1d"}}}}},
+		{"term on another field", map[string]interface{}{"query": map[string]interface{}{"term": map[string]interface{}{"data.format": "lightning-talk"}}}},
+		{"must_not", map[string]interface{}{"query": map[string]interface{}{"bool": map[string]interface{}{"must_not": []interface{}{}}}}},
+		{"two clauses in one object", map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}, "term": map[string]interface{}{}}}},
+		{"negative size", map[string]interface{}{"size": -1}},
+		{"fractional from", map[string]interface{}{"from": 1.5}},
+		{"sort on another field", map[string]interface{}{"sort": []interface{}{map[string]interface{}{"lastUpdated": map[string]interface{}{"order": "desc"}}}}},
+		{"ascending sort", map[string]interface{}{"sort": []interface{}{map[string]interface{}{"review.score": map[string]interface{}{"order": "asc"}}}}},
+		{"sort after relevance", map[string]interface{}{"sort": []interface{}{"_score", map[string]interface{}{"review.score": map[string]interface{}{"order": "desc"}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.body)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+		})
+	}
+}
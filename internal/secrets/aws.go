@@ -0,0 +1,181 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// awsService is the name AWS Secrets Manager requests are signed for
+const awsService = "secretsmanager"
+
+// AWSCredentials are the access key requests to AWS are signed with
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is set for temporary credentials
+	SessionToken string
+}
+
+// AWSSecretsManager reads secrets from AWS Secrets Manager, signing the requests with an access key.
+// References are the name or ARN of the secret, followed by the key of the value for secrets holding
+// JSON, such as "talks-indexer/prod#moresleep_password".
+type AWSSecretsManager struct {
+	endpoint    string
+	region      string
+	credentials AWSCredentials
+	httpClient  *http.Client
+	now         func() time.Time
+}
+
+// NewAWSSecretsManager creates an AWS Secrets Manager resolver for the region. The endpoint defaults to
+// the regional endpoint of the service.
+func NewAWSSecretsManager(region, endpoint string, credentials AWSCredentials, httpClient *http.Client) *AWSSecretsManager {
+	if endpoint == "" && region != "" {
+		endpoint = "https://secretsmanager." + region + ".amazonaws.com"
+	}
+	return &AWSSecretsManager{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		region:      region,
+		credentials: credentials,
+		httpClient:  httpClient,
+		now:         time.Now,
+	}
+}
+
+// Resolve reads the current version of the secret and returns it, or the value of the key of the
+// reference within it
+func (m *AWSSecretsManager) Resolve(ctx context.Context, reference string) (string, error) {
+	if m.region == "" {
+		return "", fmt.Errorf("the AWS region is not configured")
+	}
+	if m.credentials.AccessKeyID == "" || m.credentials.SecretAccessKey == "" {
+		return "", fmt.Errorf("the AWS access key is not configured")
+	}
+	secretID, key := splitKey(reference)
+	if secretID == "" {
+		return "", fmt.Errorf("invalid AWS Secrets Manager reference %q: expected <secret-id>[#<key>]", reference)
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signRequest(req, body, m.credentials, m.region, awsService, m.now())
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read AWS secret %s: %w", secretID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("failed to read AWS secret %s: status %d: %s", secretID, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to decode AWS secret %s: %w", secretID, err)
+	}
+	if secret.SecretString == nil {
+		return "", fmt.Errorf("failed to read AWS secret %s: only string secrets are supported", secretID)
+	}
+	if key == "" {
+		return *secret.SecretString, nil
+	}
+
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*secret.SecretString), &data); err != nil {
+		return "", fmt.Errorf("failed to read AWS secret %s: key %q given but the secret is not a JSON object", secretID, key)
+	}
+	value, err := lookupKey(data, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read AWS secret %s: %w", secretID, err)
+	}
+	return value, nil
+}
+
+// signRequest signs the request with AWS Signature Version 4, signing the host and every header set
+func signRequest(req *http.Request, body []byte, credentials AWSCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := slices.Sorted(maps.Keys(headers))
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hashHex returns the hex-encoded SHA-256 hash of the data
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSSecretsManager_Resolve(t *testing.T) {
+	var authorization, securityToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		securityToken = r.Header.Get("X-Amz-Security-Token")
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+
+		var request struct {
+			SecretId string
+		}
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &request))
+
+		switch request.SecretId {
+		case "talks-indexer/prod":
+			w.Write([]byte(`{"Name":"talks-indexer/prod","SecretString":"{\"moresleep_password\":\"s3cret\"}"}`))
+		case "plain":
+			w.Write([]byte(`{"Name":"plain","SecretString":"plain-secret"}`))
+		case "binary":
+			w.Write([]byte(`{"Name":"binary","SecretBinary":"AAEC"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ResourceNotFoundException","Message":"Secrets Manager can't find the specified secret."}`))
+		}
+	}))
+	defer server.Close()
+
+	credentials := AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret-key", SessionToken: "session"}
+	manager := NewAWSSecretsManager("eu-north-1", server.URL, credentials, server.Client())
+	manager.now = func() time.Time { return time.Date(2026, 5, 4, 12, 30, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		reference string
+		expected  string
+		wantErr   string
+	}{
+		{name: "key of JSON secret", reference: "talks-indexer/prod#moresleep_password", expected: "s3cret"},
+		{name: "whole secret", reference: "plain", expected: "plain-secret"},
+		{name: "key of plain secret", reference: "plain#password", wantErr: "not a JSON object"},
+		{name: "binary secret", reference: "binary", wantErr: "only string secrets are supported"},
+		{name: "missing secret", reference: "other", wantErr: "ResourceNotFoundException"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := manager.Resolve(context.Background(), tt.reference)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260504/eu-north-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature="), authorization)
+	assert.Equal(t, "session", securityToken)
+
+	t.Run("not configured", func(t *testing.T) {
+		_, err := NewAWSSecretsManager("", "", credentials, server.Client()).Resolve(context.Background(), "plain")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "AWS region is not configured")
+
+		_, err = NewAWSSecretsManager("eu-north-1", server.URL, AWSCredentials{}, server.Client()).Resolve(context.Background(), "plain")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "AWS access key is not configured")
+	})
+}
+
+// TestSignRequest checks the signature against the example of the AWS Signature Version 4 documentation
+func TestSignRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	credentials := AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signRequest(req, nil, credentials, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7", req.Header.Get("Authorization"))
+}
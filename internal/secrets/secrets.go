@@ -0,0 +1,60 @@
+// Package secrets reads credentials from secrets backends, so settings can name a secret in Vault or
+// AWS Secrets Manager instead of holding it. It is used by the configuration loader, which is why it
+// does not depend on the configuration itself.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SchemeVault prefixes references to secrets in the Vault key/value engine, as "vault:<path>#<key>"
+	SchemeVault = "vault"
+
+	// SchemeAWS prefixes references to AWS Secrets Manager secrets, as "aws-sm:<secret-id>[#<key>]"
+	SchemeAWS = "aws-sm"
+)
+
+// Resolver reads secrets from a secrets backend
+type Resolver interface {
+	// Resolve returns the secret named by the reference, the part of the setting after the scheme
+	Resolve(ctx context.Context, reference string) (string, error)
+}
+
+// ParseReference splits a setting referring to a secret into the scheme of its backend and the
+// reference within it. ok is false for settings holding the secret itself.
+func ParseReference(value string) (scheme, reference string, ok bool) {
+	scheme, reference, found := strings.Cut(value, ":")
+	if !found || reference == "" {
+		return "", "", false
+	}
+	switch scheme {
+	case SchemeVault, SchemeAWS:
+		return scheme, reference, true
+	}
+	return "", "", false
+}
+
+// splitKey splits a reference into the name of the secret and the key of the value within it
+func splitKey(reference string) (name, key string) {
+	name, key, _ = strings.Cut(reference, "#")
+	return name, key
+}
+
+// lookupKey returns the value of the key in a secret holding a JSON object. String values are returned
+// as they are, and other values as JSON.
+func lookupKey(data map[string]json.RawMessage, key string) (string, error) {
+	raw, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret has no key %q", key)
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+	return string(raw), nil
+}
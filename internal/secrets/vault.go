@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Vault reads secrets from the key/value secrets engine of HashiCorp Vault over its HTTP API.
+// References are the API path of the secret and the key of the value, such as
+// "secret/data/talks-indexer#moresleep_password" for version 2 of the engine.
+type Vault struct {
+	addr       string
+	token      string
+	httpClient *http.Client
+}
+
+// NewVault creates a Vault resolver for the server at addr, authenticating with the token
+func NewVault(addr, token string, httpClient *http.Client) *Vault {
+	return &Vault{
+		addr:       strings.TrimSuffix(addr, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// Resolve reads the secret at the path of the reference and returns the value of its key
+func (v *Vault) Resolve(ctx context.Context, reference string) (string, error) {
+	if v.addr == "" {
+		return "", fmt.Errorf("the Vault address is not configured")
+	}
+	path, key := splitKey(reference)
+	if path == "" || key == "" {
+		return "", fmt.Errorf("invalid Vault reference %q: expected <path>#<key>", reference)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.addr+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("failed to read Vault secret %s: status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to decode Vault secret %s: %w", path, err)
+	}
+
+	data := secret.Data
+	// Version 2 of the engine nests the values next to the metadata of the version
+	if nested, ok := data["data"]; ok && data["metadata"] != nil {
+		data = nil
+		if err := json.Unmarshal(nested, &data); err != nil {
+			return "", fmt.Errorf("failed to decode Vault secret %s: %w", path, err)
+		}
+	}
+
+	value, err := lookupKey(data, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault secret %s: %w", path, err)
+	}
+	return value, nil
+}
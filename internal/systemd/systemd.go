@@ -0,0 +1,92 @@
+// Package systemd implements the parts of the systemd service protocol the server uses: readiness
+// and watchdog notifications over NOTIFY_SOCKET, and listening on a socket passed with LISTEN_FDS.
+// Outside systemd the environment variables are unset and every function does nothing.
+package systemd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Notification states, see sd_notify(3)
+const (
+	Ready     = "READY=1"
+	Reloading = "RELOADING=1"
+	Stopping  = "STOPPING=1"
+	Watchdog  = "WATCHDOG=1"
+)
+
+// listenFDsStart is the first file descriptor systemd passes sockets on
+const listenFDsStart = 3
+
+// Notify sends the state to the service manager. It returns false without an error if the process
+// was not started by systemd with Type=notify.
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+
+	// Names starting with @ are in the abstract namespace, which net resolves itself
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to the notify socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, fmt.Errorf("failed to notify %q: %w", state, err)
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns how often the service manager expects a watchdog notification, or zero
+// if the watchdog is not enabled for this process
+func WatchdogInterval() (time.Duration, error) {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return 0, nil
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid WATCHDOG_USEC %q", usec)
+	}
+	return time.Duration(n) * time.Microsecond, nil
+}
+
+// Listener returns the socket passed by socket activation, or nil if none was passed to this
+// process. The variables are unset, so processes started by the server do not take the socket for theirs.
+func Listener() (net.Listener, error) {
+	fds, pid := os.Getenv("LISTEN_FDS"), os.Getenv("LISTEN_PID")
+	if fds == "" || pid != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	n, err := strconv.Atoi(fds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LISTEN_FDS %q", fds)
+	}
+	if n != 1 {
+		return nil, errors.New("socket activation must pass exactly one socket, the HTTP listener")
+	}
+
+	file := os.NewFile(uintptr(listenFDsStart), "LISTEN_FD_3")
+	defer file.Close()
+
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the passed socket: %w", err)
+	}
+	return listener, nil
+}
@@ -0,0 +1,102 @@
+package systemd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify(t *testing.T) {
+	t.Run("not started by systemd", func(t *testing.T) {
+		t.Setenv("NOTIFY_SOCKET", "")
+		sent, err := Notify(Ready)
+		require.NoError(t, err)
+		assert.False(t, sent)
+	})
+
+	t.Run("sends the state", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notify.sock")
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+		require.NoError(t, err)
+		defer conn.Close()
+		t.Setenv("NOTIFY_SOCKET", path)
+
+		sent, err := Notify(Ready)
+		require.NoError(t, err)
+		assert.True(t, sent)
+
+		buf := make([]byte, 64)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "READY=1", string(buf[:n]))
+	})
+
+	t.Run("missing socket", func(t *testing.T) {
+		t.Setenv("NOTIFY_SOCKET", filepath.Join(t.TempDir(), "missing.sock"))
+		_, err := Notify(Ready)
+		assert.Error(t, err)
+	})
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	tests := []struct {
+		name    string
+		usec    string
+		pid     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "disabled"},
+		{name: "enabled", usec: "30000000", want: 30 * time.Second},
+		{name: "this process", usec: "30000000", pid: pid, want: 30 * time.Second},
+		{name: "another process", usec: "30000000", pid: "1"},
+		{name: "invalid", usec: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WATCHDOG_USEC", tt.usec)
+			t.Setenv("WATCHDOG_PID", tt.pid)
+
+			interval, err := WatchdogInterval()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, interval)
+		})
+	}
+}
+
+func TestListener(t *testing.T) {
+	t.Run("not socket activated", func(t *testing.T) {
+		t.Setenv("LISTEN_FDS", "")
+		listener, err := Listener()
+		require.NoError(t, err)
+		assert.Nil(t, listener)
+	})
+
+	t.Run("sockets of another process", func(t *testing.T) {
+		t.Setenv("LISTEN_FDS", "1")
+		t.Setenv("LISTEN_PID", "1")
+		listener, err := Listener()
+		require.NoError(t, err)
+		assert.Nil(t, listener)
+	})
+
+	t.Run("more than one socket", func(t *testing.T) {
+		t.Setenv("LISTEN_FDS", "2")
+		t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+		_, err := Listener()
+		assert.Error(t, err)
+		assert.Empty(t, os.Getenv("LISTEN_FDS"), "the variables are not passed on")
+	})
+}
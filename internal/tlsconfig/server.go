@@ -0,0 +1,194 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+)
+
+// Server holds the TLS configuration of the HTTP server and what keeps its certificate current
+type Server struct {
+	// Config is the TLS configuration to serve HTTPS with
+	Config *tls.Config
+
+	// RedirectHandler serves the plain HTTP port: ACME HTTP challenges, and redirects to HTTPS
+	RedirectHandler http.Handler
+
+	// reloader reloads the certificate files, nil with ACME
+	reloader *CertificateReloader
+}
+
+// NewServer returns the server TLS configuration of the settings, loading the certificate files or
+// preparing to obtain certificates over ACME for the configured domains on the first handshake
+func NewServer(cfg config.ServerTLSConfig, httpsPort int) (*Server, error) {
+	if cfg.UsesACME() {
+		if cfg.CertFile != "" || cfg.KeyFile != "" {
+			return nil, errors.New("certificate files and ACME domains cannot both be configured")
+		}
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.ACMEDomains...),
+			Cache:      autocert.DirCache(cfg.ACMECacheDir),
+			Email:      cfg.ACMEEmail,
+		}
+		if cfg.ACMEDirectoryURL != "" {
+			manager.Client = &acme.Client{DirectoryURL: cfg.ACMEDirectoryURL}
+		}
+
+		tlsConfig := manager.TLSConfig()
+		tlsConfig.MinVersion = tls.VersionTLS12
+		return &Server{Config: tlsConfig, RedirectHandler: manager.HTTPHandler(redirectHandler(httpsPort))}, nil
+	}
+
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("serving HTTPS needs both a certificate and a key file")
+	}
+	reloader, err := NewCertificateReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		Config: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		},
+		RedirectHandler: redirectHandler(httpsPort),
+		reloader:        reloader,
+	}, nil
+}
+
+// Watch reloads the certificate files when they change until the context is cancelled. It returns
+// at once with ACME, whose certificates are renewed on handshakes.
+func (s *Server) Watch(ctx context.Context) error {
+	if s.reloader == nil {
+		return nil
+	}
+	return s.reloader.Watch(ctx)
+}
+
+// redirectHandler redirects requests to the same host and path over HTTPS on the port
+func redirectHandler(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(httpsPort))
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+// CertificateReloader serves the certificate of a certificate and key file pair, reloading it when
+// the files change so renewed certificates are served without a restart
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+	logger   *slog.Logger
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+}
+
+// NewCertificateReloader loads the certificate of the files
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   slog.Default().With("component", "tls"),
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the current certificate, as tls.Config.GetCertificate
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate, nil
+}
+
+// Watch reloads the certificate on changes in the directories of the files until the context is
+// cancelled. Directories are watched as certificate renewals and Kubernetes secrets replace files
+// instead of writing them. A pair that fails to load, such as a certificate written before its key,
+// keeps the previous certificate until the next change.
+func (r *CertificateReloader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch certificate files: %w", err)
+	}
+	defer watcher.Close()
+
+	for _, dir := range uniqueDirs(r.certFile, r.keyFile) {
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				r.logger.WarnContext(ctx, "keeping previous certificate", "error", err)
+				continue
+			}
+			r.logger.InfoContext(ctx, "reloaded certificate", "certFile", r.certFile)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			r.logger.WarnContext(ctx, "certificate file watch error", "error", err)
+		}
+	}
+}
+
+// reload loads the certificate of the files, keeping the current one if they cannot be loaded
+func (r *CertificateReloader) reload() error {
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.certificate = &certificate
+	return nil
+}
+
+// uniqueDirs returns the directories of the files, without duplicates
+func uniqueDirs(files ...string) []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,124 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeServerCertificate writes a self-signed server certificate with the serial number and its key,
+// returning their paths
+func writeServerCertificate(t *testing.T, dir string, serial int64) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return writePEM(t, dir, "tls.crt", "CERTIFICATE", der), writePEM(t, dir, "tls.key", "EC PRIVATE KEY", keyDER)
+}
+
+// servedSerial returns the serial number of the certificate the reloader serves
+func servedSerial(t *testing.T, reloader *CertificateReloader) int64 {
+	t.Helper()
+	certificate, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(t, err)
+	return leaf.SerialNumber.Int64()
+}
+
+func TestCertificateReloader_Watch(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeServerCertificate(t, dir, 1)
+
+	reloader, err := NewCertificateReloader(certFile, keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), servedSerial(t, reloader))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- reloader.Watch(ctx) }()
+
+	// The watcher may start after the first write, so the renewal is written until it is seen
+	assert.Eventually(t, func() bool {
+		writeServerCertificate(t, dir, 2)
+		return servedSerial(t, reloader) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestNewServer(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeServerCertificate(t, dir, 1)
+
+	t.Run("certificate files", func(t *testing.T) {
+		server, err := NewServer(config.ServerTLSConfig{CertFile: certFile, KeyFile: keyFile}, 8443)
+		require.NoError(t, err)
+		certificate, err := server.Config.GetCertificate(nil)
+		require.NoError(t, err)
+		assert.NotNil(t, certificate)
+	})
+
+	t.Run("acme", func(t *testing.T) {
+		server, err := NewServer(config.ServerTLSConfig{ACMEDomains: []string{"talks.javazone.no"}, ACMECacheDir: t.TempDir()}, 443)
+		require.NoError(t, err)
+		assert.True(t, slices.Contains(server.Config.NextProtos, "acme-tls/1"), "TLS-ALPN challenges are answered")
+		assert.NoError(t, server.Watch(context.Background()))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, cfg := range map[string]config.ServerTLSConfig{
+			"key missing":     {CertFile: certFile},
+			"files and acme":  {CertFile: certFile, KeyFile: keyFile, ACMEDomains: []string{"talks.javazone.no"}},
+			"unreadable file": {CertFile: certFile, KeyFile: certFile},
+		} {
+			_, err := NewServer(cfg, 443)
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestRedirectHandler(t *testing.T) {
+	tests := []struct {
+		port int
+		host string
+		want string
+	}{
+		{443, "talks.javazone.no", "https://talks.javazone.no/api/search?q=kotlin"},
+		{443, "talks.javazone.no:80", "https://talks.javazone.no/api/search?q=kotlin"},
+		{8443, "localhost:8080", "https://localhost:8443/api/search?q=kotlin"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://"+tt.host+"/api/search?q=kotlin", nil)
+		rec := httptest.NewRecorder()
+		redirectHandler(tt.port).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+		assert.Equal(t, tt.want, rec.Header().Get("Location"))
+	}
+}
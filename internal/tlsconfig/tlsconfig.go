@@ -0,0 +1,66 @@
+// Package tlsconfig builds the transports of outbound HTTP clients from their TLS settings, so the
+// Elasticsearch and moresleep clients trust the same kind of custom certificate authorities and present
+// client certificates the same way, and the TLS configuration the HTTP server serves HTTPS with.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+)
+
+// New returns the TLS configuration of the settings: the system roots and the CA bundle are trusted,
+// and the client certificate is presented if configured
+func New(cfg config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // only allowed in development mode, checked by bootstrap
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("a client certificate needs both a certificate and a key file")
+		}
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// Transport returns a copy of the default transport using the TLS settings, or nil if none are
+// configured so callers keep the default transport
+func Transport(cfg config.TLSConfig) (http.RoundTripper, error) {
+	if !cfg.IsConfigured() {
+		return nil, nil
+	}
+	tlsConfig, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
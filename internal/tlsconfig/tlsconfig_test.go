@@ -0,0 +1,117 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javaBin/talks-indexer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes the PEM block to a file in the directory and returns its path
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// writeClientCertificate writes a self-signed client certificate and its key, returning their paths
+func writeClientCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "talks-indexer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+// get sends a request to the server through a client with the TLS settings
+func get(t *testing.T, server *httptest.Server, cfg config.TLSConfig) error {
+	t.Helper()
+	transport, err := Transport(cfg)
+	require.NoError(t, err)
+	res, err := (&http.Client{Transport: transport, Timeout: 5 * time.Second}).Get(server.URL)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+func TestTransport(t *testing.T) {
+	dir := t.TempDir()
+
+	var clientCertificates int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientCertificates = len(r.TLS.PeerCertificates)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	t.Run("not configured", func(t *testing.T) {
+		transport, err := Transport(config.TLSConfig{})
+		require.NoError(t, err)
+		assert.Nil(t, transport)
+
+		assert.Error(t, get(t, server, config.TLSConfig{}), "the server certificate is not trusted by default")
+	})
+
+	t.Run("custom CA", func(t *testing.T) {
+		require.NoError(t, get(t, server, config.TLSConfig{CAFile: caFile}))
+		assert.Zero(t, clientCertificates)
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		certFile, keyFile := writeClientCertificate(t, dir)
+
+		require.NoError(t, get(t, server, config.TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}))
+		assert.Equal(t, 1, clientCertificates)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		require.NoError(t, get(t, server, config.TLSConfig{InsecureSkipVerify: true}))
+	})
+}
+
+func TestNew_Errors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(empty, []byte("no certificates here"), 0o600))
+
+	_, err := New(config.TLSConfig{CAFile: filepath.Join(dir, "missing.pem")})
+	assert.ErrorContains(t, err, "failed to read CA bundle")
+
+	_, err = New(config.TLSConfig{CAFile: empty})
+	assert.ErrorContains(t, err, "no certificates found")
+
+	_, err = New(config.TLSConfig{CertFile: empty})
+	assert.ErrorContains(t, err, "both a certificate and a key file")
+
+	_, err = New(config.TLSConfig{CertFile: empty, KeyFile: empty})
+	assert.ErrorContains(t, err, "failed to load client certificate")
+}